@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DataConnectionSpec defines a data connection shared by data science projects.
+type DataConnectionSpec struct {
+	// Name of the data connection displayed in the dashboard, defaults to the name of the DataConnection.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// S3 compatible object storage the data connection points to.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	S3 S3ConnectionSpec `json:"s3"`
+	// Selects the data science projects the data connection is added to, all of them when not set.
+	// Only namespaces labeled as data science projects ("opendatahub.io/dashboard: true") are selected.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// S3ConnectionSpec defines an S3 compatible object storage endpoint.
+type S3ConnectionSpec struct {
+	// Endpoint of the object storage.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// Region of the object storage.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Default bucket of the data connection.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// Name of the Secret holding the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY of the object storage,
+	// in the applications namespace set in the DSCInitialization.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// DataConnectionStatus defines the observed state of DataConnection.
+type DataConnectionStatus struct {
+	// Phase describes the Phase of DataConnection
+	Phase string `json:"phase,omitempty"`
+
+	// Conditions describes the state of the DataConnection resource
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+
+	// Data science projects the data connection is added to
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=.spec.s3.endpoint
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="Data Connection"
+
+// DataConnection is the Schema for the dataconnections API.
+type DataConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataConnectionSpec   `json:"spec,omitempty"`
+	Status DataConnectionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DataConnectionList contains a list of DataConnection.
+type DataConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataConnection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&DataConnection{},
+		&DataConnectionList{},
+	)
+}
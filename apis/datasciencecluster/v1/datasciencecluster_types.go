@@ -20,8 +20,10 @@ import (
 	"errors"
 	"reflect"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
@@ -41,12 +43,101 @@ import (
 )
 
 // DataScienceClusterSpec defines the desired state of the cluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.distributedWorkloads) || !has(self.distributedWorkloads.managementState) || self.distributedWorkloads.managementState != 'Managed' || !has(self.components) || ((!has(self.components.kueue) || !has(self.components.kueue.managementState) || self.components.kueue.managementState == 'Managed') && (!has(self.components.ray) || !has(self.components.ray.managementState) || self.components.ray.managementState == 'Managed') && (!has(self.components.codeflare) || !has(self.components.codeflare.managementState) || self.components.codeflare.managementState == 'Managed') && (!has(self.components.trainingoperator) || !has(self.components.trainingoperator.managementState) || self.components.trainingoperator.managementState == 'Managed'))",message="kueue, ray, codeflare and trainingoperator must be Managed or unset when distributedWorkloads is Managed"
 type DataScienceClusterSpec struct {
 	// Override and fine tune specific component configurations.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
 	Components Components `json:"components,omitempty"`
+
+	// Aggregation of Kueue, Ray and Training Operator job metrics into user workload monitoring.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	DistributedWorkloadsMetrics DistributedWorkloadsMetrics `json:"distributedWorkloadsMetrics,omitempty"`
+
+	// Profile sets the management state of the components not set in components: "Managed" for the components of
+	// the profile, "Removed" for the other ones. One of serving-only, training, edge or full.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	Profile Profile `json:"profile,omitempty"`
+
+	// Kueue, Ray, CodeFlare and Training Operator enabled together, sharing a ClusterQueue and priority classes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	DistributedWorkloads DistributedWorkloads `json:"distributedWorkloads,omitempty"`
+
+	// Labels and annotations set on every resource deployed for the components, e.g. cost-center tags, backup selectors
+	// or compliance markers.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	Metadata *ResourceMetadata `json:"metadata,omitempty"`
 }
 
+// ResourceMetadata holds labels and annotations set on the resources deployed for the components, where their
+// manifests do not set them. Keys of the opendatahub.io domain are reserved to the operator and ignored.
+type ResourceMetadata struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DistributedWorkloadsMetrics configures recording rules for quota utilization of distributed workloads.
+type DistributedWorkloadsMetrics struct {
+	// Set to "Managed" to collect distributed workloads metrics with pre-defined recording rules, "Removed" to remove them.
+	// +kubebuilder:default=Removed
+	// +kubebuilder:validation:Enum=Managed;Removed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+}
+
+// DistributedWorkloads enables Kueue, Ray, CodeFlare and the Training Operator as a whole, with consistent defaults:
+// components not set in components are Managed, and Kueue is set up with a ClusterQueue shared by all projects and the
+// priority classes of jobs.
+type DistributedWorkloads struct {
+	// Set to "Managed" to enable distributed workloads, "Removed" to remove the shared Kueue resources.
+	// Kueue, Ray, CodeFlare and Training Operator components must then be Managed or unset.
+	// +kubebuilder:default=Removed
+	// +kubebuilder:validation:Enum=Managed;Removed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// ClusterQueue is the name of the Kueue ClusterQueue shared by all projects, for their LocalQueues to point to.
+	// +kubebuilder:default=default
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	ClusterQueue string `json:"clusterQueue,omitempty"`
+	// Quota of the shared ClusterQueue. The ClusterQueue is only created once a quota is set.
+	// +optional
+	Quota *DistributedWorkloadsQuota `json:"quota,omitempty"`
+	// PriorityClasses are the Kueue WorkloadPriorityClasses jobs select to be admitted, and preempt jobs of lower
+	// priority in the shared ClusterQueue.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PriorityClasses []WorkloadPriorityClass `json:"priorityClasses,omitempty"`
+}
+
+// DistributedWorkloadsQuota defines the nominal quota of the shared ClusterQueue, resources not set are not covered.
+// +kubebuilder:validation:MinProperties=1
+type DistributedWorkloadsQuota struct {
+	// CPU available to the admitted workloads.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// Memory available to the admitted workloads.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// NvidiaGPU is the number of nvidia.com/gpu available to the admitted workloads.
+	// +optional
+	NvidiaGPU *resource.Quantity `json:"nvidiaGPU,omitempty"`
+}
+
+// WorkloadPriorityClass defines a Kueue WorkloadPriorityClass.
+type WorkloadPriorityClass struct {
+	// Name of the WorkloadPriorityClass, set on jobs with the kueue.x-k8s.io/priority-class label.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Value of the priority, jobs of higher value being admitted first.
+	Value int32 `json:"value"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.kserve) || !has(self.modelmeshserving) || !has(self.kserve.modelController) || !has(self.modelmeshserving.modelController) || self.kserve.modelController == self.modelmeshserving.modelController",message="modelController must be equal for Kserve and ModelMeshServing, as both deploy odh-model-controller"
+// +kubebuilder:validation:XValidation:rule="!has(self.trainingoperator) || !has(self.trainingoperator.config) || !has(self.trainingoperator.config.gangScheduling) || self.trainingoperator.config.gangScheduling.scheduler != 'Kueue' || (has(self.kueue) && has(self.kueue.managementState) && self.kueue.managementState == 'Managed')",message="gang scheduling with Kueue requires the Kueue component to be Managed"
 type Components struct {
 	// Dashboard component configuration.
 	Dashboard dashboard.Dashboard `json:"dashboard,omitempty"`
@@ -116,6 +207,26 @@ type DataScienceClusterStatus struct {
 	// +optional
 	Components ComponentsStatus `json:"components,omitempty"`
 
+	// Remediation lists next steps to fix each failing component
+	// +optional
+	Remediation []status.Remediation `json:"remediation,omitempty"`
+
+	// Manifests lists the digest and source of the manifests each enabled component was deployed from
+	// +optional
+	Manifests []status.ManifestsProvenance `json:"manifests,omitempty"`
+
+	// Resets lists the customizations discarded by the last reset of each component to its defaults
+	// +optional
+	Resets []status.ComponentReset `json:"resets,omitempty"`
+
+	// CRDUpdates lists the incompatible changes found in CRDs of each component when last applying its manifests
+	// +optional
+	CRDUpdates []status.CRDUpdate `json:"crdUpdates,omitempty"`
+
+	// Accelerators lists the GPU operators found in the cluster, their readiness and the accelerators nodes advertise
+	// +optional
+	Accelerators []status.AcceleratorStatus `json:"accelerators,omitempty"`
+
 	// Version and release type
 	Release cluster.Release `json:"release,omitempty"`
 }
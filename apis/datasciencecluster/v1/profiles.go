@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"fmt"
+	"slices"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/codeflare"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kueue"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelmeshserving"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/ray"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/trainingoperator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/workbenches"
+)
+
+// Profile is a pre-canned set of components enabled in a DataScienceCluster.
+// +kubebuilder:validation:Enum=serving-only;training;edge;full
+type Profile string
+
+const (
+	// ProfileServingOnly enables the dashboard and model serving.
+	ProfileServingOnly Profile = "serving-only"
+	// ProfileTraining enables the dashboard, workbenches, pipelines and distributed training.
+	ProfileTraining Profile = "training"
+	// ProfileEdge enables KServe only, for small clusters serving models without the UI.
+	ProfileEdge Profile = "edge"
+	// ProfileFull enables all the components.
+	ProfileFull Profile = "full"
+)
+
+// profileComponents lists the components enabled by each profile, a nil list enables all of them.
+var profileComponents = map[Profile][]string{
+	ProfileServingOnly: {dashboard.ComponentNameUpstream, kserve.ComponentName, modelmeshserving.ComponentName},
+	ProfileTraining: {
+		dashboard.ComponentNameUpstream, workbenches.ComponentName, datasciencepipelines.ComponentName,
+		codeflare.ComponentName, ray.ComponentName, kueue.ComponentName, trainingoperator.ComponentName,
+	},
+	ProfileEdge: {kserve.ComponentName},
+	ProfileFull: nil,
+}
+
+// distributedWorkloadsComponents lists the components enabled by distributed workloads.
+var distributedWorkloadsComponents = []string{kueue.ComponentName, ray.ComponentName, codeflare.ComponentName, trainingoperator.ComponentName}
+
+// ApplyProfile sets the management state of the components without any according to distributed workloads and the
+// profile of the spec, so that components set explicitly override them. Nothing changes when neither is selected.
+// The result is meant to be reconciled rather than stored, for a change of the profile to apply to these components.
+func (d *DataScienceCluster) ApplyProfile() error {
+	if err := d.applyDistributedWorkloads(); err != nil {
+		return err
+	}
+	if d.Spec.Profile == "" {
+		return nil
+	}
+	enabled, known := profileComponents[d.Spec.Profile]
+	if !known {
+		return fmt.Errorf("unknown profile %q", d.Spec.Profile)
+	}
+
+	allComponents, err := d.GetComponents()
+	if err != nil {
+		return err
+	}
+	for _, component := range allComponents {
+		if component.GetManagementState() != "" {
+			continue
+		}
+		if enabled == nil || slices.Contains(enabled, component.GetComponentName()) {
+			component.SetManagementState(operatorv1.Managed)
+		} else {
+			component.SetManagementState(operatorv1.Removed)
+		}
+	}
+
+	return nil
+}
+
+// applyDistributedWorkloads enables the components of distributed workloads without any management state.
+func (d *DataScienceCluster) applyDistributedWorkloads() error {
+	if d.Spec.DistributedWorkloads.ManagementState != operatorv1.Managed {
+		return nil
+	}
+
+	allComponents, err := d.GetComponents()
+	if err != nil {
+		return err
+	}
+	for _, component := range allComponents {
+		if component.GetManagementState() == "" && slices.Contains(distributedWorkloadsComponents, component.GetComponentName()) {
+			component.SetManagementState(operatorv1.Managed)
+		}
+	}
+
+	return nil
+}
@@ -136,6 +136,13 @@ func (in *DataScienceClusterList) DeepCopyObject() runtime.Object {
 func (in *DataScienceClusterSpec) DeepCopyInto(out *DataScienceClusterSpec) {
 	*out = *in
 	in.Components.DeepCopyInto(&out.Components)
+	out.DistributedWorkloadsMetrics = in.DistributedWorkloadsMetrics
+	in.DistributedWorkloads.DeepCopyInto(&out.DistributedWorkloads)
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataScienceClusterSpec.
@@ -171,6 +178,35 @@ func (in *DataScienceClusterStatus) DeepCopyInto(out *DataScienceClusterStatus)
 		}
 	}
 	in.Components.DeepCopyInto(&out.Components)
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = make([]status.Remediation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]status.ManifestsProvenance, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resets != nil {
+		in, out := &in.Resets, &out.Resets
+		*out = make([]status.ComponentReset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CRDUpdates != nil {
+		in, out := &in.CRDUpdates, &out.CRDUpdates
+		*out = make([]status.CRDUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]status.AcceleratorStatus, len(*in))
+		copy(*out, *in)
+	}
 	in.Release.DeepCopyInto(&out.Release)
 }
 
@@ -183,3 +219,117 @@ func (in *DataScienceClusterStatus) DeepCopy() *DataScienceClusterStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedWorkloads) DeepCopyInto(out *DistributedWorkloads) {
+	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(DistributedWorkloadsQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]WorkloadPriorityClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedWorkloads.
+func (in *DistributedWorkloads) DeepCopy() *DistributedWorkloads {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedWorkloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedWorkloadsMetrics) DeepCopyInto(out *DistributedWorkloadsMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedWorkloadsMetrics.
+func (in *DistributedWorkloadsMetrics) DeepCopy() *DistributedWorkloadsMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedWorkloadsMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributedWorkloadsQuota) DeepCopyInto(out *DistributedWorkloadsQuota) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NvidiaGPU != nil {
+		in, out := &in.NvidiaGPU, &out.NvidiaGPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributedWorkloadsQuota.
+func (in *DistributedWorkloadsQuota) DeepCopy() *DistributedWorkloadsQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributedWorkloadsQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetadata) DeepCopyInto(out *ResourceMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetadata.
+func (in *ResourceMetadata) DeepCopy() *ResourceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPriorityClass) DeepCopyInto(out *WorkloadPriorityClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPriorityClass.
+func (in *WorkloadPriorityClass) DeepCopy() *WorkloadPriorityClass {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPriorityClass)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConnectionType is the kind of external system a connection is checked against.
+// +kubebuilder:validation:Enum=S3;Database;OIDC;Registry
+type ConnectionType string
+
+const (
+	// S3Connection is an S3 compatible object storage, checked by listing the objects of the bucket, or the buckets of
+	// the credentials when no bucket is set.
+	S3Connection ConnectionType = "S3"
+	// DatabaseConnection is a database, checked by opening a TCP connection to its host and port.
+	DatabaseConnection ConnectionType = "Database"
+	// OIDCConnection is an OpenID Connect issuer, checked by fetching its discovery document.
+	OIDCConnection ConnectionType = "OIDC"
+	// RegistryConnection is a container image registry, checked by querying its v2 API.
+	RegistryConnection ConnectionType = "Registry"
+)
+
+// Connection is an external system the components depend on.
+type Connection struct {
+	// Name of the connection, identifying its result.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Type of the system.
+	Type ConnectionType `json:"type"`
+	// Endpoint of the system: URL of the object storage or of the OIDC issuer, host:port of the database, or host of
+	// the registry.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// Bucket of the object storage to list.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// Region of the object storage, used to sign requests. Defaults to "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Name of the Secret holding the credentials, in the applications namespace set in the DSCInitialization:
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY for object storage, or a .dockerconfigjson for registries.
+	// Only connectivity is checked when not set, as for databases and OIDC issuers.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// ConnectionCheckSpec defines the external systems to check.
+type ConnectionCheckSpec struct {
+	// Connections to check.
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	Connections []Connection `json:"connections"`
+	// Interval at which connections are checked again. They are only checked when the ConnectionCheck changes when
+	// not set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Timeout of the check of each connection. Defaults to 10s.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ConnectionResult is the outcome of the check of a connection.
+type ConnectionResult struct {
+	// Name of the connection.
+	Name string `json:"name"`
+	// Whether the system was reached and, when checked, accepted the credentials.
+	Succeeded bool `json:"succeeded"`
+	// Whether the system could be reached from the cluster.
+	Reachable bool `json:"reachable"`
+	// Whether the credentials were accepted by the system, when they were checked.
+	// +optional
+	Authenticated *bool `json:"authenticated,omitempty"`
+	// Time taken by the check.
+	// +optional
+	Latency metav1.Duration `json:"latency,omitempty"`
+	// Cause of the failure, or what was checked.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConnectionCheckStatus holds the results of the last check.
+type ConnectionCheckStatus struct {
+	// Phase is "Ready" when all connections succeeded, "Error" otherwise.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Conditions describes the state of the ConnectionCheck resource.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+	// When connections were last checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Results of the last check, in the order of the connections.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Results []ConnectionResult `json:"results,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+kubebuilder:printcolumn:name="Last Check",type=date,JSONPath=.status.lastCheckTime
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+operator-sdk:csv:customresourcedefinitions:displayName="Connection Check"
+
+// ConnectionCheck is the Schema for the connectionchecks API. It tests the connectivity to, and authentication with,
+// the external systems the components depend on from the operator, and records the results.
+type ConnectionCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectionCheckSpec   `json:"spec,omitempty"`
+	Status ConnectionCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ConnectionCheckList contains a list of ConnectionCheck.
+type ConnectionCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConnectionCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ConnectionCheck{},
+		&ConnectionCheckList{},
+	)
+}
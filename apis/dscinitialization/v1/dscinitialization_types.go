@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 )
 
@@ -60,8 +61,225 @@ type DSCInitializationSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
 	// +optional
 	DevFlags *DevFlags `json:"devFlags,omitempty"`
+	// Set to one of the following values:
+	//
+	// - "Create" : the operator creates the applications and monitoring namespaces and sets their labels
+	//
+	// - "Verify" : the namespaces are expected to be pre-created, e.g. by a provisioning system. The operator
+	//              does not create or modify them, it only verifies they exist with the required labels
+	//
+	// +kubebuilder:validation:Enum=Create;Verify
+	// +kubebuilder:default=Create
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	// +optional
+	NamespacePolicy NamespacePolicy `json:"namespacePolicy,omitempty"`
+	// TemplateValues parameterize the manifests of the Service Mesh, Serverless and authorization capabilities:
+	//
+	// - "ingressGatewaySelector" : value of the knative label selecting the ingress gateway, defaults to "ingressgateway"
+	//
+	// - "terminationDrainDuration" : drain duration of the mesh proxies, defaults to "35s"
+	//
+	// - "proxyCPURequest", "proxyMemoryRequest", "proxyCPULimit", "proxyMemoryLimit" : resources of the mesh proxies
+	//
+	// - "pilotCPURequest", "pilotMemoryRequest" : resources requested by the control plane
+	//
+	// - "authorinoReplicas" : number of replicas of the authorization provider
+	//
+	// Values apply to resources created afterwards, and to resources managed by the operator on each reconciliation.
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['ingressGatewaySelector', 'terminationDrainDuration', 'proxyCPURequest', 'proxyMemoryRequest', 'proxyCPULimit', 'proxyMemoryLimit', 'pilotCPURequest', 'pilotMemoryRequest', 'authorinoReplicas'])",message="Unknown template value"
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k].matches('^[a-zA-Z0-9._-]+$'))",message="Template values must be alphanumeric, '.', '_' or '-'"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7
+	// +optional
+	TemplateValues map[string]string `json:"templateValues,omitempty"`
+	// Capabilities turns off platform-level capabilities configured on top of the Service Mesh, e.g. to bring one's
+	// own ingress or authentication, while the components relying on them keep running.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8
+	// +optional
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+	// WorkloadPolicy restricts the destinations workloads of data science projects connect to, and the registries
+	// their images are pulled from.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=9
+	// +optional
+	WorkloadPolicy *WorkloadPolicySpec `json:"workloadPolicy,omitempty"`
+	// PlatformOverride sets the platform the operator behaves as, for the rare cases where it is not detected
+	// correctly. Changes are applied when the operator restarts.
+	// +kubebuilder:validation:Enum=OpenDataHub;ManagedRHOAI;SelfManagedRHOAI
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=10
+	// +optional
+	PlatformOverride string `json:"platformOverride,omitempty"`
+	// IdleReaper stops notebooks and model servers of data science projects once idle for long enough, to reclaim
+	// their resources on shared clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=11
+	// +optional
+	IdleReaper *IdleReaperSpec `json:"idleReaper,omitempty"`
+	// CostAttribution stamps the cost-attribution labels of data science projects on their pods, and reports the
+	// resources used by each project in the UsageReport, for chargeback.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=12
+	// +optional
+	CostAttribution *CostAttributionSpec `json:"costAttribution,omitempty"`
+	// Cloud overrides the cloud provider detected on startup, and configures the integrations of components with the
+	// storage, load balancers and identities of the cloud.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=13
+	// +optional
+	Cloud *CloudSpec `json:"cloud,omitempty"`
+}
+
+type Capabilities struct {
+	// Routing of requests to the components through the ingress gateways managed by the operator.
+	// Set to "Removed" to remove the gateways and bring one's own ingress.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	Routing operatorv1.ManagementState `json:"routing,omitempty"`
+	// Authorization of requests to the components through Authorino.
+	// Set to "Removed" to remove the authorization provider and policies and bring one's own authentication.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	Authorization operatorv1.ManagementState `json:"authorization,omitempty"`
+}
+
+// RoutingEnabled tells whether the operator manages the ingress gateways, which is the case unless Removed.
+func (s *DSCInitializationSpec) RoutingEnabled() bool {
+	return s.Capabilities == nil || s.Capabilities.Routing != operatorv1.Removed
+}
+
+// AuthorizationEnabled tells whether the operator manages the authorization of requests, which is the case unless
+// Removed.
+func (s *DSCInitializationSpec) AuthorizationEnabled() bool {
+	return s.Capabilities == nil || s.Capabilities.Authorization != operatorv1.Removed
+}
+
+// WorkloadPolicySpec defines the egress and image policies enforced in data science projects.
+type WorkloadPolicySpec struct {
+	// Set to "Removed" to remove the policies from the data science projects.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// Destinations outside of the cluster workloads are allowed to connect to, any other is denied. Egress is not
+	// restricted when empty. Enforced by an EgressFirewall on OVN-Kubernetes clusters, and otherwise by a
+	// NetworkPolicy, which does not support DNS names.
+	// +optional
+	AllowedEgress []EgressDestination `json:"allowedEgress,omitempty"`
+	// Registries, or repositories within them, images of workloads must be pulled from, e.g. "quay.io/opendatahub".
+	// Pods with images from other registries are rejected. Images are not restricted when empty.
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?(:[0-9]+)?(/[a-z0-9._-]+)*$`
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+}
+
+// EgressDestination is a destination outside of the cluster, either an IP range or a DNS name.
+// +kubebuilder:validation:XValidation:rule="has(self.cidr) != has(self.dnsName)",message="Exactly one of cidr and dnsName must be set"
+type EgressDestination struct {
+	// IP range of the destination, e.g. "10.0.0.0/16".
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// DNS name of the destination, e.g. "s3.us-east-1.amazonaws.com".
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+}
+
+// WorkloadPolicyEnabled tells whether the operator enforces the workload policy in data science projects.
+func (s *DSCInitializationSpec) WorkloadPolicyEnabled() bool {
+	return s.WorkloadPolicy != nil && s.WorkloadPolicy.ManagementState != operatorv1.Removed
+}
+
+// IdleReaperSpec defines after how long idle workloads of data science projects are stopped.
+type IdleReaperSpec struct {
+	// Set to "Removed" to stop reaping idle workloads.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// Inactivity after which notebooks are stopped, their last activity being reported by the notebook controller.
+	// Notebooks are not stopped when not set.
+	// +optional
+	NotebookIdleTimeout *metav1.Duration `json:"notebookIdleTimeout,omitempty"`
+	// Inactivity after which InferenceServices are stopped, their activity being the requests received by their
+	// predictors through the service mesh, as measured by the cluster monitoring. Model servers are not stopped when
+	// not set.
+	// +optional
+	ModelServerIdleTimeout *metav1.Duration `json:"modelServerIdleTimeout,omitempty"`
+	// Labels exempting workloads, or all workloads of namespaces, labelled with them from being stopped, unless set
+	// to "false". The "opendatahub.io/idle-reaper-exempt" label always does.
+	// +optional
+	ExemptionLabels []string `json:"exemptionLabels,omitempty"`
 }
 
+// IdleReaperEnabled tells whether the operator stops idle workloads of data science projects.
+func (s *DSCInitializationSpec) IdleReaperEnabled() bool {
+	return s.IdleReaper != nil && s.IdleReaper.ManagementState != operatorv1.Removed
+}
+
+// CloudSpec defines the integrations of components with the cloud the cluster runs on.
+type CloudSpec struct {
+	// Cloud provider the integrations are selected for, the one detected on startup when not set. Set to "None" to
+	// turn off the defaults of the provider.
+	// +kubebuilder:validation:Enum=AWS;Azure;GCP;None
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Storage class the volumes of the databases of pipeline servers and model registry are provisioned from when
+	// they do not set one. Defaults to "managed-csi" on Azure and "standard-csi" on GCP.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Provisions load balancers only reachable from the virtual network of the cluster for the Services of type
+	// LoadBalancer deployed by components.
+	// +optional
+	InternalLoadBalancer bool `json:"internalLoadBalancer,omitempty"`
+	// Annotations set on the Services of type LoadBalancer deployed by components, e.g. to tune the health probes of
+	// the load balancer.
+	// +optional
+	LoadBalancerAnnotations map[string]string `json:"loadBalancerAnnotations,omitempty"`
+	// Cloud identities the service accounts of components are federated with, by component name, e.g.
+	// "modelregistry": the ARN of an IAM role on AWS, the client ID of a managed identity on Azure, or the email of a
+	// service account on GCP.
+	// +optional
+	WorkloadIdentities map[string]string `json:"workloadIdentities,omitempty"`
+	// Cloud identities the service accounts of data science projects are federated with, e.g. the runner of pipeline
+	// servers or the service account of model servers, to access cloud storage without static credentials. The
+	// operator annotates the service accounts, and reports in the status whether the token of the identity is projected
+	// in their pods.
+	// +optional
+	ServiceAccountIdentities []ServiceAccountIdentity `json:"serviceAccountIdentities,omitempty"`
+}
+
+// ServiceAccountIdentity federates a service account of data science projects with a cloud identity.
+type ServiceAccountIdentity struct {
+	// Name of the service account, e.g. "pipeline-runner-dspa".
+	// +kubebuilder:validation:MinLength=1
+	ServiceAccount string `json:"serviceAccount"`
+	// Namespace of the service account. The service account of every data science project is federated when not set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Cloud identity the service account is federated with: the ARN of an IAM role on AWS, the client ID of a managed
+	// identity on Azure, or the email of a service account on GCP.
+	// +kubebuilder:validation:MinLength=1
+	Identity string `json:"identity"`
+}
+
+// CostAttributionSpec defines the labels the costs of data science projects are attributed by.
+type CostAttributionSpec struct {
+	// Set to "Removed" to stop stamping labels and reporting usage.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// Labels of data science projects copied to the pods of the projects which are not labelled with them already,
+	// e.g. "cost-center". The "opendatahub.io/cost-center" label always is.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+}
+
+// CostAttributionEnabled tells whether the operator attributes the costs of data science projects.
+func (s *DSCInitializationSpec) CostAttributionEnabled() bool {
+	return s.CostAttribution != nil && s.CostAttribution.ManagementState != operatorv1.Removed
+}
+
+type NamespacePolicy string
+
+const (
+	// NamespacePolicyCreate lets the operator create and label the namespaces.
+	NamespacePolicyCreate NamespacePolicy = "Create"
+	// NamespacePolicyVerify expects the namespaces to be pre-created with the required labels.
+	NamespacePolicyVerify NamespacePolicy = "Verify"
+)
+
 type Monitoring struct {
 	// Set to one of the following values:
 	// - "Managed" : the operator is actively managing the component and trying to keep it active.
@@ -117,10 +335,40 @@ type DSCInitializationStatus struct {
 	RelatedObjects []corev1.ObjectReference `json:"relatedObjects,omitempty"`
 	ErrorMessage   string                   `json:"errorMessage,omitempty"`
 
+	// Remediation lists next steps to fix each failing capability
+	// +optional
+	Remediation []status.Remediation `json:"remediation,omitempty"`
+
+	// AuthorizationExemptions lists the namespaces where requests to model servers are not authorized
+	// +optional
+	AuthorizationExemptions []string `json:"authorizationExemptions,omitempty"`
+
+	// Platform the operator runs on, as detected on startup
+	// +optional
+	Platform *cluster.PlatformInfo `json:"platform,omitempty"`
+
+	// WorkloadIdentities lists the service accounts of data science projects federated with cloud identities, and
+	// whether the token of the identity is projected in their pods
+	// +optional
+	WorkloadIdentities []WorkloadIdentityStatus `json:"workloadIdentities,omitempty"`
+
 	// Version and release type
 	Release cluster.Release `json:"release,omitempty"`
 }
 
+// WorkloadIdentityStatus reports a service account federated with a cloud identity.
+type WorkloadIdentityStatus struct {
+	Namespace      string `json:"namespace"`
+	ServiceAccount string `json:"serviceAccount"`
+	Identity       string `json:"identity"`
+	// TokenProjected tells whether the running pods of the service account have the token of the identity projected
+	// by the workload identity webhook of the cloud, true when none is running.
+	TokenProjected bool `json:"tokenProjected"`
+	// Message tells why the token is not projected, or the service account could not be annotated.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:scope=Cluster,shortName=dsci
 //+kubebuilder:subresource:status
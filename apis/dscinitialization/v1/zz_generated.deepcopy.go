@@ -22,11 +22,83 @@ package v1
 
 import (
 	infrastructurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Capabilities) DeepCopyInto(out *Capabilities) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Capabilities.
+func (in *Capabilities) DeepCopy() *Capabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(Capabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSpec) DeepCopyInto(out *CloudSpec) {
+	*out = *in
+	if in.LoadBalancerAnnotations != nil {
+		in, out := &in.LoadBalancerAnnotations, &out.LoadBalancerAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WorkloadIdentities != nil {
+		in, out := &in.WorkloadIdentities, &out.WorkloadIdentities
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAccountIdentities != nil {
+		in, out := &in.ServiceAccountIdentities, &out.ServiceAccountIdentities
+		*out = make([]ServiceAccountIdentity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSpec.
+func (in *CloudSpec) DeepCopy() *CloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostAttributionSpec) DeepCopyInto(out *CostAttributionSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostAttributionSpec.
+func (in *CostAttributionSpec) DeepCopy() *CostAttributionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CostAttributionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DSCInitialization) DeepCopyInto(out *DSCInitialization) {
 	*out = *in
@@ -105,6 +177,38 @@ func (in *DSCInitializationSpec) DeepCopyInto(out *DSCInitializationSpec) {
 		*out = new(DevFlags)
 		**out = **in
 	}
+	if in.TemplateValues != nil {
+		in, out := &in.TemplateValues, &out.TemplateValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(Capabilities)
+		**out = **in
+	}
+	if in.WorkloadPolicy != nil {
+		in, out := &in.WorkloadPolicy, &out.WorkloadPolicy
+		*out = new(WorkloadPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleReaper != nil {
+		in, out := &in.IdleReaper, &out.IdleReaper
+		*out = new(IdleReaperSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostAttribution != nil {
+		in, out := &in.CostAttribution, &out.CostAttribution
+		*out = new(CostAttributionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cloud != nil {
+		in, out := &in.Cloud, &out.Cloud
+		*out = new(CloudSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSCInitializationSpec.
@@ -132,6 +236,26 @@ func (in *DSCInitializationStatus) DeepCopyInto(out *DSCInitializationStatus) {
 		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = make([]status.Remediation, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthorizationExemptions != nil {
+		in, out := &in.AuthorizationExemptions, &out.AuthorizationExemptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(cluster.PlatformInfo)
+		**out = **in
+	}
+	if in.WorkloadIdentities != nil {
+		in, out := &in.WorkloadIdentities, &out.WorkloadIdentities
+		*out = make([]WorkloadIdentityStatus, len(*in))
+		copy(*out, *in)
+	}
 	in.Release.DeepCopyInto(&out.Release)
 }
 
@@ -160,6 +284,51 @@ func (in *DevFlags) DeepCopy() *DevFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressDestination) DeepCopyInto(out *EgressDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressDestination.
+func (in *EgressDestination) DeepCopy() *EgressDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleReaperSpec) DeepCopyInto(out *IdleReaperSpec) {
+	*out = *in
+	if in.NotebookIdleTimeout != nil {
+		in, out := &in.NotebookIdleTimeout, &out.NotebookIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ModelServerIdleTimeout != nil {
+		in, out := &in.ModelServerIdleTimeout, &out.ModelServerIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExemptionLabels != nil {
+		in, out := &in.ExemptionLabels, &out.ExemptionLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleReaperSpec.
+func (in *IdleReaperSpec) DeepCopy() *IdleReaperSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleReaperSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitoring) DeepCopyInto(out *Monitoring) {
 	*out = *in
@@ -175,6 +344,21 @@ func (in *Monitoring) DeepCopy() *Monitoring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountIdentity) DeepCopyInto(out *ServiceAccountIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountIdentity.
+func (in *ServiceAccountIdentity) DeepCopy() *ServiceAccountIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrustedCABundleSpec) DeepCopyInto(out *TrustedCABundleSpec) {
 	*out = *in
@@ -189,3 +373,43 @@ func (in *TrustedCABundleSpec) DeepCopy() *TrustedCABundleSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityStatus) DeepCopyInto(out *WorkloadIdentityStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityStatus.
+func (in *WorkloadIdentityStatus) DeepCopy() *WorkloadIdentityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPolicySpec) DeepCopyInto(out *WorkloadPolicySpec) {
+	*out = *in
+	if in.AllowedEgress != nil {
+		in, out := &in.AllowedEgress, &out.AllowedEgress
+		*out = make([]EgressDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPolicySpec.
+func (in *WorkloadPolicySpec) DeepCopy() *WorkloadPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -46,15 +46,17 @@ var ConditionReason = struct {
 	LoadTemplateData,
 	ApplyManifests,
 	PostConditions,
+	OwnershipConflict,
 	FeatureCreated FeatureConditionReason
 }{
-	FailedApplying:   "FailedApplying",
-	PreConditions:    "PreConditions",
-	ResourceCreation: "ResourceCreation",
-	LoadTemplateData: "LoadTemplateData",
-	ApplyManifests:   "ApplyManifests",
-	PostConditions:   "PostConditions",
-	FeatureCreated:   "FeatureCreated",
+	FailedApplying:    "FailedApplying",
+	PreConditions:     "PreConditions",
+	ResourceCreation:  "ResourceCreation",
+	LoadTemplateData:  "LoadTemplateData",
+	ApplyManifests:    "ApplyManifests",
+	PostConditions:    "PostConditions",
+	OwnershipConflict: "OwnershipConflict",
+	FeatureCreated:    "FeatureCreated",
 }
 
 const (
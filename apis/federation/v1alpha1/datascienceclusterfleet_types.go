@@ -0,0 +1,130 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+)
+
+// DataScienceClusterFleetSpec defines the platform distributed from the hub cluster to managed clusters.
+type DataScienceClusterFleetSpec struct {
+	// Names of the ManagedClusters the platform is distributed to. Each of them gets a ManifestWork in its namespace
+	// of the hub cluster. Removing a cluster removes the DSCInitialization and DataScienceCluster from it.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	// +listType=set
+	Clusters []string `json:"clusters,omitempty"`
+	// Spec of the DSCInitialization created on managed clusters, named default-dsci.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	DSCInitialization dsciv1.DSCInitializationSpec `json:"dscInitialization"`
+	// Spec of the DataScienceCluster created on managed clusters, named default-dsc.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	DataScienceCluster dscv1.DataScienceClusterSpec `json:"dataScienceCluster"`
+}
+
+// ClusterComponentStatus is the readiness of a component on a managed cluster.
+type ClusterComponentStatus struct {
+	// Name of the component.
+	Name string `json:"name"`
+	// Status of the Ready condition of the component, Unknown until reported by the cluster.
+	Ready corev1.ConditionStatus `json:"ready"`
+}
+
+// ClusterStatus is the state of the platform on a managed cluster, as reported through its ManifestWork.
+type ClusterStatus struct {
+	// Name of the ManagedCluster.
+	Name string `json:"name"`
+	// Whether the DSCInitialization and DataScienceCluster have been applied to the cluster.
+	// +optional
+	Applied bool `json:"applied,omitempty"`
+	// Phase of the DataScienceCluster of the cluster.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Readiness of the Managed components, sorted by name.
+	// +optional
+	Components []ClusterComponentStatus `json:"components,omitempty"`
+	// Why the platform is not ready on the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FleetSummary counts the managed clusters by state.
+type FleetSummary struct {
+	Total int `json:"total"`
+	Ready int `json:"ready"`
+}
+
+// DataScienceClusterFleetStatus aggregates the state of the platform on managed clusters.
+type DataScienceClusterFleetStatus struct {
+	// Phase describes the Phase of DataScienceClusterFleet
+	Phase string `json:"phase,omitempty"`
+
+	// Conditions describes the state of the DataScienceClusterFleet resource
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+
+	// Summary of the state of the managed clusters.
+	// +optional
+	Summary FleetSummary `json:"summary,omitempty"`
+
+	// State of the platform on each managed cluster, sorted by name.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Clusters",type=integer,JSONPath=.status.summary.total
+//+kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=.status.summary.ready
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="Data Science Cluster Fleet"
+
+// DataScienceClusterFleet is the Schema for the datascienceclusterfleets API. Created on a hub cluster of Open Cluster
+// Management, it distributes a DSCInitialization and a DataScienceCluster to managed clusters and aggregates their
+// status.
+type DataScienceClusterFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataScienceClusterFleetSpec   `json:"spec,omitempty"`
+	Status DataScienceClusterFleetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DataScienceClusterFleetList contains a list of DataScienceClusterFleet.
+type DataScienceClusterFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataScienceClusterFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&DataScienceClusterFleet{},
+		&DataScienceClusterFleetList{},
+	)
+}
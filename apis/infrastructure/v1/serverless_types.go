@@ -18,4 +18,85 @@ type ServingSpec struct {
 	// IngressGateway allows to customize some parameters for the Istio Ingress Gateway
 	// that is bound to KNative-Serving.
 	IngressGateway GatewaySpec `json:"ingressGateway,omitempty"`
+	// Config tunes the KNativeServing resource created when the management state is Managed.
+	// When not set, defaults of KNative Serving apply.
+	// +optional
+	Config *KnativeServingConfig `json:"config,omitempty"`
+}
+
+// KnativeServingConfig holds settings of KNative Serving relevant to model serving.
+// Istio is always used as ingress of the KNativeServing, as it is required by KServe.
+type KnativeServingConfig struct {
+	// DomainMapping configures custom domains of KNative services.
+	// +optional
+	DomainMapping *DomainMappingConfig `json:"domainMapping,omitempty"`
+	// Autoscaler configures cluster-wide defaults of the KNative autoscaler, which can still be overridden per InferenceService.
+	// +optional
+	Autoscaler *AutoscalerConfig `json:"autoscaler,omitempty"`
+}
+
+// DomainMappingConfig configures how custom domains are mapped to KNative services.
+type DomainMappingConfig struct {
+	// DomainTemplate is the golang text template used to generate external domain names of KNative services,
+	// e.g. "{{.Name}}-{{.Namespace}}.{{.Domain}}".
+	// +optional
+	DomainTemplate string `json:"domainTemplate,omitempty"`
+	// AutocreateClusterDomainClaims allows DomainMappings to claim any domain not yet claimed by another namespace,
+	// instead of requiring a ClusterDomainClaim to be created for each domain by the cluster admin.
+	// +optional
+	AutocreateClusterDomainClaims bool `json:"autocreateClusterDomainClaims,omitempty"`
+	// Domains registers custom domains of served models. A DomainMapping is created for each, routing the domain
+	// to the KNative service of the model.
+	// +optional
+	Domains []ServingDomain `json:"domains,omitempty"`
+	// DomainSuffixes replaces the domain suffixes of KNative services, which are set in the config-domain ConfigMap of KNative Serving.
+	// The suffix without selector is used by services not matching any other; when there is none, defaults of KNative Serving apply.
+	// +optional
+	DomainSuffixes []DomainSuffix `json:"domainSuffixes,omitempty"`
+}
+
+// ServingDomain maps a custom domain to the KNative service of a served model.
+type ServingDomain struct {
+	// Name is the fully qualified domain name, e.g. "fraud-detection.models.example.com".
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Namespace of the served model.
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+	// Service is the name of the KNative service the domain is routed to, e.g. "<inferenceservice>-predictor".
+	Service string `json:"service"`
+	// TLSSecretName is the name of the Secret of type kubernetes.io/tls in Namespace holding the certificate of the domain.
+	// The operator labels it so that it is picked up by KNative. When not set, the domain is served over plain HTTP.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// DomainSuffix is a domain suffix of KNative services, used by the services matching its selector.
+type DomainSuffix struct {
+	// Name of the domain suffix, e.g. "models.example.com".
+	Name string `json:"name"`
+	// Selector matches labels of KNative services using the suffix.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+}
+
+// AutoscalerConfig holds defaults of the KNative autoscaler.
+type AutoscalerConfig struct {
+	// EnableScaleToZero allows models without traffic to be scaled down to zero replicas.
+	// +optional
+	EnableScaleToZero *bool `json:"enableScaleToZero,omitempty"`
+	// ScaleToZeroGracePeriod is how long the last replica is kept after traffic stops, e.g. "30s".
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +optional
+	ScaleToZeroGracePeriod string `json:"scaleToZeroGracePeriod,omitempty"`
+	// MinScale is the default minimum number of replicas of a model.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+	// MaxScale is the default maximum number of replicas of a model, zero meaning unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
 }
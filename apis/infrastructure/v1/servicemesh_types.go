@@ -12,6 +12,69 @@ type ServiceMeshSpec struct {
 	// Auth holds configuration of authentication and authorization services
 	// used by Service Mesh in Opendatahub.
 	Auth AuthSpec `json:"auth,omitempty"`
+	// MTLS configures mutual TLS enforced between workloads of Opendatahub components in the mesh.
+	// When not set, the operator does not manage PeerAuthentication and DestinationRule resources.
+	// +optional
+	MTLS *MTLSSpec `json:"mtls,omitempty"`
+	// SidecarInjection configures which namespaces get Istio sidecars injected, and by which control plane revision.
+	// When not set, the operator does not manage injection labels of namespaces.
+	// +optional
+	SidecarInjection *SidecarInjectionSpec `json:"sidecarInjection,omitempty"`
+}
+
+// SidecarInjectionSpec configures injection of Istio sidecars into pods of Opendatahub namespaces.
+type SidecarInjectionSpec struct {
+	// Revision of the Istio control plane injecting sidecars. When set, namespaces are labeled with "istio.io/rev",
+	// otherwise with "istio-injection". Changing it relabels all listed namespaces at once, so that their workloads are
+	// injected by the new revision once restarted.
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Namespaces lists namespaces whose sidecar injection is managed by the operator.
+	// +optional
+	Namespaces []NamespaceSidecarInjection `json:"namespaces,omitempty"`
+}
+
+// NamespaceSidecarInjection defines whether sidecars are injected into pods of the given namespace.
+type NamespaceSidecarInjection struct {
+	// Name of the namespace.
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Policy is "Enabled" to inject sidecars into pods of the namespace, "Disabled" to prevent it.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +kubebuilder:default=Enabled
+	Policy SidecarInjectionPolicy `json:"policy,omitempty"`
+}
+
+type SidecarInjectionPolicy string
+
+const (
+	SidecarInjectionEnabled  SidecarInjectionPolicy = "Enabled"
+	SidecarInjectionDisabled SidecarInjectionPolicy = "Disabled"
+)
+
+// MTLSSpec configures mutual TLS enforced in the namespaces of Opendatahub components.
+type MTLSSpec struct {
+	// Mode enforced in the applications namespace. Defaults to "STRICT".
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE;DISABLE
+	// +kubebuilder:default=STRICT
+	Mode string `json:"mode,omitempty"`
+	// Namespaces lists additional namespaces, e.g. the ones where models are served, with their own mode.
+	// +optional
+	Namespaces []NamespaceMTLS `json:"namespaces,omitempty"`
+}
+
+// NamespaceMTLS defines mutual TLS mode enforced in the given namespace.
+type NamespaceMTLS struct {
+	// Name of the namespace.
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Mode enforced in the namespace.
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE;DISABLE
+	Mode string `json:"mode"`
 }
 
 type ControlPlaneSpec struct {
@@ -57,4 +120,11 @@ type AuthSpec struct {
 	// Kubernetes apiserver (kubernetes.default.svc).
 	// +kubebuilder:default={"https://kubernetes.default.svc"}
 	Audiences *[]string `json:"audiences,omitempty"`
+	// ExemptNamespaces lists namespaces where requests to model servers are not authorized, e.g. smoke-test or
+	// public demo projects. While any namespace is exempted, AuthorizationPolicies are created in each other namespace
+	// of the mesh instead of a single one applying to the whole mesh.
+	// +kubebuilder:validation:items:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:items:MaxLength=63
+	// +optional
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
 }
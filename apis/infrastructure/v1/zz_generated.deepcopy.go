@@ -34,6 +34,11 @@ func (in *AuthSpec) DeepCopyInto(out *AuthSpec) {
 			copy(*out, *in)
 		}
 	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthSpec.
@@ -46,6 +51,36 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerConfig) DeepCopyInto(out *AutoscalerConfig) {
+	*out = *in
+	if in.EnableScaleToZero != nil {
+		in, out := &in.EnableScaleToZero, &out.EnableScaleToZero
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinScale != nil {
+		in, out := &in.MinScale, &out.MinScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxScale != nil {
+		in, out := &in.MaxScale, &out.MaxScale
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerConfig.
+func (in *AutoscalerConfig) DeepCopy() *AutoscalerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 	*out = *in
@@ -76,6 +111,55 @@ func (in *ControlPlaneSpec) DeepCopy() *ControlPlaneSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMappingConfig) DeepCopyInto(out *DomainMappingConfig) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]ServingDomain, len(*in))
+		copy(*out, *in)
+	}
+	if in.DomainSuffixes != nil {
+		in, out := &in.DomainSuffixes, &out.DomainSuffixes
+		*out = make([]DomainSuffix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMappingConfig.
+func (in *DomainMappingConfig) DeepCopy() *DomainMappingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMappingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainSuffix) DeepCopyInto(out *DomainSuffix) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSuffix.
+func (in *DomainSuffix) DeepCopy() *DomainSuffix {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainSuffix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
 	*out = *in
@@ -92,11 +176,96 @@ func (in *GatewaySpec) DeepCopy() *GatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeServingConfig) DeepCopyInto(out *KnativeServingConfig) {
+	*out = *in
+	if in.DomainMapping != nil {
+		in, out := &in.DomainMapping, &out.DomainMapping
+		*out = new(DomainMappingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaler != nil {
+		in, out := &in.Autoscaler, &out.Autoscaler
+		*out = new(AutoscalerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeServingConfig.
+func (in *KnativeServingConfig) DeepCopy() *KnativeServingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeServingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceMTLS, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
+func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceMTLS) DeepCopyInto(out *NamespaceMTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceMTLS.
+func (in *NamespaceMTLS) DeepCopy() *NamespaceMTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceMTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSidecarInjection) DeepCopyInto(out *NamespaceSidecarInjection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSidecarInjection.
+func (in *NamespaceSidecarInjection) DeepCopy() *NamespaceSidecarInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSidecarInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceMeshSpec) DeepCopyInto(out *ServiceMeshSpec) {
 	*out = *in
 	out.ControlPlane = in.ControlPlane
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SidecarInjection != nil {
+		in, out := &in.SidecarInjection, &out.SidecarInjection
+		*out = new(SidecarInjectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshSpec.
@@ -109,10 +278,30 @@ func (in *ServiceMeshSpec) DeepCopy() *ServiceMeshSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingDomain) DeepCopyInto(out *ServingDomain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingDomain.
+func (in *ServingDomain) DeepCopy() *ServingDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingSpec) DeepCopyInto(out *ServingSpec) {
 	*out = *in
 	out.IngressGateway = in.IngressGateway
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(KnativeServingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingSpec.
@@ -124,3 +313,23 @@ func (in *ServingSpec) DeepCopy() *ServingSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarInjectionSpec) DeepCopyInto(out *SidecarInjectionSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceSidecarInjection, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarInjectionSpec.
+func (in *SidecarInjectionSpec) DeepCopy() *SidecarInjectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarInjectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
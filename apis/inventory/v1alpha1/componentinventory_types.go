@@ -0,0 +1,134 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentInventorySpec identifies the component the inventory is kept for.
+type ComponentInventorySpec struct {
+	// Component the resources have been created for.
+	Component string `json:"component"`
+}
+
+// InventoryPhase tells whether the resources of the component are deployed or being removed.
+type InventoryPhase string
+
+const (
+	// InventoryDeployed is the phase of inventories of Managed components.
+	InventoryDeployed InventoryPhase = "Deployed"
+	// InventoryRemoving is the phase of inventories of removed components whose resources still exist.
+	InventoryRemoving InventoryPhase = "Removing"
+)
+
+// ResourceHealth is the health of an inventoried resource.
+// +kubebuilder:validation:Enum=Healthy;Unhealthy;Missing;Leftover
+type ResourceHealth string
+
+const (
+	// ResourceHealthy resources exist and, for workloads, have all their replicas ready.
+	ResourceHealthy ResourceHealth = "Healthy"
+	// ResourceUnhealthy resources exist but have replicas not ready, or a Ready or Available condition not True.
+	ResourceUnhealthy ResourceHealth = "Unhealthy"
+	// ResourceMissing resources have been deployed but do not exist anymore.
+	ResourceMissing ResourceHealth = "Missing"
+	// ResourceLeftover resources still exist after the component has been removed.
+	ResourceLeftover ResourceHealth = "Leftover"
+)
+
+// InventoryResource is a resource created by the operator for the component.
+type InventoryResource struct {
+	// API group of the resource, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// API version of the resource.
+	Version string `json:"version"`
+	// Kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace of the resource, empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the resource.
+	Name string `json:"name"`
+	// Health of the resource when last inventoried.
+	Health ResourceHealth `json:"health"`
+	// Why the resource is not healthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InventorySummary counts the inventoried resources by health.
+type InventorySummary struct {
+	Total     int `json:"total"`
+	Healthy   int `json:"healthy"`
+	Unhealthy int `json:"unhealthy"`
+	Missing   int `json:"missing"`
+	Leftover  int `json:"leftover"`
+}
+
+// ComponentInventoryStatus lists the resources of the component.
+type ComponentInventoryStatus struct {
+	// Phase of the component.
+	// +optional
+	Phase InventoryPhase `json:"phase,omitempty"`
+	// When the resources or their health last changed.
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+	// Summary of the health of the resources.
+	// +optional
+	Summary InventorySummary `json:"summary,omitempty"`
+	// Resources of the component, sorted by group, kind, namespace and name.
+	// +optional
+	Resources []InventoryResource `json:"resources,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Component",type=string,JSONPath=.spec.component
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+kubebuilder:printcolumn:name="Resources",type=integer,JSONPath=.status.summary.total
+//+kubebuilder:printcolumn:name="Unhealthy",type=integer,JSONPath=.status.summary.unhealthy
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+operator-sdk:csv:customresourcedefinitions:displayName="Component Inventory"
+
+// ComponentInventory is the Schema for the componentinventories API. It is maintained by the operator, one per
+// component, named after the component.
+type ComponentInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentInventorySpec   `json:"spec,omitempty"`
+	Status ComponentInventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentInventoryList contains a list of ComponentInventory.
+type ComponentInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ComponentInventory{},
+		&ComponentInventoryList{},
+	)
+}
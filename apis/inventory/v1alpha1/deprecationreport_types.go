@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeprecatedUsage is a resource of a data science project using an API deprecated by an upcoming version of a component.
+type DeprecatedUsage struct {
+	// API group of the resource.
+	Group string `json:"group"`
+	// API version the resource was listed with.
+	Version string `json:"version"`
+	// Kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name of the resource.
+	Name string `json:"name"`
+	// Component deprecating the API.
+	Component string `json:"component"`
+	// Deprecation the resource is affected by, e.g. "PipelinesV1".
+	Deprecation string `json:"deprecation"`
+	// What to change before upgrading.
+	Message string `json:"message"`
+}
+
+// DeprecationReportStatus lists the resources using deprecated APIs.
+type DeprecationReportStatus struct {
+	// When data science projects were last scanned.
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+	// Number of resources using deprecated APIs.
+	// +optional
+	Total int `json:"total,omitempty"`
+	// Resources using deprecated APIs, sorted by namespace, kind, name and deprecation.
+	// +optional
+	Usages []DeprecatedUsage `json:"usages,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Deprecated",type=integer,JSONPath=.status.total
+//+kubebuilder:printcolumn:name="Last Scan",type=date,JSONPath=.status.lastScanTime
+//+operator-sdk:csv:customresourcedefinitions:displayName="Deprecation Report"
+
+// DeprecationReport is the Schema for the deprecationreports API. It is maintained by the operator, as a single
+// instance named "default-deprecation-report", and lists the resources of data science projects to migrate before
+// upgrading.
+type DeprecationReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DeprecationReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DeprecationReportList contains a list of DeprecationReport.
+type DeprecationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeprecationReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&DeprecationReport{},
+		&DeprecationReportList{},
+	)
+}
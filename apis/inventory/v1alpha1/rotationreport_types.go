@@ -0,0 +1,106 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ManagedSecretKind tells how a Secret is managed by the operator.
+// +kubebuilder:validation:Enum=Certificate;OAuthClientSecret;GeneratedSecret
+type ManagedSecretKind string
+
+const (
+	// CertificateSecret holds a TLS certificate set up by the operator, e.g. for the ingress gateway of the mesh.
+	CertificateSecret ManagedSecretKind = "Certificate"
+	// OAuthClientSecret holds the secret of an OAuthClient of a component.
+	OAuthClientSecret ManagedSecretKind = "OAuthClientSecret"
+	// GeneratedSecret is generated by the secret generator from an annotated Secret.
+	GeneratedSecret ManagedSecretKind = "GeneratedSecret"
+)
+
+// ManagedSecret is a Secret managed by the operator, with its age, expiry and last rotation.
+type ManagedSecret struct {
+	// Namespace of the Secret.
+	Namespace string `json:"namespace"`
+	// Name of the Secret.
+	Name string `json:"name"`
+	// Kind of secret.
+	Kind ManagedSecretKind `json:"kind"`
+	// When the Secret was created.
+	CreationTime metav1.Time `json:"creationTime"`
+	// When the secret was last rotated, its creation time when it never was.
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+	// When the certificate expires, for certificates.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// Whether the operator rotates the secret itself.
+	AutoRotated bool `json:"autoRotated"`
+	// Whether the secret is due for rotation: the certificate is about to expire, or the secret is older than the
+	// maximum age.
+	RotationDue bool `json:"rotationDue"`
+}
+
+// RotationReportStatus lists the Secrets managed by the operator.
+type RotationReportStatus struct {
+	// When Secrets were last inventoried.
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+	// Number of Secrets managed by the operator.
+	// +optional
+	Total int `json:"total,omitempty"`
+	// Number of Secrets due for rotation.
+	// +optional
+	Due int `json:"due,omitempty"`
+	// Secrets managed by the operator, sorted by namespace and name.
+	// +optional
+	Secrets []ManagedSecret `json:"secrets,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Secrets",type=integer,JSONPath=.status.total
+//+kubebuilder:printcolumn:name="Due",type=integer,JSONPath=.status.due
+//+kubebuilder:printcolumn:name="Last Scan",type=date,JSONPath=.status.lastScanTime
+//+operator-sdk:csv:customresourcedefinitions:displayName="Rotation Report"
+
+// RotationReport is the Schema for the rotationreports API. It is maintained by the operator, as a single instance
+// named "default-rotation-report", and lists the certificates and secrets managed by the operator with their age,
+// expiry and last rotation.
+type RotationReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status RotationReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RotationReportList contains a list of RotationReport.
+type RotationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RotationReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&RotationReport{},
+		&RotationReportList{},
+	)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServingCatalogSpec selects the data science projects whose serving endpoints are listed.
+type ServingCatalogSpec struct {
+	// Selects the data science projects whose endpoints are listed, all of them when not set. Users allowed to read
+	// the catalog see the endpoints of every selected project, projects holding private models are to be left out.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ServingEndpoint is a model served by an InferenceService of a data science project.
+type ServingEndpoint struct {
+	// Namespace of the InferenceService.
+	Namespace string `json:"namespace"`
+	// Name of the InferenceService.
+	Name string `json:"name"`
+	// URL of the model, exposed outside of the cluster when its route is.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// URL of the model inside of the cluster.
+	// +optional
+	InternalURL string `json:"internalURL,omitempty"`
+	// Whether requests to the model require a token, set with the "security.opendatahub.io/enable-auth" annotation.
+	AuthRequired bool `json:"authRequired"`
+	// Deployment mode of the model, e.g. "Serverless", "RawDeployment" or "ModelMesh".
+	// +optional
+	DeploymentMode string `json:"deploymentMode,omitempty"`
+	// Format of the model, e.g. "onnx".
+	// +optional
+	ModelFormat string `json:"modelFormat,omitempty"`
+	// Name of the ServingRuntime or ClusterServingRuntime serving the model.
+	// +optional
+	Runtime string `json:"runtime,omitempty"`
+	// Version of the runtime, from its "opendatahub.io/runtime-version" annotation or the tag of its image.
+	// +optional
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// Whether the InferenceService is ready to serve requests.
+	Ready bool `json:"ready"`
+}
+
+// ServingCatalogStatus lists the serving endpoints of the selected data science projects.
+type ServingCatalogStatus struct {
+	// When data science projects were last scanned.
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+	// Number of serving endpoints.
+	// +optional
+	Total int `json:"total,omitempty"`
+	// Serving endpoints, sorted by namespace and name.
+	// +optional
+	Endpoints []ServingEndpoint `json:"endpoints,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Endpoints",type=integer,JSONPath=.status.total
+//+kubebuilder:printcolumn:name="Last Scan",type=date,JSONPath=.status.lastScanTime
+//+operator-sdk:csv:customresourcedefinitions:displayName="Serving Catalog"
+
+// ServingCatalog is the Schema for the servingcatalogs API. It is maintained by the operator, as a single instance
+// named "default-serving-catalog", and lists the models served in data science projects, for the dashboard and
+// discovery tooling to find them without scanning every project.
+type ServingCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServingCatalogSpec   `json:"spec,omitempty"`
+	Status ServingCatalogStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ServingCatalogList contains a list of ServingCatalog.
+type ServingCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServingCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ServingCatalog{},
+		&ServingCatalogList{},
+	)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProjectUsage is the resource usage of a data science project, with the labels its costs are attributed by.
+type ProjectUsage struct {
+	// Namespace of the project.
+	Namespace string `json:"namespace"`
+	// Cost-attribution labels of the project, stamped on its pods.
+	// +optional
+	Attribution map[string]string `json:"attribution,omitempty"`
+	// Number of pods of the project which are not terminated.
+	Pods int `json:"pods"`
+	// CPU, memory and GPUs requested by the pods which are not terminated.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// CPU and memory used by the pods, as measured by the metrics API, when available.
+	// +optional
+	Usage corev1.ResourceList `json:"usage,omitempty"`
+}
+
+// UsageReportStatus lists the resource usage of data science projects.
+type UsageReportStatus struct {
+	// When data science projects were last scanned.
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+	// Number of data science projects.
+	// +optional
+	Total int `json:"total,omitempty"`
+	// Resource usage of data science projects, sorted by namespace.
+	// +optional
+	Projects []ProjectUsage `json:"projects,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Projects",type=integer,JSONPath=.status.total
+//+kubebuilder:printcolumn:name="Last Scan",type=date,JSONPath=.status.lastScanTime
+//+operator-sdk:csv:customresourcedefinitions:displayName="Usage Report"
+
+// UsageReport is the Schema for the usagereports API. It is maintained by the operator, as a single instance named
+// "default-usage-report", and lists the CPU, memory and GPUs used by each data science project for chargeback.
+type UsageReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status UsageReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// UsageReportList contains a list of UsageReport.
+type UsageReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UsageReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&UsageReport{},
+		&UsageReportList{},
+	)
+}
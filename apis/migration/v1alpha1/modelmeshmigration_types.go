@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentMode is the KServe deployment mode of migrated models.
+// +kubebuilder:validation:Enum=Serverless;RawDeployment
+type DeploymentMode string
+
+const (
+	Serverless    DeploymentMode = "Serverless"
+	RawDeployment DeploymentMode = "RawDeployment"
+)
+
+// ModelPhase tells how far the migration of a model went.
+type ModelPhase string
+
+const (
+	// ModelCompatible models have an equivalent KServe InferenceService, listed in the migration plan.
+	ModelCompatible ModelPhase = "Compatible"
+	// ModelIncompatible models cannot be migrated, the message of the model tells why.
+	ModelIncompatible ModelPhase = "Incompatible"
+	// ModelMigrated models are served by KServe.
+	ModelMigrated ModelPhase = "Migrated"
+)
+
+// ModelMeshMigrationSpec defines the migration of models served by ModelMesh to KServe.
+type ModelMeshMigrationSpec struct {
+	// Data science projects whose models are migrated, all projects serving models with ModelMesh when empty.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Set to true to replace ModelMesh InferenceServices by their KServe equivalent. Only projects whose models are
+	// all compatible are cut over. When false, models are only inventoried and migration plans are generated.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	// +optional
+	Cutover bool `json:"cutover,omitempty"`
+	// KServe deployment mode of migrated models.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +kubebuilder:default=Serverless
+	DeploymentMode DeploymentMode `json:"deploymentMode,omitempty"`
+	// Maps ModelMesh ServingRuntimes to the KServe ServingRuntimes serving their models. Models selecting a
+	// ServingRuntime which is not mapped are incompatible, models selecting none keep selecting the runtime by
+	// model format.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	// +optional
+	// +listType=map
+	// +listMapKey=modelMeshRuntime
+	RuntimeMappings []RuntimeMapping `json:"runtimeMappings,omitempty"`
+}
+
+// RuntimeMapping maps a ModelMesh ServingRuntime to a KServe one.
+type RuntimeMapping struct {
+	// Name of the ModelMesh ServingRuntime.
+	ModelMeshRuntime string `json:"modelMeshRuntime"`
+	// Name of the KServe ServingRuntime or ClusterServingRuntime.
+	KServeRuntime string `json:"kserveRuntime"`
+}
+
+// ModelMeshMigrationStatus defines the observed state of ModelMeshMigration.
+type ModelMeshMigrationStatus struct {
+	// Phase describes the Phase of ModelMeshMigration
+	Phase string `json:"phase,omitempty"`
+
+	// Conditions describes the state of the ModelMeshMigration resource
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+
+	// Inventory of the models of the migrated projects
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Namespaces []NamespaceMigration `json:"namespaces,omitempty"`
+}
+
+// NamespaceMigration defines the state of the migration of a data science project.
+type NamespaceMigration struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+	// Name of the ConfigMap of the namespace holding the KServe InferenceServices generated for its models.
+	// +optional
+	Plan string `json:"plan,omitempty"`
+	// Models of the namespace.
+	// +optional
+	Models []ModelMigration `json:"models,omitempty"`
+	// Whether the namespace has been cut over to KServe.
+	// +optional
+	Migrated bool `json:"migrated,omitempty"`
+}
+
+// ModelMigration defines the state of the migration of a model.
+type ModelMigration struct {
+	// Name of the InferenceService.
+	Name string `json:"name"`
+	// ServingRuntime selected by the InferenceService.
+	// +optional
+	Runtime string `json:"runtime,omitempty"`
+	// Phase of the migration of the model.
+	Phase ModelPhase `json:"phase"`
+	// Why the model is incompatible.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cutover",type=boolean,JSONPath=.spec.cutover
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="ModelMesh Migration"
+
+// ModelMeshMigration is the Schema for the modelmeshmigrations API.
+type ModelMeshMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModelMeshMigrationSpec   `json:"spec,omitempty"`
+	Status ModelMeshMigrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModelMeshMigrationList contains a list of ModelMeshMigration.
+type ModelMeshMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelMeshMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ModelMeshMigration{},
+		&ModelMeshMigrationList{},
+	)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigName is the name of the only OperatorConfig the operator takes into account.
+const OperatorConfigName = "default"
+
+// OperatorConfigSpec defines operator-internal tuning, kept apart from the platform configuration of DSCInitialization.
+type OperatorConfigSpec struct {
+	// Logging configuration of the operator, applied at runtime.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	Logging *LoggingSpec `json:"logging,omitempty"`
+	// Reconcile concurrency of the operator controllers. Changes take effect once the operator is restarted.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	// +optional
+	Concurrency ConcurrencySpec `json:"concurrency,omitempty"`
+	// Scope of the operator cache. Changes take effect once the operator is restarted.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +optional
+	Cache CacheSpec `json:"cache,omitempty"`
+	// Feature gates to enable or disable, by name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// Notifications of lifecycle events of the platform, sent to webhook, Slack or email sinks.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	// +optional
+	Notifications *NotificationsSpec `json:"notifications,omitempty"`
+	// Rate and priority of the requests of the operator to the API server.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	// +optional
+	Client ClientSpec `json:"client,omitempty"`
+	// Tracing of the reconciliations of the operator, exported with OTLP and applied at runtime. Spans are not
+	// recorded when not set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+}
+
+// LoggingSpec defines log levels and format of the operator.
+type LoggingSpec struct {
+	// Global log level, one of "debug", "info", "warn", "error" or a positive verbosity number.
+	// +kubebuilder:default=info
+	Level string `json:"level,omitempty"`
+	// Format of component loggers, the operator's own logger keeps the format set on startup.
+	// +kubebuilder:validation:Enum=json;console
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Log level overrides by component (e.g. "kserve") or feature (e.g. "mesh-control-plane-creation") name.
+	// +optional
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// ConcurrencySpec defines how many reconciles the operator controllers run in parallel.
+type ConcurrencySpec struct {
+	// Maximum number of concurrent reconciles of every controller.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// Maximum number of concurrent reconciles by kind of the reconciled resource, in Kind.group form
+	// (e.g. "Deployment.apps"), taking precedence over maxConcurrentReconciles.
+	// +optional
+	ByKind map[string]int `json:"byKind,omitempty"`
+}
+
+// CacheSpec defines which namespaces are cached by the operator on top of its own ones.
+type CacheSpec struct {
+	// Additional namespaces in which Secrets and Deployments are cached.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// ClientSpec defines the load the operator puts on the API server.
+type ClientSpec struct {
+	// Average number of requests per second the operator sends to the API server, 20 when not set.
+	// Changes take effect once the operator is restarted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+	// Number of requests the operator can send at once above qps, 30 when not set.
+	// Changes take effect once the operator is restarted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+	// Name of the PriorityLevelConfiguration of API Priority and Fairness the requests of the operator are assigned to,
+	// through a FlowSchema matching its ServiceAccount. Requests are classified by the FlowSchemas of the cluster when
+	// not set.
+	// +optional
+	PriorityLevel string `json:"priorityLevel,omitempty"`
+}
+
+// TracingSpec defines where the operator exports the spans of its reconciliations.
+type TracingSpec struct {
+	// Endpoint of the OTLP/HTTP receiver spans are exported to, as host:port, e.g.
+	// "otel-collector.observability.svc:4318".
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// Exports spans over plain HTTP rather than HTTPS.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// NotificationEvent is a lifecycle event of the platform notifications are sent for.
+// +kubebuilder:validation:Enum=ComponentDegraded;UpgradeCompleted;CapabilityFailed;CertificateExpiring
+type NotificationEvent string
+
+const (
+	// ComponentDegraded is sent when the reconciliation of a component starts failing.
+	ComponentDegraded NotificationEvent = "ComponentDegraded"
+	// UpgradeCompleted is sent once the platform has been reconciled with a new release of the operator.
+	UpgradeCompleted NotificationEvent = "UpgradeCompleted"
+	// CapabilityFailed is sent when a capability of the platform, e.g. the service mesh, fails to be activated.
+	CapabilityFailed NotificationEvent = "CapabilityFailed"
+	// CertificateExpiring is sent when the certificate served by a component Route is about to expire.
+	CertificateExpiring NotificationEvent = "CertificateExpiring"
+)
+
+// NotificationSinkType is the kind of destination of notifications.
+// +kubebuilder:validation:Enum=Webhook;Slack;Email
+type NotificationSinkType string
+
+const (
+	// WebhookSink receives events as JSON in POST requests.
+	WebhookSink NotificationSinkType = "Webhook"
+	// SlackSink posts events to a Slack incoming webhook.
+	SlackSink NotificationSinkType = "Slack"
+	// EmailSink sends events by email through an SMTP server.
+	EmailSink NotificationSinkType = "Email"
+)
+
+// NotificationsSpec defines the sinks lifecycle events are sent to.
+type NotificationsSpec struct {
+	// Sinks notifications are sent to.
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sinks []NotificationSink `json:"sinks,omitempty"`
+	// Minimum interval between two notifications of the same event about the same resource, 24h when not set.
+	// +optional
+	RepeatInterval *metav1.Duration `json:"repeatInterval,omitempty"`
+}
+
+// NotificationSink is a destination of notifications, whose settings are read from a Secret of the operator namespace.
+type NotificationSink struct {
+	// Name of the sink.
+	Name string `json:"name"`
+	// Type of the sink.
+	Type NotificationSinkType `json:"type"`
+	// Name of the Secret in the operator namespace holding the settings of the sink: the "url" key for Webhook and
+	// Slack sinks; the "host" (host:port of the SMTP server), "from" and "to" (comma separated) keys for Email sinks,
+	// with "username" and "password" when the server requires authentication.
+	SecretName string `json:"secretName"`
+	// Events sent to the sink, all of them when empty.
+	// +optional
+	Events []NotificationEvent `json:"events,omitempty"`
+}
+
+// OperatorConfigStatus defines the observed state of OperatorConfig.
+type OperatorConfigStatus struct {
+	// Phase describes the Phase of OperatorConfig
+	Phase string `json:"phase,omitempty"`
+
+	// Conditions describes the state of the OperatorConfig resource
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+
+	// Feature gates known to the operator and whether they are enabled
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	FeatureGates []FeatureGateStatus `json:"featureGates,omitempty"`
+}
+
+// FeatureGateStatus describes a feature gate of the operator.
+type FeatureGateStatus struct {
+	// Name of the feature gate.
+	Name string `json:"name"`
+	// Maturity of the gated functionality, one of Alpha, Beta or GA.
+	Stage string `json:"stage"`
+	// Whether the gated functionality is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="OperatorConfig name must be default"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="Operator Config"
+
+// OperatorConfig is the Schema for the operatorconfigs API.
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigSpec   `json:"spec,omitempty"`
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OperatorConfigList contains a list of OperatorConfig.
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&OperatorConfig{},
+		&OperatorConfigList{},
+	)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProfileSpec defines the owner of the data science project of the profile, and the quota and policies it is created
+// with.
+type ProfileSpec struct {
+	// Name of the project displayed in the dashboard, defaults to the name of the Profile.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Owner of the project, bound to the admin role in its namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	Owner ProfileSubject `json:"owner"`
+	// Contributors of the project, bound to the edit role in its namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +optional
+	Contributors []ProfileSubject `json:"contributors,omitempty"`
+	// Name of the ProjectTemplate instantiated in the project, e.g. for its data connections and workbench. Templates
+	// are instantiated once, changing the template of a profile instantiates the new one.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	// +optional
+	Template string `json:"template,omitempty"`
+	// Hard limits of the ResourceQuota of the project, e.g. "requests.cpu" or "requests.nvidia.com/gpu". It applies on
+	// top of the quota of the template, if any.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	// +optional
+	Quota corev1.ResourceList `json:"quota,omitempty"`
+	// Enrolls the project in the service mesh set in the DSCInitialization, e.g. for KServe in serverless mode.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	// +optional
+	ServiceMesh bool `json:"serviceMesh,omitempty"`
+}
+
+// ProfileSubject is a user or a group of the cluster.
+type ProfileSubject struct {
+	// Kind of the subject.
+	// +kubebuilder:validation:Enum=User;Group
+	Kind string `json:"kind"`
+	// Name of the user or group.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ProfileStatus defines the observed state of Profile.
+type ProfileStatus struct {
+	// Phase is "Ready" once the project is set up, "Progressing" while its template is instantiated, "Error" otherwise.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Namespace of the project.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// What the project is waiting for, or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Whether the project is a member of the service mesh.
+	// +optional
+	ServiceMeshMember bool `json:"serviceMeshMember,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 63",message="Profile name must be a valid namespace name"
+//+kubebuilder:printcolumn:name="Owner",type=string,JSONPath=.spec.owner.name
+//+kubebuilder:printcolumn:name="Template",type=string,JSONPath=.spec.template
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="Profile"
+
+// Profile is the Schema for the profiles API. It onboards a user or group as the owner of a data science project of
+// the same name, as Kubeflow Profiles do. The namespace of the project is deleted with the Profile.
+type Profile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProfileSpec   `json:"spec,omitempty"`
+	Status ProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ProfileList contains a list of Profile.
+type ProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Profile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&Profile{},
+		&ProfileList{},
+	)
+}
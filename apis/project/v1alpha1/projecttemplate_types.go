@@ -0,0 +1,172 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProjectTemplateSpec defines the resources created in data science projects instantiated from the template.
+type ProjectTemplateSpec struct {
+	// Name of the template displayed in the dashboard, defaults to the name of the ProjectTemplate.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=1
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Description of the template displayed in the dashboard.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Data connections created in the project, for users to fill in the credentials of.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	// +optional
+	DataConnections []DataConnectionPlaceholder `json:"dataConnections,omitempty"`
+	// Workbench created in the project.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	// +optional
+	Workbench *WorkbenchTemplate `json:"workbench,omitempty"`
+	// Pipeline server created in the project, once the credentials of its data connection are filled in.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	// +optional
+	PipelineServer *PipelineServerTemplate `json:"pipelineServer,omitempty"`
+	// Hard limits of the ResourceQuota of the project, e.g. "requests.cpu" or "requests.nvidia.com/gpu".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	// +optional
+	Quota corev1.ResourceList `json:"quota,omitempty"`
+}
+
+// DataConnectionPlaceholder defines a data connection of the project, created without credentials.
+type DataConnectionPlaceholder struct {
+	// Name of the data connection, the Secret is named "aws-connection-<name>" as data connections of the dashboard.
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Name of the data connection displayed in the dashboard, defaults to its name.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Endpoint of the S3 compatible object storage.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region of the object storage.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Default bucket of the data connection.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// WorkbenchTemplate defines the workbench of the project.
+type WorkbenchTemplate struct {
+	// Name of the Notebook, and of the PersistentVolumeClaim holding its home directory.
+	// +kubebuilder:default=workbench
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+	// Image of the workbench, e.g. one of the notebook images of the applications namespace.
+	Image string `json:"image"`
+	// Compute resources of the workbench.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Size of the volume holding the home directory of the workbench.
+	// +kubebuilder:default="20Gi"
+	StorageSize resource.Quantity `json:"storageSize,omitempty"`
+	// Storage class of the volume, the default storage class of the cluster is used when not set.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// PipelineServerTemplate defines the pipeline server of the project.
+type PipelineServerTemplate struct {
+	// Name of the DataSciencePipelinesApplication.
+	// +kubebuilder:default=dspa
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+	// Name of the data connection of the template the pipeline server stores artifacts in.
+	DataConnection string `json:"dataConnection"`
+}
+
+// ProjectPhase is the progress of the instantiation of a template in a project.
+// +kubebuilder:validation:Enum=Pending;Ready;Failed
+type ProjectPhase string
+
+const (
+	// ProjectPending projects wait for resources of the template to be created, e.g. for data connections to be filled in.
+	ProjectPending ProjectPhase = "Pending"
+	// ProjectReady projects have all the resources of the template. They are not reconciled anymore.
+	ProjectReady ProjectPhase = "Ready"
+	// ProjectFailed projects could not be instantiated.
+	ProjectFailed ProjectPhase = "Failed"
+)
+
+// ProjectStatus is the instantiation of the template in a data science project.
+type ProjectStatus struct {
+	// Name of the project.
+	Name string `json:"name"`
+	// Phase of the instantiation.
+	Phase ProjectPhase `json:"phase"`
+	// What the project is waiting for, or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ProjectTemplateStatus defines the observed state of ProjectTemplate.
+type ProjectTemplateStatus struct {
+	// Phase describes the Phase of ProjectTemplate
+	Phase string `json:"phase,omitempty"`
+
+	// Projects instantiated from the template, sorted by name.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Projects []ProjectStatus `json:"projects,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=.spec.displayName
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase,description="Current Phase"
+//+operator-sdk:csv:customresourcedefinitions:displayName="Project Template"
+
+// ProjectTemplate is the Schema for the projecttemplates API. Data science projects are instantiated from it by
+// annotating their namespace with "opendatahub.io/project-template: <name>".
+type ProjectTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectTemplateSpec   `json:"spec,omitempty"`
+	Status ProjectTemplateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ProjectTemplateList contains a list of ProjectTemplate.
+type ProjectTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ProjectTemplate{},
+		&ProjectTemplateList{},
+	)
+}
@@ -9,13 +9,16 @@ import (
 	"path/filepath"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 )
 
@@ -26,8 +29,13 @@ var (
 	ParamsPath        = deploy.DefaultManifestPath + "/" + ComponentName + "/manager"
 )
 
-// Verifies that CodeFlare implements ComponentInterface.
-var _ components.ComponentInterface = (*CodeFlare)(nil)
+// Verifies that CodeFlare implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*CodeFlare)(nil)
+	_ components.LoggingProvider     = (*CodeFlare)(nil)
+	_ components.PersonaRBACProvider = (*CodeFlare)(nil)
+	_ components.WorkloadCounter     = (*CodeFlare)(nil)
+)
 
 // CodeFlare struct holds the configuration for the CodeFlare component.
 // +kubebuilder:object:generate=true
@@ -71,6 +79,32 @@ func (c *CodeFlare) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the CodeFlare operator.
+func (c *CodeFlare) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("codeflare-operator-manager")
+}
+
+// Workloads returns the AppWrappers queued by CodeFlare.
+func (c *CodeFlare) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	appWrappers, err := components.CountWorkloads(ctx, cli, gvk.AppWrapper, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{appWrappers}, nil
+}
+
+// PersonaPolicyRules returns permissions on CodeFlare resources contributed to the personas' ClusterRoles.
+func (c *CodeFlare) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	appWrappers := []string{"appwrappers"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"workload.codeflare.dev"}, Resources: appWrappers, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"workload.codeflare.dev"}, Resources: appWrappers, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"workload.codeflare.dev"}, Resources: appWrappers, Verbs: components.EditVerbs}},
+	}
+}
+
 func (c *CodeFlare) ReconcileComponent(ctx context.Context,
 	cli client.Client,
 	owner metav1.Object,
@@ -95,14 +129,20 @@ func (c *CodeFlare) ReconcileComponent(ctx context.Context,
 		if found, err := cluster.OperatorExists(ctx, cli, dependentOperator); err != nil {
 			return fmt.Errorf("operator exists throws error %w", err)
 		} else if found {
-			return fmt.Errorf("operator %s is found. Please uninstall the operator before enabling %s component",
-				dependentOperator, ComponentName)
+			return status.NewRemediationError(status.RemediationConflictingOperator,
+				fmt.Sprintf("Uninstall the %s operator, or set codeflare managementState to Removed", dependentOperator),
+				fmt.Errorf("operator %s is found. Please uninstall the operator before enabling %s component",
+					dependentOperator, ComponentName))
 		}
 
 		// It updates stock manifests, overridden manifests should contain proper namespace
 		if err := deploy.ApplyParams(ParamsPath, nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
 			return fmt.Errorf("failed update image from %s : %w", CodeflarePath+"/bases", err)
 		}
+
+		if err := deploy.ApplyExtraParams(ComponentName, c.ExtraParams, ParamsPath); err != nil {
+			return err
+		}
 	}
 
 	// Deploy Codeflare
@@ -3,14 +3,21 @@ package components
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"gopkg.in/yaml.v2"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
@@ -36,6 +43,71 @@ type Component struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
 	DevFlags *DevFlags `json:"devFlags,omitempty"`
+
+	// Extra parameters merged into the component's params.env during rendering, e.g. to change a single image or flag
+	// without maintaining a fork of the manifests. Only keys already defined in the component's params.env are accepted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
+	ExtraParams map[string]string `json:"extraParams,omitempty"`
+
+	// Remediation the operator applies when Deployments of the component stay unhealthy, e.g. crash-looping or failing readiness.
+	// Self-healing is disabled when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	SelfHealing *SelfHealing `json:"selfHealing,omitempty"`
+
+	// Secrets of the component sourced from an external secret manager, e.g. Vault, through External Secrets Operator.
+	// The component is not deployed until all of them have been materialized.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	ExternalSecrets []ExternalSecret `json:"externalSecrets,omitempty"`
+
+	// Limits the rate at which resources of the component are applied, for components with many resources on clusters
+	// throttling API requests. Resources are applied at the rate of the operator client when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	Apply *ApplySettings `json:"apply,omitempty"`
+
+	// Log level of the Deployments of the component, set through the environment variable or the command line flag each
+	// of them reads it from, e.g. to collect diagnostics. The level set by the manifests is kept when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=7
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// Set to one of the following values:
+	//
+	// - "Always" : CRDs of the component are created and updated from its manifests
+	//
+	// - "IfCompatible" : existing CRDs are only updated when the schema of their served versions stays compatible,
+	//                    i.e. no version, property or enum value is removed, no type changes and no property becomes required
+	//
+	// - "Never" : CRDs of the component are neither created nor updated, they have to be installed beforehand
+	//
+	// Incompatible changes found are reported in the status of the DataScienceCluster. Defaults to "Always".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=8
+	CRDUpdatePolicy CRDUpdatePolicy `json:"crdUpdatePolicy,omitempty"`
+
+	// Security context of the pods of the Deployments of the component, and SecurityContextConstraints they require, e.g.
+	// for clusters enforcing the restricted-v2 SCC everywhere or mapping custom SCCs. The manifests are kept when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=9
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+
+	// Set to one of the following values:
+	//
+	// - "Adopt" : resources of the component found existing without being managed by the operator, e.g. created by hand,
+	//             are taken over and updated from its manifests
+	//
+	// - "Skip" : such resources are left as they are
+	//
+	// - "Fail" : the reconciliation of the component fails until such resources are removed, or annotated with
+	//            "opendatahub.io/managed: true" for the operator to adopt them
+	//
+	// Defaults to "Adopt".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=10
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
 }
 
 func (c *Component) Init(_ context.Context, _ cluster.Platform) error {
@@ -46,11 +118,239 @@ func (c *Component) GetManagementState() operatorv1.ManagementState {
 	return c.ManagementState
 }
 
+func (c *Component) SetManagementState(state operatorv1.ManagementState) {
+	c.ManagementState = state
+}
+
+func (c *Component) GetSelfHealing() *SelfHealing {
+	return c.SelfHealing
+}
+
+func (c *Component) GetExternalSecrets() []ExternalSecret {
+	return c.ExternalSecrets
+}
+
+func (c *Component) GetApplySettings() *ApplySettings {
+	return c.Apply
+}
+
+func (c *Component) GetLogLevel() LogLevel {
+	return c.LogLevel
+}
+
+func (c *Component) GetCRDUpdatePolicy() CRDUpdatePolicy {
+	return c.CRDUpdatePolicy
+}
+
+func (c *Component) GetSecurityContext() *SecurityContext {
+	return c.SecurityContext
+}
+
+func (c *Component) GetAdoptionPolicy() AdoptionPolicy {
+	return c.AdoptionPolicy
+}
+
+// Customizations are the settings of a component changing what is rendered from its manifests.
+type Customizations struct {
+	DevFlags    *DevFlags         `json:"devFlags,omitempty"`
+	ExtraParams map[string]string `json:"extraParams,omitempty"`
+}
+
+// IsEmpty tells whether the component was not customized.
+func (c Customizations) IsEmpty() bool {
+	return (c.DevFlags == nil || len(c.DevFlags.Manifests) == 0) && len(c.ExtraParams) == 0
+}
+
+// ResetCustomizations removes the devFlags and extraParams of the component, and returns them.
+func (c *Component) ResetCustomizations() Customizations {
+	discarded := Customizations{DevFlags: c.DevFlags, ExtraParams: c.ExtraParams}
+	c.DevFlags = nil
+	c.ExtraParams = nil
+
+	return discarded
+}
+
 func (c *Component) Cleanup(_ context.Context, _ client.Client, _ metav1.Object, _ *dsciv1.DSCInitializationSpec) error {
 	// noop
 	return nil
 }
 
+// LogLevel is the verbosity of the logs of a component.
+// +kubebuilder:validation:Enum=Info;Debug
+type LogLevel string
+
+const (
+	// LogLevelInfo logs informational messages and errors.
+	LogLevelInfo LogLevel = "Info"
+	// LogLevelDebug also logs debug messages.
+	LogLevelDebug LogLevel = "Debug"
+)
+
+// CRDUpdatePolicy tells how CRDs shipped with the manifests of a component are applied.
+// +kubebuilder:validation:Enum=Always;IfCompatible;Never
+type CRDUpdatePolicy string
+
+const (
+	// CRDUpdateAlways creates and updates CRDs, even when the changes are incompatible.
+	CRDUpdateAlways CRDUpdatePolicy = "Always"
+	// CRDUpdateIfCompatible creates CRDs, but only updates them when the changes are compatible.
+	CRDUpdateIfCompatible CRDUpdatePolicy = "IfCompatible"
+	// CRDUpdateNever leaves CRDs alone.
+	CRDUpdateNever CRDUpdatePolicy = "Never"
+)
+
+// AdoptionPolicy tells how existing resources of a component which are not managed by the operator are handled.
+// +kubebuilder:validation:Enum=Adopt;Skip;Fail
+type AdoptionPolicy string
+
+const (
+	// AdoptExisting takes over existing resources.
+	AdoptExisting AdoptionPolicy = "Adopt"
+	// SkipExisting leaves existing resources alone.
+	SkipExisting AdoptionPolicy = "Skip"
+	// FailOnExisting fails the reconciliation of the component.
+	FailOnExisting AdoptionPolicy = "Fail"
+)
+
+type SelfHealingAction string
+
+const (
+	// RestartPods deletes pods of the unhealthy Deployment, so they get recreated.
+	RestartPods SelfHealingAction = "RestartPods"
+	// ReapplyManifests triggers a reconciliation of the DataScienceCluster, applying manifests of all components again.
+	ReapplyManifests SelfHealingAction = "ReapplyManifests"
+	// RecreateDeployment deletes the unhealthy Deployment, so it gets created again from the manifests.
+	RecreateDeployment SelfHealingAction = "RecreateDeployment"
+)
+
+// SelfHealing defines how the operator remediates Deployments of a component which are not available.
+// +kubebuilder:object:generate=true
+type SelfHealing struct {
+	// Set to one of the following values:
+	//
+	// - "RestartPods" : pods of the Deployment are deleted and recreated
+	//
+	// - "ReapplyManifests" : manifests are applied again, reverting any drift
+	//
+	// - "RecreateDeployment" : the Deployment is deleted and created again from the manifests
+	//
+	// +kubebuilder:validation:Enum=RestartPods;ReapplyManifests;RecreateDeployment
+	Action SelfHealingAction `json:"action"`
+	// Minutes a Deployment can be unavailable before the action is taken. The action is not repeated for the same period afterwards.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThresholdMinutes int32 `json:"unhealthyThresholdMinutes,omitempty"`
+}
+
+// ExternalSecret declares a Secret of the component which is synced from a SecretStore of External Secrets Operator.
+// +kubebuilder:object:generate=true
+type ExternalSecret struct {
+	// Name of the Secret created in the applications namespace.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// SecretStoreRef references the store, e.g. backed by Vault, holding the data.
+	SecretStoreRef SecretStoreRef `json:"secretStoreRef"`
+	// Data maps keys of the Secret to the references in the store.
+	// +kubebuilder:validation:MinItems=1
+	Data []ExternalSecretData `json:"data"`
+	// RefreshInterval is the amount of time before the Secret is synced again from the store, e.g. "1h".
+	// +kubebuilder:default:="1h"
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+}
+
+// ApplySettings defines how resources of a component are applied: in batches, reporting progress in the Ready condition
+// of the component while it is not ready yet, and at a limited rate of requests to the API server.
+// +kubebuilder:object:generate=true
+type ApplySettings struct {
+	// Number of resources applied between progress reports. Progress is not reported when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+	// Average number of requests per second sent to the API server while applying resources. Not limited when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+	// Number of requests which can be sent at once above the average rate, qps when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+type SecretStoreRef struct {
+	// Name of the store.
+	Name string `json:"name"`
+	// Kind of the store, either namespaced "SecretStore" in the applications namespace or "ClusterSecretStore".
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +kubebuilder:default:=ClusterSecretStore
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+type ExternalSecretData struct {
+	// SecretKey is the key in the Secret.
+	SecretKey string `json:"secretKey"`
+	// RemoteKey is the key, or path, of the data in the store.
+	RemoteKey string `json:"remoteKey"`
+	// Property of the data at the remote key, e.g. a field of a JSON object.
+	// +optional
+	Property string `json:"property,omitempty"`
+}
+
+// PodDisruptionBudget limits how many pods of a platform component Deployment can be taken down at once by voluntary
+// disruptions, e.g. node drains during cluster upgrades. Budgets are only created for Deployments running more than one
+// replica. When neither field is set, one pod at a time can be disrupted. Set maxUnavailable to "100%" to not limit
+// disruptions.
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="only one of minAvailable and maxUnavailable can be set"
+type PodDisruptionBudget struct {
+	// Number or percentage of pods which must stay available.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Number or percentage of pods which can be unavailable.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ModelControllerDeployment is the name of the Deployment of odh-model-controller, shared by model serving components.
+const ModelControllerDeployment = "odh-model-controller"
+
+// ModelController configures reconcilers of odh-model-controller. Reconcilers not set keep the default of odh-model-controller.
+// +kubebuilder:object:generate=true
+type ModelController struct {
+	// Routes enables creation of OpenShift Routes exposing served models outside of the cluster.
+	// +optional
+	Routes *bool `json:"routes,omitempty"`
+	// AuthInjection enables protection of served models requesting token authentication, with Authorino.
+	// +optional
+	AuthInjection *bool `json:"authInjection,omitempty"`
+	// Metrics enables creation of ServiceMonitors and dashboards of served models.
+	// +optional
+	Metrics *bool `json:"metrics,omitempty"`
+}
+
+// Env returns the environment variables of odh-model-controller enabling or disabling its reconcilers.
+func (m *ModelController) Env() map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	env := map[string]string{}
+	for name, enabled := range map[string]*bool{
+		"ENABLE_ROUTE_RECONCILER":   m.Routes,
+		"ENABLE_AUTH_RECONCILER":    m.AuthInjection,
+		"ENABLE_METRICS_RECONCILER": m.Metrics,
+	} {
+		if enabled != nil {
+			env[name] = strconv.FormatBool(*enabled)
+		}
+	}
+
+	return env
+}
+
 // DevFlags defines list of fields that can be used by developers to test customizations. This is not recommended
 // to be used in production environment.
 // +kubebuilder:object:generate=true
@@ -78,6 +378,137 @@ type ManifestsConfig struct {
 	// +kubebuilder:default:=""
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3
 	SourcePath string `json:"sourcePath,omitempty"`
+
+	// digest is the expected sha256 digest of the tarball, e.g. sha256:<hex>. Manifests not matching it are rejected.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4
+	Digest string `json:"digest,omitempty"`
+
+	// allowDigestMismatch deploys manifests not matching the digest anyway, recording them as unverified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
+	AllowDigestMismatch bool `json:"allowDigestMismatch,omitempty"`
+}
+
+// RBACProvider is implemented by components requiring the operator to hold additional permissions only while they are enabled.
+// These permissions are granted to the operator when the component is Managed and revoked once it is Removed,
+// instead of being part of the operator's ClusterRole.
+type RBACProvider interface {
+	OperatorPolicyRules() []rbacv1.PolicyRule
+}
+
+// Persona is a kind of data science user, whose permissions are aggregated into a ClusterRole of the same name.
+type Persona string
+
+const (
+	// PersonaAdmin manages the data science platform across all projects.
+	PersonaAdmin Persona = "odh-admin"
+	// PersonaProjectAdmin manages data science resources of the projects it is granted access to.
+	PersonaProjectAdmin Persona = "odh-project-admin"
+	// PersonaUser creates and runs data science workloads in the projects it is granted access to.
+	PersonaUser Persona = "odh-user"
+)
+
+// Personas lists all personas the operator ships a ClusterRole for.
+var Personas = []Persona{PersonaAdmin, PersonaProjectAdmin, PersonaUser}
+
+var (
+	// ManageVerbs grant full control over resources.
+	ManageVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+	// EditVerbs grant creation and modification of resources.
+	EditVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	// ViewVerbs grant read access to resources.
+	ViewVerbs = []string{"get", "list", "watch"}
+)
+
+// PersonaRBACProvider is implemented by components contributing permissions on their resources to the personas' ClusterRoles.
+// The rules are created as a ClusterRole per persona while the component is Managed, aggregated into the ClusterRole of the persona.
+type PersonaRBACProvider interface {
+	PersonaPolicyRules() map[Persona][]rbacv1.PolicyRule
+}
+
+// ReadinessGater is implemented by components whose readiness also depends on resources reconciled by other operators,
+// e.g. a custom resource created for the component. The returned gates describe what is not ready yet.
+type ReadinessGater interface {
+	ReadinessGates(ctx context.Context, cli client.Reader) ([]string, error)
+}
+
+// LoggingProvider is implemented by components whose Deployments can log at the level set in logLevel. Each returned
+// entry tells how a Deployment reads its log level.
+type LoggingProvider interface {
+	Logging() []DeploymentLogging
+}
+
+// DeploymentLogging tells through which environment variable or command line flag a Deployment reads its log level,
+// given in lower case, e.g. "debug".
+type DeploymentLogging struct {
+	Deployment string
+	Env        string
+	Flag       string
+}
+
+// ZapLogging returns the logging of Deployments running controllers built with controller-runtime, which read their
+// log level from the zap-log-level flag.
+func ZapLogging(deployments ...string) []DeploymentLogging {
+	logging := make([]DeploymentLogging, 0, len(deployments))
+	for _, deployment := range deployments {
+		logging = append(logging, DeploymentLogging{Deployment: deployment, Flag: "zap-log-level"})
+	}
+
+	return logging
+}
+
+// WorkloadCounter is implemented by components serving user workloads, e.g. models or pipelines, which stop working
+// once the component is Removed. The returned workloads report the impact of removing the component.
+type WorkloadCounter interface {
+	Workloads(ctx context.Context, cli client.Reader) ([]Workloads, error)
+}
+
+// Workloads counts the resources of a kind served by a component.
+type Workloads struct {
+	Kind       string
+	Count      int
+	Namespaces int
+}
+
+func (w Workloads) String() string {
+	kind, namespaces := w.Kind, "namespaces"
+	if w.Count != 1 {
+		kind += "s"
+	}
+	if w.Namespaces == 1 {
+		namespaces = "namespace"
+	}
+
+	return fmt.Sprintf("%d %s across %d %s", w.Count, kind, w.Namespaces, namespaces)
+}
+
+// CountWorkloads counts the resources of the kind accepted by match, all of them when match is nil.
+// Kinds whose CRD is not installed have no workloads.
+func CountWorkloads(ctx context.Context, cli client.Reader, kind schema.GroupVersionKind, match func(*unstructured.Unstructured) bool) (Workloads, error) {
+	workloads := Workloads{Kind: kind.Kind}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+	if err := cli.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return workloads, nil
+		}
+		return workloads, fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+	}
+
+	namespaces := map[string]struct{}{}
+	for i := range list.Items {
+		if match != nil && !match(&list.Items[i]) {
+			continue
+		}
+		workloads.Count++
+		namespaces[list.Items[i].GetNamespace()] = struct{}{}
+	}
+	workloads.Namespaces = len(namespaces)
+
+	return workloads, nil
 }
 
 type ComponentInterface interface {
@@ -87,6 +518,15 @@ type ComponentInterface interface {
 	Cleanup(ctx context.Context, cli client.Client, owner metav1.Object, DSCISpec *dsciv1.DSCInitializationSpec) error
 	GetComponentName() string
 	GetManagementState() operatorv1.ManagementState
+	SetManagementState(state operatorv1.ManagementState)
+	GetSelfHealing() *SelfHealing
+	GetExternalSecrets() []ExternalSecret
+	GetApplySettings() *ApplySettings
+	GetLogLevel() LogLevel
+	GetCRDUpdatePolicy() CRDUpdatePolicy
+	GetSecurityContext() *SecurityContext
+	GetAdoptionPolicy() AdoptionPolicy
+	ResetCustomizations() Customizations
 	OverrideManifests(ctx context.Context, platform cluster.Platform) error
 	UpdatePrometheusConfig(cli client.Client, logger logr.Logger, enable bool, component string) error
 }
@@ -195,3 +635,19 @@ func (c *Component) UpdatePrometheusConfig(_ client.Client, logger logr.Logger,
 
 	return err
 }
+
+// OAuthClientProvider is implemented by components whose UI authenticates users with OpenShift SSO. The operator
+// creates the OAuthClients of the component while it is Managed, and rotates their secrets.
+type OAuthClientProvider interface {
+	OAuthClients(platform cluster.Platform) []OAuthClient
+}
+
+// OAuthClient is an OpenShift OAuthClient of a component.
+type OAuthClient struct {
+	// Name of the OAuthClient. Its secret is stored under the "secret" key of the "<name>-generated" Secret in the
+	// applications namespace.
+	Name string
+	// RedirectURIs are Go templates of the redirect URIs, given the applications .Namespace and the cluster ingress
+	// .Domain, e.g. "https://odh-dashboard-{{.Namespace}}.{{.Domain}}".
+	RedirectURIs []string
+}
@@ -8,11 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
-	"github.com/go-logr/logr"
 	operatorv1 "github.com/openshift/api/operator/v1"
-	corev1 "k8s.io/api/core/v1"
-	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,13 +33,49 @@ var (
 	DefaultPath             = ""
 )
 
-// Verifies that Dashboard implements ComponentInterface.
-var _ components.ComponentInterface = (*Dashboard)(nil)
+// ConfigName is the OdhDashboardConfig deployed with the dashboard in the applications namespace.
+const ConfigName = "odh-dashboard-config"
+
+// Verifies that Dashboard implements ComponentInterface, LoggingProvider and OAuthClientProvider.
+var (
+	_ components.ComponentInterface  = (*Dashboard)(nil)
+	_ components.LoggingProvider     = (*Dashboard)(nil)
+	_ components.OAuthClientProvider = (*Dashboard)(nil)
+)
 
 // Dashboard struct holds the configuration for the Dashboard component.
 // +kubebuilder:object:generate=true
 type Dashboard struct {
 	components.Component `json:""`
+	// PodDisruptionBudget of the dashboard, one pod at a time can be disrupted when not set.
+	// +optional
+	PodDisruptionBudget *components.PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// Access maps groups of users to the personas of the platform. When set, the operator manages the groups of the
+	// dashboard configuration and binds the groups to the personas' ClusterRoles, instead of the admin group of the platform.
+	// +optional
+	Access *Access `json:"access,omitempty"`
+	// ModelServerSizes offered to users deploying model servers, with KServe or ModelMesh. When set, the operator
+	// manages the model server sizes of the dashboard configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ModelServerSizes []components.Size `json:"modelServerSizes,omitempty"`
+}
+
+// Access lists the groups of users granted each persona of the platform.
+// +kubebuilder:object:generate=true
+type Access struct {
+	// AdminGroups administer the platform, they are bound to the odh-admin ClusterRole across the cluster
+	// and are the admin groups of the dashboard.
+	// +optional
+	AdminGroups []string `json:"adminGroups,omitempty"`
+	// ProjectAdminGroups are bound to the odh-project-admin ClusterRole in each data science project.
+	// +optional
+	ProjectAdminGroups []string `json:"projectAdminGroups,omitempty"`
+	// AllowedGroups are bound to the odh-user ClusterRole in each data science project
+	// and are the groups allowed to log in to the dashboard.
+	// +optional
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
 }
 
 func (d *Dashboard) Init(ctx context.Context, platform cluster.Platform) error {
@@ -82,12 +116,33 @@ func (d *Dashboard) GetComponentName() string {
 	return ComponentNameUpstream
 }
 
+// Logging returns the log level variable of the dashboard backend, named after the platform.
+func (d *Dashboard) Logging() []components.DeploymentLogging {
+	return []components.DeploymentLogging{
+		{Deployment: "odh-dashboard", Env: "LOG_LEVEL"},
+		{Deployment: ComponentNameDownstream, Env: "LOG_LEVEL"},
+	}
+}
+
+// OAuthClients returns the OAuthClient the dashboard logs users in with, redirecting to its route.
+func (d *Dashboard) OAuthClients(platform cluster.Platform) []components.OAuthClient {
+	routeName := "odh-dashboard"
+	if platform == cluster.SelfManagedRhods || platform == cluster.ManagedRhods {
+		routeName = ComponentNameDownstream
+	}
+
+	return []components.OAuthClient{{
+		Name:         "dashboard-oauth-client",
+		RedirectURIs: []string{"https://" + routeName + "-{{.Namespace}}.{{.Domain}}"},
+	}}
+}
+
 func (d *Dashboard) ReconcileComponent(ctx context.Context,
 	cli client.Client,
 	owner metav1.Object,
 	dscispec *dsciv1.DSCInitializationSpec,
 	platform cluster.Platform,
-	currentComponentExist bool,
+	_ bool,
 ) error {
 	entryPath := DefaultPath
 	l := logf.FromContext(ctx)
@@ -95,12 +150,8 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 	monitoringEnabled := dscispec.Monitoring.ManagementState == operatorv1.Managed
 
 	if enabled {
-		// 1. cleanup OAuth client related secret and CR if dashboard is in 'installed false' status
-		if err := d.cleanOauthClient(ctx, cli, dscispec, currentComponentExist, l); err != nil {
-			return err
-		}
+		// 1. Download manifests and update paths
 		if d.DevFlags != nil && len(d.DevFlags.Manifests) != 0 {
-			// Download manifests and update paths
 			if err := d.OverrideManifests(ctx, platform); err != nil {
 				return err
 			}
@@ -125,13 +176,26 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 		if err != nil {
 			return errors.New("failed to set variable for extraParamsMap")
 		}
+		// admin groups managed through access take over the one of the platform
+		if d.Access != nil && len(d.Access.AdminGroups) != 0 {
+			extraParamsMap["admin_groups"] = strings.Join(d.Access.AdminGroups, ",")
+		}
 
 		// 4. update params.env regardless devFlags is provided of not
 		if err := deploy.ApplyParams(entryPath, nil, extraParamsMap); err != nil {
 			return fmt.Errorf("failed to update params.env  from %s : %w", entryPath, err)
 		}
+
+		// 5. user provided parameters take precedence over the ones set by the operator
+		if err := deploy.ApplyExtraParams(ComponentNameUpstream, d.ExtraParams, entryPath); err != nil {
+			return err
+		}
 	}
 
+	// keep the dashboard reachable while nodes are drained, its OAuthClient is managed by the OAuthClient controller
+	pdbCtx := deploy.WithPodDisruptionBudget(ctx, d.PodDisruptionBudget, "odh-dashboard", ComponentNameDownstream)
+	pdbCtx = deploy.WithOAuthClients(pdbCtx, d.OAuthClients(platform))
+
 	// common: Deploy odh-dashboard manifests
 	// TODO: check if we can have the same component name odh-dashboard for both, or still keep rhods-dashboard for RHOAI
 	switch platform {
@@ -141,7 +205,7 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 			return fmt.Errorf("failed to create access-secret for anaconda: %w", err)
 		}
 		// Deploy RHOAI manifests
-		if err := deploy.DeployManifestsFromPath(ctx, cli, owner, entryPath, dscispec.ApplicationsNamespace, ComponentNameDownstream, enabled); err != nil {
+		if err := deploy.DeployManifestsFromPath(pdbCtx, cli, owner, entryPath, dscispec.ApplicationsNamespace, ComponentNameDownstream, enabled); err != nil {
 			return fmt.Errorf("failed to apply manifests from %s: %w", PathDownstream, err)
 		}
 		l.Info("apply manifests done")
@@ -169,7 +233,7 @@ func (d *Dashboard) ReconcileComponent(ctx context.Context,
 
 	default:
 		// Deploy ODH manifests
-		if err := deploy.DeployManifestsFromPath(ctx, cli, owner, entryPath, dscispec.ApplicationsNamespace, ComponentNameUpstream, enabled); err != nil {
+		if err := deploy.DeployManifestsFromPath(pdbCtx, cli, owner, entryPath, dscispec.ApplicationsNamespace, ComponentNameUpstream, enabled); err != nil {
 			return err
 		}
 		l.Info("apply manifests done")
@@ -215,30 +279,3 @@ func updateKustomizeVariable(ctx context.Context, cli client.Client, platform cl
 		"section-title": sectionTitle,
 	}, nil
 }
-
-func (d *Dashboard) cleanOauthClient(ctx context.Context, cli client.Client, dscispec *dsciv1.DSCInitializationSpec, currentComponentExist bool, l logr.Logger) error {
-	// Remove previous oauth-client secrets
-	// Check if component is going from state of `Not Installed --> Installed`
-	// Assumption: Component is currently set to enabled
-	name := "dashboard-oauth-client"
-	if !currentComponentExist {
-		l.Info("Cleanup any left secret")
-		// Delete client secrets from previous installation
-		oauthClientSecret := &corev1.Secret{}
-		err := cli.Get(ctx, client.ObjectKey{
-			Namespace: dscispec.ApplicationsNamespace,
-			Name:      name,
-		}, oauthClientSecret)
-		if err != nil {
-			if !k8serr.IsNotFound(err) {
-				return fmt.Errorf("error getting secret %s: %w", name, err)
-			}
-		} else {
-			if err := cli.Delete(ctx, oauthClientSecret); err != nil {
-				return fmt.Errorf("error deleting secret %s: %w", name, err)
-			}
-			l.Info("successfully deleted secret", "secret", name)
-		}
-	}
-	return nil
-}
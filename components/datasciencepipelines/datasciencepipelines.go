@@ -11,7 +11,7 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,6 +21,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
@@ -32,13 +33,84 @@ var (
 	ArgoWorkflowCRD = "workflows.argoproj.io"
 )
 
-// Verifies that Dashboard implements ComponentInterface.
-var _ components.ComponentInterface = (*DataSciencePipelines)(nil)
+// Verifies that DataSciencePipelines implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*DataSciencePipelines)(nil)
+	_ components.LoggingProvider     = (*DataSciencePipelines)(nil)
+	_ components.PersonaRBACProvider = (*DataSciencePipelines)(nil)
+	_ components.WorkloadCounter     = (*DataSciencePipelines)(nil)
+)
 
 // DataSciencePipelines struct holds the configuration for the DataSciencePipelines component.
 // +kubebuilder:object:generate=true
 type DataSciencePipelines struct {
 	components.Component `json:""`
+	// PipelineServer configures the pipeline server created in every data science project, sparing users its setup.
+	// No pipeline server is created when not set.
+	// +optional
+	PipelineServer *PipelineServerSpec `json:"pipelineServer,omitempty"`
+}
+
+// ObjectStorageProvisioner tells how object storage of pipeline servers is provisioned.
+// +kubebuilder:validation:Enum=DataConnection;ObjectBucketClaim
+type ObjectStorageProvisioner string
+
+const (
+	// DataConnectionProvisioner uses a data connection of the project.
+	DataConnectionProvisioner ObjectStorageProvisioner = "DataConnection"
+	// ObjectBucketClaimProvisioner claims a bucket in the project.
+	ObjectBucketClaimProvisioner ObjectStorageProvisioner = "ObjectBucketClaim"
+)
+
+// PipelineServerSpec defines the pipeline server created in data science projects.
+// +kubebuilder:object:generate=true
+type PipelineServerSpec struct {
+	// Set to Managed to create a pipeline server in each data science project which has none. Pipeline servers are
+	// only created once per project: changing or deleting them afterwards is left to users.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// Name of the DataSciencePipelinesApplication created in data science projects.
+	// +kubebuilder:default=dspa
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+	// Version of Data Science Pipelines run by the pipeline servers.
+	// +kubebuilder:validation:Enum=v1;v2
+	// +kubebuilder:default=v2
+	DSPVersion string `json:"dspVersion,omitempty"`
+	// Object storage the pipeline servers store artifacts in.
+	ObjectStorage PipelineObjectStorage `json:"objectStorage"`
+	// Storage of the MariaDB database deployed with each pipeline server. The claim is created along with the pipeline
+	// server, and expanded afterwards when the size is increased. It is deleted with the pipeline server by users.
+	// The database is provisioned by data-science-pipelines-operator with its default storage when not set.
+	// +optional
+	Database *components.PersistentStorage `json:"database,omitempty"`
+}
+
+// DatabaseClaimName returns the name of the PersistentVolumeClaim data-science-pipelines-operator mounts in the
+// MariaDB database of the pipeline server.
+func (s *PipelineServerSpec) DatabaseClaimName() string {
+	return "mariadb-" + s.Name
+}
+
+// PipelineObjectStorage defines how object storage of a pipeline server is provisioned.
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="self.provisioner != 'DataConnection' || has(self.dataConnection)",message="dataConnection is required by the DataConnection provisioner"
+// +kubebuilder:validation:XValidation:rule="self.provisioner != 'ObjectBucketClaim' || has(self.storageClassName)",message="storageClassName is required by the ObjectBucketClaim provisioner"
+type PipelineObjectStorage struct {
+	// Set to one of the following values:
+	//
+	// - "DataConnection" : the data connection Secret of the given name in the project is used, e.g. one added by a DataConnection
+	//
+	// - "ObjectBucketClaim" : a bucket is claimed in the project from the given storage class, e.g. of OpenShift Data Foundation
+	Provisioner ObjectStorageProvisioner `json:"provisioner"`
+	// Name of the data connection Secret used by the DataConnection provisioner, e.g. "aws-connection-shared-models".
+	// +optional
+	DataConnection string `json:"dataConnection,omitempty"`
+	// Storage class buckets are claimed from by the ObjectBucketClaim provisioner.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
 }
 
 func (d *DataSciencePipelines) Init(ctx context.Context, _ cluster.Platform) error {
@@ -92,6 +164,32 @@ func (d *DataSciencePipelines) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of data-science-pipelines-operator. Pipeline servers it deploys keep their level.
+func (d *DataSciencePipelines) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("data-science-pipelines-operator-controller-manager")
+}
+
+// Workloads returns the pipeline servers of data science projects.
+func (d *DataSciencePipelines) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	pipelineServers, err := components.CountWorkloads(ctx, cli, gvk.DataSciencePipelinesApplication, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{pipelineServers}, nil
+}
+
+// PersonaPolicyRules returns permissions on DataSciencePipelines resources contributed to the personas' ClusterRoles.
+func (d *DataSciencePipelines) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	applications := []string{"datasciencepipelinesapplications"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"datasciencepipelinesapplications.opendatahub.io"}, Resources: applications, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"datasciencepipelinesapplications.opendatahub.io"}, Resources: applications, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"datasciencepipelinesapplications.opendatahub.io"}, Resources: applications, Verbs: components.ViewVerbs}},
+	}
+}
+
 func (d *DataSciencePipelines) ReconcileComponent(ctx context.Context,
 	cli client.Client,
 	owner metav1.Object,
@@ -115,6 +213,10 @@ func (d *DataSciencePipelines) ReconcileComponent(ctx context.Context,
 		if err := UnmanagedArgoWorkFlowExists(ctx, cli); err != nil {
 			return err
 		}
+
+		if err := deploy.ApplyExtraParams(ComponentName, d.ExtraParams, Path); err != nil {
+			return err
+		}
 	}
 
 	// new overlay
@@ -153,7 +255,9 @@ func (d *DataSciencePipelines) ReconcileComponent(ctx context.Context,
 
 func UnmanagedArgoWorkFlowExists(ctx context.Context,
 	cli client.Client) error {
-	workflowCRD := &apiextensionsv1.CustomResourceDefinition{}
+	// only labels are needed, CRDs are cached as metadata
+	workflowCRD := &metav1.PartialObjectMetadata{}
+	workflowCRD.SetGroupVersionKind(gvk.CustomResourceDefinition)
 	if err := cli.Get(ctx, client.ObjectKey{Name: ArgoWorkflowCRD}, workflowCRD); err != nil {
 		if k8serr.IsNotFound(err) {
 			return nil
@@ -165,8 +269,10 @@ func UnmanagedArgoWorkFlowExists(ctx context.Context,
 	if odhLabelExists && odhLabelValue == "true" {
 		return nil
 	}
-	return fmt.Errorf("%s CRD already exists but not deployed by this operator. "+
-		"Remove existing Argo workflows or set `spec.components.datasciencepipelines.managementState` to Removed to proceed ", ArgoWorkflowCRD)
+	return status.NewRemediationError(status.RemediationConflictingCRD,
+		"Remove the existing Argo Workflows installation, or set datasciencepipelines managementState to Removed",
+		fmt.Errorf("%s CRD already exists but not deployed by this operator. "+
+			"Remove existing Argo workflows or set `spec.components.datasciencepipelines.managementState` to Removed to proceed ", ArgoWorkflowCRD))
 }
 
 func SetExistingArgoCondition(conditions *[]conditionsv1.Condition, reason, message string) {
@@ -20,12 +20,19 @@ limitations under the License.
 
 package datasciencepipelines
 
-import ()
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSciencePipelines) DeepCopyInto(out *DataSciencePipelines) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
+	if in.PipelineServer != nil {
+		in, out := &in.PipelineServer, &out.PipelineServer
+		*out = new(PipelineServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSciencePipelines.
@@ -37,3 +44,39 @@ func (in *DataSciencePipelines) DeepCopy() *DataSciencePipelines {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineObjectStorage) DeepCopyInto(out *PipelineObjectStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineObjectStorage.
+func (in *PipelineObjectStorage) DeepCopy() *PipelineObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineServerSpec) DeepCopyInto(out *PipelineServerSpec) {
+	*out = *in
+	out.ObjectStorage = in.ObjectStorage
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(components.PersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineServerSpec.
+func (in *PipelineServerSpec) DeepCopy() *PipelineServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
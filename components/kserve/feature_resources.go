@@ -18,6 +18,8 @@ var Resources = struct {
 	InstallDir string
 	// GatewaysDir is the path to the Serving Istio gateways templates.
 	GatewaysDir string
+	// DomainsDir is the path to the templates mapping custom domains to served models.
+	DomainsDir string
 	// Location specifies the file system that contains the templates to be used.
 	Location fs.FS
 	// BaseDir is the path to the base of the embedded FS
@@ -26,6 +28,7 @@ var Resources = struct {
 	ServiceMeshDir: path.Join(baseDir, "servicemesh"),
 	InstallDir:     path.Join(baseDir, "serving-install"),
 	GatewaysDir:    path.Join(baseDir, "servicemesh", "routing"),
+	DomainsDir:     path.Join(baseDir, "serving-domains"),
 	Location:       kserveEmbeddedFS,
 	BaseDir:        baseDir,
 }
@@ -9,7 +9,10 @@ import (
 	"strings"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -17,7 +20,9 @@ import (
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
 var (
@@ -29,8 +34,14 @@ var (
 	ServerlessOperator     = "serverless-operator"
 )
 
-// Verifies that Kserve implements ComponentInterface.
-var _ components.ComponentInterface = (*Kserve)(nil)
+// Verifies that Kserve implements ComponentInterface, LoggingProvider, PersonaRBACProvider, ReadinessGater and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*Kserve)(nil)
+	_ components.LoggingProvider     = (*Kserve)(nil)
+	_ components.PersonaRBACProvider = (*Kserve)(nil)
+	_ components.ReadinessGater      = (*Kserve)(nil)
+	_ components.WorkloadCounter     = (*Kserve)(nil)
+)
 
 // +kubebuilder:validation:Pattern=`^(Serverless|RawDeployment)$`
 type DefaultDeploymentMode string
@@ -54,6 +65,93 @@ type Kserve struct {
 	// This field is optional. If no default deployment mode is specified, Kserve will use Serverless mode.
 	// +kubebuilder:validation:Enum=Serverless;RawDeployment
 	DefaultDeploymentMode DefaultDeploymentMode `json:"defaultDeploymentMode,omitempty"`
+	// ModelController configures reconcilers of odh-model-controller. When also set for ModelMeshServing, both must be equal.
+	// +optional
+	ModelController *components.ModelController `json:"modelController,omitempty"`
+	// InferenceServiceDefaults are applied to InferenceServices created in the cluster, for fields they leave unset.
+	// +optional
+	InferenceServiceDefaults *InferenceServiceDefaults `json:"inferenceServiceDefaults,omitempty"`
+	// PodDisruptionBudget of the KServe controller, one pod at a time can be disrupted when not set.
+	// +optional
+	PodDisruptionBudget *components.PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// RateLimit of requests to models protected by the authorization capability, which requires Service Mesh and
+	// Authorino. Requests are not limited when not set.
+	// +optional
+	RateLimit *InferenceRateLimit `json:"rateLimit,omitempty"`
+	// InferenceGraph configures InferenceGraphs, chaining models, and the routers KServe deploys for them. The router
+	// configuration of the manifests is kept when not set.
+	// +optional
+	InferenceGraph *InferenceGraphConfig `json:"inferenceGraph,omitempty"`
+}
+
+// InferenceGraphConfig configures InferenceGraphs and their routers.
+// +kubebuilder:object:generate=true
+type InferenceGraphConfig struct {
+	// Set to Managed to configure the routers of InferenceGraphs, Removed to stop granting data science users and
+	// project admins access to InferenceGraphs.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// RouterImage is the image of the routers, e.g. mirrored for disconnected clusters. The image of the manifests is
+	// kept when not set.
+	// +optional
+	RouterImage string `json:"routerImage,omitempty"`
+	// Compute resources of the routers, for each CPU and memory request or limit set.
+	// +optional
+	Resources *ResourceDefaults `json:"resources,omitempty"`
+	// PropagateHeaders are forwarded by the routers to the nodes of the graph, in addition to the Authorization header
+	// which is always forwarded, for models requiring authentication to authorize requests going through the graph.
+	// +optional
+	PropagateHeaders []string `json:"propagateHeaders,omitempty"`
+}
+
+// InferenceRateLimit guards shared model servers from abusive clients.
+// +kubebuilder:object:generate=true
+type InferenceRateLimit struct {
+	// Requests per minute served by each predictor pod, further requests are rejected with HTTP 429 until the next minute.
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerMinute int32 `json:"requestsPerMinute"`
+}
+
+// RouteVisibility tells whether models are reachable from outside of the cluster.
+// +kubebuilder:validation:Enum=Exposed;ClusterLocal
+type RouteVisibility string
+
+const (
+	// Exposed models are reachable from outside of the cluster.
+	Exposed RouteVisibility = "Exposed"
+	// ClusterLocal models are only reachable from within the cluster.
+	ClusterLocal RouteVisibility = "ClusterLocal"
+)
+
+// InferenceServiceDefaults defines platform defaults of InferenceServices, applied by a mutating webhook on creation.
+// +kubebuilder:object:generate=true
+type InferenceServiceDefaults struct {
+	// Set to Managed to apply the defaults to new InferenceServices, Removed to stop applying them.
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	ManagementState operatorv1.ManagementState `json:"managementState,omitempty"`
+	// Name of the ServingRuntime or ClusterServingRuntime set on predictors which select none.
+	// +optional
+	Runtime string `json:"runtime,omitempty"`
+	// Compute resources set on predictors, for each resource they do not request or limit.
+	// +optional
+	Resources *ResourceDefaults `json:"resources,omitempty"`
+	// Whether models require authentication, set with the "security.opendatahub.io/enable-auth" annotation.
+	// +optional
+	EnableAuth *bool `json:"enableAuth,omitempty"`
+	// Visibility of the routes of models.
+	// +optional
+	RouteVisibility RouteVisibility `json:"routeVisibility,omitempty"`
+}
+
+// ResourceDefaults defines default compute resources of predictors.
+// +kubebuilder:object:generate=true
+type ResourceDefaults struct {
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
 }
 
 func (k *Kserve) Init(ctx context.Context, _ cluster.Platform) error {
@@ -110,6 +208,49 @@ func (k *Kserve) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the KServe controller. odh-model-controller is shared with ModelMesh and keeps its level.
+func (k *Kserve) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("kserve-controller-manager")
+}
+
+// Workloads returns the InferenceServices served by KServe, i.e. not by ModelMesh.
+func (k *Kserve) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	isvcs, err := components.CountWorkloads(ctx, cli, gvk.InferenceService, func(isvc *unstructured.Unstructured) bool {
+		return isvc.GetAnnotations()[annotations.DeploymentMode] != annotations.ModelMeshDeploymentMode
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{isvcs}, nil
+}
+
+// PersonaPolicyRules returns permissions on Kserve resources contributed to the personas' ClusterRoles.
+// InferenceGraphs are only granted to project admins and users while they are not Removed.
+func (k *Kserve) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	projectResources := []string{"inferenceservices", "inferencegraphs"}
+	if k.InferenceGraph != nil && k.InferenceGraph.ManagementState == operatorv1.Removed {
+		projectResources = []string{"inferenceservices"}
+	}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin: {
+			{
+				APIGroups: []string{"serving.kserve.io"},
+				Resources: []string{"inferenceservices", "inferencegraphs", "servingruntimes", "clusterservingruntimes"},
+				Verbs:     components.ManageVerbs,
+			},
+		},
+		components.PersonaProjectAdmin: {
+			{APIGroups: []string{"serving.kserve.io"}, Resources: append(projectResources, "servingruntimes"), Verbs: components.ManageVerbs},
+		},
+		components.PersonaUser: {
+			{APIGroups: []string{"serving.kserve.io"}, Resources: projectResources, Verbs: components.EditVerbs},
+			{APIGroups: []string{"serving.kserve.io"}, Resources: []string{"servingruntimes"}, Verbs: components.ViewVerbs},
+		},
+	}
+}
+
 func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -131,13 +272,17 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 				return err
 			}
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, k.ExtraParams, Path, DependentPath); err != nil {
+			return err
+		}
 	}
 
 	if err := k.configureServiceMesh(ctx, cli, owner, dscispec); err != nil {
 		return fmt.Errorf("failed configuring service mesh while reconciling kserve component. cause: %w", err)
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+	pdbCtx := deploy.WithPodDisruptionBudget(ctx, k.PodDisruptionBudget, "kserve-controller-manager")
+	if err := deploy.DeployManifestsFromPath(pdbCtx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		return fmt.Errorf("failed to apply manifests from %s : %w", Path, err)
 	}
 
@@ -147,6 +292,9 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 		if err := k.setupKserveConfig(ctx, cli, l, dscispec); err != nil {
 			return err
 		}
+		if err := k.configureInferenceGraphRouter(ctx, cli, dscispec); err != nil {
+			return err
+		}
 
 		// For odh-model-controller
 		if err := cluster.UpdatePodSecurityRolebinding(ctx, cli, dscispec.ApplicationsNamespace, "odh-model-controller"); err != nil {
@@ -154,7 +302,8 @@ func (k *Kserve) ReconcileComponent(ctx context.Context, cli client.Client,
 		}
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+	modelControllerCtx := deploy.WithDeploymentEnv(ctx, components.ModelControllerDeployment, k.ModelController.Env())
+	if err := deploy.DeployManifestsFromPath(modelControllerCtx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		if !strings.Contains(err.Error(), "spec.selector") || !strings.Contains(err.Error(), "field is immutable") {
 			// explicitly ignore error if error contains keywords "spec.selector" and "field is immutable" and return all other error.
 			return err
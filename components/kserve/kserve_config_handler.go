@@ -5,17 +5,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
@@ -54,6 +59,12 @@ func (k *Kserve) setupKserveConfig(ctx context.Context, cli client.Client, logge
 }
 
 func (k *Kserve) setDefaultDeploymentMode(ctx context.Context, cli client.Client, dscispec *dsciv1.DSCInitializationSpec, defaultmode DefaultDeploymentMode) error {
+	if defaultmode == RawDeployment && !featuregate.Enabled(featuregate.KServeRawDeployment) {
+		return status.NewRemediationError(status.RemediationFeatureGateDisabled,
+			fmt.Sprintf("Enable the %s feature gate in the OperatorConfig, or set Serving to Managed with Serverless mode", featuregate.KServeRawDeployment),
+			fmt.Errorf("RawDeployment mode of %s is disabled by feature gate %s", ComponentName, featuregate.KServeRawDeployment))
+	}
+
 	inferenceServiceConfigMap := &corev1.ConfigMap{}
 	err := cli.Get(ctx, client.ObjectKey{
 		Namespace: dscispec.ApplicationsNamespace,
@@ -119,6 +130,70 @@ func (k *Kserve) setDefaultDeploymentMode(ctx context.Context, cli client.Client
 	return nil
 }
 
+// configureInferenceGraphRouter sets the image, resources and propagated headers of the routers of InferenceGraphs in
+// the router configuration of KServe, read whenever an InferenceGraph is reconciled.
+func (k *Kserve) configureInferenceGraphRouter(ctx context.Context, cli client.Client, dscispec *dsciv1.DSCInitializationSpec) error {
+	if k.InferenceGraph == nil || k.InferenceGraph.ManagementState != operatorv1.Managed {
+		return nil
+	}
+
+	inferenceServiceConfigMap := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: dscispec.ApplicationsNamespace, Name: KserveConfigMapName}, inferenceServiceConfigMap); err != nil {
+		return fmt.Errorf("error getting configmap %v: %w", KserveConfigMapName, err)
+	}
+
+	routerData := map[string]interface{}{}
+	if router := inferenceServiceConfigMap.Data["router"]; router != "" {
+		if err := json.Unmarshal([]byte(router), &routerData); err != nil {
+			return fmt.Errorf("error retrieving value for key 'router' from configmap %s. %w", KserveConfigMapName, err)
+		}
+	}
+	if k.InferenceGraph.RouterImage != "" {
+		routerData["image"] = k.InferenceGraph.RouterImage
+	}
+	if resources := k.InferenceGraph.Resources; resources != nil {
+		for key, quantity := range map[string]resource.Quantity{
+			"cpuRequest":    resources.Requests[corev1.ResourceCPU],
+			"cpuLimit":      resources.Limits[corev1.ResourceCPU],
+			"memoryRequest": resources.Requests[corev1.ResourceMemory],
+			"memoryLimit":   resources.Limits[corev1.ResourceMemory],
+		} {
+			if !quantity.IsZero() {
+				routerData[key] = quantity.String()
+			}
+		}
+	}
+	propagate := []string{"Authorization"}
+	for _, header := range k.InferenceGraph.PropagateHeaders {
+		if !slices.ContainsFunc(propagate, func(h string) bool { return strings.EqualFold(h, header) }) {
+			propagate = append(propagate, header)
+		}
+	}
+	headers, _ := routerData["headers"].(map[string]interface{})
+	if headers == nil {
+		headers = map[string]interface{}{}
+	}
+	headers["propagate"] = propagate
+	routerData["headers"] = headers
+
+	routerDataBytes, err := json.MarshalIndent(routerData, "", " ")
+	if err != nil {
+		return fmt.Errorf("could not set values in configmap %s. %w", KserveConfigMapName, err)
+	}
+	if inferenceServiceConfigMap.Data["router"] == string(routerDataBytes) {
+		return nil
+	}
+	if inferenceServiceConfigMap.Data == nil {
+		inferenceServiceConfigMap.Data = map[string]string{}
+	}
+	inferenceServiceConfigMap.Data["router"] = string(routerDataBytes)
+	if err := cli.Update(ctx, inferenceServiceConfigMap); err != nil {
+		return fmt.Errorf("could not configure the router of InferenceGraphs for Kserve. %w", err)
+	}
+
+	return nil
+}
+
 func (k *Kserve) configureServerless(ctx context.Context, cli client.Client, logger logr.Logger, owner metav1.Object, instance *dsciv1.DSCInitializationSpec) error {
 	switch k.Serving.ManagementState {
 	case operatorv1.Unmanaged: // Bring your own CR
@@ -132,14 +207,18 @@ func (k *Kserve) configureServerless(ctx context.Context, cli client.Client, log
 
 	case operatorv1.Managed: // standard workflow to create CR
 		if instance.ServiceMesh == nil {
-			return errors.New("ServiceMesh needs to be configured and 'Managed' in DSCI CR, " +
-				"it is required by KServe serving")
+			return status.NewRemediationError(status.RemediationServiceMeshRequired,
+				"Set spec.serviceMesh.managementState to Managed in DSCInitialization",
+				errors.New("ServiceMesh needs to be configured and 'Managed' in DSCI CR, "+
+					"it is required by KServe serving"))
 		}
 
 		switch instance.ServiceMesh.ManagementState {
 		case operatorv1.Unmanaged, operatorv1.Removed:
-			return fmt.Errorf("ServiceMesh is currently set to '%s'. It needs to be set to 'Managed' in DSCI CR, "+
-				"as it is required by the KServe serving field", instance.ServiceMesh.ManagementState)
+			return status.NewRemediationError(status.RemediationServiceMeshRequired,
+				"Set spec.serviceMesh.managementState to Managed in DSCInitialization",
+				fmt.Errorf("ServiceMesh is currently set to '%s'. It needs to be set to 'Managed' in DSCI CR, "+
+					"as it is required by the KServe serving field", instance.ServiceMesh.ManagementState))
 		}
 
 		// check on dependent operators if all installed in cluster
@@ -169,16 +248,20 @@ func checkDependentOperators(ctx context.Context, cli client.Client) *multierror
 	if found, err := cluster.OperatorExists(ctx, cli, ServiceMeshOperator); err != nil {
 		multiErr = multierror.Append(multiErr, err)
 	} else if !found {
-		err = fmt.Errorf("operator %s not found. Please install the operator before enabling %s component",
-			ServiceMeshOperator, ComponentName)
+		err = status.NewRemediationError(status.RemediationMissingOperator,
+			"Install the Red Hat OpenShift Service Mesh operator from OperatorHub",
+			fmt.Errorf("operator %s not found. Please install the operator before enabling %s component",
+				ServiceMeshOperator, ComponentName))
 		multiErr = multierror.Append(multiErr, err)
 	}
 
 	if found, err := cluster.OperatorExists(ctx, cli, ServerlessOperator); err != nil {
 		multiErr = multierror.Append(multiErr, err)
 	} else if !found {
-		err = fmt.Errorf("operator %s not found. Please install the operator before enabling %s component",
-			ServerlessOperator, ComponentName)
+		err = status.NewRemediationError(status.RemediationMissingOperator,
+			"Install the Red Hat OpenShift Serverless operator from OperatorHub",
+			fmt.Errorf("operator %s not found. Please install the operator before enabling %s component",
+				ServerlessOperator, ComponentName))
 		multiErr = multierror.Append(multiErr, err)
 	}
 	return multiErr
@@ -1,9 +1,19 @@
 package kserve
 
 import (
+	"context"
+	"fmt"
 	"path"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/serverless"
@@ -48,6 +58,9 @@ func (k *Kserve) configureServerlessFeatures(dsciSpec *dsciv1.DSCInitializationS
 			)
 
 		servingGateway := feature.Define("serverless-serving-gateways").
+			EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+				return dsciSpec.RoutingEnabled(), nil
+			}).
 			Manifests(
 				manifest.Location(Resources.Location).
 					Include(
@@ -59,14 +72,75 @@ func (k *Kserve) configureServerlessFeatures(dsciSpec *dsciv1.DSCInitializationS
 				serverless.FeatureData.CertificateName.Define(&k.Serving).AsAction(),
 				serverless.FeatureData.Serving.Define(&k.Serving).AsAction(),
 				servicemesh.FeatureData.ControlPlane.Define(dsciSpec).AsAction(),
+				servicemesh.FeatureData.Values.Define(dsciSpec).AsAction(),
 			).
 			WithResources(serverless.ServingCertificateResource).
 			PreConditions(serverless.EnsureServerlessServingDeployed)
 
+		servingDomains := feature.Define("serverless-serving-domains").
+			EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+				return len(serverless.ServingDomains(&k.Serving)) > 0, nil
+			}).
+			Manifests(
+				manifest.Location(Resources.Location).
+					Include(
+						path.Join(Resources.DomainsDir),
+					),
+			).
+			WithData(serverless.FeatureData.Serving.Define(&k.Serving).AsAction()).
+			WithResources(serverless.ServingDomainCertificates).
+			PreConditions(serverless.EnsureServerlessServingDeployed)
+
 		return registry.Add(
 			servingDeployment,
 			istioSecretFiltering,
 			servingGateway,
+			servingDomains,
 		)
 	}
 }
+
+// ReadinessGates reports the KNativeServing created for Kserve as an unmet gate until the KNative Operator marks it Ready,
+// as models in Serverless mode cannot be served before.
+func (k *Kserve) ReadinessGates(ctx context.Context, cli client.Reader) ([]string, error) {
+	if k.Serving.ManagementState != operatorv1.Managed {
+		return nil, nil
+	}
+
+	knativeServing := &unstructured.Unstructured{}
+	knativeServing.SetGroupVersionKind(gvk.KnativeServing)
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: serverless.KnativeServingNamespace, Name: k.Serving.Name}, knativeServing); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return []string{fmt.Sprintf("KnativeServing %s/%s is not created yet", serverless.KnativeServingNamespace, k.Serving.Name)}, nil
+		}
+		return nil, err
+	}
+
+	ready, message := knativeServingReady(knativeServing)
+	if ready {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf("KnativeServing %s/%s is not Ready: %s", serverless.KnativeServingNamespace, k.Serving.Name, message)}, nil
+}
+
+// knativeServingReady tells whether the Ready condition of the KNativeServing is True, and its message otherwise.
+func knativeServingReady(knativeServing *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(knativeServing.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, isMap := item.(map[string]any)
+		if !isMap || condition["type"] != "Ready" {
+			continue
+		}
+		if condition["status"] == string(corev1.ConditionTrue) {
+			return true, ""
+		}
+		message, _ := condition["message"].(string)
+		if message == "" {
+			message, _ = condition["reason"].(string)
+		}
+		return false, message
+	}
+
+	return false, "no Ready condition reported yet"
+}
@@ -4,24 +4,37 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"slices"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
+// predictorAuthorizationPolicy is the name of the AuthorizationPolicy sending requests to model servers to the
+// authorization provider, in the namespace of the control plane or in each authorized namespace of the mesh.
+const predictorAuthorizationPolicy = "kserve-predictor"
+
 func (k *Kserve) configureServiceMesh(ctx context.Context, cli client.Client, owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec) error {
 	if dscispec.ServiceMesh != nil {
 		if dscispec.ServiceMesh.ManagementState == operatorv1.Managed && k.GetManagementState() == operatorv1.Managed {
 			serviceMeshInitializer := feature.ComponentFeaturesHandler(owner, k.GetComponentName(), dscispec.ApplicationsNamespace, k.defineServiceMeshFeatures(ctx, cli, dscispec))
-			return serviceMeshInitializer.Apply(ctx, cli)
+			if err := serviceMeshInitializer.Apply(ctx, cli); err != nil {
+				return err
+			}
+			return removeStaleAuthorizationPolicies(ctx, cli, dscispec)
 		}
 		if dscispec.ServiceMesh.ManagementState == operatorv1.Unmanaged && k.GetManagementState() == operatorv1.Managed {
 			return nil
@@ -44,7 +57,12 @@ func (k *Kserve) defineServiceMeshFeatures(ctx context.Context, cli client.Clien
 		}
 
 		if authorinoInstalled {
+			authorizationEnabled := func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+				return dscispec.AuthorizationEnabled(), nil
+			}
+
 			kserveExtAuthzErr := registry.Add(feature.Define("kserve-external-authz").
+				EnabledWhen(authorizationEnabled).
 				Manifests(
 					manifest.Location(Resources.Location).
 						Include(
@@ -61,12 +79,37 @@ func (k *Kserve) defineServiceMeshFeatures(ctx context.Context, cli client.Clien
 				).
 				WithData(
 					servicemesh.FeatureData.Authorization.All(dscispec)...,
+				).
+				WithData(
+					servicemesh.FeatureData.Authorization.Exemptions.Define(dscispec).AsAction(),
+					servicemesh.FeatureData.Authorization.AuthorizedNamespaces.Define(dscispec).AsAction(),
 				),
 			)
 
 			if kserveExtAuthzErr != nil {
 				return kserveExtAuthzErr
 			}
+
+			kserveRateLimitErr := registry.Add(feature.Define("kserve-predictor-ratelimit").
+				EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+					return k.RateLimit != nil && dscispec.AuthorizationEnabled(), nil
+				}).
+				Manifests(
+					manifest.Location(Resources.Location).
+						Include(
+							path.Join(Resources.ServiceMeshDir, "kserve-predictor-ratelimit.tmpl.yaml"),
+						),
+				).
+				Managed().
+				WithData(
+					feature.Entry("RateLimit", provider.ValueOf(k.RateLimit).Get),
+					servicemesh.FeatureData.ControlPlane.Define(dscispec).AsAction(),
+				),
+			)
+
+			if kserveRateLimitErr != nil {
+				return kserveRateLimitErr
+			}
 		} else {
 			ctrl.Log.Info("WARN: Authorino operator is not installed on the cluster, skipping authorization capability")
 		}
@@ -74,3 +117,37 @@ func (k *Kserve) defineServiceMeshFeatures(ctx context.Context, cli client.Clien
 		return nil
 	}
 }
+
+// removeStaleAuthorizationPolicies deletes the predictor AuthorizationPolicies which are not rendered anymore: the one
+// applying to the whole mesh once namespaces are exempted, and the ones of namespaces which are exempted or have left
+// the mesh, or all of them once no namespace is exempted anymore.
+func removeStaleAuthorizationPolicies(ctx context.Context, cli client.Client, dscispec *dsciv1.DSCInitializationSpec) error {
+	desired := []string{dscispec.ServiceMesh.ControlPlane.Namespace}
+	if len(servicemesh.AuthorizationExemptions(dscispec)) > 0 {
+		namespaces, err := servicemesh.AuthorizedNamespaces(ctx, cli, dscispec)
+		if err != nil {
+			return err
+		}
+		desired = namespaces
+	}
+
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(gvk.AuthorizationPolicy)
+	if err := cli.List(ctx, policies, client.MatchingLabels{labels.ODHAppPrefix + "/kserve": "true"}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list AuthorizationPolicies: %w", err)
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if policy.GetName() != predictorAuthorizationPolicy || slices.Contains(desired, policy.GetNamespace()) {
+			continue
+		}
+		if err := cli.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete AuthorizationPolicy of namespace %s: %w", policy.GetNamespace(), err)
+		}
+	}
+
+	return nil
+}
@@ -20,13 +20,106 @@ limitations under the License.
 
 package kserve
 
-import ()
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"k8s.io/api/core/v1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphConfig) DeepCopyInto(out *InferenceGraphConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagateHeaders != nil {
+		in, out := &in.PropagateHeaders, &out.PropagateHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphConfig.
+func (in *InferenceGraphConfig) DeepCopy() *InferenceGraphConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceRateLimit) DeepCopyInto(out *InferenceRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRateLimit.
+func (in *InferenceRateLimit) DeepCopy() *InferenceRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceDefaults) DeepCopyInto(out *InferenceServiceDefaults) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableAuth != nil {
+		in, out := &in.EnableAuth, &out.EnableAuth
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceDefaults.
+func (in *InferenceServiceDefaults) DeepCopy() *InferenceServiceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kserve) DeepCopyInto(out *Kserve) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
-	out.Serving = in.Serving
+	in.Serving.DeepCopyInto(&out.Serving)
+	if in.ModelController != nil {
+		in, out := &in.ModelController, &out.ModelController
+		*out = new(components.ModelController)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InferenceServiceDefaults != nil {
+		in, out := &in.InferenceServiceDefaults, &out.InferenceServiceDefaults
+		*out = new(InferenceServiceDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(components.PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(InferenceRateLimit)
+		**out = **in
+	}
+	if in.InferenceGraph != nil {
+		in, out := &in.InferenceGraph, &out.InferenceGraph
+		*out = new(InferenceGraphConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kserve.
@@ -38,3 +131,32 @@ func (in *Kserve) DeepCopy() *Kserve {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDefaults) DeepCopyInto(out *ResourceDefaults) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDefaults.
+func (in *ResourceDefaults) DeepCopy() *ResourceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -14,6 +15,7 @@ import (
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 )
 
@@ -22,8 +24,13 @@ var (
 	Path          = deploy.DefaultManifestPath + "/" + ComponentName + "/rhoai" // same path for both odh and rhoai
 )
 
-// Verifies that Kueue implements ComponentInterface.
-var _ components.ComponentInterface = (*Kueue)(nil)
+// Verifies that Kueue implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*Kueue)(nil)
+	_ components.LoggingProvider     = (*Kueue)(nil)
+	_ components.PersonaRBACProvider = (*Kueue)(nil)
+	_ components.WorkloadCounter     = (*Kueue)(nil)
+)
 
 // Kueue struct holds the configuration for the Kueue component.
 // +kubebuilder:object:generate=true
@@ -67,6 +74,41 @@ func (k *Kueue) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the Kueue controller.
+func (k *Kueue) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("kueue-controller-manager")
+}
+
+// Workloads returns the workloads admitted by Kueue.
+func (k *Kueue) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	workloads, err := components.CountWorkloads(ctx, cli, gvk.KueueWorkload, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{workloads}, nil
+}
+
+// PersonaPolicyRules returns permissions on Kueue resources contributed to the personas' ClusterRoles.
+func (k *Kueue) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin: {
+			{
+				APIGroups: []string{"kueue.x-k8s.io"},
+				Resources: []string{"clusterqueues", "resourceflavors", "workloadpriorityclasses", "localqueues", "workloads"},
+				Verbs:     components.ManageVerbs,
+			},
+		},
+		components.PersonaProjectAdmin: {
+			{APIGroups: []string{"kueue.x-k8s.io"}, Resources: []string{"localqueues", "workloads"}, Verbs: components.ManageVerbs},
+			{APIGroups: []string{"kueue.x-k8s.io"}, Resources: []string{"clusterqueues", "resourceflavors"}, Verbs: components.ViewVerbs},
+		},
+		components.PersonaUser: {
+			{APIGroups: []string{"kueue.x-k8s.io"}, Resources: []string{"localqueues", "workloads"}, Verbs: components.ViewVerbs},
+		},
+	}
+}
+
 func (k *Kueue) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -79,6 +121,9 @@ func (k *Kueue) ReconcileComponent(ctx context.Context, cli client.Client,
 				return err
 			}
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, k.ExtraParams, Path); err != nil {
+			return err
+		}
 	}
 	// Deploy Kueue Operator
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
@@ -10,13 +10,16 @@ import (
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
 var (
@@ -26,13 +29,20 @@ var (
 	DependentPath          = deploy.DefaultManifestPath + "/" + DependentComponentName + "/base"
 )
 
-// Verifies that Dashboard implements ComponentInterface.
-var _ components.ComponentInterface = (*ModelMeshServing)(nil)
+// Verifies that ModelMeshServing implements ComponentInterface, LoggingProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface = (*ModelMeshServing)(nil)
+	_ components.LoggingProvider    = (*ModelMeshServing)(nil)
+	_ components.WorkloadCounter    = (*ModelMeshServing)(nil)
+)
 
 // ModelMeshServing struct holds the configuration for the ModelMeshServing component.
 // +kubebuilder:object:generate=true
 type ModelMeshServing struct {
 	components.Component `json:""`
+	// ModelController configures reconcilers of odh-model-controller. When also set for Kserve, both must be equal.
+	// +optional
+	ModelController *components.ModelController `json:"modelController,omitempty"`
 }
 
 func (m *ModelMeshServing) Init(ctx context.Context, _ cluster.Platform) error {
@@ -100,6 +110,23 @@ func (m *ModelMeshServing) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the ModelMesh controller. odh-model-controller is shared with KServe and keeps its level.
+func (m *ModelMeshServing) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("modelmesh-controller")
+}
+
+// Workloads returns the InferenceServices served by ModelMesh.
+func (m *ModelMeshServing) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	isvcs, err := components.CountWorkloads(ctx, cli, gvk.InferenceService, func(isvc *unstructured.Unstructured) bool {
+		return isvc.GetAnnotations()[annotations.DeploymentMode] == annotations.ModelMeshDeploymentMode
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{isvcs}, nil
+}
+
 func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 	cli client.Client,
 	owner metav1.Object,
@@ -127,6 +154,10 @@ func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 			"prometheus-custom"); err != nil {
 			return err
 		}
+
+		if err := deploy.ApplyExtraParams(ComponentName, m.ExtraParams, Path, DependentPath); err != nil {
+			return err
+		}
 	}
 
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, Path, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
@@ -140,7 +171,8 @@ func (m *ModelMeshServing) ReconcileComponent(ctx context.Context,
 			return err
 		}
 	}
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
+	modelControllerCtx := deploy.WithDeploymentEnv(ctx, components.ModelControllerDeployment, m.ModelController.Env())
+	if err := deploy.DeployManifestsFromPath(modelControllerCtx, cli, owner, DependentPath, dscispec.ApplicationsNamespace, m.GetComponentName(), enabled); err != nil {
 		// explicitly ignore error if error contains keywords "spec.selector" and "field is immutable" and return all other error.
 		if !strings.Contains(err.Error(), "spec.selector") || !strings.Contains(err.Error(), "field is immutable") {
 			return err
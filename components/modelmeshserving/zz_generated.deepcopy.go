@@ -20,12 +20,19 @@ limitations under the License.
 
 package modelmeshserving
 
-import ()
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelMeshServing) DeepCopyInto(out *ModelMeshServing) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
+	if in.ModelController != nil {
+		in, out := &in.ModelController, &out.ModelController
+		*out = new(components.ModelController)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelMeshServing.
@@ -0,0 +1,348 @@
+package modelregistry
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// DatabaseProvider is the database operator provisioning the database of model registries.
+// +kubebuilder:validation:Enum=CloudNativePG;CrunchyPostgres
+type DatabaseProvider string
+
+const (
+	// CloudNativePG provisions a PostgreSQL Cluster of CloudNativePG.
+	CloudNativePG DatabaseProvider = "CloudNativePG"
+	// CrunchyPostgres provisions a PostgresCluster of Crunchy Postgres for Kubernetes.
+	CrunchyPostgres DatabaseProvider = "CrunchyPostgres"
+
+	// DatabaseCredentialsSecret holds the connection to the provisioned database, with the host, port, database,
+	// username and password keys, for model registries to reference.
+	DatabaseCredentialsSecret = "model-registry-db-credentials"
+
+	// databaseCluster is the name of the database cluster created through the database operator.
+	databaseCluster = "model-registry-db"
+	// databaseUser owns the database of the same name. Crunchy does not accept underscores in user names.
+	databaseUser = "modelregistry"
+	// crunchyPostgresVersion is the major version of PostgreSQL run by Crunchy, which has no default.
+	crunchyPostgresVersion = 16
+)
+
+var defaultDatabaseSize = resource.MustParse("10Gi")
+
+// clusterKind returns the kind of database cluster created through the provider.
+func (p DatabaseProvider) clusterKind() schema.GroupVersionKind {
+	if p == CrunchyPostgres {
+		return gvk.CrunchyPostgresCluster
+	}
+
+	return gvk.CloudNativePGCluster
+}
+
+// credentialsSecret returns the Secret the provider publishes the connection to the database in.
+func (p DatabaseProvider) credentialsSecret() string {
+	if p == CrunchyPostgres {
+		return databaseCluster + "-pguser-" + databaseUser
+	}
+
+	return databaseCluster + "-app"
+}
+
+// reconcileDatabase creates the database cluster through the database operator, expands its storage when the size
+// of the database was increased, and copies the connection published by the operator into DatabaseCredentialsSecret.
+// The volume of the database is provisioned from defaultStorageClass when the database sets no storage class.
+func (m *ModelRegistry) reconcileDatabase(ctx context.Context, cli client.Client, defaultStorageClass string) error {
+	desired := m.desiredDatabaseCluster(defaultStorageClass)
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := cli.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case meta.IsNoMatchError(err):
+		return status.NewRemediationError(status.RemediationMissingOperator,
+			fmt.Sprintf("Install the %s operator, or unset databaseProvider of modelregistry", m.DatabaseProvider),
+			fmt.Errorf("%s is not installed, it is required by the databaseProvider of %s", desired.GetKind(), ComponentName))
+	case k8serr.IsNotFound(err):
+		if err := cli.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create %s %s/%s: %w", desired.GetKind(), desired.GetNamespace(), desired.GetName(), err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get %s %s/%s: %w", desired.GetKind(), desired.GetNamespace(), desired.GetName(), err)
+	default:
+		// only the storage is reconciled, the rest of the spec is defaulted by the database operator and left to admins
+		if resized := setDatabaseSize(existing, m.DatabaseProvider, m.databaseSize()); resized {
+			if err := cli.Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to expand %s %s/%s: %w", existing.GetKind(), existing.GetNamespace(), existing.GetName(), err)
+			}
+		}
+	}
+
+	source := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: m.DatabaseProvider.credentialsSecret(), Namespace: m.RegistriesNamespace}, source); err != nil {
+		// published once the database is initialized, the readiness gate reports it until then
+		return client.IgnoreNotFound(err)
+	}
+
+	return m.publishDatabaseCredentials(ctx, cli, source)
+}
+
+// removeDatabase deletes the database cluster and its credentials once model registry is Removed, unless the storage
+// of the database is retained.
+func (m *ModelRegistry) removeDatabase(ctx context.Context, cli client.Client) error {
+	if m.Database != nil && m.Database.RetainOnRemoval {
+		return nil
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(m.DatabaseProvider.clusterKind())
+	cluster.SetName(databaseCluster)
+	cluster.SetNamespace(m.RegistriesNamespace)
+	if err := cli.Delete(ctx, cluster); err != nil && !k8serr.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %w", cluster.GetKind(), m.RegistriesNamespace, databaseCluster, err)
+	}
+
+	credentials := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: DatabaseCredentialsSecret, Namespace: m.RegistriesNamespace}}
+	if err := cli.Delete(ctx, credentials); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Secret %s/%s: %w", m.RegistriesNamespace, DatabaseCredentialsSecret, err)
+	}
+
+	return nil
+}
+
+// ReadinessGates reports the database cluster as an unmet gate until the database operator has it running, and its
+// credentials are published.
+func (m *ModelRegistry) ReadinessGates(ctx context.Context, cli client.Reader) ([]string, error) {
+	if m.DatabaseProvider == "" {
+		return nil, nil
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(m.DatabaseProvider.clusterKind())
+	if err := cli.Get(ctx, client.ObjectKey{Name: databaseCluster, Namespace: m.RegistriesNamespace}, cluster); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return []string{fmt.Sprintf("%s %s/%s is not created yet", cluster.GetKind(), m.RegistriesNamespace, databaseCluster)}, nil
+		}
+		return nil, err
+	}
+	if !databaseReady(cluster, m.DatabaseProvider) {
+		return []string{fmt.Sprintf("%s %s/%s is not ready", cluster.GetKind(), m.RegistriesNamespace, databaseCluster)}, nil
+	}
+
+	credentials := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: DatabaseCredentialsSecret, Namespace: m.RegistriesNamespace}, credentials); err != nil {
+		if k8serr.IsNotFound(err) {
+			return []string{fmt.Sprintf("Secret %s/%s is not published yet", m.RegistriesNamespace, DatabaseCredentialsSecret)}, nil
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (m *ModelRegistry) databaseSize() resource.Quantity {
+	if m.Database == nil || m.Database.Size.IsZero() {
+		return defaultDatabaseSize
+	}
+
+	return m.Database.Size
+}
+
+// desiredDatabaseCluster returns the database cluster of the provider, with a database owned by databaseUser.
+func (m *ModelRegistry) desiredDatabaseCluster(defaultStorageClass string) *unstructured.Unstructured {
+	storageClass := defaultStorageClass
+	if m.Database != nil && m.Database.StorageClassName != "" {
+		storageClass = m.Database.StorageClassName
+	}
+
+	size := m.databaseSize()
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(m.DatabaseProvider.clusterKind())
+	cluster.SetName(databaseCluster)
+	cluster.SetNamespace(m.RegistriesNamespace)
+	cluster.SetLabels(map[string]string{labels.ODH.Component(ComponentName): "true"})
+
+	switch m.DatabaseProvider {
+	case CrunchyPostgres:
+		volumeClaimSpec := map[string]interface{}{
+			"accessModes": []interface{}{string(corev1.ReadWriteOnce)},
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"storage": size.String()},
+			},
+		}
+		if storageClass != "" {
+			volumeClaimSpec["storageClassName"] = storageClass
+		}
+		cluster.Object["spec"] = map[string]interface{}{
+			"postgresVersion": int64(crunchyPostgresVersion),
+			"instances": []interface{}{
+				map[string]interface{}{"name": "instance1", "dataVolumeClaimSpec": volumeClaimSpec},
+			},
+			"users": []interface{}{
+				map[string]interface{}{"name": databaseUser, "databases": []interface{}{databaseUser}},
+			},
+		}
+	default:
+		storage := map[string]interface{}{"size": size.String()}
+		if storageClass != "" {
+			storage["storageClass"] = storageClass
+		}
+		cluster.Object["spec"] = map[string]interface{}{
+			"instances": int64(1),
+			"bootstrap": map[string]interface{}{
+				"initdb": map[string]interface{}{"database": databaseUser, "owner": databaseUser},
+			},
+			"storage": storage,
+		}
+	}
+
+	return cluster
+}
+
+// publishDatabaseCredentials copies the connection published by the database operator into DatabaseCredentialsSecret,
+// under the same keys whatever the provider.
+func (m *ModelRegistry) publishDatabaseCredentials(ctx context.Context, cli client.Client, source *corev1.Secret) error {
+	data := databaseCredentials(source, m.DatabaseProvider)
+
+	credentials := &corev1.Secret{}
+	err := cli.Get(ctx, client.ObjectKey{Name: DatabaseCredentialsSecret, Namespace: m.RegistriesNamespace}, credentials)
+	switch {
+	case k8serr.IsNotFound(err):
+		credentials = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      DatabaseCredentialsSecret,
+				Namespace: m.RegistriesNamespace,
+				Labels:    map[string]string{labels.ODH.Component(ComponentName): "true"},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := cli.Create(ctx, credentials); err != nil {
+			return fmt.Errorf("failed to create Secret %s/%s: %w", m.RegistriesNamespace, DatabaseCredentialsSecret, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get Secret %s/%s: %w", m.RegistriesNamespace, DatabaseCredentialsSecret, err)
+	}
+
+	if secretDataEqual(credentials.Data, data) {
+		return nil
+	}
+	credentials.Data = data
+	if err := cli.Update(ctx, credentials); err != nil {
+		return fmt.Errorf("failed to update Secret %s/%s: %w", m.RegistriesNamespace, DatabaseCredentialsSecret, err)
+	}
+
+	return nil
+}
+
+// databaseCredentials maps the keys of the Secret published by the provider to the keys of DatabaseCredentialsSecret.
+func databaseCredentials(source *corev1.Secret, provider DatabaseProvider) map[string][]byte {
+	usernameKey := "username"
+	if provider == CrunchyPostgres {
+		usernameKey = "user"
+	}
+
+	return map[string][]byte{
+		"host":     source.Data["host"],
+		"port":     source.Data["port"],
+		"database": source.Data["dbname"],
+		"username": source.Data[usernameKey],
+		"password": source.Data["password"],
+	}
+}
+
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, found := b[key]; !found || string(other) != string(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// databaseReady tells whether the database operator reports the cluster as running.
+func databaseReady(cluster *unstructured.Unstructured, provider DatabaseProvider) bool {
+	if provider == CrunchyPostgres {
+		// PostgresClusters have no Ready condition, their instance sets report ready replicas instead
+		instances, _, _ := unstructured.NestedSlice(cluster.Object, "status", "instances")
+		if len(instances) == 0 {
+			return false
+		}
+		for _, item := range instances {
+			instance, isMap := item.(map[string]interface{})
+			if !isMap {
+				return false
+			}
+			replicas, _, _ := unstructured.NestedInt64(instance, "replicas")
+			readyReplicas, _, _ := unstructured.NestedInt64(instance, "readyReplicas")
+			if replicas == 0 || readyReplicas < replicas {
+				return false
+			}
+		}
+		return true
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(cluster.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, isMap := item.(map[string]interface{})
+		if isMap && condition["type"] == "Ready" {
+			return condition["status"] == string(corev1.ConditionTrue)
+		}
+	}
+
+	return false
+}
+
+// setDatabaseSize sets the size of the storage of the database cluster, and tells whether it changed.
+func setDatabaseSize(cluster *unstructured.Unstructured, provider DatabaseProvider, size resource.Quantity) bool {
+	if provider == CrunchyPostgres {
+		instances, _, _ := unstructured.NestedSlice(cluster.Object, "spec", "instances")
+		resized := false
+		for i, item := range instances {
+			instance, isMap := item.(map[string]interface{})
+			if !isMap {
+				continue
+			}
+			if current, _, _ := unstructured.NestedString(instance, "dataVolumeClaimSpec", "resources", "requests", "storage"); atLeast(current, size) {
+				continue
+			}
+			_ = unstructured.SetNestedField(instance, size.String(), "dataVolumeClaimSpec", "resources", "requests", "storage")
+			instances[i] = instance
+			resized = true
+		}
+		if resized {
+			_ = unstructured.SetNestedSlice(cluster.Object, instances, "spec", "instances")
+		}
+		return resized
+	}
+
+	if current, _, _ := unstructured.NestedString(cluster.Object, "spec", "storage", "size"); atLeast(current, size) {
+		return false
+	}
+	_ = unstructured.SetNestedField(cluster.Object, size.String(), "spec", "storage", "size")
+
+	return true
+}
+
+// atLeast tells whether the current size is not below the given one, as volumes can only be expanded.
+func atLeast(current string, size resource.Quantity) bool {
+	quantity, err := resource.ParseQuantity(current)
+
+	return err == nil && quantity.Cmp(size) >= 0
+}
@@ -12,6 +12,7 @@ import (
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -19,14 +20,22 @@ import (
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cloud"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/conversion"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 
 	_ "embed"
 )
 
-const DefaultModelRegistryCert = "default-modelregistry-cert"
+const (
+	DefaultModelRegistryCert = "default-modelregistry-cert"
+	// DatabaseClaimName is the PersistentVolumeClaim of the database of model registries.
+	DatabaseClaimName = "model-registry-db"
+)
 
 var (
 	ComponentName                   = "model-registry-operator"
@@ -38,8 +47,15 @@ var (
 	// ).
 )
 
-// Verifies that ModelRegistry implements ComponentInterface.
-var _ components.ComponentInterface = (*ModelRegistry)(nil)
+// Verifies that ModelRegistry implements ComponentInterface, LoggingProvider, RBACProvider, PersonaRBACProvider, ReadinessGater and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*ModelRegistry)(nil)
+	_ components.LoggingProvider     = (*ModelRegistry)(nil)
+	_ components.RBACProvider        = (*ModelRegistry)(nil)
+	_ components.PersonaRBACProvider = (*ModelRegistry)(nil)
+	_ components.ReadinessGater      = (*ModelRegistry)(nil)
+	_ components.WorkloadCounter     = (*ModelRegistry)(nil)
+)
 
 // ModelRegistry struct holds the configuration for the ModelRegistry component.
 // The property `registriesNamespace` is immutable when `managementState` is `Managed`
@@ -56,6 +72,25 @@ type ModelRegistry struct {
 	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
 	// +kubebuilder:validation:MaxLength=63
 	RegistriesNamespace string `json:"registriesNamespace,omitempty"`
+
+	// Storage of the database of model registries, a PersistentVolumeClaim named "model-registry-db" created in the
+	// registries namespace for the database deployment to mount. No claim is created when not set.
+	// With a databaseProvider, it sets the storage of the database cluster instead.
+	// +optional
+	Database *components.PersistentStorage `json:"database,omitempty"`
+
+	// Database operator provisioning a PostgreSQL database for model registries in the registries namespace, e.g.
+	// CloudNativePG. The connection is published in the "model-registry-db-credentials" Secret, and model registry
+	// is not ready until the database is. Model registries bring their own database when not set.
+	// +optional
+	DatabaseProvider DatabaseProvider `json:"databaseProvider,omitempty"`
+
+	// Registries deployed by the operator, e.g. one per team, each in its own namespace with its own database and
+	// groups of users allowed to access it. Names are unique as they make the hosts the registries are routed at.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Registries []Registry `json:"registries,omitempty"`
 }
 
 func (m *ModelRegistry) Init(ctx context.Context, _ cluster.Platform) error {
@@ -96,6 +131,50 @@ func (m *ModelRegistry) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the model registry operator.
+func (m *ModelRegistry) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("model-registry-operator-controller-manager")
+}
+
+// Workloads returns the model registries deployed by the model registry operator.
+func (m *ModelRegistry) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	registries, err := components.CountWorkloads(ctx, cli, gvk.ModelRegistry, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{registries}, nil
+}
+
+// OperatorPolicyRules returns permissions the operator needs to hold only while ModelRegistry is enabled.
+func (m *ModelRegistry) OperatorPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"modelregistry.opendatahub.io"},
+			Resources: []string{"modelregistries"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		},
+		{
+			APIGroups: []string{"modelregistry.opendatahub.io"},
+			Resources: []string{"modelregistries/status"},
+			Verbs:     []string{"get", "update", "patch"},
+		},
+		{
+			APIGroups: []string{"modelregistry.opendatahub.io"},
+			Resources: []string{"modelregistries/finalizers"},
+			Verbs:     []string{"update", "get"},
+		},
+	}
+}
+
+// PersonaPolicyRules returns permissions on ModelRegistry resources contributed to the personas' ClusterRoles.
+func (m *ModelRegistry) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	// Registries live in the registries namespace, they are managed by platform admins only
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin: {{APIGroups: []string{"modelregistry.opendatahub.io"}, Resources: []string{"modelregistries"}, Verbs: components.ManageVerbs}},
+	}
+}
+
 func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -105,7 +184,9 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 	if enabled {
 		// return error if ServiceMesh is not enabled, as it's a required feature
 		if dscispec.ServiceMesh == nil || dscispec.ServiceMesh.ManagementState != operatorv1.Managed {
-			return errors.New("ServiceMesh needs to be set to 'Managed' in DSCI CR, it is required by Model Registry")
+			return status.NewRemediationError(status.RemediationServiceMeshRequired,
+				"Set spec.serviceMesh.managementState to Managed in DSCInitialization",
+				errors.New("ServiceMesh needs to be set to 'Managed' in DSCI CR, it is required by Model Registry"))
 		}
 
 		if err := m.createDependencies(ctx, cli, dscispec); err != nil {
@@ -125,6 +206,9 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 		if err := deploy.ApplyParams(Path, nil, extraParamsMap); err != nil {
 			return fmt.Errorf("failed to update image from %s : %w", Path, err)
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, m.ExtraParams, Path); err != nil {
+			return err
+		}
 
 		// Create model registries namespace
 		// We do not delete this namespace even when ModelRegistry is Removed or when operator is uninstalled.
@@ -139,11 +223,39 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 			return err
 		}
 		l.Info("created model registry servicemesh member", "namespace", m.RegistriesNamespace)
+		defaultStorageClass := cloud.For(dscispec).StorageClassName
+		switch {
+		case m.DatabaseProvider != "":
+			if err := m.reconcileDatabase(ctx, cli, defaultStorageClass); err != nil {
+				return err
+			}
+		case m.Database != nil:
+			if err := m.Database.WithDefaultStorageClass(defaultStorageClass).Apply(ctx, cli, cluster.APIReader(ctx, cli), DatabaseClaimName, m.RegistriesNamespace,
+				cluster.WithLabels(labels.ODH.Component(ComponentName), "true")); err != nil {
+				return err
+			}
+		}
 	} else {
 		err := m.removeDependencies(ctx, cli, dscispec)
 		if err != nil {
 			return err
 		}
+		if err := removeRegistries(ctx, cli, dscispec); err != nil {
+			return err
+		}
+		if m.DatabaseProvider != "" && m.RegistriesNamespace != "" {
+			if err := m.removeDatabase(ctx, cli); err != nil {
+				return err
+			}
+		} else if m.Database != nil && m.RegistriesNamespace != "" {
+			released, err := m.Database.Release(ctx, cli, cluster.APIReader(ctx, cli), DatabaseClaimName, m.RegistriesNamespace)
+			if err != nil {
+				return err
+			}
+			if !released {
+				l.Info("database claim of model registries is still in use, not deleting it", "namespace", m.RegistriesNamespace)
+			}
+		}
 	}
 
 	// Deploy ModelRegistry Operator
@@ -162,6 +274,10 @@ func (m *ModelRegistry) ReconcileComponent(ctx context.Context, cli client.Clien
 		if err := cluster.WaitForDeploymentAvailable(ctx, cli, m.GetComponentName(), dscispec.ApplicationsNamespace, 10, 1); err != nil {
 			return fmt.Errorf("deployment for %s is not ready to server: %w", ComponentName, err)
 		}
+		// Registries are created once the model registry operator serves their API
+		if err := m.reconcileRegistries(ctx, cli, owner, dscispec); err != nil {
+			return err
+		}
 	}
 
 	// CloudService Monitoring handling
@@ -0,0 +1,219 @@
+package modelregistry
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
+)
+
+// DatabaseType is the kind of database a model registry stores its metadata in.
+// +kubebuilder:validation:Enum=postgres;mysql
+type DatabaseType string
+
+const (
+	// PostgresDatabase is a PostgreSQL database.
+	PostgresDatabase DatabaseType = "postgres"
+	// MySQLDatabase is a MySQL database.
+	MySQLDatabase DatabaseType = "mysql"
+)
+
+// Registry is a model registry deployed by the operator.
+// +kubebuilder:object:generate=true
+type Registry struct {
+	// Name of the model registry.
+	// +kubebuilder:validation:Pattern="^[a-z]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// Namespace of the model registry, defaults to the registries namespace. Other namespaces are created and enrolled
+	// in the service mesh.
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$"
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Database the model registry stores its metadata in.
+	Database RegistryDatabase `json:"database"`
+	// AccessGroups are the groups of users allowed to access the model registry through its route. Only users allowed
+	// to get the service of the model registry, e.g. admins of its namespace, have access when not set.
+	// +optional
+	AccessGroups []string `json:"accessGroups,omitempty"`
+}
+
+// RegistryDatabase references the database of a model registry.
+// +kubebuilder:object:generate=true
+type RegistryDatabase struct {
+	// Type of the database.
+	// +kubebuilder:default=postgres
+	// +optional
+	Type DatabaseType `json:"type,omitempty"`
+	// Name of the Secret, in the namespace of the model registry, holding the connection to the database in its host,
+	// port, database, username and password keys, as the "model-registry-db-credentials" Secret does.
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+//go:embed resources/registries
+var registriesFS embed.FS
+
+const (
+	registriesDir = "resources/registries"
+	// registryFeaturePrefix prefixes the name of the feature deploying each registry, followed by its name.
+	registryFeaturePrefix = "model-registry-"
+)
+
+var defaultDatabasePorts = map[DatabaseType]int{
+	PostgresDatabase: 5432,
+	MySQLDatabase:    3306,
+}
+
+// registryData is the data the templates of a registry are rendered with.
+type registryData struct {
+	Name           string
+	Namespace      string
+	DatabaseType   DatabaseType
+	Host           string
+	Port           int
+	Database       string
+	Username       string
+	PasswordSecret string
+	AccessGroups   []string
+}
+
+// reconcileRegistries deploys each registry through its own feature, after creating the namespaces other than the
+// registries namespace and enrolling them in the service mesh, and removes the registries not listed anymore.
+func (m *ModelRegistry) reconcileRegistries(ctx context.Context, cli client.Client, owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec) error {
+	listed := make([]string, 0, len(m.Registries))
+	for _, registry := range m.Registries {
+		listed = append(listed, registry.Name)
+		namespace := m.registryNamespace(registry)
+		if namespace == m.RegistriesNamespace {
+			continue
+		}
+		ns, err := cluster.CreateNamespace(ctx, cli, namespace)
+		if err != nil {
+			return err
+		}
+		if err := enrollToServiceMesh(ctx, cli, dscispec, ns); err != nil {
+			return err
+		}
+	}
+
+	handler := feature.ComponentFeaturesHandler(owner, ComponentName, dscispec.ApplicationsNamespace, m.defineRegistryFeatures(dscispec))
+	if err := handler.Apply(ctx, cli); err != nil {
+		return err
+	}
+
+	return removeRegistries(ctx, cli, dscispec, listed...)
+}
+
+func (m *ModelRegistry) registryNamespace(registry Registry) string {
+	if registry.Namespace == "" {
+		return m.RegistriesNamespace
+	}
+
+	return registry.Namespace
+}
+
+func (m *ModelRegistry) defineRegistryFeatures(dscispec *dsciv1.DSCInitializationSpec) feature.FeaturesProvider {
+	return func(registries feature.FeaturesRegistry) error {
+		for _, registry := range m.Registries {
+			if err := registries.Add(feature.Define(registryFeaturePrefix + registry.Name).
+				Manifests(
+					manifest.Location(registriesFS).
+						Include(registriesDir),
+				).
+				Managed().
+				WithData(
+					feature.Entry("Registry", registryDataProvider(registry, m.registryNamespace(registry))),
+					feature.Entry("Domain", cluster.GetDomain),
+					servicemesh.FeatureData.Authorization.ExtensionProviderName.Define(dscispec).AsAction(),
+				),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// registryDataProvider reads the connection to the database of the registry from its credentials Secret.
+func registryDataProvider(registry Registry, namespace string) provider.DataProviderFunc[registryData] {
+	return func(ctx context.Context, cli client.Client) (registryData, error) {
+		databaseType := registry.Database.Type
+		if databaseType == "" {
+			databaseType = PostgresDatabase
+		}
+		data := registryData{
+			Name:           registry.Name,
+			Namespace:      namespace,
+			DatabaseType:   databaseType,
+			Port:           defaultDatabasePorts[databaseType],
+			PasswordSecret: registry.Database.CredentialsSecretName,
+			AccessGroups:   registry.AccessGroups,
+		}
+
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: registry.Database.CredentialsSecretName, Namespace: namespace}, secret); err != nil {
+			return data, fmt.Errorf("failed to get database credentials of model registry %s: %w", registry.Name, err)
+		}
+		for _, key := range []string{"host", "database", "username", "password"} {
+			if len(secret.Data[key]) == 0 {
+				return data, fmt.Errorf("database credentials Secret %s/%s of model registry %s has no %s key",
+					namespace, secret.Name, registry.Name, key)
+			}
+		}
+		data.Host = string(secret.Data["host"])
+		data.Database = string(secret.Data["database"])
+		data.Username = string(secret.Data["username"])
+		if port := string(secret.Data["port"]); port != "" {
+			parsed, err := strconv.Atoi(port)
+			if err != nil {
+				return data, fmt.Errorf("invalid port %q in database credentials Secret %s/%s: %w", port, namespace, secret.Name, err)
+			}
+			data.Port = parsed
+		}
+
+		return data, nil
+	}
+}
+
+// removeRegistries deletes the features of the registries not kept, which garbage collects their resources. All
+// registries are removed when none is kept.
+func removeRegistries(ctx context.Context, cli client.Client, dscispec *dsciv1.DSCInitializationSpec, keep ...string) error {
+	trackers := &featurev1.FeatureTrackerList{}
+	if err := cli.List(ctx, trackers); err != nil {
+		return fmt.Errorf("failed to list FeatureTrackers: %w", err)
+	}
+
+	prefix := dscispec.ApplicationsNamespace + "-" + registryFeaturePrefix
+	for i := range trackers.Items {
+		tracker := &trackers.Items[i]
+		source := tracker.Spec.Source
+		if source.Type != featurev1.ComponentType || source.Name != ComponentName || !strings.HasPrefix(tracker.Name, prefix) {
+			continue
+		}
+		if name := strings.TrimPrefix(tracker.Name, prefix); slices.Contains(keep, name) {
+			continue
+		}
+		if err := cli.Delete(ctx, tracker); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete FeatureTracker %s: %w", tracker.Name, err)
+		}
+	}
+
+	return nil
+}
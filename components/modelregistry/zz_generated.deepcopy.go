@@ -20,12 +20,26 @@ limitations under the License.
 
 package modelregistry
 
-import ()
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelRegistry) DeepCopyInto(out *ModelRegistry) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(components.PersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]Registry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRegistry.
@@ -37,3 +51,39 @@ func (in *ModelRegistry) DeepCopy() *ModelRegistry {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Registry) DeepCopyInto(out *Registry) {
+	*out = *in
+	out.Database = in.Database
+	if in.AccessGroups != nil {
+		in, out := &in.AccessGroups, &out.AccessGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registry.
+func (in *Registry) DeepCopy() *Registry {
+	if in == nil {
+		return nil
+	}
+	out := new(Registry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryDatabase) DeepCopyInto(out *RegistryDatabase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDatabase.
+func (in *RegistryDatabase) DeepCopy() *RegistryDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
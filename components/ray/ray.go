@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -16,6 +17,7 @@ import (
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 )
 
@@ -24,8 +26,14 @@ var (
 	RayPath       = deploy.DefaultManifestPath + "/" + ComponentName + "/openshift"
 )
 
-// Verifies that Ray implements ComponentInterface.
-var _ components.ComponentInterface = (*Ray)(nil)
+// Verifies that Ray implements ComponentInterface, LoggingProvider, RBACProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*Ray)(nil)
+	_ components.LoggingProvider     = (*Ray)(nil)
+	_ components.RBACProvider        = (*Ray)(nil)
+	_ components.PersonaRBACProvider = (*Ray)(nil)
+	_ components.WorkloadCounter     = (*Ray)(nil)
+)
 
 // Ray struct holds the configuration for the Ray component.
 // +kubebuilder:object:generate=true
@@ -68,6 +76,43 @@ func (r *Ray) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the KubeRay operator.
+func (r *Ray) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("kuberay-operator")
+}
+
+// Workloads returns the Ray clusters managed by KubeRay.
+func (r *Ray) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	rayClusters, err := components.CountWorkloads(ctx, cli, gvk.RayCluster, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{rayClusters}, nil
+}
+
+// OperatorPolicyRules returns permissions the operator needs to hold only while Ray is enabled.
+func (r *Ray) OperatorPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"ray.io"},
+			Resources: []string{"rayclusters", "rayjobs", "rayservices"},
+			Verbs:     []string{"create", "delete", "list", "watch", "update", "patch", "get"},
+		},
+	}
+}
+
+// PersonaPolicyRules returns permissions on Ray resources contributed to the personas' ClusterRoles.
+func (r *Ray) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	workloads := []string{"rayclusters", "rayjobs", "rayservices"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"ray.io"}, Resources: workloads, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"ray.io"}, Resources: workloads, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"ray.io"}, Resources: workloads, Verbs: components.EditVerbs}},
+	}
+}
+
 func (r *Ray) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -84,6 +129,9 @@ func (r *Ray) ReconcileComponent(ctx context.Context, cli client.Client,
 		if err := deploy.ApplyParams(RayPath, nil, map[string]string{"namespace": dscispec.ApplicationsNamespace}); err != nil {
 			return fmt.Errorf("failed to update namespace from %s : %w", RayPath, err)
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, r.ExtraParams, RayPath); err != nil {
+			return err
+		}
 	}
 	// Deploy Ray Operator
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, RayPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
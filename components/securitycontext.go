@@ -0,0 +1,53 @@
+package components
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodSecurityLevel is the Pod Security Standard the pods of a component are rendered for.
+// +kubebuilder:validation:Enum=Baseline;Restricted
+type PodSecurityLevel string
+
+const (
+	// PodSecurityBaseline keeps the security context of the manifests.
+	PodSecurityBaseline PodSecurityLevel = "Baseline"
+	// PodSecurityRestricted completes the security context of the manifests to comply with the restricted level.
+	PodSecurityRestricted PodSecurityLevel = "Restricted"
+)
+
+// RequiredSCCAnnotation is set on pods to select the SecurityContextConstraints they are admitted with on OpenShift,
+// rather than the one with the highest priority their service account can use.
+const RequiredSCCAnnotation = "openshift.io/required-scc"
+
+// SecurityContext defines the security context of the pods of the Deployments of a component.
+// +kubebuilder:object:generate=true
+type SecurityContext struct {
+	// Set to one of the following values:
+	//
+	// - "Baseline" : the security context of the manifests is kept
+	//
+	// - "Restricted" : containers run as non-root, without privilege escalation and with all capabilities dropped, and
+	//                  pods with the RuntimeDefault seccomp profile, where the manifests leave them unset
+	//
+	// +optional
+	PodSecurityLevel PodSecurityLevel `json:"podSecurityLevel,omitempty"`
+	// Name of the SecurityContextConstraints the pods of the component require, e.g. "restricted-v2" or a custom SCC.
+	// Service accounts of the component have to be allowed to use it.
+	// +optional
+	SCC string `json:"scc,omitempty"`
+	// UID the containers of the component run as, e.g. within the UID range of a custom SCC.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// GID the containers of the component run as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// Supplemental group owning the volumes of the pods of the component.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+	// Seccomp profile of the pods of the component, overriding the one of the manifests.
+	// +optional
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+}
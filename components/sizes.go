@@ -0,0 +1,26 @@
+package components
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Size is a T-shirt size of the resources offered to users in the dashboard, e.g. when creating workbenches or
+// deploying model servers, in the format of the dashboard configuration. GPUs are requested with their extended
+// resource name, e.g. "nvidia.com/gpu".
+// +kubebuilder:object:generate=true
+type Size struct {
+	// Name of the size displayed in the dashboard, e.g. "Small".
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Resources of the containers of this size.
+	Resources SizeResources `json:"resources"`
+}
+
+// SizeResources holds the requests and limits of a size.
+// +kubebuilder:object:generate=true
+type SizeResources struct {
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
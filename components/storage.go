@@ -0,0 +1,173 @@
+package components
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+// PersistentStorage defines the PersistentVolumeClaim holding the data of a stateful component, e.g. its database.
+// +kubebuilder:object:generate=true
+type PersistentStorage struct {
+	// Storage class the volume is provisioned from. The default storage class of the cluster is used when not set.
+	// Cannot be changed once the claim is created.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Size of the volume, e.g. "10Gi". Increasing it requests the expansion of the volume, provided the storage class
+	// allows volume expansion. Volumes cannot be shrunk.
+	// +kubebuilder:default="10Gi"
+	Size resource.Quantity `json:"size,omitempty"`
+	// Keeps the claim, and the data of the component, when the component is Removed. The claim is deleted otherwise,
+	// once no pod mounts it anymore.
+	// +optional
+	RetainOnRemoval bool `json:"retainOnRemoval,omitempty"`
+}
+
+// WithDefaultStorageClass returns a copy of the storage provisioning its volume from the given storage class, when it
+// does not set one.
+func (s *PersistentStorage) WithDefaultStorageClass(name string) *PersistentStorage {
+	storage := s.DeepCopy()
+	if storage.StorageClassName == "" {
+		storage.StorageClassName = name
+	}
+
+	return storage
+}
+
+// Apply creates the PersistentVolumeClaim of the given name, or requests the expansion of its volume when its size
+// was increased. Claims are read with the reader rather than the cache of the manager, which only holds their metadata.
+func (s *PersistentStorage) Apply(ctx context.Context, cli client.Client, reader client.Reader, name, namespace string,
+	metaOptions ...cluster.MetaOptions,
+) error {
+	claim := &corev1.PersistentVolumeClaim{}
+	err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, claim)
+	if k8serr.IsNotFound(err) {
+		return s.create(ctx, cli, name, namespace, metaOptions...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+
+	return s.expand(ctx, cli, claim)
+}
+
+// Expand requests the expansion of the volume of the PersistentVolumeClaim of the given name when its size was
+// increased. Claims which do not exist are left alone.
+func (s *PersistentStorage) Expand(ctx context.Context, cli client.Client, reader client.Reader, name, namespace string) error {
+	claim := &corev1.PersistentVolumeClaim{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, claim); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	return s.expand(ctx, cli, claim)
+}
+
+// Release deletes the PersistentVolumeClaim of the given name once the component is Removed, unless it is retained.
+// Claims still mounted by pods are not deleted, and it tells whether the claim is gone.
+func (s *PersistentStorage) Release(ctx context.Context, cli client.Client, reader client.Reader, name, namespace string) (bool, error) {
+	if s.RetainOnRemoval {
+		return true, nil
+	}
+
+	claim := &metav1.PartialObjectMetadata{}
+	claim.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+	if err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, claim); err != nil {
+		if k8serr.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list pods of %s: %w", namespace, err)
+	}
+	for i := range pods.Items {
+		if mountsClaim(&pods.Items[i], name) {
+			return false, nil
+		}
+	}
+
+	if err := cli.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+
+	return true, nil
+}
+
+func (s *PersistentStorage) create(ctx context.Context, cli client.Client, name, namespace string, metaOptions ...cluster.MetaOptions) error {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: s.Size},
+			},
+		},
+	}
+	if s.StorageClassName != "" {
+		claim.Spec.StorageClassName = &s.StorageClassName
+	}
+	if err := cluster.ApplyMetaOptions(claim, metaOptions...); err != nil {
+		return err
+	}
+	if err := cli.Create(ctx, claim); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("failed to create PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
+func (s *PersistentStorage) expand(ctx context.Context, cli client.Client, claim *corev1.PersistentVolumeClaim) error {
+	current := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch s.Size.Cmp(current) {
+	case 0:
+		return nil
+	case -1:
+		return fmt.Errorf("cannot shrink PersistentVolumeClaim %s/%s from %s to %s", claim.Namespace, claim.Name, current.String(), s.Size.String())
+	}
+
+	if claim.Spec.StorageClassName == nil {
+		return fmt.Errorf("cannot expand PersistentVolumeClaim %s/%s, it has no storage class", claim.Namespace, claim.Name)
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: *claim.Spec.StorageClassName}, storageClass); err != nil {
+		return fmt.Errorf("failed to get StorageClass %s: %w", *claim.Spec.StorageClassName, err)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return fmt.Errorf("cannot expand PersistentVolumeClaim %s/%s, StorageClass %s does not allow volume expansion",
+			claim.Namespace, claim.Name, storageClass.Name)
+	}
+
+	original := claim.DeepCopy()
+	claim.Spec.Resources.Requests[corev1.ResourceStorage] = s.Size
+	if err := cli.Patch(ctx, claim, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to expand PersistentVolumeClaim %s/%s: %w", claim.Namespace, claim.Name, err)
+	}
+
+	return nil
+}
+
+func mountsClaim(pod *corev1.Pod, claimName string) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+	}
+
+	return false
+}
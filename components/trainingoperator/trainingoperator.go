@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -24,13 +26,110 @@ var (
 	TrainingOperatorPath = deploy.DefaultManifestPath + "/" + ComponentName + "/rhoai"
 )
 
-// Verifies that TrainingOperator implements ComponentInterface.
-var _ components.ComponentInterface = (*TrainingOperator)(nil)
+// DeploymentName is the name of the Deployment of the Training Operator.
+const DeploymentName = "kubeflow-training-operator"
+
+// Verifies that TrainingOperator implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*TrainingOperator)(nil)
+	_ components.LoggingProvider     = (*TrainingOperator)(nil)
+	_ components.PersonaRBACProvider = (*TrainingOperator)(nil)
+	_ components.WorkloadCounter     = (*TrainingOperator)(nil)
+)
 
 // TrainingOperator struct holds the configuration for the TrainingOperator component.
 // +kubebuilder:object:generate=true
 type TrainingOperator struct {
 	components.Component `json:""`
+	// Config configures the Training Operator. Settings not set keep the defaults of the manifests.
+	// +optional
+	Config *TrainingOperatorConfig `json:"config,omitempty"`
+}
+
+// JobScheme is a kind of job run by the Training Operator.
+// +kubebuilder:validation:Enum=pytorchjob;tfjob;mpijob;xgboostjob;paddlejob
+type JobScheme string
+
+// GangScheduler schedules all pods of a job at once.
+// +kubebuilder:validation:Enum=Kueue;SchedulerPlugins;Volcano
+type GangScheduler string
+
+const (
+	// Kueue admits jobs when quota is available for all of their pods. Requires the Kueue component to be Managed.
+	Kueue GangScheduler = "Kueue"
+	// SchedulerPlugins schedules pods with the coscheduling plugin of scheduler-plugins.
+	SchedulerPlugins GangScheduler = "SchedulerPlugins"
+	// Volcano schedules pods with the Volcano scheduler.
+	Volcano GangScheduler = "Volcano"
+)
+
+// CleanPodPolicy tells which pods of a job are deleted once it finishes.
+// +kubebuilder:validation:Enum=None;Running;All
+type CleanPodPolicy string
+
+// TrainingOperatorConfig defines settings of the Training Operator managed by the operator.
+// +kubebuilder:object:generate=true
+type TrainingOperatorConfig struct {
+	// EnabledSchemes are the kinds of jobs reconciled by the Training Operator, all of them when empty.
+	// +optional
+	// +listType=set
+	EnabledSchemes []JobScheme `json:"enabledSchemes,omitempty"`
+	// GangScheduling configures scheduling of all pods of a job at once.
+	// +optional
+	GangScheduling *GangSchedulingSpec `json:"gangScheduling,omitempty"`
+	// PyTorchJobDefaults are applied to PyTorchJobs created in the cluster, for fields they leave unset.
+	// +optional
+	PyTorchJobDefaults *PyTorchJobDefaults `json:"pytorchJobDefaults,omitempty"`
+}
+
+// GangSchedulingSpec defines the gang scheduler of jobs.
+// +kubebuilder:object:generate=true
+type GangSchedulingSpec struct {
+	// Scheduler gang scheduling jobs.
+	Scheduler GangScheduler `json:"scheduler"`
+	// DefaultLocalQueue is the Kueue LocalQueue set on new jobs which select none. Only used with the Kueue scheduler.
+	// +optional
+	DefaultLocalQueue string `json:"defaultLocalQueue,omitempty"`
+}
+
+// PyTorchJobDefaults defines defaults of the run policy of PyTorchJobs, applied by a mutating webhook on creation.
+// +kubebuilder:object:generate=true
+type PyTorchJobDefaults struct {
+	// Pods deleted once the job finishes.
+	// +optional
+	CleanPodPolicy CleanPodPolicy `json:"cleanPodPolicy,omitempty"`
+	// Seconds after which finished jobs are deleted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// Number of retries before the job is marked as failed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+}
+
+// Args returns the command line flags of the Training Operator implementing the configuration.
+func (c *TrainingOperatorConfig) Args() map[string][]string {
+	if c == nil {
+		return nil
+	}
+
+	args := map[string][]string{}
+	for _, scheme := range c.EnabledSchemes {
+		args["enable-scheme"] = append(args["enable-scheme"], string(scheme))
+	}
+	// jobs gang scheduled by Kueue are suspended until admitted, the Training Operator needs no scheduler
+	if c.GangScheduling != nil {
+		switch c.GangScheduling.Scheduler {
+		case SchedulerPlugins:
+			args["gang-scheduler-name"] = []string{"scheduler-plugins"}
+		case Volcano:
+			args["gang-scheduler-name"] = []string{"volcano"}
+		case Kueue:
+		}
+	}
+
+	return args
 }
 
 func (r *TrainingOperator) Init(ctx context.Context, _ cluster.Platform) error {
@@ -69,6 +168,36 @@ func (r *TrainingOperator) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the training operator.
+func (r *TrainingOperator) Logging() []components.DeploymentLogging {
+	return components.ZapLogging(DeploymentName)
+}
+
+// Workloads returns the jobs run by the Training Operator.
+func (r *TrainingOperator) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	var workloads []components.Workloads
+	for _, kind := range []string{"PyTorchJob", "TFJob", "MPIJob", "XGBoostJob", "PaddleJob"} {
+		jobs, err := components.CountWorkloads(ctx, cli, schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: kind}, nil)
+		if err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, jobs)
+	}
+
+	return workloads, nil
+}
+
+// PersonaPolicyRules returns permissions on TrainingOperator resources contributed to the personas' ClusterRoles.
+func (r *TrainingOperator) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	jobs := []string{"pytorchjobs", "tfjobs", "mpijobs", "xgboostjobs", "paddlejobs"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"kubeflow.org"}, Resources: jobs, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"kubeflow.org"}, Resources: jobs, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"kubeflow.org"}, Resources: jobs, Verbs: components.EditVerbs}},
+	}
+}
+
 func (r *TrainingOperator) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -82,9 +211,13 @@ func (r *TrainingOperator) ReconcileComponent(ctx context.Context, cli client.Cl
 				return err
 			}
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, r.ExtraParams, TrainingOperatorPath); err != nil {
+			return err
+		}
 	}
 	// Deploy Training Operator
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, TrainingOperatorPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
+	trainingOperatorCtx := deploy.WithDeploymentArgs(ctx, DeploymentName, r.Config.Args())
+	if err := deploy.DeployManifestsFromPath(trainingOperatorCtx, cli, owner, TrainingOperatorPath, dscispec.ApplicationsNamespace, ComponentName, enabled); err != nil {
 		return err
 	}
 	l.Info("apply manifests done")
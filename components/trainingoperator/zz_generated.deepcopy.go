@@ -22,10 +22,55 @@ package trainingoperator
 
 import ()
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GangSchedulingSpec) DeepCopyInto(out *GangSchedulingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GangSchedulingSpec.
+func (in *GangSchedulingSpec) DeepCopy() *GangSchedulingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GangSchedulingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PyTorchJobDefaults) DeepCopyInto(out *PyTorchJobDefaults) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PyTorchJobDefaults.
+func (in *PyTorchJobDefaults) DeepCopy() *PyTorchJobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PyTorchJobDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrainingOperator) DeepCopyInto(out *TrainingOperator) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(TrainingOperatorConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingOperator.
@@ -37,3 +82,33 @@ func (in *TrainingOperator) DeepCopy() *TrainingOperator {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainingOperatorConfig) DeepCopyInto(out *TrainingOperatorConfig) {
+	*out = *in
+	if in.EnabledSchemes != nil {
+		in, out := &in.EnabledSchemes, &out.EnabledSchemes
+		*out = make([]JobScheme, len(*in))
+		copy(*out, *in)
+	}
+	if in.GangScheduling != nil {
+		in, out := &in.GangScheduling, &out.GangScheduling
+		*out = new(GangSchedulingSpec)
+		**out = **in
+	}
+	if in.PyTorchJobDefaults != nil {
+		in, out := &in.PyTorchJobDefaults, &out.PyTorchJobDefaults
+		*out = new(PyTorchJobDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingOperatorConfig.
+func (in *TrainingOperatorConfig) DeepCopy() *TrainingOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainingOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
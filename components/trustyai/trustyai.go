@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -15,6 +16,7 @@ import (
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 )
 
@@ -27,8 +29,13 @@ var (
 	DefaultPath       = ""
 )
 
-// Verifies that TrustyAI implements ComponentInterface.
-var _ components.ComponentInterface = (*TrustyAI)(nil)
+// Verifies that TrustyAI implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*TrustyAI)(nil)
+	_ components.LoggingProvider     = (*TrustyAI)(nil)
+	_ components.PersonaRBACProvider = (*TrustyAI)(nil)
+	_ components.WorkloadCounter     = (*TrustyAI)(nil)
+)
 
 // TrustyAI struct holds the configuration for the TrustyAI component.
 // +kubebuilder:object:generate=true
@@ -78,6 +85,32 @@ func (t *TrustyAI) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flag of the TrustyAI service operator.
+func (t *TrustyAI) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("trustyai-service-operator-controller-manager")
+}
+
+// Workloads returns the TrustyAI services of data science projects.
+func (t *TrustyAI) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	services, err := components.CountWorkloads(ctx, cli, gvk.TrustyAIService, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{services}, nil
+}
+
+// PersonaPolicyRules returns permissions on TrustyAI resources contributed to the personas' ClusterRoles.
+func (t *TrustyAI) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	services := []string{"trustyaiservices"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"trustyai.opendatahub.io"}, Resources: services, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"trustyai.opendatahub.io"}, Resources: services, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"trustyai.opendatahub.io"}, Resources: services, Verbs: components.ViewVerbs}},
+	}
+}
+
 func (t *TrustyAI) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -95,6 +128,9 @@ func (t *TrustyAI) ReconcileComponent(ctx context.Context, cli client.Client,
 				entryPath = OverridePath
 			}
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, t.ExtraParams, entryPath); err != nil {
+			return err
+		}
 	}
 	// Deploy TrustyAI Operator
 	if err := deploy.DeployManifestsFromPath(ctx, cli, owner, entryPath, dscispec.ApplicationsNamespace, t.GetComponentName(), enabled); err != nil {
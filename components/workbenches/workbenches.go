@@ -9,13 +9,19 @@ import (
 	"strings"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
@@ -31,13 +37,37 @@ var (
 	notebookImagesPath = deploy.DefaultManifestPath + "/notebooks/overlays/additional"
 )
 
-// Verifies that Workbench implements ComponentInterface.
-var _ components.ComponentInterface = (*Workbenches)(nil)
+// Verifies that Workbench implements ComponentInterface, LoggingProvider, PersonaRBACProvider and WorkloadCounter.
+var (
+	_ components.ComponentInterface  = (*Workbenches)(nil)
+	_ components.LoggingProvider     = (*Workbenches)(nil)
+	_ components.PersonaRBACProvider = (*Workbenches)(nil)
+	_ components.WorkloadCounter     = (*Workbenches)(nil)
+)
 
 // Workbenches struct holds the configuration for the Workbenches component.
 // +kubebuilder:object:generate=true
 type Workbenches struct {
 	components.Component `json:""`
+	// PodDisruptionBudget of the notebook controllers, one pod at a time can be disrupted when not set.
+	// +optional
+	PodDisruptionBudget *components.PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// NotebookSizes offered to users creating workbenches. When set, the operator manages the notebook sizes of the
+	// dashboard configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NotebookSizes []components.Size `json:"notebookSizes,omitempty"`
+	// PVCSize is the default size of the storage of new workbenches. When set, the operator manages it in the
+	// dashboard configuration.
+	// +optional
+	PVCSize *resource.Quantity `json:"pvcSize,omitempty"`
+	// StorageClassName is the default storage class of the storage of new workbenches, which must exist. When set,
+	// the operator manages it in the dashboard configuration. Existing workbenches keep their storage class.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
 }
 
 func (w *Workbenches) Init(ctx context.Context, _ cluster.Platform) error {
@@ -110,6 +140,32 @@ func (w *Workbenches) GetComponentName() string {
 	return ComponentName
 }
 
+// Logging returns the log level flags of both notebook controllers.
+func (w *Workbenches) Logging() []components.DeploymentLogging {
+	return components.ZapLogging("odh-notebook-controller-manager", "notebook-controller-deployment")
+}
+
+// Workloads returns the workbenches of data science projects.
+func (w *Workbenches) Workloads(ctx context.Context, cli client.Reader) ([]components.Workloads, error) {
+	notebooks, err := components.CountWorkloads(ctx, cli, gvk.Notebook, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []components.Workloads{notebooks}, nil
+}
+
+// PersonaPolicyRules returns permissions on Workbench resources contributed to the personas' ClusterRoles.
+func (w *Workbenches) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	notebooks := []string{"notebooks"}
+
+	return map[components.Persona][]rbacv1.PolicyRule{
+		components.PersonaAdmin:        {{APIGroups: []string{"kubeflow.org"}, Resources: notebooks, Verbs: components.ManageVerbs}},
+		components.PersonaProjectAdmin: {{APIGroups: []string{"kubeflow.org"}, Resources: notebooks, Verbs: components.ManageVerbs}},
+		components.PersonaUser:         {{APIGroups: []string{"kubeflow.org"}, Resources: notebooks, Verbs: components.EditVerbs}},
+	}
+}
+
 func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 	owner metav1.Object, dscispec *dsciv1.DSCInitializationSpec, platform cluster.Platform, _ bool) error {
 	l := logf.FromContext(ctx)
@@ -137,9 +193,13 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 		if err != nil {
 			return err
 		}
+		if err := deploy.ApplyExtraParams(ComponentName, w.ExtraParams, notebookControllerPath, kfnotebookControllerPath, notebookImagesPath); err != nil {
+			return err
+		}
 	}
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner,
+	pdbCtx := deploy.WithPodDisruptionBudget(ctx, w.PodDisruptionBudget, "odh-notebook-controller-manager", "notebook-controller-deployment")
+	if err := deploy.DeployManifestsFromPath(pdbCtx, cli, owner,
 		notebookControllerPath,
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
@@ -147,7 +207,7 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 	}
 	l.WithValues("Path", notebookControllerPath).Info("apply manifests done notebook controller done")
 
-	if err := deploy.DeployManifestsFromPath(ctx, cli, owner,
+	if err := deploy.DeployManifestsFromPath(pdbCtx, cli, owner,
 		kfnotebookControllerPath,
 		dscispec.ApplicationsNamespace,
 		ComponentName, enabled); err != nil {
@@ -165,6 +225,9 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 
 	// Wait for deployment available
 	if enabled {
+		if err := w.validateStorage(ctx, cli); err != nil {
+			return err
+		}
 		if err := cluster.WaitForDeploymentAvailable(ctx, cli, ComponentName, dscispec.ApplicationsNamespace, 10, 2); err != nil {
 			return fmt.Errorf("deployments for %s are not ready to server: %w", ComponentName, err)
 		}
@@ -185,3 +248,33 @@ func (w *Workbenches) ReconcileComponent(ctx context.Context, cli client.Client,
 	}
 	return nil
 }
+
+// validateStorage checks the default storage of new workbenches can be provisioned: its size is positive and its
+// storage class exists.
+func (w *Workbenches) validateStorage(ctx context.Context, cli client.Client) error {
+	if w.PVCSize != nil && w.PVCSize.Sign() <= 0 {
+		return status.NewRemediationError(status.RemediationInvalidConfiguration,
+			"Set pvcSize of workbenches to a positive size, e.g. 20Gi",
+			fmt.Errorf("pvcSize %s of %s is not positive", w.PVCSize.String(), ComponentName))
+	}
+	if w.StorageClassName == "" {
+		return nil
+	}
+
+	err := cli.Get(ctx, client.ObjectKey{Name: w.StorageClassName}, &storagev1.StorageClass{})
+	if !k8serr.IsNotFound(err) {
+		return err
+	}
+	storageClasses := &storagev1.StorageClassList{}
+	if err := cli.List(ctx, storageClasses); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(storageClasses.Items))
+	for _, storageClass := range storageClasses.Items {
+		names = append(names, storageClass.Name)
+	}
+
+	return status.NewRemediationError(status.RemediationInvalidConfiguration,
+		fmt.Sprintf("Create StorageClass %s, or set storageClassName of workbenches to one of: %s", w.StorageClassName, strings.Join(names, ", ")),
+		fmt.Errorf("storageClassName %s of %s does not exist", w.StorageClassName, ComponentName))
+}
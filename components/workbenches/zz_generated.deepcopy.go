@@ -20,12 +20,31 @@ limitations under the License.
 
 package workbenches
 
-import ()
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workbenches) DeepCopyInto(out *Workbenches) {
 	*out = *in
 	in.Component.DeepCopyInto(&out.Component)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(components.PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotebookSizes != nil {
+		in, out := &in.NotebookSizes, &out.NotebookSizes
+		*out = make([]components.Size, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PVCSize != nil {
+		in, out := &in.PVCSize, &out.PVCSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workbenches.
@@ -0,0 +1,196 @@
+// Package admissionpolicy contains controller logic enforcing the validation rules of the operator webhook which can be
+// expressed in CEL with ValidatingAdmissionPolicies, so that they keep being enforced by the API server while the
+// webhook is unavailable.
+package admissionpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// SingleDataScienceClusterPolicyName is the name of the policy allowing a single DataScienceCluster, and of its binding.
+	SingleDataScienceClusterPolicyName = "odh-single-datasciencecluster"
+	// SingleDSCInitializationPolicyName is the name of the policy allowing a single DSCInitialization, and of its binding.
+	SingleDSCInitializationPolicyName = "odh-single-dscinitialization"
+	// DSCInitializationDeletionPolicyName is the name of the policy keeping the DSCInitialization while a
+	// DataScienceCluster exists, and of its binding.
+	DSCInitializationDeletionPolicyName = "odh-dscinitialization-deletion"
+)
+
+// +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update;delete
+
+// AdmissionPolicyReconciler holds the controller configuration.
+type AdmissionPolicyReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AdmissionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for admission policies.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("admission-policy-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile applies the ValidatingAdmissionPolicies equivalent to the rules of the validating webhook of the operator
+// while the AdmissionPolicies gate is enabled, and removes them otherwise. Clusters not serving
+// ValidatingAdmissionPolicies are reported.
+func (r *AdmissionPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	var errs []error
+	for _, p := range admissionPolicies() {
+		if !featuregate.Enabled(featuregate.AdmissionPolicies) {
+			for _, obj := range []client.Object{p.binding, p.policy} {
+				if err := r.Client.Delete(ctx, obj); err != nil && !k8serr.IsNotFound(err) && !meta.IsNoMatchError(err) {
+					errs = append(errs, err)
+				}
+			}
+			continue
+		}
+
+		err := r.applyAdmissionPolicy(ctx, p.policy, p.binding)
+		if meta.IsNoMatchError(err) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "AdmissionPoliciesIgnored",
+				"ValidatingAdmissionPolicies are not served by the cluster, validation rules are only enforced by the operator webhook")
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply ValidatingAdmissionPolicy %s: %w", p.policy.Name, err))
+		}
+	}
+
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+func (r *AdmissionPolicyReconciler) applyAdmissionPolicy(ctx context.Context, policy *admissionregistrationv1beta1.ValidatingAdmissionPolicy,
+	binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding,
+) error {
+	foundPolicy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(policy), foundPolicy)
+	switch {
+	case k8serr.IsNotFound(err):
+		err = r.Client.Create(ctx, policy)
+	case err == nil && !equality.Semantic.DeepDerivative(policy.Spec, foundPolicy.Spec):
+		foundPolicy.Spec = policy.Spec
+		err = r.Client.Update(ctx, foundPolicy)
+	}
+	if err != nil {
+		return err
+	}
+
+	foundBinding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(binding), foundBinding)
+	switch {
+	case k8serr.IsNotFound(err):
+		return r.Client.Create(ctx, binding)
+	case err != nil:
+		return err
+	case equality.Semantic.DeepDerivative(binding.Spec, foundBinding.Spec):
+		return nil
+	}
+	foundBinding.Spec = binding.Spec
+
+	return r.Client.Update(ctx, foundBinding)
+}
+
+// admissionPolicy is a ValidatingAdmissionPolicy along with its binding.
+type admissionPolicy struct {
+	policy  *admissionregistrationv1beta1.ValidatingAdmissionPolicy
+	binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding
+}
+
+// admissionPolicies returns the policies equivalent to the rules of the validating webhook which depend on the
+// existence of other DataScienceClusters or DSCInitializations. Those are bound to every existing instance as a
+// parameter, so that the validation is evaluated against each of them, and admission is allowed when none exists.
+// The removal of components with workloads depending on them is left to the webhook, as it counts resources of all
+// namespaces, which CEL cannot.
+func admissionPolicies() []admissionPolicy {
+	return []admissionPolicy{
+		newAdmissionPolicy(SingleDataScienceClusterPolicyName, gvk.DataScienceCluster, "datascienceclusters", admissionregistrationv1.Create,
+			gvk.DataScienceCluster, "object.metadata.name == params.metadata.name",
+			"Only one instance of DataScienceCluster object is allowed"),
+		newAdmissionPolicy(SingleDSCInitializationPolicyName, gvk.DSCInitialization, "dscinitializations", admissionregistrationv1.Create,
+			gvk.DSCInitialization, "object.metadata.name == params.metadata.name",
+			"Only one instance of DSCInitialization object is allowed"),
+		newAdmissionPolicy(DSCInitializationDeletionPolicyName, gvk.DSCInitialization, "dscinitializations", admissionregistrationv1.Delete,
+			gvk.DataScienceCluster, "false",
+			"Cannot delete DSCI object when DSC object still exists"),
+	}
+}
+
+// newAdmissionPolicy returns the policy validating the operation on the resource against every instance of the kind
+// of its parameters, along with its binding.
+func newAdmissionPolicy(name string, target schema.GroupVersionKind, resource string, operation admissionregistrationv1.OperationType,
+	param schema.GroupVersionKind, expression, message string,
+) admissionPolicy {
+	objectMeta := metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"}}
+	failurePolicy := admissionregistrationv1beta1.Fail
+	parameterNotFoundAction := admissionregistrationv1beta1.AllowAction
+
+	return admissionPolicy{
+		policy: &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+			ObjectMeta: objectMeta,
+			Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+				FailurePolicy: &failurePolicy,
+				ParamKind:     &admissionregistrationv1beta1.ParamKind{APIVersion: param.GroupVersion().String(), Kind: param.Kind},
+				MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+					ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{operation},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups: []string{target.Group}, APIVersions: []string{target.Version}, Resources: []string{resource},
+							},
+						},
+					}},
+				},
+				Validations: []admissionregistrationv1beta1.Validation{{Expression: expression, Message: message}},
+			},
+		},
+		binding: &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+			ObjectMeta: *objectMeta.DeepCopy(),
+			Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicyBindingSpec{
+				PolicyName: name,
+				ParamRef: &admissionregistrationv1beta1.ParamRef{
+					Selector:                &metav1.LabelSelector{},
+					ParameterNotFoundAction: &parameterNotFoundAction,
+				},
+				ValidationActions: []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Deny},
+			},
+		},
+	}
+}
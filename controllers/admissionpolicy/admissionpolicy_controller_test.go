@@ -0,0 +1,242 @@
+package admissionpolicy
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var policyNames = []string{SingleDataScienceClusterPolicyName, SingleDSCInitializationPolicyName, DSCInitializationDeletionPolicyName}
+
+var _ = Describe("Admission policy controller", func() {
+	var (
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	BeforeEach(func() {
+		objects = []client.Object{&dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &AdmissionPolicyReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), Recorder: recorder}
+		return r.Reconcile(ctx, req)
+	}
+	policy := func(ctx context.Context, name string) (*admissionregistrationv1beta1.ValidatingAdmissionPolicy, error) {
+		policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+		return policy, cli.Get(ctx, client.ObjectKey{Name: name}, policy)
+	}
+	binding := func(ctx context.Context, name string) (*admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding, error) {
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+		return binding, cli.Get(ctx, client.ObjectKey{Name: name}, binding)
+	}
+	noKindMatch := func(obj client.Object) error {
+		switch obj.(type) {
+		case *admissionregistrationv1beta1.ValidatingAdmissionPolicy, *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding:
+			return &meta.NoKindMatchError{GroupKind: admissionregistrationv1beta1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicy").GroupKind()}
+		}
+		return nil
+	}
+
+	DescribeTable("should validate the rules of the webhook against every instance of the parameter kind",
+		func(name string, operation admissionregistrationv1.OperationType, resource, paramKind, expression string) {
+			policies := admissionPolicies()
+			i := slices.IndexFunc(policies, func(p admissionPolicy) bool { return p.policy.Name == name })
+			Expect(i).ToNot(Equal(-1))
+			p := policies[i]
+
+			spec := p.policy.Spec
+			Expect(*spec.FailurePolicy).To(Equal(admissionregistrationv1beta1.Fail))
+			Expect(spec.ParamKind.Kind).To(Equal(paramKind))
+			Expect(spec.MatchConstraints.ResourceRules).To(HaveLen(1))
+			rule := spec.MatchConstraints.ResourceRules[0]
+			Expect(rule.Operations).To(ConsistOf(operation))
+			Expect(rule.Resources).To(ConsistOf(resource))
+			Expect(spec.Validations).To(HaveLen(1))
+			Expect(spec.Validations[0].Expression).To(Equal(expression))
+
+			Expect(p.binding.Name).To(Equal(name))
+			Expect(p.binding.Spec.PolicyName).To(Equal(name))
+			Expect(p.binding.Spec.ParamRef.Selector).To(Equal(&metav1.LabelSelector{}))
+			Expect(*p.binding.Spec.ParamRef.ParameterNotFoundAction).To(Equal(admissionregistrationv1beta1.AllowAction))
+			Expect(p.binding.Spec.ValidationActions).To(ConsistOf(admissionregistrationv1beta1.Deny))
+		},
+		Entry("single DataScienceCluster", SingleDataScienceClusterPolicyName, admissionregistrationv1.Create,
+			"datascienceclusters", "DataScienceCluster", "object.metadata.name == params.metadata.name"),
+		Entry("single DSCInitialization", SingleDSCInitializationPolicyName, admissionregistrationv1.Create,
+			"dscinitializations", "DSCInitialization", "object.metadata.name == params.metadata.name"),
+		Entry("DSCInitialization deletion while a DataScienceCluster exists", DSCInitializationDeletionPolicyName, admissionregistrationv1.Delete,
+			"dscinitializations", "DataScienceCluster", "false"),
+	)
+
+	Context("with the AdmissionPolicies feature enabled", func() {
+		BeforeEach(func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.AdmissionPolicies: true})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(featuregate.Set(nil)).To(Succeed())
+			})
+		})
+
+		It("should create the policies and their bindings", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+			for _, name := range policyNames {
+				created, err := policy(ctx, name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "opendatahub-operator"))
+				createdBinding, err := binding(ctx, name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(createdBinding.Spec.PolicyName).To(Equal(name))
+			}
+		})
+
+		It("should restore the policies changed by hand", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			changed, err := policy(ctx, DSCInitializationDeletionPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+			changed.Spec.Validations[0].Expression = "true"
+			Expect(cli.Update(ctx, changed)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			restored, err := policy(ctx, DSCInitializationDeletionPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restored.Spec.Validations[0].Expression).To(Equal("false"))
+		})
+
+		It("should restore the bindings changed by hand", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			changed, err := binding(ctx, SingleDataScienceClusterPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+			changed.Spec.ValidationActions = []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Audit}
+			Expect(cli.Update(ctx, changed)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			restored, err := binding(ctx, SingleDataScienceClusterPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restored.Spec.ValidationActions).To(ConsistOf(admissionregistrationv1beta1.Deny))
+		})
+
+		It("should not update the policies once up to date", func(ctx context.Context) {
+			updates := 0
+			funcs.Update = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				updates++
+				return cli.Update(ctx, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(updates).To(BeZero())
+		})
+
+		It("should report the clusters not serving ValidatingAdmissionPolicies", func(ctx context.Context) {
+			funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := noKindMatch(obj); err != nil {
+					return err
+				}
+				return cli.Get(ctx, key, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(HavePrefix("Warning AdmissionPoliciesIgnored "))
+		})
+
+		It("should report the policies failing to be applied", func(ctx context.Context) {
+			funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetName() == SingleDSCInitializationPolicyName {
+					return errors.New("forbidden")
+				}
+				return cli.Create(ctx, obj, opts...)
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to apply ValidatingAdmissionPolicy " + SingleDSCInitializationPolicyName)))
+			_, err = policy(ctx, SingleDataScienceClusterPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = policy(ctx, DSCInitializationDeletionPolicyName)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+			objects = nil
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			_, err := policy(ctx, SingleDataScienceClusterPolicyName)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("with the AdmissionPolicies feature disabled", func() {
+		It("should leave validation to the webhook, removing the policies", func(ctx context.Context) {
+			for _, p := range admissionPolicies() {
+				objects = append(objects, p.policy, p.binding)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			for _, name := range policyNames {
+				_, err := policy(ctx, name)
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+				_, err = binding(ctx, name)
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			}
+		})
+
+		It("should succeed without policies", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		})
+
+		It("should succeed on clusters not serving ValidatingAdmissionPolicies", func(ctx context.Context) {
+			funcs.Delete = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if err := noKindMatch(obj); err != nil {
+					return err
+				}
+				return cli.Delete(ctx, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should report the policies failing to be removed", func(ctx context.Context) {
+			funcs.Delete = func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				return errors.New("forbidden")
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("forbidden")))
+		})
+	})
+})
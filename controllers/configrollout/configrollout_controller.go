@@ -0,0 +1,262 @@
+// Package configrollout contains controller logic rolling component Deployments out when the ConfigMaps or Secrets
+// consumed by their pods change, e.g. after editing the dashboard config or the trusted CA bundle.
+package configrollout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	configMapKind = "ConfigMap"
+	secretKind    = "Secret"
+)
+
+// ConfigRolloutReconciler holds the controller configuration.
+type ConfigRolloutReconciler struct {
+	Client    client.Client
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates of Deployments are ignored.
+// ConfigMaps and Secrets are only mapped to component Deployments of their namespace, which are cached in the
+// namespaces of the operator only.
+func (r *ConfigRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for component config rollouts.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("config-rollout-controller").
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.NewPredicateFuncs(isComponentDeployment), predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.watchConsumedObject(configMapKind))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.watchConsumedObject(secretKind))).
+		Complete(r)
+}
+
+// Reconcile records the checksum of the ConfigMaps and Secrets consumed by the Deployment, and rolls it out when the
+// checksum differs from the one recorded. The first checksum is only recorded, not to restart every component when
+// the operator is installed or upgraded.
+func (r *ConfigRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("deployment", req.NamespacedName)
+	if !featuregate.Enabled(featuregate.ComponentConfigRollout) {
+		return ctrl.Result{}, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, req.NamespacedName, deployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if deployment.GetDeletionTimestamp() != nil || cluster.IsUnmanaged(deployment) {
+		return ctrl.Result{}, nil
+	}
+
+	hash, err := r.configHash(ctx, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	recorded, found := deployment.GetAnnotations()[annotations.ConfigHash]
+	if recorded == hash {
+		return ctrl.Result{}, nil
+	}
+
+	// the pod template is left untouched when recording the first checksum, so pods are not restarted
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, annotations.ConfigHash, hash)
+	if found {
+		patch = fmt.Sprintf(`{"metadata":{"annotations":{%[1]q:%[2]q}},"spec":{"template":{"metadata":{"annotations":{%[1]q:%[2]q}}}}}`,
+			annotations.ConfigHash, hash)
+	}
+	if err := r.Client.Patch(ctx, deployment, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if found {
+		log.Info("ConfigMaps or Secrets consumed by Deployment changed, rolling it out")
+		r.Recorder.Event(deployment, corev1.EventTypeNormal, "ConfigRollout", "ConfigMaps or Secrets consumed by the pods changed, rolling out")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// configHash returns the checksum of the data of the ConfigMaps and Secrets consumed by pods of the Deployment.
+// Missing optional ConfigMaps and Secrets are part of the checksum, so their creation rolls the Deployment out as well.
+func (r *ConfigRolloutReconciler) configHash(ctx context.Context, deployment *appsv1.Deployment) (string, error) {
+	hash := sha256.New()
+	for _, ref := range consumedObjects(&deployment.Spec.Template.Spec) {
+		key := client.ObjectKey{Namespace: deployment.Namespace, Name: ref.name}
+		data := map[string][]byte{}
+
+		var err error
+		switch ref.kind {
+		case configMapKind:
+			configMap := &corev1.ConfigMap{}
+			if err = r.APIReader.Get(ctx, key, configMap); err == nil {
+				for k, v := range configMap.Data {
+					data[k] = []byte(v)
+				}
+				for k, v := range configMap.BinaryData {
+					data[k] = v
+				}
+			}
+		case secretKind:
+			secret := &corev1.Secret{}
+			if err = r.APIReader.Get(ctx, key, secret); err == nil {
+				data = secret.Data
+			}
+		}
+		if err != nil && !k8serr.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get %s %s: %w", ref.kind, key, err)
+		}
+
+		fmt.Fprintf(hash, "%s/%s\n", ref.kind, ref.name)
+		writeData(hash, data)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeData(w io.Writer, data map[string][]byte) {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s=%d:", k, len(data[k]))
+		_, _ = w.Write(data[k])
+	}
+}
+
+type objectRef struct {
+	kind string
+	name string
+}
+
+// consumedObjects returns the ConfigMaps and Secrets mounted as volumes or read as environment variables by the pods,
+// sorted and without duplicates.
+func consumedObjects(spec *corev1.PodSpec) []objectRef {
+	refs := map[objectRef]struct{}{}
+	add := func(kind, name string) {
+		if name != "" {
+			refs[objectRef{kind: kind, name: name}] = struct{}{}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			add(configMapKind, volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			add(secretKind, volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add(configMapKind, source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					add(secretKind, source.Secret.Name)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				add(configMapKind, envFrom.ConfigMapRef.Name)
+			}
+			if envFrom.SecretRef != nil {
+				add(secretKind, envFrom.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add(configMapKind, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				add(secretKind, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+
+	sorted := make([]objectRef, 0, len(refs))
+	for ref := range refs {
+		sorted = append(sorted, ref)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].kind != sorted[j].kind {
+			return sorted[i].kind < sorted[j].kind
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	return sorted
+}
+
+// watchConsumedObject enqueues the component Deployments of the namespace of the ConfigMap or Secret consuming it.
+func (r *ConfigRolloutReconciler) watchConsumedObject(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		deployments := &appsv1.DeploymentList{}
+		if err := r.Client.List(ctx, deployments, client.InNamespace(obj.GetNamespace())); err != nil {
+			// namespaces not cached hold no component Deployments
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			if !isComponentDeployment(deployment) {
+				continue
+			}
+			for _, ref := range consumedObjects(&deployment.Spec.Template.Spec) {
+				if ref.kind == kind && ref.name == obj.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(deployment)})
+					break
+				}
+			}
+		}
+
+		return requests
+	}
+}
+
+// isComponentDeployment tells whether the object has been deployed for a component.
+func isComponentDeployment(obj client.Object) bool {
+	for k, v := range obj.GetLabels() {
+		if strings.HasPrefix(k, labels.ODHAppPrefix+"/") && v == "true" {
+			return true
+		}
+	}
+
+	return false
+}
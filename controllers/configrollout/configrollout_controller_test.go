@@ -0,0 +1,219 @@
+package configrollout
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func dashboardDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "odh-dashboard",
+			Namespace: "opendatahub",
+			Labels:    map[string]string{"app.opendatahub.io/dashboard": "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{Name: "ca", VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "odh-trusted-ca-bundle"}},
+						}},
+						{Name: "proxy-tls", VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "dashboard-proxy-tls"},
+						}},
+					},
+					Containers: []corev1.Container{{
+						Name: "odh-dashboard",
+						Env: []corev1.EnvVar{{
+							Name: "CLIENT_SECRET",
+							ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "dashboard-oauth-client"},
+								Key:                  "secret",
+							}},
+						}},
+						EnvFrom: []corev1.EnvFromSource{{
+							ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "odh-trusted-ca-bundle"}},
+						}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Consumed objects", func() {
+	It("should be the ConfigMaps and Secrets mounted or read from the environment, sorted and without duplicates", func() {
+		Expect(consumedObjects(&dashboardDeployment().Spec.Template.Spec)).To(Equal([]objectRef{
+			{kind: configMapKind, name: "odh-trusted-ca-bundle"},
+			{kind: secretKind, name: "dashboard-oauth-client"},
+			{kind: secretKind, name: "dashboard-proxy-tls"},
+		}))
+	})
+
+	It("should include projected volumes and init containers", func() {
+		spec := &corev1.PodSpec{
+			Volumes: []corev1.Volume{{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}}},
+					{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}}},
+				},
+			}}}},
+			InitContainers: []corev1.Container{{
+				Name: "init",
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init-credentials"}},
+				}},
+				Env: []corev1.EnvVar{{Name: "PLAIN", Value: "value"}, {Name: "FROM_CONFIG", ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "init-config"}, Key: "key"},
+				}}},
+			}},
+		}
+
+		Expect(consumedObjects(spec)).To(Equal([]objectRef{
+			{kind: configMapKind, name: "config"},
+			{kind: configMapKind, name: "init-config"},
+			{kind: secretKind, name: "init-credentials"},
+			{kind: secretKind, name: "token"},
+		}))
+	})
+})
+
+var _ = Describe("Config rollout controller", func() {
+	var (
+		deployment *appsv1.Deployment
+		caBundle   *corev1.ConfigMap
+		cli        client.Client
+		recorder   *record.FakeRecorder
+		req        = ctrl.Request{NamespacedName: client.ObjectKey{Name: "odh-dashboard", Namespace: "opendatahub"}}
+	)
+
+	reconcile := func(ctx context.Context) *appsv1.Deployment {
+		GinkgoHelper()
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, caBundle).Build()
+		}
+		r := &ConfigRolloutReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+
+		reconciled := &appsv1.Deployment{}
+		Expect(cli.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		return reconciled
+	}
+	updateCABundle := func(ctx context.Context, value string) {
+		GinkgoHelper()
+		caBundle.Data["ca-bundle.crt"] = value
+		Expect(cli.Update(ctx, caBundle)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		deployment = dashboardDeployment()
+		caBundle = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "odh-trusted-ca-bundle", Namespace: "opendatahub"},
+			Data:       map[string]string{"ca-bundle.crt": "first"},
+		}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should only record the first checksum, not to restart pods", func(ctx context.Context) {
+		reconciled := reconcile(ctx)
+
+		Expect(reconciled.GetAnnotations()).To(HaveKeyWithValue(annotations.ConfigHash, Not(BeEmpty())))
+		Expect(reconciled.Spec.Template.GetAnnotations()).ToNot(HaveKey(annotations.ConfigHash))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should not roll the Deployment out when nothing changed", func(ctx context.Context) {
+		first := reconcile(ctx).GetAnnotations()[annotations.ConfigHash]
+
+		reconciled := reconcile(ctx)
+		Expect(reconciled.GetAnnotations()).To(HaveKeyWithValue(annotations.ConfigHash, first))
+		Expect(reconciled.Spec.Template.GetAnnotations()).ToNot(HaveKey(annotations.ConfigHash))
+	})
+
+	It("should roll the Deployment out once a consumed ConfigMap changed", func(ctx context.Context) {
+		first := reconcile(ctx).GetAnnotations()[annotations.ConfigHash]
+		updateCABundle(ctx, "second")
+
+		reconciled := reconcile(ctx)
+		second := reconciled.GetAnnotations()[annotations.ConfigHash]
+		Expect(second).ToNot(Equal(first))
+		Expect(reconciled.Spec.Template.GetAnnotations()).To(HaveKeyWithValue(annotations.ConfigHash, second))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConfigRollout")))
+	})
+
+	It("should roll the Deployment out once a missing Secret is created", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dashboard-proxy-tls", Namespace: "opendatahub"},
+			Data:       map[string][]byte{"tls.crt": []byte("cert")},
+		})).To(Succeed())
+
+		Expect(reconcile(ctx).Spec.Template.GetAnnotations()).To(HaveKey(annotations.ConfigHash))
+	})
+
+	When("the Deployment is not managed by the operator", func() {
+		BeforeEach(func() {
+			deployment.SetAnnotations(map[string]string{annotations.ManagedByODHOperator: "false"})
+		})
+
+		It("should leave it untouched", func(ctx context.Context) {
+			Expect(reconcile(ctx).GetAnnotations()).ToNot(HaveKey(annotations.ConfigHash))
+		})
+	})
+
+	When("the feature gate is disabled", func() {
+		BeforeEach(func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.ComponentConfigRollout: false})).To(Succeed())
+			DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+		})
+
+		It("should leave Deployments untouched", func(ctx context.Context) {
+			Expect(reconcile(ctx).GetAnnotations()).ToNot(HaveKey(annotations.ConfigHash))
+		})
+	})
+
+	Context("watching ConfigMaps and Secrets", func() {
+		BeforeEach(func() {
+			other := dashboardDeployment()
+			other.Name = "not-a-component"
+			other.Labels = nil
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, other).Build()
+		})
+
+		DescribeTable("should only enqueue component Deployments consuming them",
+			func(ctx context.Context, kind, name string, expected []ctrl.Request) {
+				r := &ConfigRolloutReconciler{Client: cli, Log: logr.Discard()}
+				obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opendatahub"}}
+
+				Expect(r.watchConsumedObject(kind)(ctx, obj)).To(Equal(expected))
+			},
+			Entry("consumed ConfigMap", configMapKind, "odh-trusted-ca-bundle", []ctrl.Request{req}),
+			Entry("consumed Secret", secretKind, "dashboard-proxy-tls", []ctrl.Request{req}),
+			Entry("Secret of the name of a consumed ConfigMap", secretKind, "odh-trusted-ca-bundle", nil),
+			Entry("other ConfigMap", configMapKind, "other", nil),
+		)
+	})
+})
@@ -0,0 +1,13 @@
+package configrollout
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigRollout(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config rollout controller suite")
+}
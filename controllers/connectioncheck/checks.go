@@ -0,0 +1,316 @@
+package connectioncheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	diagnosticsv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/diagnostics/v1alpha1"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	// hash of the empty payload of the requests signed for object storage
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// outcome is the result of a check, without the name of its connection and the time it took.
+type outcome struct {
+	reachable     bool
+	authenticated *bool
+	failure       string
+	message       string
+}
+
+func unreachable(err error) outcome {
+	return outcome{failure: err.Error()}
+}
+
+// checkS3 lists the objects of the bucket, or the buckets when no bucket is set, signed with the credentials when set.
+func checkS3(ctx context.Context, cli *http.Client, conn diagnosticsv1alpha1.Connection, credentials map[string][]byte) outcome {
+	endpoint, err := url.Parse(strings.TrimSuffix(conn.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return outcome{failure: fmt.Sprintf("invalid endpoint %q, expected a URL", conn.Endpoint)}
+	}
+	target := *endpoint
+	if conn.Bucket != "" {
+		target.Path += "/" + conn.Bucket
+		target.RawQuery = url.Values{"list-type": {"2"}, "max-keys": {"1"}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return unreachable(err)
+	}
+	if credentials != nil {
+		region := conn.Region
+		if region == "" {
+			region = defaultS3Region
+		}
+		signV4(req, string(credentials["AWS_ACCESS_KEY_ID"]), string(credentials["AWS_SECRET_ACCESS_KEY"]), region, time.Now())
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return unreachable(err)
+	}
+	defer resp.Body.Close()
+	result := outcome{reachable: true}
+	if credentials == nil {
+		result.message = fmt.Sprintf("object storage responded with %s, credentials not checked", resp.Status)
+		return result
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		result.authenticated = ptr.To(true)
+		result.message = "credentials accepted"
+		if conn.Bucket != "" {
+			result.message = fmt.Sprintf("objects of bucket %s listed", conn.Bucket)
+		}
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		result.authenticated = ptr.To(false)
+		result.failure = "credentials rejected: " + s3Error(resp)
+	default:
+		result.failure = fmt.Sprintf("object storage responded with %s: %s", resp.Status, s3Error(resp))
+	}
+
+	return result
+}
+
+// s3Error returns the code and message of the error returned by the object storage.
+func s3Error(resp *http.Response) string {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err != nil || body.Code == "" {
+		return resp.Status
+	}
+	if body.Message == "" {
+		return body.Code
+	}
+
+	return body.Code + ", " + body.Message
+}
+
+// signV4 signs the request with AWS Signature Version 4, as expected by S3 compatible object storage.
+func signV4(req *http.Request, accessKeyID, secretAccessKey, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + emptyPayloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{amzDate[:8], region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// checkDatabase opens a TCP connection to the database. Credentials are not checked, it would take its driver.
+func checkDatabase(ctx context.Context, timeout time.Duration, conn diagnosticsv1alpha1.Connection) outcome {
+	address := conn.Endpoint
+	if endpoint, err := url.Parse(conn.Endpoint); err == nil && endpoint.Host != "" {
+		address = endpoint.Host
+	}
+	if _, port, err := net.SplitHostPort(address); err != nil || port == "" {
+		return outcome{failure: fmt.Sprintf("invalid endpoint %q, expected host:port", conn.Endpoint)}
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	connection, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return unreachable(err)
+	}
+	connection.Close()
+
+	return outcome{reachable: true, message: "TCP connection established to " + address}
+}
+
+// checkOIDC fetches the discovery document of the issuer, which has to name it.
+func checkOIDC(ctx context.Context, cli *http.Client, conn diagnosticsv1alpha1.Connection) outcome {
+	issuer := strings.TrimSuffix(conn.Endpoint, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return unreachable(err)
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return unreachable(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return outcome{reachable: true, failure: "discovery document not served: " + resp.Status}
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&discovery); err != nil {
+		return outcome{reachable: true, failure: "invalid discovery document: " + err.Error()}
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return outcome{reachable: true, failure: fmt.Sprintf("discovery document names issuer %q", discovery.Issuer)}
+	}
+
+	return outcome{reachable: true, message: "discovery document served, keys at " + discovery.JWKSURI}
+}
+
+// checkRegistry queries the v2 API of the registry, authenticating with the credentials when set, either with basic
+// authentication or with a token of the authorization server the registry points to.
+func checkRegistry(ctx context.Context, cli *http.Client, conn diagnosticsv1alpha1.Connection, credentials map[string][]byte) outcome {
+	base := strings.TrimSuffix(conn.Endpoint, "/")
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	endpoint, err := url.Parse(base)
+	if err != nil || endpoint.Host == "" {
+		return outcome{failure: fmt.Sprintf("invalid endpoint %q, expected a host", conn.Endpoint)}
+	}
+
+	resp, err := get(ctx, cli, base+"/v2/", "")
+	if err != nil {
+		return unreachable(err)
+	}
+	resp.Body.Close()
+	result := outcome{reachable: true}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		result.message = "registry allows anonymous access"
+		return result
+	case resp.StatusCode != http.StatusUnauthorized:
+		result.failure = "registry API not served: " + resp.Status
+		return result
+	case credentials == nil:
+		result.message = "registry requires authentication, credentials not checked"
+		return result
+	}
+
+	auth, err := registryAuth(credentials[corev1.DockerConfigJsonKey], endpoint.Host)
+	if err != nil {
+		result.failure = err.Error()
+		return result
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if scheme, params, _ := strings.Cut(challenge, " "); strings.EqualFold(scheme, "Bearer") {
+		resp, err = get(ctx, cli, tokenURL(params), "Basic "+auth)
+	} else {
+		resp, err = get(ctx, cli, base+"/v2/", "Basic "+auth)
+	}
+	if err != nil {
+		result.failure = "failed to authenticate: " + err.Error()
+		return result
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.authenticated = ptr.To(true)
+		result.message = "credentials accepted"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		result.authenticated = ptr.To(false)
+		result.failure = "credentials rejected: " + resp.Status
+	default:
+		result.failure = "failed to authenticate: " + resp.Status
+	}
+
+	return result
+}
+
+func get(ctx context.Context, cli *http.Client, target, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return cli.Do(req)
+}
+
+// tokenURL returns the URL of the token of the authorization server from the parameters of a Bearer challenge, e.g.
+// realm="https://auth.example.com/token",service="registry.example.com".
+func tokenURL(params string) string {
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(value, `"`)
+		if key == "realm" {
+			realm = value
+			continue
+		}
+		values.Set(key, value)
+	}
+	if len(values) == 0 {
+		return realm
+	}
+
+	return realm + "?" + values.Encode()
+}
+
+// registryAuth returns the base64 encoded "username:password" of the registry host in the .dockerconfigjson.
+func registryAuth(dockerConfig []byte, host string) (string, error) {
+	var config struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(dockerConfig, &config); err != nil {
+		return "", fmt.Errorf("invalid %s in credentials Secret: %w", corev1.DockerConfigJsonKey, err)
+	}
+	for registry, entry := range config.Auths {
+		registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+		if name, _, _ := strings.Cut(registry, "/"); name != host {
+			continue
+		}
+		if entry.Auth != "" {
+			return entry.Auth, nil
+		}
+		return base64.StdEncoding.EncodeToString([]byte(entry.Username + ":" + entry.Password)), nil
+	}
+
+	return "", errors.New("no credentials for " + host + " in credentials Secret")
+}
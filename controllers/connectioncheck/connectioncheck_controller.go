@@ -0,0 +1,185 @@
+// Package connectioncheck contains controller logic testing the connectivity to, and authentication with, the external
+// systems the components depend on, e.g. object storage, databases, OIDC issuers and mirror registries, from within
+// the cluster, and recording the results in ConnectionChecks.
+package connectioncheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	diagnosticsv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/diagnostics/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+const (
+	// ConditionConnectionsReady tells whether all connections of the ConnectionCheck succeeded.
+	ConditionConnectionsReady conditionsv1.ConditionType = "ConnectionsReady"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// +kubebuilder:rbac:groups="diagnostics.opendatahub.io",resources=connectionchecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="diagnostics.opendatahub.io",resources=connectionchecks/status,verbs=get;update;patch
+
+// ConnectionCheckReconciler holds the controller configuration.
+type ConnectionCheckReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads the credentials Secrets, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConnectionCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for connection checks.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("connection-check-controller").
+		For(&diagnosticsv1alpha1.ConnectionCheck{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile checks the connections of the ConnectionCheck and records their results. Failed connections are not
+// retried before the interval of the ConnectionCheck, if any, as they are reported and not a reconciliation error.
+func (r *ConnectionCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &diagnosticsv1alpha1.ConnectionCheck{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	timeout := defaultTimeout
+	if instance.Spec.Timeout != nil && instance.Spec.Timeout.Duration > 0 {
+		timeout = instance.Spec.Timeout.Duration
+	}
+	results := make([]diagnosticsv1alpha1.ConnectionResult, 0, len(instance.Spec.Connections))
+	var failed []string
+	for _, conn := range instance.Spec.Connections {
+		result := r.check(ctx, conn, timeout)
+		if !result.Succeeded {
+			failed = append(failed, conn.Name)
+		}
+		results = append(results, result)
+	}
+
+	condition := conditionsv1.Condition{
+		Type:    ConditionConnectionsReady,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ConnectionsSucceeded",
+		Message: fmt.Sprintf("%d connections succeeded", len(results)),
+	}
+	phase := status.PhaseReady
+	if len(failed) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "ConnectionsFailed"
+		condition.Message = "Connections failed: " + strings.Join(failed, ", ")
+		phase = status.PhaseError
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "ConnectionsFailed", condition.Message)
+	}
+
+	now := metav1.Now()
+	if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *diagnosticsv1alpha1.ConnectionCheck) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, condition)
+		saved.Status.Phase = phase
+		saved.Status.LastCheckTime = &now
+		saved.Status.Results = results
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if instance.Spec.Interval != nil && instance.Spec.Interval.Duration > 0 {
+		return ctrl.Result{RequeueAfter: instance.Spec.Interval.Duration}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// check tests the connection within the timeout.
+func (r *ConnectionCheckReconciler) check(ctx context.Context, conn diagnosticsv1alpha1.Connection, timeout time.Duration) diagnosticsv1alpha1.ConnectionResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+
+	var result outcome
+	credentials, err := r.credentials(ctx, conn)
+	if err != nil {
+		result = outcome{failure: err.Error()}
+	} else {
+		cli := &http.Client{Timeout: timeout}
+		switch conn.Type {
+		case diagnosticsv1alpha1.S3Connection:
+			result = checkS3(ctx, cli, conn, credentials)
+		case diagnosticsv1alpha1.DatabaseConnection:
+			result = checkDatabase(ctx, timeout, conn)
+		case diagnosticsv1alpha1.OIDCConnection:
+			result = checkOIDC(ctx, cli, conn)
+		case diagnosticsv1alpha1.RegistryConnection:
+			result = checkRegistry(ctx, cli, conn, credentials)
+		default:
+			result = outcome{failure: fmt.Sprintf("unsupported connection type %q", conn.Type)}
+		}
+	}
+
+	message := result.message
+	if result.failure != "" {
+		message = result.failure
+		r.Log.Info("connection failed", "connection", conn.Name, "type", conn.Type, "endpoint", conn.Endpoint, "cause", result.failure)
+	}
+
+	return diagnosticsv1alpha1.ConnectionResult{
+		Name:          conn.Name,
+		Succeeded:     result.failure == "",
+		Reachable:     result.reachable,
+		Authenticated: result.authenticated,
+		Latency:       metav1.Duration{Duration: time.Since(start).Round(time.Millisecond)},
+		Message:       message,
+	}
+}
+
+// credentials returns the data of the credentials Secret of the connection in the applications namespace, nil when
+// the connection has none or its credentials are not checked.
+func (r *ConnectionCheckReconciler) credentials(ctx context.Context, conn diagnosticsv1alpha1.Connection) (map[string][]byte, error) {
+	if conn.CredentialsSecretName == "" || (conn.Type != diagnosticsv1alpha1.S3Connection && conn.Type != diagnosticsv1alpha1.RegistryConnection) {
+		return nil, nil
+	}
+
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: conn.CredentialsSecretName, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get credentials Secret %s/%s: %w", namespace, conn.CredentialsSecretName, err)
+	}
+	required := []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}
+	if conn.Type == diagnosticsv1alpha1.RegistryConnection {
+		required = []string{corev1.DockerConfigJsonKey}
+	}
+	for _, key := range required {
+		if _, exists := secret.Data[key]; !exists {
+			return nil, fmt.Errorf("credentials Secret %s/%s has no %s", namespace, secret.Name, key)
+		}
+	}
+
+	return secret.Data, nil
+}
@@ -0,0 +1,225 @@
+package connectioncheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	diagnosticsv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/diagnostics/v1alpha1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func credentialsSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opendatahub"}, Data: data}
+}
+
+var _ = Describe("Connection check controller", func() {
+	var (
+		objectStorage *httptest.Server
+		registry      *httptest.Server
+		database      net.Listener
+		instance      *diagnosticsv1alpha1.ConnectionCheck
+		objects       []client.Object
+		funcs         interceptor.Funcs
+		cli           client.Client
+		recorder      *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		objectStorage = objectStorageServer()
+		DeferCleanup(objectStorage.Close)
+		registry = registryServer(true)
+		DeferCleanup(registry.Close)
+		var err error
+		database, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() { _ = database.Close() })
+
+		instance = &diagnosticsv1alpha1.ConnectionCheck{ObjectMeta: metav1.ObjectMeta{Name: "external-systems"}}
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+				Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "opendatahub"},
+			},
+			credentialsSecret("s3-valid", s3Credentials("valid")),
+			credentialsSecret("s3-invalid", s3Credentials("invalid")),
+			credentialsSecret("mirror-pull-secret", dockerConfig(strings.TrimPrefix(registry.URL, "http://"), "mirror", "secret")),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(diagnosticsv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&diagnosticsv1alpha1.ConnectionCheck{}).
+				WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &ConnectionCheckReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+	}
+	checked := func(ctx context.Context) *diagnosticsv1alpha1.ConnectionCheck {
+		GinkgoHelper()
+		checked := &diagnosticsv1alpha1.ConnectionCheck{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), checked)).To(Succeed())
+		return checked
+	}
+	results := func(ctx context.Context) map[string]diagnosticsv1alpha1.ConnectionResult {
+		GinkgoHelper()
+		results := map[string]diagnosticsv1alpha1.ConnectionResult{}
+		for _, result := range checked(ctx).Status.Results {
+			results[result.Name] = result
+		}
+		return results
+	}
+	connect := func(connections ...diagnosticsv1alpha1.Connection) {
+		instance.Spec.Connections = connections
+	}
+
+	It("should report the connections succeeding", func(ctx context.Context) {
+		connect(
+			diagnosticsv1alpha1.Connection{Name: "models", Type: diagnosticsv1alpha1.S3Connection, Endpoint: objectStorage.URL,
+				Bucket: "models", CredentialsSecretName: "s3-valid"},
+			diagnosticsv1alpha1.Connection{Name: "mirror", Type: diagnosticsv1alpha1.RegistryConnection, Endpoint: registry.URL,
+				CredentialsSecretName: "mirror-pull-secret"},
+			diagnosticsv1alpha1.Connection{Name: "metadata-db", Type: diagnosticsv1alpha1.DatabaseConnection,
+				Endpoint: "mysql://" + database.Addr().String() + "/mlmd"},
+		)
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		check := checked(ctx)
+		Expect(check.Status.Phase).To(Equal(status.PhaseReady))
+		Expect(check.Status.LastCheckTime).ToNot(BeNil())
+		condition := conditionsv1.FindStatusCondition(check.Status.Conditions, ConditionConnectionsReady)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Message).To(Equal("3 connections succeeded"))
+		Expect(recorder.Events).To(BeEmpty())
+
+		Expect(check.Status.Results).To(HaveLen(3))
+		for _, result := range check.Status.Results {
+			Expect(result.Succeeded).To(BeTrue(), result.Name)
+			Expect(result.Reachable).To(BeTrue(), result.Name)
+		}
+		Expect(results(ctx)["models"].Authenticated).To(Equal(ptr.To(true)))
+		Expect(results(ctx)["mirror"].Authenticated).To(Equal(ptr.To(true)))
+		Expect(results(ctx)["metadata-db"].Authenticated).To(BeNil())
+	})
+
+	It("should report the connections failing", func(ctx context.Context) {
+		connect(
+			diagnosticsv1alpha1.Connection{Name: "models", Type: diagnosticsv1alpha1.S3Connection, Endpoint: objectStorage.URL,
+				CredentialsSecretName: "s3-valid"},
+			diagnosticsv1alpha1.Connection{Name: "pipelines", Type: diagnosticsv1alpha1.S3Connection, Endpoint: objectStorage.URL,
+				CredentialsSecretName: "s3-invalid"},
+			diagnosticsv1alpha1.Connection{Name: "queue", Type: "Kafka", Endpoint: "kafka:9092"},
+		)
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		check := checked(ctx)
+		Expect(check.Status.Phase).To(Equal(status.PhaseError))
+		condition := conditionsv1.FindStatusCondition(check.Status.Conditions, ConditionConnectionsReady)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("ConnectionsFailed"))
+		Expect(condition.Message).To(Equal("Connections failed: pipelines, queue"))
+		Expect(<-recorder.Events).To(Equal("Warning ConnectionsFailed Connections failed: pipelines, queue"))
+
+		pipelines := results(ctx)["pipelines"]
+		Expect(pipelines.Succeeded).To(BeFalse())
+		Expect(pipelines.Reachable).To(BeTrue())
+		Expect(pipelines.Authenticated).To(Equal(ptr.To(false)))
+		Expect(pipelines.Message).To(ContainSubstring("InvalidAccessKeyId"))
+		Expect(results(ctx)["queue"].Message).To(Equal(`unsupported connection type "Kafka"`))
+	})
+
+	DescribeTable("should report credentials which cannot be read",
+		func(ctx context.Context, conn diagnosticsv1alpha1.Connection, message string) {
+			connect(conn)
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			result := results(ctx)[conn.Name]
+			Expect(result.Succeeded).To(BeFalse())
+			Expect(result.Reachable).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring(message))
+		},
+		Entry("missing Secret", diagnosticsv1alpha1.Connection{Name: "models", Type: diagnosticsv1alpha1.S3Connection,
+			Endpoint: "https://s3.example.com", CredentialsSecretName: "missing"},
+			"failed to get credentials Secret opendatahub/missing"),
+		Entry("Secret without access key", diagnosticsv1alpha1.Connection{Name: "models", Type: diagnosticsv1alpha1.S3Connection,
+			Endpoint: "https://s3.example.com", CredentialsSecretName: "mirror-pull-secret"},
+			"credentials Secret opendatahub/mirror-pull-secret has no AWS_ACCESS_KEY_ID"),
+		Entry("Secret without docker config", diagnosticsv1alpha1.Connection{Name: "mirror", Type: diagnosticsv1alpha1.RegistryConnection,
+			Endpoint: "quay.io", CredentialsSecretName: "s3-valid"},
+			"credentials Secret opendatahub/s3-valid has no .dockerconfigjson"),
+	)
+
+	It("should not read the credentials of connections not checking them", func(ctx context.Context) {
+		connect(diagnosticsv1alpha1.Connection{Name: "metadata-db", Type: diagnosticsv1alpha1.DatabaseConnection,
+			Endpoint: database.Addr().String(), CredentialsSecretName: "missing"})
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		Expect(results(ctx)["metadata-db"].Succeeded).To(BeTrue())
+	})
+
+	It("should check the connections again at the interval", func(ctx context.Context) {
+		instance.Spec.Interval = &metav1.Duration{Duration: 30 * time.Minute}
+		connect(diagnosticsv1alpha1.Connection{Name: "metadata-db", Type: diagnosticsv1alpha1.DatabaseConnection, Endpoint: database.Addr().String()})
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Minute}))
+	})
+
+	It("should give up the connections exceeding the timeout", func(ctx context.Context) {
+		instance.Spec.Timeout = &metav1.Duration{Duration: 50 * time.Millisecond}
+		slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { time.Sleep(time.Second) }))
+		DeferCleanup(slow.Close)
+		connect(diagnosticsv1alpha1.Connection{Name: "sso", Type: diagnosticsv1alpha1.OIDCConnection, Endpoint: slow.URL})
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		result := results(ctx)["sso"]
+		Expect(result.Succeeded).To(BeFalse())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(result.Latency.Duration).To(BeNumerically("<", time.Second))
+	})
+
+	It("should fail when the results cannot be recorded", func(ctx context.Context) {
+		funcs.SubResourceUpdate = func(context.Context, client.Client, string, client.Object, ...client.SubResourceUpdateOption) error {
+			return errors.New("forbidden")
+		}
+
+		_, err := reconcile(ctx)
+		Expect(err).To(MatchError(ContainSubstring("forbidden")))
+	})
+
+	It("should ignore a deleted ConnectionCheck", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+})
@@ -0,0 +1,13 @@
+package connectioncheck
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConnectionCheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Connection check suite")
+}
@@ -0,0 +1,253 @@
+// Package costattribution contains controller logic stamping the cost-attribution labels of data science projects on
+// their pods, and reporting the CPU, memory and GPUs used by each project in the UsageReport and in metrics, for
+// chargeback without a separate agent.
+package costattribution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ReportName is the name of the UsageReport maintained by the operator.
+	ReportName = "default-usage-report"
+	// Pods are not watched, data science projects are scanned periodically.
+	scanInterval = 15 * time.Minute
+)
+
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=usagereports,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=usagereports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=list
+
+// CostAttributionReconciler holds the controller configuration.
+type CostAttributionReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists the pods of data science projects and their metrics, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CostAttributionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for cost attribution.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cost-attribution-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile stamps the cost-attribution labels of the data science projects on their pods, and reports the resources
+// requested and used by each project in the UsageReport. It requeues to account for pods created in the meantime.
+func (r *CostAttributionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !instance.Spec.CostAttributionEnabled() {
+		observe(nil)
+		report := &inventoryv1alpha1.UsageReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName}}
+		return ctrl.Result{}, client.IgnoreNotFound(r.Client.Delete(ctx, report))
+	}
+	keys := append([]string{labels.ODH.CostCenter}, instance.Spec.CostAttribution.Labels...)
+
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+	usages := []inventoryv1alpha1.ProjectUsage{}
+	var errs []error
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		if project.GetDeletionTimestamp() != nil {
+			continue
+		}
+		usage, err := r.account(ctx, project, keys)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		usages = append(usages, usage)
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].Namespace < usages[j].Namespace })
+
+	observe(usages)
+	if err := r.publish(ctx, usages); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "CostAttributionFailed", "Failed to attribute the costs of data science projects: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: scanInterval}, nil
+}
+
+// account stamps the cost-attribution labels of the project on its pods which are not labelled with them already, and
+// returns the resources requested and used by them.
+func (r *CostAttributionReconciler) account(ctx context.Context, project *corev1.Namespace, keys []string) (inventoryv1alpha1.ProjectUsage, error) {
+	usage := inventoryv1alpha1.ProjectUsage{Namespace: project.Name}
+	for _, key := range keys {
+		if value, found := project.Labels[key]; found {
+			if usage.Attribution == nil {
+				usage.Attribution = map[string]string{}
+			}
+			usage.Attribution[key] = value
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, pods, client.InNamespace(project.Name)); err != nil {
+		return usage, fmt.Errorf("failed to list pods of namespace %s: %w", project.Name, err)
+	}
+	var errs []error
+	requests := corev1.ResourceList{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		usage.Pods++
+		for _, container := range pod.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				if name == corev1.ResourceCPU || name == corev1.ResourceMemory || strings.HasSuffix(string(name), "/gpu") {
+					add(requests, name, quantity)
+				}
+			}
+		}
+		errs = append(errs, r.stamp(ctx, pod, usage.Attribution))
+	}
+	if len(requests) > 0 {
+		usage.Requests = requests
+	}
+
+	used, err := r.usage(ctx, project.Name)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	usage.Usage = used
+
+	return usage, errors.Join(errs...)
+}
+
+// stamp labels the pod with the cost-attribution labels it does not have. Pods labelled otherwise keep their label, for
+// workloads charged to another cost center than their project.
+func (r *CostAttributionReconciler) stamp(ctx context.Context, pod *corev1.Pod, attribution map[string]string) error {
+	original := pod.DeepCopy()
+	for key, value := range attribution {
+		if _, found := pod.Labels[key]; !found {
+			if pod.Labels == nil {
+				pod.Labels = map[string]string{}
+			}
+			pod.Labels[key] = value
+		}
+	}
+	if len(pod.Labels) == len(original.Labels) {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, pod, client.MergeFrom(original)); err != nil && !k8serr.IsNotFound(err) {
+		return fmt.Errorf("failed to label pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	return nil
+}
+
+// usage returns the CPU and memory used by the pods of the namespace, nil when the metrics API is not available.
+func (r *CostAttributionReconciler) usage(ctx context.Context, namespace string) (corev1.ResourceList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.PodMetrics.GroupVersion().WithKind(gvk.PodMetrics.Kind + "List"))
+	if err := r.APIReader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list metrics of pods of namespace %s: %w", namespace, err)
+	}
+
+	used := corev1.ResourceList{}
+	for _, podMetrics := range list.Items {
+		containers, _, _ := unstructured.NestedSlice(podMetrics.Object, "containers")
+		for _, container := range containers {
+			fields, ok := container.(map[string]any)
+			if !ok {
+				continue
+			}
+			containerUsage, _, _ := unstructured.NestedStringMap(fields, "usage")
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				if quantity, err := resource.ParseQuantity(containerUsage[string(name)]); err == nil {
+					add(used, name, quantity)
+				}
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil, nil
+	}
+
+	return used, nil
+}
+
+// publish creates the UsageReport if needed, and records the usage of the projects and the time of the scan in its
+// status.
+func (r *CostAttributionReconciler) publish(ctx context.Context, usages []inventoryv1alpha1.ProjectUsage) error {
+	report := &inventoryv1alpha1.UsageReport{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: ReportName}, report)
+	if k8serr.IsNotFound(err) {
+		report = &inventoryv1alpha1.UsageReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ReportName,
+				Labels: map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"},
+			},
+		}
+		if err := r.Client.Create(ctx, report); err != nil {
+			return fmt.Errorf("failed to create UsageReport: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get UsageReport: %w", err)
+	}
+
+	now := metav1.Now()
+	report.Status = inventoryv1alpha1.UsageReportStatus{
+		LastScanTime: &now,
+		Total:        len(usages),
+		Projects:     usages,
+	}
+	if err := r.Client.Status().Update(ctx, report); err != nil {
+		return fmt.Errorf("failed to update UsageReport: %w", err)
+	}
+
+	return nil
+}
+
+func add(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	total := list[name]
+	total.Add(quantity)
+	list[name] = total
+}
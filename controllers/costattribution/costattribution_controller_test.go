@@ -0,0 +1,318 @@
+package costattribution
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func pod(namespace, name string, podLabels map[string]string, phase corev1.PodPhase, requests corev1.ResourceList) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:      "main",
+			Resources: corev1.ResourceRequirements{Requests: requests},
+		}}},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func podMetrics(namespace, name, cpu, memory string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"containers": []any{
+		map[string]any{"name": "main", "usage": map[string]any{"cpu": cpu, "memory": memory}},
+	}}}
+	obj.SetGroupVersionKind(gvk.PodMetrics)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// failList fails listing the kind, and lists the other kinds
+func failList(kind schema.GroupVersionKind, err error) interceptor.Funcs {
+	return interceptor.Funcs{List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+		if listKind, _ := cli.GroupVersionKindFor(list); listKind.Kind == kind.Kind+"List" {
+			return err
+		}
+		return cli.List(ctx, list, opts...)
+	}}
+}
+
+var _ = Describe("Cost attribution controller", func() {
+	var (
+		instance *dsciv1.DSCInitialization
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+	)
+
+	gpuRequests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}
+	cpuRequests := corev1.ResourceList{
+		corev1.ResourceCPU:              resource.MustParse("500m"),
+		corev1.ResourceMemory:           resource.MustParse("1Gi"),
+		corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+	}
+
+	BeforeEach(func() {
+		instance = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{
+				ApplicationsNamespace: "opendatahub",
+				CostAttribution:       &dsciv1.CostAttributionSpec{Labels: []string{"team"}},
+			},
+		}
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fraud", Labels: map[string]string{
+				labels.ODH.Dashboard: "true", labels.ODH.CostCenter: "cc-1234", "team": "risk", "env": "prod",
+			}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo", Labels: map[string]string{labels.ODH.Dashboard: "true"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			pod("fraud", "training", nil, corev1.PodRunning, gpuRequests),
+			pod("fraud", "shared", map[string]string{labels.ODH.CostCenter: "cc-9999"}, corev1.PodPending, cpuRequests),
+			pod("fraud", "finished", nil, corev1.PodSucceeded, gpuRequests),
+			pod("demo", "notebook", nil, corev1.PodRunning, cpuRequests),
+			pod("kube-system", "dns", nil, corev1.PodRunning, cpuRequests),
+			podMetrics("fraud", "training", "1500m", "6Gi"),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(inventoryv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&inventoryv1alpha1.UsageReport{}).
+				WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &CostAttributionReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+	}
+	podLabels := func(ctx context.Context, namespace, name string) map[string]string {
+		GinkgoHelper()
+		stamped := &corev1.Pod{}
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, stamped)).To(Succeed())
+		return stamped.Labels
+	}
+	report := func(ctx context.Context) *inventoryv1alpha1.UsageReport {
+		GinkgoHelper()
+		report := &inventoryv1alpha1.UsageReport{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: ReportName}, report)).To(Succeed())
+		return report
+	}
+	project := func(ctx context.Context, namespace string) inventoryv1alpha1.ProjectUsage {
+		GinkgoHelper()
+		projects := report(ctx).Status.Projects
+		for _, usage := range projects {
+			if usage.Namespace == namespace {
+				return usage
+			}
+		}
+		Fail("no usage reported for project " + namespace)
+		return inventoryv1alpha1.ProjectUsage{}
+	}
+	quantity := func(value string) resource.Quantity {
+		return resource.MustParse(value)
+	}
+
+	Describe("stamping the cost-attribution labels", func() {
+		DescribeTable("should label the pods of data science projects",
+			func(ctx context.Context, name string, expected map[string]string) {
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+				Expect(podLabels(ctx, "fraud", name)).To(Equal(expected))
+			},
+			Entry("unlabelled pod", "training", map[string]string{labels.ODH.CostCenter: "cc-1234", "team": "risk"}),
+			Entry("pod charged to another cost center", "shared", map[string]string{labels.ODH.CostCenter: "cc-9999", "team": "risk"}),
+		)
+
+		It("should not label the pods of other namespaces", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(podLabels(ctx, "kube-system", "dns")).To(BeEmpty())
+			Expect(podLabels(ctx, "demo", "notebook")).To(BeEmpty())
+		})
+
+		It("should not patch the pods labelled already", func(ctx context.Context) {
+			patches := 0
+			funcs.Patch = func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patches++
+				return cli.Patch(ctx, obj, patch, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(patches).To(Equal(2))
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(patches).To(Equal(2))
+		})
+
+		It("should ignore the pods deleted in the meantime", func(ctx context.Context) {
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return k8serr.NewNotFound(corev1.Resource("pods"), "training")
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+		})
+
+		It("should report the pods failing to be labelled", func(ctx context.Context) {
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return errors.New("forbidden")
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to label pod fraud/training: forbidden")))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning CostAttributionFailed")))
+			Expect(report(ctx).Status.Total).To(Equal(2))
+		})
+	})
+
+	Describe("reporting the usage", func() {
+		It("should report the data science projects only, sorted", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+			report := report(ctx)
+			Expect(report.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "opendatahub-operator"))
+			Expect(report.Status.LastScanTime).ToNot(BeNil())
+			Expect(report.Status.Total).To(Equal(2))
+			Expect(report.Status.Projects).To(HaveExactElements(HaveField("Namespace", "demo"), HaveField("Namespace", "fraud")))
+		})
+
+		It("should report the attribution and the requests of the pods not terminated", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+			fraud := project(ctx, "fraud")
+			Expect(fraud.Pods).To(Equal(2))
+			Expect(fraud.Attribution).To(Equal(map[string]string{labels.ODH.CostCenter: "cc-1234", "team": "risk"}))
+			Expect(fraud.Requests).To(HaveLen(3))
+			Expect(fraud.Requests.Cpu().Cmp(quantity("2500m"))).To(BeZero())
+			Expect(fraud.Requests.Memory().Cmp(quantity("9Gi"))).To(BeZero())
+			Expect(fraud.Requests.Name("nvidia.com/gpu", resource.DecimalSI).Cmp(quantity("1"))).To(BeZero())
+			Expect(fraud.Usage.Cpu().Cmp(quantity("1500m"))).To(BeZero())
+			Expect(fraud.Usage.Memory().Cmp(quantity("6Gi"))).To(BeZero())
+		})
+
+		It("should report the projects without attribution nor metrics", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+			demo := project(ctx, "demo")
+			Expect(demo.Pods).To(Equal(1))
+			Expect(demo.Attribution).To(BeNil())
+			Expect(demo.Usage).To(BeNil())
+		})
+
+		It("should report the projects without pods", func(ctx context.Context) {
+			objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "empty", Labels: map[string]string{labels.ODH.Dashboard: "true"}}})
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			empty := project(ctx, "empty")
+			Expect(empty.Pods).To(BeZero())
+			Expect(empty.Requests).To(BeNil())
+		})
+
+		It("should report the usage without the metrics API", func(ctx context.Context) {
+			funcs = failList(gvk.PodMetrics, &meta.NoKindMatchError{GroupKind: gvk.PodMetrics.GroupKind()})
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(project(ctx, "fraud").Usage).To(BeNil())
+		})
+
+		It("should update the existing UsageReport", func(ctx context.Context) {
+			objects = append(objects, &inventoryv1alpha1.UsageReport{
+				ObjectMeta: metav1.ObjectMeta{Name: ReportName},
+				Status: inventoryv1alpha1.UsageReportStatus{
+					Total:    1,
+					Projects: []inventoryv1alpha1.ProjectUsage{{Namespace: "deleted"}},
+				},
+			})
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(report(ctx).Status.Projects).To(HaveExactElements(HaveField("Namespace", "demo"), HaveField("Namespace", "fraud")))
+		})
+
+		It("should still report the projects whose pods cannot be listed", func(ctx context.Context) {
+			funcs = failList(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, errors.New("timeout"))
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to list pods of namespace demo: timeout")))
+			Expect(project(ctx, "fraud").Attribution).To(HaveKeyWithValue("team", "risk"))
+		})
+
+		It("should report the UsageReport failing to be updated", func(ctx context.Context) {
+			funcs.SubResourceUpdate = func(context.Context, client.Client, string, client.Object, ...client.SubResourceUpdateOption) error {
+				return errors.New("conflict")
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError("failed to update UsageReport: conflict"))
+			Expect(recorder.Events).To(Receive(Equal("Warning CostAttributionFailed Failed to attribute the costs of data science projects: failed to update UsageReport: conflict")))
+		})
+
+		It("should publish the usage in metrics", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+			Expect(testutil.ToFloat64(projectRequests.WithLabelValues("fraud", "cpu"))).To(Equal(2.5))
+			Expect(testutil.ToFloat64(projectRequests.WithLabelValues("fraud", "nvidia.com/gpu"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(projectUsage.WithLabelValues("fraud", "memory"))).To(Equal(6.0 * (1 << 30)))
+			Expect(testutil.ToFloat64(projectAttribution.WithLabelValues("fraud", "team", "risk"))).To(Equal(1.0))
+			Expect(testutil.CollectAndCount(projectUsage)).To(Equal(2))
+		})
+	})
+
+	When("cost attribution is removed", func() {
+		BeforeEach(func() {
+			instance.Spec.CostAttribution.ManagementState = operatorv1.Removed
+		})
+
+		It("should delete the UsageReport and the metrics", func(ctx context.Context) {
+			objects = append(objects, &inventoryv1alpha1.UsageReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName}})
+			observe([]inventoryv1alpha1.ProjectUsage{{Namespace: "fraud", Attribution: map[string]string{"team": "risk"}}})
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			err := cli.Get(ctx, client.ObjectKey{Name: ReportName}, &inventoryv1alpha1.UsageReport{})
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			Expect(testutil.CollectAndCount(projectAttribution)).To(BeZero())
+		})
+
+		It("should not label pods", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(podLabels(ctx, "fraud", "training")).To(BeEmpty())
+		})
+	})
+
+	It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+})
@@ -0,0 +1,13 @@
+package costattribution
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCostAttribution(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cost attribution suite")
+}
@@ -0,0 +1,57 @@
+package costattribution
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+)
+
+var (
+	// projectRequests backs the chargeback of the resources reserved by data science projects.
+	projectRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_project_resource_requests",
+			Help: "Resources requested by the pods of a data science project which are not terminated, in cores for cpu, bytes for memory and units otherwise.",
+		},
+		[]string{"namespace", "resource"},
+	)
+	// projectUsage backs the chargeback of the resources consumed by data science projects.
+	projectUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_project_resource_usage",
+			Help: "Resources used by the pods of a data science project, in cores for cpu and bytes for memory.",
+		},
+		[]string{"namespace", "resource"},
+	)
+	// projectAttribution is joined with the usage of projects to aggregate it by cost center.
+	projectAttribution = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_project_cost_attribution_info",
+			Help: "Cost-attribution label of a data science project, always 1.",
+		},
+		[]string{"namespace", "label", "value"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(projectRequests, projectUsage, projectAttribution)
+}
+
+// observe replaces the metrics with the ones of the projects, so that deleted projects are not reported anymore.
+func observe(projects []inventoryv1alpha1.ProjectUsage) {
+	projectRequests.Reset()
+	projectUsage.Reset()
+	projectAttribution.Reset()
+	for _, project := range projects {
+		for name, quantity := range project.Requests {
+			projectRequests.WithLabelValues(project.Namespace, string(name)).Set(quantity.AsApproximateFloat64())
+		}
+		for name, quantity := range project.Usage {
+			projectUsage.WithLabelValues(project.Namespace, string(name)).Set(quantity.AsApproximateFloat64())
+		}
+		for key, value := range project.Attribution {
+			projectAttribution.WithLabelValues(project.Namespace, key, value).Set(1)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Package dashboardaccess contains controller logic granting the groups of the dashboard access to the personas of the
+// platform, through RoleBindings in each data science project, and keeping the groups of the dashboard config in sync.
+package dashboardaccess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// bindings edited or deleted by hand are restored on the next sync
+	syncInterval = 10 * time.Minute
+)
+
+// DashboardAccessReconciler holds the controller configuration.
+type DashboardAccessReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DashboardAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for dashboard access.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dashboard-access-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.watchProjects), builder.WithPredicates(projectLabelChanged)).
+		Complete(r)
+}
+
+// Reconcile binds the groups of the dashboard access to the personas while the dashboard is Managed: admin groups
+// across the cluster, project admin and allowed groups in each data science project. Bindings of groups or projects
+// no longer listed are deleted, as are all of them once access is not managed. The outcome is reported in the
+// DashboardAccessSynced condition of the DataScienceCluster.
+func (r *DashboardAccessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	var access *dashboard.Access
+	if instance.Spec.Components.Dashboard.GetManagementState() == operatorv1.Managed {
+		access = instance.Spec.Components.Dashboard.Access
+	}
+
+	var errs []error
+	if err := r.reconcileAdminBinding(ctx, instance, access); err != nil {
+		errs = append(errs, err)
+	}
+	projects, err := r.reconcileProjectBindings(ctx, access)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if access != nil {
+		if err := r.reconcileDashboardConfig(ctx, access); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	syncErr := errors.Join(errs...)
+
+	var condition *conditionsv1.Condition
+	switch {
+	case access == nil:
+	case syncErr != nil:
+		condition = &conditionsv1.Condition{Status: corev1.ConditionFalse, Reason: status.AccessSyncFailed, Message: syncErr.Error()}
+	default:
+		condition = &conditionsv1.Condition{Status: corev1.ConditionTrue, Reason: status.AccessSynced,
+			Message: fmt.Sprintf("groups bound to the personas in %d data science projects", projects)}
+	}
+	if err := r.updateCondition(ctx, instance, condition); err != nil {
+		return ctrl.Result{}, errors.Join(syncErr, err)
+	}
+	if syncErr != nil {
+		return ctrl.Result{}, syncErr
+	}
+	if access == nil {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: syncInterval}, nil
+}
+
+// reconcileAdminBinding binds the admin groups to the admin persona across the cluster, deleting the binding when
+// there are none.
+func (r *DashboardAccessReconciler) reconcileAdminBinding(ctx context.Context, instance *dscv1.DataScienceCluster, access *dashboard.Access) error {
+	name := bindingName(components.PersonaAdmin)
+	if access == nil || len(access.AdminGroups) == 0 {
+		if err := cluster.DeleteClusterRoleBinding(ctx, r.Client, name); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ClusterRoleBinding %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if _, err := cluster.CreateOrUpdateClusterRoleBinding(ctx, r.Client, name, groupSubjects(access.AdminGroups), roleRef(components.PersonaAdmin),
+		cluster.OwnedBy(instance, r.Scheme), cluster.WithLabels(labels.ODH.DashboardAccess, "true")); err != nil {
+		return fmt.Errorf("failed to bind admin groups: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileProjectBindings binds the project admin and allowed groups to their personas in each data science project,
+// then deletes the bindings not desired anymore. It returns the number of projects the groups are bound in.
+func (r *DashboardAccessReconciler) reconcileProjectBindings(ctx context.Context, access *dashboard.Access) (int, error) {
+	desired := map[types.NamespacedName]bool{}
+	var errs []error
+
+	if access != nil {
+		groups := map[components.Persona][]string{
+			components.PersonaProjectAdmin: access.ProjectAdminGroups,
+			components.PersonaUser:         access.AllowedGroups,
+		}
+		projects := &corev1.NamespaceList{}
+		if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+			return 0, fmt.Errorf("failed to list data science projects: %w", err)
+		}
+		for _, project := range projects.Items {
+			if project.GetDeletionTimestamp() != nil {
+				continue
+			}
+			for persona, personaGroups := range groups {
+				if len(personaGroups) == 0 {
+					continue
+				}
+				key := types.NamespacedName{Namespace: project.Name, Name: bindingName(persona)}
+				desired[key] = true
+				if err := r.applyRoleBinding(ctx, key, persona, personaGroups); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	managed := &rbacv1.RoleBindingList{}
+	if err := r.Client.List(ctx, managed, client.MatchingLabels{labels.ODH.DashboardAccess: "true"}); err != nil {
+		return 0, errors.Join(append(errs, fmt.Errorf("failed to list RoleBindings of dashboard access: %w", err))...)
+	}
+	projects := map[string]bool{}
+	for i := range managed.Items {
+		binding := &managed.Items[i]
+		if desired[client.ObjectKeyFromObject(binding)] {
+			projects[binding.Namespace] = true
+			continue
+		}
+		r.Log.Info("Deleting RoleBinding of dashboard access", "namespace", binding.Namespace, "name", binding.Name)
+		if err := r.Client.Delete(ctx, binding); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete RoleBinding %s/%s: %w", binding.Namespace, binding.Name, err))
+		}
+	}
+
+	return len(projects), errors.Join(errs...)
+}
+
+// applyRoleBinding creates the RoleBinding of the persona to the groups, or updates its subjects if it already exists.
+func (r *DashboardAccessReconciler) applyRoleBinding(ctx context.Context, key types.NamespacedName, persona components.Persona, groups []string) error {
+	subjects := groupSubjects(groups)
+	found := &rbacv1.RoleBinding{}
+	err := r.Client.Get(ctx, key, found)
+	switch {
+	case k8serr.IsNotFound(err):
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    map[string]string{labels.ODH.DashboardAccess: "true", labels.K8SCommon.PartOf: "opendatahub-operator"},
+			},
+			Subjects: subjects,
+			RoleRef:  roleRef(persona),
+		}
+		err = r.Client.Create(ctx, binding)
+	case err != nil:
+	case found.RoleRef != roleRef(persona):
+		// the role of a binding cannot be changed, it is recreated on the next sync
+		err = r.Client.Delete(ctx, found)
+	case !reflect.DeepEqual(found.Subjects, subjects) || found.Labels[labels.ODH.DashboardAccess] != "true":
+		if found.Labels == nil {
+			found.Labels = map[string]string{}
+		}
+		found.Labels[labels.ODH.DashboardAccess] = "true"
+		found.Subjects = subjects
+		err = r.Client.Update(ctx, found)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bind groups to %s in project %s: %w", persona, key.Namespace, err)
+	}
+
+	return nil
+}
+
+// reconcileDashboardConfig sets the admin and allowed groups of the dashboard config, leaving those not listed in
+// access to the defaults of the dashboard. Nothing is set when the dashboard is not deployed yet.
+func (r *DashboardAccessReconciler) reconcileDashboardConfig(ctx context.Context, access *dashboard.Access) error {
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+
+	dashboardConfig := &unstructured.Unstructured{}
+	dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dashboard.ConfigName}, dashboardConfig); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	desired := map[string]any{}
+	if len(access.AdminGroups) != 0 {
+		desired["adminGroups"] = strings.Join(access.AdminGroups, ",")
+	}
+	if len(access.AllowedGroups) != 0 {
+		desired["allowedGroups"] = strings.Join(access.AllowedGroups, ",")
+	}
+	existing, _, _ := unstructured.NestedMap(dashboardConfig.Object, "spec", "groupsConfig")
+	changed := false
+	for key, value := range desired {
+		changed = changed || existing[key] != value
+	}
+	if !changed {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"groupsConfig": desired}})
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, dashboardConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to set the groups of the dashboard config: %w", err)
+	}
+	r.Log.Info("updated the groups of the dashboard config")
+
+	return nil
+}
+
+// updateCondition sets the DashboardAccessSynced condition, removing it when nil. Status is only updated on changes,
+// to not bump the heartbeat of the condition with every sync.
+func (r *DashboardAccessReconciler) updateCondition(ctx context.Context, instance *dscv1.DataScienceCluster, condition *conditionsv1.Condition) error {
+	existing := conditionsv1.FindStatusCondition(instance.Status.Conditions, status.ConditionDashboardAccessSynced)
+	switch {
+	case condition == nil && existing == nil:
+		return nil
+	case condition != nil && existing != nil &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message:
+		return nil
+	}
+
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		if condition == nil {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.ConditionDashboardAccessSynced)
+			return
+		}
+		status.SetCondition(&saved.Status.Conditions, string(status.ConditionDashboardAccessSynced), condition.Reason, condition.Message, condition.Status)
+	})
+
+	return err
+}
+
+// bindingName is the name of the bindings granting groups the persona.
+func bindingName(persona components.Persona) string {
+	return string(persona) + "-groups"
+}
+
+func roleRef(persona components.Persona) rbacv1.RoleRef {
+	return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: string(persona)}
+}
+
+func groupSubjects(groups []string) []rbacv1.Subject {
+	subjects := make([]rbacv1.Subject, 0, len(groups))
+	for _, group := range groups {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group})
+	}
+
+	return subjects
+}
+
+// watchProjects reconciles all DataScienceClusters, so that groups are bound in projects once they are created.
+func (r *DashboardAccessReconciler) watchProjects(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dscv1.DataScienceClusterList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DataScienceClusters")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
+
+// projectLabelChanged passes namespaces becoming or ceasing to be data science projects.
+var projectLabelChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetLabels()[labels.ODH.Dashboard] == "true"
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.ODH.Dashboard] != e.ObjectNew.GetLabels()[labels.ODH.Dashboard]
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
@@ -0,0 +1,302 @@
+package dashboardaccess
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+func namespace(name string, project bool) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if project {
+		ns.Labels = map[string]string{labels.ODH.Dashboard: "true"}
+	}
+
+	return ns
+}
+
+func groupNames(subjects []rbacv1.Subject) []string {
+	names := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		names = append(names, subject.Name)
+	}
+
+	return names
+}
+
+var _ = Describe("Dashboard access controller", func() {
+	var (
+		dsc             *dscv1.DataScienceCluster
+		dashboardConfig *unstructured.Unstructured
+		objects         []client.Object
+		funcs           interceptor.Funcs
+		cli             client.Client
+		req             = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsc"}}
+	)
+
+	reconciler := func() *DashboardAccessReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(dsc).WithInterceptorFuncs(funcs).Build()
+		}
+		return &DashboardAccessReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	}
+	// bindings returns the groups of the RoleBindings by namespace and name.
+	bindings := func(ctx context.Context) map[string][]string {
+		GinkgoHelper()
+		list := &rbacv1.RoleBindingList{}
+		Expect(cli.List(ctx, list)).To(Succeed())
+		found := map[string][]string{}
+		for _, binding := range list.Items {
+			found[binding.Namespace+"/"+binding.Name] = groupNames(binding.Subjects)
+		}
+		return found
+	}
+	groupsConfig := func(ctx context.Context) map[string]string {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(dashboardConfig), dashboardConfig)).To(Succeed())
+		groups, _, err := unstructured.NestedStringMap(dashboardConfig.Object, "spec", "groupsConfig")
+		Expect(err).ToNot(HaveOccurred())
+		return groups
+	}
+	accessCondition := func(ctx context.Context) *conditionsv1.Condition {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, req.NamespacedName, dsc)).To(Succeed())
+		return conditionsv1.FindStatusCondition(dsc.Status.Conditions, status.ConditionDashboardAccessSynced)
+	}
+
+	BeforeEach(func() {
+		dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Managed
+		dsc.Spec.Components.Dashboard.Access = &dashboard.Access{
+			AdminGroups:        []string{"platform-admins", "sre"},
+			ProjectAdminGroups: []string{"leads"},
+			AllowedGroups:      []string{"scientists", "analysts"},
+		}
+		dashboardConfig = &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{
+			"groupsConfig": map[string]any{"adminGroups": "odh-admins", "allowedGroups": "system:authenticated"},
+		}}}
+		dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+		dashboardConfig.SetName(dashboard.ConfigName)
+		dashboardConfig.SetNamespace("opendatahub")
+		objects = []client.Object{
+			dsc,
+			&dsciv1.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+				Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "opendatahub"},
+			},
+			dashboardConfig,
+			namespace("sales", true),
+			namespace("finance", true),
+			namespace("default", false),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	Context("binding groups in projects", func() {
+		var access *dashboard.Access
+
+		BeforeEach(func(ctx context.Context) {
+			access = dsc.Spec.Components.Dashboard.Access
+			Expect(reconciler().reconcileProjectBindings(ctx, access)).To(Equal(2))
+		})
+
+		It("should bind the groups to each persona in each project", func(ctx context.Context) {
+			Expect(bindings(ctx)).To(Equal(map[string][]string{
+				"sales/" + bindingName(components.PersonaProjectAdmin):   {"leads"},
+				"sales/" + bindingName(components.PersonaUser):           {"scientists", "analysts"},
+				"finance/" + bindingName(components.PersonaProjectAdmin): {"leads"},
+				"finance/" + bindingName(components.PersonaUser):         {"scientists", "analysts"},
+			}))
+		})
+
+		It("should update the groups and delete the bindings of personas without groups", func(ctx context.Context) {
+			access.AllowedGroups = nil
+			access.ProjectAdminGroups = []string{"leads", "managers"}
+
+			Expect(reconciler().reconcileProjectBindings(ctx, access)).To(Equal(2))
+
+			Expect(bindings(ctx)).To(Equal(map[string][]string{
+				"sales/" + bindingName(components.PersonaProjectAdmin):   {"leads", "managers"},
+				"finance/" + bindingName(components.PersonaProjectAdmin): {"leads", "managers"},
+			}))
+		})
+
+		It("should delete the bindings in namespaces which are not projects anymore", func(ctx context.Context) {
+			sales := namespace("sales", false)
+			Expect(cli.Update(ctx, sales)).To(Succeed())
+
+			Expect(reconciler().reconcileProjectBindings(ctx, access)).To(Equal(1))
+
+			Expect(bindings(ctx)).ToNot(HaveKey(HavePrefix("sales/")))
+		})
+
+		It("should recreate bindings to another role", func(ctx context.Context) {
+			binding := &rbacv1.RoleBinding{}
+			key := client.ObjectKey{Namespace: "sales", Name: bindingName(components.PersonaUser)}
+			Expect(cli.Get(ctx, key, binding)).To(Succeed())
+			Expect(cli.Delete(ctx, binding)).To(Succeed())
+			binding.ResourceVersion = ""
+			binding.RoleRef = roleRef(components.PersonaAdmin)
+			Expect(cli.Create(ctx, binding)).To(Succeed())
+
+			By("deleting the binding first")
+			Expect(reconciler().reconcileProjectBindings(ctx, access)).To(Equal(2))
+			Expect(bindings(ctx)).ToNot(HaveKey("sales/" + bindingName(components.PersonaUser)))
+
+			By("creating it again on the next sync")
+			Expect(reconciler().reconcileProjectBindings(ctx, access)).To(Equal(2))
+			Expect(cli.Get(ctx, key, binding)).To(Succeed())
+			Expect(binding.RoleRef).To(Equal(roleRef(components.PersonaUser)))
+		})
+
+		It("should delete all the bindings once access is not managed", func(ctx context.Context) {
+			Expect(reconciler().reconcileProjectBindings(ctx, nil)).To(Equal(0))
+
+			Expect(bindings(ctx)).To(BeEmpty())
+		})
+	})
+
+	Context("setting the groups of the dashboard config", func() {
+		It("should set the listed groups only", func(ctx context.Context) {
+			Expect(reconciler().reconcileDashboardConfig(ctx, &dashboard.Access{AdminGroups: []string{"platform-admins", "sre"}})).To(Succeed())
+
+			Expect(groupsConfig(ctx)).To(Equal(map[string]string{
+				"adminGroups":   "platform-admins,sre",
+				"allowedGroups": "system:authenticated",
+			}))
+		})
+
+		It("should not fail while the dashboard is not deployed", func(ctx context.Context) {
+			objects = objects[:2]
+
+			Expect(reconciler().reconcileDashboardConfig(ctx, &dashboard.Access{AdminGroups: []string{"sre"}})).To(Succeed())
+		})
+	})
+
+	It("should grant access and report it while the dashboard is Managed", func(ctx context.Context) {
+		result, err := reconciler().Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+
+		adminBinding := &rbacv1.ClusterRoleBinding{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: bindingName(components.PersonaAdmin)}, adminBinding)).To(Succeed())
+		Expect(groupNames(adminBinding.Subjects)).To(Equal([]string{"platform-admins", "sre"}))
+		Expect(bindings(ctx)).To(HaveLen(4))
+		Expect(groupsConfig(ctx)).To(Equal(map[string]string{"adminGroups": "platform-admins,sre", "allowedGroups": "scientists,analysts"}))
+		Expect(accessCondition(ctx)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(corev1.ConditionTrue),
+			"Reason":  Equal(status.AccessSynced),
+			"Message": Equal("groups bound to the personas in 2 data science projects"),
+		})))
+	})
+
+	It("should report bindings which failed", func(ctx context.Context) {
+		funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if obj.GetNamespace() == "finance" {
+				return errors.New("quota exceeded")
+			}
+			return cli.Create(ctx, obj, opts...)
+		}
+
+		_, err := reconciler().Reconcile(ctx, req)
+		Expect(err).To(MatchError(ContainSubstring("quota exceeded")))
+
+		Expect(bindings(ctx)).To(HaveLen(2))
+		Expect(accessCondition(ctx)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(corev1.ConditionFalse),
+			"Reason":  Equal(status.AccessSyncFailed),
+			"Message": ContainSubstring("failed to bind groups to %s in project finance", components.PersonaProjectAdmin),
+		})))
+	})
+
+	When("access is granted", func() {
+		BeforeEach(func(ctx context.Context) {
+			_, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should revoke it once the dashboard is Removed", func(ctx context.Context) {
+			Expect(cli.Get(ctx, req.NamespacedName, dsc)).To(Succeed())
+			dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+
+			result, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			Expect(cli.Get(ctx, client.ObjectKey{Name: bindingName(components.PersonaAdmin)}, &rbacv1.ClusterRoleBinding{})).ToNot(Succeed())
+			Expect(bindings(ctx)).To(BeEmpty())
+			Expect(accessCondition(ctx)).To(BeNil())
+		})
+
+		It("should bind the groups in new projects", func(ctx context.Context) {
+			Expect(cli.Create(ctx, namespace("research", true))).To(Succeed())
+			Expect(reconciler().watchProjects(ctx, nil)).To(ConsistOf(req))
+
+			_, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(bindings(ctx)).To(HaveKey("research/" + bindingName(components.PersonaUser)))
+			Expect(accessCondition(ctx).Message).To(Equal("groups bound to the personas in 3 data science projects"))
+		})
+	})
+
+	It("should do nothing once the DataScienceCluster is deleted", func(ctx context.Context) {
+		objects = objects[1:]
+
+		result, err := reconciler().Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(bindings(ctx)).To(BeEmpty())
+	})
+
+	DescribeTable("should watch namespaces becoming or ceasing to be projects",
+		func(e any, expected bool) {
+			switch e := e.(type) {
+			case event.CreateEvent:
+				Expect(projectLabelChanged.Create(e)).To(Equal(expected))
+			case event.UpdateEvent:
+				Expect(projectLabelChanged.Update(e)).To(Equal(expected))
+			case event.DeleteEvent:
+				Expect(projectLabelChanged.Delete(e)).To(Equal(expected))
+			}
+		},
+		Entry("when a project is created", event.CreateEvent{Object: namespace("sales", true)}, true),
+		Entry("when another namespace is created", event.CreateEvent{Object: namespace("default", false)}, false),
+		Entry("when a namespace becomes a project", event.UpdateEvent{ObjectOld: namespace("sales", false), ObjectNew: namespace("sales", true)}, true),
+		Entry("when a namespace ceases to be a project", event.UpdateEvent{ObjectOld: namespace("sales", true), ObjectNew: namespace("sales", false)}, true),
+		Entry("when a project is updated", event.UpdateEvent{ObjectOld: namespace("sales", true), ObjectNew: namespace("sales", true)}, false),
+		Entry("when a project is deleted", event.DeleteEvent{Object: namespace("sales", true)}, false),
+	)
+})
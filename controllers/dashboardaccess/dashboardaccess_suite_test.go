@@ -0,0 +1,13 @@
+package dashboardaccess
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDashboardAccess(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dashboard access suite")
+}
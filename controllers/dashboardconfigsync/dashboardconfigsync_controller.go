@@ -0,0 +1,355 @@
+// Package dashboardconfigsync contains controller logic importing the settings of the dashboard config into the
+// DataScienceCluster, and managing them in the dashboard config from then on, so that they do not drift from the
+// state managed by the operator.
+package dashboardconfigsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// settings edited by hand in the dashboard config are restored on the next sync
+	syncInterval = 10 * time.Minute
+
+	// fields of the dashboard config holding the default storage of new workbenches
+	pvcSizeField      = "notebookController.pvcSize"
+	storageClassField = "notebookController.storageClassName"
+)
+
+// DashboardConfigSyncReconciler holds the controller configuration.
+type DashboardConfigSyncReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists the volumes of workbenches, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DashboardConfigSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for syncing settings to the dashboard config.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dashboard-config-sync-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Complete(r)
+}
+
+// Reconcile imports the settings of the dashboard config into the DataScienceCluster when requested with the
+// ImportDashboardConfig annotation. Otherwise, it sets the notebook sizes, PVC size and storage class of the workbenches
+// in the dashboard config while Workbenches is Managed, and the model server sizes while Dashboard is Managed, the
+// groups being set by the dashboard access controller.
+func (r *DashboardConfigSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	importRequested := instance.GetAnnotations()[annotations.ImportDashboardConfig] == "true"
+
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	dashboardConfig := &unstructured.Unstructured{}
+	dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dashboard.ConfigName}, dashboardConfig); err != nil {
+		if !k8serr.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return ctrl.Result{}, err
+		}
+		if importRequested {
+			r.Log.Info("waiting for the dashboard config to be deployed to import its settings")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if importRequested {
+		return ctrl.Result{}, r.importSettings(ctx, instance, dashboardConfig)
+	}
+
+	if err := instance.ApplyProfile(); err != nil {
+		return ctrl.Result{}, err
+	}
+	settings, err := desiredSettings(instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(settings) == 0 {
+		return ctrl.Result{}, nil
+	}
+	if err := r.reconcileDashboardConfig(ctx, instance, dashboardConfig, settings); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: syncInterval}, nil
+}
+
+// importSettings sets the settings of the dashboard config in the DataScienceCluster, then removes the
+// ImportDashboardConfig annotation in the same update. Settings which cannot be imported are logged and left out.
+func (r *DashboardConfigSyncReconciler) importSettings(ctx context.Context, instance *dscv1.DataScienceCluster, dashboardConfig *unstructured.Unstructured) error {
+	imported, err := importFields(instance, dashboardConfig)
+	if err != nil {
+		r.Log.Error(err, "settings of the dashboard config not imported")
+	}
+
+	dscAnnotations := instance.GetAnnotations()
+	delete(dscAnnotations, annotations.ImportDashboardConfig)
+	instance.SetAnnotations(dscAnnotations)
+	if err := r.Client.Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to import the settings of the dashboard config: %w", err)
+	}
+	r.Log.Info("imported the settings of the dashboard config", "fields", imported)
+
+	return nil
+}
+
+// importFields sets the groups, model server sizes, notebook sizes, PVC size and storage class of the dashboard config in the fields of the dashboard and
+// workbenches which are not set yet, so that existing settings are kept once managed by the operator. It returns the
+// fields of the dashboard config imported, along with the errors of those which could not be.
+func importFields(instance *dscv1.DataScienceCluster, dashboardConfig *unstructured.Unstructured) ([]string, error) {
+	var imported []string
+	var errs []error
+
+	access := instance.Spec.Components.Dashboard.Access
+	if access == nil {
+		access = &dashboard.Access{}
+	}
+	groups, _, err := unstructured.NestedStringMap(dashboardConfig.Object, "spec", "groupsConfig")
+	if err != nil {
+		errs = append(errs, fmt.Errorf("groupsConfig: %w", err))
+	}
+	if adminGroups := splitGroups(groups["adminGroups"]); len(access.AdminGroups) == 0 && len(adminGroups) != 0 {
+		access.AdminGroups = adminGroups
+		imported = append(imported, "groupsConfig.adminGroups")
+	}
+	if allowedGroups := splitGroups(groups["allowedGroups"]); len(access.AllowedGroups) == 0 && len(allowedGroups) != 0 {
+		access.AllowedGroups = allowedGroups
+		imported = append(imported, "groupsConfig.allowedGroups")
+	}
+	if len(imported) != 0 {
+		instance.Spec.Components.Dashboard.Access = access
+	}
+	if field, found, _ := unstructured.NestedFieldNoCopy(dashboardConfig.Object, "spec", "modelServerSizes"); found && len(instance.Spec.Components.Dashboard.ModelServerSizes) == 0 {
+		if decoded, err := sizes(field); err != nil {
+			errs = append(errs, fmt.Errorf("modelServerSizes: %w", err))
+		} else if len(decoded) != 0 {
+			instance.Spec.Components.Dashboard.ModelServerSizes = decoded
+			imported = append(imported, "modelServerSizes")
+		}
+	}
+
+	component := &instance.Spec.Components.Workbenches
+	if field, found, _ := unstructured.NestedFieldNoCopy(dashboardConfig.Object, "spec", "notebookSizes"); found && len(component.NotebookSizes) == 0 {
+		if decoded, err := sizes(field); err != nil {
+			errs = append(errs, fmt.Errorf("notebookSizes: %w", err))
+		} else if len(decoded) != 0 {
+			component.NotebookSizes = decoded
+			imported = append(imported, "notebookSizes")
+		}
+	}
+	if pvcSize, found, _ := unstructured.NestedString(dashboardConfig.Object, "spec", "notebookController", "pvcSize"); found && component.PVCSize == nil {
+		if quantity, err := resource.ParseQuantity(pvcSize); err != nil {
+			errs = append(errs, fmt.Errorf("notebookController.pvcSize: %w", err))
+		} else {
+			component.PVCSize = &quantity
+			imported = append(imported, "notebookController.pvcSize")
+		}
+	}
+	if storageClassName, _, _ := unstructured.NestedString(dashboardConfig.Object, "spec", "notebookController", "storageClassName"); storageClassName != "" &&
+		component.StorageClassName == "" {
+		component.StorageClassName = storageClassName
+		imported = append(imported, "notebookController.storageClassName")
+	}
+
+	return imported, errors.Join(errs...)
+}
+
+// setting is a field of the dashboard config spec managed by the operator, with its desired value.
+type setting struct {
+	path  []string
+	value any
+}
+
+// desiredSettings returns the settings of the dashboard config set in the DataScienceCluster for the components which
+// are Managed, leaving those not set to the dashboard.
+func desiredSettings(instance *dscv1.DataScienceCluster) ([]setting, error) {
+	var settings []setting
+	add := func(value any, path ...string) error {
+		// round-tripped, to compare with the decoded content of the dashboard config
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return err
+		}
+		settings = append(settings, setting{path: path, value: decoded})
+		return nil
+	}
+
+	var errs []error
+	if dashboardComponent := instance.Spec.Components.Dashboard; dashboardComponent.GetManagementState() == operatorv1.Managed {
+		if len(dashboardComponent.ModelServerSizes) != 0 {
+			errs = append(errs, add(dashboardComponent.ModelServerSizes, "modelServerSizes"))
+		}
+	}
+	if workbenchesComponent := instance.Spec.Components.Workbenches; workbenchesComponent.GetManagementState() == operatorv1.Managed {
+		if len(workbenchesComponent.NotebookSizes) != 0 {
+			errs = append(errs, add(workbenchesComponent.NotebookSizes, "notebookSizes"))
+		}
+		if workbenchesComponent.PVCSize != nil {
+			errs = append(errs, add(workbenchesComponent.PVCSize, "notebookController", "pvcSize"))
+		}
+		if workbenchesComponent.StorageClassName != "" {
+			errs = append(errs, add(workbenchesComponent.StorageClassName, "notebookController", "storageClassName"))
+		}
+	}
+
+	return settings, errors.Join(errs...)
+}
+
+// reconcileDashboardConfig sets the settings in the dashboard config, patching those which differ, and guides the
+// migration of existing workbenches when their default storage changes.
+func (r *DashboardConfigSyncReconciler) reconcileDashboardConfig(ctx context.Context, instance *dscv1.DataScienceCluster,
+	dashboardConfig *unstructured.Unstructured, settings []setting,
+) error {
+	desired := map[string]any{}
+	previous := map[string]any{}
+	var fields []string
+	for _, s := range settings {
+		path := append([]string{"spec"}, s.path...)
+		existing, _, _ := unstructured.NestedFieldNoCopy(dashboardConfig.Object, path...)
+		if reflect.DeepEqual(existing, s.value) {
+			continue
+		}
+		if err := unstructured.SetNestedField(desired, s.value, path...); err != nil {
+			return err
+		}
+		field := strings.Join(s.path, ".")
+		fields = append(fields, field)
+		if existing != nil {
+			previous[field] = existing
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, dashboardConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to set %s in the dashboard config: %w", strings.Join(fields, ", "), err)
+	}
+	r.Log.Info("updated the dashboard config", "fields", fields)
+
+	return r.guideMigration(ctx, instance, previous)
+}
+
+// guideMigration records an event telling how many volumes of existing workbenches do not have the new default size
+// or storage class, as the dashboard only applies them to new workbenches, and how to migrate them.
+func (r *DashboardConfigSyncReconciler) guideMigration(ctx context.Context, instance *dscv1.DataScienceCluster, previous map[string]any) error {
+	previousSize, sizeChanged := previous[pvcSizeField]
+	previousClass, classChanged := previous[storageClassField]
+	if !sizeChanged && !classChanged {
+		return nil
+	}
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.APIReader.List(ctx, claims, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return fmt.Errorf("failed to list volumes of workbenches: %w", err)
+	}
+	workbenches := instance.Spec.Components.Workbenches
+	smaller, otherClass := 0, 0
+	for _, claim := range claims.Items {
+		if size := claim.Spec.Resources.Requests[corev1.ResourceStorage]; workbenches.PVCSize != nil && size.Cmp(*workbenches.PVCSize) < 0 {
+			smaller++
+		}
+		if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName != workbenches.StorageClassName {
+			otherClass++
+		}
+	}
+
+	if sizeChanged {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "WorkbenchStorageChanged",
+			"The default storage size of new workbenches changed from %v to %s. Volumes of existing workbenches keep their size, %d of "+
+				"them are smaller: expand them from the dashboard, provided their storage class allows volume expansion.",
+			previousSize, workbenches.PVCSize.String(), smaller)
+	}
+	if classChanged {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "WorkbenchStorageChanged",
+			"The default storage class of new workbenches changed from %v to %s. Volumes of existing workbenches keep their storage "+
+				"class, %d of them use another one: to migrate one, stop its workbench, copy its data to a new volume of the %s "+
+				"storage class, and attach it to the workbench in place of the former one.",
+			previousClass, workbenches.StorageClassName, otherClass, workbenches.StorageClassName)
+	}
+
+	return nil
+}
+
+// sizes decodes the sizes of the dashboard config.
+func sizes(field any) ([]components.Size, error) {
+	data, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+	var decoded []components.Size
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	for _, size := range decoded {
+		if size.Name == "" {
+			return nil, errors.New("size without a name")
+		}
+	}
+
+	return decoded, nil
+}
+
+// splitGroups returns the groups of the comma-separated list of the dashboard config.
+func splitGroups(list string) []string {
+	var groups []string
+	for _, group := range strings.Split(list, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,526 @@
+package dashboardconfigsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newDashboardConfig(spec map[string]any) *unstructured.Unstructured {
+	dashboardConfig := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	dashboardConfig.SetName(dashboard.ConfigName)
+	dashboardConfig.SetNamespace("opendatahub")
+
+	return dashboardConfig
+}
+
+func quantity(value string) *resource.Quantity {
+	q := resource.MustParse(value)
+	return &q
+}
+
+func size(name string, requests, limits corev1.ResourceList) components.Size {
+	return components.Size{Name: name, Resources: components.SizeResources{Requests: requests, Limits: limits}}
+}
+
+var _ = Describe("Dashboard config sync", func() {
+	var instance *dscv1.DataScienceCluster
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		instance.Spec.Components.Workbenches.ManagementState = operatorv1.Managed
+	})
+
+	Describe("importFields", func() {
+		It("should import the settings into the fields not set yet", func() {
+			dashboardConfig := newDashboardConfig(map[string]any{
+				"groupsConfig": map[string]any{"adminGroups": "odh-admins, sre", "allowedGroups": "system:authenticated"},
+				"notebookSizes": []any{map[string]any{
+					"name":      "Small",
+					"resources": map[string]any{"requests": map[string]any{"cpu": "1", "memory": "8Gi"}, "limits": map[string]any{"cpu": "2", "memory": "8Gi"}},
+				}},
+				"notebookController": map[string]any{"enabled": true, "pvcSize": "20Gi"},
+			})
+
+			imported, err := importFields(instance, dashboardConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(ConsistOf("groupsConfig.adminGroups", "groupsConfig.allowedGroups", "notebookSizes", "notebookController.pvcSize"))
+			Expect(instance.Spec.Components.Dashboard.Access).To(Equal(&dashboard.Access{
+				AdminGroups:   []string{"odh-admins", "sre"},
+				AllowedGroups: []string{"system:authenticated"},
+			}))
+			workbenches := instance.Spec.Components.Workbenches
+			Expect(workbenches.NotebookSizes).To(Equal([]components.Size{size("Small",
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+			)}))
+			Expect(workbenches.PVCSize).To(Equal(quantity("20Gi")))
+		})
+
+		It("should keep the fields already set", func() {
+			instance.Spec.Components.Dashboard.Access = &dashboard.Access{AllowedGroups: []string{"scientists"}}
+			instance.Spec.Components.Workbenches.NotebookSizes = []components.Size{{Name: "Large"}}
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+			dashboardConfig := newDashboardConfig(map[string]any{
+				"groupsConfig":       map[string]any{"adminGroups": "odh-admins", "allowedGroups": "system:authenticated"},
+				"notebookSizes":      []any{map[string]any{"name": "Small"}},
+				"notebookController": map[string]any{"pvcSize": "20Gi"},
+			})
+
+			imported, err := importFields(instance, dashboardConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(ConsistOf("groupsConfig.adminGroups"))
+			Expect(instance.Spec.Components.Dashboard.Access).To(Equal(&dashboard.Access{
+				AdminGroups:   []string{"odh-admins"},
+				AllowedGroups: []string{"scientists"},
+			}))
+			Expect(instance.Spec.Components.Workbenches.NotebookSizes).To(Equal([]components.Size{{Name: "Large"}}))
+			Expect(instance.Spec.Components.Workbenches.PVCSize).To(Equal(quantity("40Gi")))
+		})
+
+		It("should import nothing from an empty dashboard config", func() {
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{
+				"groupsConfig":  map[string]any{"adminGroups": " , "},
+				"notebookSizes": []any{},
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(BeEmpty())
+			Expect(instance.Spec.Components.Dashboard.Access).To(BeNil())
+			Expect(instance.Spec.Components.Workbenches.NotebookSizes).To(BeEmpty())
+		})
+
+		DescribeTable("should leave out the invalid settings and import the others",
+			func(spec map[string]any, field string) {
+				spec["groupsConfig"] = map[string]any{"adminGroups": "odh-admins"}
+
+				imported, err := importFields(instance, newDashboardConfig(spec))
+				Expect(err).To(MatchError(ContainSubstring(field + ": ")))
+				Expect(imported).To(ConsistOf("groupsConfig.adminGroups"))
+				Expect(instance.Spec.Components.Workbenches.NotebookSizes).To(BeEmpty())
+				Expect(instance.Spec.Components.Workbenches.PVCSize).To(BeNil())
+			},
+			Entry("PVC size not a quantity", map[string]any{"notebookController": map[string]any{"pvcSize": "large"}}, "notebookController.pvcSize"),
+			Entry("notebook size without a name", map[string]any{"notebookSizes": []any{map[string]any{"resources": map[string]any{}}}}, "notebookSizes"),
+			Entry("notebook sizes not a list", map[string]any{"notebookSizes": "Small"}, "notebookSizes"),
+		)
+
+		It("should report groups which are not strings", func() {
+			_, err := importFields(instance, newDashboardConfig(map[string]any{"groupsConfig": map[string]any{"adminGroups": true}}))
+			Expect(err).To(MatchError(ContainSubstring("groupsConfig: ")))
+		})
+
+		It("should import the model server sizes not set yet", func() {
+			dashboardConfig := newDashboardConfig(map[string]any{
+				"modelServerSizes": []any{map[string]any{
+					"name":      "Small",
+					"resources": map[string]any{"limits": map[string]any{"cpu": "2", "memory": "8Gi", "nvidia.com/gpu": "1"}},
+				}},
+			})
+
+			imported, err := importFields(instance, dashboardConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(ConsistOf("modelServerSizes"))
+			Expect(instance.Spec.Components.Dashboard.ModelServerSizes).To(Equal([]components.Size{size("Small", nil, corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("8Gi"), "nvidia.com/gpu": resource.MustParse("1"),
+			})}))
+		})
+
+		It("should keep the model server sizes already set", func() {
+			instance.Spec.Components.Dashboard.ModelServerSizes = []components.Size{{Name: "Large"}}
+
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{"modelServerSizes": []any{map[string]any{"name": "Small"}}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(BeEmpty())
+			Expect(instance.Spec.Components.Dashboard.ModelServerSizes).To(Equal([]components.Size{{Name: "Large"}}))
+		})
+
+		It("should leave out model server sizes without a name", func() {
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{"modelServerSizes": []any{map[string]any{"name": ""}}}))
+			Expect(err).To(MatchError(ContainSubstring("modelServerSizes: size without a name")))
+			Expect(imported).To(BeEmpty())
+			Expect(instance.Spec.Components.Dashboard.ModelServerSizes).To(BeEmpty())
+		})
+
+		It("should import the storage class not set yet", func() {
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{"notebookController": map[string]any{"storageClassName": "gp3-csi"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(ConsistOf("notebookController.storageClassName"))
+			Expect(instance.Spec.Components.Workbenches.StorageClassName).To(Equal("gp3-csi"))
+		})
+
+		It("should keep the storage class already set", func() {
+			instance.Spec.Components.Workbenches.StorageClassName = "io2-csi"
+
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{"notebookController": map[string]any{"storageClassName": "gp3-csi"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(BeEmpty())
+			Expect(instance.Spec.Components.Workbenches.StorageClassName).To(Equal("io2-csi"))
+		})
+
+		It("should not import an empty storage class", func() {
+			imported, err := importFields(instance, newDashboardConfig(map[string]any{"notebookController": map[string]any{"storageClassName": ""}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("should split the groups of the dashboard config",
+		func(list string, expected []string) {
+			Expect(splitGroups(list)).To(Equal(expected))
+		},
+		Entry("no group", "", nil),
+		Entry("a single group", "odh-admins", []string{"odh-admins"}),
+		Entry("groups with spaces", " odh-admins ,sre ", []string{"odh-admins", "sre"}),
+		Entry("empty groups", "odh-admins,,  ,", []string{"odh-admins"}),
+	)
+
+	Describe("desiredSettings", func() {
+		It("should set the settings of the managed workbenches, decoded as in the dashboard config", func() {
+			instance.Spec.Components.Workbenches.NotebookSizes = []components.Size{
+				size("Large", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}, nil),
+			}
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+
+			settings, err := desiredSettings(instance)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal([]setting{
+				{path: []string{"notebookSizes"}, value: []any{map[string]any{"name": "Large", "resources": map[string]any{"requests": map[string]any{"cpu": "4"}}}}},
+				{path: []string{"notebookController", "pvcSize"}, value: "40Gi"},
+			}))
+		})
+
+		It("should set the storage class of the managed workbenches", func() {
+			instance.Spec.Components.Workbenches.StorageClassName = "io2-csi"
+
+			settings, err := desiredSettings(instance)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal([]setting{{path: []string{"notebookController", "storageClassName"}, value: "io2-csi"}}))
+		})
+
+		It("should set the model server sizes of the managed dashboard", func() {
+			instance.Spec.Components.Dashboard.ManagementState = operatorv1.Managed
+			instance.Spec.Components.Dashboard.ModelServerSizes = []components.Size{
+				size("Small", nil, corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}),
+			}
+
+			settings, err := desiredSettings(instance)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal([]setting{
+				{path: []string{"modelServerSizes"}, value: []any{map[string]any{"name": "Small", "resources": map[string]any{"limits": map[string]any{"nvidia.com/gpu": "1"}}}}},
+			}))
+		})
+
+		It("should leave the model server sizes of the dashboard not managed to the dashboard", func() {
+			instance.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+			instance.Spec.Components.Dashboard.ModelServerSizes = []components.Size{{Name: "Small"}}
+
+			Expect(desiredSettings(instance)).To(BeEmpty())
+		})
+
+		It("should leave the settings not set to the dashboard", func() {
+			Expect(desiredSettings(instance)).To(BeEmpty())
+		})
+
+		It("should leave the settings of workbenches not managed to the dashboard", func() {
+			instance.Spec.Components.Workbenches.ManagementState = operatorv1.Removed
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+
+			Expect(desiredSettings(instance)).To(BeEmpty())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var (
+			objects  []client.Object
+			funcs    interceptor.Funcs
+			cli      client.Client
+			recorder *record.FakeRecorder
+			patches  int
+		)
+
+		BeforeEach(func() {
+			dsci := &dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}
+			dsci.Spec.ApplicationsNamespace = "opendatahub"
+			objects = []client.Object{dsci}
+			funcs = interceptor.Funcs{}
+			cli = nil
+			recorder = record.NewFakeRecorder(10)
+			patches = 0
+		})
+
+		reconcile := func(ctx context.Context) (ctrl.Result, error) {
+			if cli == nil {
+				scheme := runtime.NewScheme()
+				Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+				Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+				Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+				patch := funcs.Patch
+				funcs.Patch = func(ctx context.Context, cli client.WithWatch, obj client.Object, p client.Patch, opts ...client.PatchOption) error {
+					patches++
+					if patch != nil {
+						return patch(ctx, cli, obj, p, opts...)
+					}
+					return cli.Patch(ctx, obj, p, opts...)
+				}
+				cli = fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+			}
+			r := &DashboardConfigSyncReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+			return r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+		}
+		dashboardConfigSpec := func(ctx context.Context) map[string]any {
+			GinkgoHelper()
+			dashboardConfig := newDashboardConfig(nil)
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(dashboardConfig), dashboardConfig)).To(Succeed())
+			return dashboardConfig.Object["spec"].(map[string]any)
+		}
+		saved := func(ctx context.Context) *dscv1.DataScienceCluster {
+			GinkgoHelper()
+			saved := &dscv1.DataScienceCluster{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+			return saved
+		}
+
+		Context("when the import of the dashboard config is requested", func() {
+			BeforeEach(func() {
+				instance.SetAnnotations(map[string]string{annotations.ImportDashboardConfig: "true", "team": "fraud"})
+			})
+
+			It("should import the settings and remove the annotation", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{
+					"groupsConfig":       map[string]any{"adminGroups": "odh-admins"},
+					"notebookController": map[string]any{"pvcSize": "20Gi"},
+				}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+				imported := saved(ctx)
+				Expect(imported.GetAnnotations()).To(Equal(map[string]string{"team": "fraud"}))
+				Expect(imported.Spec.Components.Dashboard.Access.AdminGroups).To(Equal([]string{"odh-admins"}))
+				Expect(imported.Spec.Components.Workbenches.PVCSize).To(Equal(quantity("20Gi")))
+				Expect(patches).To(BeZero())
+			})
+
+			It("should remove the annotation even when settings cannot be imported", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{"notebookController": map[string]any{"pvcSize": "large"}}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+				imported := saved(ctx)
+				Expect(imported.GetAnnotations()).ToNot(HaveKey(annotations.ImportDashboardConfig))
+				Expect(imported.Spec.Components.Workbenches.PVCSize).To(BeNil())
+			})
+
+			It("should wait for the dashboard config to be deployed", func(ctx context.Context) {
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+				Expect(saved(ctx).GetAnnotations()).To(HaveKeyWithValue(annotations.ImportDashboardConfig, "true"))
+			})
+
+			It("should fail when the DataScienceCluster cannot be updated", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{}))
+				funcs.Update = func(context.Context, client.WithWatch, client.Object, ...client.UpdateOption) error {
+					return errors.New("conflict")
+				}
+
+				_, err := reconcile(ctx)
+				Expect(err).To(MatchError(ContainSubstring("failed to import the settings of the dashboard config")))
+			})
+		})
+
+		It("should set the settings in the dashboard config and keep the others", func(ctx context.Context) {
+			objects = append(objects, newDashboardConfig(map[string]any{
+				"notebookController": map[string]any{"enabled": true, "pvcSize": "20Gi"},
+				"groupsConfig":       map[string]any{"adminGroups": "odh-admins"},
+			}))
+			instance.Spec.Components.Workbenches.NotebookSizes = []components.Size{
+				size("Large", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}, nil),
+			}
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+			Expect(dashboardConfigSpec(ctx)).To(Equal(map[string]any{
+				"notebookController": map[string]any{"enabled": true, "pvcSize": "40Gi"},
+				"groupsConfig":       map[string]any{"adminGroups": "odh-admins"},
+				"notebookSizes":      []any{map[string]any{"name": "Large", "resources": map[string]any{"requests": map[string]any{"cpu": "4"}}}},
+			}))
+			Expect(patches).To(Equal(1))
+		})
+
+		It("should set the model server sizes in the dashboard config", func(ctx context.Context) {
+			objects = append(objects, newDashboardConfig(map[string]any{
+				"modelServerSizes": []any{map[string]any{"name": "Small"}, map[string]any{"name": "Medium"}},
+			}))
+			instance.Spec.Components.Dashboard.ManagementState = operatorv1.Managed
+			instance.Spec.Components.Dashboard.ModelServerSizes = []components.Size{
+				size("Large", nil, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")}),
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+			Expect(dashboardConfigSpec(ctx)).To(HaveKeyWithValue("modelServerSizes",
+				[]any{map[string]any{"name": "Large", "resources": map[string]any{"limits": map[string]any{"memory": "64Gi"}}}}))
+		})
+
+		Context("when the default storage of workbenches changes", func() {
+			claim := func(namespace, name, storageClassName, size string, labels map[string]string) *corev1.PersistentVolumeClaim {
+				claim := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}},
+					},
+				}
+				if storageClassName != "" {
+					claim.Spec.StorageClassName = &storageClassName
+				}
+				return claim
+			}
+			workbench := map[string]string{labels.ODH.Dashboard: "true"}
+
+			BeforeEach(func() {
+				objects = append(objects,
+					claim("fraud", "analysis", "gp3-csi", "20Gi", workbench),
+					claim("fraud", "training", "io2-csi", "100Gi", workbench),
+					claim("fraud", "scratch", "", "10Gi", workbench),
+					claim("fraud", "database", "gp3-csi", "5Gi", nil),
+				)
+				instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+				instance.Spec.Components.Workbenches.StorageClassName = "io2-csi"
+			})
+
+			It("should set the storage class in the dashboard config", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{"notebookController": map[string]any{"pvcSize": "40Gi"}}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+				storageClassName, _, err := unstructured.NestedString(dashboardConfigSpec(ctx), "notebookController", "storageClassName")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(storageClassName).To(Equal("io2-csi"))
+			})
+
+			It("should count the smaller volumes of workbenches", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{
+					"notebookController": map[string]any{"pvcSize": "20Gi", "storageClassName": "io2-csi"},
+				}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(And(
+					HavePrefix("Normal WorkbenchStorageChanged "),
+					ContainSubstring("from 20Gi to 40Gi. Volumes of existing workbenches keep their size, 2 of them are smaller"),
+				))
+			})
+
+			It("should count the volumes of workbenches of another storage class", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{
+					"notebookController": map[string]any{"pvcSize": "40Gi", "storageClassName": "gp3-csi"},
+				}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(ContainSubstring(
+					"from gp3-csi to io2-csi. Volumes of existing workbenches keep their storage class, 2 of them use another one"))
+			})
+
+			It("should guide the migration of both the size and storage class", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{
+					"notebookController": map[string]any{"pvcSize": "20Gi", "storageClassName": "gp3-csi"},
+				}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+				Expect(recorder.Events).To(HaveLen(2))
+			})
+
+			It("should not guide the migration when the defaults were not set before", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{"notebookController": map[string]any{"enabled": true}}))
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+				Expect(patches).To(Equal(1))
+				Expect(recorder.Events).To(BeEmpty())
+			})
+
+			It("should fail when the volumes of workbenches cannot be listed", func(ctx context.Context) {
+				objects = append(objects, newDashboardConfig(map[string]any{"notebookController": map[string]any{"pvcSize": "20Gi"}}))
+				funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*corev1.PersistentVolumeClaimList); ok {
+						return errors.New("forbidden")
+					}
+					return cli.List(ctx, list, opts...)
+				}
+
+				_, err := reconcile(ctx)
+				Expect(err).To(MatchError(ContainSubstring("failed to list volumes of workbenches")))
+			})
+		})
+
+		It("should not patch the dashboard config once up to date", func(ctx context.Context) {
+			objects = append(objects, newDashboardConfig(map[string]any{
+				"notebookController": map[string]any{"pvcSize": "40Gi"},
+			}))
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+			Expect(patches).To(BeZero())
+		})
+
+		It("should leave the dashboard config as is without settings", func(ctx context.Context) {
+			objects = append(objects, newDashboardConfig(map[string]any{"notebookController": map[string]any{"pvcSize": "20Gi"}}))
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(patches).To(BeZero())
+		})
+
+		It("should do nothing until the dashboard config is deployed", func(ctx context.Context) {
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(patches).To(BeZero())
+		})
+
+		It("should fail when the dashboard config cannot be patched", func(ctx context.Context) {
+			objects = append(objects, newDashboardConfig(map[string]any{}))
+			instance.Spec.Components.Workbenches.PVCSize = quantity("40Gi")
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return errors.New("forbidden")
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to set notebookController.pvcSize in the dashboard config")))
+		})
+
+		It("should fail without DSCInitialization to get the applications namespace from", func(ctx context.Context) {
+			objects = nil
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("no DSCInitialization found")))
+		})
+
+		It("should ignore a deleted DataScienceCluster", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		})
+	})
+})
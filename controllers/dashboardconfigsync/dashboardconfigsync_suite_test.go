@@ -0,0 +1,13 @@
+package dashboardconfigsync
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDashboardConfigSync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dashboard config sync suite")
+}
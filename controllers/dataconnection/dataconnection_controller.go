@@ -0,0 +1,328 @@
+// Package dataconnection contains controller logic adding the data connections defined at platform level
+// to data science projects, as Secrets recognized by the dashboard.
+package dataconnection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dataconnectionv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dataconnection/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ConditionDataConnectionAdded tells whether the data connection has been added to all selected projects.
+	ConditionDataConnectionAdded conditionsv1.ConditionType = "DataConnectionAdded"
+
+	// SecretPrefix is prepended to the name of the DataConnection to name Secrets of data science projects,
+	// following the naming of data connections created from the dashboard.
+	SecretPrefix = "aws-connection-"
+
+	// credentialsResync is how often credentials are copied again, as Secrets of the applications namespace
+	// are not watched.
+	credentialsResync = 10 * time.Minute
+)
+
+// +kubebuilder:rbac:groups="dataconnection.opendatahub.io",resources=dataconnections,verbs=get;list;watch
+// +kubebuilder:rbac:groups="dataconnection.opendatahub.io",resources=dataconnections/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="dataconnection.opendatahub.io",resources=dataconnections/finalizers,verbs=update
+
+// DataConnectionReconciler holds the controller configuration.
+type DataConnectionReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads Secrets, which are only cached in the namespaces of the operator.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DataConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for data connections.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("data-connection-controller").
+		For(&dataconnectionv1alpha1.DataConnection{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.watchNamespaces), builder.WithPredicates(namespaceLabelsChanged)).
+		Complete(r)
+}
+
+// Reconcile adds a Secret holding the data connection to each selected data science project,
+// and removes it from projects which are not selected anymore.
+func (r *DataConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("dataconnection", req.Name)
+
+	instance := &dataconnectionv1alpha1.DataConnection{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		// Secrets are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, err := r.selectedNamespaces(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var errs []error
+	credentials, err := r.credentials(ctx, instance)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		for _, namespace := range namespaces {
+			if err := r.apply(ctx, instance, namespace, credentials); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := r.removeStale(ctx, instance, namespaces); err != nil {
+		errs = append(errs, err)
+	}
+
+	condition := conditionsv1.Condition{
+		Type:    ConditionDataConnectionAdded,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: fmt.Sprintf("Data connection added to %d data science projects", len(namespaces)),
+	}
+	phase := status.PhaseReady
+	reconcileErr := errors.Join(errs...)
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "Failed to add data connection to data science projects")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DataConnectionFailed", "Failed to add data connection to data science projects: %v", reconcileErr)
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "DataConnectionFailed"
+		condition.Message = reconcileErr.Error()
+		phase = status.PhaseError
+	}
+
+	if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dataconnectionv1alpha1.DataConnection) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, condition)
+		saved.Status.Namespaces = namespaces
+		saved.Status.Phase = phase
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return ctrl.Result{RequeueAfter: credentialsResync}, nil
+}
+
+// selectedNamespaces returns the sorted names of data science projects matching the namespace selector.
+func (r *DataConnectionReconciler) selectedNamespaces(ctx context.Context, instance *dataconnectionv1alpha1.DataConnection) ([]string, error) {
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+
+	return selectNamespaces(projects.Items, instance.Spec.NamespaceSelector)
+}
+
+// selectNamespaces returns the sorted names of the active namespaces matching the selector, all of them when nil.
+func selectNamespaces(namespaces []corev1.Namespace, selector *metav1.LabelSelector) ([]string, error) {
+	matcher := k8slabels.Everything()
+	if selector != nil {
+		var err error
+		if matcher, err = metav1.LabelSelectorAsSelector(selector); err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+	}
+
+	selected := []string{}
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		if namespace.Status.Phase == corev1.NamespaceTerminating || !matcher.Matches(k8slabels.Set(namespace.GetLabels())) {
+			continue
+		}
+		selected = append(selected, namespace.Name)
+	}
+	sort.Strings(selected)
+
+	return selected, nil
+}
+
+// credentials returns the data of the credentials Secret in the applications namespace.
+func (r *DataConnectionReconciler) credentials(ctx context.Context, instance *dataconnectionv1alpha1.DataConnection) (map[string][]byte, error) {
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: instance.Spec.S3.CredentialsSecretName, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get credentials Secret %s/%s: %w", namespace, instance.Spec.S3.CredentialsSecretName, err)
+	}
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+		if _, exists := secret.Data[key]; !exists {
+			return nil, fmt.Errorf("credentials Secret %s/%s has no %s", namespace, secret.Name, key)
+		}
+	}
+
+	return secret.Data, nil
+}
+
+// apply creates or updates the Secret of the data connection in the namespace. Secrets of the same name which
+// are not managed by the operator, e.g. created from the dashboard, are left untouched.
+func (r *DataConnectionReconciler) apply(ctx context.Context, instance *dataconnectionv1alpha1.DataConnection, namespace string, credentials map[string][]byte) error {
+	desired, err := desiredSecret(instance, namespace, credentials, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.Secret{}
+	err = r.APIReader.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case k8serr.IsNotFound(err):
+		if err := r.Client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create data connection in %s: %w", namespace, err)
+		}
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if existing.GetLabels()[labels.ODH.DataConnection] != instance.Name {
+		r.Log.Info("Skipping data connection Secret not managed by the operator", "namespace", namespace, "name", existing.Name)
+		return nil
+	}
+	if reflect.DeepEqual(existing.Data, desired.Data) && reflect.DeepEqual(existing.Labels, desired.Labels) &&
+		reflect.DeepEqual(existing.Annotations, desired.Annotations) {
+		return nil
+	}
+
+	existing.Data = desired.Data
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	if err := r.Client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update data connection in %s: %w", namespace, err)
+	}
+
+	return nil
+}
+
+// removeStale deletes Secrets of the data connection from namespaces which are not selected anymore.
+func (r *DataConnectionReconciler) removeStale(ctx context.Context, instance *dataconnectionv1alpha1.DataConnection, namespaces []string) error {
+	secrets := &corev1.SecretList{}
+	if err := r.APIReader.List(ctx, secrets, client.MatchingLabels{labels.ODH.DataConnection: instance.Name}); err != nil {
+		return fmt.Errorf("failed to list data connection Secrets: %w", err)
+	}
+
+	selected := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		selected[namespace] = struct{}{}
+	}
+	var errs []error
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if _, exists := selected[secret.Namespace]; exists {
+			continue
+		}
+		r.Log.Info("Removing data connection", "namespace", secret.Namespace, "name", secret.Name)
+		if err := r.Client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to remove data connection from %s: %w", secret.Namespace, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// desiredSecret returns the Secret of the data connection in the namespace, labeled as data connection for the dashboard.
+func desiredSecret(instance *dataconnectionv1alpha1.DataConnection, namespace string, credentials map[string][]byte, scheme *runtime.Scheme) (*corev1.Secret, error) {
+	displayName := instance.Spec.DisplayName
+	if displayName == "" {
+		displayName = instance.Name
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretPrefix + instance.Name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				labels.ODH.Dashboard:             "true",
+				annotations.ManagedByODHOperator: "true",
+				labels.ODH.DataConnection:        instance.Name,
+			},
+			Annotations: map[string]string{
+				annotations.ConnectionType: "s3",
+				annotations.DisplayName:    displayName,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     credentials["AWS_ACCESS_KEY_ID"],
+			"AWS_SECRET_ACCESS_KEY": credentials["AWS_SECRET_ACCESS_KEY"],
+			"AWS_S3_ENDPOINT":       []byte(instance.Spec.S3.Endpoint),
+			"AWS_DEFAULT_REGION":    []byte(instance.Spec.S3.Region),
+			"AWS_S3_BUCKET":         []byte(instance.Spec.S3.Bucket),
+		},
+	}
+	// cluster scoped owner, Secrets are removed along with the DataConnection
+	if err := controllerutil.SetControllerReference(instance, secret, scheme); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// watchNamespaces reconciles all DataConnections, as any of them may select the namespace.
+func (r *DataConnectionReconciler) watchNamespaces(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dataconnectionv1alpha1.DataConnectionList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DataConnections")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
+
+// namespaceLabelsChanged passes created namespaces and namespaces whose labels changed, as selection is label based.
+var namespaceLabelsChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
@@ -0,0 +1,291 @@
+package dataconnection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dataconnectionv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dataconnection/v1alpha1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Data connection Secrets", func() {
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "leaving", Labels: map[string]string{"team": "a"}},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+		},
+	}
+
+	DescribeTable("should be added to the active namespaces matching the selector",
+		func(selector *metav1.LabelSelector, expected []string) {
+			Expect(selectNamespaces(namespaces, selector)).To(Equal(expected))
+		},
+		Entry("all of them without selector", nil, []string{"team-a", "team-b"}),
+		Entry("with a selector", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, []string{"team-a"}),
+		Entry("none of them without match", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "c"}}, []string{}),
+	)
+
+	It("should reject an invalid selector", func() {
+		selector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}}
+
+		_, err := selectNamespaces(namespaces, selector)
+		Expect(err).To(MatchError(ContainSubstring("invalid namespace selector")))
+	})
+
+	Context("desired in a namespace", func() {
+		var (
+			scheme   *runtime.Scheme
+			instance *dataconnectionv1alpha1.DataConnection
+		)
+		credentials := map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id"), "AWS_SECRET_ACCESS_KEY": []byte("key"), "other": []byte("ignored")}
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			Expect(dataconnectionv1alpha1.AddToScheme(scheme)).To(Succeed())
+			instance = &dataconnectionv1alpha1.DataConnection{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-models", UID: "uid"},
+				Spec: dataconnectionv1alpha1.DataConnectionSpec{
+					S3: dataconnectionv1alpha1.S3ConnectionSpec{Endpoint: "https://s3.example.com", Bucket: "models"},
+				},
+			}
+		})
+
+		It("should be recognized by the dashboard and owned by the DataConnection", func() {
+			secret, err := desiredSecret(instance, "team-a", credentials, scheme)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(secret.Namespace).To(Equal("team-a"))
+			Expect(secret.Name).To(Equal("aws-connection-shared-models"))
+			Expect(secret.Labels).To(HaveKeyWithValue(labels.ODH.Dashboard, "true"))
+			Expect(secret.Labels).To(HaveKeyWithValue(labels.ODH.DataConnection, "shared-models"))
+			Expect(secret.Annotations).To(Equal(map[string]string{annotations.DisplayName: "shared-models", annotations.ConnectionType: "s3"}))
+			Expect(secret.OwnerReferences).To(ConsistOf(HaveField("Name", "shared-models")))
+		})
+
+		It("should only hold the credentials and the endpoint of the data connection", func() {
+			secret, err := desiredSecret(instance, "team-a", credentials, scheme)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(secret.Data).To(Equal(map[string][]byte{
+				"AWS_ACCESS_KEY_ID":     []byte("id"),
+				"AWS_SECRET_ACCESS_KEY": []byte("key"),
+				"AWS_S3_ENDPOINT":       []byte("https://s3.example.com"),
+				"AWS_DEFAULT_REGION":    []byte(""),
+				"AWS_S3_BUCKET":         []byte("models"),
+			}))
+		})
+
+		It("should be displayed with the display name when set", func() {
+			instance.Spec.DisplayName = "Shared models"
+
+			secret, err := desiredSecret(instance, "team-a", credentials, scheme)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Annotations).To(HaveKeyWithValue(annotations.DisplayName, "Shared models"))
+		})
+	})
+})
+
+var _ = Describe("Data connection controller", func() {
+	var (
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "shared-models"}}
+	)
+
+	project := func(name string, projectLabels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: projectLabels}}
+	}
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(dataconnectionv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+				WithStatusSubresource(&dataconnectionv1alpha1.DataConnection{}).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &DataConnectionReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, req)
+	}
+	instance := func(ctx context.Context) *dataconnectionv1alpha1.DataConnection {
+		GinkgoHelper()
+		instance := &dataconnectionv1alpha1.DataConnection{}
+		Expect(cli.Get(ctx, req.NamespacedName, instance)).To(Succeed())
+		return instance
+	}
+	secret := func(ctx context.Context, namespace string) (*corev1.Secret, error) {
+		secret := &corev1.Secret{}
+		err := cli.Get(ctx, client.ObjectKey{Name: SecretPrefix + "shared-models", Namespace: namespace}, secret)
+		return secret, err
+	}
+
+	BeforeEach(func() {
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+				Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: "opendatahub"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: "opendatahub"},
+				Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id"), "AWS_SECRET_ACCESS_KEY": []byte("key")},
+			},
+			&dataconnectionv1alpha1.DataConnection{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-models"},
+				Spec: dataconnectionv1alpha1.DataConnectionSpec{
+					S3:                dataconnectionv1alpha1.S3ConnectionSpec{Endpoint: "https://s3.example.com", CredentialsSecretName: "s3-credentials"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				},
+			},
+			project("team-a", map[string]string{labels.ODH.Dashboard: "true", "team": "a"}),
+			project("team-b", map[string]string{labels.ODH.Dashboard: "true", "team": "b"}),
+			project("not-a-project", map[string]string{"team": "a"}),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should add the data connection to the selected data science projects", func(ctx context.Context) {
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(credentialsResync))
+
+		added, err := secret(ctx, "team-a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(added.Data).To(HaveKeyWithValue("AWS_ACCESS_KEY_ID", []byte("id")))
+		for _, namespace := range []string{"team-b", "not-a-project"} {
+			_, err := secret(ctx, namespace)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue(), namespace)
+		}
+
+		saved := instance(ctx)
+		Expect(saved.Status.Phase).To(Equal(status.PhaseReady))
+		Expect(saved.Status.Namespaces).To(Equal([]string{"team-a"}))
+		Expect(conditionsv1.IsStatusConditionTrue(saved.Status.Conditions, ConditionDataConnectionAdded)).To(BeTrue())
+	})
+
+	It("should copy the credentials again once rotated", func(ctx context.Context) {
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		credentials := &corev1.Secret{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "s3-credentials", Namespace: "opendatahub"}, credentials)).To(Succeed())
+		credentials.Data["AWS_SECRET_ACCESS_KEY"] = []byte("rotated")
+		Expect(cli.Update(ctx, credentials)).To(Succeed())
+
+		_, err = reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		updated, err := secret(ctx, "team-a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Data).To(HaveKeyWithValue("AWS_SECRET_ACCESS_KEY", []byte("rotated")))
+	})
+
+	It("should remove the data connection from projects not selected anymore", func(ctx context.Context) {
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		updated := instance(ctx)
+		updated.Spec.NamespaceSelector.MatchLabels["team"] = "b"
+		Expect(cli.Update(ctx, updated)).To(Succeed())
+
+		_, err = reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = secret(ctx, "team-a")
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		_, err = secret(ctx, "team-b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instance(ctx).Status.Namespaces).To(Equal([]string{"team-b"}))
+	})
+
+	When("a data connection of the same name was created from the dashboard", func() {
+		BeforeEach(func() {
+			objects = append(objects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: SecretPrefix + "shared-models", Namespace: "team-a", Labels: map[string]string{labels.ODH.Dashboard: "true"}},
+				Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("own")},
+			})
+		})
+
+		It("should leave it untouched", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			existing, err := secret(ctx, "team-a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(existing.Data).To(Equal(map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("own")}))
+		})
+	})
+
+	DescribeTable("should report credentials which cannot be copied",
+		func(ctx context.Context, mutate func(*corev1.Secret), message string) {
+			credentials := objects[1].(*corev1.Secret)
+			mutate(credentials)
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+
+			saved := instance(ctx)
+			Expect(saved.Status.Phase).To(Equal(status.PhaseError))
+			Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, ConditionDataConnectionAdded)).To(And(
+				HaveField("Status", corev1.ConditionFalse),
+				HaveField("Message", ContainSubstring(message)),
+			))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DataConnectionFailed")))
+			_, err = secret(ctx, "team-a")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		},
+		Entry("when the Secret is missing", func(credentials *corev1.Secret) {
+			credentials.Name = "other"
+		}, "failed to get credentials Secret opendatahub/s3-credentials"),
+		Entry("when a key is missing", func(credentials *corev1.Secret) {
+			delete(credentials.Data, "AWS_SECRET_ACCESS_KEY")
+		}, "credentials Secret opendatahub/s3-credentials has no AWS_SECRET_ACCESS_KEY"),
+	)
+
+	When("removing a stale data connection fails", func() {
+		BeforeEach(func() {
+			objects = append(objects, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name: SecretPrefix + "shared-models", Namespace: "team-b", Labels: map[string]string{labels.ODH.DataConnection: "shared-models"},
+			}})
+			funcs.Delete = func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				return errors.New("forbidden")
+			}
+		})
+
+		It("should still add the data connection to the selected projects", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to remove data connection from team-b")))
+
+			_, err = secret(ctx, "team-a")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	It("should ignore a deleted DataConnection", func(ctx context.Context) {
+		objects = objects[:2]
+
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+})
@@ -0,0 +1,13 @@
+package dataconnection
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDataConnection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Data connection controller suite")
+}
@@ -0,0 +1,323 @@
+package datasciencecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	// acceleratorsRequeueInterval is how often accelerators are checked again while a GPU operator is not ready
+	acceleratorsRequeueInterval = time.Minute
+)
+
+// acceleratorVendor describes how the GPU operator of a vendor is detected, and the dashboard defaults depending on
+// the accelerators of the vendor.
+type acceleratorVendor struct {
+	name string
+	// resource is the extended resource advertised by nodes for the accelerators
+	resource corev1.ResourceName
+	// operator prefixes the name of the OperatorCondition of the GPU operator
+	operator string
+	// config is the kind of the resource configuring the GPU operator, which tells whether it is ready
+	config      schema.GroupVersionKind
+	configReady func(config *unstructured.Unstructured) bool
+	// profile is the name of the AcceleratorProfile created for the accelerators
+	profile string
+	// templates are the serving runtime templates of the dashboard running on the accelerators
+	templates []string
+}
+
+var acceleratorVendors = []acceleratorVendor{
+	{
+		name:     "NVIDIA",
+		resource: "nvidia.com/gpu",
+		operator: "gpu-operator-certified",
+		config:   gvk.ClusterPolicy,
+		configReady: func(config *unstructured.Unstructured) bool {
+			state, _, _ := unstructured.NestedString(config.Object, "status", "state")
+			return state == "ready"
+		},
+		profile:   "nvidia-gpu",
+		templates: []string{"vllm-runtime-template"},
+	},
+	{
+		name:     "AMD",
+		resource: "amd.com/gpu",
+		operator: "amd-gpu-operator",
+		config:   gvk.DeviceConfig,
+		configReady: func(config *unstructured.Unstructured) bool {
+			desired, _, _ := unstructured.NestedInt64(config.Object, "status", "devicePlugin", "desiredNumber")
+			available, _, _ := unstructured.NestedInt64(config.Object, "status", "devicePlugin", "availableNumber")
+			return desired > 0 && available == desired
+		},
+		profile:   "amd-gpu",
+		templates: []string{"vllm-rocm-runtime-template"},
+	},
+}
+
+// reconcileAccelerators reports the GPU operators found in the cluster and the accelerators nodes advertise, and gates
+// the defaults of the dashboard depending on them: the AcceleratorProfile of a vendor is created and enabled once its
+// accelerators are ready, and its serving runtime templates are disabled until then.
+func (r *DataScienceClusterReconciler) reconcileAccelerators(ctx context.Context, instance *dscv1.DataScienceCluster) (*dscv1.DataScienceCluster, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.Client.List(ctx, nodes); err != nil {
+		return instance, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var accelerators []status.AcceleratorStatus
+	ready := map[string]bool{}
+	for _, vendor := range acceleratorVendors {
+		accelerator, err := r.acceleratorStatus(ctx, vendor, nodes.Items)
+		if err != nil {
+			return instance, err
+		}
+		ready[vendor.name] = accelerator.Ready
+		if accelerator.OperatorInstalled || accelerator.Nodes > 0 {
+			accelerators = append(accelerators, accelerator)
+		}
+	}
+
+	var errs *multierror.Error
+	namespace := r.DataScienceCluster.DSCISpec.ApplicationsNamespace
+	for _, vendor := range acceleratorVendors {
+		errs = multierror.Append(errs, r.reconcileAcceleratorProfile(ctx, namespace, vendor, ready[vendor.name]))
+	}
+	errs = multierror.Append(errs, r.gateServingRuntimeTemplates(ctx, namespace, ready))
+
+	conditionStatus, reason, message := acceleratorsCondition(accelerators)
+	instance, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		status.SetCondition(&saved.Status.Conditions, string(status.CapabilityAccelerators), reason, message, conditionStatus)
+		saved.Status.Accelerators = accelerators
+	})
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return instance, errs.ErrorOrNil()
+}
+
+// acceleratorStatus checks the GPU operator of the vendor, when installed, and counts the accelerators nodes advertise.
+func (r *DataScienceClusterReconciler) acceleratorStatus(ctx context.Context, vendor acceleratorVendor, nodes []corev1.Node) (status.AcceleratorStatus, error) {
+	accelerator := status.AcceleratorStatus{Vendor: vendor.name, Resource: string(vendor.resource)}
+	for _, node := range nodes {
+		if quantity, exists := node.Status.Allocatable[vendor.resource]; exists && !quantity.IsZero() {
+			accelerator.Nodes++
+			accelerator.Allocatable += quantity.Value()
+		}
+	}
+
+	installed, err := cluster.OperatorExists(ctx, r.Client, vendor.operator)
+	if err != nil {
+		return accelerator, fmt.Errorf("failed to find the %s GPU operator: %w", vendor.name, err)
+	}
+	accelerator.OperatorInstalled = installed
+	if installed {
+		operatorReady, message, err := r.gpuOperatorReady(ctx, vendor)
+		if err != nil {
+			return accelerator, err
+		}
+		if !operatorReady {
+			accelerator.Message = message
+			return accelerator, nil
+		}
+	}
+
+	switch {
+	case accelerator.Allocatable == 0 && installed:
+		accelerator.Message = fmt.Sprintf("GPU operator is ready but no node advertises %s", vendor.resource)
+	case accelerator.Allocatable == 0:
+		accelerator.Message = "GPU operator is not installed"
+	default:
+		accelerator.Ready = true
+		accelerator.Message = fmt.Sprintf("%d %s allocatable on %d nodes", accelerator.Allocatable, vendor.resource, accelerator.Nodes)
+	}
+
+	return accelerator, nil
+}
+
+// gpuOperatorReady tells whether a resource configuring the GPU operator of the vendor reports it ready, or why not.
+func (r *DataScienceClusterReconciler) gpuOperatorReady(ctx context.Context, vendor acceleratorVendor) (bool, string, error) {
+	configs := &unstructured.UnstructuredList{}
+	configs.SetGroupVersionKind(vendor.config.GroupVersion().WithKind(vendor.config.Kind + "List"))
+	err := r.Client.List(ctx, configs)
+	switch {
+	case meta.IsNoMatchError(err):
+		return false, fmt.Sprintf("GPU operator is installed but the %s CRD is missing", vendor.config.Kind), nil
+	case err != nil:
+		return false, "", fmt.Errorf("failed to list %s resources: %w", vendor.config.Kind, err)
+	case len(configs.Items) == 0:
+		return false, fmt.Sprintf("GPU operator is installed but no %s configures it", vendor.config.Kind), nil
+	}
+
+	names := make([]string, 0, len(configs.Items))
+	for i := range configs.Items {
+		if vendor.configReady(&configs.Items[i]) {
+			return true, "", nil
+		}
+		names = append(names, configs.Items[i].GetName())
+	}
+
+	return false, fmt.Sprintf("GPU operator is installed but %s %s is not ready", vendor.config.Kind, strings.Join(names, ", ")), nil
+}
+
+// acceleratorsCondition returns the condition reporting the accelerators, explaining what is missing when none are ready.
+func acceleratorsCondition(accelerators []status.AcceleratorStatus) (corev1.ConditionStatus, string, string) {
+	if len(accelerators) == 0 {
+		return corev1.ConditionFalse, status.MissingOperatorReason,
+			"No GPU operator is installed and no node advertises accelerators, install the NVIDIA or AMD GPU Operator to use GPUs"
+	}
+
+	var ready, notReady []string
+	for _, accelerator := range accelerators {
+		summary := fmt.Sprintf("%s: %s", accelerator.Vendor, accelerator.Message)
+		if accelerator.Ready {
+			ready = append(ready, summary)
+		} else {
+			notReady = append(notReady, summary)
+		}
+	}
+	if len(ready) == 0 {
+		return corev1.ConditionFalse, status.AcceleratorsNotReady, strings.Join(notReady, "; ")
+	}
+
+	return corev1.ConditionTrue, status.ConfiguredReason, strings.Join(append(ready, notReady...), "; ")
+}
+
+// acceleratorsPending tells whether a GPU operator is installed but its accelerators are not ready yet.
+func acceleratorsPending(conditions []conditionsv1.Condition) bool {
+	condition := conditionsv1.FindStatusCondition(conditions, status.CapabilityAccelerators)
+	return condition != nil && condition.Reason == status.AcceleratorsNotReady
+}
+
+// reconcileAcceleratorProfile creates the AcceleratorProfile of the vendor once its accelerators are ready, and keeps
+// it enabled only while they are. Profiles not labelled as managed by the operator are left to users.
+func (r *DataScienceClusterReconciler) reconcileAcceleratorProfile(ctx context.Context, namespace string, vendor acceleratorVendor, ready bool) error {
+	profile := &unstructured.Unstructured{}
+	profile.SetGroupVersionKind(gvk.AcceleratorProfile)
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: vendor.profile}, profile)
+	switch {
+	case meta.IsNoMatchError(err):
+		// the dashboard is not deployed
+		return nil
+	case k8serr.IsNotFound(err):
+		if !ready {
+			return nil
+		}
+		profile.SetNamespace(namespace)
+		profile.SetName(vendor.profile)
+		profile.SetLabels(map[string]string{annotations.ManagedByODHOperator: "true"})
+		profile.Object["spec"] = map[string]any{
+			"displayName": vendor.name + " GPU",
+			"description": fmt.Sprintf("%s GPUs advertised by nodes as %s", vendor.name, vendor.resource),
+			"identifier":  string(vendor.resource),
+			"enabled":     true,
+			"tolerations": []any{
+				map[string]any{"key": string(vendor.resource), "operator": "Exists", "effect": "NoSchedule"},
+			},
+		}
+		if err := r.Client.Create(ctx, profile); err != nil {
+			return fmt.Errorf("failed to create AcceleratorProfile %s: %w", vendor.profile, err)
+		}
+		r.Log.Info("created AcceleratorProfile", "name", vendor.profile, "vendor", vendor.name)
+
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if profile.GetLabels()[annotations.ManagedByODHOperator] != "true" {
+		return nil
+	}
+	if enabled, _, _ := unstructured.NestedBool(profile.Object, "spec", "enabled"); enabled == ready {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"enabled": ready}})
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, profile, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to update AcceleratorProfile %s: %w", vendor.profile, err)
+	}
+	r.Log.Info("updated AcceleratorProfile", "name", vendor.profile, "enabled", ready)
+
+	return nil
+}
+
+// gateServingRuntimeTemplates disables the serving runtime templates of the vendors whose accelerators are not ready in
+// the dashboard config, and enables them back once ready. Templates disabled by users are left as they are.
+func (r *DataScienceClusterReconciler) gateServingRuntimeTemplates(ctx context.Context, namespace string, ready map[string]bool) error {
+	dashboardConfig := &unstructured.Unstructured{}
+	dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dashboard.ConfigName}, dashboardConfig); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	disabled, _, _ := unstructured.NestedStringSlice(dashboardConfig.Object, "spec", "templateDisablement")
+	var gated []string
+	if value := dashboardConfig.GetAnnotations()[annotations.AcceleratorDisabledTemplates]; value != "" {
+		gated = strings.Split(value, ",")
+	}
+	changed := false
+	for _, vendor := range acceleratorVendors {
+		for _, template := range vendor.templates {
+			switch {
+			case ready[vendor.name] && slices.Contains(gated, template):
+				disabled = slices.DeleteFunc(disabled, func(name string) bool { return name == template })
+				gated = slices.DeleteFunc(gated, func(name string) bool { return name == template })
+				changed = true
+			case !ready[vendor.name] && !slices.Contains(disabled, template):
+				disabled = append(disabled, template)
+				gated = append(gated, template)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var gatedValue any
+	if len(gated) > 0 {
+		gatedValue = strings.Join(gated, ",")
+	}
+	if disabled == nil {
+		disabled = []string{}
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{annotations.AcceleratorDisabledTemplates: gatedValue}},
+		"spec":     map[string]any{"templateDisablement": disabled},
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, dashboardConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to gate serving runtime templates in the dashboard config: %w", err)
+	}
+	r.Log.Info("gated serving runtime templates on accelerators", "disabled", gated)
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package datasciencecluster
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	ofapiv2 "github.com/operator-framework/api/pkg/operators/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Accelerators", func() {
+	var (
+		instance      *dscv1.DataScienceCluster
+		node          *corev1.Node
+		clusterPolicy *unstructured.Unstructured
+		config        *unstructured.Unstructured
+		objects       []client.Object
+		funcs         interceptor.Funcs
+		cli           client.Client
+	)
+
+	profileKey := types.NamespacedName{Namespace: "opendatahub", Name: "nvidia-gpu"}
+	configKey := types.NamespacedName{Namespace: "opendatahub", Name: dashboard.ConfigName}
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpu-worker"},
+			Status:     corev1.NodeStatus{Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")}},
+		}
+		clusterPolicy = &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"state": "notReady"}}}
+		clusterPolicy.SetGroupVersionKind(gvk.ClusterPolicy)
+		clusterPolicy.SetName("gpu-cluster-policy")
+		config = &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"templateDisablement": []any{"caikit-tgis-template"}}}}
+		config.SetGroupVersionKind(gvk.OdhDashboardConfig)
+		config.SetName(dashboard.ConfigName)
+		config.SetNamespace("opendatahub")
+		objects = []client.Object{node, clusterPolicy, config,
+			&ofapiv2.OperatorCondition{ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-certified.v24.3.0", Namespace: "nvidia-gpu-operator"}},
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	reconcile := func(ctx context.Context) error {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			Expect(ofapiv2.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dscv1.DataScienceCluster{}).
+				WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &DataScienceClusterReconciler{
+			Client:             cli,
+			Scheme:             cli.Scheme(),
+			Log:                logr.Discard(),
+			DataScienceCluster: &DataScienceClusterConfig{DSCISpec: &dsciv1.DSCInitializationSpec{ApplicationsNamespace: "opendatahub"}},
+		}
+		var err error
+		instance, err = r.reconcileAccelerators(ctx, instance)
+		return err
+	}
+	condition := func() *conditionsv1.Condition {
+		GinkgoHelper()
+		condition := conditionsv1.FindStatusCondition(instance.Status.Conditions, status.CapabilityAccelerators)
+		Expect(condition).ToNot(BeNil())
+		return condition
+	}
+	profile := func(ctx context.Context) (*unstructured.Unstructured, error) {
+		profile := &unstructured.Unstructured{}
+		profile.SetGroupVersionKind(gvk.AcceleratorProfile)
+		return profile, cli.Get(ctx, profileKey, profile)
+	}
+	enabled := func(profile *unstructured.Unstructured) bool {
+		enabled, _, _ := unstructured.NestedBool(profile.Object, "spec", "enabled")
+		return enabled
+	}
+	dashboardConfig := func(ctx context.Context) *unstructured.Unstructured {
+		GinkgoHelper()
+		saved := &unstructured.Unstructured{}
+		saved.SetGroupVersionKind(gvk.OdhDashboardConfig)
+		Expect(cli.Get(ctx, configKey, saved)).To(Succeed())
+		return saved
+	}
+	without := func(removed client.Object) {
+		objects = slices.DeleteFunc(objects, func(obj client.Object) bool { return obj == removed })
+	}
+	disabledTemplates := func(ctx context.Context) []string {
+		GinkgoHelper()
+		disabled, _, _ := unstructured.NestedStringSlice(dashboardConfig(ctx).Object, "spec", "templateDisablement")
+		return disabled
+	}
+	setReady := func() {
+		GinkgoHelper()
+		Expect(unstructured.SetNestedField(clusterPolicy.Object, "ready", "status", "state")).To(Succeed())
+	}
+
+	When("the GPU operator is not ready", func() {
+		It("should report the accelerators pending", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(instance.Status.Accelerators).To(Equal([]status.AcceleratorStatus{{
+				Vendor:            "NVIDIA",
+				Resource:          "nvidia.com/gpu",
+				OperatorInstalled: true,
+				Nodes:             1,
+				Allocatable:       4,
+				Message:           "GPU operator is installed but ClusterPolicy gpu-cluster-policy is not ready",
+			}}))
+			Expect(condition().Status).To(Equal(corev1.ConditionFalse))
+			Expect(acceleratorsPending(instance.Status.Conditions)).To(BeTrue())
+		})
+
+		It("should not create the AcceleratorProfile", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			_, err := profile(ctx)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should disable the GPU serving runtime templates", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(disabledTemplates(ctx)).To(Equal([]string{"caikit-tgis-template", "vllm-runtime-template", "vllm-rocm-runtime-template"}))
+			Expect(dashboardConfig(ctx).GetAnnotations()).To(HaveKeyWithValue(annotations.AcceleratorDisabledTemplates,
+				"vllm-runtime-template,vllm-rocm-runtime-template"))
+		})
+
+		It("should explain the ClusterPolicy CRD is missing", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Kind == gvk.ClusterPolicy.Kind+"List" {
+					return &meta.NoKindMatchError{GroupKind: gvk.ClusterPolicy.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(instance.Status.Accelerators[0].Message).To(Equal("GPU operator is installed but the ClusterPolicy CRD is missing"))
+		})
+
+		It("should explain no ClusterPolicy configures the GPU operator", func(ctx context.Context) {
+			without(clusterPolicy)
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(instance.Status.Accelerators[0].Message).To(Equal("GPU operator is installed but no ClusterPolicy configures it"))
+		})
+
+		It("should enable back the NVIDIA templates only once ready", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			setReady()
+			Expect(cli.Update(ctx, clusterPolicy)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(disabledTemplates(ctx)).To(Equal([]string{"caikit-tgis-template", "vllm-rocm-runtime-template"}))
+			Expect(dashboardConfig(ctx).GetAnnotations()).To(HaveKeyWithValue(annotations.AcceleratorDisabledTemplates, "vllm-rocm-runtime-template"))
+		})
+	})
+
+	When("the GPU operator gets ready", func() {
+		BeforeEach(setReady)
+
+		It("should report the accelerators ready", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(instance.Status.Accelerators).To(HaveExactElements(And(
+				HaveField("Ready", BeTrue()),
+				HaveField("Message", "4 nvidia.com/gpu allocatable on 1 nodes"),
+			)))
+			Expect(condition().Status).To(Equal(corev1.ConditionTrue))
+			Expect(acceleratorsPending(instance.Status.Conditions)).To(BeFalse())
+		})
+
+		It("should create the AcceleratorProfile enabled", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			profile, err := profile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile.GetLabels()).To(HaveKeyWithValue(annotations.ManagedByODHOperator, "true"))
+			Expect(enabled(profile)).To(BeTrue())
+			Expect(profile.Object).To(HaveKeyWithValue("spec", HaveKeyWithValue("identifier", "nvidia.com/gpu")))
+		})
+
+		It("should keep the templates disabled by users", func(ctx context.Context) {
+			Expect(unstructured.SetNestedStringSlice(config.Object, []string{"vllm-runtime-template"}, "spec", "templateDisablement")).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(disabledTemplates(ctx)).To(Equal([]string{"vllm-runtime-template", "vllm-rocm-runtime-template"}))
+			Expect(dashboardConfig(ctx).GetAnnotations()).To(HaveKeyWithValue(annotations.AcceleratorDisabledTemplates, "vllm-rocm-runtime-template"))
+		})
+
+		It("should leave the AcceleratorProfile of users as it is", func(ctx context.Context) {
+			userProfile := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"enabled": false}}}
+			userProfile.SetGroupVersionKind(gvk.AcceleratorProfile)
+			userProfile.SetNamespace(profileKey.Namespace)
+			userProfile.SetName(profileKey.Name)
+			objects = append(objects, userProfile)
+
+			Expect(reconcile(ctx)).To(Succeed())
+			profile, err := profile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enabled(profile)).To(BeFalse())
+		})
+
+		It("should disable the AcceleratorProfile once no node advertises GPUs anymore", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(cli.Delete(ctx, node)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Succeed())
+			profile, err := profile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enabled(profile)).To(BeFalse())
+			Expect(condition().Reason).To(Equal(status.AcceleratorsNotReady))
+			Expect(condition().Message).To(Equal("NVIDIA: GPU operator is ready but no node advertises nvidia.com/gpu"))
+		})
+
+		It("should not need the dashboard config", func(ctx context.Context) {
+			without(config)
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(condition().Status).To(Equal(corev1.ConditionTrue))
+		})
+	})
+
+	It("should report accelerators advertised by nodes without a GPU operator", func(ctx context.Context) {
+		objects = []client.Object{node}
+
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(instance.Status.Accelerators).To(HaveExactElements(And(
+			HaveField("OperatorInstalled", BeFalse()),
+			HaveField("Ready", BeTrue()),
+		)))
+	})
+
+	It("should report no GPU operator is installed", func(ctx context.Context) {
+		objects = nil
+
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(instance.Status.Accelerators).To(BeEmpty())
+		Expect(condition().Reason).To(Equal(status.MissingOperatorReason))
+		Expect(acceleratorsPending(instance.Status.Conditions)).To(BeFalse())
+	})
+
+	It("should fail when nodes cannot be listed", func(ctx context.Context) {
+		funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, isNodeList := list.(*corev1.NodeList); isNodeList {
+				return errors.New("forbidden")
+			}
+			return cli.List(ctx, list, opts...)
+		}
+
+		Expect(reconcile(ctx)).To(MatchError("failed to list nodes: forbidden"))
+	})
+
+	DescribeTable("should tell whether the AMD DeviceConfig is ready",
+		func(devicePlugin map[string]any, expected bool) {
+			config := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"devicePlugin": devicePlugin}}}
+			Expect(acceleratorVendors[1].configReady(config)).To(Equal(expected))
+		},
+		Entry("all device plugins available", map[string]any{"desiredNumber": int64(2), "availableNumber": int64(2)}, true),
+		Entry("some device plugins available", map[string]any{"desiredNumber": int64(2), "availableNumber": int64(1)}, false),
+		Entry("no device plugin desired", map[string]any{"desiredNumber": int64(0), "availableNumber": int64(0)}, false),
+		Entry("no status", map[string]any{}, false),
+	)
+
+	DescribeTable("should report the accelerators condition",
+		func(accelerators []status.AcceleratorStatus, conditionStatus corev1.ConditionStatus, reason, message string) {
+			actualStatus, actualReason, actualMessage := acceleratorsCondition(accelerators)
+			Expect(actualStatus).To(Equal(conditionStatus))
+			Expect(actualReason).To(Equal(reason))
+			Expect(actualMessage).To(Equal(message))
+		},
+		Entry("without GPU operator", nil, corev1.ConditionFalse, status.MissingOperatorReason,
+			"No GPU operator is installed and no node advertises accelerators, install the NVIDIA or AMD GPU Operator to use GPUs"),
+		Entry("none ready", []status.AcceleratorStatus{{Vendor: "NVIDIA", Message: "not ready"}, {Vendor: "AMD", Message: "missing"}},
+			corev1.ConditionFalse, status.AcceleratorsNotReady, "NVIDIA: not ready; AMD: missing"),
+		Entry("some ready", []status.AcceleratorStatus{{Vendor: "NVIDIA", Message: "not ready"}, {Vendor: "AMD", Ready: true, Message: "ready"}},
+			corev1.ConditionTrue, status.ConfiguredReason, "AMD: ready; NVIDIA: not ready"),
+	)
+})
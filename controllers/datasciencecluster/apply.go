@@ -0,0 +1,41 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+)
+
+// reportApplyProgress logs how many resources of the component have been applied and, while the component is not
+// ready yet, e.g. on first install, shows it in the Ready condition of the component. Components already ready keep
+// their condition, not to flap on every reconciliation.
+func (r *DataScienceClusterReconciler) reportApplyProgress(ctx context.Context, instance *dscv1.DataScienceCluster,
+	componentName, manifestPath string, applied, total int,
+) *dscv1.DataScienceCluster {
+	if rel, err := filepath.Rel(deploy.DefaultManifestPath, manifestPath); err == nil {
+		manifestPath = rel
+	}
+	message := fmt.Sprintf("Applied %d of %d resources from %s", applied, total, manifestPath)
+	r.Log.Info(message, "component", componentName)
+
+	if conditionsv1.IsStatusConditionTrue(instance.Status.Conditions, conditionsv1.ConditionType(componentName+status.ReadySuffix)) {
+		return instance
+	}
+	updated, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ApplyingResources, message, corev1.ConditionUnknown)
+	})
+	if err != nil {
+		// progress is informational only, the next batch or the result of the reconciliation updates the condition
+		r.Log.Error(err, "failed to report progress of applying resources", "component", componentName)
+		return instance
+	}
+
+	return updated
+}
@@ -0,0 +1,108 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+)
+
+const (
+	// conflictWindow is how long reverted changes of other field managers are taken into account.
+	conflictWindow = 15 * time.Minute
+	// conflictThreshold is how many times a change of the same manager has to be reverted within conflictWindow
+	// to report it: a single out-of-band change is just drift, repeated ones mean another controller fights the operator.
+	conflictThreshold = 3
+)
+
+// conflictTracker keeps when changes of other field managers to resources of components have been reverted.
+type conflictTracker struct {
+	mu      sync.Mutex
+	reverts map[deploy.Conflict][]time.Time
+}
+
+// observe records reverted changes and forgets those older than conflictWindow.
+func (t *conflictTracker) observe(conflicts []deploy.Conflict, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.reverts == nil {
+		t.reverts = map[deploy.Conflict][]time.Time{}
+	}
+	for _, conflict := range conflicts {
+		t.reverts[conflict] = append(t.reverts[conflict], now)
+	}
+	for conflict, times := range t.reverts {
+		recent := times[:0]
+		for _, revert := range times {
+			if now.Sub(revert) <= conflictWindow {
+				recent = append(recent, revert)
+			}
+		}
+		if len(recent) == 0 {
+			delete(t.reverts, conflict)
+		} else {
+			t.reverts[conflict] = recent
+		}
+	}
+}
+
+// fighting describes resources whose changes have been reverted at least conflictThreshold times within conflictWindow,
+// sorted so the description is stable across reconciles.
+func (t *conflictTracker) fighting() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var descriptions []string
+	for conflict, times := range t.reverts {
+		if len(times) >= conflictThreshold {
+			descriptions = append(descriptions, fmt.Sprintf("%s %s/%s modified by field manager %q (reverted %d times)",
+				conflict.Kind, conflict.Namespace, conflict.Name, conflict.Manager, len(times)))
+		}
+	}
+	sort.Strings(descriptions)
+
+	return descriptions
+}
+
+// reportConflicts sets the ConflictingManagers condition, naming field managers whose changes the operator keeps reverting,
+// and removes it once there are none.
+func (r *DataScienceClusterReconciler) reportConflicts(ctx context.Context, instance *dscv1.DataScienceCluster) (*dscv1.DataScienceCluster, error) {
+	fighting := r.conflicts.fighting()
+	existing := conditionsv1.FindStatusCondition(instance.Status.Conditions, status.ConditionConflictingManagers)
+
+	if len(fighting) == 0 {
+		if existing == nil {
+			return instance, nil
+		}
+		return status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.ConditionConflictingManagers)
+		})
+	}
+
+	message := "Other controllers keep changing resources managed by the operator, their changes are reverted: " + strings.Join(fighting, "; ")
+	if existing != nil && existing.Message == message {
+		return instance, nil
+	}
+	if existing == nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, status.ConflictingFieldManagers, message)
+	}
+
+	return status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, conditionsv1.Condition{
+			Type:    status.ConditionConflictingManagers,
+			Status:  corev1.ConditionTrue,
+			Reason:  status.ConflictingFieldManagers,
+			Message: message,
+		})
+	})
+}
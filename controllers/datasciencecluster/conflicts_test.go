@@ -0,0 +1,130 @@
+package datasciencecluster
+
+import (
+	"context"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conflicts with other field managers", func() {
+	const fightingArgo = `Deployment opendatahub/odh-dashboard modified by field manager "argocd-controller" (reverted 3 times)`
+	var (
+		now      time.Time
+		conflict deploy.Conflict
+		tracker  *conflictTracker
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		conflict = deploy.Conflict{Kind: "Deployment", Namespace: "opendatahub", Name: "odh-dashboard", Manager: "argocd-controller"}
+		tracker = &conflictTracker{}
+	})
+
+	It("should not be reported below the threshold", func() {
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-time.Minute))
+		tracker.observe([]deploy.Conflict{conflict}, now)
+
+		Expect(tracker.fighting()).To(BeEmpty())
+	})
+
+	It("should be reported once reverted repeatedly within the window", func() {
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-2*time.Minute))
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-time.Minute))
+		tracker.observe([]deploy.Conflict{conflict}, now)
+
+		Expect(tracker.fighting()).To(Equal([]string{fightingArgo}))
+	})
+
+	It("should forget reverts outside of the window", func() {
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-time.Hour))
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-time.Minute))
+		tracker.observe([]deploy.Conflict{conflict}, now)
+		Expect(tracker.fighting()).To(BeEmpty())
+
+		tracker.observe([]deploy.Conflict{conflict}, now)
+		tracker.observe(nil, now.Add(conflictWindow))
+		Expect(tracker.fighting()).To(BeEmpty())
+	})
+
+	It("should count reverts of each manager separately", func() {
+		other := conflict
+		other.Manager = "kubectl-edit"
+		tracker.observe([]deploy.Conflict{conflict, other}, now.Add(-2*time.Minute))
+		tracker.observe([]deploy.Conflict{conflict}, now.Add(-time.Minute))
+		tracker.observe([]deploy.Conflict{conflict, other}, now)
+
+		Expect(tracker.fighting()).To(Equal([]string{fightingArgo}))
+	})
+
+	Context("in the status", func() {
+		var (
+			instance *dscv1.DataScienceCluster
+			cli      client.Client
+			recorder *record.FakeRecorder
+			r        *DataScienceClusterReconciler
+		)
+
+		fight := func() {
+			for i := 3; i > 0; i-- {
+				r.conflicts.observe([]deploy.Conflict{conflict}, now.Add(-time.Duration(i)*time.Minute))
+			}
+		}
+		report := func(ctx context.Context) *conditionsv1.Condition {
+			GinkgoHelper()
+			var err error
+			instance, err = r.reportConflicts(ctx, instance)
+			Expect(err).NotTo(HaveOccurred())
+			saved := &dscv1.DataScienceCluster{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+			return conditionsv1.FindStatusCondition(saved.Status.Conditions, status.ConditionConflictingManagers)
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &DataScienceClusterReconciler{Client: cli, Scheme: scheme, Recorder: recorder}
+		})
+
+		It("should name the managers fighting the operator and emit an event once", func(ctx context.Context) {
+			fight()
+
+			Expect(report(ctx)).To(And(
+				HaveField("Status", corev1.ConditionTrue),
+				HaveField("Reason", status.ConflictingFieldManagers),
+				HaveField("Message", ContainSubstring(fightingArgo)),
+			))
+			report(ctx)
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("should remove the condition once nobody fights the operator anymore", func(ctx context.Context) {
+			fight()
+			report(ctx)
+			r.conflicts.observe(nil, now.Add(conflictWindow))
+
+			Expect(report(ctx)).To(BeNil())
+		})
+
+		It("should not set the condition without conflicts", func(ctx context.Context) {
+			Expect(report(ctx)).To(BeNil())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
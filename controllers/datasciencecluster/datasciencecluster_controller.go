@@ -29,6 +29,8 @@ import (
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"go.opentelemetry.io/otel/attribute"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -49,17 +51,26 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelregistry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cleanup"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cloud"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/componenthooks"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
 	ctrlogger "github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
 	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 )
 
@@ -71,6 +82,21 @@ type DataScienceClusterReconciler struct {
 	// Recorder to generate events
 	Recorder           record.EventRecorder
 	DataScienceCluster *DataScienceClusterConfig
+	// ResyncInterval requeues a successfully reconciled DataScienceCluster, so resources deleted out-of-band
+	// are restored even when nothing else triggers a reconcile. Disabled when zero.
+	ResyncInterval time.Duration
+	// ReadinessTimeout is how long components can fail their readiness gates before the DataScienceCluster is reported Degraded.
+	ReadinessTimeout time.Duration
+	// APIReader reads resources which are not worth caching, e.g. for readiness checks. Defaults to the Client.
+	APIReader client.Reader
+	// Notifier sends degraded components and completed upgrades to the sinks of the OperatorConfig.
+	Notifier *notification.Notifier
+	// ManifestsChanged receives an event whenever local manifests are synced in developer mode, for the components
+	// to be deployed from them. Nil unless the operator runs with --dev-manifests-path.
+	ManifestsChanged <-chan event.GenericEvent
+
+	conflicts conflictTracker
+	upgrades  upgradeTracker
 }
 
 // DataScienceClusterConfig passing Spec of DSCI for reconcile DataScienceCluster.
@@ -85,6 +111,8 @@ const (
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) { //nolint:maintidx,gocyclo
+	ctx, span := tracing.Start(ctx, "reconcile DataScienceCluster", attribute.String("datasciencecluster", req.Name))
+	defer span.End()
 	log := r.Log
 	log.Info("Reconciling DataScienceCluster resources", "Request.Name", req.Name)
 
@@ -114,8 +142,14 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	instance := &instances.Items[0]
+	r.upgrades.started(instance.Status.Release, currentOperatorRelease)
 
-	allComponents, err := instance.GetComponents()
+	// Components follow the profile on a copy, the management states it sets are not stored in the spec
+	profiled := instance.DeepCopy()
+	if err := profiled.ApplyProfile(); err != nil {
+		return ctrl.Result{}, err
+	}
+	allComponents, err := profiled.GetComponents()
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -146,6 +180,13 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	// Incompatibilities found on startup are verified again, for the condition to go away once the spec is fixed
+	if upgrade.HasIncompatibilities(instance.Status.Conditions) {
+		if err := upgrade.VerifyCompatibility(ctx, r.Client); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Verify a valid DSCInitialization instance is created
 	dsciInstances := &dsciv1.DSCInitializationList{}
 	err = r.Client.List(ctx, dsciInstances)
@@ -189,11 +230,21 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	} else {
 		log.Info("Finalization DataScienceCluster start deleting instance", "name", instance.Name, "finalizer", finalizerName)
+		tracker, err := cleanup.NewTracker(instance)
+		if err != nil {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "CleanupSkipped", err.Error())
+		}
 		for _, component := range allComponents {
-			if err := component.Cleanup(ctx, r.Client, instance, r.DataScienceCluster.DSCISpec); err != nil {
+			if err := tracker.Run(component.GetComponentName(), func() error {
+				return component.Cleanup(ctx, r.Client, instance, r.DataScienceCluster.DSCISpec)
+			}); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
+		if len(tracker.Skipped()) > 0 {
+			log.Info("Forced cleanup of DataScienceCluster", "name", instance.Name, "skipped", tracker.Skipped())
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "CleanupSkipped", tracker.Message())
+		}
 		if controllerutil.ContainsFinalizer(instance, finalizerName) {
 			controllerutil.RemoveFinalizer(instance, finalizerName)
 			if err := r.Update(ctx, instance); err != nil {
@@ -207,6 +258,16 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, nil
 	}
+	// Components requested to be reset lose their customizations, the update of the spec triggers a new reconciliation
+	if instance.GetAnnotations()[annotations.ResetComponent] != "" {
+		return ctrl.Result{}, r.resetComponents(ctx, instance)
+	}
+
+	// Components requested through the annotation are reconciled alone, e.g. while debugging one of them
+	if instance.GetAnnotations()[annotations.ReconcileComponent] != "" {
+		return r.reconcileRequestedComponents(ctx, instance, platform, allComponents)
+	}
+
 	// Check preconditions if this is an upgrade
 	if instance.Status.Phase == status.PhaseReady {
 		// Check for existence of Argo Workflows if DSP is
@@ -242,12 +303,44 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 	// Initialize error list, instead of returning errors after every component is deployed
 	var componentErrors *multierror.Error
 
+	if err := r.reconcilePersonaRoles(ctx, instance); err != nil {
+		instance = r.reportError(err, instance, "failed to reconcile persona ClusterRoles")
+		componentErrors = multierror.Append(componentErrors, err)
+	}
+
 	for _, component := range allComponents {
 		if instance, err = r.reconcileSubComponent(ctx, instance, platform, component); err != nil {
 			componentErrors = multierror.Append(componentErrors, err)
 		}
 	}
 
+	if instance, err = r.reconcileDistributedWorkloads(ctx, instance); err != nil {
+		instance = r.reportError(err, instance, "failed to reconcile distributed workloads")
+		componentErrors = multierror.Append(componentErrors, err)
+	}
+
+	if instance, err = r.reconcileDistributedWorkloadsMetrics(ctx, instance); err != nil {
+		instance = r.reportError(err, instance, "failed to reconcile distributed workloads metrics")
+		componentErrors = multierror.Append(componentErrors, err)
+	}
+
+	if instance, err = r.reconcileAccelerators(ctx, instance); err != nil {
+		instance = r.reportError(err, instance, "failed to reconcile accelerators")
+		componentErrors = multierror.Append(componentErrors, err)
+	}
+
+	// Diagnostics only, it should never fail the reconciliation
+	if updated, err := r.reportConflicts(ctx, instance); err != nil {
+		log.Error(err, "failed to report conflicting field managers")
+	} else {
+		instance = updated
+	}
+
+	// Mirroring aid only, it should never fail the reconciliation
+	if err := deploy.PublishImageSet(ctx, r.Client, instance, r.DataScienceCluster.DSCISpec.ApplicationsNamespace); err != nil {
+		log.Error(err, "failed to publish image set of enabled components")
+	}
+
 	// Process errors for components
 	if componentErrors != nil {
 		log.Info("DataScienceCluster Deployment Incomplete.")
@@ -268,6 +361,30 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{RequeueAfter: time.Second * 30}, componentErrors
 	}
 
+	// components reconciled, but some of them are not functional yet
+	if notReady, timedOut := componentsNotReady(instance.Status.Conditions); len(notReady) > 0 {
+		message := fmt.Sprintf("Components %v have not passed their readiness gates", notReady)
+		instance, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+			if timedOut {
+				status.SetErrorCondition(&saved.Status.Conditions, status.ReadinessGatesTimeout, message)
+			} else {
+				status.SetProgressingCondition(&saved.Status.Conditions, status.ReadinessGatesPending, message)
+			}
+			saved.Status.Phase = status.PhaseNotReady
+			saved.Status.Release = currentOperatorRelease
+		})
+		if err != nil {
+			log.Error(err, "failed to update DataScienceCluster conditions with components not ready")
+
+			return ctrl.Result{}, err
+		}
+		if timedOut {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DataScienceClusterDegraded", "%s within %s", message, r.readinessTimeout())
+		}
+
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+
 	// finalize reconciliation
 	instance, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
 		status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, "DataScienceCluster resource reconciled successfully")
@@ -284,14 +401,36 @@ func (r *DataScienceClusterReconciler) Reconcile(ctx context.Context, req ctrl.R
 	log.Info("DataScienceCluster Deployment Completed.")
 	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DataScienceClusterCreationSuccessful",
 		"DataScienceCluster instance %s created and deployed successfully", instance.Name)
+	if from := r.upgrades.completed(); from != "" {
+		r.notify(ctx, notification.Event{
+			Type:    operatorconfigv1alpha1.UpgradeCompleted,
+			Object:  instance.Name,
+			Message: fmt.Sprintf("Upgraded from %s to %s", from, currentOperatorRelease.Version.String()),
+		})
+	}
 
-	return ctrl.Result{}, nil
+	// GPU operators being installed are checked again sooner, to enable the defaults depending on them
+	if acceleratorsPending(instance.Status.Conditions) && (r.ResyncInterval == 0 || r.ResyncInterval > acceleratorsRequeueInterval) {
+		return ctrl.Result{RequeueAfter: acceleratorsRequeueInterval}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context, instance *dscv1.DataScienceCluster,
 	platform cluster.Platform, component components.ComponentInterface,
 ) (*dscv1.DataScienceCluster, error) {
-	log := r.Log
+	ctx, span := tracing.Start(ctx, "reconcile component", attribute.String("component", component.GetComponentName()),
+		attribute.String("managementState", string(component.GetManagementState())))
+	instance, err := r.reconcileComponent(ctx, instance, platform, component)
+	tracing.End(span, err)
+
+	return instance, err
+}
+
+func (r *DataScienceClusterReconciler) reconcileComponent(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, component components.ComponentInterface,
+) (*dscv1.DataScienceCluster, error) {
 	componentName := component.GetComponentName()
 
 	enabled := component.GetManagementState() == operatorv1.Managed
@@ -312,15 +451,84 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 			// try to continue with reconciliation, as further updates can fix the status
 		}
 	}
+	// Grant permissions the component needs before reconciling it.
+	// When not running in the cluster (e.g. `make run`) there is no ServiceAccount to grant them to.
+	rbacProvider, hasRBAC := component.(components.RBACProvider)
+	if _, errSA := cluster.GetOperatorServiceAccount(); errSA != nil {
+		hasRBAC = false
+	}
+	if hasRBAC && enabled {
+		if err := cluster.GrantOperatorPermissions(ctx, r.Client, operatorRoleName(componentName), rbacProvider.OperatorPolicyRules(),
+			cluster.OwnedBy(instance, r.Scheme), cluster.WithLabels(labels.K8SCommon.PartOf, componentName)); err != nil {
+			instance = r.reportError(err, instance, "failed to grant permissions for "+componentName)
+			return instance, err
+		}
+	}
+
+	// Secrets sourced from an external secret manager have to be materialized before the component is rolled out
+	if err := r.reconcileExternalSecrets(ctx, instance, componentName, component.GetExternalSecrets(), enabled); err != nil {
+		instance = r.reportError(err, instance, "failed to reconcile external secrets of "+componentName)
+		instance, _ = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+			status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileFailed, fmt.Sprintf("Component reconciliation failed: %v", err), corev1.ConditionFalse)
+			status.SetRemediation(&saved.Status.Remediation, status.RemediationFor(componentName, err))
+		})
+		return instance, err
+	}
+
 	// Reconcile component
-	componentLogger := newComponentLogger(log, componentName, r.DataScienceCluster.DSCISpec)
+	// Logger from the reconcile context carries reconcileID, so logs of all components can be correlated to a single reconcile
+	componentLogger := newComponentLogger(logf.FromContext(ctx), componentName, r.DataScienceCluster.DSCISpec)
 	componentCtx := logf.IntoContext(ctx, componentLogger)
-	err := component.ReconcileComponent(componentCtx, r.Client, instance, r.DataScienceCluster.DSCISpec, platform, installedComponentValue)
+	if enabled && installedComponentValue {
+		componentCtx = deploy.WithComponentInstalled(componentCtx)
+	}
+	conflicts := &deploy.ConflictRecorder{}
+	componentCtx = deploy.WithConflictRecorder(componentCtx, conflicts)
+	manifests := &deploy.ManifestsRecorder{}
+	componentCtx = deploy.WithManifestsRecorder(componentCtx, manifests)
+	deployed := &deploy.ResourcesRecorder{}
+	componentCtx = deploy.WithResourcesRecorder(componentCtx, deployed)
+	componentCtx = deploy.WithApplySettings(componentCtx, component.GetApplySettings(), func(manifestPath string, applied, total int) {
+		instance = r.reportApplyProgress(ctx, instance, componentName, manifestPath, applied, total)
+	})
+	if provider, ok := component.(components.LoggingProvider); ok {
+		componentCtx = deploy.WithLogLevel(componentCtx, component.GetLogLevel(), provider.Logging())
+	}
+	componentCtx = deploy.WithSecurityContext(componentCtx, component.GetSecurityContext())
+	integration := cloud.For(r.DataScienceCluster.DSCISpec)
+	identityAnnotations, identityLabels := integration.WorkloadIdentity(componentName)
+	componentCtx = deploy.WithCloudIntegration(componentCtx, integration.LoadBalancerAnnotations, identityAnnotations, identityLabels)
+	componentCtx = cluster.WithAdoptionPolicy(componentCtx, cluster.AdoptionPolicy(component.GetAdoptionPolicy()))
+	componentCtx = cluster.WithAPIReader(componentCtx, r.APIReader)
+	if metadata := instance.Spec.Metadata; metadata != nil {
+		componentCtx = cluster.WithResourceMetadata(componentCtx, metadata.Labels, metadata.Annotations)
+	}
+	crds := &deploy.CRDRecorder{}
+	componentCtx = deploy.WithCRDRecorder(deploy.WithCRDUpdatePolicy(componentCtx, component.GetCRDUpdatePolicy()), crds)
+	start := time.Now()
+	err := componenthooks.Run(componentCtx, r.Client, componenthooks.PreApply, component, r.DataScienceCluster.DSCISpec)
+	if err == nil {
+		err = component.ReconcileComponent(componentCtx, r.Client, instance, r.DataScienceCluster.DSCISpec, platform, installedComponentValue)
+	}
+	if err == nil {
+		err = componenthooks.Run(componentCtx, r.Client, componenthooks.PostApply, component, r.DataScienceCluster.DSCISpec)
+	}
+	elapsed := time.Since(start)
+	r.conflicts.observe(conflicts.Conflicts(), time.Now())
+	observeComponentReconcile(componentName, elapsed, err)
+	componentLogger.Info("component reconcile finished", "duration", elapsed.String(), "success", err == nil)
 
 	// TODO: replace this hack with a full refactor of component status in the future
 
 	if err != nil {
 		// reconciliation failed: log errors, raise event and update status accordingly
+		if enabled && !conditionsv1.IsStatusConditionFalse(instance.Status.Conditions, conditionsv1.ConditionType(componentName+status.ReadySuffix)) {
+			r.notify(ctx, notification.Event{
+				Type:    operatorconfigv1alpha1.ComponentDegraded,
+				Object:  componentName,
+				Message: fmt.Sprintf("Component reconciliation failed: %v", err),
+			})
+		}
 		instance = r.reportError(err, instance, "failed to reconcile "+componentName+" on DataScienceCluster")
 		instance, _ = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
 			if enabled {
@@ -332,16 +540,64 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 			} else {
 				status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileFailed, fmt.Sprintf("Component removal failed: %v", err), corev1.ConditionFalse)
 			}
+			status.SetRemediation(&saved.Status.Remediation, status.RemediationFor(componentName, err))
 		})
 		return instance, err
 	}
+	// Revoke permissions only once the component has been removed
+	if hasRBAC && !enabled {
+		if err := cluster.RevokeOperatorPermissions(ctx, r.Client, operatorRoleName(componentName)); err != nil {
+			instance = r.reportError(err, instance, "failed to revoke permissions for "+componentName)
+			return instance, err
+		}
+	}
+	// Permissions users get on resources of the component follow its management state
+	if personaProvider, ok := component.(components.PersonaRBACProvider); ok {
+		if err := r.reconcileComponentPersonaRoles(ctx, instance, componentName, personaProvider, enabled); err != nil {
+			instance = r.reportError(err, instance, "failed to reconcile persona ClusterRoles of "+componentName)
+			return instance, err
+		}
+	}
+
+	// Audit aid only, it should never fail the reconciliation
+	if err := r.reconcileInventory(ctx, instance, componentName, deployed.Resources(), enabled); err != nil {
+		componentLogger.Error(err, "failed to reconcile inventory of component resources")
+	}
+
+	// Component is only ready once its readiness gates pass, not just when its resources exist
+	var unmetGates []string
+	if enabled {
+		if unmetGates, err = r.checkReadinessGates(ctx, componentName); err != nil {
+			instance = r.reportError(err, instance, "failed to check readiness of "+componentName)
+			return instance, err
+		}
+		if gater, ok := component.(components.ReadinessGater); ok {
+			componentGates, errGates := gater.ReadinessGates(ctx, r.reader())
+			if errGates != nil {
+				instance = r.reportError(errGates, instance, "failed to check readiness of "+componentName)
+				return instance, errGates
+			}
+			unmetGates = append(unmetGates, componentGates...)
+		}
+	}
+
 	// reconciliation succeeded: update status accordingly
 	instance, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
 		if saved.Status.InstalledComponents == nil {
 			saved.Status.InstalledComponents = make(map[string]bool)
 		}
 		saved.Status.InstalledComponents[componentName] = enabled
+		status.RemoveRemediation(&saved.Status.Remediation, componentName)
 		if enabled {
+			status.SetManifestsProvenance(&saved.Status.Manifests, componentName, manifests.Provenance())
+			status.SetCRDUpdates(&saved.Status.CRDUpdates, componentName, crds.Updates())
+		} else {
+			status.RemoveManifestsProvenance(&saved.Status.Manifests, componentName)
+			status.RemoveCRDUpdates(&saved.Status.CRDUpdates, componentName)
+		}
+		if enabled && len(unmetGates) > 0 {
+			setReadinessGatesCondition(&saved.Status.Conditions, componentName, unmetGates, r.readinessTimeout(), time.Now())
+		} else if enabled {
 			status.SetComponentCondition(&saved.Status.Conditions, componentName, status.ReconcileCompleted, "Component reconciled successfully", corev1.ConditionTrue)
 		} else {
 			status.RemoveComponentCondition(&saved.Status.Conditions, componentName)
@@ -365,13 +621,25 @@ func (r *DataScienceClusterReconciler) reconcileSubComponent(ctx context.Context
 	return instance, nil
 }
 
+func (r *DataScienceClusterReconciler) readinessTimeout() time.Duration {
+	if r.ReadinessTimeout <= 0 {
+		return defaultReadinessTimeout
+	}
+	return r.ReadinessTimeout
+}
+
+// operatorRoleName is the name of ClusterRole and ClusterRoleBinding holding operator's permissions specific to the component.
+func operatorRoleName(componentName string) string {
+	return "opendatahub-operator-" + componentName
+}
+
 // newComponentLogger is a wrapper to add DSC name and extract log mode from DSCISpec.
 func newComponentLogger(logger logr.Logger, componentName string, dscispec *dsciv1.DSCInitializationSpec) logr.Logger {
 	mode := ""
 	if dscispec.DevFlags != nil {
 		mode = dscispec.DevFlags.LogMode
 	}
-	return ctrlogger.NewNamedLogger(logger, "DSC.Components."+componentName, mode)
+	return ctrlogger.NewComponentLogger(logger, componentName, "DSC.Components."+componentName, mode)
 }
 
 func (r *DataScienceClusterReconciler) reportError(err error, instance *dscv1.DataScienceCluster, message string) *dscv1.DataScienceCluster {
@@ -382,6 +650,13 @@ func (r *DataScienceClusterReconciler) reportError(err error, instance *dscv1.Da
 	return instance
 }
 
+// resourceChangedPredicate prevents meaningless reconciliations from being triggered by the resources watched.
+var resourceChangedPredicate = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+
+// dataScienceClusterChangedPredicate also passes annotation changes of the DataScienceCluster, e.g. to reconcile
+// components on demand. Annotations of the other resources change too often, e.g. with each rollout of a Deployment.
+var dataScienceClusterChangedPredicate = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, annotationChangedPredicate)
+
 var configMapPredicates = predicate.Funcs{
 	UpdateFunc: func(e event.UpdateEvent) bool {
 		// Do not reconcile on prometheus configmap update, since it is handled by DSCI
@@ -475,80 +750,102 @@ var modelMeshGeneralPredicates = predicate.Funcs{
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DataScienceClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&dscv1.DataScienceCluster{}).
-		Owns(&corev1.Namespace{}).
-		Owns(&corev1.Secret{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(dataScienceClusterChangedPredicate)).
+		Owns(&corev1.Namespace{}, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(&corev1.Secret{}, builder.WithPredicates(resourceChangedPredicate)).
 		Owns(
 			&corev1.ConfigMap{},
-			builder.WithPredicates(configMapPredicates),
+			builder.WithPredicates(resourceChangedPredicate, configMapPredicates),
 		).
 		Owns(
 			&networkingv1.NetworkPolicy{},
-			builder.WithPredicates(networkpolicyPredicates),
+			builder.WithPredicates(resourceChangedPredicate, networkpolicyPredicates),
 		).
 		Owns(
 			&rbacv1.Role{},
-			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRolePredicates))).
+			builder.WithPredicates(resourceChangedPredicate, predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRolePredicates))).
 		Owns(
 			&rbacv1.RoleBinding{},
-			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRBPredicates))).
+			builder.WithPredicates(resourceChangedPredicate, predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRBPredicates))).
 		Owns(
 			&rbacv1.ClusterRole{},
-			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRolePredicates))).
+			builder.WithPredicates(resourceChangedPredicate, predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRolePredicates))).
 		Owns(
 			&rbacv1.ClusterRoleBinding{},
-			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRBPredicates))).
+			builder.WithPredicates(resourceChangedPredicate, predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshRBPredicates))).
 		Owns(
 			&appsv1.Deployment{},
-			builder.WithPredicates(componentDeploymentPredicates)).
-		Owns(&corev1.PersistentVolumeClaim{}).
+			builder.WithPredicates(resourceChangedPredicate, componentDeploymentPredicates)).
+		// kinds the operator never reads are only cached as metadata, to keep its memory low on large clusters
+		Owns(&corev1.PersistentVolumeClaim{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
 		Owns(
 			&corev1.Service{},
-			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshGeneralPredicates))).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&imagev1.ImageStream{}).
-		Owns(&buildv1.BuildConfig{}).
-		Owns(&apiregistrationv1.APIService{}).
-		Owns(&networkingv1.Ingress{}).
-		Owns(&admissionregistrationv1.MutatingWebhookConfiguration{}).
+			builder.WithPredicates(resourceChangedPredicate, predicate.Or(predicate.GenerationChangedPredicate{}, modelMeshGeneralPredicates))).
+		Owns(&appsv1.StatefulSet{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(&imagev1.ImageStream{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(&buildv1.BuildConfig{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(&apiregistrationv1.APIService{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(&networkingv1.Ingress{}, builder.OnlyMetadata, builder.WithPredicates(resourceChangedPredicate)).
+		Owns(
+			&admissionregistrationv1.MutatingWebhookConfiguration{},
+			builder.OnlyMetadata,
+			builder.WithPredicates(resourceChangedPredicate),
+		).
 		Owns(
 			&admissionregistrationv1.ValidatingWebhookConfiguration{},
-			builder.WithPredicates(modelMeshwebhookPredicates),
+			builder.OnlyMetadata,
+			builder.WithPredicates(resourceChangedPredicate, modelMeshwebhookPredicates),
 		).
 		Owns(
 			&corev1.ServiceAccount{},
-			builder.WithPredicates(saPredicates),
+			builder.OnlyMetadata,
+			builder.WithPredicates(resourceChangedPredicate, saPredicates),
 		).
 		Watches(
 			&dsciv1.DSCInitialization{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
 				return r.watchDataScienceClusterForDSCI(ctx, a)
 			},
-			)).
+			),
+			builder.WithPredicates(resourceChangedPredicate)).
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
 				return r.watchDataScienceClusterResources(ctx, a)
 			}),
-			builder.WithPredicates(configMapPredicates),
+			builder.WithPredicates(resourceChangedPredicate, configMapPredicates),
 		).
 		Watches(
 			&apiextensionsv1.CustomResourceDefinition{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
 				return r.watchDataScienceClusterResources(ctx, a)
 			}),
-			builder.WithPredicates(argoWorkflowCRDPredicates),
+			builder.OnlyMetadata,
+			builder.WithPredicates(resourceChangedPredicate, argoWorkflowCRDPredicates),
 		).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
 				return r.watchDefaultIngressSecret(ctx, a)
 			}),
-			builder.WithPredicates(defaultIngressCertSecretPredicates)).
-		// this predicates prevents meaningless reconciliations from being triggered
-		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})).
-		Complete(r)
+			builder.WithPredicates(resourceChangedPredicate, defaultIngressCertSecretPredicates)).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.watchMeshMembers),
+			builder.WithPredicates(resourceChangedPredicate, meshMembershipPredicates))
+	if r.ManifestsChanged != nil {
+		b = b.WatchesRawSource(&source.Channel{Source: r.ManifestsChanged}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, _ client.Object) []reconcile.Request {
+				requestName, err := r.getRequestName(ctx)
+				if err != nil {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: requestName}}}
+			}))
+	}
+
+	return b.Complete(r)
 }
 
 func (r *DataScienceClusterReconciler) watchDataScienceClusterForDSCI(ctx context.Context, a client.Object) []reconcile.Request {
@@ -655,3 +952,34 @@ var defaultIngressCertSecretPredicates = predicate.Funcs{
 		return true
 	},
 }
+
+// watchMeshMembers reconciles the DataScienceCluster when a namespace joins or leaves the mesh while namespaces are
+// exempted from authorization, so that its AuthorizationPolicy is created or deleted.
+func (r *DataScienceClusterReconciler) watchMeshMembers(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil || len(instances.Items) == 0 {
+		return nil
+	}
+	if len(servicemesh.AuthorizationExemptions(&instances.Items[0].Spec)) == 0 {
+		return nil
+	}
+	requestName, err := r.getRequestName(ctx)
+	if err != nil {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: requestName}}}
+}
+
+// meshMembershipPredicates passes namespaces whose membership of the mesh changes.
+var meshMembershipPredicates = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetLabels()[labels.MaistraMemberOf] != ""
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.MaistraMemberOf] != e.ObjectNew.GetLabels()[labels.MaistraMemberOf]
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return e.Object.GetLabels()[labels.MaistraMemberOf] != ""
+	},
+}
@@ -0,0 +1,96 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
+)
+
+const distributedWorkloads = "distributed-workloads"
+
+// distributedWorkloadsData is the data of the distributed workloads templates.
+type distributedWorkloadsData struct {
+	ClusterQueue string
+	// Quota maps the resources covered by the shared ClusterQueue to their nominal quota
+	Quota           map[string]string
+	PriorityClasses []dscv1.WorkloadPriorityClass
+}
+
+func newDistributedWorkloadsData(spec dscv1.DistributedWorkloads) distributedWorkloadsData {
+	data := distributedWorkloadsData{
+		ClusterQueue:    spec.ClusterQueue,
+		Quota:           map[string]string{},
+		PriorityClasses: spec.PriorityClasses,
+	}
+	if data.ClusterQueue == "" {
+		data.ClusterQueue = "default"
+	}
+	if quota := spec.Quota; quota != nil {
+		if quota.CPU != nil {
+			data.Quota[string(corev1.ResourceCPU)] = quota.CPU.String()
+		}
+		if quota.Memory != nil {
+			data.Quota[string(corev1.ResourceMemory)] = quota.Memory.String()
+		}
+		if quota.NvidiaGPU != nil {
+			data.Quota["nvidia.com/gpu"] = quota.NvidiaGPU.String()
+		}
+	}
+
+	return data
+}
+
+// reconcileDistributedWorkloads sets up Kueue for the distributed workloads: a ResourceFlavor, the ClusterQueue shared by
+// all projects once a quota is set, and the priority classes of jobs. Components are enabled by ApplyProfile, and the
+// resources are removed once the DataScienceCluster stops requesting them.
+func (r *DataScienceClusterReconciler) reconcileDistributedWorkloads(ctx context.Context, instance *dscv1.DataScienceCluster) (*dscv1.DataScienceCluster, error) {
+	enabled := instance.Spec.DistributedWorkloads.ManagementState == operatorv1.Managed
+
+	handler := feature.ComponentFeaturesHandler(instance, distributedWorkloads, r.DataScienceCluster.DSCISpec.ApplicationsNamespace,
+		func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define(distributedWorkloads).
+					EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+						return enabled, nil
+					}).
+					Manifests(
+						manifest.Location(Templates.Location).
+							Include(Templates.DistributedWorkloadsDir),
+					).
+					WithData(
+						feature.Entry("DistributedWorkloads", provider.ValueOf(newDistributedWorkloadsData(instance.Spec.DistributedWorkloads)).Get),
+					),
+			)
+		})
+
+	err := handler.Apply(ctx, r.Client)
+	if err == nil && !enabled && conditionsv1.FindStatusCondition(instance.Status.Conditions, status.CapabilityDistributedWorkloads) == nil {
+		return instance, nil
+	}
+
+	instance, errStatus := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		switch {
+		case err != nil:
+			status.SetCondition(&saved.Status.Conditions, string(status.CapabilityDistributedWorkloads), status.CapabilityFailed, err.Error(), corev1.ConditionFalse)
+		case enabled:
+			status.SetCondition(&saved.Status.Conditions, string(status.CapabilityDistributedWorkloads), status.ConfiguredReason,
+				"Kueue configured for distributed workloads", corev1.ConditionTrue)
+		default:
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.CapabilityDistributedWorkloads)
+		}
+	})
+	if err != nil {
+		return instance, err
+	}
+
+	return instance, errStatus
+}
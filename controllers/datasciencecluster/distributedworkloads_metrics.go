@@ -0,0 +1,60 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+)
+
+const distributedWorkloadsMetrics = "distributed-workloads-metrics"
+
+// reconcileDistributedWorkloadsMetrics collects metrics of Kueue, Ray and Training Operator into user workload monitoring
+// and aggregates them with recording rules backing quota utilization views of the dashboard.
+// Resources are removed once the DataScienceCluster stops requesting them.
+func (r *DataScienceClusterReconciler) reconcileDistributedWorkloadsMetrics(ctx context.Context, instance *dscv1.DataScienceCluster) (*dscv1.DataScienceCluster, error) {
+	enabled := instance.Spec.DistributedWorkloadsMetrics.ManagementState == operatorv1.Managed
+
+	handler := feature.ComponentFeaturesHandler(instance, distributedWorkloadsMetrics, r.DataScienceCluster.DSCISpec.ApplicationsNamespace,
+		func(registry feature.FeaturesRegistry) error {
+			return registry.Add(
+				feature.Define(distributedWorkloadsMetrics).
+					EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+						return enabled, nil
+					}).
+					Manifests(
+						manifest.Location(Templates.Location).
+							Include(Templates.DistributedWorkloadsMetricsDir),
+					),
+			)
+		})
+
+	err := handler.Apply(ctx, r.Client)
+	if err == nil && !enabled && conditionsv1.FindStatusCondition(instance.Status.Conditions, status.CapabilityDistributedWorkloadsMetrics) == nil {
+		return instance, nil
+	}
+
+	instance, errStatus := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		switch {
+		case err != nil:
+			status.SetCondition(&saved.Status.Conditions, string(status.CapabilityDistributedWorkloadsMetrics), status.CapabilityFailed, err.Error(), corev1.ConditionFalse)
+		case enabled:
+			status.SetCondition(&saved.Status.Conditions, string(status.CapabilityDistributedWorkloadsMetrics), status.ConfiguredReason,
+				"Distributed workloads metrics collected in user workload monitoring", corev1.ConditionTrue)
+		default:
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.CapabilityDistributedWorkloadsMetrics)
+		}
+	})
+	if err != nil {
+		return instance, err
+	}
+
+	return instance, errStatus
+}
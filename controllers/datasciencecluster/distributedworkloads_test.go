@@ -0,0 +1,112 @@
+package datasciencecluster
+
+import (
+	"path"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Distributed workloads", func() {
+	quantity := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+
+	DescribeTable("should build the template data from the spec",
+		func(spec dscv1.DistributedWorkloads, expected distributedWorkloadsData) {
+			Expect(newDistributedWorkloadsData(spec)).To(Equal(expected))
+		},
+		Entry("default ClusterQueue without quota", dscv1.DistributedWorkloads{},
+			distributedWorkloadsData{ClusterQueue: "default", Quota: map[string]string{}}),
+		Entry("named ClusterQueue", dscv1.DistributedWorkloads{ClusterQueue: "shared"},
+			distributedWorkloadsData{ClusterQueue: "shared", Quota: map[string]string{}}),
+		Entry("quota of all resources",
+			dscv1.DistributedWorkloads{Quota: &dscv1.DistributedWorkloadsQuota{CPU: quantity("10"), Memory: quantity("64Gi"), NvidiaGPU: quantity("2")}},
+			distributedWorkloadsData{ClusterQueue: "default", Quota: map[string]string{"cpu": "10", "memory": "64Gi", "nvidia.com/gpu": "2"}}),
+		Entry("quota of some resources",
+			dscv1.DistributedWorkloads{Quota: &dscv1.DistributedWorkloadsQuota{Memory: quantity("500Mi")}},
+			distributedWorkloadsData{ClusterQueue: "default", Quota: map[string]string{"memory": "500Mi"}}),
+		Entry("empty quota", dscv1.DistributedWorkloads{Quota: &dscv1.DistributedWorkloadsQuota{}},
+			distributedWorkloadsData{ClusterQueue: "default", Quota: map[string]string{}}),
+		Entry("priority classes",
+			dscv1.DistributedWorkloads{PriorityClasses: []dscv1.WorkloadPriorityClass{{Name: "high", Value: 1000}}},
+			distributedWorkloadsData{ClusterQueue: "default", Quota: map[string]string{}, PriorityClasses: []dscv1.WorkloadPriorityClass{{Name: "high", Value: 1000}}}),
+	)
+
+	Describe("Kueue template", func() {
+		render := func(spec dscv1.DistributedWorkloads) []*unstructured.Unstructured {
+			GinkgoHelper()
+			objects, err := manifest.Create(Templates.Location, path.Join(Templates.DistributedWorkloadsDir, "kueue.tmpl.yaml")).
+				Process(map[string]any{"DistributedWorkloads": newDistributedWorkloadsData(spec)})
+			Expect(err).ToNot(HaveOccurred())
+			return objects
+		}
+		kinds := func(objects []*unstructured.Unstructured) []string {
+			kinds := make([]string, 0, len(objects))
+			for _, obj := range objects {
+				kinds = append(kinds, obj.GetKind()+"/"+obj.GetName())
+			}
+			return kinds
+		}
+		resourceGroup := func(queue *unstructured.Unstructured) map[string]any {
+			GinkgoHelper()
+			groups, found, err := unstructured.NestedSlice(queue.Object, "spec", "resourceGroups")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(groups).To(HaveLen(1))
+			return groups[0].(map[string]any)
+		}
+
+		It("should only create the ResourceFlavor without quota nor priority classes", func() {
+			Expect(kinds(render(dscv1.DistributedWorkloads{}))).To(Equal([]string{"ResourceFlavor/default-flavor"}))
+		})
+
+		It("should not create the ClusterQueue for an empty quota", func() {
+			objects := render(dscv1.DistributedWorkloads{Quota: &dscv1.DistributedWorkloadsQuota{}})
+			Expect(kinds(objects)).To(Equal([]string{"ResourceFlavor/default-flavor"}))
+		})
+
+		It("should create the ClusterQueue covering the resources of the quota", func() {
+			objects := render(dscv1.DistributedWorkloads{
+				Quota: &dscv1.DistributedWorkloadsQuota{CPU: quantity("10"), Memory: quantity("64Gi"), NvidiaGPU: quantity("2")},
+			})
+			Expect(kinds(objects)).To(Equal([]string{"ResourceFlavor/default-flavor", "ClusterQueue/default"}))
+
+			group := resourceGroup(objects[1])
+			Expect(group).To(HaveKeyWithValue("coveredResources", []any{"cpu", "memory", "nvidia.com/gpu"}))
+			Expect(group).To(HaveKeyWithValue("flavors", []any{map[string]any{
+				"name": "default-flavor",
+				"resources": []any{
+					map[string]any{"name": "cpu", "nominalQuota": "10"},
+					map[string]any{"name": "memory", "nominalQuota": "64Gi"},
+					map[string]any{"name": "nvidia.com/gpu", "nominalQuota": "2"},
+				},
+			}}))
+			preemption, _, err := unstructured.NestedString(objects[1].Object, "spec", "preemption", "withinClusterQueue")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preemption).To(Equal("LowerPriority"))
+		})
+
+		It("should name the ClusterQueue after the spec", func() {
+			objects := render(dscv1.DistributedWorkloads{ClusterQueue: "shared", Quota: &dscv1.DistributedWorkloadsQuota{CPU: quantity("4")}})
+			Expect(kinds(objects)).To(Equal([]string{"ResourceFlavor/default-flavor", "ClusterQueue/shared"}))
+			Expect(resourceGroup(objects[1])).To(HaveKeyWithValue("coveredResources", []any{"cpu"}))
+		})
+
+		It("should create a WorkloadPriorityClass per priority class", func() {
+			objects := render(dscv1.DistributedWorkloads{
+				PriorityClasses: []dscv1.WorkloadPriorityClass{{Name: "high", Value: 1000}, {Name: "low", Value: -10}},
+			})
+			Expect(kinds(objects)).To(Equal([]string{"ResourceFlavor/default-flavor", "WorkloadPriorityClass/high", "WorkloadPriorityClass/low"}))
+			Expect(objects[1].Object).To(HaveKeyWithValue("value", int64(1000)))
+			Expect(objects[2].Object).To(HaveKeyWithValue("value", int64(-10)))
+		})
+	})
+})
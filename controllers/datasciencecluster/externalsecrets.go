@@ -0,0 +1,132 @@
+package datasciencecluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// fieldManager is used for server-side apply of resources created by the DataScienceCluster controller itself.
+const fieldManager = "opendatahub-operator"
+
+// reconcileExternalSecrets makes sure ExternalSecrets declared for the component exist and returns an error
+// until all their Secrets have been materialized, so the component is not rolled out without them.
+// ExternalSecrets which are no longer declared, or of a removed component, are deleted.
+func (r *DataScienceClusterReconciler) reconcileExternalSecrets(ctx context.Context, instance *dscv1.DataScienceCluster,
+	componentName string, secrets []components.ExternalSecret, enabled bool,
+) error {
+	namespace := r.DataScienceCluster.DSCISpec.ApplicationsNamespace
+
+	declared := map[string]bool{}
+	if enabled {
+		for _, secret := range secrets {
+			declared[secret.Name] = true
+			if err := r.applyExternalSecret(ctx, instance, namespace, componentName, secret); err != nil {
+				return fmt.Errorf("failed to apply ExternalSecret %s: %w", secret.Name, err)
+			}
+		}
+	}
+
+	if err := r.deleteUndeclaredExternalSecrets(ctx, namespace, componentName, declared); err != nil {
+		return err
+	}
+
+	var notReady []string
+	for name := range declared {
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &corev1.Secret{}); err != nil {
+			if !k8serr.IsNotFound(err) {
+				return err
+			}
+			notReady = append(notReady, name)
+		}
+	}
+	if len(notReady) > 0 {
+		return fmt.Errorf("waiting for secrets %v to be materialized by External Secrets Operator", notReady)
+	}
+
+	return nil
+}
+
+func (r *DataScienceClusterReconciler) applyExternalSecret(ctx context.Context, instance *dscv1.DataScienceCluster,
+	namespace, componentName string, secret components.ExternalSecret,
+) error {
+	storeKind := secret.SecretStoreRef.Kind
+	if storeKind == "" {
+		storeKind = "ClusterSecretStore"
+	}
+	refreshInterval := secret.RefreshInterval
+	if refreshInterval == "" {
+		refreshInterval = "1h"
+	}
+
+	data := make([]any, 0, len(secret.Data))
+	for _, entry := range secret.Data {
+		remoteRef := map[string]any{"key": entry.RemoteKey}
+		if entry.Property != "" {
+			remoteRef["property"] = entry.Property
+		}
+		data = append(data, map[string]any{"secretKey": entry.SecretKey, "remoteRef": remoteRef})
+	}
+
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(gvk.ExternalSecret)
+	externalSecret.SetName(secret.Name)
+	externalSecret.SetNamespace(namespace)
+	externalSecret.Object["spec"] = map[string]any{
+		"refreshInterval": refreshInterval,
+		"secretStoreRef": map[string]any{
+			"name": secret.SecretStoreRef.Name,
+			"kind": storeKind,
+		},
+		"target": map[string]any{
+			"name":           secret.Name,
+			"creationPolicy": "Owner",
+		},
+		"data": data,
+	}
+
+	if err := cluster.ApplyMetaOptions(externalSecret,
+		cluster.OwnedBy(instance, r.Scheme),
+		cluster.WithLabels(labels.ODH.Component(componentName), "true", labels.K8SCommon.PartOf, componentName),
+	); err != nil {
+		return err
+	}
+
+	return r.Client.Patch(ctx, externalSecret, client.Apply, client.ForceOwnership, client.FieldOwner(fieldManager))
+}
+
+func (r *DataScienceClusterReconciler) deleteUndeclaredExternalSecrets(ctx context.Context, namespace, componentName string, declared map[string]bool) error {
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(gvk.ExternalSecret)
+	if err := r.Client.List(ctx, existing, client.InNamespace(namespace),
+		client.MatchingLabels{labels.ODH.Component(componentName): "true"}); err != nil {
+		if errors.Is(err, &meta.NoKindMatchError{}) {
+			// External Secrets Operator is not installed, so there is nothing to delete
+			return nil
+		}
+		return err
+	}
+
+	for i := range existing.Items {
+		if declared[existing.Items[i].GetName()] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &existing.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ExternalSecret %s: %w", existing.Items[i].GetName(), err)
+		}
+	}
+
+	return nil
+}
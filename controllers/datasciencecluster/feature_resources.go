@@ -0,0 +1,28 @@
+package datasciencecluster
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+)
+
+//go:embed resources
+var dscEmbeddedFS embed.FS
+
+const baseDir = "resources"
+
+var Templates = struct {
+	// DistributedWorkloadsDir is the path to the templates of the Kueue resources shared by distributed workloads.
+	DistributedWorkloadsDir string
+	// DistributedWorkloadsMetricsDir is the path to the distributed workloads metrics templates.
+	DistributedWorkloadsMetricsDir string
+	// Location specifies the file system that contains the templates to be used.
+	Location fs.FS
+	// BaseDir is the path to the base of the embedded FS
+	BaseDir string
+}{
+	DistributedWorkloadsDir:        path.Join(baseDir, "distributed-workloads"),
+	DistributedWorkloadsMetricsDir: path.Join(baseDir, "distributed-workloads-metrics"),
+	Location:                       dscEmbeddedFS,
+	BaseDir:                        baseDir,
+}
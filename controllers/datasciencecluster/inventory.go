@@ -0,0 +1,175 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// reconcileInventory keeps the ComponentInventory of the component, named after it, listing the resources deployed
+// for it and their health. Once the component is removed, resources of the inventory which still exist are listed as
+// leftovers until they are gone, then the inventory is deleted.
+func (r *DataScienceClusterReconciler) reconcileInventory(ctx context.Context, instance *dscv1.DataScienceCluster,
+	componentName string, deployed []deploy.InventoryEntry, enabled bool,
+) error {
+	inventory := &inventoryv1alpha1.ComponentInventory{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: componentName}, inventory)
+	switch {
+	case meta.IsNoMatchError(err):
+		// the CRD is not installed, e.g. running an older bundle
+		return nil
+	case k8serr.IsNotFound(err):
+		if !enabled {
+			return nil
+		}
+		inventory = &inventoryv1alpha1.ComponentInventory{
+			ObjectMeta: metav1.ObjectMeta{Name: componentName},
+			Spec:       inventoryv1alpha1.ComponentInventorySpec{Component: componentName},
+		}
+		if err := cluster.ApplyMetaOptions(inventory,
+			cluster.OwnedBy(instance, r.Scheme),
+			cluster.WithLabels(labels.ODH.Component(componentName), "true", labels.K8SCommon.PartOf, componentName),
+		); err != nil {
+			return err
+		}
+		if err := r.Client.Create(ctx, inventory); err != nil {
+			return fmt.Errorf("failed to create ComponentInventory %s: %w", componentName, err)
+		}
+	case err != nil:
+		return err
+	}
+
+	var resources []inventoryv1alpha1.InventoryResource
+	phase := inventoryv1alpha1.InventoryDeployed
+	if enabled {
+		for _, entry := range deployed {
+			health, message, err := r.resourceHealth(ctx, entry)
+			if err != nil {
+				return err
+			}
+			resources = append(resources, inventoryResource(entry, health, message))
+		}
+	} else {
+		phase = inventoryv1alpha1.InventoryRemoving
+		for _, entry := range inventoryEntries(inventory.Status.Resources) {
+			health, _, err := r.resourceHealth(ctx, entry)
+			if err != nil {
+				return err
+			}
+			if health == inventoryv1alpha1.ResourceMissing {
+				continue
+			}
+			resources = append(resources, inventoryResource(entry, inventoryv1alpha1.ResourceLeftover, "resource still exists after the component was removed"))
+		}
+		if len(resources) == 0 {
+			return client.IgnoreNotFound(r.Client.Delete(ctx, inventory))
+		}
+	}
+
+	summary := summarizeInventory(resources)
+	if inventory.Status.Phase == phase && inventory.Status.Summary == summary && reflect.DeepEqual(inventory.Status.Resources, resources) {
+		return nil
+	}
+	now := metav1.Now()
+	inventory.Status = inventoryv1alpha1.ComponentInventoryStatus{
+		Phase:          phase,
+		LastChangeTime: &now,
+		Summary:        summary,
+		Resources:      resources,
+	}
+
+	return r.Client.Status().Update(ctx, inventory)
+}
+
+// resourceHealth tells whether the resource exists and, when it reports it, whether it is ready.
+func (r *DataScienceClusterReconciler) resourceHealth(ctx context.Context, entry deploy.InventoryEntry) (inventoryv1alpha1.ResourceHealth, string, error) {
+	found := &unstructured.Unstructured{}
+	found.SetGroupVersionKind(entry.GroupVersionKind)
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: entry.Namespace, Name: entry.Name}, found); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return inventoryv1alpha1.ResourceMissing, "resource not found", nil
+		}
+		return "", "", fmt.Errorf("failed to get %s %s: %w", entry.GroupVersionKind.Kind, entry.Name, err)
+	}
+
+	if entry.GroupVersionKind.Kind == "Deployment" || entry.GroupVersionKind.Kind == "StatefulSet" {
+		replicas, set, _ := unstructured.NestedInt64(found.Object, "spec", "replicas")
+		if !set {
+			replicas = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(found.Object, "status", "readyReplicas")
+		if ready < replicas {
+			return inventoryv1alpha1.ResourceUnhealthy, fmt.Sprintf("%d of %d replicas ready", ready, replicas), nil
+		}
+		return inventoryv1alpha1.ResourceHealthy, "", nil
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(found.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]any)
+		if !ok || (condition["type"] != "Ready" && condition["type"] != "Available") || condition["status"] != "False" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return inventoryv1alpha1.ResourceUnhealthy, fmt.Sprintf("%s: %s", condition["type"], message), nil
+	}
+
+	return inventoryv1alpha1.ResourceHealthy, "", nil
+}
+
+func inventoryResource(entry deploy.InventoryEntry, health inventoryv1alpha1.ResourceHealth, message string) inventoryv1alpha1.InventoryResource {
+	return inventoryv1alpha1.InventoryResource{
+		Group:     entry.GroupVersionKind.Group,
+		Version:   entry.GroupVersionKind.Version,
+		Kind:      entry.GroupVersionKind.Kind,
+		Namespace: entry.Namespace,
+		Name:      entry.Name,
+		Health:    health,
+		Message:   message,
+	}
+}
+
+func inventoryEntries(resources []inventoryv1alpha1.InventoryResource) []deploy.InventoryEntry {
+	entries := make([]deploy.InventoryEntry, 0, len(resources))
+	for _, res := range resources {
+		entries = append(entries, deploy.InventoryEntry{
+			GroupVersionKind: schema.GroupVersionKind{Group: res.Group, Version: res.Version, Kind: res.Kind},
+			Namespace:        res.Namespace,
+			Name:             res.Name,
+		})
+	}
+	deploy.SortInventory(entries)
+
+	return entries
+}
+
+func summarizeInventory(resources []inventoryv1alpha1.InventoryResource) inventoryv1alpha1.InventorySummary {
+	summary := inventoryv1alpha1.InventorySummary{Total: len(resources)}
+	for _, res := range resources {
+		switch res.Health {
+		case inventoryv1alpha1.ResourceHealthy:
+			summary.Healthy++
+		case inventoryv1alpha1.ResourceUnhealthy:
+			summary.Unhealthy++
+		case inventoryv1alpha1.ResourceMissing:
+			summary.Missing++
+		case inventoryv1alpha1.ResourceLeftover:
+			summary.Leftover++
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,161 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Component inventory", func() {
+	var (
+		instance   *dscv1.DataScienceCluster
+		deployment *appsv1.Deployment
+		service    *corev1.Service
+		deployed   []deploy.InventoryEntry
+		objects    []client.Object
+		funcs      interceptor.Funcs
+		cli        client.Client
+	)
+
+	reconcileInventory := func(ctx context.Context, deployed []deploy.InventoryEntry, enabled bool) error {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			Expect(inventoryv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&inventoryv1alpha1.ComponentInventory{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &DataScienceClusterReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+		return r.reconcileInventory(ctx, instance, "dashboard", deployed, enabled)
+	}
+	inventory := func(ctx context.Context) *inventoryv1alpha1.ComponentInventory {
+		GinkgoHelper()
+		inventory := &inventoryv1alpha1.ComponentInventory{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "dashboard"}, inventory)).To(Succeed())
+		return inventory
+	}
+	entry := func(obj client.Object, kind schema.GroupVersionKind) deploy.InventoryEntry {
+		return deploy.InventoryEntry{GroupVersionKind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	}
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "uid"}}
+		replicas := int32(2)
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "odh-dashboard", Namespace: "opendatahub"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		}
+		service = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "odh-dashboard", Namespace: "opendatahub"}}
+		deployed = []deploy.InventoryEntry{
+			{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, Namespace: "opendatahub", Name: "odh-dashboard-config"},
+			entry(service, schema.GroupVersionKind{Version: "v1", Kind: "Service"}),
+			entry(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		}
+		objects = []client.Object{instance, deployment, service}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should list the health of the deployed resources", func(ctx context.Context) {
+		Expect(reconcileInventory(ctx, deployed, true)).To(Succeed())
+
+		found := inventory(ctx)
+		Expect(found.Status.Phase).To(Equal(inventoryv1alpha1.InventoryDeployed))
+		Expect(found.Status.Summary).To(Equal(inventoryv1alpha1.InventorySummary{Total: 3, Healthy: 1, Unhealthy: 1, Missing: 1}))
+		Expect(found.Status.Resources).To(HaveExactElements(
+			And(HaveField("Kind", "ConfigMap"), HaveField("Health", inventoryv1alpha1.ResourceMissing)),
+			And(HaveField("Kind", "Service"), HaveField("Health", inventoryv1alpha1.ResourceHealthy)),
+			And(HaveField("Kind", "Deployment"), HaveField("Health", inventoryv1alpha1.ResourceUnhealthy), HaveField("Message", "1 of 2 replicas ready")),
+		))
+		Expect(found.OwnerReferences).To(ConsistOf(HaveField("Name", instance.Name)))
+	})
+
+	It("should report resources with a False Ready or Available condition as unhealthy", func(ctx context.Context) {
+		route := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Available", "status": "False", "message": "no endpoints"},
+		}}}}
+		route.SetGroupVersionKind(schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Route"})
+		route.SetName("odh-dashboard")
+		route.SetNamespace("opendatahub")
+		objects = append(objects, route)
+
+		Expect(reconcileInventory(ctx, []deploy.InventoryEntry{entry(route, route.GroupVersionKind())}, true)).To(Succeed())
+
+		Expect(inventory(ctx).Status.Resources).To(ConsistOf(And(
+			HaveField("Health", inventoryv1alpha1.ResourceUnhealthy),
+			HaveField("Message", "Available: no endpoints"),
+		)))
+	})
+
+	It("should not update an unchanged inventory", func(ctx context.Context) {
+		Expect(reconcileInventory(ctx, deployed, true)).To(Succeed())
+		version := inventory(ctx).ResourceVersion
+
+		Expect(reconcileInventory(ctx, deployed, true)).To(Succeed())
+
+		Expect(inventory(ctx).ResourceVersion).To(Equal(version))
+	})
+
+	It("should not create an inventory for a component which is not enabled", func(ctx context.Context) {
+		Expect(reconcileInventory(ctx, nil, false)).To(Succeed())
+
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "dashboard"}, &inventoryv1alpha1.ComponentInventory{})).To(Satisfy(k8serr.IsNotFound))
+	})
+
+	It("should do nothing while the ComponentInventory CRD is missing", func(ctx context.Context) {
+		funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*inventoryv1alpha1.ComponentInventory); ok {
+				return &meta.NoKindMatchError{GroupKind: inventoryv1alpha1.GroupVersion.WithKind("ComponentInventory").GroupKind()}
+			}
+			return cli.Get(ctx, key, obj, opts...)
+		}
+
+		Expect(reconcileInventory(ctx, deployed, true)).To(Succeed())
+	})
+
+	When("the component is removed", func() {
+		BeforeEach(func(ctx context.Context) {
+			Expect(reconcileInventory(ctx, deployed, true)).To(Succeed())
+			Expect(cli.Delete(ctx, deployment)).To(Succeed())
+		})
+
+		It("should report the resources left until they are gone", func(ctx context.Context) {
+			Expect(reconcileInventory(ctx, nil, false)).To(Succeed())
+
+			found := inventory(ctx)
+			Expect(found.Status.Phase).To(Equal(inventoryv1alpha1.InventoryRemoving))
+			Expect(found.Status.Summary).To(Equal(inventoryv1alpha1.InventorySummary{Total: 1, Leftover: 1}))
+			Expect(found.Status.Resources).To(ConsistOf(HaveField("Kind", "Service")))
+		})
+
+		It("should delete the inventory once all resources are gone", func(ctx context.Context) {
+			Expect(cli.Delete(ctx, service)).To(Succeed())
+
+			Expect(reconcileInventory(ctx, nil, false)).To(Succeed())
+
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "dashboard"}, &inventoryv1alpha1.ComponentInventory{})).To(Satisfy(k8serr.IsNotFound))
+		})
+	})
+})
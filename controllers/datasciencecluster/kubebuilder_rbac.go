@@ -1,8 +1,13 @@
 package datasciencecluster
 
+// Permissions needed by the operator only while a particular component is enabled are granted at runtime instead,
+// see components.RBACProvider.
+
 //+kubebuilder:rbac:groups="datasciencecluster.opendatahub.io",resources=datascienceclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups="datasciencecluster.opendatahub.io",resources=datascienceclusters/finalizers,verbs=update;patch
 //+kubebuilder:rbac:groups="datasciencecluster.opendatahub.io",resources=datascienceclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="inventory.opendatahub.io",resources=componentinventories/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="inventory.opendatahub.io",resources=componentinventories,verbs=get;list;watch;create;update;patch;delete
 
 /* Serverless prerequisite */
 // +kubebuilder:rbac:groups="networking.istio.io",resources=gateways,verbs=*
@@ -20,6 +25,10 @@ package datasciencecluster
 // +kubebuilder:rbac:groups="networking.istio.io",resources=gateways,verbs=*
 // +kubebuilder:rbac:groups="networking.istio.io",resources=envoyfilters,verbs=*
 // +kubebuilder:rbac:groups="security.istio.io",resources=authorizationpolicies,verbs=*
+// +kubebuilder:rbac:groups="security.istio.io",resources=peerauthentications,verbs=*
+// +kubebuilder:rbac:groups="external-secrets.io",resources=externalsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="wgpolicyk8s.io",resources=policyreports,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="networking.istio.io",resources=destinationrules,verbs=*
 // +kubebuilder:rbac:groups="authorino.kuadrant.io",resources=authconfigs,verbs=*
 // +kubebuilder:rbac:groups="operator.authorino.kuadrant.io",resources=authorinos,verbs=*
 
@@ -38,6 +47,10 @@ package datasciencecluster
 // +kubebuilder:rbac:groups="dashboard.opendatahub.io",resources=odhapplications,verbs=create;get;patch;list;delete
 // +kubebuilder:rbac:groups="dashboard.opendatahub.io",resources=acceleratorprofiles,verbs=create;get;patch;list;delete
 
+/* This is for accelerators */
+// +kubebuilder:rbac:groups="nvidia.com",resources=clusterpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="amd.com",resources=deviceconfigs,verbs=get;list;watch
+
 // +kubebuilder:rbac:groups="operators.coreos.com",resources=clusterserviceversions,verbs=get;list;watch;delete;update
 // +kubebuilder:rbac:groups="operators.coreos.com",resources=customresourcedefinitions,verbs=create;get;patch;delete
 // +kubebuilder:rbac:groups="operators.coreos.com",resources=subscriptions,verbs=get;list;watch;delete
@@ -76,6 +89,7 @@ package datasciencecluster
 // +kubebuilder:rbac:groups="serving.knative.dev",resources=services/status,verbs=update;patch;delete;get
 // +kubebuilder:rbac:groups="serving.knative.dev",resources=services/finalizers,verbs=create;delete;list;watch;update;patch;get
 // +kubebuilder:rbac:groups="serving.knative.dev",resources=services,verbs=create;delete;list;watch;update;patch;get
+// +kubebuilder:rbac:groups="serving.knative.dev",resources=domainmappings,verbs=create;delete;list;watch;update;patch;get
 
 // +kubebuilder:rbac:groups="security.openshift.io",resources=securitycontextconstraints,verbs=*,resourceNames=restricted
 // +kubebuilder:rbac:groups="security.openshift.io",resources=securitycontextconstraints,verbs=*,resourceNames=anyuid
@@ -91,10 +105,6 @@ package datasciencecluster
 
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings,verbs=*
 
-// +kubebuilder:rbac:groups="ray.io",resources=rayservices,verbs=create;delete;list;watch;update;patch;get
-// +kubebuilder:rbac:groups="ray.io",resources=rayjobs,verbs=create;delete;list;update;watch;patch;get
-// +kubebuilder:rbac:groups="ray.io",resources=rayclusters,verbs=create;delete;list;patch;get
-
 // +kubebuilder:rbac:groups="apiregistration.k8s.io",resources=apiservices,verbs=create;delete;list;watch;update;patch;get
 
 // +kubebuilder:rbac:groups="operator.openshift.io",resources=consoles,verbs=get;list;watch;patch;delete
@@ -122,10 +132,6 @@ package datasciencecluster
 // +kubebuilder:rbac:groups="monitoring.coreos.com",resources=probes,verbs=get;create;patch;delete;deletecollection
 // +kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheusrules,verbs=get;create;patch;delete;deletecollection
 
-//+kubebuilder:rbac:groups=modelregistry.opendatahub.io,resources=modelregistries,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=modelregistry.opendatahub.io,resources=modelregistries/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=modelregistry.opendatahub.io,resources=modelregistries/finalizers,verbs=update;get
-
 // +kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheuses/finalizers,verbs=get;create;patch;delete;deletecollection
 // +kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheuses/status,verbs=get;create;patch;delete;deletecollection
 
@@ -185,6 +191,7 @@ package datasciencecluster
 
 // +kubebuilder:rbac:groups="core",resources=clusterversions,verbs=watch;list;get
 // +kubebuilder:rbac:groups="config.openshift.io",resources=clusterversions,verbs=watch;list;get
+// +kubebuilder:rbac:groups="config.openshift.io",resources=infrastructures,verbs=get
 
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
 
@@ -217,6 +224,8 @@ package datasciencecluster
 
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=*
 
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
 // +kubebuilder:rbac:groups="apps",resources=deployments/finalizers,verbs=*
 // +kubebuilder:rbac:groups="core",resources=deployments,verbs=*
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=*
@@ -232,6 +241,19 @@ package datasciencecluster
 
 // +kubebuilder:rbac:groups="*",resources=replicasets,verbs=*
 
+// Databases of model registries are provisioned through database operators
+// +kubebuilder:rbac:groups="postgresql.cnpg.io",resources=clusters,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources=postgresclusters,verbs=get;list;watch;create;update;delete
+
+// Kueue resources shared by distributed workloads
+// +kubebuilder:rbac:groups="kueue.x-k8s.io",resources=clusterqueues;resourceflavors;workloadpriorityclasses,verbs=get;list;watch;create;update;patch;delete
+
+// Workloads of components are counted to report the impact of removing them
+// +kubebuilder:rbac:groups="kubeflow.org",resources=notebooks;pytorchjobs;tfjobs;mpijobs;xgboostjobs;paddlejobs,verbs=list
+// +kubebuilder:rbac:groups="kueue.x-k8s.io",resources=workloads,verbs=list
+// +kubebuilder:rbac:groups="trustyai.opendatahub.io",resources=trustyaiservices,verbs=list
+// +kubebuilder:rbac:groups="workload.codeflare.dev",resources=appwrappers,verbs=list
+
 /* Only for RHODS */
 // +kubebuilder:rbac:groups="user.openshift.io",resources=groups,verbs=get;create;list;watch;patch;delete
 // +kubebuilder:rbac:groups="console.openshift.io",resources=consolelinks,verbs=create;get;patch;delete
@@ -0,0 +1,33 @@
+package datasciencecluster
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// componentReconcileDuration complements controller-runtime's own per-controller reconcile metrics, breaking
+	// the time spent in a single DataScienceCluster reconcile down to its components (render, apply and readiness checks).
+	componentReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "odh_component_reconcile_duration_seconds",
+			Help:    "Time spent reconciling a DataScienceCluster component, labeled by component name and result.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+		[]string{"component", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(componentReconcileDuration)
+}
+
+func observeComponentReconcile(componentName string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	componentReconcileDuration.WithLabelValues(componentName, result).Observe(duration.Seconds())
+}
@@ -0,0 +1,48 @@
+package datasciencecluster
+
+import (
+	"context"
+	"sync"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
+)
+
+// upgradeTracker keeps the release the DataScienceCluster is upgraded from until the upgrade completes, as the release
+// in status is updated once components are reconciled, whether they succeed or not.
+type upgradeTracker struct {
+	mu   sync.Mutex
+	from string
+}
+
+// started records an upgrade when the DataScienceCluster was last reconciled by another release.
+func (t *upgradeTracker) started(previous, current cluster.Release) {
+	if previous.Version.Equals(semver.Version{}) || previous.Version.Equals(current.Version.Version) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.from == "" {
+		t.from = previous.Version.String()
+	}
+}
+
+// completed returns the release an upgrade in progress started from and forgets it, empty when there is none.
+func (t *upgradeTracker) completed() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	from := t.from
+	t.from = ""
+
+	return from
+}
+
+// notify sends the event, failures are only logged not to hold reconciliation back.
+func (r *DataScienceClusterReconciler) notify(ctx context.Context, event notification.Event) {
+	if err := r.Notifier.Notify(ctx, event); err != nil {
+		r.Log.Error(err, "failed to send notification", "event", event.Type, "object", event.Object)
+	}
+}
@@ -0,0 +1,64 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// reconcilePersonaRoles makes sure ClusterRoles of the personas exist, aggregating rules contributed by enabled components.
+func (r *DataScienceClusterReconciler) reconcilePersonaRoles(ctx context.Context, instance *dscv1.DataScienceCluster) error {
+	for _, persona := range components.Personas {
+		selector := metav1.LabelSelector{
+			MatchLabels: map[string]string{labels.ODH.AggregateTo(string(persona)): "true"},
+		}
+		if _, err := cluster.CreateOrUpdateAggregatedClusterRole(ctx, r.Client, string(persona), selector,
+			cluster.OwnedBy(instance, r.Scheme), cluster.WithLabels(labels.K8SCommon.PartOf, "opendatahub-operator")); err != nil {
+			return fmt.Errorf("failed to reconcile ClusterRole of persona %s: %w", persona, err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileComponentPersonaRoles creates ClusterRoles holding the rules the component contributes to each persona,
+// so they are aggregated into the personas' ClusterRoles. They are deleted once the component is removed,
+// as well as those of personas the component no longer contributes to.
+func (r *DataScienceClusterReconciler) reconcileComponentPersonaRoles(ctx context.Context, instance *dscv1.DataScienceCluster,
+	componentName string, provider components.PersonaRBACProvider, enabled bool,
+) error {
+	rules := map[components.Persona]bool{}
+	if enabled {
+		for persona, personaRules := range provider.PersonaPolicyRules() {
+			rules[persona] = true
+			if _, err := cluster.CreateOrUpdateClusterRole(ctx, r.Client, personaRoleName(persona, componentName), personaRules,
+				cluster.OwnedBy(instance, r.Scheme),
+				cluster.WithLabels(labels.K8SCommon.PartOf, componentName, labels.ODH.AggregateTo(string(persona)), "true")); err != nil {
+				return fmt.Errorf("failed to reconcile ClusterRole of persona %s: %w", persona, err)
+			}
+		}
+	}
+
+	for _, persona := range components.Personas {
+		if rules[persona] {
+			continue
+		}
+		if err := cluster.DeleteClusterRole(ctx, r.Client, personaRoleName(persona, componentName)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ClusterRole of persona %s: %w", persona, err)
+		}
+	}
+
+	return nil
+}
+
+// personaRoleName is the name of ClusterRole holding rules the component contributes to the persona.
+func personaRoleName(persona components.Persona, componentName string) string {
+	return string(persona) + "-" + componentName
+}
@@ -0,0 +1,131 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type personaRules map[components.Persona][]rbacv1.PolicyRule
+
+func (p personaRules) PersonaPolicyRules() map[components.Persona][]rbacv1.PolicyRule {
+	return p
+}
+
+var _ = Describe("Personas", func() {
+	It("should only get complete rules of known personas from components", func() {
+		allComponents, err := (&dscv1.DataScienceCluster{}).GetComponents()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, component := range allComponents {
+			provider, ok := component.(components.PersonaRBACProvider)
+			if !ok {
+				continue
+			}
+			for persona, rules := range provider.PersonaPolicyRules() {
+				Expect(components.Personas).To(ContainElement(persona), "%s contributes to an unknown persona", component.GetComponentName())
+				Expect(rules).NotTo(BeEmpty(), "%s contributes no rules to persona %s", component.GetComponentName(), persona)
+				for _, rule := range rules {
+					Expect(rule.APIGroups).NotTo(BeEmpty(), "%s contributes an incomplete rule to persona %s", component.GetComponentName(), persona)
+					Expect(rule.Resources).NotTo(BeEmpty(), "%s contributes an incomplete rule to persona %s", component.GetComponentName(), persona)
+					Expect(rule.Verbs).NotTo(BeEmpty(), "%s contributes an incomplete rule to persona %s", component.GetComponentName(), persona)
+				}
+			}
+		}
+	})
+
+	Context("ClusterRoles", func() {
+		var (
+			instance *dscv1.DataScienceCluster
+			cli      client.Client
+			r        *DataScienceClusterReconciler
+			rules    personaRules
+		)
+
+		clusterRole := func(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+			role := &rbacv1.ClusterRole{}
+			return role, cli.Get(ctx, client.ObjectKey{Name: name}, role)
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "uid"}}
+			cli = fake.NewClientBuilder().WithScheme(scheme).Build()
+			r = &DataScienceClusterReconciler{Client: cli, Scheme: scheme}
+			rules = personaRules{
+				components.PersonaAdmin: {{APIGroups: []string{"serving.kserve.io"}, Resources: []string{"inferenceservices"}, Verbs: []string{"*"}}},
+				components.PersonaUser:  {{APIGroups: []string{"serving.kserve.io"}, Resources: []string{"inferenceservices"}, Verbs: []string{"get"}}},
+			}
+		})
+
+		It("should aggregate the rules of components into each persona", func(ctx context.Context) {
+			Expect(r.reconcilePersonaRoles(ctx, instance)).To(Succeed())
+
+			for _, persona := range components.Personas {
+				role, err := clusterRole(ctx, string(persona))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(role.AggregationRule.ClusterRoleSelectors).To(ConsistOf(metav1.LabelSelector{
+					MatchLabels: map[string]string{labels.ODH.AggregateTo(string(persona)): "true"},
+				}))
+			}
+		})
+
+		It("should hold the rules of an enabled component for the personas it contributes to", func(ctx context.Context) {
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+
+			role, err := clusterRole(ctx, "odh-user-kserve")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(role.Rules).To(Equal(rules[components.PersonaUser]))
+			Expect(role.Labels).To(HaveKeyWithValue(labels.ODH.AggregateTo("odh-user"), "true"))
+			Expect(role.OwnerReferences).To(ConsistOf(HaveField("Name", "default-dsc")))
+			_, err = clusterRole(ctx, "odh-project-admin-kserve")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should update the rules contributed by a component", func(ctx context.Context) {
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+			rules[components.PersonaUser][0].Verbs = []string{"get", "list"}
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+
+			role, err := clusterRole(ctx, "odh-user-kserve")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(role.Rules[0].Verbs).To(ConsistOf("get", "list"))
+		})
+
+		It("should delete the roles of personas a component no longer contributes to", func(ctx context.Context) {
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+			delete(rules, components.PersonaUser)
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+
+			_, err := clusterRole(ctx, "odh-admin-kserve")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = clusterRole(ctx, "odh-user-kserve")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should delete all roles of a removed component", func(ctx context.Context) {
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, true)).To(Succeed())
+			Expect(r.reconcileComponentPersonaRoles(ctx, instance, "kserve", rules, false)).To(Succeed())
+
+			for _, persona := range components.Personas {
+				_, err := clusterRole(ctx, personaRoleName(persona, "kserve"))
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			}
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// defaultReadinessTimeout is used when the reconciler has no ReadinessTimeout configured.
+const defaultReadinessTimeout = 10 * time.Minute
+
+// checkReadinessGates returns the readiness gates of the component which are not passing yet. These are:
+// - Deployments of the component have their replicas available
+// - CRDs of the component are established
+// - Services backing webhooks of the component have endpoints ready to serve.
+func (r *DataScienceClusterReconciler) checkReadinessGates(ctx context.Context, componentName string) ([]string, error) {
+	selector := client.MatchingLabels{labels.ODH.Component(componentName): "true"}
+	var unmet []string
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, selector); err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.AvailableReplicas < replicas {
+			unmet = append(unmet, fmt.Sprintf("deployment %s has %d/%d replicas available", deployment.Name, deployment.Status.AvailableReplicas, replicas))
+		}
+	}
+
+	// CRDs and webhook configurations are read directly, there is no need to cache them cluster-wide just for readiness checks
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := r.reader().List(ctx, crds, selector); err != nil {
+		return nil, err
+	}
+	for _, crd := range crds.Items {
+		if !crdEstablished(&crd) {
+			unmet = append(unmet, fmt.Sprintf("CRD %s is not established", crd.Name))
+		}
+	}
+
+	services, err := r.webhookServices(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		serving, errEndpoints := r.hasReadyEndpoints(ctx, service)
+		if errEndpoints != nil {
+			return nil, errEndpoints
+		}
+		if !serving {
+			unmet = append(unmet, fmt.Sprintf("webhook service %s has no ready endpoints", service.Name))
+		}
+	}
+
+	return unmet, nil
+}
+
+func (r *DataScienceClusterReconciler) reader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+func crdEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *DataScienceClusterReconciler) webhookServices(ctx context.Context, selector client.MatchingLabels) ([]client.ObjectKey, error) {
+	var services []client.ObjectKey
+	addService := func(clientConfig admissionregistrationv1.WebhookClientConfig) {
+		if clientConfig.Service != nil {
+			services = append(services, client.ObjectKey{Name: clientConfig.Service.Name, Namespace: clientConfig.Service.Namespace})
+		}
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := r.reader().List(ctx, validating, selector); err != nil {
+		return nil, err
+	}
+	for _, configuration := range validating.Items {
+		for _, webhook := range configuration.Webhooks {
+			addService(webhook.ClientConfig)
+		}
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := r.reader().List(ctx, mutating, selector); err != nil {
+		return nil, err
+	}
+	for _, configuration := range mutating.Items {
+		for _, webhook := range configuration.Webhooks {
+			addService(webhook.ClientConfig)
+		}
+	}
+
+	return services, nil
+}
+
+func (r *DataScienceClusterReconciler) hasReadyEndpoints(ctx context.Context, service client.ObjectKey) (bool, error) {
+	endpoints := &corev1.Endpoints{}
+	if err := r.reader().Get(ctx, service, endpoints); err != nil {
+		if k8serr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setReadinessGatesCondition marks the component as not ready yet. Once its gates have not been passing for longer
+// than the timeout, the reason changes to status.ReadinessGatesTimeout.
+func setReadinessGatesCondition(conditions *[]conditionsv1.Condition, componentName string, unmet []string, timeout time.Duration, now time.Time) {
+	reason := status.ReadinessGatesPending
+	existing := conditionsv1.FindStatusCondition(*conditions, conditionsv1.ConditionType(componentName+status.ReadySuffix))
+	if existing != nil && existing.Status == corev1.ConditionFalse &&
+		(existing.Reason == status.ReadinessGatesPending || existing.Reason == status.ReadinessGatesTimeout) &&
+		now.Sub(existing.LastTransitionTime.Time) > timeout {
+		reason = status.ReadinessGatesTimeout
+	}
+	status.SetComponentCondition(conditions, componentName, reason, "Waiting for readiness gates: "+strings.Join(unmet, "; "), corev1.ConditionFalse)
+}
+
+// componentsNotReady returns names of the components waiting for their readiness gates, and whether any of them timed out.
+func componentsNotReady(conditions []conditionsv1.Condition) ([]string, bool) {
+	var notReady []string
+	timedOut := false
+	for _, condition := range conditions {
+		if condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		switch condition.Reason {
+		case status.ReadinessGatesTimeout:
+			timedOut = true
+			fallthrough
+		case status.ReadinessGatesPending:
+			notReady = append(notReady, strings.TrimSuffix(string(condition.Type), status.ReadySuffix))
+		}
+	}
+	return notReady, timedOut
+}
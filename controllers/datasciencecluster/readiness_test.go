@@ -0,0 +1,169 @@
+package datasciencecluster
+
+import (
+	"context"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Readiness gates", func() {
+	Context("checked in the cluster", func() {
+		var objects []client.Object
+
+		componentMeta := func(name string) metav1.ObjectMeta {
+			return metav1.ObjectMeta{Name: name, Namespace: "opendatahub", Labels: map[string]string{labels.ODH.Component("kserve"): "true"}}
+		}
+		deployment := func(replicas, available int32) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: componentMeta("kserve-controller-manager"),
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+			}
+		}
+		crd := func(established apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+			meta := componentMeta("inferenceservices.serving.kserve.io")
+			meta.Namespace = ""
+			return &apiextensionsv1.CustomResourceDefinition{ObjectMeta: meta, Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{Type: apiextensionsv1.Established, Status: established}},
+			}}
+		}
+		webhook := func() *admissionregistrationv1.ValidatingWebhookConfiguration {
+			meta := componentMeta("inferenceservice.serving.kserve.io")
+			meta.Namespace = ""
+			return &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: meta, Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "inferenceservice.kserve-webhook-server.validator",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Name: "kserve-webhook-server-service", Namespace: "opendatahub"},
+				},
+			}}}
+		}
+		endpoints := func(addresses ...corev1.EndpointAddress) *corev1.Endpoints {
+			return &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "kserve-webhook-server-service", Namespace: "opendatahub"},
+				Subsets:    []corev1.EndpointSubset{{Addresses: addresses}},
+			}
+		}
+
+		check := func(ctx context.Context) []string {
+			GinkgoHelper()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+			r := &DataScienceClusterReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()}
+			unmet, err := r.checkReadinessGates(ctx, "kserve")
+			Expect(err).NotTo(HaveOccurred())
+			return unmet
+		}
+
+		BeforeEach(func() {
+			objects = nil
+		})
+
+		It("should pass once deployments, CRDs and webhooks are ready", func(ctx context.Context) {
+			objects = []client.Object{deployment(2, 2), crd(apiextensionsv1.ConditionTrue), webhook(), endpoints(corev1.EndpointAddress{IP: "10.0.0.1"})}
+
+			Expect(check(ctx)).To(BeEmpty())
+		})
+
+		It("should pass without any resources of the component", func(ctx context.Context) {
+			Expect(check(ctx)).To(BeEmpty())
+		})
+
+		It("should wait for replicas of deployments to be available", func(ctx context.Context) {
+			objects = []client.Object{deployment(2, 1)}
+
+			Expect(check(ctx)).To(ConsistOf("deployment kserve-controller-manager has 1/2 replicas available"))
+		})
+
+		It("should wait for CRDs to be established", func(ctx context.Context) {
+			objects = []client.Object{crd(apiextensionsv1.ConditionFalse)}
+
+			Expect(check(ctx)).To(ConsistOf("CRD inferenceservices.serving.kserve.io is not established"))
+		})
+
+		It("should wait for webhook services to have ready endpoints", func(ctx context.Context) {
+			objects = []client.Object{webhook(), endpoints()}
+
+			Expect(check(ctx)).To(ConsistOf("webhook service kserve-webhook-server-service has no ready endpoints"))
+		})
+
+		It("should wait for webhook services without endpoints", func(ctx context.Context) {
+			objects = []client.Object{webhook()}
+
+			Expect(check(ctx)).To(ConsistOf("webhook service kserve-webhook-server-service has no ready endpoints"))
+		})
+	})
+
+	Context("not passing", func() {
+		const timeout = 10 * time.Minute
+		var now time.Time
+
+		condition := func(status corev1.ConditionStatus, reason string, since time.Duration) []conditionsv1.Condition {
+			return []conditionsv1.Condition{{
+				Type: "kserveReady", Status: status, Reason: reason, LastTransitionTime: metav1.NewTime(now.Add(-since)),
+			}}
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+		})
+
+		DescribeTable("should mark the component as not ready",
+			func(conditions func() []conditionsv1.Condition, reason string, timedOut bool) {
+				current := conditions()
+				setReadinessGatesCondition(&current, "kserve", []string{"deployment kserve-controller-manager has 0/1 replicas available"}, timeout, now)
+
+				Expect(conditionsv1.FindStatusCondition(current, "kserveReady")).To(And(
+					HaveField("Status", corev1.ConditionFalse),
+					HaveField("Reason", reason),
+					HaveField("Message", ContainSubstring("0/1 replicas available")),
+				))
+				notReady, isTimedOut := componentsNotReady(current)
+				Expect(notReady).To(ConsistOf("kserve"))
+				Expect(isTimedOut).To(Equal(timedOut))
+			},
+			Entry("when it was ready", func() []conditionsv1.Condition {
+				return condition(corev1.ConditionTrue, status.ReconcileCompleted, time.Hour)
+			}, status.ReadinessGatesPending, false),
+			Entry("when it was not reconciled yet", func() []conditionsv1.Condition {
+				return nil
+			}, status.ReadinessGatesPending, false),
+			Entry("when gates are pending within the timeout", func() []conditionsv1.Condition {
+				return condition(corev1.ConditionFalse, status.ReadinessGatesPending, time.Minute)
+			}, status.ReadinessGatesPending, false),
+			Entry("when gates are pending for longer than the timeout", func() []conditionsv1.Condition {
+				return condition(corev1.ConditionFalse, status.ReadinessGatesPending, time.Hour)
+			}, status.ReadinessGatesTimeout, true),
+			Entry("when gates already timed out", func() []conditionsv1.Condition {
+				return condition(corev1.ConditionFalse, status.ReadinessGatesTimeout, time.Hour)
+			}, status.ReadinessGatesTimeout, true),
+			Entry("when it failed for longer than the timeout", func() []conditionsv1.Condition {
+				return condition(corev1.ConditionFalse, status.ReconcileFailed, time.Hour)
+			}, status.ReadinessGatesPending, false),
+		)
+
+		It("should not report components failing for other reasons", func() {
+			notReady, timedOut := componentsNotReady(condition(corev1.ConditionFalse, status.ReconcileFailed, time.Hour))
+
+			Expect(notReady).To(BeEmpty())
+			Expect(timedOut).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,113 @@
+package datasciencecluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// requestedComponents returns the components named in the given annotation, in the order they are listed, and the
+// names which do not match any component.
+func requestedComponents(instance *dscv1.DataScienceCluster, annotation string,
+	allComponents []components.ComponentInterface,
+) ([]components.ComponentInterface, []string) {
+	byName := make(map[string]components.ComponentInterface, len(allComponents))
+	for _, component := range allComponents {
+		byName[component.GetComponentName()] = component
+	}
+
+	var requested []components.ComponentInterface
+	var unknown []string
+	for _, name := range strings.Split(instance.GetAnnotations()[annotation], ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if component, found := byName[name]; found {
+			requested = append(requested, component)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+
+	return requested, unknown
+}
+
+// reconcileRequestedComponents reconciles the components named in the ReconcileComponent annotation only, then removes
+// the annotation. The phase and the overall conditions of the DataScienceCluster are left to the next full
+// reconciliation, which the removal of the annotation does not trigger.
+func (r *DataScienceClusterReconciler) reconcileRequestedComponents(ctx context.Context, instance *dscv1.DataScienceCluster,
+	platform cluster.Platform, allComponents []components.ComponentInterface,
+) (ctrl.Result, error) {
+	requested, unknown := requestedComponents(instance, annotations.ReconcileComponent, allComponents)
+	if len(unknown) > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "UnknownComponent",
+			"Cannot reconcile unknown components %v requested by the %s annotation", unknown, annotations.ReconcileComponent)
+	}
+
+	var componentErrors *multierror.Error
+	for _, component := range requested {
+		r.Log.Info("Reconciling component requested by annotation", "component", component.GetComponentName())
+		var err error
+		if instance, err = r.reconcileSubComponent(ctx, instance, platform, component); err != nil {
+			componentErrors = multierror.Append(componentErrors, err)
+		}
+	}
+
+	// removed whatever the outcome, for the next events to reconcile all components again
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, annotations.ReconcileComponent)
+	if err := r.Client.Patch(ctx, instance, client.RawPatch(types.MergePatchType, []byte(patch))); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove the %s annotation: %w", annotations.ReconcileComponent, err)
+	}
+
+	return ctrl.Result{}, componentErrors.ErrorOrNil()
+}
+
+// annotationChangedPredicate triggers reconciliations when annotations change, except for the removal of the
+// ReconcileComponent annotation once the requested components have been reconciled.
+var annotationChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		oldAnnotations := e.ObjectOld.GetAnnotations()
+		newAnnotations := e.ObjectNew.GetAnnotations()
+		if _, found := newAnnotations[annotations.ReconcileComponent]; !found {
+			oldAnnotations = withoutAnnotation(oldAnnotations, annotations.ReconcileComponent)
+		}
+
+		if len(oldAnnotations) == 0 && len(newAnnotations) == 0 {
+			return false
+		}
+
+		return !reflect.DeepEqual(oldAnnotations, newAnnotations)
+	},
+}
+
+func withoutAnnotation(all map[string]string, key string) map[string]string {
+	if _, found := all[key]; !found {
+		return all
+	}
+	filtered := make(map[string]string, len(all))
+	for k, v := range all {
+		if k != key {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
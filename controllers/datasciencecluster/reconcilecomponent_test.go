@@ -0,0 +1,119 @@
+package datasciencecluster
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func componentNames(all []components.ComponentInterface) []string {
+	var names []string
+	for _, component := range all {
+		names = append(names, component.GetComponentName())
+	}
+
+	return names
+}
+
+var _ = Describe("Reconciling components on demand", func() {
+	dsc := func(annotations map[string]string) *dscv1.DataScienceCluster {
+		return &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", Generation: 1, Annotations: annotations}}
+	}
+
+	DescribeTable("should list the components requested by the annotation",
+		func(annotation string, requested, unknown []string) {
+			instance := dsc(map[string]string{annotations.ReconcileComponent: annotation})
+			allComponents, err := instance.GetComponents()
+			Expect(err).ToNot(HaveOccurred())
+
+			components, unknownNames := requestedComponents(instance, annotations.ReconcileComponent, allComponents)
+
+			Expect(componentNames(components)).To(Equal(requested))
+			Expect(unknownNames).To(Equal(unknown))
+		},
+		Entry("in the order they are listed", "kserve, unknown,,dashboard", []string{"kserve", "dashboard"}, []string{"unknown"}),
+		Entry("with none of them known", "unknown", nil, []string{"unknown"}),
+		Entry("with an empty annotation", "", nil, nil),
+	)
+
+	Context("reconciling requested components", func() {
+		var (
+			instance *dscv1.DataScienceCluster
+			recorder *record.FakeRecorder
+			cli      client.Client
+		)
+
+		reconcile := func(ctx context.Context, allComponents []components.ComponentInterface) error {
+			r := &DataScienceClusterReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), Recorder: recorder}
+			_, err := r.reconcileRequestedComponents(ctx, instance, cluster.OpenDataHub, allComponents)
+			return err
+		}
+
+		BeforeEach(func() {
+			instance = dsc(map[string]string{annotations.ReconcileComponent: "unknown", "a": "b"})
+			recorder = record.NewFakeRecorder(10)
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).Build()
+		})
+
+		It("should report unknown components and remove the annotation", func(ctx context.Context) {
+			allComponents, err := instance.GetComponents()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reconcile(ctx, allComponents)).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("UnknownComponent")))
+			saved := &dscv1.DataScienceCluster{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+			Expect(saved.Annotations).To(Equal(map[string]string{"a": "b"}))
+		})
+
+		It("should ignore a deleted DataScienceCluster", func(ctx context.Context) {
+			Expect(cli.Delete(ctx, instance.DeepCopy())).To(Succeed())
+
+			Expect(reconcile(ctx, nil)).To(Succeed())
+		})
+	})
+
+	DescribeTable("should reconcile annotation changes of the DataScienceCluster",
+		func(old, updated map[string]string, expected bool) {
+			Expect(annotationChangedPredicate.Update(event.UpdateEvent{ObjectOld: dsc(old), ObjectNew: dsc(updated)})).To(Equal(expected))
+			Expect(dataScienceClusterChangedPredicate.Update(event.UpdateEvent{ObjectOld: dsc(old), ObjectNew: dsc(updated)})).To(Equal(expected))
+		},
+		Entry("unless they are unchanged", map[string]string{"a": "b"}, map[string]string{"a": "b"}, false),
+		Entry("when they change", map[string]string{"a": "b"}, map[string]string{"a": "c"}, true),
+		Entry("when an annotation is added", nil, map[string]string{"a": "b"}, true),
+		Entry("when components are requested", nil, map[string]string{annotations.ReconcileComponent: "kserve"}, true),
+		Entry("when other components are requested",
+			map[string]string{annotations.ReconcileComponent: "kserve"}, map[string]string{annotations.ReconcileComponent: "ray"}, true),
+		Entry("unless the request is removed", map[string]string{annotations.ReconcileComponent: "kserve"}, nil, false),
+		Entry("when the request is removed along with another change",
+			map[string]string{annotations.ReconcileComponent: "kserve", "a": "b"}, map[string]string{"a": "c"}, true),
+	)
+
+	It("should not reconcile annotation changes of owned resources, which change with each rollout", func() {
+		deployment := func(revision string) *appsv1.Deployment {
+			return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "odh-dashboard", Generation: 1, Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": revision,
+			}}}
+		}
+
+		Expect(resourceChangedPredicate.Update(event.UpdateEvent{ObjectOld: deployment("1"), ObjectNew: deployment("2")})).To(BeFalse())
+	})
+})
@@ -0,0 +1,70 @@
+package datasciencecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// resetComponents discards the devFlags and extraParams of the components named in the ResetComponent annotation,
+// restores the embedded manifests their devFlags were downloaded over, and records what was discarded in the status.
+// The update of the spec triggers a full reconciliation, rendering the components from their pristine manifests.
+func (r *DataScienceClusterReconciler) resetComponents(ctx context.Context, instance *dscv1.DataScienceCluster) error {
+	allComponents, err := instance.GetComponents()
+	if err != nil {
+		return err
+	}
+	requested, unknown := requestedComponents(instance, annotations.ResetComponent, allComponents)
+	if len(unknown) > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "UnknownComponent",
+			"Cannot reset unknown components %v requested by the %s annotation", unknown, annotations.ResetComponent)
+	}
+
+	now := metav1.Now()
+	resets := make([]status.ComponentReset, 0, len(requested))
+	for _, component := range requested {
+		componentName := component.GetComponentName()
+		reset := status.ComponentReset{Component: componentName, Time: now}
+		if discarded := component.ResetCustomizations(); !discarded.IsEmpty() {
+			data, err := json.Marshal(discarded)
+			if err != nil {
+				return err
+			}
+			reset.Discarded = string(data)
+		}
+		// manifests downloaded through devFlags replaced the embedded ones on disk
+		for _, provenance := range instance.Status.Manifests {
+			if provenance.Component != componentName || provenance.Source == status.ManifestsSourceEmbedded {
+				continue
+			}
+			if _, err := deploy.RestoreEmbeddedManifests(provenance.Path); err != nil {
+				return fmt.Errorf("failed to reset manifests of %s: %w", componentName, err)
+			}
+		}
+		resets = append(resets, reset)
+		r.Log.Info("Reset component to its defaults", "component", componentName, "discarded", reset.Discarded)
+	}
+
+	delete(instance.Annotations, annotations.ResetComponent)
+	if err := r.Client.Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to discard customizations of components: %w", err)
+	}
+	for _, reset := range resets {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "ComponentReset", "Component %s reset to its defaults", reset.Component)
+	}
+	_, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		for _, reset := range resets {
+			status.SetComponentReset(&saved.Status.Resets, reset)
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,138 @@
+package datasciencecluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/ray"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	annotations "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resetting components", func() {
+	var (
+		instance *dscv1.DataScienceCluster
+		recorder *record.FakeRecorder
+		funcs    interceptor.Funcs
+		cli      client.Client
+	)
+
+	reset := func(ctx context.Context) error {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dscv1.DataScienceCluster{}).
+				WithObjects(instance).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &DataScienceClusterReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), Recorder: recorder}
+		return r.resetComponents(ctx, instance)
+	}
+	saved := func(ctx context.Context) *dscv1.DataScienceCluster {
+		GinkgoHelper()
+		saved := &dscv1.DataScienceCluster{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+		return saved
+	}
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", Annotations: map[string]string{annotations.ResetComponent: "kserve"}},
+			Spec: dscv1.DataScienceClusterSpec{Components: dscv1.Components{
+				Kserve: kserve.Kserve{Component: components.Component{
+					DevFlags:    &components.DevFlags{Manifests: []components.ManifestsConfig{{URI: "https://example.com/tarball/main"}}},
+					ExtraParams: map[string]string{"kserve-controller": "quay.io/example/kserve:dev"},
+				}},
+				Ray: ray.Ray{Component: components.Component{ExtraParams: map[string]string{"odh-kuberay-operator-controller-image": "quay.io/example/ray:dev"}}},
+			}},
+		}
+		recorder = record.NewFakeRecorder(10)
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should discard the customizations of the requested components and record them", func(ctx context.Context) {
+		Expect(reset(ctx)).To(Succeed())
+
+		saved := saved(ctx)
+		Expect(saved.Spec.Components.Kserve.DevFlags).To(BeNil())
+		Expect(saved.Spec.Components.Kserve.ExtraParams).To(BeNil())
+		Expect(saved.Annotations).ToNot(HaveKey(annotations.ResetComponent))
+		Expect(saved.Status.Resets).To(HaveExactElements(And(
+			HaveField("Component", "kserve"),
+			HaveField("Discarded", `{"devFlags":{"manifests":[{"uri":"https://example.com/tarball/main"}]},"extraParams":{"kserve-controller":"quay.io/example/kserve:dev"}}`),
+		)))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Component kserve reset to its defaults")))
+	})
+
+	It("should keep the customizations of the other components", func(ctx context.Context) {
+		Expect(reset(ctx)).To(Succeed())
+
+		Expect(saved(ctx).Spec.Components.Ray.ExtraParams).To(HaveKey("odh-kuberay-operator-controller-image"))
+	})
+
+	It("should record the reset of components without customizations", func(ctx context.Context) {
+		instance.Annotations[annotations.ResetComponent] = "ray, dashboard"
+
+		Expect(reset(ctx)).To(Succeed())
+
+		Expect(saved(ctx).Status.Resets).To(ConsistOf(
+			And(HaveField("Component", "ray"), HaveField("Discarded", Not(BeEmpty()))),
+			And(HaveField("Component", "dashboard"), HaveField("Discarded", BeEmpty())),
+		))
+	})
+
+	It("should report unknown components and remove the annotation", func(ctx context.Context) {
+		instance.Annotations[annotations.ResetComponent] = "unknown"
+
+		Expect(reset(ctx)).To(Succeed())
+
+		Expect(recorder.Events).To(Receive(ContainSubstring("UnknownComponent")))
+		saved := saved(ctx)
+		Expect(saved.Annotations).ToNot(HaveKey(annotations.ResetComponent))
+		Expect(saved.Status.Resets).To(BeEmpty())
+		Expect(saved.Spec.Components.Kserve.DevFlags).ToNot(BeNil())
+	})
+
+	It("should restore the embedded manifests the devFlags were downloaded over", func(ctx context.Context) {
+		manifestPath := deploy.DefaultManifestPath
+		deploy.DefaultManifestPath = GinkgoT().TempDir()
+		DeferCleanup(func() { deploy.DefaultManifestPath = manifestPath })
+		for dir, content := range map[string]string{"kserve": "downloaded", filepath.Join(".embedded", "kserve"): "embedded"} {
+			Expect(os.MkdirAll(filepath.Join(deploy.DefaultManifestPath, dir), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(deploy.DefaultManifestPath, dir, "kustomization.yaml"), []byte(content), 0o600)).To(Succeed())
+		}
+		instance.Status.Manifests = []status.ManifestsProvenance{
+			{Component: "kserve", Path: "kserve", Source: "https://example.com/tarball/main"},
+		}
+
+		Expect(reset(ctx)).To(Succeed())
+
+		Expect(os.ReadFile(filepath.Join(deploy.DefaultManifestPath, "kserve", "kustomization.yaml"))).To(BeEquivalentTo("embedded"))
+	})
+
+	It("should fail when the customizations cannot be discarded", func(ctx context.Context) {
+		funcs.Update = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			return errors.New("conflict")
+		}
+
+		Expect(reset(ctx)).To(MatchError(ContainSubstring("failed to discard customizations of components")))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+})
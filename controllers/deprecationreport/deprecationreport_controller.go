@@ -0,0 +1,176 @@
+// Package deprecationreport contains controller logic listing the resources of data science projects which use APIs
+// deprecated by upcoming versions of components, for admins to migrate them before upgrading.
+package deprecationreport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ReportName is the name of the DeprecationReport maintained by the operator.
+	ReportName = "default-deprecation-report"
+	// Workloads are not watched, data science projects are scanned periodically.
+	scanInterval = time.Hour
+)
+
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=deprecationreports,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=deprecationreports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="ray.io",resources=rayclusters,verbs=list
+
+// DeprecationReportReconciler holds the controller configuration.
+type DeprecationReportReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists workloads of data science projects, which are not cached.
+	APIReader client.Reader
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DeprecationReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for reports of deprecated API usage.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("deprecation-report-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile scans the data science projects for resources using deprecated APIs, and lists them in the
+// DeprecationReport. It requeues to report on workloads created or changed in the meantime.
+func (r *DeprecationReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !featuregate.Enabled(featuregate.DeprecatedAPIReport) {
+		report := &inventoryv1alpha1.DeprecationReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName}}
+		return ctrl.Result{}, client.IgnoreNotFound(r.Client.Delete(ctx, report))
+	}
+
+	usages, err := r.scan(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.publish(ctx, usages); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: scanInterval}, nil
+}
+
+// scan returns the usages of deprecated APIs in data science projects, sorted by namespace, kind, name and deprecation.
+func (r *DeprecationReportReconciler) scan(ctx context.Context) ([]inventoryv1alpha1.DeprecatedUsage, error) {
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+	inProjects := make(map[string]bool, len(projects.Items))
+	for _, project := range projects.Items {
+		inProjects[project.Name] = true
+	}
+
+	usages := []inventoryv1alpha1.DeprecatedUsage{}
+	listed := map[schema.GroupVersionKind][]unstructured.Unstructured{}
+	for _, d := range deprecations {
+		items, done := listed[d.kind]
+		if !done {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(d.kind)
+			if err := r.APIReader.List(ctx, list); err != nil && !meta.IsNoMatchError(err) {
+				return nil, fmt.Errorf("failed to list %s: %w", d.kind.Kind, err)
+			}
+			items = list.Items
+			listed[d.kind] = items
+		}
+		for i := range items {
+			obj := &items[i]
+			if !inProjects[obj.GetNamespace()] || !d.usedBy(obj) {
+				continue
+			}
+			usages = append(usages, inventoryv1alpha1.DeprecatedUsage{
+				Group:       d.kind.Group,
+				Version:     d.kind.Version,
+				Kind:        d.kind.Kind,
+				Namespace:   obj.GetNamespace(),
+				Name:        obj.GetName(),
+				Component:   d.component,
+				Deprecation: d.name,
+				Message:     d.message,
+			})
+		}
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return usages, nil
+}
+
+// publish creates the DeprecationReport if needed, and records the usages and the time of the scan in its status.
+func (r *DeprecationReportReconciler) publish(ctx context.Context, usages []inventoryv1alpha1.DeprecatedUsage) error {
+	report := &inventoryv1alpha1.DeprecationReport{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: ReportName}, report)
+	if k8serr.IsNotFound(err) {
+		report = &inventoryv1alpha1.DeprecationReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ReportName,
+				Labels: map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"},
+			},
+		}
+		if err := r.Client.Create(ctx, report); err != nil {
+			return fmt.Errorf("failed to create DeprecationReport: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get DeprecationReport: %w", err)
+	}
+
+	if !reflect.DeepEqual(report.Status.Usages, usages) && len(report.Status.Usages)+len(usages) > 0 {
+		r.Log.Info("Usages of deprecated APIs changed", "total", len(usages))
+	}
+	now := metav1.Now()
+	report.Status = inventoryv1alpha1.DeprecationReportStatus{
+		LastScanTime: &now,
+		Total:        len(usages),
+		Usages:       usages,
+	}
+	if err := r.Client.Status().Update(ctx, report); err != nil {
+		return fmt.Errorf("failed to update DeprecationReport: %w", err)
+	}
+
+	return nil
+}
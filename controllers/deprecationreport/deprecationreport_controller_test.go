@@ -0,0 +1,230 @@
+package deprecationreport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func workload(kind schema.GroupVersionKind, namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	return obj
+}
+
+func project(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.ODH.Dashboard: "true"}}}
+}
+
+// managedThroughAPI returns the workload as written through the given API version.
+func managedThroughAPI(obj *unstructured.Unstructured, apiVersion string) *unstructured.Unstructured {
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", APIVersion: apiVersion}})
+
+	return obj
+}
+
+// usagesOf returns the usages as namespace/kind/name/deprecation.
+func usagesOf(usages []inventoryv1alpha1.DeprecatedUsage) []string {
+	names := []string{}
+	for _, usage := range usages {
+		names = append(names, usage.Namespace+"/"+usage.Kind+"/"+usage.Name+"/"+usage.Deprecation)
+	}
+
+	return names
+}
+
+var _ = Describe("Deprecation report controller", func() {
+	var (
+		objects []client.Object
+		funcs   interceptor.Funcs
+		cli     client.Client
+		req     = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	reconciler := func() *DeprecationReportReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(inventoryv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&inventoryv1alpha1.DeprecationReport{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return &DeprecationReportReconciler{Client: cli, Scheme: cli.Scheme(), APIReader: cli, Log: logr.Discard()}
+	}
+	scan := func(ctx context.Context) []string {
+		GinkgoHelper()
+		usages, err := reconciler().scan(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		return usagesOf(usages)
+	}
+	report := func(ctx context.Context) (*inventoryv1alpha1.DeprecationReport, error) {
+		report := &inventoryv1alpha1.DeprecationReport{}
+		return report, cli.Get(ctx, client.ObjectKey{Name: ReportName}, report)
+	}
+
+	BeforeEach(func() {
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}},
+			project("fraud"),
+			project("sales"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}},
+			workload(gvk.DataSciencePipelinesApplication, "sales", "pipelines", map[string]any{}),
+			workload(gvk.DataSciencePipelinesApplication, "fraud", "pipelines", map[string]any{"dspVersion": "v2"}),
+			workload(gvk.DataSciencePipelinesApplication, "platform", "pipelines", map[string]any{}),
+			workload(gvk.InferenceService, "fraud", "sklearn", map[string]any{"predictor": map[string]any{"sklearn": map[string]any{}}}),
+			workload(gvk.InferenceService, "fraud", "ovms", map[string]any{"predictor": map[string]any{"model": map[string]any{}}}),
+			managedThroughAPI(workload(gvk.RayCluster, "fraud", "legacy", map[string]any{}), "ray.io/v1alpha1"),
+			managedThroughAPI(workload(gvk.RayCluster, "fraud", "current", map[string]any{}), "ray.io/v1"),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	Context("scanning data science projects", func() {
+		It("should list the usages of deprecated APIs, sorted", func(ctx context.Context) {
+			Expect(scan(ctx)).To(Equal([]string{
+				"fraud/InferenceService/sklearn/InferenceServiceFrameworkPredictor",
+				"fraud/RayCluster/legacy/RayV1alpha1API",
+				"sales/DataSciencePipelinesApplication/pipelines/PipelinesV1",
+			}))
+		})
+
+		It("should report each deprecated API a resource uses", func(ctx context.Context) {
+			objects = append(objects, managedThroughAPI(workload(gvk.DataSciencePipelinesApplication, "sales", "legacy", map[string]any{}),
+				"datasciencepipelinesapplications.opendatahub.io/v1alpha1"))
+
+			Expect(scan(ctx)).To(ContainElements(
+				"sales/DataSciencePipelinesApplication/legacy/PipelinesV1",
+				"sales/DataSciencePipelinesApplication/legacy/PipelinesV1alpha1API",
+			))
+		})
+
+		It("should skip the kinds of components not installed", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Group == gvk.RayCluster.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.RayCluster.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			Expect(scan(ctx)).To(Equal([]string{
+				"fraud/InferenceService/sklearn/InferenceServiceFrameworkPredictor",
+				"sales/DataSciencePipelinesApplication/pipelines/PipelinesV1",
+			}))
+		})
+
+		It("should report nothing without data science projects", func(ctx context.Context) {
+			objects = objects[:1]
+
+			Expect(scan(ctx)).To(BeEmpty())
+		})
+
+		It("should fail when workloads cannot be listed", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Group == gvk.InferenceService.Group {
+					return k8serr.NewForbidden(schema.GroupResource{Group: gvk.InferenceService.Group, Resource: "inferenceservices"}, "", errors.New("denied"))
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			_, err := reconciler().scan(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to list InferenceService")))
+		})
+	})
+
+	Context("publishing the report", func() {
+		It("should create the report with the usages", func(ctx context.Context) {
+			usages, err := reconciler().scan(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reconciler().publish(ctx, usages)).To(Succeed())
+
+			saved, err := report(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "opendatahub-operator"))
+			Expect(saved.Status.Total).To(Equal(3))
+			Expect(saved.Status.Usages).To(Equal(usages))
+			Expect(saved.Status.LastScanTime).ToNot(BeNil())
+		})
+
+		It("should clear usages once migrated", func(ctx context.Context) {
+			usages, err := reconciler().scan(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconciler().publish(ctx, usages)).To(Succeed())
+
+			Expect(reconciler().publish(ctx, []inventoryv1alpha1.DeprecatedUsage{})).To(Succeed())
+
+			saved, err := report(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Status.Total).To(BeZero())
+			Expect(saved.Status.Usages).To(BeEmpty())
+		})
+
+		It("should fail when the report cannot be read", func(ctx context.Context) {
+			funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return errors.New("unavailable")
+			}
+
+			Expect(reconciler().publish(ctx, nil)).To(MatchError(ContainSubstring("failed to get DeprecationReport")))
+		})
+	})
+
+	Context("reconciling", func() {
+		It("should report usages and requeue to scan again", func(ctx context.Context) {
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+			saved, err := report(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Status.Total).To(Equal(3))
+		})
+
+		It("should delete the report when the feature gate is disabled", func(ctx context.Context) {
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+			Expect(featuregate.Set(map[string]bool{featuregate.DeprecatedAPIReport: false})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(featuregate.Set(nil)).To(Succeed())
+			})
+
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+			_, err := report(ctx)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+			objects = objects[1:]
+
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+			_, err := report(ctx)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
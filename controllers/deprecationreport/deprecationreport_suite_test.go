@@ -0,0 +1,13 @@
+package deprecationreport
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeprecationReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deprecation report suite")
+}
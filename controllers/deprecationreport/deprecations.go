@@ -0,0 +1,79 @@
+package deprecationreport
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/ray"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// deprecation is an API deprecated by an upcoming version of a component, which resources of a kind may use.
+type deprecation struct {
+	name      string
+	component string
+	kind      schema.GroupVersionKind
+	message   string
+	// usedBy tells whether the resource uses the deprecated API.
+	usedBy func(*unstructured.Unstructured) bool
+}
+
+// frameworkPredictors are the framework-specific predictors of InferenceServices, superseded by the model predictor.
+var frameworkPredictors = []string{"sklearn", "xgboost", "tensorflow", "pytorch", "triton", "onnx", "pmml", "lightgbm", "paddle"}
+
+// deprecations are the deprecated APIs checked, in the order they are reported for a resource.
+var deprecations = []deprecation{
+	{
+		name:      "PipelinesV1",
+		component: datasciencepipelines.ComponentName,
+		kind:      gvk.DataSciencePipelinesApplication,
+		message:   "Data Science Pipelines v1 is deprecated, create a pipeline server with dspVersion v2 and migrate the pipelines to it",
+		usedBy: func(obj *unstructured.Unstructured) bool {
+			version, _, _ := unstructured.NestedString(obj.Object, "spec", "dspVersion")
+			return version != "v2"
+		},
+	},
+	{
+		name:      "PipelinesV1alpha1API",
+		component: datasciencepipelines.ComponentName,
+		kind:      gvk.DataSciencePipelinesApplication,
+		message:   "the datasciencepipelinesapplications.opendatahub.io/v1alpha1 API is deprecated, manage the pipeline server through v1",
+		usedBy:    managedThrough("datasciencepipelinesapplications.opendatahub.io/v1alpha1"),
+	},
+	{
+		name:      "InferenceServiceFrameworkPredictor",
+		component: kserve.ComponentName,
+		kind:      gvk.InferenceService,
+		message:   "framework-specific predictors, e.g. spec.predictor.sklearn, are deprecated, use spec.predictor.model with a modelFormat",
+		usedBy: func(obj *unstructured.Unstructured) bool {
+			for _, framework := range frameworkPredictors {
+				if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "predictor", framework); found {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name:      "RayV1alpha1API",
+		component: ray.ComponentName,
+		kind:      gvk.RayCluster,
+		message:   "the ray.io/v1alpha1 API is deprecated, manage the cluster through ray.io/v1",
+		usedBy:    managedThrough("ray.io/v1alpha1"),
+	},
+}
+
+// managedThrough tells whether fields of the resource are managed through the given API version, i.e. whether users or
+// their tooling still write the resource with it.
+func managedThrough(apiVersion string) func(*unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		for _, entry := range obj.GetManagedFields() {
+			if entry.APIVersion == apiVersion {
+				return true
+			}
+		}
+		return false
+	}
+}
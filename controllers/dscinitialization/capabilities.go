@@ -45,6 +45,9 @@ func createCapabilityReporter(cli client.Client, object *dsciv1.DSCInitializatio
 					if errors.As(err, &missingOperatorErr) {
 						actualCondition.Reason = status.MissingOperatorReason
 					}
+					status.SetRemediation(&saved.Status.Remediation, status.RemediationFor(string(actualCondition.Type), err))
+				} else {
+					status.RemoveRemediation(&saved.Status.Remediation, string(actualCondition.Type))
 				}
 				conditionsv1.SetStatusCondition(&saved.Status.Conditions, *actualCondition)
 			}
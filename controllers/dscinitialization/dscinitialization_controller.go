@@ -19,6 +19,7 @@ package dscinitialization
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"reflect"
 
@@ -46,8 +47,10 @@ import (
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cleanup"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/trustedcabundle"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 )
@@ -67,6 +70,8 @@ type DSCInitializationReconciler struct {
 	Log                   logr.Logger
 	Recorder              record.EventRecorder
 	ApplicationsNamespace string
+	// Notifier sends capability activation failures to the sinks of the OperatorConfig.
+	Notifier *notification.Notifier
 }
 
 // +kubebuilder:rbac:groups="dscinitialization.opendatahub.io",resources=dscinitializations/status,verbs=get;update;patch;delete
@@ -111,11 +116,19 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	} else {
 		log.Info("Finalization DSCInitialization start deleting instance", "name", instance.Name, "finalizer", finalizerName)
-		if err := r.removeServiceMesh(ctx, instance); err != nil {
+		tracker, err := cleanup.NewTracker(instance)
+		if err != nil {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "CleanupSkipped", err.Error())
+		}
+		if err := tracker.Run("service mesh", func() error { return r.removeServiceMesh(ctx, instance) }); err != nil {
 			return reconcile.Result{}, err
 		}
+		if len(tracker.Skipped()) > 0 {
+			log.Info("Forced cleanup of DSCInitialization", "name", instance.Name, "skipped", tracker.Skipped())
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "CleanupSkipped", tracker.Message())
+		}
 
-		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 			newInstance := &dsciv1.DSCInitialization{}
 			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), newInstance); err != nil {
 				return err
@@ -136,6 +149,13 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// Incompatibilities found on startup are verified again, for the condition to go away once the spec is fixed
+	if upgrade.HasIncompatibilities(instance.Status.Conditions) {
+		if err := upgrade.VerifyCompatibility(ctx, r.Client); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Start reconciling
 	if instance.Status.Conditions == nil {
 		reason := status.ReconcileInit
@@ -154,8 +174,8 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
-	// upgrade case to update release version in status
-	if !instance.Status.Release.Version.Equals(currentOperatorRelease.Version.Version) {
+	// upgrade case to update release version in status, or platform overridden
+	if !instance.Status.Release.Version.Equals(currentOperatorRelease.Version.Version) || instance.Status.Release.Name != currentOperatorRelease.Name {
 		message := "Updating DSCInitialization status"
 		instance, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
 			saved.Status.Release = currentOperatorRelease
@@ -168,10 +188,36 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
+	// Report the platform downstream logic branches on, it is only detected on startup
+	if platformInfo := cluster.GetPlatformInfo(); instance.Status.Platform == nil || *instance.Status.Platform != platformInfo {
+		instance, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+			saved.Status.Platform = &platformInfo
+		})
+		if err != nil {
+			log.Error(err, "Failed to update platform for DSCInitialization resource.", "DSCInitialization", req.Namespace, "Request.Name", req.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DSCInitializationReconcileError",
+				"Updating DSCInitialization status for instance %s", instance.Name)
+			return reconcile.Result{}, err
+		}
+	}
+	if cluster.PlatformOverridePending(instance.Spec.PlatformOverride) {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "PlatformOverridePending",
+			"Changes of the platform override are applied when the operator restarts")
+	}
+
 	// Check namespace is not exist, then create
 	namespace := instance.Spec.ApplicationsNamespace
 	err := r.createOdhNamespace(ctx, instance, namespace, platform)
 	if err != nil {
+		if errors.Is(err, ErrNamespaceNotReady) {
+			log.Error(err, "Pre-created namespace verification failed")
+			r.Recorder.Event(instance, corev1.EventTypeWarning, status.NamespaceNotReady, err.Error())
+			_, _ = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+				status.SetErrorCondition(&saved.Status.Conditions, status.NamespaceNotReady, err.Error())
+				status.SetRemediation(&saved.Status.Remediation, status.RemediationFor(namespaceRemediationTarget, err))
+				saved.Status.Phase = status.PhaseError
+			})
+		}
 		// no need to log error as it was already logged in createOdhNamespace
 		return reconcile.Result{}, err
 	}
@@ -272,6 +318,10 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			}
 		}
 
+		if err = r.configureGrafanaDashboards(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
+
 		// Apply Service Mesh configurations
 		if errServiceMesh := r.configureServiceMesh(ctx, instance); errServiceMesh != nil {
 			return reconcile.Result{}, errServiceMesh
@@ -280,6 +330,7 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		// Finish reconciling
 		_, err = status.UpdateWithRetry[*dsciv1.DSCInitialization](ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
 			status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, status.ReconcileCompletedMessage)
+			status.RemoveRemediation(&saved.Status.Remediation, namespaceRemediationTarget)
 			saved.Status.Phase = status.PhaseReady
 		})
 		if err != nil {
@@ -329,6 +380,7 @@ func (r *DSCInitializationReconciler) SetupWithManager(ctx context.Context, mgr
 			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
 		Owns(
 			&corev1.ServiceAccount{},
+			builder.OnlyMetadata,
 			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
 		Owns(
 			&corev1.Service{},
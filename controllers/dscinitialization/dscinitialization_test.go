@@ -26,6 +26,7 @@ const (
 	configmapName        = "odh-common-config"
 	monitoringNamespace  = "test-monitoring-ns"
 	readyPhase           = "Ready"
+	errorPhase           = "Error"
 )
 
 var _ = Describe("DataScienceCluster initialization", func() {
@@ -164,6 +165,29 @@ var _ = Describe("DataScienceCluster initialization", func() {
 		})
 	})
 
+	Context("Pre-created namespaces", func() {
+		AfterEach(cleanupResources)
+		const applicationName = "default-dsci"
+		It("Should not create application namespace when namespace policy is Verify", func(ctx context.Context) {
+			// when
+			desiredDsci := createDSCI(operatorv1.Removed, operatorv1.Managed, monitoringNamespace)
+			desiredDsci.Spec.NamespacePolicy = dsciv1.NamespacePolicyVerify
+			Expect(k8sClient.Create(ctx, desiredDsci)).Should(Succeed())
+			// then
+			foundDsci := &dsciv1.DSCInitialization{}
+			Eventually(func(ctx context.Context) string {
+				_ = k8sClient.Get(ctx, client.ObjectKey{Name: applicationName, Namespace: workingNamespace}, foundDsci)
+				return foundDsci.Status.Phase
+			}).
+				WithContext(ctx).
+				WithTimeout(timeout).
+				WithPolling(interval).
+				Should(Equal(errorPhase))
+			foundApplicationNamespace := &corev1.Namespace{}
+			Expect(namespaceExists(applicationNamespace, foundApplicationNamespace)(ctx)).Should(BeFalse())
+		})
+	})
+
 	Context("Handling existing resources", func() {
 		AfterEach(cleanupResources)
 		const applicationName = "default-dsci"
@@ -18,14 +18,20 @@ var Templates = struct {
 	AuthorinoDir string
 	// MetricsDir is the path to the Metrics Collection templates.
 	MetricsDir string
+	// MTLSDir is the path to the mutual TLS templates.
+	MTLSDir string
+	// GrafanaDashboardsDir is the path to the Grafana dashboards templates.
+	GrafanaDashboardsDir string
 	// Location specifies the file system that contains the templates to be used.
 	Location fs.FS
 	// BaseDir is the path to the base of the embedded FS
 	BaseDir string
 }{
-	ServiceMeshDir: path.Join(baseDir, "servicemesh"),
-	AuthorinoDir:   path.Join(baseDir, "authorino"),
-	MetricsDir:     path.Join(baseDir, "metrics-collection"),
-	Location:       dsciEmbeddedFS,
-	BaseDir:        baseDir,
+	ServiceMeshDir:       path.Join(baseDir, "servicemesh"),
+	AuthorinoDir:         path.Join(baseDir, "authorino"),
+	MetricsDir:           path.Join(baseDir, "metrics-collection"),
+	MTLSDir:              path.Join(baseDir, "mtls"),
+	GrafanaDashboardsDir: path.Join(baseDir, "grafana-dashboards"),
+	Location:             dsciEmbeddedFS,
+	BaseDir:              baseDir,
 }
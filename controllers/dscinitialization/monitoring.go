@@ -20,6 +20,9 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/provider"
 )
 
 // +kubebuilder:rbac:groups="route.openshift.io",resources=routers/metrics,verbs=get
@@ -497,3 +500,30 @@ func (r *DSCInitializationReconciler) configureCommonMonitoring(ctx context.Cont
 	}
 	return nil
 }
+
+// configureGrafanaDashboards ships Grafana dashboards for model serving, pipelines and workbenches to the monitoring namespace.
+// Dashboards are embedded in the operator, so they are updated on upgrade, and removed when monitoring is disabled.
+func (r *DSCInitializationReconciler) configureGrafanaDashboards(ctx context.Context, dsciInit *dsciv1.DSCInitialization) error {
+	dashboards := feature.ClusterFeaturesHandler(dsciInit, func(registry feature.FeaturesRegistry) error {
+		return registry.Add(
+			feature.Define("monitoring-grafana-dashboards").
+				EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+					return dsciInit.Spec.Monitoring.ManagementState == operatorv1.Managed, nil
+				}).
+				Manifests(
+					manifest.Location(Templates.Location).
+						Include(Templates.GrafanaDashboardsDir),
+				).
+				WithData(
+					feature.Entry("Monitoring", provider.ValueOf(dsciInit.Spec.Monitoring).Get),
+				),
+		)
+	})
+
+	if err := dashboards.Apply(ctx, r.Client); err != nil {
+		r.Log.Error(err, "error to deploy Grafana dashboards")
+		return err
+	}
+
+	return nil
+}
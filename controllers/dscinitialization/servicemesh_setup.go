@@ -4,18 +4,23 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"slices"
+	"strings"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/manifest"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
 )
 
 func (r *DSCInitializationReconciler) configureServiceMesh(ctx context.Context, instance *dsciv1.DSCInitialization) error {
@@ -30,25 +35,38 @@ func (r *DSCInitializationReconciler) configureServiceMesh(ctx context.Context,
 	switch serviceMeshManagementState {
 	case operatorv1.Managed:
 
-		capabilities := []*feature.HandlerWithReporter[*dsciv1.DSCInitialization]{
-			r.serviceMeshCapability(instance, serviceMeshCondition(status.ConfiguredReason, "Service Mesh configured")),
-		}
-
 		authzCapability, err := r.authorizationCapability(ctx, instance, authorizationCondition(status.ConfiguredReason, "Service Mesh Authorization configured"))
 		if err != nil {
 			return err
 		}
-		capabilities = append(capabilities, authzCapability)
+		capabilities := []struct {
+			name    conditionsv1.ConditionType
+			handler *feature.HandlerWithReporter[*dsciv1.DSCInitialization]
+		}{
+			{status.CapabilityServiceMesh, r.serviceMeshCapability(instance, serviceMeshCondition(status.ConfiguredReason, "Service Mesh configured"))},
+			{status.CapabilityServiceMeshAuthorization, authzCapability},
+		}
 
 		for _, capability := range capabilities {
-			capabilityErr := capability.Apply(ctx, r.Client)
+			capabilityErr := capability.handler.Apply(ctx, r.Client)
 			if capabilityErr != nil {
 				log.Error(capabilityErr, "failed applying service mesh resources")
 				r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DSCInitializationReconcileError", "failed applying service mesh resources")
+				if err := r.Notifier.Notify(ctx, notification.Event{
+					Type:    operatorconfigv1alpha1.CapabilityFailed,
+					Object:  string(capability.name),
+					Message: capabilityErr.Error(),
+				}); err != nil {
+					log.Error(err, "failed to send notification", "capability", capability.name)
+				}
 				return capabilityErr
 			}
 		}
 
+		if err := r.reportMTLSCompliance(ctx, instance); err != nil {
+			return err
+		}
+
 	case operatorv1.Unmanaged:
 		log.Info("ServiceMesh CR is not configured by the operator, we won't do anything")
 	case operatorv1.Removed:
@@ -58,7 +76,25 @@ func (r *DSCInitializationReconciler) configureServiceMesh(ctx context.Context,
 		}
 	}
 
-	return nil
+	return r.reportAuthorizationExemptions(ctx, instance)
+}
+
+// reportAuthorizationExemptions lists the namespaces exempted from authorization in the status, none unless the
+// authorization capability is enabled.
+func (r *DSCInitializationReconciler) reportAuthorizationExemptions(ctx context.Context, instance *dsciv1.DSCInitialization) error {
+	var exemptions []string
+	if instance.Spec.ServiceMesh != nil && instance.Spec.ServiceMesh.ManagementState == operatorv1.Managed && instance.Spec.AuthorizationEnabled() {
+		exemptions = servicemesh.AuthorizationExemptions(&instance.Spec)
+	}
+	if slices.Equal(instance.Status.AuthorizationExemptions, exemptions) {
+		return nil
+	}
+
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+		saved.Status.AuthorizationExemptions = exemptions
+	})
+
+	return err
 }
 
 func (r *DSCInitializationReconciler) removeServiceMesh(ctx context.Context, instance *dsciv1.DSCInitialization) error {
@@ -100,6 +136,14 @@ func (r *DSCInitializationReconciler) serviceMeshCapability(instance *dsciv1.DSC
 }
 
 func (r *DSCInitializationReconciler) authorizationCapability(ctx context.Context, instance *dsciv1.DSCInitialization, condition *conditionsv1.Condition) (*feature.HandlerWithReporter[*dsciv1.DSCInitialization], error) { //nolint:lll // Reason: generics are long
+	if !instance.Spec.AuthorizationEnabled() {
+		// authorization features are disabled, applying them cleans up what they created before
+		return feature.NewHandlerWithReporter(
+			feature.ClusterFeaturesHandler(instance, r.authorizationFeatures(instance)),
+			createCapabilityReporter(r.Client, instance, authorizationCondition(status.RemovedReason, "Authorization capability is Removed in DSCInitialization")),
+		), nil
+	}
+
 	authorinoInstalled, err := cluster.SubscriptionExists(ctx, r.Client, "authorino-operator")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list subscriptions %w", err)
@@ -143,7 +187,10 @@ func (r *DSCInitializationReconciler) serviceMeshCapabilityFeatures(instance *ds
 							path.Join(Templates.ServiceMeshDir),
 						),
 				).
-				WithData(servicemesh.FeatureData.ControlPlane.Define(&instance.Spec).AsAction()).
+				WithData(
+					servicemesh.FeatureData.ControlPlane.Define(&instance.Spec).AsAction(),
+					servicemesh.FeatureData.Values.Define(&instance.Spec).AsAction(),
+				).
 				PreConditions(
 					servicemesh.EnsureServiceMeshOperatorInstalled,
 					feature.CreateNamespaceIfNotExists(controlPlaneSpec.Namespace),
@@ -165,6 +212,22 @@ func (r *DSCInitializationReconciler) serviceMeshCapabilityFeatures(instance *ds
 				PreConditions(
 					servicemesh.EnsureServiceMeshInstalled,
 				),
+			feature.Define("mesh-mtls").
+				EnabledWhen(func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+					return instance.Spec.ServiceMesh.MTLS != nil, nil
+				}).
+				Manifests(
+					manifest.Location(Templates.Location).
+						Include(
+							path.Join(Templates.MTLSDir),
+						),
+				).
+				WithData(
+					servicemesh.FeatureData.MTLS.Define(&instance.Spec).AsAction(),
+				).
+				PreConditions(
+					servicemesh.EnsureServiceMeshInstalled,
+				),
 			feature.Define("mesh-shared-configmap").
 				WithResources(servicemesh.MeshRefs, servicemesh.AuthRefs).
 				WithData(
@@ -181,8 +244,13 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 	return func(registry feature.FeaturesRegistry) error {
 		serviceMeshSpec := instance.Spec.ServiceMesh
 
+		authorizationEnabled := func(_ context.Context, _ client.Client, _ *feature.Feature) (bool, error) {
+			return instance.Spec.AuthorizationEnabled(), nil
+		}
+
 		return registry.Add(
 			feature.Define("mesh-control-plane-external-authz").
+				EnabledWhen(authorizationEnabled).
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(
@@ -193,6 +261,7 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 				).
 				WithData(
 					servicemesh.FeatureData.ControlPlane.Define(&instance.Spec).AsAction(),
+					servicemesh.FeatureData.Values.Define(&instance.Spec).AsAction(),
 				).
 				WithData(
 					servicemesh.FeatureData.Authorization.All(&instance.Spec)...,
@@ -219,6 +288,7 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 			// To make it part of Service Mesh we have to patch it with injection
 			// enabled instead, otherwise it will not have proxy pod injected.
 			feature.Define("enable-proxy-injection-in-authorino-deployment").
+				EnabledWhen(authorizationEnabled).
 				Manifests(
 					manifest.Location(Templates.Location).
 						Include(path.Join(Templates.AuthorinoDir, "deployment.injection.patch.tmpl.yaml")),
@@ -238,3 +308,46 @@ func (r *DSCInitializationReconciler) authorizationFeatures(instance *dsciv1.DSC
 		)
 	}
 }
+
+// reportMTLSCompliance sets a condition listing Deployments of the applications namespace which are not part
+// of the mesh, while mutual TLS is enforced there. Such workloads cannot be reached by other components anymore.
+func (r *DSCInitializationReconciler) reportMTLSCompliance(ctx context.Context, instance *dsciv1.DSCInitialization) error {
+	mtlsNamespaces := servicemesh.MTLSNamespaces(&instance.Spec)
+	if len(mtlsNamespaces) == 0 || mtlsNamespaces[0].Mode != "STRICT" {
+		_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.CapabilityServiceMeshMTLS)
+		})
+		return err
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, client.InNamespace(instance.Spec.ApplicationsNamespace)); err != nil {
+		return err
+	}
+
+	var nonCompliant []string
+	for _, deployment := range deployments.Items {
+		if !servicemesh.HasSidecarInjected(&deployment.Spec.Template) {
+			nonCompliant = append(nonCompliant, deployment.Name)
+		}
+	}
+
+	condition := conditionsv1.Condition{
+		Type:    status.CapabilityServiceMeshMTLS,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: "All workloads in " + instance.Spec.ApplicationsNamespace + " are part of the mesh",
+	}
+	if len(nonCompliant) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = status.NonCompliantWorkloads
+		condition.Message = fmt.Sprintf("Deployments in %s without sidecar, unreachable with STRICT mTLS: %s",
+			instance.Spec.ApplicationsNamespace, strings.Join(nonCompliant, ", "))
+	}
+
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, condition)
+	})
+
+	return err
+}
@@ -3,7 +3,11 @@ package dscinitialization
 import (
 	"context"
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -20,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
@@ -30,13 +35,55 @@ var (
 	resourceTimeout  = 1 * time.Minute
 )
 
+// ErrNamespaceNotReady is returned when a namespace expected to be pre-created does not exist or is not labeled as required.
+var ErrNamespaceNotReady = errors.New("namespace not ready")
+
+// namespaceRemediationTarget is the target of the remediation reported when pre-created namespaces are not ready.
+const namespaceRemediationTarget = "namespaces"
+
 // createOdhNamespace creates a Namespace with given name and with ODH defaults. The defaults include:
 // - Odh specific labels
 // - Pod security labels for baseline permissions
 // - ConfigMap  'odh-common-config'
 // - Network Policies 'opendatahub' that allow traffic between the ODH namespaces
 // - RoleBinding 'opendatahub'.
+// With the "Verify" namespace policy, namespaces are expected to be pre-created and are only checked for required labels.
 func (r *DSCInitializationReconciler) createOdhNamespace(ctx context.Context, dscInit *dsciv1.DSCInitialization, name string, platform cluster.Platform) error {
+	log := r.Log
+	if dscInit.Spec.NamespacePolicy == dsciv1.NamespacePolicyVerify {
+		if err := r.verifyOdhNamespaces(ctx, dscInit, name); err != nil {
+			return err
+		}
+	} else if err := r.reconcileOdhNamespaces(ctx, dscInit, name); err != nil {
+		return err
+	}
+
+	// Create default NetworkPolicy for the namespace
+	err := r.reconcileDefaultNetworkPolicy(ctx, name, dscInit, platform)
+	if err != nil {
+		log.Error(err, "error reconciling network policy ", "name", name)
+		return err
+	}
+
+	// Create odh-common-config Configmap for the Namespace
+	err = r.createOdhCommonConfigMap(ctx, name, dscInit)
+	if err != nil {
+		log.Error(err, "error creating configmap", "name", "odh-common-config")
+		return err
+	}
+
+	// Create default Rolebinding for the namespace
+	err = r.createDefaultRoleBinding(ctx, name, dscInit)
+	if err != nil {
+		log.Error(err, "error creating rolebinding", "name", name)
+		return err
+	}
+	return nil
+}
+
+// reconcileOdhNamespaces creates the applications and, if monitoring is enabled, the monitoring namespace,
+// or patches them with the required labels if they already exist.
+func (r *DSCInitializationReconciler) reconcileOdhNamespaces(ctx context.Context, dscInit *dsciv1.DSCInitialization, name string) error {
 	log := r.Log
 	// Expected application namespace for the given name
 	desiredNamespace := &corev1.Namespace{
@@ -118,29 +165,69 @@ func (r *DSCInitializationReconciler) createOdhNamespace(ctx context.Context, ds
 		}
 	}
 
-	// Create default NetworkPolicy for the namespace
-	err = r.reconcileDefaultNetworkPolicy(ctx, name, dscInit, platform)
-	if err != nil {
-		log.Error(err, "error reconciling network policy ", "name", name)
+	return nil
+}
+
+// verifyOdhNamespaces checks the pre-created applications and monitoring namespaces have the labels
+// the operator would otherwise set, without modifying them.
+func (r *DSCInitializationReconciler) verifyOdhNamespaces(ctx context.Context, dscInit *dsciv1.DSCInitialization, name string) error {
+	monitoringEnabled := dscInit.Spec.Monitoring.ManagementState == operatorv1.Managed
+
+	required := map[string]string{labels.ODH.OwnedNamespace: "true"}
+	if monitoringEnabled {
+		required[labels.ClusterMonitoring] = "true"
+	}
+	if err := r.verifyNamespace(ctx, name, required); err != nil {
 		return err
 	}
 
-	// Create odh-common-config Configmap for the Namespace
-	err = r.createOdhCommonConfigMap(ctx, name, dscInit)
-	if err != nil {
-		log.Error(err, "error creating configmap", "name", "odh-common-config")
-		return err
+	if monitoringEnabled && dscInit.Spec.Monitoring.Namespace != name {
+		return r.verifyNamespace(ctx, dscInit.Spec.Monitoring.Namespace, map[string]string{
+			labels.ODH.OwnedNamespace: "true",
+			labels.ClusterMonitoring:  "true",
+		})
 	}
 
-	// Create default Rolebinding for the namespace
-	err = r.createDefaultRoleBinding(ctx, name, dscInit)
-	if err != nil {
-		log.Error(err, "error creating rolebinding", "name", name)
+	return nil
+}
+
+func (r *DSCInitializationReconciler) verifyNamespace(ctx context.Context, name string, requiredLabels map[string]string) error {
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		if k8serr.IsNotFound(err) {
+			return status.NewRemediationError(status.RemediationNamespaceNotReady,
+				fmt.Sprintf("Create namespace %s with labels %s, or set spec.namespacePolicy to Create", name, formatLabels(requiredLabels)),
+				fmt.Errorf("%w: namespace %s does not exist", ErrNamespaceNotReady, name))
+		}
 		return err
 	}
+
+	var missing []string
+	for key, value := range requiredLabels {
+		if namespace.GetLabels()[key] != value {
+			missing = append(missing, key+"="+value)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return status.NewRemediationError(status.RemediationNamespaceNotReady,
+			fmt.Sprintf("Label namespace %s with %s, or set spec.namespacePolicy to Create", name, strings.Join(missing, ", ")),
+			fmt.Errorf("%w: namespace %s is missing labels %s", ErrNamespaceNotReady, name, strings.Join(missing, ", ")))
+	}
+
 	return nil
 }
 
+func formatLabels(requiredLabels map[string]string) string {
+	formatted := make([]string, 0, len(requiredLabels))
+	for key, value := range requiredLabels {
+		formatted = append(formatted, key+"="+value)
+	}
+	sort.Strings(formatted)
+
+	return strings.Join(formatted, ", ")
+}
+
 func (r *DSCInitializationReconciler) createDefaultRoleBinding(ctx context.Context, name string, dscInit *dsciv1.DSCInitialization) error {
 	log := r.Log
 	// Expected namespace for the given name
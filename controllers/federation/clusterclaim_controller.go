@@ -0,0 +1,199 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelmeshserving"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// ClusterClaims published by the operator. The klusterlet of Open Cluster Management reports them in the status of the
+// ManagedCluster on the hub, where Placements and policies can select clusters by them.
+const (
+	// ClaimVersion holds the version of the operator.
+	ClaimVersion = "version.opendatahub.io"
+	// ClaimPhase holds the phase of the DataScienceCluster.
+	ClaimPhase = "phase.opendatahub.io"
+	// ClaimGPUServing is "true" when a model serving component is ready and the cluster has GPU nodes.
+	ClaimGPUServing = "gpu-serving.opendatahub.io"
+
+	claimReady    = "Ready"
+	claimNotReady = "NotReady"
+
+	// GPU nodes are not watched, the claims are refreshed periodically.
+	claimResync = 5 * time.Minute
+	// gpuNodeLabel is set on nodes with NVIDIA GPUs by the GPU feature discovery of the NVIDIA GPU operator.
+	gpuNodeLabel = "nvidia.com/gpu.present"
+)
+
+// ComponentClaimName returns the name of the ClusterClaim holding the readiness of a Managed component.
+func ComponentClaimName(component string) string {
+	return component + ".component.opendatahub.io"
+}
+
+// +kubebuilder:rbac:groups="cluster.open-cluster-management.io",resources=clusterclaims,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// ClusterClaimReconciler holds the controller configuration.
+type ClusterClaimReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for managed cluster claims.")
+
+	// status changes of the DataScienceCluster are reconciled too, as they change the readiness of components
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-claim-controller").
+		For(&dscv1.DataScienceCluster{}).
+		Complete(r)
+}
+
+// Reconcile publishes the version of the operator, the phase of the DataScienceCluster, the readiness of its Managed
+// components and the capabilities they provide as ClusterClaims, and deletes the claims no longer relevant.
+func (r *ClusterClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+
+	claims := map[string]string{}
+	if featuregate.Enabled(featuregate.ManagedClusterClaims) && instance.GetDeletionTimestamp() == nil && instance.GetName() != "" {
+		var err error
+		if claims, err = r.claims(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.publish(ctx, claims); err != nil {
+		if meta.IsNoMatchError(err) {
+			r.Log.Info("ClusterClaim CRD is not installed, the cluster is not managed by an Open Cluster Management hub")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if len(claims) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: claimResync}, nil
+}
+
+// claims returns the values of the ClusterClaims of the DataScienceCluster, by name.
+func (r *ClusterClaimReconciler) claims(ctx context.Context, instance *dscv1.DataScienceCluster) (map[string]string, error) {
+	claims := map[string]string{
+		ClaimVersion: cluster.GetRelease().Version.String(),
+		ClaimPhase:   instance.Status.Phase,
+	}
+
+	allComponents, err := instance.GetComponents()
+	if err != nil {
+		return nil, err
+	}
+	servingReady := false
+	for _, component := range allComponents {
+		if component.GetManagementState() != operatorv1.Managed {
+			continue
+		}
+		name := component.GetComponentName()
+		condition := conditionsv1.FindStatusCondition(instance.Status.Conditions, conditionsv1.ConditionType(name+status.ReadySuffix))
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			claims[ComponentClaimName(name)] = claimNotReady
+			continue
+		}
+		claims[ComponentClaimName(name)] = claimReady
+		if name == kserve.ComponentName || name == modelmeshserving.ComponentName {
+			servingReady = true
+		}
+	}
+
+	gpuNodes := &metav1.PartialObjectMetadataList{}
+	gpuNodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+	if err := r.Client.List(ctx, gpuNodes, client.MatchingLabels{gpuNodeLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+	claims[ClaimGPUServing] = fmt.Sprint(servingReady && len(gpuNodes.Items) > 0)
+
+	return claims, nil
+}
+
+// publish creates or updates the given ClusterClaims and deletes the other ones of the operator.
+func (r *ClusterClaimReconciler) publish(ctx context.Context, claims map[string]string) error {
+	for name, value := range claims {
+		if err := r.apply(ctx, name, value); err != nil {
+			return err
+		}
+	}
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(gvk.ClusterClaim)
+	if err := r.Client.List(ctx, existing, client.MatchingLabels{labels.K8SCommon.PartOf: "opendatahub-operator"}); err != nil {
+		return err
+	}
+	for i := range existing.Items {
+		claim := &existing.Items[i]
+		if _, found := claims[claim.GetName()]; found {
+			continue
+		}
+		if err := r.Client.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ClusterClaim %s: %w", claim.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ClusterClaimReconciler) apply(ctx context.Context, name, value string) error {
+	claim := &unstructured.Unstructured{}
+	claim.SetGroupVersionKind(gvk.ClusterClaim)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name}, claim)
+	switch {
+	case k8serr.IsNotFound(err):
+		claim.SetName(name)
+		claim.SetLabels(map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"})
+		if err := unstructured.SetNestedField(claim.Object, value, "spec", "value"); err != nil {
+			return err
+		}
+		if err := r.Client.Create(ctx, claim); err != nil {
+			return fmt.Errorf("failed to create ClusterClaim %s: %w", name, err)
+		}
+	case err != nil:
+		return err
+	default:
+		if current, _, _ := unstructured.NestedString(claim.Object, "spec", "value"); current == value {
+			return nil
+		}
+		if err := unstructured.SetNestedField(claim.Object, value, "spec", "value"); err != nil {
+			return err
+		}
+		if err := r.Client.Update(ctx, claim); err != nil {
+			return fmt.Errorf("failed to update ClusterClaim %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package federation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func clusterClaim(name, value string, claimLabels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk.ClusterClaim)
+	obj.SetName(name)
+	obj.SetLabels(claimLabels)
+	obj.Object["spec"] = map[string]any{"value": value}
+
+	return obj
+}
+
+var _ = Describe("ClusterClaim controller", func() {
+	var (
+		instance *dscv1.DataScienceCluster
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		req      = ctrl.Request{NamespacedName: types.NamespacedName{Name: DataScienceClusterName}}
+		partOf   = map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"}
+	)
+
+	reconciler := func() *ClusterClaimReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return &ClusterClaimReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	}
+	reconcile := func(ctx context.Context) ctrl.Result {
+		GinkgoHelper()
+		result, err := reconciler().Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+	claims := func(ctx context.Context) map[string]string {
+		GinkgoHelper()
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.ClusterClaim)
+		Expect(cli.List(ctx, list)).To(Succeed())
+		values := map[string]string{}
+		for _, claim := range list.Items {
+			values[claim.GetName()], _, _ = unstructured.NestedString(claim.Object, "spec", "value")
+		}
+		return values
+	}
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: DataScienceClusterName},
+			Spec: dscv1.DataScienceClusterSpec{Components: dscv1.Components{
+				Dashboard: dashboard.Dashboard{Component: components.Component{ManagementState: operatorv1.Managed}},
+				Kserve:    kserve.Kserve{Component: components.Component{ManagementState: operatorv1.Managed}},
+			}},
+			Status: dscv1.DataScienceClusterStatus{
+				Phase: "Not Ready",
+				Conditions: []conditionsv1.Condition{
+					{Type: "dashboardReady", Status: corev1.ConditionFalse},
+					{Type: "kserveReady", Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		objects = []client.Object{
+			instance,
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1", Labels: map[string]string{gpuNodeLabel: "true"}}},
+			clusterClaim(ComponentClaimName("ray"), claimReady, partOf),
+			clusterClaim("id.k8s.io", "spoke-1", nil),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should only delete its claims while the feature gate is disabled", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+		Expect(claims(ctx)).To(Equal(map[string]string{"id.k8s.io": "spoke-1"}))
+	})
+
+	When("the feature gate is enabled", func() {
+		BeforeEach(func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.ManagedClusterClaims: true})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(featuregate.Set(nil)).To(Succeed())
+			})
+		})
+
+		It("should publish the health of the platform", func(ctx context.Context) {
+			Expect(reconcile(ctx).RequeueAfter).To(Equal(claimResync))
+
+			Expect(claims(ctx)).To(Equal(map[string]string{
+				"id.k8s.io":                              "spoke-1",
+				ClaimVersion:                             cluster.GetRelease().Version.String(),
+				ClaimPhase:                               "Not Ready",
+				ClaimGPUServing:                          "true",
+				ComponentClaimName("dashboard"):          claimNotReady,
+				ComponentClaimName(kserve.ComponentName): claimReady,
+			}))
+		})
+
+		It("should not update claims which are up to date", func(ctx context.Context) {
+			objects = append(objects, clusterClaim(ClaimPhase, "Not Ready", partOf))
+			reconcile(ctx)
+			claim := &unstructured.Unstructured{}
+			claim.SetGroupVersionKind(gvk.ClusterClaim)
+			Expect(cli.Get(ctx, types.NamespacedName{Name: ClaimPhase}, claim)).To(Succeed())
+
+			reconcile(ctx)
+
+			updated := claim.DeepCopy()
+			Expect(cli.Get(ctx, types.NamespacedName{Name: ClaimPhase}, updated)).To(Succeed())
+			Expect(updated.GetResourceVersion()).To(Equal(claim.GetResourceVersion()))
+		})
+
+		It("should update claims as the platform changes", func(ctx context.Context) {
+			objects = append(objects, clusterClaim(ClaimPhase, "Ready", partOf))
+
+			reconcile(ctx)
+
+			Expect(claims(ctx)).To(HaveKeyWithValue(ClaimPhase, "Not Ready"))
+		})
+
+		DescribeTable("should only claim GPU serving when a model serving component is ready on a cluster with GPU nodes",
+			func(ctx context.Context, servingReady corev1.ConditionStatus, gpuNodeLabels map[string]string, expected string) {
+				instance.Status.Conditions[1].Status = servingReady
+				objects[1].SetLabels(gpuNodeLabels)
+
+				reconcile(ctx)
+
+				Expect(claims(ctx)).To(HaveKeyWithValue(ClaimGPUServing, expected))
+			},
+			Entry("ready with GPU nodes", corev1.ConditionTrue, map[string]string{gpuNodeLabel: "true"}, "true"),
+			Entry("not ready with GPU nodes", corev1.ConditionFalse, map[string]string{gpuNodeLabel: "true"}, "false"),
+			Entry("ready without GPU nodes", corev1.ConditionTrue, nil, "false"),
+		)
+
+		It("should delete its claims along with the DataScienceCluster", func(ctx context.Context) {
+			reconcile(ctx)
+			Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+			Expect(claims(ctx)).To(Equal(map[string]string{"id.k8s.io": "spoke-1"}))
+		})
+
+		It("should ignore clusters not managed by a hub", func(ctx context.Context) {
+			funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if obj.GetObjectKind().GroupVersionKind().Group == gvk.ClusterClaim.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.ClusterClaim.GroupKind()}
+				}
+				return cli.Get(ctx, key, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		})
+
+		It("should report claims failing to be published", func(ctx context.Context) {
+			funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return k8serr.NewForbidden(gvk.ClusterClaim.GroupVersion().WithResource("clusterclaims").GroupResource(), obj.GetName(), nil)
+			}
+
+			_, err := reconciler().Reconcile(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("failed to create ClusterClaim")))
+		})
+	})
+})
@@ -0,0 +1,398 @@
+// Package federation contains controller logic distributing the platform from an Open Cluster Management hub cluster
+// to managed clusters through ManifestWorks, and aggregating its status on each of them. On managed clusters, it
+// publishes the health of the platform as ClusterClaims for the hub to select clusters by.
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	federationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/federation/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ConditionDistributed tells whether the platform has been distributed to all clusters of the fleet.
+	ConditionDistributed conditionsv1.ConditionType = "Distributed"
+
+	// DSCInitializationName and DataScienceClusterName are the names of the resources created on managed clusters.
+	DSCInitializationName  = "default-dsci"
+	DataScienceClusterName = "default-dsc"
+
+	// ManifestWorks are not watched, as their API may not be installed, their status is aggregated periodically.
+	statusResync = time.Minute
+	// phaseFeedback is the name of the status feedback holding the phase of the DataScienceCluster.
+	phaseFeedback = "phase"
+)
+
+// +kubebuilder:rbac:groups="federation.opendatahub.io",resources=datascienceclusterfleets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="federation.opendatahub.io",resources=datascienceclusterfleets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="federation.opendatahub.io",resources=datascienceclusterfleets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="work.open-cluster-management.io",resources=manifestworks,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="cluster.open-cluster-management.io",resources=managedclusters,verbs=get;list;watch
+
+// FleetReconciler holds the controller configuration.
+type FleetReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for DataScienceCluster fleets.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("fleet-controller").
+		For(&federationv1alpha1.DataScienceClusterFleet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile applies a ManifestWork holding the DSCInitialization and DataScienceCluster of the fleet to each of its
+// clusters, removes the ones of clusters no longer part of it, and aggregates the status they report.
+func (r *FleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("datascienceclusterfleet", req.Name)
+
+	instance := &federationv1alpha1.DataScienceClusterFleet{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		// ManifestWorks are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	}
+
+	condition := conditionsv1.Condition{
+		Type:   ConditionDistributed,
+		Status: corev1.ConditionFalse,
+		Reason: "FeatureGateDisabled",
+	}
+	if !featuregate.Enabled(featuregate.MultiClusterFederation) {
+		// ManifestWorks are left as they are, removing them would uninstall the platform from managed clusters
+		condition.Message = fmt.Sprintf("Enable the %s feature gate to distribute the platform", featuregate.MultiClusterFederation)
+		return ctrl.Result{}, r.updateStatus(ctx, instance, condition, status.PhaseNotReady, nil)
+	}
+
+	manifests, err := r.manifests(instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	managedComponents, err := managedComponentNames(instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterNames := append([]string{}, instance.Spec.Clusters...)
+	sort.Strings(clusterNames)
+	clusters := make([]federationv1alpha1.ClusterStatus, 0, len(clusterNames))
+	var errs []error
+	for _, name := range clusterNames {
+		clusterStatus, err := r.distribute(ctx, instance, name, manifests, managedComponents)
+		if meta.IsNoMatchError(err) {
+			condition.Reason = "HubNotDetected"
+			condition.Message = "The Open Cluster Management APIs are not available, the fleet must be created on a hub cluster"
+			return ctrl.Result{}, r.updateStatus(ctx, instance, condition, status.PhaseError, nil)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to distribute the platform to %s: %w", name, err))
+			continue
+		}
+		clusters = append(clusters, clusterStatus)
+	}
+	if err := r.deleteRemovedClusters(ctx, instance); err != nil {
+		errs = append(errs, err)
+	}
+
+	phase := status.PhaseReady
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = status.ConfiguredReason
+	condition.Message = fmt.Sprintf("The platform is distributed to %d clusters", len(clusters))
+	for _, clusterStatus := range clusters {
+		if clusterStatus.Phase != status.PhaseReady {
+			phase = status.PhaseProgressing
+		}
+	}
+	reconcileErr := errors.Join(errs...)
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "Failed to distribute the platform")
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "DistributionFailed"
+		condition.Message = reconcileErr.Error()
+		phase = status.PhaseError
+	}
+	if err := r.updateStatus(ctx, instance, condition, phase, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return ctrl.Result{RequeueAfter: statusResync}, nil
+}
+
+func (r *FleetReconciler) updateStatus(ctx context.Context, instance *federationv1alpha1.DataScienceClusterFleet,
+	condition conditionsv1.Condition, phase string, clusters []federationv1alpha1.ClusterStatus,
+) error {
+	summary := federationv1alpha1.FleetSummary{Total: len(clusters)}
+	for _, clusterStatus := range clusters {
+		if clusterStatus.Phase == status.PhaseReady {
+			summary.Ready++
+		}
+	}
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *federationv1alpha1.DataScienceClusterFleet) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, condition)
+		saved.Status.Phase = phase
+		saved.Status.Summary = summary
+		saved.Status.Clusters = clusters
+	})
+
+	return err
+}
+
+// manifests returns the DSCInitialization and DataScienceCluster to create on managed clusters.
+func (r *FleetReconciler) manifests(instance *federationv1alpha1.DataScienceClusterFleet) ([]any, error) {
+	dsci := &dsciv1.DSCInitialization{Spec: instance.Spec.DSCInitialization}
+	dsci.SetGroupVersionKind(gvk.DSCInitialization)
+	dsci.SetName(DSCInitializationName)
+	dsc := &dscv1.DataScienceCluster{Spec: instance.Spec.DataScienceCluster}
+	dsc.SetGroupVersionKind(gvk.DataScienceCluster)
+	dsc.SetName(DataScienceClusterName)
+
+	manifests := make([]any, 0, 2)
+	for _, obj := range []client.Object{dsci, dsc} {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+		delete(content, "status")
+		manifests = append(manifests, content)
+	}
+
+	return manifests, nil
+}
+
+// managedComponentNames returns the sorted names of the components Managed in the DataScienceCluster of the fleet.
+func managedComponentNames(instance *federationv1alpha1.DataScienceClusterFleet) ([]string, error) {
+	allComponents, err := (&dscv1.DataScienceCluster{Spec: instance.Spec.DataScienceCluster}).GetComponents()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, component := range allComponents {
+		if component.GetManagementState() == operatorv1.Managed {
+			names = append(names, component.GetComponentName())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// ManifestWorkName returns the name of the ManifestWork of the fleet in the namespace of each managed cluster.
+func ManifestWorkName(fleetName string) string {
+	return "opendatahub-" + fleetName
+}
+
+// distribute applies the ManifestWork of the fleet to the cluster and returns the status it reports.
+func (r *FleetReconciler) distribute(ctx context.Context, instance *federationv1alpha1.DataScienceClusterFleet,
+	clusterName string, manifests []any, managedComponents []string,
+) (federationv1alpha1.ClusterStatus, error) {
+	clusterStatus := federationv1alpha1.ClusterStatus{Name: clusterName}
+
+	managedCluster := &unstructured.Unstructured{}
+	managedCluster.SetGroupVersionKind(gvk.ManagedCluster)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		if !k8serr.IsNotFound(err) {
+			return clusterStatus, err
+		}
+		clusterStatus.Message = "ManagedCluster not found"
+		return clusterStatus, nil
+	}
+
+	desired := map[string]any{
+		"workload": map[string]any{"manifests": manifests},
+		"manifestConfigs": []any{
+			map[string]any{
+				"resourceIdentifier": map[string]any{
+					"group":    gvk.DataScienceCluster.Group,
+					"resource": "datascienceclusters",
+					"name":     DataScienceClusterName,
+				},
+				"feedbackRules": []any{
+					map[string]any{"type": "JSONPaths", "jsonPaths": feedbackPaths(managedComponents)},
+				},
+			},
+		},
+	}
+	// round-tripped, to compare with the decoded content of the existing ManifestWork
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return clusterStatus, err
+	}
+	var spec map[string]any
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return clusterStatus, err
+	}
+
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(gvk.ManifestWork)
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: clusterName, Name: ManifestWorkName(instance.Name)}, work)
+	switch {
+	case k8serr.IsNotFound(err):
+		work.SetNamespace(clusterName)
+		work.SetName(ManifestWorkName(instance.Name))
+		if err := cluster.ApplyMetaOptions(work,
+			cluster.OwnedBy(instance, r.Scheme),
+			cluster.WithLabels(labels.ODH.Fleet, instance.Name, labels.K8SCommon.PartOf, "opendatahub-operator"),
+		); err != nil {
+			return clusterStatus, err
+		}
+		work.Object["spec"] = spec
+		if err := r.Client.Create(ctx, work); err != nil {
+			return clusterStatus, err
+		}
+	case err != nil:
+		return clusterStatus, err
+	default:
+		// other fields of the spec, e.g. the delete option, are left to administrators of the hub
+		current, _, _ := unstructured.NestedMap(work.Object, "spec")
+		if current == nil {
+			current = map[string]any{}
+		}
+		if reflect.DeepEqual(current["workload"], spec["workload"]) && reflect.DeepEqual(current["manifestConfigs"], spec["manifestConfigs"]) {
+			break
+		}
+		current["workload"] = spec["workload"]
+		current["manifestConfigs"] = spec["manifestConfigs"]
+		work.Object["spec"] = current
+		if err := r.Client.Update(ctx, work); err != nil {
+			return clusterStatus, err
+		}
+	}
+
+	return workStatus(work, clusterStatus, managedComponents), nil
+}
+
+// feedbackPaths returns the status fields of the DataScienceCluster reported back to the hub: its phase and the
+// status of the Ready condition of each Managed component.
+func feedbackPaths(managedComponents []string) []any {
+	paths := []any{map[string]any{"name": phaseFeedback, "path": ".status.phase"}}
+	for _, name := range managedComponents {
+		paths = append(paths, map[string]any{
+			"name": name,
+			"path": fmt.Sprintf(`.status.conditions[?(@.type=="%s%s")].status`, name, status.ReadySuffix),
+		})
+	}
+
+	return paths
+}
+
+// workStatus fills the status of the cluster from the conditions and the status feedback of its ManifestWork.
+func workStatus(work *unstructured.Unstructured, clusterStatus federationv1alpha1.ClusterStatus, managedComponents []string) federationv1alpha1.ClusterStatus {
+	conditions, _, _ := unstructured.NestedSlice(work.Object, "status", "conditions")
+	for _, item := range conditions {
+		if condition, ok := item.(map[string]any); ok && condition["type"] == "Applied" && condition["status"] == "True" {
+			clusterStatus.Applied = true
+		}
+	}
+
+	feedback := map[string]string{}
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "status", "resourceStatus", "manifests")
+	for _, item := range manifests {
+		manifest, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if kind, _, _ := unstructured.NestedString(manifest, "resourceMeta", "kind"); kind != gvk.DataScienceCluster.Kind {
+			continue
+		}
+		values, _, _ := unstructured.NestedSlice(manifest, "statusFeedback", "values")
+		for _, value := range values {
+			v, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(v, "name")
+			fieldValue, _, _ := unstructured.NestedString(v, "fieldValue", "string")
+			feedback[name] = fieldValue
+		}
+	}
+
+	clusterStatus.Phase = feedback[phaseFeedback]
+	var notReady []string
+	for _, name := range managedComponents {
+		ready := corev1.ConditionStatus(feedback[name])
+		if ready == "" {
+			ready = corev1.ConditionUnknown
+		}
+		if ready != corev1.ConditionTrue {
+			notReady = append(notReady, name)
+		}
+		clusterStatus.Components = append(clusterStatus.Components, federationv1alpha1.ClusterComponentStatus{Name: name, Ready: ready})
+	}
+	switch {
+	case !clusterStatus.Applied:
+		clusterStatus.Message = "The DSCInitialization and DataScienceCluster have not been applied yet"
+	case len(notReady) > 0:
+		clusterStatus.Message = "Components not ready: " + strings.Join(notReady, ", ")
+	}
+
+	return clusterStatus
+}
+
+// deleteRemovedClusters deletes the ManifestWorks of the fleet in clusters no longer part of it, which removes the
+// platform from them.
+func (r *FleetReconciler) deleteRemovedClusters(ctx context.Context, instance *federationv1alpha1.DataScienceClusterFleet) error {
+	works := &unstructured.UnstructuredList{}
+	works.SetGroupVersionKind(gvk.ManifestWork)
+	if err := r.Client.List(ctx, works, client.MatchingLabels{labels.ODH.Fleet: instance.Name}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list ManifestWorks of the fleet: %w", err)
+	}
+
+	clusters := map[string]struct{}{}
+	for _, name := range instance.Spec.Clusters {
+		clusters[name] = struct{}{}
+	}
+	for i := range works.Items {
+		work := &works.Items[i]
+		if _, found := clusters[work.GetNamespace()]; found {
+			continue
+		}
+		r.Log.Info("Removing the platform from cluster no longer part of the fleet", "fleet", instance.Name, "cluster", work.GetNamespace())
+		if err := r.Client.Delete(ctx, work); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ManifestWork of %s: %w", work.GetNamespace(), err)
+		}
+	}
+
+	return nil
+}
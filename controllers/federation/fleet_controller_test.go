@@ -0,0 +1,331 @@
+package federation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	federationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/federation/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func managedCluster(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk.ManagedCluster)
+	obj.SetName(name)
+
+	return obj
+}
+
+func manifestWork(cluster, fleet string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk.ManifestWork)
+	obj.SetNamespace(cluster)
+	obj.SetName(ManifestWorkName(fleet))
+	obj.SetLabels(map[string]string{labels.ODH.Fleet: fleet})
+
+	return obj
+}
+
+// feedback returns the status of a ManifestWork reporting the given feedback values of the DataScienceCluster.
+func feedback(applied string, values map[string]string) map[string]any {
+	items := []any{}
+	for name, value := range values {
+		items = append(items, map[string]any{"name": name, "fieldValue": map[string]any{"type": "String", "string": value}})
+	}
+
+	return map[string]any{
+		"conditions": []any{map[string]any{"type": "Applied", "status": applied}},
+		"resourceStatus": map[string]any{"manifests": []any{
+			map[string]any{
+				"resourceMeta":   map[string]any{"kind": "DataScienceCluster", "name": DataScienceClusterName},
+				"statusFeedback": map[string]any{"values": items},
+			},
+		}},
+	}
+}
+
+var _ = Describe("Fleet controller", func() {
+	var (
+		instance *federationv1alpha1.DataScienceClusterFleet
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		req      = ctrl.Request{NamespacedName: types.NamespacedName{Name: "fleet"}}
+	)
+
+	reconciler := func() *FleetReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(federationv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+				WithStatusSubresource(&federationv1alpha1.DataScienceClusterFleet{}).WithInterceptorFuncs(funcs).Build()
+		}
+		return &FleetReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	}
+	distribute := func(ctx context.Context, clusterName string) federationv1alpha1.ClusterStatus {
+		GinkgoHelper()
+		r := reconciler()
+		manifests, err := r.manifests(instance)
+		Expect(err).ToNot(HaveOccurred())
+		managedComponents, err := managedComponentNames(instance)
+		Expect(err).ToNot(HaveOccurred())
+		clusterStatus, err := r.distribute(ctx, instance, clusterName, manifests, managedComponents)
+		Expect(err).ToNot(HaveOccurred())
+		return clusterStatus
+	}
+	getWork := func(ctx context.Context, clusterName string) (*unstructured.Unstructured, error) {
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(gvk.ManifestWork)
+		return work, cli.Get(ctx, types.NamespacedName{Namespace: clusterName, Name: ManifestWorkName("fleet")}, work)
+	}
+	fleetStatus := func(ctx context.Context) federationv1alpha1.DataScienceClusterFleetStatus {
+		GinkgoHelper()
+		saved := &federationv1alpha1.DataScienceClusterFleet{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		return saved.Status
+	}
+	hubNotDetected := func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+		if obj.GetObjectKind().GroupVersionKind().Group == gvk.ManagedCluster.Group {
+			return &meta.NoKindMatchError{GroupKind: gvk.ManagedCluster.GroupKind()}
+		}
+		return cli.Get(ctx, key, obj, opts...)
+	}
+
+	BeforeEach(func() {
+		instance = &federationv1alpha1.DataScienceClusterFleet{
+			ObjectMeta: metav1.ObjectMeta{Name: "fleet", UID: "fleet-uid"},
+			Spec: federationv1alpha1.DataScienceClusterFleetSpec{
+				Clusters: []string{"spoke-1", "spoke-2"},
+				DataScienceCluster: dscv1.DataScienceClusterSpec{Components: dscv1.Components{
+					Dashboard: dashboard.Dashboard{Component: components.Component{ManagementState: operatorv1.Managed}},
+					Kserve:    kserve.Kserve{Component: components.Component{ManagementState: operatorv1.Removed}},
+				}},
+			},
+		}
+		objects = []client.Object{instance, managedCluster("spoke-1"), manifestWork("spoke-old", "fleet")}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should only report Managed components", func() {
+		Expect(managedComponentNames(instance)).To(Equal([]string{"dashboard"}))
+	})
+
+	Context("distributing the platform to a cluster", func() {
+		It("should report clusters unknown to the hub", func(ctx context.Context) {
+			Expect(distribute(ctx, "spoke-2").Message).To(Equal("ManagedCluster not found"))
+
+			_, err := getWork(ctx, "spoke-2")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should create a ManifestWork holding the DSCInitialization and DataScienceCluster", func(ctx context.Context) {
+			clusterStatus := distribute(ctx, "spoke-1")
+
+			Expect(clusterStatus.Message).To(Equal("The DSCInitialization and DataScienceCluster have not been applied yet"))
+			work, err := getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(work.GetLabels()).To(HaveKeyWithValue(labels.ODH.Fleet, "fleet"))
+			Expect(work.GetOwnerReferences()).To(ConsistOf(HaveField("UID", instance.UID)))
+			workManifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+			Expect(workManifests).To(HaveLen(2))
+			Expect(workManifests[0]).To(HaveKeyWithValue("metadata", HaveKeyWithValue("name", DSCInitializationName)))
+			Expect(workManifests[1]).To(HaveKeyWithValue("metadata", HaveKeyWithValue("name", DataScienceClusterName)))
+			Expect(workManifests[1]).ToNot(HaveKey("status"))
+		})
+
+		It("should not update an up to date ManifestWork", func(ctx context.Context) {
+			distribute(ctx, "spoke-1")
+			work, err := getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+
+			distribute(ctx, "spoke-1")
+
+			updated, err := getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated.GetResourceVersion()).To(Equal(work.GetResourceVersion()))
+		})
+
+		It("should update the workload but keep the other fields set on the hub", func(ctx context.Context) {
+			distribute(ctx, "spoke-1")
+			work, err := getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unstructured.SetNestedField(work.Object, "Orphan", "spec", "deleteOption", "propagationPolicy")).To(Succeed())
+			Expect(cli.Update(ctx, work)).To(Succeed())
+
+			instance.Spec.DataScienceCluster.Components.Kserve.ManagementState = operatorv1.Managed
+			distribute(ctx, "spoke-1")
+
+			updated, err := getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+			propagationPolicy, _, _ := unstructured.NestedString(updated.Object, "spec", "deleteOption", "propagationPolicy")
+			Expect(propagationPolicy).To(Equal("Orphan"))
+			paths, _, _ := unstructured.NestedSlice(updated.Object, "spec", "manifestConfigs")
+			Expect(paths[0]).To(HaveKeyWithValue("feedbackRules", ContainElement(HaveKeyWithValue("jsonPaths", HaveLen(3)))))
+		})
+	})
+
+	Context("removing clusters from the fleet", func() {
+		It("should delete the ManifestWorks of clusters no longer part of it", func(ctx context.Context) {
+			objects = append(objects, manifestWork("spoke-1", "fleet"), manifestWork("spoke-3", "other"))
+
+			Expect(reconciler().deleteRemovedClusters(ctx, instance)).To(Succeed())
+
+			_, err := getWork(ctx, "spoke-old")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			_, err = getWork(ctx, "spoke-1")
+			Expect(err).ToNot(HaveOccurred())
+			other := &unstructured.Unstructured{}
+			other.SetGroupVersionKind(gvk.ManifestWork)
+			Expect(cli.Get(ctx, types.NamespacedName{Namespace: "spoke-3", Name: ManifestWorkName("other")}, other)).To(Succeed())
+		})
+
+		It("should ignore a missing ManifestWork CRD", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return &meta.NoKindMatchError{GroupKind: gvk.ManifestWork.GroupKind()}
+			}
+
+			Expect(reconciler().deleteRemovedClusters(ctx, instance)).To(Succeed())
+		})
+	})
+
+	DescribeTable("aggregating the status of a cluster",
+		func(status map[string]any, expected federationv1alpha1.ClusterStatus) {
+			work := &unstructured.Unstructured{Object: map[string]any{"status": status}}
+
+			Expect(workStatus(work, federationv1alpha1.ClusterStatus{Name: "spoke-1"}, []string{"dashboard", "kserve", "ray"})).To(Equal(expected))
+		},
+		Entry("should list components not ready, or not reported",
+			feedback("True", map[string]string{phaseFeedback: "Not Ready", "dashboard": "True", "kserve": "False"}),
+			federationv1alpha1.ClusterStatus{
+				Name:    "spoke-1",
+				Applied: true,
+				Phase:   "Not Ready",
+				Components: []federationv1alpha1.ClusterComponentStatus{
+					{Name: "dashboard", Ready: corev1.ConditionTrue},
+					{Name: "kserve", Ready: corev1.ConditionFalse},
+					{Name: "ray", Ready: corev1.ConditionUnknown},
+				},
+				Message: "Components not ready: kserve, ray",
+			}),
+		Entry("should not report a message when all components are ready",
+			feedback("True", map[string]string{phaseFeedback: "Ready", "dashboard": "True", "kserve": "True", "ray": "True"}),
+			federationv1alpha1.ClusterStatus{
+				Name:    "spoke-1",
+				Applied: true,
+				Phase:   "Ready",
+				Components: []federationv1alpha1.ClusterComponentStatus{
+					{Name: "dashboard", Ready: corev1.ConditionTrue},
+					{Name: "kserve", Ready: corev1.ConditionTrue},
+					{Name: "ray", Ready: corev1.ConditionTrue},
+				},
+			}),
+		Entry("should report ManifestWorks not applied yet",
+			feedback("False", nil),
+			federationv1alpha1.ClusterStatus{
+				Name: "spoke-1",
+				Components: []federationv1alpha1.ClusterComponentStatus{
+					{Name: "dashboard", Ready: corev1.ConditionUnknown},
+					{Name: "kserve", Ready: corev1.ConditionUnknown},
+					{Name: "ray", Ready: corev1.ConditionUnknown},
+				},
+				Message: "The DSCInitialization and DataScienceCluster have not been applied yet",
+			}),
+	)
+
+	Context("reconciling", func() {
+		It("should not distribute the platform while the feature gate is disabled", func(ctx context.Context) {
+			_, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fleetStatus(ctx).Conditions).To(ContainElement(And(
+				HaveField("Type", ConditionDistributed), HaveField("Reason", "FeatureGateDisabled"))))
+			_, err = getWork(ctx, "spoke-1")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			_, err = getWork(ctx, "spoke-old")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the feature gate is enabled", func() {
+			BeforeEach(func() {
+				Expect(featuregate.Set(map[string]bool{featuregate.MultiClusterFederation: true})).To(Succeed())
+				DeferCleanup(func() {
+					Expect(featuregate.Set(nil)).To(Succeed())
+				})
+			})
+
+			It("should distribute the platform and aggregate the status of the clusters", func(ctx context.Context) {
+				result, err := reconciler().Reconcile(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(statusResync))
+
+				fleet := fleetStatus(ctx)
+				Expect(conditionsv1.IsStatusConditionTrue(fleet.Conditions, ConditionDistributed)).To(BeTrue())
+				Expect(fleet.Phase).To(Equal(status.PhaseProgressing))
+				Expect(fleet.Summary).To(Equal(federationv1alpha1.FleetSummary{Total: 2}))
+				Expect(fleet.Clusters).To(HaveExactElements(HaveField("Name", "spoke-1"), HaveField("Name", "spoke-2")))
+				_, err = getWork(ctx, "spoke-1")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = getWork(ctx, "spoke-old")
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should report a fleet created outside of a hub", func(ctx context.Context) {
+				funcs.Get = hubNotDetected
+
+				_, err := reconciler().Reconcile(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+
+				fleet := fleetStatus(ctx)
+				Expect(fleet.Phase).To(Equal(status.PhaseError))
+				Expect(fleet.Conditions).To(ContainElement(And(
+					HaveField("Type", ConditionDistributed), HaveField("Reason", "HubNotDetected"))))
+			})
+
+			It("should report clusters the platform failed to be distributed to", func(ctx context.Context) {
+				funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					return k8serr.NewForbidden(gvk.ManifestWork.GroupVersion().WithResource("manifestworks").GroupResource(), obj.GetName(), nil)
+				}
+
+				_, err := reconciler().Reconcile(ctx, req)
+				Expect(err).To(MatchError(ContainSubstring("failed to distribute the platform to spoke-1")))
+
+				fleet := fleetStatus(ctx)
+				Expect(fleet.Phase).To(Equal(status.PhaseError))
+				Expect(fleet.Clusters).To(HaveExactElements(HaveField("Name", "spoke-2")))
+				Expect(fleet.Conditions).To(ContainElement(And(
+					HaveField("Type", ConditionDistributed), HaveField("Reason", "DistributionFailed"))))
+			})
+		})
+
+		It("should ignore deleted fleets", func(ctx context.Context) {
+			objects = nil
+
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package idlereaper
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// DefaultMonitoringURL is the query API of the cluster monitoring of OpenShift.
+	DefaultMonitoringURL = "https://thanos-querier.openshift-monitoring.svc:9091"
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// requestsQuery sums the requests received through the service mesh by each workload over a window
+	requestsQuery = `sum by (destination_workload_namespace, destination_workload) (increase(istio_requests_total{reporter="destination"}[%ds]))`
+)
+
+// RequestCounter measures the activity of workloads.
+type RequestCounter interface {
+	// Requests returns the number of requests received by each workload, by namespace and name, over the window.
+	Requests(ctx context.Context, window time.Duration) (map[types.NamespacedName]float64, error)
+}
+
+// MonitoringRequestCounter counts the requests received through the service mesh with the query API of the cluster
+// monitoring.
+type MonitoringRequestCounter struct {
+	URL string
+	// Token authenticates the queries, the one of the service account of the operator when empty.
+	Token  string
+	Client *http.Client
+}
+
+// NewMonitoringRequestCounter returns a RequestCounter querying the cluster monitoring as the operator, trusting the
+// service CA of OpenShift.
+func NewMonitoringRequestCounter() *MonitoringRequestCounter {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if ca, err := os.ReadFile(serviceAccountDir + "/service-ca.crt"); err == nil {
+		pool.AppendCertsFromPEM(ca)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	return &MonitoringRequestCounter{
+		URL:    DefaultMonitoringURL,
+		Client: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+	}
+}
+
+func (m *MonitoringRequestCounter) Requests(ctx context.Context, window time.Duration) (map[types.NamespacedName]float64, error) {
+	token := m.Token
+	if token == "" {
+		data, err := os.ReadFile(serviceAccountDir + "/token")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the token of the operator: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	query := url.Values{"query": {fmt.Sprintf(requestsQuery, int64(window.Seconds()))}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(m.URL, "/")+"/api/v1/query?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("monitoring responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Data struct {
+			Result []struct {
+				Metric map[string]string `json:"metric"`
+				Value  []any             `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 32<<20)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response of the monitoring: %w", err)
+	}
+	requests := make(map[types.NamespacedName]float64, len(result.Data.Result))
+	for _, sample := range result.Data.Result {
+		if len(sample.Value) != 2 {
+			continue
+		}
+		value, _ := sample.Value[1].(string)
+		count, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		requests[types.NamespacedName{
+			Namespace: sample.Metric["destination_workload_namespace"],
+			Name:      sample.Metric["destination_workload"],
+		}] = count
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,70 @@
+package idlereaper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Monitoring request counter", func() {
+	var response string
+
+	counter := func() *MonitoringRequestCounter {
+		monitoring := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" || r.URL.Path != "/api/v1/query" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("forbidden\n"))
+				return
+			}
+			Expect(r.URL.Query().Get("query")).To(ContainSubstring("[3600s]"))
+			_, _ = w.Write([]byte(response))
+		}))
+		DeferCleanup(monitoring.Close)
+		return &MonitoringRequestCounter{URL: monitoring.URL + "/", Token: "token", Client: monitoring.Client()}
+	}
+
+	BeforeEach(func() {
+		response = `{"status": "success", "data": {"resultType": "vector", "result": [
+			{"metric": {"destination_workload_namespace": "fraud", "destination_workload": "scoring-predictor"}, "value": [1700000000, "12.5"]}
+		]}}`
+	})
+
+	It("should count the requests of each workload over the window", func(ctx context.Context) {
+		Expect(counter().Requests(ctx, time.Hour)).To(Equal(map[types.NamespacedName]float64{
+			{Namespace: "fraud", Name: "scoring-predictor"}: 12.5,
+		}))
+	})
+
+	It("should skip malformed samples", func(ctx context.Context) {
+		response = `{"data": {"result": [
+			{"metric": {"destination_workload_namespace": "fraud", "destination_workload": "no-value"}, "value": [1700000000]},
+			{"metric": {"destination_workload_namespace": "fraud", "destination_workload": "not-a-number"}, "value": [1700000000, "NaN?"]},
+			{"metric": {"destination_workload_namespace": "fraud", "destination_workload": "unused"}, "value": [1700000000, "0"]}
+		]}}`
+
+		Expect(counter().Requests(ctx, time.Hour)).To(Equal(map[types.NamespacedName]float64{
+			{Namespace: "fraud", Name: "unused"}: 0,
+		}))
+	})
+
+	It("should fail when the monitoring rejects the query", func(ctx context.Context) {
+		counter := counter()
+		counter.Token = "expired"
+
+		_, err := counter.Requests(ctx, time.Hour)
+		Expect(err).To(MatchError("monitoring responded with 403 Forbidden: forbidden"))
+	})
+
+	It("should fail on an invalid response", func(ctx context.Context) {
+		response = "<html>"
+
+		_, err := counter().Requests(ctx, time.Hour)
+		Expect(err).To(MatchError(ContainSubstring("invalid response of the monitoring")))
+	})
+})
@@ -0,0 +1,262 @@
+// Package idlereaper contains controller logic stopping the notebooks and model servers of data science projects
+// which have been idle for longer than set in the DSCInitialization, and reporting the resources reclaimed in metrics.
+package idlereaper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// workloads are not watched, they are checked periodically
+	checkInterval = 5 * time.Minute
+
+	// NotebookLastActivity is set on notebooks by the notebook controller with the time of their last activity.
+	NotebookLastActivity = "notebooks.kubeflow.org/last-activity"
+	// NotebookStopped is set on notebooks with the time they were stopped, as the dashboard does.
+	NotebookStopped = "kubeflow-resource-stopped"
+	// InferenceServiceStop is set to "true" on InferenceServices to stop their model servers.
+	InferenceServiceStop = "serving.kserve.io/stop"
+
+	notebookKind         = "Notebook"
+	inferenceServiceKind = "InferenceService"
+)
+
+// +kubebuilder:rbac:groups="kubeflow.org",resources=notebooks,verbs=get;list;patch
+
+// IdleReaperReconciler holds the controller configuration.
+type IdleReaperReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists the pods of workloads, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	// Requests measures the activity of model servers, which are not stopped when nil.
+	Requests RequestCounter
+}
+
+// workload is a notebook or model server checked by the reaper.
+type workload struct {
+	kind string
+	gvk  schema.GroupVersionKind
+	// podLabel selects the pods of the workload, with its name as value
+	podLabel string
+	// lastActivity returns when the workload was last active, looking back over the window from now, false when it is
+	// already stopped or cannot tell
+	lastActivity func(obj *unstructured.Unstructured, now time.Time, window time.Duration) (time.Time, bool)
+	// stop is the merge patch stopping the workload at the given time
+	stop func(now time.Time) map[string]any
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IdleReaperReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for the idle reaper.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("idle-reaper-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile stops the notebooks and model servers of data science projects idle for longer than the timeouts of the
+// idle reaper of the DSCInitialization, unless they or their namespace are exempted. It requeues to check them again.
+func (r *IdleReaperReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil || !instance.Spec.IdleReaperEnabled() {
+		return ctrl.Result{}, nil
+	}
+	spec := instance.Spec.IdleReaper
+	exemptions := append([]string{labels.ODH.IdleReaperExempt}, spec.ExemptionLabels...)
+
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+	var namespaces []string
+	for _, namespace := range projects.Items {
+		if namespace.GetDeletionTimestamp() == nil && !exempted(namespace.Labels, exemptions) {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+
+	now := time.Now()
+	var errs []error
+	if timeout := spec.NotebookIdleTimeout; timeout != nil && timeout.Duration > 0 {
+		errs = append(errs, r.reap(ctx, notebooks(), namespaces, exemptions, timeout.Duration, now))
+	}
+	if timeout := spec.ModelServerIdleTimeout; timeout != nil && timeout.Duration > 0 && r.Requests != nil {
+		if requests, err := r.Requests.Requests(ctx, timeout.Duration); err != nil {
+			errs = append(errs, fmt.Errorf("failed to measure the activity of model servers: %w", err))
+		} else {
+			errs = append(errs, r.reap(ctx, inferenceServices(requests), namespaces, exemptions, timeout.Duration, now))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "IdleReaperFailed", "Failed to stop idle workloads: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: checkInterval}, nil
+}
+
+// reap stops the workloads of the namespaces with running pods which have not been active, nor started, within the
+// timeout.
+func (r *IdleReaperReconciler) reap(ctx context.Context, kind workload, namespaces, exemptions []string, timeout time.Duration, now time.Time) error {
+	var errs []error
+	for _, namespace := range namespaces {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.gvk.GroupVersion().WithKind(kind.gvk.Kind + "List"))
+		if err := r.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) {
+				// the component is not deployed
+				return nil
+			}
+			errs = append(errs, fmt.Errorf("failed to list %s resources of namespace %s: %w", kind.kind, namespace, err))
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if obj.GetDeletionTimestamp() != nil || exempted(obj.GetLabels(), exemptions) {
+				continue
+			}
+			lastActivity, known := kind.lastActivity(obj, now, timeout)
+			if !known {
+				continue
+			}
+			pods := &corev1.PodList{}
+			if err := r.APIReader.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels{kind.podLabel: obj.GetName()}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to list pods of %s %s/%s: %w", kind.kind, namespace, obj.GetName(), err))
+				continue
+			}
+			requests, started := runningRequests(pods.Items)
+			if started.IsZero() || now.Sub(lastActivity) < timeout || now.Sub(started) < timeout {
+				continue
+			}
+			errs = append(errs, r.stop(ctx, kind, obj, requests, now.Sub(lastActivity), now))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *IdleReaperReconciler) stop(ctx context.Context, kind workload, obj *unstructured.Unstructured, requests corev1.ResourceList,
+	idle time.Duration, now time.Time,
+) error {
+	patch, err := json.Marshal(kind.stop(now))
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to stop %s %s/%s: %w", kind.kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	observe(kind.kind, requests)
+	r.Log.Info("stopped idle workload", "kind", kind.kind, "namespace", obj.GetNamespace(), "name", obj.GetName(), "idle", idle.Round(time.Minute))
+	r.Recorder.Eventf(obj, corev1.EventTypeNormal, "IdleWorkloadStopped", "Stopped after being idle for %s", idle.Round(time.Minute))
+
+	return nil
+}
+
+// notebooks are active as last reported by the notebook controller, and not checked before it reports it.
+func notebooks() workload {
+	return workload{
+		kind:     notebookKind,
+		gvk:      gvk.Notebook,
+		podLabel: "notebook-name",
+		lastActivity: func(obj *unstructured.Unstructured, _ time.Time, _ time.Duration) (time.Time, bool) {
+			annotations := obj.GetAnnotations()
+			if _, stopped := annotations[NotebookStopped]; stopped {
+				return time.Time{}, false
+			}
+			lastActivity, err := time.Parse(time.RFC3339, annotations[NotebookLastActivity])
+			return lastActivity, err == nil
+		},
+		stop: func(now time.Time) map[string]any {
+			return map[string]any{"metadata": map[string]any{"annotations": map[string]any{NotebookStopped: now.UTC().Format(time.RFC3339)}}}
+		},
+	}
+}
+
+// inferenceServices are active when their predictors received requests within the window, as counted beforehand.
+func inferenceServices(requests map[types.NamespacedName]float64) workload {
+	return workload{
+		kind:     inferenceServiceKind,
+		gvk:      gvk.InferenceService,
+		podLabel: "serving.kserve.io/inferenceservice",
+		lastActivity: func(obj *unstructured.Unstructured, now time.Time, window time.Duration) (time.Time, bool) {
+			if obj.GetAnnotations()[InferenceServiceStop] == "true" {
+				return time.Time{}, false
+			}
+			// predictors are named after the InferenceService, e.g. "<name>-predictor" or "<name>-predictor-00001-deployment"
+			predictor := obj.GetName() + "-predictor"
+			for key, count := range requests {
+				if key.Namespace == obj.GetNamespace() && strings.HasPrefix(key.Name, predictor) && count > 0 {
+					return now, true
+				}
+			}
+			return now.Add(-window), true
+		},
+		stop: func(_ time.Time) map[string]any {
+			return map[string]any{"metadata": map[string]any{"annotations": map[string]any{InferenceServiceStop: "true"}}}
+		},
+	}
+}
+
+// runningRequests returns the resources requested by the running pods and when the last of them started, zero when
+// none is running.
+func runningRequests(pods []corev1.Pod) (corev1.ResourceList, time.Time) {
+	requests := corev1.ResourceList{}
+	var started time.Time
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if pod.Status.StartTime != nil && pod.Status.StartTime.After(started) {
+			started = pod.Status.StartTime.Time
+		}
+		for _, container := range pod.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				total := requests[name]
+				total.Add(quantity)
+				requests[name] = total
+			}
+		}
+	}
+
+	return requests, started
+}
+
+// exempted tells whether any of the exemption labels is set, to another value than "false".
+func exempted(objLabels map[string]string, exemptions []string) bool {
+	for _, key := range exemptions {
+		if value, found := objLabels[key]; found && value != "false" {
+			return true
+		}
+	}
+
+	return false
+}
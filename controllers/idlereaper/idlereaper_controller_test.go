@@ -0,0 +1,340 @@
+package idlereaper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type staticRequests map[types.NamespacedName]float64
+
+func (s staticRequests) Requests(context.Context, time.Duration) (map[types.NamespacedName]float64, error) {
+	return s, nil
+}
+
+type failingRequests struct{}
+
+func (failingRequests) Requests(context.Context, time.Duration) (map[types.NamespacedName]float64, error) {
+	return nil, errors.New("monitoring unavailable")
+}
+
+func workloadObject(kind schema.GroupVersionKind, namespace, name string, annotations, objLabels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(annotations)
+	obj.SetLabels(objLabels)
+	return obj
+}
+
+func runningPod(namespace, name string, podLabels map[string]string, started time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "main",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &metav1.Time{Time: started}},
+	}
+}
+
+var _ = Describe("Idle reaper controller", func() {
+	var (
+		longAgo  time.Time
+		recently time.Time
+		instance *dsciv1.DSCInitialization
+		objects  []client.Object
+		requests RequestCounter
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		longAgo = time.Now().Add(-3 * time.Hour)
+		recently = time.Now().Add(-10 * time.Minute)
+		instance = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{
+				ApplicationsNamespace: "opendatahub",
+				IdleReaper: &dsciv1.IdleReaperSpec{
+					NotebookIdleTimeout:    &metav1.Duration{Duration: time.Hour},
+					ModelServerIdleTimeout: &metav1.Duration{Duration: time.Hour},
+					ExemptionLabels:        []string{"team.example.com/always-on"},
+				},
+			},
+		}
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fraud", Labels: map[string]string{labels.ODH.Dashboard: "true"}}},
+		}
+		requests = staticRequests{}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &IdleReaperReconciler{
+			Client:    cli,
+			Scheme:    cli.Scheme(),
+			Log:       logr.Discard(),
+			APIReader: cli,
+			Recorder:  recorder,
+			Requests:  requests,
+		}
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+	}
+	saved := func(ctx context.Context, kind schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+		GinkgoHelper()
+		obj := workloadObject(kind, "", "", nil, nil)
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)).To(Succeed())
+		return obj
+	}
+	notebookStopped := func(ctx context.Context, namespace, name string) bool {
+		GinkgoHelper()
+		_, stopped := saved(ctx, gvk.Notebook, namespace, name).GetAnnotations()[NotebookStopped]
+		return stopped
+	}
+	inferenceServiceStopped := func(ctx context.Context, name string) bool {
+		GinkgoHelper()
+		return saved(ctx, gvk.InferenceService, "fraud", name).GetAnnotations()[InferenceServiceStop] == "true"
+	}
+	// notebook adds a notebook last active at the given time, and its pod running since the given time
+	notebook := func(namespace, name string, lastActivity, started time.Time, notebookLabels map[string]string) {
+		objects = append(objects,
+			workloadObject(gvk.Notebook, namespace, name, map[string]string{NotebookLastActivity: lastActivity.UTC().Format(time.RFC3339)}, notebookLabels),
+			runningPod(namespace, name+"-0", map[string]string{"notebook-name": name}, started),
+		)
+	}
+	inferenceService := func(name string, annotations map[string]string) {
+		objects = append(objects,
+			workloadObject(gvk.InferenceService, "fraud", name, annotations, nil),
+			runningPod("fraud", name+"-predictor-0", map[string]string{"serving.kserve.io/inferenceservice": name}, longAgo),
+		)
+	}
+
+	Describe("notebooks", func() {
+		BeforeEach(func() {
+			instance.Spec.IdleReaper.ModelServerIdleTimeout = nil
+		})
+
+		It("should stop the idle notebooks and check them again later", func(ctx context.Context) {
+			notebook("fraud", "idle", longAgo, longAgo, nil)
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			stopped := saved(ctx, gvk.Notebook, "fraud", "idle").GetAnnotations()[NotebookStopped]
+			Expect(time.Parse(time.RFC3339, stopped)).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(recorder.Events).To(Receive(Equal("Normal IdleWorkloadStopped Stopped after being idle for 3h0m0s")))
+		})
+
+		It("should count the stopped notebooks and the resources reclaimed", func(ctx context.Context) {
+			notebook("fraud", "idle", longAgo, longAgo, nil)
+			notebook("fraud", "forgotten", longAgo, longAgo, nil)
+			stoppedBefore := testutil.ToFloat64(stoppedWorkloads.WithLabelValues(notebookKind))
+			cpuBefore := testutil.ToFloat64(reclaimedResources.WithLabelValues(notebookKind, "cpu"))
+			memoryBefore := testutil.ToFloat64(reclaimedResources.WithLabelValues(notebookKind, "memory"))
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			Expect(testutil.ToFloat64(stoppedWorkloads.WithLabelValues(notebookKind)) - stoppedBefore).To(Equal(2.0))
+			Expect(testutil.ToFloat64(reclaimedResources.WithLabelValues(notebookKind, "cpu")) - cpuBefore).To(Equal(4.0))
+			Expect(testutil.ToFloat64(reclaimedResources.WithLabelValues(notebookKind, "memory")) - memoryBefore).To(Equal(8.0 * (1 << 30)))
+		})
+
+		DescribeTable("should keep running the notebooks",
+			func(ctx context.Context, add func()) {
+				add()
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+				Expect(notebookStopped(ctx, "fraud", "notebook")).To(BeFalse())
+				Expect(recorder.Events).To(BeEmpty())
+			},
+			Entry("active recently", func() { notebook("fraud", "notebook", recently, longAgo, nil) }),
+			Entry("restarted recently", func() { notebook("fraud", "notebook", longAgo, recently, nil) }),
+			Entry("exempted", func() {
+				notebook("fraud", "notebook", longAgo, longAgo, map[string]string{labels.ODH.IdleReaperExempt: "true"})
+			}),
+			Entry("exempted by a label of the DSCInitialization", func() {
+				notebook("fraud", "notebook", longAgo, longAgo, map[string]string{"team.example.com/always-on": "yes"})
+			}),
+			Entry("without activity reported", func() {
+				objects = append(objects, workloadObject(gvk.Notebook, "fraud", "notebook", nil, nil),
+					runningPod("fraud", "notebook-0", map[string]string{"notebook-name": "notebook"}, longAgo))
+			}),
+			Entry("without running pod", func() {
+				notebook("fraud", "notebook", longAgo, longAgo, nil)
+				objects[len(objects)-1].(*corev1.Pod).Status.Phase = corev1.PodPending
+			}),
+		)
+
+		It("should stop the notebooks with exemption labels set to false", func(ctx context.Context) {
+			notebook("fraud", "idle", longAgo, longAgo, map[string]string{labels.ODH.IdleReaperExempt: "false"})
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			Expect(notebookStopped(ctx, "fraud", "idle")).To(BeTrue())
+		})
+
+		It("should not stop the notebooks again", func(ctx context.Context) {
+			objects = append(objects,
+				workloadObject(gvk.Notebook, "fraud", "stopped", map[string]string{
+					NotebookLastActivity: longAgo.UTC().Format(time.RFC3339), NotebookStopped: longAgo.UTC().Format(time.RFC3339),
+				}, nil),
+				runningPod("fraud", "stopped-0", map[string]string{"notebook-name": "stopped"}, longAgo))
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			Expect(saved(ctx, gvk.Notebook, "fraud", "stopped").GetAnnotations()).To(HaveKeyWithValue(NotebookStopped, longAgo.UTC().Format(time.RFC3339)))
+		})
+
+		DescribeTable("should only check the notebooks of data science projects",
+			func(ctx context.Context, namespaceLabels map[string]string) {
+				objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo", Labels: namespaceLabels}})
+				notebook("demo", "idle", longAgo, longAgo, nil)
+
+				Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+				Expect(notebookStopped(ctx, "demo", "idle")).To(BeFalse())
+			},
+			Entry("exempted project", map[string]string{labels.ODH.Dashboard: "true", labels.ODH.IdleReaperExempt: "true"}),
+			Entry("project exempted by a label of the DSCInitialization", map[string]string{labels.ODH.Dashboard: "true", "team.example.com/always-on": "true"}),
+			Entry("namespace not a data science project", map[string]string{}),
+		)
+
+		It("should not check notebooks when the notebook controller is not deployed", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Kind == gvk.Notebook.Kind+"List" {
+					return &meta.NoKindMatchError{GroupKind: gvk.Notebook.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		})
+
+		It("should report the notebooks failing to stop", func(ctx context.Context) {
+			notebook("fraud", "idle", longAgo, longAgo, nil)
+			notebook("fraud", "forgotten", longAgo, longAgo, nil)
+			funcs.Patch = func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if obj.GetName() == "idle" {
+					return errors.New("conflict")
+				}
+				return cli.Patch(ctx, obj, patch, opts...)
+			}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError("failed to stop Notebook fraud/idle: conflict"))
+			Expect(notebookStopped(ctx, "fraud", "forgotten")).To(BeTrue())
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal IdleWorkloadStopped")))
+			Expect(recorder.Events).To(Receive(Equal("Warning IdleReaperFailed Failed to stop idle workloads: failed to stop Notebook fraud/idle: conflict")))
+		})
+	})
+
+	Describe("model servers", func() {
+		BeforeEach(func() {
+			instance.Spec.IdleReaper.NotebookIdleTimeout = nil
+			inferenceService("unused", nil)
+		})
+
+		It("should stop the model servers without requests", func(ctx context.Context) {
+			inferenceService("scoring", nil)
+			requests = staticRequests{
+				{Namespace: "fraud", Name: "scoring-predictor-00001-deployment"}: 42,
+				{Namespace: "other", Name: "unused-predictor"}:                   42,
+			}
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			Expect(inferenceServiceStopped(ctx, "unused")).To(BeTrue())
+			Expect(inferenceServiceStopped(ctx, "scoring")).To(BeFalse())
+		})
+
+		It("should not stop the model servers without a way to measure their activity", func(ctx context.Context) {
+			requests = nil
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			Expect(inferenceServiceStopped(ctx, "unused")).To(BeFalse())
+		})
+
+		It("should report the activity failing to be measured", func(ctx context.Context) {
+			requests = failingRequests{}
+
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError("failed to measure the activity of model servers: monitoring unavailable"))
+			Expect(inferenceServiceStopped(ctx, "unused")).To(BeFalse())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning IdleReaperFailed")))
+		})
+	})
+
+	DescribeTable("should not stop workloads when the idle reaper is off",
+		func(ctx context.Context, spec *dsciv1.IdleReaperSpec) {
+			instance.Spec.IdleReaper = spec
+			notebook("fraud", "idle", longAgo, longAgo, nil)
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(notebookStopped(ctx, "fraud", "idle")).To(BeFalse())
+		},
+		Entry("not set", (*dsciv1.IdleReaperSpec)(nil)),
+		Entry("removed", &dsciv1.IdleReaperSpec{ManagementState: operatorv1.Removed, NotebookIdleTimeout: &metav1.Duration{Duration: time.Hour}}),
+	)
+
+	It("should not stop workloads without timeouts", func(ctx context.Context) {
+		instance.Spec.IdleReaper = &dsciv1.IdleReaperSpec{NotebookIdleTimeout: &metav1.Duration{}}
+		notebook("fraud", "idle", longAgo, longAgo, nil)
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(notebookStopped(ctx, "fraud", "idle")).To(BeFalse())
+	})
+
+	It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+
+	DescribeTable("should sum the requests of the running pods",
+		func(pods []corev1.Pod, cpu string, started time.Time) {
+			requests, lastStarted := runningRequests(pods)
+			Expect(requests.Cpu().Equal(resource.MustParse(cpu))).To(BeTrue(), requests.Cpu().String())
+			Expect(lastStarted).To(Equal(started))
+		},
+		Entry("no pod", []corev1.Pod{}, "0", time.Time{}),
+		Entry("running pods", []corev1.Pod{
+			*runningPod("fraud", "a", nil, time.Unix(100, 0)), *runningPod("fraud", "b", nil, time.Unix(200, 0)),
+		}, "4", time.Unix(200, 0)),
+		Entry("pods not running", []corev1.Pod{
+			*runningPod("fraud", "a", nil, time.Unix(100, 0)), {Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		}, "2", time.Unix(100, 0)),
+	)
+})
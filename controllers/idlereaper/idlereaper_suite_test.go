@@ -0,0 +1,13 @@
+package idlereaper
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIdleReaper(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Idle reaper suite")
+}
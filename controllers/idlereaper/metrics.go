@@ -0,0 +1,38 @@
+package idlereaper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// stoppedWorkloads backs the dashboards of workloads stopped once idle.
+	stoppedWorkloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "odh_idle_reaper_stopped_workloads_total",
+			Help: "Number of idle workloads of data science projects stopped by the operator.",
+		},
+		[]string{"kind"},
+	)
+	// reclaimedResources backs the dashboards of resources saved by stopping idle workloads.
+	reclaimedResources = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "odh_idle_reaper_reclaimed_resources_total",
+			Help: "Resources requested by the pods of the idle workloads stopped by the operator, in cores for cpu, bytes for memory and units otherwise.",
+		},
+		[]string{"kind", "resource"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(stoppedWorkloads, reclaimedResources)
+}
+
+// observe counts a stopped workload of the kind and the resources it requested.
+func observe(kind string, requests corev1.ResourceList) {
+	stoppedWorkloads.WithLabelValues(kind).Inc()
+	for name, quantity := range requests {
+		reclaimedResources.WithLabelValues(kind, string(name)).Add(quantity.AsApproximateFloat64())
+	}
+}
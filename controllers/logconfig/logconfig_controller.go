@@ -0,0 +1,83 @@
+// Package logconfig contains controller logic reloading operator's logging configuration from a ConfigMap at runtime.
+package logconfig
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/operatorconfig"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+)
+
+// LogConfigReconciler holds the controller configuration.
+type LogConfigReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LogConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	log := r.Log
+	log.Info("Adding controller for logging configuration.")
+
+	operatorNs, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		// Running outside the cluster, e.g. with `make run`
+		log.Info("operator namespace is not known, watching logging configuration in all namespaces", "name", logger.ConfigMapName)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("log-config-controller").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == logger.ConfigMapName && (operatorNs == "" || obj.GetNamespace() == operatorNs)
+		}))).
+		Complete(r)
+}
+
+// Reconcile applies logging configuration from the ConfigMap, or reverts to defaults when it has been removed.
+// The ConfigMap is ignored when logging is configured in the OperatorConfig.
+func (r *LogConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log
+
+	// Logging set in the OperatorConfig takes precedence over the ConfigMap
+	operatorConfig, err := operatorconfig.Get(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if operatorConfig != nil && operatorConfig.Spec.Logging != nil {
+		log.Info("Logging is configured in the OperatorConfig, ignoring ConfigMap", "name", req.Name, "namespace", req.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, req.NamespacedName, configMap); err != nil {
+		if k8serr.IsNotFound(err) {
+			log.Info("Logging configuration removed, reverting to defaults")
+			logger.ResetConfig()
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	cfg, err := logger.ParseConfig(configMap.Data)
+	if err != nil {
+		// Do not requeue, invalid configuration needs to be fixed by the user
+		log.Error(err, "Invalid logging configuration, keeping current one", "name", req.Name, "namespace", req.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	logger.ApplyConfig(cfg)
+	log.Info("Logging configuration applied", "level", cfg.Level.String(), "format", cfg.Format, "overrides", len(cfg.Components))
+
+	return ctrl.Result{}, nil
+}
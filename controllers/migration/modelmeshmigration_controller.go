@@ -0,0 +1,400 @@
+// Package migration contains controller logic migrating models served by ModelMesh to KServe, as ModelMesh
+// is deprecated.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/yaml"
+
+	migrationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/migration/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ConditionMigrationPlanned tells whether all models of the selected projects have been inventoried.
+	ConditionMigrationPlanned conditionsv1.ConditionType = "MigrationPlanned"
+
+	// PlanConfigMap is the name of the ConfigMap of data science projects holding the KServe InferenceServices
+	// generated for their ModelMesh models, one per key. It is applied on cutover.
+	PlanConfigMap = "modelmesh-migration-plan"
+
+	// inventoryResync is how often models are inventoried again, as InferenceServices are not watched.
+	inventoryResync = 10 * time.Minute
+	// cutoverRequeue is how often projects being cut over are checked again, while ModelMesh InferenceServices
+	// are deleted.
+	cutoverRequeue = 30 * time.Second
+)
+
+// +kubebuilder:rbac:groups="migration.opendatahub.io",resources=modelmeshmigrations,verbs=get;list;watch
+// +kubebuilder:rbac:groups="migration.opendatahub.io",resources=modelmeshmigrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="migration.opendatahub.io",resources=modelmeshmigrations/finalizers,verbs=update
+
+// ModelMeshMigrationReconciler holds the controller configuration.
+type ModelMeshMigrationReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads resources of data science projects, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ModelMeshMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for ModelMesh migrations.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("modelmesh-migration-controller").
+		For(&migrationv1alpha1.ModelMeshMigration{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile inventories the ModelMesh models of the selected projects and generates their migration plan. On cutover,
+// projects whose models are all compatible get their ModelMesh InferenceServices replaced by the planned ones.
+func (r *ModelMeshMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("modelmeshmigration", req.Name)
+
+	instance := &migrationv1alpha1.ModelMeshMigration{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		// migration plans are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, err := r.selectedNamespaces(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var errs []error
+	inProgress := false
+	results := make([]migrationv1alpha1.NamespaceMigration, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		result, pending, err := r.migrate(ctx, instance, namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to migrate models of %s: %w", namespace, err))
+			continue
+		}
+		inProgress = inProgress || pending
+		results = append(results, result)
+	}
+
+	condition := conditionsv1.Condition{
+		Type:    ConditionMigrationPlanned,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: fmt.Sprintf("Models of %d data science projects inventoried", len(results)),
+	}
+	phase := status.PhaseReady
+	if inProgress {
+		phase = status.PhaseProgressing
+	}
+	reconcileErr := errors.Join(errs...)
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "Failed to migrate models")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "MigrationFailed", "Failed to migrate models: %v", reconcileErr)
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "MigrationFailed"
+		condition.Message = reconcileErr.Error()
+		phase = status.PhaseError
+	}
+
+	if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *migrationv1alpha1.ModelMeshMigration) {
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, condition)
+		saved.Status.Namespaces = results
+		saved.Status.Phase = phase
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+	if inProgress {
+		return ctrl.Result{RequeueAfter: cutoverRequeue}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: inventoryResync}, nil
+}
+
+// selectedNamespaces returns the sorted names of the projects to migrate, including projects already cut over
+// so that they stay reported.
+func (r *ModelMeshMigrationReconciler) selectedNamespaces(ctx context.Context, instance *migrationv1alpha1.ModelMeshMigration) ([]string, error) {
+	selected := map[string]struct{}{}
+	if len(instance.Spec.Namespaces) > 0 {
+		for _, namespace := range instance.Spec.Namespaces {
+			selected[namespace] = struct{}{}
+		}
+	} else {
+		projects := &corev1.NamespaceList{}
+		if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ModelMeshEnabled: "true"}); err != nil {
+			return nil, fmt.Errorf("failed to list projects serving models with ModelMesh: %w", err)
+		}
+		for _, project := range projects.Items {
+			selected[project.Name] = struct{}{}
+		}
+		for _, previous := range instance.Status.Namespaces {
+			if previous.Migrated {
+				selected[previous.Name] = struct{}{}
+			}
+		}
+	}
+
+	namespaces := make([]string, 0, len(selected))
+	for namespace := range selected {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// migrate inventories the models of the namespace and updates its migration plan, then cuts it over when requested.
+// It returns whether the cutover is still in progress.
+func (r *ModelMeshMigrationReconciler) migrate(ctx context.Context, instance *migrationv1alpha1.ModelMeshMigration,
+	namespace string) (migrationv1alpha1.NamespaceMigration, bool, error) {
+	result := migrationv1alpha1.NamespaceMigration{Name: namespace}
+
+	project := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: namespace}, project); err != nil {
+		return result, false, err
+	}
+
+	isvcs := &unstructured.UnstructuredList{}
+	isvcs.SetGroupVersionKind(gvk.InferenceService.GroupVersion().WithKind(gvk.InferenceService.Kind + "List"))
+	if err := r.APIReader.List(ctx, isvcs, client.InNamespace(namespace)); err != nil {
+		return result, false, fmt.Errorf("failed to list InferenceServices: %w", err)
+	}
+
+	var modelMesh []*unstructured.Unstructured
+	planned := map[string]string{}
+	compatible := true
+	for i := range isvcs.Items {
+		isvc := &isvcs.Items[i]
+		if isvc.GetAnnotations()[annotations.DeploymentMode] != annotations.ModelMeshDeploymentMode {
+			continue
+		}
+		modelMesh = append(modelMesh, isvc)
+
+		model := migrationv1alpha1.ModelMigration{Name: isvc.GetName(), Phase: migrationv1alpha1.ModelCompatible}
+		model.Runtime, _, _ = unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "runtime")
+		converted, reason := convertInferenceService(isvc, &instance.Spec)
+		if reason != "" {
+			model.Phase = migrationv1alpha1.ModelIncompatible
+			model.Message = reason
+			compatible = false
+		} else {
+			manifest, err := yaml.Marshal(converted.Object)
+			if err != nil {
+				return result, false, err
+			}
+			planned[isvc.GetName()+".yaml"] = string(manifest)
+		}
+		result.Models = append(result.Models, model)
+	}
+
+	// once ModelMesh InferenceServices are deleted, the plan is kept to be applied
+	if len(modelMesh) > 0 {
+		if err := r.applyPlan(ctx, instance, namespace, planned); err != nil {
+			return result, false, err
+		}
+	}
+
+	plan := &corev1.ConfigMap{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: PlanConfigMap, Namespace: namespace}, plan); client.IgnoreNotFound(err) != nil {
+		return result, false, err
+	} else if err == nil {
+		result.Plan = plan.Name
+	}
+
+	if project.GetLabels()[labels.ModelMeshEnabled] != "true" {
+		// projects cut over are switched to KServe, others do not serve models with ModelMesh
+		result.Migrated = project.GetLabels()[labels.ModelMeshEnabled] == "false" && result.Plan != ""
+		if result.Migrated {
+			result.Models = migratedModels(plan.Data, isvcs.Items)
+		}
+		return result, false, nil
+	}
+	if !instance.Spec.Cutover || !compatible {
+		return result, false, nil
+	}
+
+	// ModelMesh and KServe InferenceServices cannot share names, ModelMesh ones are deleted first
+	if len(modelMesh) > 0 {
+		for _, isvc := range modelMesh {
+			if isvc.GetDeletionTimestamp() != nil {
+				continue
+			}
+			r.Log.Info("Deleting ModelMesh InferenceService", "namespace", namespace, "name", isvc.GetName())
+			if err := r.Client.Delete(ctx, isvc); client.IgnoreNotFound(err) != nil {
+				return result, false, err
+			}
+		}
+		return result, true, nil
+	}
+
+	if err := r.cutover(ctx, plan); err != nil {
+		return result, false, err
+	}
+	project.Labels[labels.ModelMeshEnabled] = "false"
+	if err := r.Client.Update(ctx, project); err != nil {
+		return result, false, fmt.Errorf("failed to switch project to KServe: %w", err)
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "ProjectMigrated", "Models of %s are served by KServe", namespace)
+
+	result.Models = migratedModels(plan.Data, nil)
+	result.Migrated = true
+
+	return result, false, nil
+}
+
+// applyPlan creates or updates the ConfigMap holding the migration plan of the namespace.
+func (r *ModelMeshMigrationReconciler) applyPlan(ctx context.Context, instance *migrationv1alpha1.ModelMeshMigration,
+	namespace string, planned map[string]string) error {
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PlanConfigMap,
+			Namespace: namespace,
+			Labels:    map[string]string{annotations.ManagedByODHOperator: "true"},
+		},
+		Data: planned,
+	}
+	if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	existing := &corev1.ConfigMap{}
+	err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case k8serr.IsNotFound(err):
+		return r.Client.Create(ctx, desired)
+	case err != nil:
+		return err
+	}
+	if reflect.DeepEqual(existing.Data, desired.Data) {
+		return nil
+	}
+	existing.Data = desired.Data
+
+	return r.Client.Update(ctx, existing)
+}
+
+// cutover creates the InferenceServices of the migration plan which do not exist yet.
+func (r *ModelMeshMigrationReconciler) cutover(ctx context.Context, plan *corev1.ConfigMap) error {
+	var errs []error
+	for key, manifest := range plan.Data {
+		isvc := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(manifest), &isvc.Object); err != nil {
+			errs = append(errs, fmt.Errorf("invalid plan entry %s: %w", key, err))
+			continue
+		}
+		r.Log.Info("Creating KServe InferenceService", "namespace", isvc.GetNamespace(), "name", isvc.GetName())
+		if err := r.Client.Create(ctx, isvc); err != nil && !k8serr.IsAlreadyExists(err) {
+			errs = append(errs, fmt.Errorf("failed to create InferenceService %s: %w", isvc.GetName(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// migratedModels returns the models of the migration plan, as migrated. When InferenceServices are given, only
+// planned models which exist are returned.
+func migratedModels(plan map[string]string, isvcs []unstructured.Unstructured) []migrationv1alpha1.ModelMigration {
+	var existing map[string]struct{}
+	if isvcs != nil {
+		existing = make(map[string]struct{}, len(isvcs))
+		for _, isvc := range isvcs {
+			existing[isvc.GetName()] = struct{}{}
+		}
+	}
+
+	var models []migrationv1alpha1.ModelMigration
+	for _, manifest := range plan {
+		isvc := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(manifest), &isvc.Object); err != nil {
+			continue
+		}
+		if _, exists := existing[isvc.GetName()]; existing != nil && !exists {
+			continue
+		}
+		model := migrationv1alpha1.ModelMigration{Name: isvc.GetName(), Phase: migrationv1alpha1.ModelMigrated}
+		model.Runtime, _, _ = unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "runtime")
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+
+	return models
+}
+
+// convertInferenceService returns the KServe InferenceService equivalent to the ModelMesh one, or why there is none.
+func convertInferenceService(isvc *unstructured.Unstructured, spec *migrationv1alpha1.ModelMeshMigrationSpec) (*unstructured.Unstructured, string) {
+	predictor, _, err := unstructured.NestedMap(isvc.Object, "spec", "predictor")
+	if err != nil {
+		return nil, err.Error()
+	}
+	model, _ := predictor["model"].(map[string]interface{})
+	if model == nil {
+		return nil, "predictor declares no model format, only predictors of the model kind can be migrated"
+	}
+
+	if runtime, _ := model["runtime"].(string); runtime != "" {
+		mapped := ""
+		for _, mapping := range spec.RuntimeMappings {
+			if mapping.ModelMeshRuntime == runtime {
+				mapped = mapping.KServeRuntime
+				break
+			}
+		}
+		if mapped == "" {
+			return nil, fmt.Sprintf("no KServe runtime mapped to ServingRuntime %s", runtime)
+		}
+		model["runtime"] = mapped
+	}
+
+	deploymentMode := spec.DeploymentMode
+	if deploymentMode == "" {
+		deploymentMode = migrationv1alpha1.Serverless
+	}
+	isvcAnnotations := map[string]string{}
+	for key, value := range isvc.GetAnnotations() {
+		if key != corev1.LastAppliedConfigAnnotation {
+			isvcAnnotations[key] = value
+		}
+	}
+	isvcAnnotations[annotations.DeploymentMode] = string(deploymentMode)
+
+	converted := &unstructured.Unstructured{}
+	converted.SetGroupVersionKind(gvk.InferenceService)
+	converted.SetName(isvc.GetName())
+	converted.SetNamespace(isvc.GetNamespace())
+	converted.SetLabels(isvc.GetLabels())
+	converted.SetAnnotations(isvcAnnotations)
+	if err := unstructured.SetNestedMap(converted.Object, predictor, "spec", "predictor"); err != nil {
+		return nil, err.Error()
+	}
+
+	return converted, ""
+}
@@ -0,0 +1,299 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	migrationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/migration/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func modelMeshInferenceService(name string, predictor map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1beta1",
+		"kind":       "InferenceService",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "fraud-detection",
+			"labels":    map[string]interface{}{"opendatahub.io/dashboard": "true"},
+			"annotations": map[string]interface{}{
+				annotations.DeploymentMode:  annotations.ModelMeshDeploymentMode,
+				"openshift.io/display-name": "Fraud detection",
+			},
+		},
+		"spec": map[string]interface{}{"predictor": predictor},
+	}}
+}
+
+func ovmsPredictor() map[string]interface{} {
+	return map[string]interface{}{
+		"model": map[string]interface{}{
+			"modelFormat": map[string]interface{}{"name": "onnx"},
+			"runtime":     "ovms",
+			"storage":     map[string]interface{}{"key": "aws-connection-models", "path": "fraud/1"},
+		},
+	}
+}
+
+var _ = Describe("ModelMesh InferenceService conversion", func() {
+	var spec *migrationv1alpha1.ModelMeshMigrationSpec
+
+	BeforeEach(func() {
+		spec = &migrationv1alpha1.ModelMeshMigrationSpec{
+			DeploymentMode:  migrationv1alpha1.RawDeployment,
+			RuntimeMappings: []migrationv1alpha1.RuntimeMapping{{ModelMeshRuntime: "ovms", KServeRuntime: "kserve-ovms"}},
+		}
+	})
+
+	It("should map the runtime and keep the model and metadata", func() {
+		isvc := modelMeshInferenceService("fraud", ovmsPredictor())
+
+		converted, reason := convertInferenceService(isvc, spec)
+		Expect(reason).To(BeEmpty())
+
+		Expect(converted.GetAnnotations()).To(Equal(map[string]string{
+			annotations.DeploymentMode:  "RawDeployment",
+			"openshift.io/display-name": "Fraud detection",
+		}))
+		Expect(converted.GetLabels()).To(Equal(map[string]string{"opendatahub.io/dashboard": "true"}))
+		Expect(converted.Object["spec"]).To(Equal(map[string]interface{}{
+			"predictor": map[string]interface{}{
+				"model": map[string]interface{}{
+					"modelFormat": map[string]interface{}{"name": "onnx"},
+					"runtime":     "kserve-ovms",
+					"storage":     map[string]interface{}{"key": "aws-connection-models", "path": "fraud/1"},
+				},
+			},
+		}))
+	})
+
+	It("should leave the ModelMesh InferenceService untouched", func() {
+		isvc := modelMeshInferenceService("fraud", ovmsPredictor())
+
+		convertInferenceService(isvc, spec)
+		Expect(isvc.GetAnnotations()).To(HaveKeyWithValue(annotations.DeploymentMode, annotations.ModelMeshDeploymentMode))
+		Expect(isvc.Object["spec"]).To(Equal(map[string]interface{}{"predictor": ovmsPredictor()}))
+	})
+
+	It("should default to serverless and drop the last applied configuration", func() {
+		spec.DeploymentMode = ""
+		isvc := modelMeshInferenceService("fraud", ovmsPredictor())
+		isvc.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}"})
+
+		converted, reason := convertInferenceService(isvc, spec)
+		Expect(reason).To(BeEmpty())
+		Expect(converted.GetAnnotations()).To(Equal(map[string]string{annotations.DeploymentMode: "Serverless"}))
+	})
+
+	DescribeTable("should report incompatible models",
+		func(predictor map[string]interface{}, reason string) {
+			converted, why := convertInferenceService(modelMeshInferenceService("model", predictor), spec)
+
+			Expect(converted).To(BeNil())
+			Expect(why).To(Equal(reason))
+		},
+		Entry("with a runtime not mapped", map[string]interface{}{
+			"model": map[string]interface{}{"modelFormat": map[string]interface{}{"name": "sklearn"}, "runtime": "mlserver"},
+		}, "no KServe runtime mapped to ServingRuntime mlserver"),
+		Entry("with a legacy predictor", map[string]interface{}{
+			"sklearn": map[string]interface{}{"storageUri": "s3://models/sklearn"},
+		}, "predictor declares no model format, only predictors of the model kind can be migrated"),
+	)
+})
+
+var _ = Describe("Migrated models", func() {
+	plan := map[string]string{
+		"fraud.yaml":   "metadata:\n  name: fraud\nspec:\n  predictor:\n    model:\n      runtime: kserve-ovms\n",
+		"churn.yaml":   "metadata:\n  name: churn\n",
+		"invalid.yaml": "metadata: [",
+	}
+
+	It("should only be the planned models which exist", func() {
+		Expect(migratedModels(plan, []unstructured.Unstructured{*modelMeshInferenceService("fraud", nil)})).To(Equal([]migrationv1alpha1.ModelMigration{
+			{Name: "fraud", Runtime: "kserve-ovms", Phase: migrationv1alpha1.ModelMigrated},
+		}))
+	})
+
+	It("should be all planned models sorted by name when InferenceServices are not known", func() {
+		Expect(migratedModels(plan, nil)).To(Equal([]migrationv1alpha1.ModelMigration{
+			{Name: "churn", Phase: migrationv1alpha1.ModelMigrated},
+			{Name: "fraud", Runtime: "kserve-ovms", Phase: migrationv1alpha1.ModelMigrated},
+		}))
+	})
+})
+
+var _ = Describe("ModelMesh migration controller", func() {
+	var (
+		instance *migrationv1alpha1.ModelMeshMigration
+		objects  []client.Object
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "migration"}}
+	)
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(migrationv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, instance)...).
+				WithStatusSubresource(instance).Build()
+		}
+		r := &ModelMeshMigrationReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, req)
+	}
+	saved := func(ctx context.Context) *migrationv1alpha1.ModelMeshMigration {
+		GinkgoHelper()
+		saved := &migrationv1alpha1.ModelMeshMigration{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		return saved
+	}
+	inferenceServices := func(ctx context.Context) map[string]string {
+		GinkgoHelper()
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.InferenceService)
+		Expect(cli.List(ctx, list, client.InNamespace("fraud-detection"))).To(Succeed())
+		modes := map[string]string{}
+		for _, isvc := range list.Items {
+			modes[isvc.GetName()] = isvc.GetAnnotations()[annotations.DeploymentMode]
+		}
+		return modes
+	}
+	projectLabel := func(ctx context.Context) string {
+		GinkgoHelper()
+		project := &corev1.Namespace{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "fraud-detection"}, project)).To(Succeed())
+		return project.Labels[labels.ModelMeshEnabled]
+	}
+
+	BeforeEach(func() {
+		instance = &migrationv1alpha1.ModelMeshMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "migration", UID: "uid"},
+			Spec: migrationv1alpha1.ModelMeshMigrationSpec{
+				RuntimeMappings: []migrationv1alpha1.RuntimeMapping{{ModelMeshRuntime: "ovms", KServeRuntime: "kserve-ovms"}},
+			},
+		}
+		kserve := modelMeshInferenceService("kserve", ovmsPredictor())
+		kserve.SetAnnotations(map[string]string{annotations.DeploymentMode: "Serverless"})
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fraud-detection", Labels: map[string]string{labels.ModelMeshEnabled: "true"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			modelMeshInferenceService("fraud", ovmsPredictor()),
+			kserve,
+		}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should plan the migration of the ModelMesh models of projects", func(ctx context.Context) {
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(inventoryResync))
+
+		plan := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: PlanConfigMap, Namespace: "fraud-detection"}, plan)).To(Succeed())
+		Expect(plan.Data).To(HaveKey("fraud.yaml"))
+		Expect(plan.Data).To(HaveLen(1))
+		Expect(plan.OwnerReferences).To(ConsistOf(HaveField("Name", "migration")))
+
+		migration := saved(ctx)
+		Expect(migration.Status.Phase).To(Equal(status.PhaseReady))
+		Expect(conditionsv1.IsStatusConditionTrue(migration.Status.Conditions, ConditionMigrationPlanned)).To(BeTrue())
+		Expect(migration.Status.Namespaces).To(Equal([]migrationv1alpha1.NamespaceMigration{{
+			Name:   "fraud-detection",
+			Plan:   PlanConfigMap,
+			Models: []migrationv1alpha1.ModelMigration{{Name: "fraud", Runtime: "ovms", Phase: migrationv1alpha1.ModelCompatible}},
+		}}))
+		Expect(inferenceServices(ctx)).To(HaveKeyWithValue("fraud", annotations.ModelMeshDeploymentMode))
+	})
+
+	It("should cut projects over to KServe once requested", func(ctx context.Context) {
+		instance.Spec.Cutover = true
+
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(cutoverRequeue))
+		Expect(saved(ctx).Status.Phase).To(Equal(status.PhaseProgressing))
+		Expect(inferenceServices(ctx)).ToNot(HaveKey("fraud"))
+
+		result, err = reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(inventoryResync))
+		Expect(inferenceServices(ctx)).To(HaveKeyWithValue("fraud", "Serverless"))
+		Expect(projectLabel(ctx)).To(Equal("false"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ProjectMigrated")))
+		Expect(saved(ctx).Status.Namespaces).To(Equal([]migrationv1alpha1.NamespaceMigration{{
+			Name:     "fraud-detection",
+			Plan:     PlanConfigMap,
+			Models:   []migrationv1alpha1.ModelMigration{{Name: "fraud", Runtime: "kserve-ovms", Phase: migrationv1alpha1.ModelMigrated}},
+			Migrated: true,
+		}}))
+
+		By("keeping projects cut over reported")
+		_, err = reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(saved(ctx).Status.Namespaces).To(ConsistOf(HaveField("Migrated", true)))
+	})
+
+	When("a model of the project is incompatible", func() {
+		BeforeEach(func() {
+			objects = append(objects, modelMeshInferenceService("churn", map[string]interface{}{
+				"model": map[string]interface{}{"modelFormat": map[string]interface{}{"name": "sklearn"}, "runtime": "mlserver"},
+			}))
+			instance.Spec.Cutover = true
+		})
+
+		It("should not cut the project over", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(inferenceServices(ctx)).To(HaveKeyWithValue("fraud", annotations.ModelMeshDeploymentMode))
+			Expect(projectLabel(ctx)).To(Equal("true"))
+			Expect(saved(ctx).Status.Namespaces[0].Models).To(ContainElement(migrationv1alpha1.ModelMigration{
+				Name: "churn", Runtime: "mlserver", Phase: migrationv1alpha1.ModelIncompatible, Message: "no KServe runtime mapped to ServingRuntime mlserver",
+			}))
+		})
+	})
+
+	When("a selected project does not exist", func() {
+		BeforeEach(func() {
+			instance.Spec.Namespaces = []string{"fraud-detection", "missing"}
+		})
+
+		It("should report the error and still migrate the other projects", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("failed to migrate models of missing")))
+
+			migration := saved(ctx)
+			Expect(migration.Status.Phase).To(Equal(status.PhaseError))
+			Expect(conditionsv1.IsStatusConditionFalse(migration.Status.Conditions, ConditionMigrationPlanned)).To(BeTrue())
+			Expect(migration.Status.Namespaces).To(ConsistOf(HaveField("Name", "fraud-detection")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("MigrationFailed")))
+		})
+	})
+
+	It("should ignore a deleted ModelMeshMigration", func(ctx context.Context) {
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+})
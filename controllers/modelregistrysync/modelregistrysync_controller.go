@@ -0,0 +1,218 @@
+// Package modelregistrysync contains controller logic publishing the connection details of the available model
+// registries into the dashboard config, so that catalogs of the UI stay consistent with the deployed registries.
+package modelregistrysync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelregistry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+)
+
+const (
+	// ModelRegistries are not watched, as their CRD only exists while the component is enabled
+	syncInterval = time.Minute
+	// registriesField is the field of the OdhDashboardConfig spec the registries are published to
+	registriesField = "modelRegistries"
+
+	defaultRESTPort = 8080
+	defaultGRPCPort = 9090
+)
+
+// Registry holds the connection details of a model registry published to the dashboard config.
+type Registry struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// RESTURL is the in-cluster URL of the REST API.
+	RESTURL string `json:"restURL"`
+	// GRPCURL is the in-cluster address of the gRPC API.
+	GRPCURL string `json:"grpcURL"`
+	// Hosts are the endpoints of the registry exposed by the Istio gateway, if any.
+	Hosts []string `json:"hosts,omitempty"`
+	// AuthRequired tells whether requests to the endpoints need a token, which is the case when served with Istio.
+	AuthRequired bool `json:"authRequired"`
+}
+
+// ModelRegistrySyncReconciler holds the controller configuration.
+type ModelRegistrySyncReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ModelRegistrySyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for syncing model registries to the dashboard.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("model-registry-sync-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile publishes the available model registries into the dashboard config while ModelRegistry is Managed,
+// and removes them once it is not. It requeues to pick up registries created or changed in the meantime.
+func (r *ModelRegistrySyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	if err := instance.ApplyProfile(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	component := instance.Spec.Components.ModelRegistry
+	enabled := featuregate.Enabled(featuregate.ModelRegistryDashboardSync) && component.GetManagementState() == operatorv1.Managed
+
+	var registries []Registry
+	if enabled {
+		registriesNamespace := component.RegistriesNamespace
+		if registriesNamespace == "" {
+			registriesNamespace = modelregistry.DefaultModelRegistriesNamespace
+		}
+		var err error
+		if registries, err = r.registries(ctx, registriesNamespace); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.publish(ctx, namespace, registries); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !enabled {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: syncInterval}, nil
+}
+
+// registries returns, sorted by name, the model registries of the namespace the model registry operator reports
+// as available.
+func (r *ModelRegistrySyncReconciler) registries(ctx context.Context, namespace string) ([]Registry, error) {
+	objs := &unstructured.UnstructuredList{}
+	objs.SetGroupVersionKind(gvk.ModelRegistry)
+	if err := r.Client.List(ctx, objs, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list model registries: %w", err)
+	}
+
+	var registries []Registry
+	for i := range objs.Items {
+		obj := &objs.Items[i]
+		if !available(obj) {
+			continue
+		}
+		registries = append(registries, connectionDetails(obj))
+	}
+	sort.Slice(registries, func(i, j int) bool { return registries[i].Name < registries[j].Name })
+
+	return registries, nil
+}
+
+// available tells whether the Available condition of the model registry is True.
+func available(obj *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if ok && condition["type"] == "Available" {
+			return condition["status"] == "True"
+		}
+	}
+
+	return false
+}
+
+func connectionDetails(obj *unstructured.Unstructured) Registry {
+	restPort, found, _ := unstructured.NestedInt64(obj.Object, "spec", "rest", "port")
+	if !found {
+		restPort = defaultRESTPort
+	}
+	grpcPort, found, _ := unstructured.NestedInt64(obj.Object, "spec", "grpc", "port")
+	if !found {
+		grpcPort = defaultGRPCPort
+	}
+	hosts, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "hosts")
+	_, withIstio, _ := unstructured.NestedMap(obj.Object, "spec", "istio")
+
+	service := fmt.Sprintf("%s.%s.svc.cluster.local", obj.GetName(), obj.GetNamespace())
+
+	return Registry{
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		RESTURL:      fmt.Sprintf("http://%s:%d", service, restPort),
+		GRPCURL:      fmt.Sprintf("%s:%d", service, grpcPort),
+		Hosts:        hosts,
+		AuthRequired: withIstio,
+	}
+}
+
+// publish sets the registries in the dashboard config, removing the field when there are none.
+// Nothing is published when the dashboard is not deployed.
+func (r *ModelRegistrySyncReconciler) publish(ctx context.Context, namespace string, registries []Registry) error {
+	dashboardConfig := &unstructured.Unstructured{}
+	dashboardConfig.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dashboard.ConfigName}, dashboardConfig); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	var desired any
+	if len(registries) > 0 {
+		// round-tripped, to compare with the decoded content of the dashboard config
+		data, err := json.Marshal(registries)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &desired); err != nil {
+			return err
+		}
+	}
+	existing, _, _ := unstructured.NestedFieldNoCopy(dashboardConfig.Object, "spec", registriesField)
+	if reflect.DeepEqual(existing, desired) {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{registriesField: desired}})
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, dashboardConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to publish model registries to the dashboard config: %w", err)
+	}
+	r.Log.Info("published model registries to the dashboard config", "registries", len(registries))
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package modelregistrysync
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func modelRegistry(name string, namespace string, status string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": spec,
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Available", "status": status}},
+			"hosts":      []any{name + "-rest.apps.example.com"},
+		},
+	}}
+	obj.SetGroupVersionKind(gvk.ModelRegistry)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+func dashboardConfig(namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"dashboardConfig": map[string]any{}}}}
+	obj.SetGroupVersionKind(gvk.OdhDashboardConfig)
+	obj.SetName(dashboard.ConfigName)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+var _ = Describe("Model registry sync controller", func() {
+	var (
+		dsc     *dscv1.DataScienceCluster
+		objects []client.Object
+		funcs   interceptor.Funcs
+		cli     client.Client
+		req     = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsc"}}
+	)
+
+	reconciler := func() *ModelRegistrySyncReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return &ModelRegistrySyncReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	}
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		return reconciler().Reconcile(ctx, req)
+	}
+	published := func(ctx context.Context) []any {
+		GinkgoHelper()
+		config := dashboardConfig("opendatahub")
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+		Expect(config.Object).To(HaveKeyWithValue("spec", HaveKey("dashboardConfig")))
+		registries, _, err := unstructured.NestedSlice(config.Object, "spec", registriesField)
+		Expect(err).ToNot(HaveOccurred())
+		return registries
+	}
+	registryNamed := func(name string) OmegaMatcher {
+		return HaveKeyWithValue("name", name)
+	}
+
+	BeforeEach(func() {
+		dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		dsc.Spec.Components.ModelRegistry.ManagementState = operatorv1.Managed
+		dsci := &dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}
+		dsci.Spec.ApplicationsNamespace = "opendatahub"
+		objects = []client.Object{
+			dsc,
+			dsci,
+			dashboardConfig("opendatahub"),
+			modelRegistry("sales", "odh-model-registries", "True", map[string]any{"rest": map[string]any{"port": int64(8443)}, "istio": map[string]any{}}),
+			modelRegistry("finance", "odh-model-registries", "True", map[string]any{}),
+			modelRegistry("pending", "odh-model-registries", "False", map[string]any{}),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should list the connection details of the available registries by name", func(ctx context.Context) {
+		Expect(reconciler().registries(ctx, "odh-model-registries")).To(Equal([]Registry{
+			{
+				Name:      "finance",
+				Namespace: "odh-model-registries",
+				RESTURL:   "http://finance.odh-model-registries.svc.cluster.local:8080",
+				GRPCURL:   "finance.odh-model-registries.svc.cluster.local:9090",
+				Hosts:     []string{"finance-rest.apps.example.com"},
+			},
+			{
+				Name:         "sales",
+				Namespace:    "odh-model-registries",
+				RESTURL:      "http://sales.odh-model-registries.svc.cluster.local:8443",
+				GRPCURL:      "sales.odh-model-registries.svc.cluster.local:9090",
+				Hosts:        []string{"sales-rest.apps.example.com"},
+				AuthRequired: true,
+			},
+		}))
+	})
+
+	It("should publish the available registries and check them again later on", func(ctx context.Context) {
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: syncInterval}))
+
+		Expect(published(ctx)).To(HaveExactElements(registryNamed("finance"), registryNamed("sales")))
+	})
+
+	It("should publish the registries of the configured namespace", func(ctx context.Context) {
+		dsc.Spec.Components.ModelRegistry.RegistriesNamespace = "team-registries"
+		objects = append(objects, modelRegistry("research", "team-registries", "True", map[string]any{}))
+
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(published(ctx)).To(HaveExactElements(registryNamed("research")))
+	})
+
+	It("should publish nothing while the ModelRegistry CRD is missing", func(ctx context.Context) {
+		funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if list.GetObjectKind().GroupVersionKind().Group == gvk.ModelRegistry.Group {
+				return &meta.NoKindMatchError{GroupKind: gvk.ModelRegistry.GroupKind()}
+			}
+			return cli.List(ctx, list, opts...)
+		}
+
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(published(ctx)).To(BeEmpty())
+	})
+
+	It("should not fail while the dashboard is not deployed", func(ctx context.Context) {
+		objects = objects[:2]
+
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	When("registries are published", func() {
+		BeforeEach(func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(published(ctx)).To(HaveLen(2))
+		})
+
+		It("should remove registries which are not available anymore", func(ctx context.Context) {
+			Expect(cli.Delete(ctx, modelRegistry("sales", "odh-model-registries", "True", nil))).To(Succeed())
+
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(published(ctx)).To(HaveExactElements(registryNamed("finance")))
+		})
+
+		It("should remove them once ModelRegistry is Removed", func(ctx context.Context) {
+			Expect(cli.Get(ctx, req.NamespacedName, dsc)).To(Succeed())
+			dsc.Spec.Components.ModelRegistry.ManagementState = operatorv1.Removed
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+
+			result, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			Expect(published(ctx)).To(BeEmpty())
+		})
+
+		It("should remove them once the feature gate is disabled", func(ctx context.Context) {
+			Expect(featuregate.Set(map[string]bool{featuregate.ModelRegistryDashboardSync: false})).To(Succeed())
+			DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(published(ctx)).To(BeEmpty())
+		})
+	})
+
+	It("should do nothing once the DataScienceCluster is deleted", func(ctx context.Context) {
+		objects = objects[1:]
+
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(published(ctx)).To(BeEmpty())
+	})
+})
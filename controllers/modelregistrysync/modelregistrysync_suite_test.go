@@ -0,0 +1,13 @@
+package modelregistrysync
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestModelRegistrySync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Model registry sync suite")
+}
@@ -0,0 +1,298 @@
+// Package oauthclient contains controller logic managing the OpenShift OAuthClients of components whose UI
+// authenticates users with OpenShift SSO, and rotating the secrets of the clients.
+package oauthclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/secretgenerator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	secretKey    = "secret"
+	secretLength = 32
+	// previous secret stays valid for the grace period after a rotation, while pods are rolled out with the new one
+	rotationGracePeriod = time.Hour
+)
+
+// OAuthClientReconciler holds the controller configuration.
+type OAuthClientReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// RotationInterval is the age after which secrets of OAuthClients are rotated, never when zero.
+	RotationInterval time.Duration
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OAuthClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for component OAuthClients.")
+
+	ownedByDSC := handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &dscv1.DataScienceCluster{})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("oauth-client-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&oauthv1.OAuthClient{}, ownedByDSC).
+		Watches(&corev1.Secret{}, ownedByDSC).
+		Complete(r)
+}
+
+// Reconcile applies the OAuthClients of the Managed components, and deletes the ones of the Removed components.
+// It requeues for the next rotation of the secrets.
+func (r *OAuthClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	namespace, err := cluster.GetApplicationsNamespace(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := instance.ApplyProfile(); err != nil {
+		return ctrl.Result{}, err
+	}
+	allComponents, err := instance.GetComponents()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var domain string
+	var requeueAfter time.Duration
+	now := time.Now()
+	for _, component := range allComponents {
+		provider, ok := component.(components.OAuthClientProvider)
+		if !ok {
+			continue
+		}
+		for _, oauthClient := range provider.OAuthClients(cluster.GetRelease().Name) {
+			if component.GetManagementState() != operatorv1.Managed {
+				if err := r.delete(ctx, namespace, oauthClient.Name); err != nil {
+					return ctrl.Result{}, err
+				}
+				continue
+			}
+
+			if domain == "" {
+				if domain, err = cluster.GetDomain(ctx, r.Client); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			next, err := r.apply(ctx, instance, namespace, domain, oauthClient, now)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to apply OAuthClient %s: %w", oauthClient.Name, err)
+			}
+			if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+				requeueAfter = next
+			}
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// apply creates or rotates the secret of the OAuthClient, then creates or updates the OAuthClient.
+// It returns how long to wait before the OAuthClient needs to be reconciled again, zero when never.
+func (r *OAuthClientReconciler) apply(ctx context.Context, instance *dscv1.DataScienceCluster, namespace, domain string,
+	oauthClient components.OAuthClient, now time.Time,
+) (time.Duration, error) {
+	redirectURIs, err := renderRedirectURIs(oauthClient.RedirectURIs, namespace, domain)
+	if err != nil {
+		return 0, err
+	}
+
+	secret := &corev1.Secret{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: oauthClient.Name + "-generated", Namespace: namespace}, secret)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return 0, err
+	}
+	exists := err == nil
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: oauthClient.Name + "-generated", Namespace: namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+	}
+
+	previous, changed, err := rotate(secret, r.RotationInterval, now)
+	if err != nil {
+		return 0, err
+	}
+	if changed {
+		if err := controllerutil.SetOwnerReference(instance, secret, r.Scheme); err != nil {
+			return 0, err
+		}
+		if exists {
+			err = r.Client.Update(ctx, secret)
+		} else {
+			err = r.Client.Create(ctx, secret)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if previous != "" {
+		r.Log.Info("Rotated secret of OAuthClient", "name", oauthClient.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "OAuthClientSecretRotated", "Rotated secret of OAuthClient %s", oauthClient.Name)
+	}
+
+	rotatedAt, _ := time.Parse(time.RFC3339, secret.GetAnnotations()[annotations.OAuthClientSecretRotatedAt])
+	inGracePeriod := now.Before(rotatedAt.Add(rotationGracePeriod))
+	if err := r.applyOAuthClient(ctx, instance, oauthClient.Name, string(secret.Data[secretKey]), previous, inGracePeriod, redirectURIs); err != nil {
+		return 0, err
+	}
+
+	var next time.Duration
+	if r.RotationInterval > 0 {
+		next = rotatedAt.Add(r.RotationInterval).Sub(now)
+	}
+	if inGracePeriod {
+		next = rotatedAt.Add(rotationGracePeriod).Sub(now)
+	}
+
+	return next, nil
+}
+
+// applyOAuthClient creates or updates the OAuthClient. The previous secret of a rotation is kept valid as additional
+// secret during the grace period.
+func (r *OAuthClientReconciler) applyOAuthClient(ctx context.Context, instance *dscv1.DataScienceCluster, name, secret, previous string,
+	inGracePeriod bool, redirectURIs []string,
+) error {
+	oauthClient := &oauthv1.OAuthClient{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: name}, oauthClient)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	additionalSecrets := oauthClient.AdditionalSecrets
+	switch {
+	case previous != "":
+		additionalSecrets = []string{previous}
+	case !inGracePeriod:
+		additionalSecrets = nil
+	}
+
+	oauthClient.Name = name
+	oauthClient.Secret = secret
+	oauthClient.AdditionalSecrets = additionalSecrets
+	oauthClient.RedirectURIs = redirectURIs
+	oauthClient.GrantMethod = oauthv1.GrantHandlerAuto
+	if err := controllerutil.SetOwnerReference(instance, oauthClient, r.Scheme); err != nil {
+		return err
+	}
+
+	if exists {
+		return r.Client.Update(ctx, oauthClient)
+	}
+
+	return r.Client.Create(ctx, oauthClient)
+}
+
+// delete deletes the OAuthClient and its Secret, when managed by the operator.
+func (r *OAuthClientReconciler) delete(ctx context.Context, namespace, name string) error {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: name + "-generated", Namespace: namespace}, secret)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	if _, managed := secret.GetAnnotations()[annotations.OAuthClientSecretRotatedAt]; err == nil && managed {
+		if err := r.Client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	oauthClient := &oauthv1.OAuthClient{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: name}, oauthClient)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for _, owner := range oauthClient.GetOwnerReferences() {
+		if owner.Kind == "DataScienceCluster" {
+			return client.IgnoreNotFound(r.Client.Delete(ctx, oauthClient))
+		}
+	}
+
+	return nil
+}
+
+// rotate generates the secret when missing or older than the rotation interval, and returns the secret it replaced.
+// Secrets created before the operator managed them, e.g. by the secret generator, are adopted without being rotated.
+// It tells whether the Secret has been changed and has to be saved.
+func rotate(secret *corev1.Secret, interval time.Duration, now time.Time) (string, bool, error) {
+	current := string(secret.Data[secretKey])
+	rotatedAt, err := time.Parse(time.RFC3339, secret.GetAnnotations()[annotations.OAuthClientSecretRotatedAt])
+	adopt := current != "" && err != nil
+	due := current == "" || (err == nil && interval > 0 && !now.Before(rotatedAt.Add(interval)))
+	if !adopt && !due {
+		return "", false, nil
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[annotations.OAuthClientSecretRotatedAt] = now.UTC().Format(time.RFC3339)
+	if adopt {
+		return "", true, nil
+	}
+
+	generated, err := secretgenerator.NewSecret(secretKey, "random", secretLength)
+	if err != nil {
+		return "", false, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[secretKey] = []byte(generated.Value)
+
+	return current, true, nil
+}
+
+// renderRedirectURIs renders the redirect URI templates with the applications namespace and the cluster domain.
+func renderRedirectURIs(templates []string, namespace, domain string) ([]string, error) {
+	data := struct{ Namespace, Domain string }{Namespace: namespace, Domain: domain}
+
+	uris := make([]string, 0, len(templates))
+	for _, text := range templates {
+		tmpl, err := template.New("redirectURI").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect URI template %q: %w", text, err)
+		}
+		uri := &bytes.Buffer{}
+		if err := tmpl.Execute(uri, data); err != nil {
+			return nil, fmt.Errorf("failed to render redirect URI template %q: %w", text, err)
+		}
+		uris = append(uris, uri.String())
+	}
+
+	return uris, nil
+}
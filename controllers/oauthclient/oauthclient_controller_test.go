@@ -0,0 +1,265 @@
+package oauthclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	applicationsNamespace = "opendatahub"
+	clientName            = "dashboard-oauth-client"
+)
+
+var _ = Describe("Rotating the secret of an OAuthClient", func() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	interval := 24 * time.Hour
+
+	secret := func(value, rotatedAt string) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: clientName + "-generated"}}
+		if value != "" {
+			s.Data = map[string][]byte{secretKey: []byte(value)}
+		}
+		if rotatedAt != "" {
+			s.Annotations = map[string]string{annotations.OAuthClientSecretRotatedAt: rotatedAt}
+		}
+		return s
+	}
+
+	DescribeTable("should generate the secret only when due",
+		func(s *corev1.Secret, interval time.Duration, expectedPrevious string, expectedChanged, kept bool) {
+			before := string(s.Data[secretKey])
+
+			previous, changed, err := rotate(s, interval, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(previous).To(Equal(expectedPrevious))
+			Expect(changed).To(Equal(expectedChanged))
+			after := string(s.Data[secretKey])
+			Expect(after).NotTo(BeEmpty())
+			Expect(after == before).To(Equal(kept))
+			if changed {
+				Expect(s.Annotations).To(HaveKeyWithValue(annotations.OAuthClientSecretRotatedAt, "2024-05-01T12:00:00Z"))
+			}
+		},
+		Entry("generated when missing", secret("", ""), interval, "", true, false),
+		Entry("adopted when not managed yet", secret("manifests", ""), interval, "", true, true),
+		Entry("kept before rotation", secret("current", "2024-05-01T00:00:00Z"), interval, "", false, true),
+		Entry("rotated once expired", secret("current", "2024-04-30T12:00:00Z"), interval, "current", true, false),
+		Entry("never rotated without interval", secret("current", "2023-01-01T00:00:00Z"), time.Duration(0), "", false, true),
+	)
+})
+
+var _ = Describe("Rendering redirect URIs", func() {
+	It("should use the applications namespace and the cluster domain", func() {
+		uris, err := renderRedirectURIs([]string{"https://odh-dashboard-{{.Namespace}}.{{.Domain}}"}, applicationsNamespace, "apps.example.com")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris).To(ConsistOf("https://odh-dashboard-opendatahub.apps.example.com"))
+	})
+
+	It("should fail on unknown fields", func() {
+		_, err := renderRedirectURIs([]string{"https://{{.Route}}"}, applicationsNamespace, "apps.example.com")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("OAuthClient controller", func() {
+	var (
+		cli      client.Client
+		r        *OAuthClientReconciler
+		dsc      *dscv1.DataScienceCluster
+		objects  []client.Object
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsc"}}
+	)
+
+	BeforeEach(func() {
+		dsc = &dscv1.DataScienceCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", UID: "dsc-uid"},
+			Spec: dscv1.DataScienceClusterSpec{Components: dscv1.Components{
+				Dashboard: dashboard.Dashboard{Component: components.Component{ManagementState: operatorv1.Managed}},
+			}},
+		}
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+				Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: applicationsNamespace},
+			},
+			&configv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       configv1.IngressSpec{Domain: "apps.example.com"},
+			},
+		}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(configv1.AddToScheme(scheme)).To(Succeed())
+		Expect(oauthv1.AddToScheme(scheme)).To(Succeed())
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+		cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, dsc)...).Build()
+		r = &OAuthClientReconciler{
+			Client:           cli,
+			Scheme:           scheme,
+			Log:              logr.Discard(),
+			Recorder:         recorder,
+			RotationInterval: 24 * time.Hour,
+		}
+	})
+
+	reconcile := func(ctx context.Context) ctrl.Result {
+		GinkgoHelper()
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	oauthClient := func(ctx context.Context) *oauthv1.OAuthClient {
+		GinkgoHelper()
+		obj := &oauthv1.OAuthClient{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: clientName}, obj)).To(Succeed())
+		return obj
+	}
+
+	generatedSecret := func(ctx context.Context) *corev1.Secret {
+		GinkgoHelper()
+		obj := &corev1.Secret{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: clientName + "-generated", Namespace: applicationsNamespace}, obj)).To(Succeed())
+		return obj
+	}
+
+	When("the dashboard is Managed", func() {
+		It("should create its OAuthClient with a generated secret", func(ctx context.Context) {
+			result := reconcile(ctx)
+
+			secret := generatedSecret(ctx)
+			Expect(secret.Data).To(HaveKey(secretKey))
+			Expect(secret.OwnerReferences).To(ContainElement(HaveField("Name", "default-dsc")))
+			obj := oauthClient(ctx)
+			Expect(obj.Secret).To(Equal(string(secret.Data[secretKey])))
+			Expect(obj.RedirectURIs).To(ConsistOf("https://odh-dashboard-opendatahub.apps.example.com"))
+			Expect(obj.AdditionalSecrets).To(BeEmpty())
+			Expect(result.RequeueAfter).To(BeNumerically("~", rotationGracePeriod, time.Minute))
+		})
+
+		It("should keep the previous secret valid after a rotation", func(ctx context.Context) {
+			reconcile(ctx)
+			secret := generatedSecret(ctx)
+			previous := string(secret.Data[secretKey])
+			secret.Annotations[annotations.OAuthClientSecretRotatedAt] = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+			Expect(cli.Update(ctx, secret)).To(Succeed())
+
+			reconcile(ctx)
+
+			obj := oauthClient(ctx)
+			Expect(obj.Secret).NotTo(Equal(previous))
+			Expect(obj.AdditionalSecrets).To(ConsistOf(previous))
+			Expect(recorder.Events).To(Receive(ContainSubstring("OAuthClientSecretRotated")))
+		})
+
+		It("should drop the previous secret after the grace period", func(ctx context.Context) {
+			reconcile(ctx)
+			secret := generatedSecret(ctx)
+			secret.Annotations[annotations.OAuthClientSecretRotatedAt] = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+			Expect(cli.Update(ctx, secret)).To(Succeed())
+			reconcile(ctx)
+
+			secret = generatedSecret(ctx)
+			secret.Annotations[annotations.OAuthClientSecretRotatedAt] = time.Now().Add(-2 * rotationGracePeriod).UTC().Format(time.RFC3339)
+			Expect(cli.Update(ctx, secret)).To(Succeed())
+			result := reconcile(ctx)
+
+			Expect(oauthClient(ctx).AdditionalSecrets).To(BeEmpty())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 22*time.Hour, time.Minute))
+		})
+
+		Context("with a secret generated by the secret generator", func() {
+			BeforeEach(func() {
+				objects = append(objects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: clientName + "-generated", Namespace: applicationsNamespace},
+					Data:       map[string][]byte{secretKey: []byte("generated")},
+				})
+			})
+
+			It("should adopt it without rotating it", func(ctx context.Context) {
+				reconcile(ctx)
+
+				Expect(generatedSecret(ctx).Annotations).To(HaveKey(annotations.OAuthClientSecretRotatedAt))
+				Expect(oauthClient(ctx).Secret).To(Equal("generated"))
+				Expect(recorder.Events).NotTo(Receive())
+			})
+		})
+	})
+
+	When("the dashboard is Removed", func() {
+		It("should delete the OAuthClient and the secret it manages", func(ctx context.Context) {
+			reconcile(ctx)
+			dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+
+			reconcile(ctx)
+
+			Expect(k8serr.IsNotFound(cli.Get(ctx, client.ObjectKey{Name: clientName}, &oauthv1.OAuthClient{}))).To(BeTrue())
+			err := cli.Get(ctx, client.ObjectKey{Name: clientName + "-generated", Namespace: applicationsNamespace}, &corev1.Secret{})
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		Context("with an OAuthClient not created by the operator", func() {
+			BeforeEach(func() {
+				dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+				objects = append(objects,
+					&oauthv1.OAuthClient{ObjectMeta: metav1.ObjectMeta{Name: clientName}, Secret: "manual"},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: clientName + "-generated", Namespace: applicationsNamespace},
+						Data:       map[string][]byte{secretKey: []byte("manual")},
+					})
+			})
+
+			It("should leave them alone", func(ctx context.Context) {
+				reconcile(ctx)
+
+				Expect(oauthClient(ctx).Secret).To(Equal("manual"))
+				Expect(generatedSecret(ctx).Data).To(HaveKeyWithValue(secretKey, []byte("manual")))
+			})
+		})
+	})
+
+	When("no DSCInitialization exists", func() {
+		BeforeEach(func() {
+			objects = objects[1:]
+		})
+
+		It("should fail", func(ctx context.Context) {
+			_, err := r.Reconcile(ctx, req)
+
+			Expect(err).To(MatchError(ContainSubstring("DSCInitialization")))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package oauthclient
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOAuthClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OAuthClient controller suite")
+}
@@ -0,0 +1,117 @@
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	flowcontrolv1beta3 "k8s.io/api/flowcontrol/v1beta3"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+const (
+	// FlowSchemaName is the name of the FlowSchema assigning requests of the operator to the priority level of the OperatorConfig.
+	FlowSchemaName = "opendatahub-operator"
+	// flowSchemaPrecedence makes the FlowSchema take precedence over the service-accounts one of the cluster, while
+	// leaving requests matched by the exempt and system FlowSchemas to them.
+	flowSchemaPrecedence = 1000
+)
+
+// +kubebuilder:rbac:groups="flowcontrol.apiserver.k8s.io",resources=flowschemas,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="flowcontrol.apiserver.k8s.io",resources=prioritylevelconfigurations,verbs=get;list;watch
+
+// reconcileFlowSchema assigns requests of the operator to the priority level of the OperatorConfig, and deletes the
+// FlowSchema once none is set. It returns the condition reporting the outcome, nil when no priority level is set.
+func (r *OperatorConfigReconciler) reconcileFlowSchema(ctx context.Context, instance *operatorconfigv1alpha1.OperatorConfig) (*conditionsv1.Condition, error) {
+	priorityLevel := instance.Spec.Client.PriorityLevel
+	if priorityLevel == "" {
+		err := r.Client.Delete(ctx, &flowcontrolv1beta3.FlowSchema{ObjectMeta: metav1.ObjectMeta{Name: FlowSchemaName}})
+		if err != nil && !k8serr.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("failed to delete FlowSchema %s: %w", FlowSchemaName, err)
+		}
+		return nil, nil
+	}
+
+	condition := &conditionsv1.Condition{
+		Type:   ConditionPriorityLevelConfigured,
+		Status: corev1.ConditionFalse,
+		Reason: "InvalidConfiguration",
+	}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: priorityLevel}, &flowcontrolv1beta3.PriorityLevelConfiguration{}); err != nil {
+		if !k8serr.IsNotFound(err) {
+			return nil, err
+		}
+		condition.Message = fmt.Sprintf("PriorityLevelConfiguration %s not found", priorityLevel)
+		return condition, nil
+	}
+	namespace, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		condition.Message = err.Error()
+		return condition, nil
+	}
+	serviceAccount, err := cluster.GetOperatorServiceAccount()
+	if err != nil {
+		condition.Message = err.Error()
+		return condition, nil
+	}
+
+	spec := flowcontrolv1beta3.FlowSchemaSpec{
+		PriorityLevelConfiguration: flowcontrolv1beta3.PriorityLevelConfigurationReference{Name: priorityLevel},
+		MatchingPrecedence:         flowSchemaPrecedence,
+		DistinguisherMethod:        &flowcontrolv1beta3.FlowDistinguisherMethod{Type: flowcontrolv1beta3.FlowDistinguisherMethodByUserType},
+		Rules: []flowcontrolv1beta3.PolicyRulesWithSubjects{{
+			Subjects: []flowcontrolv1beta3.Subject{{
+				Kind:           flowcontrolv1beta3.SubjectKindServiceAccount,
+				ServiceAccount: &flowcontrolv1beta3.ServiceAccountSubject{Namespace: namespace, Name: serviceAccount},
+			}},
+			ResourceRules: []flowcontrolv1beta3.ResourcePolicyRule{{
+				Verbs:        []string{flowcontrolv1beta3.VerbAll},
+				APIGroups:    []string{flowcontrolv1beta3.APIGroupAll},
+				Resources:    []string{flowcontrolv1beta3.ResourceAll},
+				ClusterScope: true,
+				Namespaces:   []string{flowcontrolv1beta3.NamespaceEvery},
+			}},
+			NonResourceRules: []flowcontrolv1beta3.NonResourcePolicyRule{{
+				Verbs:           []string{flowcontrolv1beta3.VerbAll},
+				NonResourceURLs: []string{flowcontrolv1beta3.NonResourceAll},
+			}},
+		}},
+	}
+
+	found := &flowcontrolv1beta3.FlowSchema{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: FlowSchemaName}, found)
+	switch {
+	case k8serr.IsNotFound(err):
+		flowSchema := &flowcontrolv1beta3.FlowSchema{ObjectMeta: metav1.ObjectMeta{Name: FlowSchemaName}, Spec: spec}
+		if err := cluster.ApplyMetaOptions(flowSchema, cluster.OwnedBy(instance, r.Scheme)); err != nil {
+			return nil, err
+		}
+		if err := r.Client.Create(ctx, flowSchema); err != nil {
+			return nil, fmt.Errorf("failed to create FlowSchema %s: %w", FlowSchemaName, err)
+		}
+	case err != nil:
+		return nil, err
+	case reflect.DeepEqual(found.Spec, spec):
+		// up to date
+	default:
+		found.Spec = spec
+		if err := r.Client.Update(ctx, found); err != nil {
+			return nil, fmt.Errorf("failed to update FlowSchema %s: %w", FlowSchemaName, err)
+		}
+	}
+
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = status.ConfiguredReason
+	condition.Message = fmt.Sprintf("Requests of the operator are assigned to priority level %s", priorityLevel)
+
+	return condition, nil
+}
@@ -0,0 +1,106 @@
+package operatorconfig
+
+import (
+	"context"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	flowcontrolv1beta3 "k8s.io/api/flowcontrol/v1beta3"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Operator FlowSchema", func() {
+	var (
+		instance *operatorconfigv1alpha1.OperatorConfig
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+	)
+
+	reconcileFlowSchema := func(ctx context.Context) (*conditionsv1.Condition, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &OperatorConfigReconciler{Client: cli, Scheme: cli.Scheme()}
+		return r.reconcileFlowSchema(ctx, instance)
+	}
+	flowSchemaExists := func(ctx context.Context) bool {
+		GinkgoHelper()
+		err := cli.Get(ctx, client.ObjectKey{Name: FlowSchemaName}, &flowcontrolv1beta3.FlowSchema{})
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		return !k8serr.IsNotFound(err)
+	}
+
+	BeforeEach(func() {
+		instance = &operatorconfigv1alpha1.OperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: operatorconfigv1alpha1.OperatorConfigName}}
+		instance.Spec.Client.PriorityLevel = "workload-low"
+		objects = []client.Object{&flowcontrolv1beta3.FlowSchema{ObjectMeta: metav1.ObjectMeta{Name: FlowSchemaName}}}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	It("should report a missing priority level", func(ctx context.Context) {
+		condition, err := reconcileFlowSchema(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(condition).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Type":    Equal(ConditionPriorityLevelConfigured),
+			"Status":  Equal(corev1.ConditionFalse),
+			"Reason":  Equal("InvalidConfiguration"),
+			"Message": Equal("PriorityLevelConfiguration workload-low not found"),
+		})))
+	})
+
+	It("should report the operator namespace cannot be found", func(ctx context.Context) {
+		objects = append(objects, &flowcontrolv1beta3.PriorityLevelConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "workload-low"}})
+
+		condition, err := reconcileFlowSchema(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(condition).To(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Status":  Equal(corev1.ConditionFalse),
+			"Message": Equal("unable to find operator namespace"),
+		})))
+	})
+
+	When("no priority level is set", func() {
+		BeforeEach(func() {
+			instance.Spec.Client.PriorityLevel = ""
+		})
+
+		It("should delete the FlowSchema without reporting a condition", func(ctx context.Context) {
+			Expect(reconcileFlowSchema(ctx)).To(BeNil())
+
+			Expect(flowSchemaExists(ctx)).To(BeFalse())
+		})
+
+		It("should not fail once the FlowSchema is deleted", func(ctx context.Context) {
+			objects = nil
+
+			Expect(reconcileFlowSchema(ctx)).To(BeNil())
+		})
+
+		It("should not fail without the FlowSchema API", func(ctx context.Context) {
+			funcs.Delete = func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				return &meta.NoKindMatchError{GroupKind: flowcontrolv1beta3.SchemeGroupVersion.WithKind("FlowSchema").GroupKind()}
+			}
+
+			Expect(reconcileFlowSchema(ctx)).To(BeNil())
+		})
+	})
+})
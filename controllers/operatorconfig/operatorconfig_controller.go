@@ -0,0 +1,230 @@
+// Package operatorconfig contains controller logic applying operator-internal configuration from the OperatorConfig at runtime.
+package operatorconfig
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
+)
+
+const (
+	// ConditionLoggingConfigured tells whether logging configuration has been applied.
+	ConditionLoggingConfigured conditionsv1.ConditionType = "LoggingConfigured"
+	// ConditionRestartRequired tells whether some changes only take effect once the operator is restarted.
+	ConditionRestartRequired conditionsv1.ConditionType = "RestartRequired"
+	// ConditionFeatureGatesConfigured tells whether feature gates have been applied.
+	ConditionFeatureGatesConfigured conditionsv1.ConditionType = "FeatureGatesConfigured"
+	// ConditionPriorityLevelConfigured tells whether requests of the operator are assigned to the priority level set.
+	ConditionPriorityLevelConfigured conditionsv1.ConditionType = "PriorityLevelConfigured"
+	// ConditionTracingConfigured tells whether spans are exported to the endpoint set.
+	ConditionTracingConfigured conditionsv1.ConditionType = "TracingConfigured"
+)
+
+// +kubebuilder:rbac:groups="operatorconfig.opendatahub.io",resources=operatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="operatorconfig.opendatahub.io",resources=operatorconfigs/status,verbs=get;update;patch
+
+// OperatorConfigReconciler holds the controller configuration.
+type OperatorConfigReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// Startup is the configuration the operator has been started with, used to tell which changes need a restart.
+	Startup operatorconfigv1alpha1.OperatorConfigSpec
+
+	loggingApplied atomic.Bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for operator configuration.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("operator-config-controller").
+		For(&operatorconfigv1alpha1.OperatorConfig{}, builder.WithPredicates(predicate.And(
+			predicate.GenerationChangedPredicate{},
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == operatorconfigv1alpha1.OperatorConfigName
+			}),
+		))).
+		Complete(r)
+}
+
+// Reconcile applies the logging and tracing configuration and the priority level of the operator requests, and reports settings
+// which only take effect after a restart.
+func (r *OperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log
+
+	instance := &operatorconfigv1alpha1.OperatorConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		if k8serr.IsNotFound(err) {
+			if r.loggingApplied.Swap(false) {
+				log.Info("Operator configuration removed, reverting logging to defaults")
+				logger.ResetConfig()
+			}
+			tracing.ResetConfig()
+			if err := featuregate.Set(nil); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	loggingCondition := conditionsv1.Condition{
+		Type:    ConditionLoggingConfigured,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: "Logging configuration applied",
+	}
+	if instance.Spec.Logging != nil {
+		cfg, err := logger.ParseConfig(LoggingData(instance.Spec.Logging))
+		if err != nil {
+			// Keep current configuration, invalid one needs to be fixed by the user
+			log.Error(err, "Invalid logging configuration, keeping current one")
+			loggingCondition.Status = corev1.ConditionFalse
+			loggingCondition.Reason = "InvalidConfiguration"
+			loggingCondition.Message = err.Error()
+		} else {
+			logger.ApplyConfig(cfg)
+			r.loggingApplied.Store(true)
+			log.Info("Logging configuration applied", "level", cfg.Level.String(), "format", cfg.Format, "overrides", len(cfg.Components))
+		}
+	} else if r.loggingApplied.Swap(false) {
+		logger.ResetConfig()
+	}
+
+	tracingCondition := conditionsv1.Condition{
+		Type:    ConditionTracingConfigured,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: "Spans exported to the tracing endpoint",
+	}
+	if tracingSpec := instance.Spec.Tracing; tracingSpec != nil {
+		if err := tracing.ApplyConfig(ctx, tracing.Config{Endpoint: tracingSpec.Endpoint, Insecure: tracingSpec.Insecure}); err != nil {
+			// Keep current exporter, invalid configuration needs to be fixed by the user
+			log.Error(err, "Invalid tracing configuration, keeping current one")
+			tracingCondition.Status = corev1.ConditionFalse
+			tracingCondition.Reason = "InvalidConfiguration"
+			tracingCondition.Message = err.Error()
+		}
+	} else {
+		tracing.ResetConfig()
+	}
+
+	featureGatesCondition := conditionsv1.Condition{
+		Type:    ConditionFeatureGatesConfigured,
+		Status:  corev1.ConditionTrue,
+		Reason:  status.ConfiguredReason,
+		Message: "Feature gates applied",
+	}
+	if err := featuregate.Set(instance.Spec.FeatureGates); err != nil {
+		// Keep current gates, invalid ones need to be fixed by the user
+		log.Error(err, "Invalid feature gates, keeping current ones")
+		featureGatesCondition.Status = corev1.ConditionFalse
+		featureGatesCondition.Reason = "InvalidConfiguration"
+		featureGatesCondition.Message = err.Error()
+	}
+
+	priorityLevelCondition, err := r.reconcileFlowSchema(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restartCondition := conditionsv1.Condition{
+		Type:    ConditionRestartRequired,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Applied",
+		Message: "Operator runs with the current configuration",
+	}
+	clientChanged := instance.Spec.Client.QPS != r.Startup.Client.QPS || instance.Spec.Client.Burst != r.Startup.Client.Burst
+	if !reflect.DeepEqual(instance.Spec.Concurrency, r.Startup.Concurrency) || !reflect.DeepEqual(instance.Spec.Cache, r.Startup.Cache) || clientChanged {
+		restartCondition.Status = corev1.ConditionTrue
+		restartCondition.Reason = "ConfigurationChanged"
+		restartCondition.Message = "Concurrency, cache or client configuration changed, restart the operator to apply it"
+	}
+
+	_, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *operatorconfigv1alpha1.OperatorConfig) {
+		if instance.Spec.Logging != nil {
+			conditionsv1.SetStatusCondition(&saved.Status.Conditions, loggingCondition)
+		} else {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, ConditionLoggingConfigured)
+		}
+		if instance.Spec.Tracing != nil {
+			conditionsv1.SetStatusCondition(&saved.Status.Conditions, tracingCondition)
+		} else {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, ConditionTracingConfigured)
+		}
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, featureGatesCondition)
+		if priorityLevelCondition != nil {
+			conditionsv1.SetStatusCondition(&saved.Status.Conditions, *priorityLevelCondition)
+		} else {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, ConditionPriorityLevelConfigured)
+		}
+		conditionsv1.SetStatusCondition(&saved.Status.Conditions, restartCondition)
+		saved.Status.FeatureGates = FeatureGatesStatus()
+		saved.Status.Phase = status.PhaseReady
+	})
+
+	return ctrl.Result{}, err
+}
+
+// Get returns the OperatorConfig of the operator, or nil when there is none or its CRD is not installed yet.
+func Get(ctx context.Context, cli client.Reader) (*operatorconfigv1alpha1.OperatorConfig, error) {
+	instance := &operatorconfigv1alpha1.OperatorConfig{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: operatorconfigv1alpha1.OperatorConfigName}, instance); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// FeatureGatesStatus reports all feature gates known to the operator and whether they are enabled.
+func FeatureGatesStatus() []operatorconfigv1alpha1.FeatureGateStatus {
+	gates := featuregate.All()
+	statuses := make([]operatorconfigv1alpha1.FeatureGateStatus, 0, len(gates))
+	for _, gate := range gates {
+		statuses = append(statuses, operatorconfigv1alpha1.FeatureGateStatus{
+			Name:    gate.Name,
+			Stage:   string(gate.Stage),
+			Enabled: gate.Enabled,
+		})
+	}
+
+	return statuses
+}
+
+// LoggingData converts logging configuration to the format of the logging ConfigMap.
+func LoggingData(logging *operatorconfigv1alpha1.LoggingSpec) map[string]string {
+	data := map[string]string{}
+	if logging.Level != "" {
+		data["level"] = logging.Level
+	}
+	if logging.Format != "" {
+		data["format"] = logging.Format
+	}
+	for name, level := range logging.Components {
+		data["level."+name] = level
+	}
+
+	return data
+}
@@ -0,0 +1,160 @@
+package operatorconfig_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/operatorconfig"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logging data", func() {
+	It("should convert the logging configuration to the logging ConfigMap format", func() {
+		data := operatorconfig.LoggingData(&operatorconfigv1alpha1.LoggingSpec{
+			Level:      "warn",
+			Format:     "json",
+			Components: map[string]string{"kserve": "debug"},
+		})
+
+		Expect(data).To(Equal(map[string]string{"level": "warn", "format": "json", "level.kserve": "debug"}))
+		cfg, err := logger.ParseConfig(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Level).To(Equal(zapcore.WarnLevel))
+		Expect(cfg.Components).To(HaveKeyWithValue("kserve", zapcore.DebugLevel))
+	})
+
+	It("should leave out unset fields", func() {
+		Expect(operatorconfig.LoggingData(&operatorconfigv1alpha1.LoggingSpec{})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Operator configuration controller", func() {
+	var (
+		instance *operatorconfigv1alpha1.OperatorConfig
+		cli      client.Client
+		r        *operatorconfig.OperatorConfigReconciler
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: operatorconfigv1alpha1.OperatorConfigName}}
+	)
+
+	reconcile := func(ctx context.Context) *operatorconfigv1alpha1.OperatorConfig {
+		GinkgoHelper()
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		saved := &operatorconfigv1alpha1.OperatorConfig{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		return saved
+	}
+	update := func(ctx context.Context, mutate func(*operatorconfigv1alpha1.OperatorConfig)) {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, req.NamespacedName, instance)).To(Succeed())
+		mutate(instance)
+		Expect(cli.Update(ctx, instance)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		instance = &operatorconfigv1alpha1.OperatorConfig{ObjectMeta: metav1.ObjectMeta{Name: operatorconfigv1alpha1.OperatorConfigName}}
+		DeferCleanup(logger.ResetConfig)
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(operatorconfigv1alpha1.AddToScheme(scheme)).To(Succeed())
+		cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).Build()
+		r = &operatorconfig.OperatorConfigReconciler{Client: cli, Scheme: scheme, Log: logr.Discard()}
+	})
+
+	It("should report the operator as ready with the startup configuration", func(ctx context.Context) {
+		saved := reconcile(ctx)
+
+		Expect(saved.Status.Phase).To(Equal("Ready"))
+		Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionRestartRequired)).
+			To(HaveField("Status", corev1.ConditionFalse))
+		Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionLoggingConfigured)).To(BeNil())
+		Expect(saved.Status.FeatureGates).NotTo(BeEmpty())
+	})
+
+	When("logging is configured", func() {
+		BeforeEach(func() {
+			instance.Spec.Logging = &operatorconfigv1alpha1.LoggingSpec{Components: map[string]string{"kserve": "debug"}}
+		})
+
+		It("should apply the configuration to component loggers", func(ctx context.Context) {
+			saved := reconcile(ctx)
+
+			Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionLoggingConfigured)).
+				To(HaveField("Status", corev1.ConditionTrue))
+			Expect(logger.NewComponentLogger(logr.Discard(), "kserve", "kserve", "").V(1).Enabled()).To(BeTrue())
+		})
+
+		It("should revert to defaults once the configuration is removed", func(ctx context.Context) {
+			reconcile(ctx)
+			update(ctx, func(instance *operatorconfigv1alpha1.OperatorConfig) { instance.Spec.Logging = nil })
+
+			saved := reconcile(ctx)
+			Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionLoggingConfigured)).To(BeNil())
+			Expect(logger.NewComponentLogger(logr.Discard(), "kserve", "kserve", "").Enabled()).To(BeFalse())
+		})
+
+		It("should revert to defaults once the OperatorConfig is deleted", func(ctx context.Context) {
+			reconcile(ctx)
+			Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.NewComponentLogger(logr.Discard(), "kserve", "kserve", "").Enabled()).To(BeFalse())
+		})
+	})
+
+	When("the logging configuration is invalid", func() {
+		BeforeEach(func() {
+			instance.Spec.Logging = &operatorconfigv1alpha1.LoggingSpec{Level: "loud"}
+		})
+
+		It("should keep the current configuration and report the error", func(ctx context.Context) {
+			saved := reconcile(ctx)
+
+			Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionLoggingConfigured)).To(And(
+				HaveField("Status", corev1.ConditionFalse),
+				HaveField("Reason", "InvalidConfiguration"),
+				HaveField("Message", ContainSubstring(`unsupported log level "loud"`)),
+			))
+		})
+	})
+
+	DescribeTable("should require a restart when settings applied on startup changed",
+		func(ctx context.Context, mutate func(*operatorconfigv1alpha1.OperatorConfig)) {
+			reconcile(ctx)
+			update(ctx, mutate)
+
+			saved := reconcile(ctx)
+			Expect(conditionsv1.FindStatusCondition(saved.Status.Conditions, operatorconfig.ConditionRestartRequired)).To(And(
+				HaveField("Status", corev1.ConditionTrue),
+				HaveField("Reason", "ConfigurationChanged"),
+			))
+		},
+		Entry("for concurrency", func(instance *operatorconfigv1alpha1.OperatorConfig) {
+			instance.Spec.Concurrency.MaxConcurrentReconciles = 4
+		}),
+		Entry("for the cache", func(instance *operatorconfigv1alpha1.OperatorConfig) {
+			instance.Spec.Cache.Namespaces = []string{"opendatahub"}
+		}),
+		Entry("for the client", func(instance *operatorconfigv1alpha1.OperatorConfig) {
+			instance.Spec.Client.QPS = 50
+		}),
+	)
+})
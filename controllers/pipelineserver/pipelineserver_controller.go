@@ -0,0 +1,369 @@
+// Package pipelineserver contains controller logic creating a pipeline server in every data science project,
+// according to the pipeline server settings of the datasciencepipelines component.
+package pipelineserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cloud"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// pendingRequeue is how often projects waiting for object storage are checked again, as neither data connection
+// Secrets nor ObjectBucketClaims of the projects are watched.
+const pendingRequeue = time.Minute
+
+// +kubebuilder:rbac:groups="objectbucket.io",resources=objectbucketclaims,verbs=get;create
+// +kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
+
+// PipelineServerReconciler holds the controller configuration.
+type PipelineServerReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads resources of data science projects, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// objectStorage holds the location of the object storage of a pipeline server.
+type objectStorage struct {
+	Scheme     string
+	Host       string
+	Port       string
+	Bucket     string
+	Region     string
+	SecretName string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PipelineServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for pipeline servers of data science projects.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pipeline-server-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.watchNamespaces), builder.WithPredicates(projectCreated)).
+		Complete(r)
+}
+
+// Reconcile creates the pipeline server set in the DataScienceCluster in data science projects which have none yet.
+// Projects are annotated once seeded, so that pipeline servers deleted by users are not created again.
+func (r *PipelineServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	pipelines := instance.Spec.Components.DataSciencePipelines
+	server := pipelines.PipelineServer
+	if instance.GetDeletionTimestamp() != nil || pipelines.ManagementState != operatorv1.Managed ||
+		server == nil || server.ManagementState != operatorv1.Managed {
+		return ctrl.Result{}, nil
+	}
+	if server.Database != nil {
+		storageClass, err := r.defaultStorageClass(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		server = server.DeepCopy()
+		server.Database = server.Database.WithDefaultStorageClass(storageClass)
+	}
+
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+
+	var errs []error
+	pending := false
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		if project.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		if seededServer, seeded := project.GetAnnotations()[annotations.PipelineServer]; seeded {
+			if server.Database != nil && seededServer == server.Name {
+				// the database claim is expanded, but neither created again nor deleted once the project is seeded
+				if err := server.Database.Expand(ctx, r.Client, r.APIReader, server.DatabaseClaimName(), project.Name); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			continue
+		}
+		done, err := r.seed(ctx, server, project)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		pending = pending || !done
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "PipelineServerFailed", "Failed to create pipeline servers: %v", err)
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: pendingRequeue}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// defaultStorageClass returns the storage class of the cloud the cluster runs on, for databases which set none.
+func (r *PipelineServerReconciler) defaultStorageClass(ctx context.Context) (string, error) {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		return "", fmt.Errorf("failed to list DSCInitializations: %w", err)
+	}
+	if len(instances.Items) == 0 {
+		return cloud.For(nil).StorageClassName, nil
+	}
+
+	return cloud.For(&instances.Items[0].Spec).StorageClassName, nil
+}
+
+// seed creates the pipeline server in the project unless it already has one, and tells whether the project is
+// seeded, rather than waiting for its object storage or for pipeline servers to be installed.
+func (r *PipelineServerReconciler) seed(ctx context.Context, server *datasciencepipelines.PipelineServerSpec, project *corev1.Namespace) (bool, error) {
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(gvk.DataSciencePipelinesApplication)
+	if err := r.APIReader.List(ctx, existing, client.InNamespace(project.Name)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// data-science-pipelines-operator is not deployed yet
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to list pipeline servers of %s: %w", project.Name, err)
+	}
+
+	if len(existing.Items) == 0 {
+		storage, err := r.objectStorage(ctx, server, project.Name)
+		if err != nil || storage == nil {
+			return false, err
+		}
+		if server.Database != nil {
+			// created ahead of the pipeline server, for the database to be provisioned from the storage class set
+			if err := server.Database.Apply(ctx, r.Client, r.APIReader, server.DatabaseClaimName(), project.Name); err != nil {
+				return false, err
+			}
+		}
+		if err := r.Client.Create(ctx, desiredPipelineServer(server, project.Name, storage)); client.IgnoreAlreadyExists(err) != nil {
+			return false, fmt.Errorf("failed to create pipeline server in %s: %w", project.Name, err)
+		}
+		r.Log.Info("Created pipeline server", "namespace", project.Name, "name", server.Name)
+	}
+
+	original := project.DeepCopy()
+	projectAnnotations := project.GetAnnotations()
+	if projectAnnotations == nil {
+		projectAnnotations = map[string]string{}
+	}
+	projectAnnotations[annotations.PipelineServer] = server.Name
+	project.SetAnnotations(projectAnnotations)
+	if err := r.Client.Patch(ctx, project, client.MergeFrom(original)); err != nil {
+		return false, fmt.Errorf("failed to annotate %s: %w", project.Name, err)
+	}
+
+	return true, nil
+}
+
+// objectStorage provisions the object storage of the pipeline server in the project, returning nil while it is not
+// available yet.
+func (r *PipelineServerReconciler) objectStorage(ctx context.Context, server *datasciencepipelines.PipelineServerSpec, namespace string) (*objectStorage, error) {
+	switch server.ObjectStorage.Provisioner {
+	case datasciencepipelines.DataConnectionProvisioner:
+		secret := &corev1.Secret{}
+		if err := r.APIReader.Get(ctx, client.ObjectKey{Name: server.ObjectStorage.DataConnection, Namespace: namespace}, secret); err != nil {
+			// the data connection may be added to the project later on
+			return nil, client.IgnoreNotFound(err)
+		}
+		return dataConnectionStorage(secret)
+	case datasciencepipelines.ObjectBucketClaimProvisioner:
+		return r.claimBucket(ctx, server, namespace)
+	}
+
+	return nil, fmt.Errorf("unknown object storage provisioner %q", server.ObjectStorage.Provisioner)
+}
+
+// claimBucket creates an ObjectBucketClaim for the pipeline server, and returns its bucket once bound.
+func (r *PipelineServerReconciler) claimBucket(ctx context.Context, server *datasciencepipelines.PipelineServerSpec, namespace string) (*objectStorage, error) {
+	name := server.Name + "-pipelines"
+	claim := &unstructured.Unstructured{}
+	claim.SetGroupVersionKind(gvk.ObjectBucketClaim)
+	err := r.APIReader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, claim)
+	switch {
+	case k8serr.IsNotFound(err):
+		claim.SetName(name)
+		claim.SetNamespace(namespace)
+		claim.Object["spec"] = map[string]interface{}{
+			"generateBucketName": name,
+			"storageClassName":   server.ObjectStorage.StorageClassName,
+		}
+		if err := r.Client.Create(ctx, claim); err != nil {
+			return nil, fmt.Errorf("failed to claim bucket in %s: %w", namespace, err)
+		}
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get bucket claim of %s: %w", namespace, err)
+	}
+
+	if phase, _, _ := unstructured.NestedString(claim.Object, "status", "phase"); phase != "Bound" {
+		return nil, nil
+	}
+
+	// the bucket location is published in a ConfigMap, and credentials in a Secret, named after the claim
+	config := &corev1.ConfigMap{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, config); err != nil {
+		return nil, fmt.Errorf("failed to get bucket of %s: %w", namespace, err)
+	}
+
+	return bucketStorage(config), nil
+}
+
+// dataConnectionStorage returns the object storage of the data connection Secret.
+func dataConnectionStorage(secret *corev1.Secret) (*objectStorage, error) {
+	endpoint, err := url.Parse(string(secret.Data["AWS_S3_ENDPOINT"]))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("data connection %s/%s has no valid AWS_S3_ENDPOINT", secret.Namespace, secret.Name)
+	}
+	if len(secret.Data["AWS_S3_BUCKET"]) == 0 {
+		return nil, fmt.Errorf("data connection %s/%s has no AWS_S3_BUCKET", secret.Namespace, secret.Name)
+	}
+
+	return &objectStorage{
+		Scheme:     endpoint.Scheme,
+		Host:       endpoint.Hostname(),
+		Port:       endpoint.Port(),
+		Bucket:     string(secret.Data["AWS_S3_BUCKET"]),
+		Region:     string(secret.Data["AWS_DEFAULT_REGION"]),
+		SecretName: secret.Name,
+	}, nil
+}
+
+// ForDataConnection returns the DataSciencePipelinesApplication storing artifacts in the object storage of the data
+// connection Secret, e.g. for project templates to create pipeline servers.
+func ForDataConnection(server *datasciencepipelines.PipelineServerSpec, secret *corev1.Secret) (*unstructured.Unstructured, error) {
+	storage, err := dataConnectionStorage(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return desiredPipelineServer(server, secret.Namespace, storage), nil
+}
+
+// bucketStorage returns the object storage of the bucket published by an ObjectBucketClaim.
+func bucketStorage(config *corev1.ConfigMap) *objectStorage {
+	scheme := "http"
+	if config.Data["BUCKET_PORT"] == "443" {
+		scheme = "https"
+	}
+
+	return &objectStorage{
+		Scheme:     scheme,
+		Host:       config.Data["BUCKET_HOST"],
+		Port:       config.Data["BUCKET_PORT"],
+		Bucket:     config.Data["BUCKET_NAME"],
+		Region:     config.Data["BUCKET_REGION"],
+		SecretName: config.Name,
+	}
+}
+
+// desiredPipelineServer returns the DataSciencePipelinesApplication of the project, storing artifacts in the object storage.
+func desiredPipelineServer(server *datasciencepipelines.PipelineServerSpec, namespace string, storage *objectStorage) *unstructured.Unstructured {
+	externalStorage := map[string]interface{}{
+		"scheme": storage.Scheme,
+		"host":   storage.Host,
+		"bucket": storage.Bucket,
+		"s3CredentialsSecret": map[string]interface{}{
+			"secretName": storage.SecretName,
+			"accessKey":  "AWS_ACCESS_KEY_ID",
+			"secretKey":  "AWS_SECRET_ACCESS_KEY",
+		},
+	}
+	if storage.Port != "" {
+		externalStorage["port"] = storage.Port
+	}
+	if storage.Region != "" {
+		externalStorage["region"] = storage.Region
+	}
+
+	spec := map[string]interface{}{
+		"dspVersion": server.DSPVersion,
+		"objectStorage": map[string]interface{}{
+			"externalStorage": externalStorage,
+		},
+	}
+	if server.Database != nil {
+		spec["database"] = map[string]interface{}{
+			"mariaDB": map[string]interface{}{
+				"deploy":  true,
+				"pvcSize": server.Database.Size.String(),
+			},
+		}
+	}
+
+	dspa := &unstructured.Unstructured{}
+	dspa.SetGroupVersionKind(gvk.DataSciencePipelinesApplication)
+	dspa.SetName(server.Name)
+	dspa.SetNamespace(namespace)
+	dspa.Object["spec"] = spec
+
+	return dspa
+}
+
+// watchNamespaces reconciles all DataScienceClusters, as there is no telling which one holds pipeline server settings.
+func (r *PipelineServerReconciler) watchNamespaces(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dscv1.DataScienceClusterList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DataScienceClusters")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
+
+// projectCreated passes namespaces created as, or turned into, data science projects.
+var projectCreated = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetLabels()[labels.ODH.Dashboard] == "true"
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.ODH.Dashboard] != "true" && e.ObjectNew.GetLabels()[labels.ODH.Dashboard] == "true"
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
@@ -0,0 +1,354 @@
+package pipelineserver
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline server object storage", func() {
+	var secret *corev1.Secret
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-connection-shared", Namespace: "team-a"},
+			Data: map[string][]byte{
+				"AWS_S3_ENDPOINT":    []byte("https://minio.example.com:9000"),
+				"AWS_S3_BUCKET":      []byte("pipelines"),
+				"AWS_DEFAULT_REGION": []byte("us-east-1"),
+			},
+		}
+	})
+
+	It("should be read from a data connection", func() {
+		Expect(dataConnectionStorage(secret)).To(Equal(&objectStorage{
+			Scheme: "https", Host: "minio.example.com", Port: "9000", Bucket: "pipelines", Region: "us-east-1", SecretName: "aws-connection-shared",
+		}))
+	})
+
+	DescribeTable("should reject an incomplete data connection",
+		func(key, value, message string) {
+			secret.Data[key] = []byte(value)
+
+			_, err := dataConnectionStorage(secret)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without bucket", "AWS_S3_BUCKET", "", "has no AWS_S3_BUCKET"),
+		Entry("without endpoint", "AWS_S3_ENDPOINT", "", "has no valid AWS_S3_ENDPOINT"),
+		Entry("with an endpoint which is not a URL", "AWS_S3_ENDPOINT", "minio.example.com", "has no valid AWS_S3_ENDPOINT"),
+	)
+
+	DescribeTable("should be read from the bucket of an ObjectBucketClaim",
+		func(port, scheme string) {
+			config := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "dspa-pipelines"},
+				Data:       map[string]string{"BUCKET_HOST": "s3.openshift-storage.svc", "BUCKET_PORT": port, "BUCKET_NAME": "dspa-pipelines-1234"},
+			}
+
+			Expect(bucketStorage(config)).To(Equal(&objectStorage{
+				Scheme: scheme, Host: "s3.openshift-storage.svc", Port: port, Bucket: "dspa-pipelines-1234", SecretName: "dspa-pipelines",
+			}))
+		},
+		Entry("over https", "443", "https"),
+		Entry("over http", "80", "http"),
+	)
+})
+
+var _ = Describe("Desired pipeline server", func() {
+	var (
+		server  *datasciencepipelines.PipelineServerSpec
+		storage *objectStorage
+	)
+
+	BeforeEach(func() {
+		server = &datasciencepipelines.PipelineServerSpec{Name: "dspa", DSPVersion: "v2"}
+		storage = &objectStorage{Scheme: "https", Host: "s3.amazonaws.com", Bucket: "pipelines", SecretName: "aws-connection-shared"}
+	})
+
+	It("should store artifacts in the object storage", func() {
+		dspa := desiredPipelineServer(server, "team-a", storage)
+
+		Expect(dspa.GetNamespace()).To(Equal("team-a"))
+		Expect(dspa.GetName()).To(Equal("dspa"))
+		Expect(dspa.Object["spec"]).To(Equal(map[string]interface{}{
+			"dspVersion": "v2",
+			"objectStorage": map[string]interface{}{
+				"externalStorage": map[string]interface{}{
+					"scheme": "https",
+					"host":   "s3.amazonaws.com",
+					"bucket": "pipelines",
+					"s3CredentialsSecret": map[string]interface{}{
+						"secretName": "aws-connection-shared",
+						"accessKey":  "AWS_ACCESS_KEY_ID",
+						"secretKey":  "AWS_SECRET_ACCESS_KEY",
+					},
+				},
+			},
+		}))
+	})
+
+	It("should set the port and region of the object storage when known", func() {
+		storage.Port = "9000"
+		storage.Region = "us-east-1"
+
+		externalStorage, _, err := unstructured.NestedMap(desiredPipelineServer(server, "team-a", storage).Object, "spec", "objectStorage", "externalStorage")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(externalStorage).To(HaveKeyWithValue("port", "9000"))
+		Expect(externalStorage).To(HaveKeyWithValue("region", "us-east-1"))
+	})
+
+	It("should size the database when set", func() {
+		server.Database = &components.PersistentStorage{Size: resource.MustParse("20Gi")}
+
+		size, _, err := unstructured.NestedString(desiredPipelineServer(server, "team-a", storage).Object, "spec", "database", "mariaDB", "pvcSize")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal("20Gi"))
+		Expect(server.DatabaseClaimName()).To(Equal("mariadb-dspa"))
+	})
+
+	It("should be created for a data connection", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-connection-shared", Namespace: "team-b"},
+			Data:       map[string][]byte{"AWS_S3_ENDPOINT": []byte("https://s3.amazonaws.com"), "AWS_S3_BUCKET": []byte("pipelines")},
+		}
+
+		dspa, err := ForDataConnection(server, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dspa.GetNamespace()).To(Equal("team-b"))
+	})
+})
+
+var _ = Describe("Pipeline server controller", func() {
+	var (
+		server   *datasciencepipelines.PipelineServerSpec
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsc"}}
+	)
+
+	project := func(name string, projectAnnotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Labels: map[string]string{labels.ODH.Dashboard: "true"}, Annotations: projectAnnotations,
+		}}
+	}
+	dataConnection := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-connection-shared", Namespace: namespace},
+			Data:       map[string][]byte{"AWS_S3_ENDPOINT": []byte("https://s3.amazonaws.com"), "AWS_S3_BUCKET": []byte("pipelines")},
+		}
+	}
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			dsc := &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+			dsc.Spec.Components.DataSciencePipelines.ManagementState = operatorv1.Managed
+			dsc.Spec.Components.DataSciencePipelines.PipelineServer = server
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, dsc)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &PipelineServerReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, req)
+	}
+	pipelineServers := func(ctx context.Context, namespace string) []unstructured.Unstructured {
+		GinkgoHelper()
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.DataSciencePipelinesApplication)
+		Expect(cli.List(ctx, list, client.InNamespace(namespace))).To(Succeed())
+		return list.Items
+	}
+	seededServer := func(ctx context.Context, namespace string) string {
+		GinkgoHelper()
+		project := &corev1.Namespace{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: namespace}, project)).To(Succeed())
+		return project.GetAnnotations()[annotations.PipelineServer]
+	}
+
+	BeforeEach(func() {
+		server = &datasciencepipelines.PipelineServerSpec{
+			ManagementState: operatorv1.Managed,
+			Name:            "dspa",
+			DSPVersion:      "v2",
+			ObjectStorage: datasciencepipelines.PipelineObjectStorage{
+				Provisioner:    datasciencepipelines.DataConnectionProvisioner,
+				DataConnection: "aws-connection-shared",
+			},
+		}
+		objects = []client.Object{
+			project("team-a", nil),
+			dataConnection("team-a"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "not-a-project"}},
+			dataConnection("not-a-project"),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should create a pipeline server in data science projects and mark them seeded", func(ctx context.Context) {
+		result, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+
+		Expect(pipelineServers(ctx, "team-a")).To(ConsistOf(HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", "dspa")))))
+		Expect(seededServer(ctx, "team-a")).To(Equal("dspa"))
+		Expect(pipelineServers(ctx, "not-a-project")).To(BeEmpty())
+	})
+
+	It("should not create a pipeline server deleted by users again", func(ctx context.Context) {
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		dspa := pipelineServers(ctx, "team-a")[0]
+		Expect(cli.Delete(ctx, &dspa)).To(Succeed())
+
+		_, err = reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pipelineServers(ctx, "team-a")).To(BeEmpty())
+	})
+
+	When("a project already has a pipeline server", func() {
+		BeforeEach(func() {
+			dspa := &unstructured.Unstructured{}
+			dspa.SetGroupVersionKind(gvk.DataSciencePipelinesApplication)
+			dspa.SetName("own")
+			dspa.SetNamespace("team-a")
+			objects = append(objects, dspa)
+		})
+
+		It("should only mark the project seeded", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineServers(ctx, "team-a")).To(HaveLen(1))
+			Expect(seededServer(ctx, "team-a")).To(Equal("dspa"))
+		})
+	})
+
+	When("the data connection is not added to a project yet", func() {
+		BeforeEach(func() {
+			objects = append(objects, project("team-b", nil))
+		})
+
+		It("should check the project again later on", func(ctx context.Context) {
+			result, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pendingRequeue))
+
+			Expect(pipelineServers(ctx, "team-b")).To(BeEmpty())
+			Expect(seededServer(ctx, "team-b")).To(BeEmpty())
+			Expect(seededServer(ctx, "team-a")).To(Equal("dspa"))
+		})
+	})
+
+	When("pipeline servers are not installed yet", func() {
+		BeforeEach(func() {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Group == gvk.DataSciencePipelinesApplication.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.DataSciencePipelinesApplication.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+		})
+
+		It("should check projects again later on", func(ctx context.Context) {
+			result, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pendingRequeue))
+			Expect(seededServer(ctx, "team-a")).To(BeEmpty())
+		})
+	})
+
+	When("the data connection of a project is invalid", func() {
+		BeforeEach(func() {
+			objects[1].(*corev1.Secret).Data["AWS_S3_BUCKET"] = nil
+		})
+
+		It("should return the error and emit an event", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).To(MatchError(ContainSubstring("data connection team-a/aws-connection-shared has no AWS_S3_BUCKET")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("PipelineServerFailed")))
+			Expect(seededServer(ctx, "team-a")).To(BeEmpty())
+		})
+	})
+
+	When("buckets are claimed in projects", func() {
+		BeforeEach(func() {
+			server.ObjectStorage = datasciencepipelines.PipelineObjectStorage{
+				Provisioner:      datasciencepipelines.ObjectBucketClaimProvisioner,
+				StorageClassName: "openshift-storage.noobaa.io",
+			}
+		})
+
+		It("should create the pipeline server once the bucket is bound", func(ctx context.Context) {
+			result, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pendingRequeue))
+			claim := &unstructured.Unstructured{}
+			claim.SetGroupVersionKind(gvk.ObjectBucketClaim)
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "dspa-pipelines", Namespace: "team-a"}, claim)).To(Succeed())
+			Expect(claim.Object["spec"]).To(HaveKeyWithValue("storageClassName", "openshift-storage.noobaa.io"))
+			Expect(pipelineServers(ctx, "team-a")).To(BeEmpty())
+
+			Expect(unstructured.SetNestedField(claim.Object, "Bound", "status", "phase")).To(Succeed())
+			Expect(cli.Update(ctx, claim)).To(Succeed())
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "dspa-pipelines", Namespace: "team-a"},
+				Data:       map[string]string{"BUCKET_HOST": "s3.openshift-storage.svc", "BUCKET_PORT": "443", "BUCKET_NAME": "dspa-pipelines-1234"},
+			})).To(Succeed())
+
+			result, err = reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(pipelineServers(ctx, "team-a")).To(HaveLen(1))
+			Expect(seededServer(ctx, "team-a")).To(Equal("dspa"))
+		})
+	})
+
+	It("should not create pipeline servers once they are removed", func(ctx context.Context) {
+		server.ManagementState = operatorv1.Removed
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		Expect(pipelineServers(ctx, "team-a")).To(BeEmpty())
+	})
+
+	DescribeTable("should watch namespaces",
+		func(old, updated map[string]string, expected bool) {
+			e := event.UpdateEvent{
+				ObjectOld: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: old}},
+				ObjectNew: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: updated}},
+			}
+			Expect(projectCreated.Update(e)).To(Equal(expected))
+		},
+		Entry("turned into data science projects", nil, map[string]string{labels.ODH.Dashboard: "true"}, true),
+		Entry("but not data science projects updated", map[string]string{labels.ODH.Dashboard: "true"}, map[string]string{labels.ODH.Dashboard: "true", "team": "a"}, false),
+		Entry("nor other namespaces", nil, map[string]string{"team": "a"}, false),
+	)
+})
@@ -0,0 +1,13 @@
+package pipelineserver
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPipelineServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pipeline server controller suite")
+}
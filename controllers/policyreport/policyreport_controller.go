@@ -0,0 +1,283 @@
+// Package policyreport contains controller logic summarizing the security posture of the platform in PolicyReports
+// (wgpolicyk8s.io), so that compliance scanners can consume it like the reports of any policy engine.
+package policyreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	reportInterval = 10 * time.Minute
+	// ReportName is the name of the PolicyReport of each reported namespace.
+	ReportName = "opendatahub-security-posture"
+	// source identifies the operator as the producer of the results
+	source   = "opendatahub-operator"
+	category = "Open Data Hub security"
+
+	enableAuthAnnotation = "security.opendatahub.io/enable-auth"
+)
+
+// Policies reported on.
+const (
+	PolicyAuthorization = "opendatahub-authorization"
+	PolicyRouteTLS      = "opendatahub-route-tls"
+	PolicyNetworkPolicy = "opendatahub-network-policy"
+)
+
+// Result is an entry of a PolicyReport, as defined by the wgpolicyk8s.io/v1alpha2 API.
+type Result struct {
+	Policy    string                   `json:"policy"`
+	Rule      string                   `json:"rule"`
+	Result    string                   `json:"result"`
+	Message   string                   `json:"message"`
+	Source    string                   `json:"source"`
+	Category  string                   `json:"category"`
+	Severity  string                   `json:"severity"`
+	Resources []corev1.ObjectReference `json:"resources"`
+}
+
+// PolicyReportReconciler holds the controller configuration.
+type PolicyReportReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for security policy reports.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("policy-report-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile reports, for the applications namespace and the data science projects, which InferenceServices are
+// protected by the authorization of the mesh, which Routes enforce TLS and whether NetworkPolicies apply.
+// It requeues to report on workloads created or changed in the meantime.
+func (r *PolicyReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !featuregate.Enabled(featuregate.SecurityPolicyReports) {
+		return ctrl.Result{}, r.deleteReports(ctx)
+	}
+
+	namespaces, err := r.namespaces(ctx, instance.Spec.ApplicationsNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, namespace := range namespaces {
+		results, err := r.evaluate(ctx, instance, namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.publish(ctx, namespace, results); err != nil {
+			if meta.IsNoMatchError(err) {
+				r.Log.Info("PolicyReport CRD is not installed, skipping security policy reports")
+				break
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: reportInterval}, nil
+}
+
+// namespaces returns the applications namespace followed by the data science projects, sorted by name.
+func (r *PolicyReportReconciler) namespaces(ctx context.Context, applicationsNamespace string) ([]string, error) {
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+
+	var names []string
+	for _, ns := range projects.Items {
+		if ns.Name != applicationsNamespace {
+			names = append(names, ns.Name)
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{applicationsNamespace}, names...), nil
+}
+
+// evaluate returns the results of the policies for the workloads of the namespace.
+func (r *PolicyReportReconciler) evaluate(ctx context.Context, instance *dsciv1.DSCInitialization, namespace string) ([]Result, error) {
+	var results []Result
+
+	isvcs := &unstructured.UnstructuredList{}
+	isvcs.SetGroupVersionKind(gvk.InferenceService)
+	if err := r.Client.List(ctx, isvcs, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list InferenceServices: %w", err)
+	}
+	meshAuthorization := instance.Spec.ServiceMesh != nil && instance.Spec.ServiceMesh.ManagementState == operatorv1.Managed &&
+		instance.Spec.AuthorizationEnabled()
+	for i := range isvcs.Items {
+		isvc := &isvcs.Items[i]
+		res := newResult(PolicyAuthorization, "inference-service-authorization", "high", reference(isvc, gvk.InferenceService))
+		switch {
+		case !meshAuthorization:
+			res.fail("the authorization capability of the platform is not enabled")
+		case isvc.GetAnnotations()[enableAuthAnnotation] != "true":
+			res.fail("authorization is not enabled, set the " + enableAuthAnnotation + " annotation to \"true\"")
+		default:
+			res.pass("requests are authorized by the authorization provider of the mesh")
+		}
+		results = append(results, res)
+	}
+
+	routes := &routev1.RouteList{}
+	if err := r.Client.List(ctx, routes, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list Routes: %w", err)
+	}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		res := newResult(PolicyRouteTLS, "route-tls-termination", "medium",
+			reference(route, routev1.GroupVersion.WithKind("Route")))
+		switch {
+		case route.Spec.TLS == nil || route.Spec.TLS.Termination == "":
+			res.fail("the route is served without TLS")
+		case route.Spec.TLS.InsecureEdgeTerminationPolicy == routev1.InsecureEdgeTerminationPolicyAllow:
+			res.fail("the route allows insecure HTTP traffic")
+		default:
+			res.pass(fmt.Sprintf("the route enforces TLS with %s termination", route.Spec.TLS.Termination))
+		}
+		results = append(results, res)
+	}
+
+	networkPolicies := &networkingv1.NetworkPolicyList{}
+	if err := r.Client.List(ctx, networkPolicies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list NetworkPolicies: %w", err)
+	}
+	res := newResult(PolicyNetworkPolicy, "namespace-network-policy", "medium",
+		corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: namespace})
+	if len(networkPolicies.Items) == 0 {
+		res.fail("the namespace has no NetworkPolicy, all traffic to its pods is allowed")
+	} else {
+		res.pass(fmt.Sprintf("the namespace is covered by %d NetworkPolicies", len(networkPolicies.Items)))
+	}
+
+	return append(results, res), nil
+}
+
+func newResult(policy, rule, severity string, resource corev1.ObjectReference) Result {
+	return Result{
+		Policy:    policy,
+		Rule:      rule,
+		Source:    source,
+		Category:  category,
+		Severity:  severity,
+		Resources: []corev1.ObjectReference{resource},
+	}
+}
+
+func (res *Result) pass(message string) {
+	res.Result = "pass"
+	res.Message = message
+}
+
+func (res *Result) fail(message string) {
+	res.Result = "fail"
+	res.Message = message
+}
+
+func reference(obj client.Object, kind schema.GroupVersionKind) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: kind.GroupVersion().String(),
+		Kind:       kind.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+}
+
+// publish creates or updates the PolicyReport of the namespace with the results and their summary.
+func (r *PolicyReportReconciler) publish(ctx context.Context, namespace string, results []Result) error {
+	summary := map[string]any{"pass": int64(0), "fail": int64(0), "warn": int64(0), "error": int64(0), "skip": int64(0)}
+	for _, res := range results {
+		summary[res.Result] = summary[res.Result].(int64) + 1
+	}
+	// round-tripped, to compare with the decoded content of the existing report
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	var desired []any
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return err
+	}
+
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(gvk.PolicyReport)
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ReportName}, report)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && reflect.DeepEqual(report.Object["results"], desired) && reflect.DeepEqual(report.Object["summary"], summary) {
+		return nil
+	}
+
+	report.SetName(ReportName)
+	report.SetNamespace(namespace)
+	report.SetLabels(map[string]string{labels.K8SCommon.PartOf: source})
+	report.Object["results"] = desired
+	report.Object["summary"] = summary
+	if exists {
+		return r.Client.Update(ctx, report)
+	}
+
+	return r.Client.Create(ctx, report)
+}
+
+// deleteReports removes the PolicyReports created by the operator.
+func (r *PolicyReportReconciler) deleteReports(ctx context.Context) error {
+	reports := &unstructured.UnstructuredList{}
+	reports.SetGroupVersionKind(gvk.PolicyReport)
+	if err := r.Client.List(ctx, reports, client.MatchingLabels{labels.K8SCommon.PartOf: source}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range reports.Items {
+		if err := r.Client.Delete(ctx, &reports.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
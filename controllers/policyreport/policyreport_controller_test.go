@@ -0,0 +1,264 @@
+package policyreport
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func inferenceService(name string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk.InferenceService)
+	obj.SetName(name)
+	obj.SetNamespace("project")
+	obj.SetAnnotations(annotations)
+
+	return obj
+}
+
+func route(name string, tls *routev1.TLSConfig) *routev1.Route {
+	return &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "project"}, Spec: routev1.RouteSpec{TLS: tls}}
+}
+
+// outcomes returns the result of each policy by policy and resource name.
+func outcomes(results []Result) map[string]string {
+	outcomes := map[string]string{}
+	for _, res := range results {
+		outcomes[res.Policy+"/"+res.Resources[0].Name] = res.Result
+	}
+
+	return outcomes
+}
+
+var _ = Describe("Policy report controller", func() {
+	var (
+		instance *dsciv1.DSCInitialization
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	reconciler := func() *PolicyReportReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(routev1.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return &PolicyReportReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	}
+	evaluate := func(ctx context.Context) map[string]string {
+		GinkgoHelper()
+		results, err := reconciler().evaluate(ctx, instance, "project")
+		Expect(err).ToNot(HaveOccurred())
+		return outcomes(results)
+	}
+	reports := func(ctx context.Context) []unstructured.Unstructured {
+		GinkgoHelper()
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.PolicyReport)
+		Expect(cli.List(ctx, list)).To(Succeed())
+		return list.Items
+	}
+	BeforeEach(func() {
+		instance = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{
+				ApplicationsNamespace: "opendatahub",
+				ServiceMesh:           &infrav1.ServiceMeshSpec{ManagementState: operatorv1.Managed},
+			},
+		}
+		objects = []client.Object{
+			instance,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Labels: map[string]string{labels.ODH.Dashboard: "true"}}},
+			inferenceService("protected", map[string]string{enableAuthAnnotation: "true"}),
+			inferenceService("public", nil),
+			route("plain", nil),
+			route("edge", &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	Context("evaluating policies", func() {
+		It("should report on the workloads of the namespace", func(ctx context.Context) {
+			Expect(evaluate(ctx)).To(Equal(map[string]string{
+				PolicyAuthorization + "/protected": "pass",
+				PolicyAuthorization + "/public":    "fail",
+				PolicyRouteTLS + "/edge":           "pass",
+				PolicyRouteTLS + "/plain":          "fail",
+				PolicyNetworkPolicy + "/project":   "fail",
+			}))
+		})
+
+		It("should fail routes allowing insecure traffic", func(ctx context.Context) {
+			objects = append(objects, route("insecure", &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationEdge,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyAllow,
+			}))
+
+			Expect(evaluate(ctx)).To(HaveKeyWithValue(PolicyRouteTLS+"/insecure", "fail"))
+		})
+
+		It("should pass namespaces covered by a NetworkPolicy", func(ctx context.Context) {
+			objects = append(objects, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deny", Namespace: "project"}})
+
+			Expect(evaluate(ctx)).To(HaveKeyWithValue(PolicyNetworkPolicy+"/project", "pass"))
+		})
+
+		DescribeTable("should not consider any InferenceService protected without the authorization of the mesh",
+			func(ctx context.Context, disable func()) {
+				disable()
+
+				Expect(evaluate(ctx)).To(And(
+					HaveKeyWithValue(PolicyAuthorization+"/protected", "fail"),
+					HaveKeyWithValue(PolicyAuthorization+"/public", "fail"),
+				))
+			},
+			Entry("when the authorization capability is removed", func() {
+				instance.Spec.Capabilities = &dsciv1.Capabilities{Authorization: operatorv1.Removed}
+			}),
+			Entry("when the service mesh is removed", func() {
+				instance.Spec.ServiceMesh.ManagementState = operatorv1.Removed
+			}),
+			Entry("without service mesh", func() {
+				instance.Spec.ServiceMesh = nil
+			}),
+		)
+
+		It("should skip InferenceServices while their CRD is missing", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Group == gvk.InferenceService.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.InferenceService.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			Expect(evaluate(ctx)).ToNot(HaveKey(PolicyAuthorization + "/protected"))
+		})
+	})
+
+	Context("publishing results", func() {
+		var results []Result
+
+		BeforeEach(func() {
+			results = []Result{
+				newResult(PolicyNetworkPolicy, "namespace-network-policy", "medium", corev1.ObjectReference{Kind: "Namespace", Name: "project"}),
+			}
+			results[0].fail("no NetworkPolicy")
+		})
+
+		It("should summarize the results in the report of the namespace", func(ctx context.Context) {
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+
+			Expect(reports(ctx)).To(ConsistOf(HaveField("Object", HaveKeyWithValue("summary", And(
+				HaveKeyWithValue("fail", BeEquivalentTo(1)),
+				HaveKeyWithValue("pass", BeEquivalentTo(0)),
+			)))))
+		})
+
+		It("should not write unchanged results again", func(ctx context.Context) {
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+			version := reports(ctx)[0].GetResourceVersion()
+
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+
+			Expect(reports(ctx)[0].GetResourceVersion()).To(Equal(version))
+		})
+
+		It("should update changed results", func(ctx context.Context) {
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+
+			results[0].pass("covered")
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+
+			Expect(reports(ctx)[0].Object).To(HaveKeyWithValue("summary", HaveKeyWithValue("pass", BeEquivalentTo(1))))
+		})
+
+		It("should only delete the reports of the operator", func(ctx context.Context) {
+			other := &unstructured.Unstructured{}
+			other.SetGroupVersionKind(gvk.PolicyReport)
+			other.SetName("kyverno")
+			other.SetNamespace("project")
+			objects = append(objects, other)
+			Expect(reconciler().publish(ctx, "project", results)).To(Succeed())
+
+			Expect(reconciler().deleteReports(ctx)).To(Succeed())
+
+			Expect(reports(ctx)).To(ConsistOf(HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("name", "kyverno")))))
+		})
+	})
+
+	When("the feature gate is enabled", func() {
+		BeforeEach(func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.SecurityPolicyReports: true})).To(Succeed())
+			DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+		})
+
+		It("should report on the applications namespace and the projects, and check them again later on", func(ctx context.Context) {
+			result, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{RequeueAfter: reportInterval}))
+
+			Expect(reports(ctx)).To(ConsistOf(
+				HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("namespace", "opendatahub"))),
+				HaveField("Object", HaveKeyWithValue("metadata", HaveKeyWithValue("namespace", "project"))),
+			))
+		})
+
+		It("should skip the reports while the PolicyReport CRD is missing", func(ctx context.Context) {
+			funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if obj.GetObjectKind().GroupVersionKind().Group == gvk.PolicyReport.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.PolicyReport.GroupKind()}
+				}
+				return cli.Get(ctx, key, obj, opts...)
+			}
+
+			_, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should do nothing once the DSCInitialization is deleted", func(ctx context.Context) {
+			objects = objects[1:]
+
+			result, err := reconciler().Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(reports(ctx)).To(BeEmpty())
+		})
+	})
+
+	It("should delete the reports while the feature gate is disabled", func(ctx context.Context) {
+		Expect(reconciler().publish(ctx, "project", nil)).To(Succeed())
+
+		result, err := reconciler().Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+
+		Expect(reports(ctx)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,13 @@
+package policyreport
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicyReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Policy report suite")
+}
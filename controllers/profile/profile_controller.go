@@ -0,0 +1,374 @@
+// Package profile contains controller logic onboarding the owners of Profiles in their data science project, creating
+// the namespace of the project with the role bindings of its owner and contributors, its quota and its membership of
+// the service mesh, and instantiating the ProjectTemplate of the profile in it.
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	projectv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/project/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	ownerBindingName        = "profile-owner"
+	contributorsBindingName = "profile-contributors"
+	quotaName               = "profile-quota"
+	// serviceMeshMemberName is the name the service mesh requires for the ServiceMeshMember of a namespace.
+	serviceMeshMemberName = "default"
+)
+
+// +kubebuilder:rbac:groups="project.opendatahub.io",resources=profiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups="project.opendatahub.io",resources=profiles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups="maistra.io",resources=servicemeshmembers,verbs=get;create;delete
+
+// ProfileReconciler holds the controller configuration.
+type ProfileReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads the quotas and service mesh members of projects, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for profiles.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("profile-controller").
+		For(&projectv1alpha1.Profile{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// namespaces are annotated once their template is instantiated
+		Owns(&corev1.Namespace{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Complete(r)
+}
+
+// Reconcile creates the namespace of the profile, binds its owner and contributors to it, applies its quota and
+// enrolls it in the service mesh, and reports whether the template of the profile is instantiated. Namespaces which
+// do not belong to the profile are left untouched.
+func (r *ProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("profile", req.Name)
+
+	instance := &projectv1alpha1.Profile{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		// the namespace is deleted with the profile by the garbage collector
+		return ctrl.Result{}, nil
+	}
+
+	profileStatus := projectv1alpha1.ProfileStatus{Phase: status.PhaseReady, Namespace: instance.Name}
+	reconcileErr := r.reconcileNamespace(ctx, instance)
+	if reconcileErr == nil {
+		reconcileErr = errors.Join(
+			r.reconcileRoleBindings(ctx, instance),
+			r.reconcileQuota(ctx, instance),
+		)
+		var meshMessage string
+		var err error
+		profileStatus.ServiceMeshMember, meshMessage, err = r.reconcileServiceMesh(ctx, instance)
+		reconcileErr = errors.Join(reconcileErr, err)
+		if meshMessage != "" {
+			profileStatus.Phase, profileStatus.Message = status.PhaseProgressing, meshMessage
+		}
+	}
+	if reconcileErr == nil && profileStatus.Phase == status.PhaseReady {
+		waitingFor, err := r.templateProgress(ctx, instance)
+		if waitingFor != "" {
+			profileStatus.Phase, profileStatus.Message = status.PhaseProgressing, waitingFor
+		}
+		reconcileErr = err
+	}
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "Failed to set up profile")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "ProfileFailed", "Failed to set up the project of the profile: %v", reconcileErr)
+		profileStatus.Phase, profileStatus.Message = status.PhaseError, reconcileErr.Error()
+	}
+
+	if !reflect.DeepEqual(instance.Status, profileStatus) {
+		if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *projectv1alpha1.Profile) {
+			saved.Status = profileStatus
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, reconcileErr
+}
+
+// reconcileNamespace creates the namespace of the profile, or updates its display name and template. It fails when
+// the namespace exists and belongs to something else than the profile.
+func (r *ProfileReconciler) reconcileNamespace(ctx context.Context, instance *projectv1alpha1.Profile) error {
+	namespace := &corev1.Namespace{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: instance.Name}, namespace)
+	found := err == nil
+	switch {
+	case k8serr.IsNotFound(err):
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: instance.Name}}
+	case err != nil:
+		return fmt.Errorf("failed to get namespace %s: %w", instance.Name, err)
+	case !metav1.IsControlledBy(namespace, instance):
+		return fmt.Errorf("namespace %s already exists and does not belong to the profile", instance.Name)
+	case namespace.Status.Phase == corev1.NamespaceTerminating:
+		return fmt.Errorf("namespace %s is being deleted", instance.Name)
+	}
+
+	original := namespace.DeepCopy()
+	namespaceLabels := namespace.GetLabels()
+	if namespaceLabels == nil {
+		namespaceLabels = map[string]string{}
+	}
+	namespaceLabels[labels.ODH.Dashboard] = "true"
+	namespaceLabels[labels.ODH.Profile] = instance.Name
+	namespace.SetLabels(namespaceLabels)
+
+	namespaceAnnotations := namespace.GetAnnotations()
+	if namespaceAnnotations == nil {
+		namespaceAnnotations = map[string]string{}
+	}
+	namespaceAnnotations[annotations.DisplayName] = instance.Spec.DisplayName
+	if instance.Spec.DisplayName == "" {
+		namespaceAnnotations[annotations.DisplayName] = instance.Name
+	}
+	if instance.Spec.Template != "" {
+		namespaceAnnotations[annotations.ProjectTemplate] = instance.Spec.Template
+	} else {
+		// resources of the template already instantiated are kept
+		delete(namespaceAnnotations, annotations.ProjectTemplate)
+	}
+	namespace.SetAnnotations(namespaceAnnotations)
+
+	if !found {
+		if err := controllerutil.SetControllerReference(instance, namespace, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(ctx, namespace); err != nil {
+			return fmt.Errorf("failed to create namespace %s: %w", instance.Name, err)
+		}
+		r.Log.Info("Created namespace of profile", "profile", instance.Name)
+
+		return nil
+	}
+	if reflect.DeepEqual(original.ObjectMeta, namespace.ObjectMeta) {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, namespace, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update namespace %s: %w", instance.Name, err)
+	}
+
+	return nil
+}
+
+// reconcileRoleBindings binds the owner of the profile to the admin role of the namespace, and its contributors to
+// the edit role. The binding of the contributors is deleted when there are none.
+func (r *ProfileReconciler) reconcileRoleBindings(ctx context.Context, instance *projectv1alpha1.Profile) error {
+	ownerKey := types.NamespacedName{Namespace: instance.Name, Name: ownerBindingName}
+	if err := r.applyRoleBinding(ctx, instance, ownerKey, "admin", []projectv1alpha1.ProfileSubject{instance.Spec.Owner}); err != nil {
+		return err
+	}
+
+	contributorsKey := types.NamespacedName{Namespace: instance.Name, Name: contributorsBindingName}
+	if len(instance.Spec.Contributors) == 0 {
+		binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: contributorsKey.Name, Namespace: contributorsKey.Namespace}}
+		if err := r.Client.Delete(ctx, binding); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete RoleBinding %s: %w", contributorsKey, err)
+		}
+		return nil
+	}
+
+	return r.applyRoleBinding(ctx, instance, contributorsKey, "edit", instance.Spec.Contributors)
+}
+
+// applyRoleBinding creates the RoleBinding of the cluster role to the subjects, or updates its subjects if it already
+// exists.
+func (r *ProfileReconciler) applyRoleBinding(ctx context.Context, instance *projectv1alpha1.Profile, key types.NamespacedName, clusterRole string,
+	profileSubjects []projectv1alpha1.ProfileSubject,
+) error {
+	subjects := make([]rbacv1.Subject, 0, len(profileSubjects))
+	for _, subject := range profileSubjects {
+		subjects = append(subjects, rbacv1.Subject{Kind: subject.Kind, APIGroup: rbacv1.GroupName, Name: subject.Name})
+	}
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole}
+
+	found := &rbacv1.RoleBinding{}
+	err := r.Client.Get(ctx, key, found)
+	switch {
+	case k8serr.IsNotFound(err):
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    map[string]string{labels.ODH.Profile: instance.Name, labels.K8SCommon.PartOf: "opendatahub-operator"},
+			},
+			Subjects: subjects,
+			RoleRef:  roleRef,
+		}
+		if err = controllerutil.SetControllerReference(instance, binding, r.Scheme); err != nil {
+			return err
+		}
+		err = r.Client.Create(ctx, binding)
+	case err != nil:
+	case found.RoleRef != roleRef:
+		// the role of a binding cannot be changed, it is recreated on the next reconciliation
+		err = r.Client.Delete(ctx, found)
+	case !reflect.DeepEqual(found.Subjects, subjects):
+		found.Subjects = subjects
+		err = r.Client.Update(ctx, found)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bind %s role in %s: %w", clusterRole, key.Namespace, err)
+	}
+
+	return nil
+}
+
+// reconcileQuota creates or updates the ResourceQuota of the profile, and deletes it when the profile sets no quota.
+func (r *ProfileReconciler) reconcileQuota(ctx context.Context, instance *projectv1alpha1.Profile) error {
+	key := types.NamespacedName{Namespace: instance.Name, Name: quotaName}
+	found := &corev1.ResourceQuota{}
+	err := r.APIReader.Get(ctx, key, found)
+	switch {
+	case k8serr.IsNotFound(err):
+		if len(instance.Spec.Quota) == 0 {
+			return nil
+		}
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    map[string]string{labels.ODH.Profile: instance.Name},
+			},
+			Spec: corev1.ResourceQuotaSpec{Hard: instance.Spec.Quota},
+		}
+		if err := controllerutil.SetControllerReference(instance, quota, r.Scheme); err != nil {
+			return err
+		}
+		err = r.Client.Create(ctx, quota)
+	case err != nil:
+	case len(instance.Spec.Quota) == 0:
+		err = client.IgnoreNotFound(r.Client.Delete(ctx, found))
+	case !reflect.DeepEqual(found.Spec.Hard, instance.Spec.Quota):
+		found.Spec.Hard = instance.Spec.Quota
+		err = r.Client.Update(ctx, found)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply quota in %s: %w", key.Namespace, err)
+	}
+
+	return nil
+}
+
+// reconcileServiceMesh enrolls the namespace in the service mesh of the DSCInitialization when the profile requests
+// it, and removes the membership created for the profile otherwise. It returns whether the namespace is a member, and
+// what the membership is waiting for, if anything.
+func (r *ProfileReconciler) reconcileServiceMesh(ctx context.Context, instance *projectv1alpha1.Profile) (bool, string, error) {
+	member := &unstructured.Unstructured{}
+	member.SetGroupVersionKind(gvk.ServiceMeshMember)
+	err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: instance.Name, Name: serviceMeshMemberName}, member)
+	switch {
+	case meta.IsNoMatchError(err):
+		if instance.Spec.ServiceMesh {
+			return false, "waiting for the service mesh to be installed", nil
+		}
+		return false, "", nil
+	case k8serr.IsNotFound(err):
+	case err != nil:
+		return false, "", fmt.Errorf("failed to get service mesh member of %s: %w", instance.Name, err)
+	case !instance.Spec.ServiceMesh:
+		if member.GetLabels()[labels.ODH.Profile] != instance.Name {
+			// enrolled by something else than the profile
+			return true, "", nil
+		}
+		if err := r.Client.Delete(ctx, member); client.IgnoreNotFound(err) != nil {
+			return true, "", fmt.Errorf("failed to remove %s from the service mesh: %w", instance.Name, err)
+		}
+		return false, "", nil
+	default:
+		return true, "", nil
+	}
+	if !instance.Spec.ServiceMesh {
+		return false, "", nil
+	}
+
+	dscis := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, dscis); err != nil {
+		return false, "", fmt.Errorf("failed to list DSCInitializations: %w", err)
+	}
+	if len(dscis.Items) == 0 || dscis.Items[0].Spec.ServiceMesh == nil || dscis.Items[0].Spec.ServiceMesh.ManagementState != operatorv1.Managed {
+		return false, "waiting for the service mesh to be Managed in the DSCInitialization", nil
+	}
+	controlPlane := dscis.Items[0].Spec.ServiceMesh.ControlPlane
+
+	member = &unstructured.Unstructured{}
+	member.SetGroupVersionKind(gvk.ServiceMeshMember)
+	member.SetName(serviceMeshMemberName)
+	member.SetNamespace(instance.Name)
+	member.SetLabels(map[string]string{labels.ODH.Profile: instance.Name})
+	member.Object["spec"] = map[string]any{
+		"controlPlaneRef": map[string]any{"namespace": controlPlane.Namespace, "name": controlPlane.Name},
+	}
+	if err := r.Client.Create(ctx, member); client.IgnoreAlreadyExists(err) != nil {
+		return false, "", fmt.Errorf("failed to enroll %s in the service mesh: %w", instance.Name, err)
+	}
+	r.Log.Info("Enrolled namespace of profile in the service mesh", "profile", instance.Name)
+
+	return true, "", nil
+}
+
+// templateProgress returns what the instantiation of the template of the profile is waiting for, if anything.
+func (r *ProfileReconciler) templateProgress(ctx context.Context, instance *projectv1alpha1.Profile) (string, error) {
+	if instance.Spec.Template == "" {
+		return "", nil
+	}
+
+	template := &projectv1alpha1.ProjectTemplate{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: instance.Spec.Template}, template); err != nil {
+		if k8serr.IsNotFound(err) {
+			return fmt.Sprintf("waiting for ProjectTemplate %s to be created", instance.Spec.Template), nil
+		}
+		return "", fmt.Errorf("failed to get ProjectTemplate %s: %w", instance.Spec.Template, err)
+	}
+	for _, project := range template.Status.Projects {
+		if project.Name == instance.Name && project.Phase != projectv1alpha1.ProjectReady && project.Message != "" {
+			return fmt.Sprintf("ProjectTemplate %s is not instantiated yet: %s", instance.Spec.Template, project.Message), nil
+		}
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: instance.Name}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %s: %w", instance.Name, err)
+	}
+	if namespace.GetAnnotations()[annotations.ProjectTemplateInstantiated] != instance.Spec.Template {
+		return fmt.Sprintf("waiting for ProjectTemplate %s to be instantiated", instance.Spec.Template), nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,402 @@
+package profile
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	projectv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/project/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func serviceMeshMember(namespace string, memberLabels map[string]string) *unstructured.Unstructured {
+	member := &unstructured.Unstructured{}
+	member.SetGroupVersionKind(gvk.ServiceMeshMember)
+	member.SetNamespace(namespace)
+	member.SetName(serviceMeshMemberName)
+	member.SetLabels(memberLabels)
+	return member
+}
+
+var _ = Describe("Profile controller", func() {
+	var (
+		instance *projectv1alpha1.Profile
+		dsci     *dsciv1.DSCInitialization
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		instance = &projectv1alpha1.Profile{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+			Spec: projectv1alpha1.ProfileSpec{
+				Owner:        projectv1alpha1.ProfileSubject{Kind: "User", Name: "alice"},
+				Contributors: []projectv1alpha1.ProfileSubject{{Kind: "Group", Name: "fraud-team"}},
+			},
+		}
+		dsci = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{ServiceMesh: &infrav1.ServiceMeshSpec{
+				ManagementState: operatorv1.Managed,
+				ControlPlane:    infrav1.ControlPlaneSpec{Name: "data-science-smcp", Namespace: "istio-system"},
+			}},
+		}
+		objects = []client.Object{dsci}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) error {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(projectv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&projectv1alpha1.Profile{}).
+				WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &ProfileReconciler{Client: cli, Scheme: cli.Scheme(), APIReader: cli, Log: logr.Discard(), Recorder: recorder}
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+		return err
+	}
+	// update changes the spec of the saved profile
+	update := func(ctx context.Context, change func(spec *projectv1alpha1.ProfileSpec)) {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+		change(&instance.Spec)
+		Expect(cli.Update(ctx, instance)).To(Succeed())
+	}
+	profileStatus := func(ctx context.Context) projectv1alpha1.ProfileStatus {
+		GinkgoHelper()
+		saved := &projectv1alpha1.Profile{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+		return saved.Status
+	}
+	namespace := func(ctx context.Context, name string) *corev1.Namespace {
+		GinkgoHelper()
+		namespace := &corev1.Namespace{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: name}, namespace)).To(Succeed())
+		return namespace
+	}
+	get := func(ctx context.Context, name string, obj client.Object) error {
+		return cli.Get(ctx, client.ObjectKey{Namespace: "alice", Name: name}, obj)
+	}
+
+	Describe("namespace", func() {
+		It("should create the data science project of the profile", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			namespace := namespace(ctx, "alice")
+			Expect(metav1.IsControlledBy(namespace, instance)).To(BeTrue())
+			Expect(namespace.Labels).To(HaveKeyWithValue(labels.ODH.Dashboard, "true"))
+			Expect(namespace.Labels).To(HaveKeyWithValue(labels.ODH.Profile, "alice"))
+			Expect(namespace.Annotations).To(Equal(map[string]string{annotations.DisplayName: "alice"}))
+			Expect(profileStatus(ctx)).To(Equal(projectv1alpha1.ProfileStatus{Phase: status.PhaseReady, Namespace: "alice"}))
+		})
+
+		It("should update the display name and the template of the project", func(ctx context.Context) {
+			instance.Spec.Template = "starter"
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(namespace(ctx, "alice").Annotations).To(HaveKeyWithValue(annotations.ProjectTemplate, "starter"))
+
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) {
+				spec.DisplayName = "Alice's experiments"
+				spec.Template = ""
+			})
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(namespace(ctx, "alice").Annotations).To(Equal(map[string]string{annotations.DisplayName: "Alice's experiments"}))
+		})
+
+		It("should leave the namespaces of someone else untouched", func(ctx context.Context) {
+			objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "alice"}})
+
+			Expect(reconcile(ctx)).To(MatchError("namespace alice already exists and does not belong to the profile"))
+			Expect(namespace(ctx, "alice").ObjectMeta).To(And(HaveField("Labels", BeEmpty()), HaveField("Annotations", BeEmpty())))
+			Expect(get(ctx, ownerBindingName, &rbacv1.RoleBinding{})).To(Satisfy(k8serr.IsNotFound))
+			Expect(profileStatus(ctx)).To(And(
+				HaveField("Phase", status.PhaseError),
+				HaveField("Message", "namespace alice already exists and does not belong to the profile"),
+			))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning ProfileFailed")))
+		})
+
+		It("should wait for the namespace being deleted", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			terminating := namespace(ctx, "alice")
+			terminating.Status.Phase = corev1.NamespaceTerminating
+			Expect(cli.Status().Update(ctx, terminating)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(MatchError("namespace alice is being deleted"))
+		})
+	})
+
+	Describe("role bindings", func() {
+		binding := func(ctx context.Context, name string) *rbacv1.RoleBinding {
+			GinkgoHelper()
+			binding := &rbacv1.RoleBinding{}
+			Expect(get(ctx, name, binding)).To(Succeed())
+			return binding
+		}
+
+		DescribeTable("should bind the subjects of the profile to their role",
+			func(ctx context.Context, name, role string, subject rbacv1.Subject) {
+				Expect(reconcile(ctx)).To(Succeed())
+
+				binding := binding(ctx, name)
+				Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role}))
+				Expect(binding.Subjects).To(Equal([]rbacv1.Subject{subject}))
+				Expect(binding.Labels).To(HaveKeyWithValue(labels.ODH.Profile, "alice"))
+				Expect(metav1.IsControlledBy(binding, instance)).To(BeTrue())
+			},
+			Entry("owner", ownerBindingName, "admin", rbacv1.Subject{Kind: "User", APIGroup: rbacv1.GroupName, Name: "alice"}),
+			Entry("contributors", contributorsBindingName, "edit", rbacv1.Subject{Kind: "Group", APIGroup: rbacv1.GroupName, Name: "fraud-team"}),
+		)
+
+		It("should update the contributors", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) {
+				spec.Contributors = append(spec.Contributors, projectv1alpha1.ProfileSubject{Kind: "User", Name: "bob"})
+			})
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(binding(ctx, contributorsBindingName).Subjects).To(HaveExactElements(HaveField("Name", "fraud-team"), HaveField("Name", "bob")))
+		})
+
+		It("should delete the binding of the contributors once there are none", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) { spec.Contributors = nil })
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, contributorsBindingName, &rbacv1.RoleBinding{})).To(Satisfy(k8serr.IsNotFound))
+			Expect(binding(ctx, ownerBindingName).Subjects).To(HaveLen(1))
+		})
+
+		It("should recreate the bindings of another role", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			changed := binding(ctx, ownerBindingName)
+			Expect(cli.Delete(ctx, changed)).To(Succeed())
+			changed.ResourceVersion = ""
+			changed.RoleRef.Name = "view"
+			Expect(cli.Create(ctx, changed)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, ownerBindingName, &rbacv1.RoleBinding{})).To(Satisfy(k8serr.IsNotFound))
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(binding(ctx, ownerBindingName).RoleRef.Name).To(Equal("admin"))
+		})
+
+		It("should report the bindings failing to be created", func(ctx context.Context) {
+			funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, isBinding := obj.(*rbacv1.RoleBinding); isBinding {
+					return errors.New("forbidden")
+				}
+				return cli.Create(ctx, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(MatchError("failed to bind admin role in alice: forbidden"))
+			Expect(profileStatus(ctx).Phase).To(Equal(status.PhaseError))
+		})
+	})
+
+	Describe("quota", func() {
+		quota := func(ctx context.Context) *corev1.ResourceQuota {
+			GinkgoHelper()
+			quota := &corev1.ResourceQuota{}
+			Expect(get(ctx, quotaName, quota)).To(Succeed())
+			return quota
+		}
+
+		BeforeEach(func() {
+			instance.Spec.Quota = corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("1")}
+		})
+
+		It("should apply the quota of the profile", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(quota(ctx).Spec.Hard).To(Equal(corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("1")}))
+			Expect(quota(ctx).Labels).To(HaveKeyWithValue(labels.ODH.Profile, "alice"))
+		})
+
+		It("should update the quota", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) {
+				spec.Quota = corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("2")}
+			})
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(quota(ctx).Spec.Hard).To(Equal(corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("2")}))
+		})
+
+		It("should delete the quota once the profile sets none", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) { spec.Quota = nil })
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, quotaName, &corev1.ResourceQuota{})).To(Satisfy(k8serr.IsNotFound))
+		})
+
+		It("should not create a quota the profile does not set", func(ctx context.Context) {
+			instance.Spec.Quota = nil
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, quotaName, &corev1.ResourceQuota{})).To(Satisfy(k8serr.IsNotFound))
+		})
+	})
+
+	Describe("service mesh", func() {
+		BeforeEach(func() {
+			instance.Spec.ServiceMesh = true
+		})
+
+		It("should enroll the project in the service mesh of the DSCInitialization", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			member := serviceMeshMember("", nil)
+			Expect(get(ctx, serviceMeshMemberName, member)).To(Succeed())
+			Expect(member.Object).To(HaveKeyWithValue("spec", map[string]any{
+				"controlPlaneRef": map[string]any{"namespace": "istio-system", "name": "data-science-smcp"},
+			}))
+			Expect(member.GetLabels()).To(HaveKeyWithValue(labels.ODH.Profile, "alice"))
+			Expect(profileStatus(ctx)).To(And(HaveField("Phase", status.PhaseReady), HaveField("ServiceMeshMember", BeTrue())))
+		})
+
+		It("should remove the project from the service mesh once not requested", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(spec *projectv1alpha1.ProfileSpec) { spec.ServiceMesh = false })
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, serviceMeshMemberName, serviceMeshMember("", nil))).To(Satisfy(k8serr.IsNotFound))
+			Expect(profileStatus(ctx).ServiceMeshMember).To(BeFalse())
+		})
+
+		It("should keep the membership of the project created by something else", func(ctx context.Context) {
+			instance.Spec.ServiceMesh = false
+			objects = append(objects, serviceMeshMember("alice", nil))
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(get(ctx, serviceMeshMemberName, serviceMeshMember("", nil))).To(Succeed())
+			Expect(profileStatus(ctx).ServiceMeshMember).To(BeTrue())
+		})
+
+		DescribeTable("should wait for the service mesh to be managed",
+			func(ctx context.Context, serviceMesh *infrav1.ServiceMeshSpec) {
+				dsci.Spec.ServiceMesh = serviceMesh
+
+				Expect(reconcile(ctx)).To(Succeed())
+				Expect(profileStatus(ctx)).To(And(
+					HaveField("Phase", status.PhaseProgressing),
+					HaveField("Message", "waiting for the service mesh to be Managed in the DSCInitialization"),
+					HaveField("ServiceMeshMember", BeFalse()),
+				))
+			},
+			Entry("not set", (*infrav1.ServiceMeshSpec)(nil)),
+			Entry("removed", &infrav1.ServiceMeshSpec{ManagementState: operatorv1.Removed}),
+		)
+
+		It("should wait for the service mesh to be installed", func(ctx context.Context) {
+			funcs.Get = func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if obj.GetObjectKind().GroupVersionKind() == gvk.ServiceMeshMember {
+					return &meta.NoKindMatchError{GroupKind: gvk.ServiceMeshMember.GroupKind()}
+				}
+				return cli.Get(ctx, key, obj, opts...)
+			}
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(profileStatus(ctx)).To(And(
+				HaveField("Phase", status.PhaseProgressing),
+				HaveField("Message", "waiting for the service mesh to be installed"),
+			))
+		})
+	})
+
+	Describe("template", func() {
+		BeforeEach(func() {
+			instance.Spec.Template = "starter"
+		})
+
+		It("should wait for the ProjectTemplate to be created", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(profileStatus(ctx)).To(And(
+				HaveField("Phase", status.PhaseProgressing),
+				HaveField("Message", "waiting for ProjectTemplate starter to be created"),
+			))
+		})
+
+		It("should tell what the instantiation of the template is waiting for", func(ctx context.Context) {
+			objects = append(objects, &projectv1alpha1.ProjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "starter"},
+				Status: projectv1alpha1.ProjectTemplateStatus{Projects: []projectv1alpha1.ProjectStatus{
+					{Name: "bob", Phase: projectv1alpha1.ProjectFailed, Message: "quota exceeded"},
+					{Name: "alice", Phase: projectv1alpha1.ProjectPending, Message: "waiting for the Notebook CRD"},
+				}},
+			})
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(profileStatus(ctx).Message).To(Equal("ProjectTemplate starter is not instantiated yet: waiting for the Notebook CRD"))
+		})
+
+		It("should be ready once the template is instantiated", func(ctx context.Context) {
+			objects = append(objects, &projectv1alpha1.ProjectTemplate{ObjectMeta: metav1.ObjectMeta{Name: "starter"}})
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(profileStatus(ctx)).To(And(
+				HaveField("Phase", status.PhaseProgressing),
+				HaveField("Message", "waiting for ProjectTemplate starter to be instantiated"),
+			))
+
+			instantiated := namespace(ctx, "alice")
+			instantiated.Annotations[annotations.ProjectTemplateInstantiated] = "starter"
+			Expect(cli.Update(ctx, instantiated)).To(Succeed())
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(profileStatus(ctx)).To(Equal(projectv1alpha1.ProfileStatus{Phase: status.PhaseReady, Namespace: "alice"}))
+		})
+	})
+
+	It("should not update the status when unchanged", func(ctx context.Context) {
+		statusUpdates := 0
+		funcs.SubResourceUpdate = func(ctx context.Context, cli client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			statusUpdates++
+			return cli.SubResource(subResourceName).Update(ctx, obj, opts...)
+		}
+
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(statusUpdates).To(Equal(1))
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(statusUpdates).To(Equal(1))
+	})
+
+	It("should ignore a deleted Profile", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Succeed())
+	})
+})
@@ -0,0 +1,360 @@
+// Package projecttemplate contains controller logic instantiating ProjectTemplates in the data science projects
+// requesting them, creating the data connections, workbench, pipeline server and quota of the template.
+package projecttemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	projectv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/project/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/datasciencepipelines"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/dataconnection"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/pipelineserver"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// pendingRequeue is how often pending projects are checked again, as data connection Secrets are not watched.
+	pendingRequeue = time.Minute
+
+	// workbenchMountPath is where the home directory of the workbench is mounted in notebook images.
+	workbenchMountPath = "/opt/app-root/src"
+)
+
+// +kubebuilder:rbac:groups="project.opendatahub.io",resources=projecttemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups="project.opendatahub.io",resources=projecttemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="kubeflow.org",resources=notebooks,verbs=get;create
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;create
+
+// ProjectTemplateReconciler holds the controller configuration.
+type ProjectTemplateReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads data connection Secrets and workbench claims of data science projects, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProjectTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for project templates.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("project-template-controller").
+		For(&projectv1alpha1.ProjectTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(watchNamespaces), builder.WithPredicates(templateRequested)).
+		Complete(r)
+}
+
+// Reconcile creates the resources of the template in the namespaces annotated with its name, and reports the
+// progress of each of them. Namespaces are annotated once instantiated, and not reconciled anymore afterwards.
+func (r *ProjectTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("projecttemplate", req.Name)
+
+	instance := &projectv1alpha1.ProjectTemplate{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		// instantiated resources belong to the projects, they are kept
+		return ctrl.Result{}, nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, namespaces); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	var errs []error
+	projects := []projectv1alpha1.ProjectStatus{}
+	phase := status.PhaseReady
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if namespace.GetAnnotations()[annotations.ProjectTemplate] != instance.Name || namespace.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		project := projectv1alpha1.ProjectStatus{Name: namespace.Name, Phase: projectv1alpha1.ProjectReady}
+		if namespace.GetAnnotations()[annotations.ProjectTemplateInstantiated] != instance.Name {
+			waitingFor, err := r.instantiate(ctx, instance, namespace)
+			switch {
+			case err != nil:
+				errs = append(errs, err)
+				project.Phase, project.Message = projectv1alpha1.ProjectFailed, err.Error()
+				phase = status.PhaseError
+			case waitingFor != "":
+				project.Phase, project.Message = projectv1alpha1.ProjectPending, waitingFor
+				if phase != status.PhaseError {
+					phase = status.PhaseProgressing
+				}
+			default:
+				log.Info("Instantiated project template", "namespace", namespace.Name)
+			}
+		}
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	reconcileErr := errors.Join(errs...)
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "Failed to instantiate project template")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "ProjectTemplateFailed", "Failed to instantiate project template: %v", reconcileErr)
+	}
+
+	if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *projectv1alpha1.ProjectTemplate) {
+		saved.Status.Projects = projects
+		saved.Status.Phase = phase
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+	if phase == status.PhaseProgressing {
+		return ctrl.Result{RequeueAfter: pendingRequeue}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// instantiate creates the missing resources of the template in the namespace, and returns what the project is waiting
+// for before its pipeline server can be created, if anything. The namespace is annotated once all resources exist.
+func (r *ProjectTemplateReconciler) instantiate(ctx context.Context, instance *projectv1alpha1.ProjectTemplate, namespace *corev1.Namespace) (string, error) {
+	spec := &instance.Spec
+
+	if len(spec.Quota) > 0 {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: namespace.Name},
+			Spec:       corev1.ResourceQuotaSpec{Hard: spec.Quota},
+		}
+		if err := r.Client.Create(ctx, quota); client.IgnoreAlreadyExists(err) != nil {
+			return "", fmt.Errorf("failed to create quota in %s: %w", namespace.Name, err)
+		}
+	}
+
+	for _, placeholder := range spec.DataConnections {
+		if err := r.Client.Create(ctx, desiredDataConnection(placeholder, namespace.Name)); client.IgnoreAlreadyExists(err) != nil {
+			return "", fmt.Errorf("failed to create data connection %s in %s: %w", placeholder.Name, namespace.Name, err)
+		}
+	}
+
+	if workbench := spec.Workbench; workbench != nil {
+		storage := &components.PersistentStorage{StorageClassName: workbench.StorageClassName, Size: workbench.StorageSize}
+		if err := storage.Apply(ctx, r.Client, r.APIReader, workbench.Name, namespace.Name); err != nil {
+			return "", err
+		}
+		notebook, err := desiredWorkbench(workbench, spec.DataConnections, namespace.Name)
+		if err != nil {
+			return "", err
+		}
+		if err := r.Client.Create(ctx, notebook); client.IgnoreAlreadyExists(err) != nil {
+			if meta.IsNoMatchError(err) {
+				return "workbenches are not installed", nil
+			}
+			return "", fmt.Errorf("failed to create workbench in %s: %w", namespace.Name, err)
+		}
+	}
+
+	if server := spec.PipelineServer; server != nil {
+		waitingFor, err := r.createPipelineServer(ctx, server, namespace.Name)
+		if err != nil || waitingFor != "" {
+			return waitingFor, err
+		}
+	}
+
+	original := namespace.DeepCopy()
+	namespaceLabels := namespace.GetLabels()
+	if namespaceLabels == nil {
+		namespaceLabels = map[string]string{}
+	}
+	namespaceLabels[labels.ODH.Dashboard] = "true"
+	namespace.SetLabels(namespaceLabels)
+	namespaceAnnotations := namespace.GetAnnotations()
+	namespaceAnnotations[annotations.ProjectTemplateInstantiated] = instance.Name
+	if spec.PipelineServer != nil {
+		// the pipeline server of the template takes the place of the one seeded by the operator
+		namespaceAnnotations[annotations.PipelineServer] = spec.PipelineServer.Name
+	}
+	namespace.SetAnnotations(namespaceAnnotations)
+	if err := r.Client.Patch(ctx, namespace, client.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("failed to annotate %s: %w", namespace.Name, err)
+	}
+
+	return "", nil
+}
+
+// createPipelineServer creates the pipeline server of the template once the credentials of its data connection are
+// filled in, returning what it is waiting for otherwise.
+func (r *ProjectTemplateReconciler) createPipelineServer(ctx context.Context, server *projectv1alpha1.PipelineServerTemplate, namespace string) (string, error) {
+	secret := &corev1.Secret{}
+	secretName := dataconnection.SecretPrefix + server.DataConnection
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: secretName, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to get data connection %s of %s: %w", server.DataConnection, namespace, err)
+	}
+	if len(secret.Data["AWS_ACCESS_KEY_ID"]) == 0 || len(secret.Data["AWS_SECRET_ACCESS_KEY"]) == 0 {
+		return fmt.Sprintf("waiting for the credentials of data connection %s to be filled in", server.DataConnection), nil
+	}
+
+	spec := &datasciencepipelines.PipelineServerSpec{
+		ManagementState: operatorv1.Managed,
+		Name:            server.Name,
+		DSPVersion:      "v2",
+		ObjectStorage: datasciencepipelines.PipelineObjectStorage{
+			Provisioner:    datasciencepipelines.DataConnectionProvisioner,
+			DataConnection: secretName,
+		},
+	}
+	dspa, err := pipelineserver.ForDataConnection(spec, secret)
+	if err != nil {
+		// endpoint or bucket left to users to fill in
+		return fmt.Sprintf("waiting for data connection %s to be completed: %v", server.DataConnection, err), nil
+	}
+	if err := r.Client.Create(ctx, dspa); client.IgnoreAlreadyExists(err) != nil {
+		if meta.IsNoMatchError(err) {
+			return "pipeline servers are not installed", nil
+		}
+		return "", fmt.Errorf("failed to create pipeline server in %s: %w", namespace, err)
+	}
+
+	return "", nil
+}
+
+// desiredDataConnection returns the Secret of the data connection, labeled for the dashboard, without credentials.
+func desiredDataConnection(placeholder projectv1alpha1.DataConnectionPlaceholder, namespace string) *corev1.Secret {
+	displayName := placeholder.DisplayName
+	if displayName == "" {
+		displayName = placeholder.Name
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dataconnection.SecretPrefix + placeholder.Name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				labels.ODH.Dashboard: "true",
+			},
+			Annotations: map[string]string{
+				annotations.ConnectionType: "s3",
+				annotations.DisplayName:    displayName,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     {},
+			"AWS_SECRET_ACCESS_KEY": {},
+			"AWS_S3_ENDPOINT":       []byte(placeholder.Endpoint),
+			"AWS_DEFAULT_REGION":    []byte(placeholder.Region),
+			"AWS_S3_BUCKET":         []byte(placeholder.Bucket),
+		},
+	}
+}
+
+// desiredWorkbench returns the Notebook of the workbench, with its home directory on the volume of the same name and
+// the data connections of the template in its environment.
+func desiredWorkbench(workbench *projectv1alpha1.WorkbenchTemplate, dataConnections []projectv1alpha1.DataConnectionPlaceholder, namespace string) (*unstructured.Unstructured, error) {
+	container := corev1.Container{
+		Name:       workbench.Name,
+		Image:      workbench.Image,
+		WorkingDir: workbenchMountPath,
+		Resources:  workbench.Resources,
+		Ports:      []corev1.ContainerPort{{Name: "notebook-port", ContainerPort: 8888, Protocol: corev1.ProtocolTCP}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      workbench.Name,
+			MountPath: workbenchMountPath,
+		}},
+	}
+	for _, placeholder := range dataConnections {
+		container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: dataconnection.SecretPrefix + placeholder.Name}},
+		})
+	}
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{container},
+		Volumes: []corev1.Volume{{
+			Name: workbench.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: workbench.Name},
+			},
+		}},
+	}
+	podSpecFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&podSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert workbench of %s: %w", namespace, err)
+	}
+
+	notebook := &unstructured.Unstructured{}
+	notebook.SetGroupVersionKind(gvk.Notebook)
+	notebook.SetName(workbench.Name)
+	notebook.SetNamespace(namespace)
+	notebook.SetLabels(map[string]string{
+		"app":                workbench.Name,
+		labels.ODH.Dashboard: "true",
+	})
+	notebook.SetAnnotations(map[string]string{
+		annotations.DisplayName:                 workbench.Name,
+		"notebooks.opendatahub.io/inject-oauth": "true",
+	})
+	notebook.Object["spec"] = map[string]any{
+		"template": map[string]any{"spec": podSpecFields},
+	}
+
+	return notebook, nil
+}
+
+// watchNamespaces reconciles the template requested by the namespace.
+func watchNamespaces(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetAnnotations()[annotations.ProjectTemplate]
+	if name == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
+// templateRequested passes namespaces annotated with a template which has not been instantiated in them yet.
+var templateRequested = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return pendingTemplate(e.Object)
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return pendingTemplate(e.ObjectNew) &&
+			e.ObjectOld.GetAnnotations()[annotations.ProjectTemplate] != e.ObjectNew.GetAnnotations()[annotations.ProjectTemplate]
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		// removed from the status of the template
+		return e.Object.GetAnnotations()[annotations.ProjectTemplate] != ""
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
+
+func pendingTemplate(obj client.Object) bool {
+	name := obj.GetAnnotations()[annotations.ProjectTemplate]
+
+	return name != "" && obj.GetAnnotations()[annotations.ProjectTemplateInstantiated] != name
+}
@@ -0,0 +1,262 @@
+package projecttemplate
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	projectv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/project/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func namespace(name string, namespaceAnnotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: namespaceAnnotations}}
+}
+
+var _ = Describe("Project template controller", func() {
+	var (
+		instance *projectv1alpha1.ProjectTemplate
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "starter"}}
+	)
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(projectv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&projectv1alpha1.ProjectTemplate{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &ProjectTemplateReconciler{Client: cli, Scheme: cli.Scheme(), APIReader: cli, Log: logr.Discard(), Recorder: recorder}
+		return r.Reconcile(ctx, req)
+	}
+	projects := func(ctx context.Context) []projectv1alpha1.ProjectStatus {
+		GinkgoHelper()
+		saved := &projectv1alpha1.ProjectTemplate{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		return saved.Status.Projects
+	}
+	get := func(ctx context.Context, kind schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(kind)
+		return obj, cli.Get(ctx, client.ObjectKey{Name: name, Namespace: "fraud"}, obj)
+	}
+	fillCredentials := func(ctx context.Context) {
+		GinkgoHelper()
+		secret := &corev1.Secret{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "aws-connection-artifacts", Namespace: "fraud"}, secret)).To(Succeed())
+		secret.Data["AWS_ACCESS_KEY_ID"] = []byte("key")
+		secret.Data["AWS_SECRET_ACCESS_KEY"] = []byte("secret")
+		Expect(cli.Update(ctx, secret)).To(Succeed())
+	}
+	noKindMatch := func(kind schema.GroupVersionKind) func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+		return func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if obj.GetObjectKind().GroupVersionKind() == kind {
+				return &meta.NoKindMatchError{GroupKind: kind.GroupKind()}
+			}
+			return cli.Create(ctx, obj, opts...)
+		}
+	}
+
+	BeforeEach(func() {
+		instance = &projectv1alpha1.ProjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "starter"},
+			Spec: projectv1alpha1.ProjectTemplateSpec{
+				DataConnections: []projectv1alpha1.DataConnectionPlaceholder{
+					{Name: "artifacts", Endpoint: "https://s3.example.com", Bucket: "pipelines"},
+				},
+				Workbench: &projectv1alpha1.WorkbenchTemplate{
+					Name: "workbench", Image: "jupyter-datascience-notebook:2024.1", StorageSize: resource.MustParse("20Gi"),
+				},
+				PipelineServer: &projectv1alpha1.PipelineServerTemplate{Name: "dspa", DataConnection: "artifacts"},
+				Quota:          corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8")},
+			},
+		}
+		objects = []client.Object{
+			instance,
+			namespace("fraud", map[string]string{annotations.ProjectTemplate: "starter"}),
+			namespace("sales", nil),
+			namespace("marketing", map[string]string{annotations.ProjectTemplate: "other"}),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	When("the credentials of the data connection are not filled in", func() {
+		It("should create the resources of the template but the pipeline server", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: pendingRequeue}))
+
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "starter", Namespace: "fraud"}, &corev1.ResourceQuota{})).To(Succeed())
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "workbench", Namespace: "fraud"}, &corev1.PersistentVolumeClaim{})).To(Succeed())
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "aws-connection-artifacts", Namespace: "fraud"}, secret)).To(Succeed())
+			Expect(secret.Labels).To(HaveKeyWithValue(labels.ODH.Dashboard, "true"))
+			Expect(secret.Data).To(HaveKeyWithValue("AWS_S3_BUCKET", BeEquivalentTo("pipelines")))
+			notebook, err := get(ctx, gvk.Notebook, "workbench")
+			Expect(err).ToNot(HaveOccurred())
+			containers, _, _ := unstructured.NestedSlice(notebook.Object, "spec", "template", "spec", "containers")
+			Expect(containers).To(ConsistOf(HaveKeyWithValue("envFrom", ConsistOf(
+				HaveKeyWithValue("secretRef", HaveKeyWithValue("name", "aws-connection-artifacts"))))))
+			_, err = get(ctx, gvk.DataSciencePipelinesApplication, "dspa")
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should report the project as pending", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(projects(ctx)).To(HaveExactElements(And(
+				HaveField("Name", "fraud"),
+				HaveField("Phase", projectv1alpha1.ProjectPending),
+				HaveField("Message", ContainSubstring("data connection artifacts")),
+			)))
+			ns := &corev1.Namespace{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "fraud"}, ns)).To(Succeed())
+			Expect(ns.Annotations).ToNot(HaveKey(annotations.ProjectTemplateInstantiated))
+		})
+	})
+
+	When("the credentials of the data connection are filled in", func() {
+		BeforeEach(func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			fillCredentials(ctx)
+		})
+
+		It("should create the pipeline server and mark the project as instantiated", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+			_, err := get(ctx, gvk.DataSciencePipelinesApplication, "dspa")
+			Expect(err).ToNot(HaveOccurred())
+			ns := &corev1.Namespace{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "fraud"}, ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue(labels.ODH.Dashboard, "true"))
+			Expect(ns.Annotations).To(HaveKeyWithValue(annotations.ProjectTemplateInstantiated, "starter"))
+			Expect(ns.Annotations).To(HaveKeyWithValue(annotations.PipelineServer, "dspa"))
+			Expect(projects(ctx)).To(HaveExactElements(HaveField("Phase", projectv1alpha1.ProjectReady)))
+		})
+
+		It("should not reconcile instantiated projects anymore", func(ctx context.Context) {
+			_, err := reconcile(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cli.Delete(ctx, &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "starter", Namespace: "fraud"}})).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+			err = cli.Get(ctx, client.ObjectKey{Name: "starter", Namespace: "fraud"}, &corev1.ResourceQuota{})
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			Expect(projects(ctx)).To(HaveExactElements(HaveField("Phase", projectv1alpha1.ProjectReady)))
+		})
+	})
+
+	It("should instantiate templates without pipeline server at once", func(ctx context.Context) {
+		instance.Spec.PipelineServer = nil
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+		ns := &corev1.Namespace{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "fraud"}, ns)).To(Succeed())
+		Expect(ns.Annotations).To(HaveKeyWithValue(annotations.ProjectTemplateInstantiated, "starter"))
+		Expect(ns.Annotations).ToNot(HaveKey(annotations.PipelineServer))
+	})
+
+	It("should skip terminating namespaces", func(ctx context.Context) {
+		terminating := namespace("legacy", map[string]string{annotations.ProjectTemplate: "starter"})
+		terminating.Status.Phase = corev1.NamespaceTerminating
+		objects = append(objects, terminating)
+
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(projects(ctx)).To(HaveExactElements(HaveField("Name", "fraud")))
+	})
+
+	It("should wait for workbenches to be installed", func(ctx context.Context) {
+		funcs.Create = noKindMatch(gvk.Notebook)
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: pendingRequeue}))
+
+		Expect(projects(ctx)).To(HaveExactElements(HaveField("Message", "workbenches are not installed")))
+	})
+
+	It("should wait for pipeline servers to be installed", func(ctx context.Context) {
+		funcs.Create = noKindMatch(gvk.DataSciencePipelinesApplication)
+		_, err := reconcile(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		fillCredentials(ctx)
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: pendingRequeue}))
+
+		Expect(projects(ctx)).To(HaveExactElements(HaveField("Message", "pipeline servers are not installed")))
+	})
+
+	It("should report projects failing to be instantiated", func(ctx context.Context) {
+		funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, isQuota := obj.(*corev1.ResourceQuota); isQuota {
+				return k8serr.NewForbidden(schema.GroupResource{Resource: "resourcequotas"}, obj.GetName(), nil)
+			}
+			return cli.Create(ctx, obj, opts...)
+		}
+
+		_, err := reconcile(ctx)
+		Expect(err).To(MatchError(ContainSubstring("failed to create quota in fraud")))
+
+		Expect(projects(ctx)).To(HaveExactElements(HaveField("Phase", projectv1alpha1.ProjectFailed)))
+		saved := &projectv1alpha1.ProjectTemplate{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		Expect(saved.Status.Phase).To(Equal(status.PhaseError))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ProjectTemplateFailed")))
+	})
+
+	It("should ignore deleted templates", func(ctx context.Context) {
+		objects = objects[1:]
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+
+	DescribeTable("should watch namespaces requesting a template",
+		func(old, updated map[string]string, expected bool) {
+			Expect(templateRequested.Update(event.UpdateEvent{ObjectOld: namespace("fraud", old), ObjectNew: namespace("fraud", updated)})).To(Equal(expected))
+		},
+		Entry("when the template is requested", nil, map[string]string{annotations.ProjectTemplate: "starter"}, true),
+		Entry("when another template is requested",
+			map[string]string{annotations.ProjectTemplate: "starter"}, map[string]string{annotations.ProjectTemplate: "other"}, true),
+		Entry("unless the template is unchanged",
+			map[string]string{annotations.ProjectTemplate: "starter"}, map[string]string{annotations.ProjectTemplate: "starter", "a": "b"}, false),
+		Entry("unless the template is instantiated", nil,
+			map[string]string{annotations.ProjectTemplate: "starter", annotations.ProjectTemplateInstantiated: "starter"}, false),
+		Entry("unless no template is requested", map[string]string{annotations.ProjectTemplate: "starter"}, nil, false),
+	)
+
+	It("should reconcile the template requested by a namespace", func(ctx context.Context) {
+		Expect(watchNamespaces(ctx, namespace("fraud", map[string]string{annotations.ProjectTemplate: "starter"}))).To(ConsistOf(req))
+		Expect(watchNamespaces(ctx, namespace("sales", nil))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,13 @@
+package projecttemplate
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestProjectTemplate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Project template suite")
+}
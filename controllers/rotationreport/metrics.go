@@ -0,0 +1,57 @@
+package rotationreport
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+)
+
+var (
+	// secretLastRotation backs the alerts on secrets not rotated for too long.
+	secretLastRotation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_secret_last_rotation_timestamp_seconds",
+			Help: "Last rotation of a Secret managed by the operator, its creation when never rotated, as Unix timestamp.",
+		},
+		[]string{"namespace", "name", "kind"},
+	)
+	// secretRotationDue backs the alerts on secrets to rotate.
+	secretRotationDue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_secret_rotation_due",
+			Help: "Whether a Secret managed by the operator is due for rotation (1) or not (0).",
+		},
+		[]string{"namespace", "name", "kind"},
+	)
+	// certificateExpiry backs the alerts on certificates about to expire.
+	certificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_secret_certificate_expiry_timestamp_seconds",
+			Help: "Expiry of the certificate of a Secret managed by the operator, as Unix timestamp.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(secretLastRotation, secretRotationDue, certificateExpiry)
+}
+
+// observe replaces the metrics with the ones of the secrets, so that deleted secrets are not reported anymore.
+func observe(secrets []inventoryv1alpha1.ManagedSecret) {
+	secretLastRotation.Reset()
+	secretRotationDue.Reset()
+	certificateExpiry.Reset()
+	for _, secret := range secrets {
+		secretLastRotation.WithLabelValues(secret.Namespace, secret.Name, string(secret.Kind)).Set(float64(secret.LastRotationTime.Unix()))
+		due := 0.0
+		if secret.RotationDue {
+			due = 1
+		}
+		secretRotationDue.WithLabelValues(secret.Namespace, secret.Name, string(secret.Kind)).Set(due)
+		if secret.NotAfter != nil {
+			certificateExpiry.WithLabelValues(secret.Namespace, secret.Name).Set(float64(secret.NotAfter.Unix()))
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Package rotationreport contains controller logic inventorying the certificates and secrets managed by the operator,
+// with their age, expiry and last rotation, in a RotationReport and in metrics, and renewing the self-signed
+// certificates it generated before they expire.
+package rotationreport
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// ReportName is the name of the RotationReport maintained by the operator.
+	ReportName = "default-rotation-report"
+	// Secrets are not watched, they are inventoried periodically.
+	scanInterval = time.Hour
+	// certificates are due for rotation this long before their expiry
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=rotationreports,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=rotationreports/status,verbs=get;update;patch
+
+// RotationReportReconciler holds the controller configuration.
+type RotationReportReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists Secrets, which are only cached in some namespaces.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	// MaxAge is the age after which secrets, other than certificates, are due for rotation, never when zero.
+	// It is the rotation interval of the secrets of OAuthClients.
+	MaxAge time.Duration
+	// RenewCertificates regenerates the self-signed certificates generated by the operator once due for rotation.
+	RenewCertificates bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RotationReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for secret rotation reports.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("rotation-report-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile inventories the Secrets managed by the operator in the applications namespace and the namespace of the
+// service mesh control plane, renews the self-signed certificates due for rotation when enabled, and lists them in
+// the RotationReport. It requeues to report on Secrets created or rotated in the meantime.
+func (r *RotationReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !featuregate.Enabled(featuregate.SecretRotationReport) {
+		observe(nil)
+		report := &inventoryv1alpha1.RotationReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName}}
+		return ctrl.Result{}, client.IgnoreNotFound(r.Client.Delete(ctx, report))
+	}
+
+	namespaces := []string{instance.Spec.ApplicationsNamespace}
+	if mesh := instance.Spec.ServiceMesh; mesh != nil && mesh.ControlPlane.Namespace != "" && mesh.ControlPlane.Namespace != namespaces[0] {
+		namespaces = append(namespaces, mesh.ControlPlane.Namespace)
+	}
+	secrets, err := r.inventory(ctx, namespaces, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	observe(secrets)
+	if err := r.publish(ctx, secrets); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: scanInterval}, nil
+}
+
+// inventory returns the Secrets managed by the operator in the namespaces, sorted by namespace and name, renewing the
+// certificates which are due for rotation and rotated by the operator. Certificates failing to be renewed are
+// reported as still due.
+func (r *RotationReportReconciler) inventory(ctx context.Context, namespaces []string, now time.Time) ([]inventoryv1alpha1.ManagedSecret, error) {
+	managed := []inventoryv1alpha1.ManagedSecret{}
+	var errs []error
+	for _, namespace := range namespaces {
+		secrets := &corev1.SecretList{}
+		if err := r.APIReader.List(ctx, secrets, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list Secrets of namespace %s: %w", namespace, err)
+		}
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			entry, cert, found := r.assess(secret, now)
+			if !found {
+				continue
+			}
+			if entry.AutoRotated && entry.RotationDue && cert != nil {
+				if renewed, err := r.renew(ctx, secret, cert); err != nil {
+					errs = append(errs, err)
+				} else {
+					entry.LastRotationTime = metav1.NewTime(renewed.NotBefore)
+					entry.NotAfter = &metav1.Time{Time: renewed.NotAfter}
+					entry.RotationDue = false
+				}
+			}
+			managed = append(managed, entry)
+		}
+	}
+
+	sort.SliceStable(managed, func(i, j int) bool {
+		a, b := managed[i], managed[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return managed, errors.Join(errs...)
+}
+
+// assess tells whether the Secret is managed by the operator, and reports its age, expiry and last rotation. It also
+// returns the certificate of the Secret, for certificates.
+func (r *RotationReportReconciler) assess(secret *corev1.Secret, now time.Time) (inventoryv1alpha1.ManagedSecret, *x509.Certificate, bool) {
+	entry := inventoryv1alpha1.ManagedSecret{
+		Namespace:        secret.Namespace,
+		Name:             secret.Name,
+		CreationTime:     secret.CreationTimestamp,
+		LastRotationTime: secret.CreationTimestamp,
+	}
+	tooOld := func() bool {
+		return r.MaxAge > 0 && !now.Before(entry.LastRotationTime.Add(r.MaxAge))
+	}
+
+	rotatedAt, isOAuthClientSecret := secret.GetAnnotations()[annotations.OAuthClientSecretRotatedAt]
+	switch {
+	case isOAuthClientSecret:
+		entry.Kind = inventoryv1alpha1.OAuthClientSecret
+		if t, err := time.Parse(time.RFC3339, rotatedAt); err == nil {
+			entry.LastRotationTime = metav1.NewTime(t)
+		}
+		entry.AutoRotated = r.MaxAge > 0
+		entry.RotationDue = tooOld()
+	case generated(secret):
+		entry.Kind = inventoryv1alpha1.GeneratedSecret
+		entry.RotationDue = tooOld()
+	case secret.Type == corev1.SecretTypeTLS:
+		cert, err := certificate(secret)
+		if err != nil {
+			return entry, nil, false
+		}
+		selfSigned := len(cert.Subject.Organization) != 0 && cert.Subject.Organization[0] == cluster.SelfSignedOrganization
+		if !selfSigned && !ownedByOperator(secret) {
+			return entry, nil, false
+		}
+		entry.Kind = inventoryv1alpha1.CertificateSecret
+		entry.LastRotationTime = metav1.NewTime(cert.NotBefore)
+		entry.NotAfter = &metav1.Time{Time: cert.NotAfter}
+		entry.AutoRotated = r.RenewCertificates && selfSigned
+		entry.RotationDue = !now.Add(renewBefore).Before(cert.NotAfter)
+		return entry, cert, true
+	default:
+		return entry, nil, false
+	}
+
+	return entry, nil, true
+}
+
+// renew regenerates the self-signed certificate of the Secret for the same host, and returns it.
+func (r *RotationReportReconciler) renew(ctx context.Context, secret *corev1.Secret, cert *x509.Certificate) (*x509.Certificate, error) {
+	generated, err := cluster.GenerateSelfSignedCertificateAsSecret(secret.Name, cert.Subject.CommonName, secret.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew certificate %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	renewed, err := certificate(generated)
+	if err != nil {
+		return nil, err
+	}
+	secret.Data = generated.Data
+	if err := r.Client.Update(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to renew certificate %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	r.Log.Info("Renewed self-signed certificate", "namespace", secret.Namespace, "name", secret.Name, "notAfter", renewed.NotAfter)
+	r.Recorder.Eventf(secret, corev1.EventTypeNormal, "CertificateRenewed", "Renewed self-signed certificate expiring %s",
+		cert.NotAfter.UTC().Format(time.RFC3339))
+
+	return renewed, nil
+}
+
+// publish creates the RotationReport if needed, and records the secrets and the time of the scan in its status.
+func (r *RotationReportReconciler) publish(ctx context.Context, secrets []inventoryv1alpha1.ManagedSecret) error {
+	report := &inventoryv1alpha1.RotationReport{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: ReportName}, report)
+	if k8serr.IsNotFound(err) {
+		report = &inventoryv1alpha1.RotationReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ReportName,
+				Labels: map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"},
+			},
+		}
+		if err := r.Client.Create(ctx, report); err != nil {
+			return fmt.Errorf("failed to create RotationReport: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get RotationReport: %w", err)
+	}
+
+	due := 0
+	for _, secret := range secrets {
+		if secret.RotationDue {
+			due++
+		}
+	}
+	if due != report.Status.Due {
+		r.Log.Info("Secrets due for rotation changed", "due", due)
+	}
+	now := metav1.Now()
+	report.Status = inventoryv1alpha1.RotationReportStatus{
+		LastScanTime: &now,
+		Total:        len(secrets),
+		Due:          due,
+		Secrets:      secrets,
+	}
+	if err := r.Client.Status().Update(ctx, report); err != nil {
+		return fmt.Errorf("failed to update RotationReport: %w", err)
+	}
+
+	return nil
+}
+
+// generated tells whether the Secret was generated by the secret generator, which makes its source Secret its controller.
+func generated(secret *corev1.Secret) bool {
+	owner := metav1.GetControllerOf(secret)
+	return owner != nil && owner.Kind == "Secret" && owner.APIVersion == "v1"
+}
+
+// ownedByOperator tells whether a resource of the operator, e.g. the FeatureTracker of a feature, owns the Secret.
+func ownedByOperator(secret *corev1.Secret) bool {
+	for _, owner := range secret.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err == nil && (gv.Group == "opendatahub.io" || strings.HasSuffix(gv.Group, ".opendatahub.io")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// certificate decodes the first certificate of the Secret.
+func certificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
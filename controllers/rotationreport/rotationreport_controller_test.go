@@ -0,0 +1,384 @@
+package rotationreport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// certificateSecret returns a TLS Secret holding a certificate of the organization, valid over the given period.
+func certificateSecret(namespace, name, organization string, notBefore, notAfter time.Time) *corev1.Secret {
+	GinkgoHelper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "*.apps.example.com", Organization: []string{organization}},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})},
+	}
+}
+
+func oauthClientSecret(namespace, name string, rotatedAt string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name,
+		Annotations: map[string]string{annotations.OAuthClientSecretRotatedAt: rotatedAt}}}
+}
+
+func generatedSecret(namespace, name string, created time.Time) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name,
+		CreationTimestamp: metav1.NewTime(created),
+		OwnerReferences:   []metav1.OwnerReference{{APIVersion: "v1", Kind: "Secret", Name: name + "-source", Controller: ptr.To(true)}}}}
+}
+
+var _ = Describe("Rotation report controller", func() {
+	const day = 24 * time.Hour
+	now := time.Now().Truncate(time.Second)
+
+	var (
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		r        *RotationReportReconciler
+	)
+
+	BeforeEach(func() {
+		objects = nil
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+		r = &RotationReportReconciler{Log: logr.Discard(), Recorder: recorder, MaxAge: 90 * day}
+	})
+
+	reconciler := func() *RotationReportReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(inventoryv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&inventoryv1alpha1.RotationReport{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+			r.Client, r.APIReader, r.Scheme = cli, cli, cli.Scheme()
+		}
+		return r
+	}
+	secretNames := func(secrets []inventoryv1alpha1.ManagedSecret) []string {
+		names := []string{}
+		for _, secret := range secrets {
+			names = append(names, secret.Namespace+"/"+secret.Name)
+		}
+		return names
+	}
+
+	Describe("assess", func() {
+		assess := func(secret *corev1.Secret, now time.Time) inventoryv1alpha1.ManagedSecret {
+			GinkgoHelper()
+			entry, _, found := r.assess(secret, now)
+			Expect(found).To(BeTrue())
+			return entry
+		}
+
+		DescribeTable("should report the secrets of OAuthClients, rotated every MaxAge",
+			func(maxAge time.Duration, rotatedAt string, lastRotation time.Time, autoRotated, due bool) {
+				r.MaxAge = maxAge
+				secret := oauthClientSecret("opendatahub", "dashboard-oauth-client", rotatedAt)
+				secret.CreationTimestamp = metav1.NewTime(now.Add(-200 * day))
+
+				entry := assess(secret, now)
+				Expect(entry.Kind).To(Equal(inventoryv1alpha1.OAuthClientSecret))
+				Expect(entry.LastRotationTime.Time).To(BeTemporally("==", lastRotation))
+				Expect(entry.AutoRotated).To(Equal(autoRotated))
+				Expect(entry.RotationDue).To(Equal(due))
+			},
+			Entry("rotated recently", 90*day, now.Add(-10*day).Format(time.RFC3339), now.Add(-10*day), true, false),
+			Entry("rotated longer ago than MaxAge", 90*day, now.Add(-100*day).Format(time.RFC3339), now.Add(-100*day), true, true),
+			Entry("rotated exactly MaxAge ago", 90*day, now.Add(-90*day).Format(time.RFC3339), now.Add(-90*day), true, true),
+			Entry("never rotated without MaxAge", time.Duration(0), now.Add(-100*day).Format(time.RFC3339), now.Add(-100*day), false, false),
+			Entry("rotation time unreadable, created longer ago than MaxAge", 90*day, "yesterday", now.Add(-200*day), true, true),
+		)
+
+		DescribeTable("should report the generated secrets, never rotated automatically",
+			func(maxAge time.Duration, created time.Time, due bool) {
+				r.MaxAge = maxAge
+
+				entry := assess(generatedSecret("opendatahub", "dashboard-oauth-config-generated", created), now)
+				Expect(entry.Kind).To(Equal(inventoryv1alpha1.GeneratedSecret))
+				Expect(entry.CreationTime.Time).To(BeTemporally("==", created))
+				Expect(entry.AutoRotated).To(BeFalse())
+				Expect(entry.RotationDue).To(Equal(due))
+			},
+			Entry("created recently", 90*day, now.Add(-day), false),
+			Entry("created longer ago than MaxAge", 90*day, now.Add(-100*day), true),
+			Entry("without MaxAge", time.Duration(0), now.Add(-1000*day), false),
+		)
+
+		DescribeTable("should report the certificates, due 30 days before their expiry",
+			func(renewCertificates bool, organization string, notAfter time.Time, autoRotated, due bool) {
+				r.RenewCertificates = renewCertificates
+				secret := certificateSecret("istio-system", "knative-serving-cert", organization, now.Add(-day), notAfter)
+				secret.OwnerReferences = []metav1.OwnerReference{{APIVersion: "features.opendatahub.io/v1", Kind: "FeatureTracker", Name: "serverless"}}
+
+				entry, cert, found := r.assess(secret, now)
+				Expect(found).To(BeTrue())
+				Expect(cert).ToNot(BeNil())
+				Expect(entry.Kind).To(Equal(inventoryv1alpha1.CertificateSecret))
+				Expect(entry.LastRotationTime.Time).To(BeTemporally("==", now.Add(-day)))
+				Expect(entry.NotAfter.Time).To(BeTemporally("==", notAfter))
+				Expect(entry.AutoRotated).To(Equal(autoRotated))
+				Expect(entry.RotationDue).To(Equal(due))
+			},
+			Entry("self-signed, valid", false, cluster.SelfSignedOrganization, now.Add(300*day), false, false),
+			Entry("self-signed, expiring within 30 days", false, cluster.SelfSignedOrganization, now.Add(10*day), false, true),
+			Entry("self-signed, expired", false, cluster.SelfSignedOrganization, now.Add(-day), false, true),
+			Entry("self-signed, renewed by the operator", true, cluster.SelfSignedOrganization, now.Add(10*day), true, true),
+			Entry("owned by the operator, not renewed", true, "Example Inc.", now.Add(10*day), false, true),
+		)
+
+		It("should report the self-signed certificates generated by the operator", func() {
+			cert, err := cluster.GenerateSelfSignedCertificateAsSecret("knative-serving-cert", "*.apps.example.com", "istio-system")
+			Expect(err).ToNot(HaveOccurred())
+
+			entry := assess(cert, now)
+			Expect(entry.Kind).To(Equal(inventoryv1alpha1.CertificateSecret))
+			Expect(entry.RotationDue).To(BeFalse())
+			Expect(assess(cert, now.Add(340*day)).RotationDue).To(BeTrue())
+		})
+
+		DescribeTable("should leave out the secrets not managed by the operator",
+			func(secret *corev1.Secret) {
+				_, _, found := r.assess(secret, now)
+				Expect(found).To(BeFalse())
+			},
+			Entry("opaque secret", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "opendatahub", Name: "aws-connection"}}),
+			Entry("secret controlled by another kind", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "opendatahub", Name: "builder-token",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "ServiceAccount", Name: "builder", Controller: ptr.To(true)}}}}),
+			Entry("certificate of another organization",
+				certificateSecret("istio-system", "custom-cert", "Example Inc.", now.Add(-day), now.Add(300*day))),
+			Entry("TLS secret without certificate", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "empty-cert"},
+				Type: corev1.SecretTypeTLS, Data: map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")}}),
+		)
+	})
+
+	Describe("inventory", func() {
+		It("should list the secrets managed by the operator in the namespaces, sorted", func(ctx context.Context) {
+			objects = append(objects,
+				oauthClientSecret("opendatahub", "dashboard-oauth-client", now.Format(time.RFC3339)),
+				generatedSecret("opendatahub", "a-generated", now),
+				certificateSecret("istio-system", "knative-serving-cert", cluster.SelfSignedOrganization, now.Add(-day), now.Add(300*day)),
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "opendatahub", Name: "aws-connection"}},
+				oauthClientSecret("other", "other-oauth-client", now.Format(time.RFC3339)),
+			)
+
+			secrets, err := reconciler().inventory(ctx, []string{"opendatahub", "istio-system"}, now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secretNames(secrets)).To(Equal([]string{
+				"istio-system/knative-serving-cert", "opendatahub/a-generated", "opendatahub/dashboard-oauth-client",
+			}))
+		})
+
+		It("should renew the self-signed certificates due for rotation when enabled", func(ctx context.Context) {
+			cert := certificateSecret("istio-system", "knative-serving-cert", cluster.SelfSignedOrganization, now.Add(-300*day), now.Add(10*day))
+			previous := cert.Data[corev1.TLSCertKey]
+			objects = append(objects, cert)
+			r.RenewCertificates = true
+
+			secrets, err := reconciler().inventory(ctx, []string{"istio-system"}, now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].AutoRotated).To(BeTrue())
+			Expect(secrets[0].RotationDue).To(BeFalse())
+			Expect(secrets[0].NotAfter.Time).To(BeTemporally(">", now.Add(300*day)))
+
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(cert), cert)).To(Succeed())
+			Expect(cert.Data[corev1.TLSCertKey]).ToNot(Equal(previous))
+			Expect(cert.Data).To(HaveKey(corev1.TLSPrivateKeyKey))
+			Expect(<-recorder.Events).To(HavePrefix("Normal CertificateRenewed Renewed self-signed certificate expiring"))
+		})
+
+		It("should not renew the certificates when disabled", func(ctx context.Context) {
+			cert := certificateSecret("istio-system", "knative-serving-cert", cluster.SelfSignedOrganization, now.Add(-300*day), now.Add(10*day))
+			objects = append(objects, cert)
+
+			secrets, err := reconciler().inventory(ctx, []string{"istio-system"}, now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].RotationDue).To(BeTrue())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should report the certificates failing to be renewed as still due", func(ctx context.Context) {
+			objects = append(objects,
+				certificateSecret("istio-system", "knative-serving-cert", cluster.SelfSignedOrganization, now.Add(-300*day), now.Add(10*day)))
+			r.RenewCertificates = true
+			funcs.Update = func(context.Context, client.WithWatch, client.Object, ...client.UpdateOption) error {
+				return errors.New("forbidden")
+			}
+
+			secrets, err := reconciler().inventory(ctx, []string{"istio-system"}, now)
+			Expect(err).To(MatchError(ContainSubstring("failed to renew certificate istio-system/knative-serving-cert")))
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].RotationDue).To(BeTrue())
+		})
+
+		It("should fail when the secrets cannot be listed", func(ctx context.Context) {
+			funcs.List = func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				return errors.New("forbidden")
+			}
+
+			_, err := reconciler().inventory(ctx, []string{"opendatahub"}, now)
+			Expect(err).To(MatchError(ContainSubstring("failed to list Secrets of namespace opendatahub")))
+		})
+	})
+
+	Describe("Reconcile", func() {
+		req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+
+		BeforeEach(func() {
+			dsci := &dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}
+			dsci.Spec.ApplicationsNamespace = "opendatahub"
+			dsci.Spec.ServiceMesh = &infrav1.ServiceMeshSpec{ControlPlane: infrav1.ControlPlaneSpec{Namespace: "istio-system"}}
+			objects = append(objects, dsci,
+				oauthClientSecret("opendatahub", "dashboard-oauth-client", now.Add(-100*day).Format(time.RFC3339)),
+				certificateSecret("istio-system", "knative-serving-cert", cluster.SelfSignedOrganization, now.Add(-day), now.Add(300*day)),
+			)
+		})
+		report := func(ctx context.Context) (*inventoryv1alpha1.RotationReport, error) {
+			report := &inventoryv1alpha1.RotationReport{}
+			return report, cli.Get(ctx, client.ObjectKey{Name: ReportName}, report)
+		}
+
+		Context("with the SecretRotationReport feature enabled", func() {
+			BeforeEach(func() {
+				Expect(featuregate.Set(map[string]bool{featuregate.SecretRotationReport: true})).To(Succeed())
+				DeferCleanup(func() {
+					Expect(featuregate.Set(nil)).To(Succeed())
+				})
+			})
+
+			It("should publish the secrets of the applications and service mesh namespaces", func(ctx context.Context) {
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+				published, err := report(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(published.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "opendatahub-operator"))
+				Expect(published.Status.LastScanTime).ToNot(BeNil())
+				Expect(published.Status.Total).To(Equal(2))
+				Expect(published.Status.Due).To(Equal(1))
+				Expect(secretNames(published.Status.Secrets)).To(Equal([]string{"istio-system/knative-serving-cert", "opendatahub/dashboard-oauth-client"}))
+			})
+
+			It("should scan the applications namespace once when it holds the service mesh", func(ctx context.Context) {
+				dsci := objects[0].(*dsciv1.DSCInitialization)
+				dsci.Spec.ServiceMesh.ControlPlane.Namespace = "opendatahub"
+				lists := 0
+				funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					lists++
+					return cli.List(ctx, list, opts...)
+				}
+
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+				Expect(lists).To(Equal(1))
+				published, err := report(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secretNames(published.Status.Secrets)).To(Equal([]string{"opendatahub/dashboard-oauth-client"}))
+			})
+
+			It("should expose the secrets as metrics", func(ctx context.Context) {
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+				Expect(testutil.ToFloat64(secretRotationDue.WithLabelValues("opendatahub", "dashboard-oauth-client", "OAuthClientSecret"))).To(Equal(1.0))
+				Expect(testutil.ToFloat64(secretRotationDue.WithLabelValues("istio-system", "knative-serving-cert", "CertificateSecret"))).To(BeZero())
+				Expect(testutil.ToFloat64(secretLastRotation.WithLabelValues("opendatahub", "dashboard-oauth-client", "OAuthClientSecret"))).
+					To(Equal(float64(now.Add(-100 * day).Unix())))
+				Expect(testutil.ToFloat64(certificateExpiry.WithLabelValues("istio-system", "knative-serving-cert"))).
+					To(Equal(float64(now.Add(300 * day).Unix())))
+				Expect(testutil.CollectAndCount(certificateExpiry)).To(Equal(1))
+			})
+
+			It("should update the existing report", func(ctx context.Context) {
+				objects = append(objects, &inventoryv1alpha1.RotationReport{
+					ObjectMeta: metav1.ObjectMeta{Name: ReportName},
+					Status:     inventoryv1alpha1.RotationReportStatus{Total: 5, Due: 3},
+				})
+
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+				published, err := report(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(published.Status.Total).To(Equal(2))
+				Expect(published.Status.Due).To(Equal(1))
+			})
+
+			It("should fail when the report cannot be created", func(ctx context.Context) {
+				funcs.Create = func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+					return errors.New("forbidden")
+				}
+
+				_, err := reconciler().Reconcile(ctx, req)
+				Expect(err).To(MatchError(ContainSubstring("failed to create RotationReport")))
+			})
+
+			It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+				objects = objects[1:]
+
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+				_, err := report(ctx)
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		It("should delete the report and clear the metrics when the feature is disabled", func(ctx context.Context) {
+			objects = append(objects, &inventoryv1alpha1.RotationReport{ObjectMeta: metav1.ObjectMeta{Name: ReportName}})
+			secretRotationDue.WithLabelValues("opendatahub", "dashboard-oauth-client", "OAuthClientSecret").Set(1)
+
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+			_, err := report(ctx)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			Expect(testutil.CollectAndCount(secretRotationDue)).To(BeZero())
+		})
+
+		It("should do nothing when the feature is disabled and there is no report", func(ctx context.Context) {
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+		})
+	})
+})
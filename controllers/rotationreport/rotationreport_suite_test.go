@@ -0,0 +1,13 @@
+package rotationreport
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRotationReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rotation report suite")
+}
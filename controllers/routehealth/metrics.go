@@ -0,0 +1,40 @@
+package routehealth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// routeReachable backs the alerts on component hosts users cannot reach.
+	routeReachable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_route_reachable",
+			Help: "Whether a host exposed by a component Route or VirtualService responded to the last probe (1) or not (0).",
+		},
+		[]string{"component", "kind", "name", "url"},
+	)
+	// routeCertificateExpiry backs the alerts on certificates about to expire.
+	routeCertificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_route_certificate_expiry_timestamp_seconds",
+			Help: "Expiry of the certificate served by a host exposed by a component Route or VirtualService, as Unix timestamp.",
+		},
+		[]string{"component", "kind", "name", "url"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(routeReachable, routeCertificateExpiry)
+}
+
+func observe(componentName string, res result) {
+	reachable := 0.0
+	if res.reachable {
+		reachable = 1
+	}
+	routeReachable.WithLabelValues(componentName, res.kind, res.name, res.url).Set(reachable)
+	if !res.notAfter.IsZero() {
+		routeCertificateExpiry.WithLabelValues(componentName, res.kind, res.name, res.url).Set(float64(res.notAfter.Unix()))
+	}
+}
@@ -0,0 +1,301 @@
+// Package routehealth contains controller logic probing the Routes and VirtualServices of components for reachability
+// and certificate expiry, reporting results in component conditions of the DataScienceCluster and in metrics.
+package routehealth
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
+)
+
+const (
+	probeInterval = 5 * time.Minute
+	probeTimeout  = 10 * time.Second
+	// certificates expiring within the threshold are reported in the component condition
+	certificateExpiryThreshold = 14 * 24 * time.Hour
+)
+
+// RouteHealthReconciler holds the controller configuration.
+type RouteHealthReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// HTTPClient probes the hosts, a client not verifying certificates is used when nil.
+	HTTPClient *http.Client
+	// Notifier sends certificates about to expire to the sinks of the OperatorConfig.
+	Notifier *notification.Notifier
+}
+
+// target is a host exposed by a component.
+type target struct {
+	kind string
+	name string
+	url  string
+}
+
+// result is the outcome of probing a target.
+type result struct {
+	target
+	reachable bool
+	message   string
+	// notAfter is the expiry of the certificate served by the host, zero when not served over TLS.
+	notAfter time.Time
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RouteHealthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for component Routes health.")
+
+	if r.HTTPClient == nil {
+		r.HTTPClient = &http.Client{
+			Timeout: probeTimeout,
+			Transport: &http.Transport{
+				// Routes are commonly served with certificates signed by the ingress operator CA, only expiry is checked
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				// redirects to the OpenShift login are a sign of a reachable host
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("route-health-controller").
+		For(&dscv1.DataScienceCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&routev1.Route{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &dscv1.DataScienceCluster{})).
+		Complete(r)
+}
+
+// Reconcile probes the Routes and VirtualServices of the Managed components, then reports their health in the
+// component conditions of the DataScienceCluster. It requeues to probe them again periodically.
+func (r *RouteHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	if err := instance.ApplyProfile(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	allComponents, err := instance.GetComponents()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	enabled := featuregate.Enabled(featuregate.RouteHealthMonitoring)
+	routeReachable.Reset()
+	routeCertificateExpiry.Reset()
+
+	conditions := map[string]*conditionsv1.Condition{}
+	now := time.Now()
+	for _, component := range allComponents {
+		name := component.GetComponentName()
+		conditions[name] = nil
+		if !enabled || component.GetManagementState() != operatorv1.Managed {
+			continue
+		}
+
+		targets, err := r.targets(ctx, name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		results := make([]result, 0, len(targets))
+		for _, t := range targets {
+			res := probe(ctx, r.HTTPClient, t)
+			observe(name, res)
+			results = append(results, res)
+		}
+		conditions[name] = evaluate(results, now)
+	}
+
+	if err := r.updateConditions(ctx, instance, conditions); err != nil {
+		return ctrl.Result{}, err
+	}
+	for name, condition := range conditions {
+		if condition == nil || condition.Reason != status.RouteCertificateExpiring {
+			continue
+		}
+		if err := r.Notifier.Notify(ctx, notification.Event{
+			Type:    operatorconfigv1alpha1.CertificateExpiring,
+			Object:  name,
+			Message: condition.Message,
+		}); err != nil {
+			r.Log.Error(err, "failed to send notification", "component", name)
+		}
+	}
+	if !enabled {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: probeInterval}, nil
+}
+
+// targets returns the hosts exposed by Routes and VirtualServices of the component.
+func (r *RouteHealthReconciler) targets(ctx context.Context, componentName string) ([]target, error) {
+	selector := client.MatchingLabels{labels.ODH.Component(componentName): "true"}
+	var targets []target
+
+	routes := &routev1.RouteList{}
+	if err := r.Client.List(ctx, routes, selector); err != nil {
+		return nil, err
+	}
+	for _, route := range routes.Items {
+		if route.Spec.Host == "" {
+			continue
+		}
+		scheme := "http"
+		if route.Spec.TLS != nil {
+			scheme = "https"
+		}
+		targets = append(targets, target{kind: "Route", name: route.Namespace + "/" + route.Name, url: scheme + "://" + route.Spec.Host + route.Spec.Path})
+	}
+
+	virtualServices := &unstructured.UnstructuredList{}
+	virtualServices.SetGroupVersionKind(gvk.VirtualService)
+	if err := r.Client.List(ctx, virtualServices, selector); err != nil {
+		if meta.IsNoMatchError(err) {
+			return targets, nil
+		}
+		return nil, err
+	}
+	for _, virtualService := range virtualServices.Items {
+		hosts, _, err := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range hosts {
+			if !externalHost(host) {
+				continue
+			}
+			targets = append(targets, target{kind: "VirtualService", name: virtualService.GetNamespace() + "/" + virtualService.GetName(), url: "https://" + host})
+		}
+	}
+
+	return targets, nil
+}
+
+// externalHost tells whether the host of a VirtualService is exposed outside of the mesh.
+func externalHost(host string) bool {
+	return strings.Contains(host, ".") && !strings.Contains(host, "*") &&
+		!strings.HasSuffix(host, ".svc") && !strings.HasSuffix(host, ".svc.cluster.local")
+}
+
+// probe requests the target. Any response but a server error, e.g. the "Application is not available" page of the
+// router, counts as reachable.
+func probe(ctx context.Context, httpClient *http.Client, t target) result {
+	res := result{target: t}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url, nil)
+	if err != nil {
+		res.message = err.Error()
+		return res
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		res.message = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		res.notAfter = resp.TLS.PeerCertificates[0].NotAfter
+	}
+	res.reachable = resp.StatusCode < http.StatusInternalServerError
+	if !res.reachable {
+		res.message = "responded with " + resp.Status
+	}
+
+	return res
+}
+
+// evaluate returns the condition reporting the results of the component. Unreachable hosts take precedence over
+// expiring certificates.
+func evaluate(results []result, now time.Time) *conditionsv1.Condition {
+	var unreachable, expiring []string
+	for _, res := range results {
+		switch {
+		case !res.reachable:
+			unreachable = append(unreachable, fmt.Sprintf("%s %s is unreachable at %s: %s", res.kind, res.name, res.url, res.message))
+		case !res.notAfter.IsZero() && res.notAfter.Sub(now) < certificateExpiryThreshold:
+			expiring = append(expiring, fmt.Sprintf("certificate of %s %s expires at %s", res.kind, res.name, res.notAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	sort.Strings(unreachable)
+	sort.Strings(expiring)
+
+	switch {
+	case len(unreachable) > 0:
+		return &conditionsv1.Condition{Status: corev1.ConditionFalse, Reason: status.RouteUnreachable, Message: strings.Join(unreachable, "; ")}
+	case len(expiring) > 0:
+		return &conditionsv1.Condition{Status: corev1.ConditionFalse, Reason: status.RouteCertificateExpiring, Message: strings.Join(expiring, "; ")}
+	default:
+		return &conditionsv1.Condition{Status: corev1.ConditionTrue, Reason: status.RoutesReachable, Message: fmt.Sprintf("%d hosts reachable", len(results))}
+	}
+}
+
+// updateConditions sets the conditions of the components, removing them when nil. Status is only updated on changes,
+// to not bump the heartbeat of the conditions with every probe.
+func (r *RouteHealthReconciler) updateConditions(ctx context.Context, instance *dscv1.DataScienceCluster, conditions map[string]*conditionsv1.Condition) error {
+	changed := false
+	for name, condition := range conditions {
+		existing := conditionsv1.FindStatusCondition(instance.Status.Conditions, conditionsv1.ConditionType(name+status.RoutesHealthySuffix))
+		switch {
+		case condition == nil:
+			changed = changed || existing != nil
+		case existing == nil:
+			changed = true
+		default:
+			changed = changed || existing.Status != condition.Status || existing.Reason != condition.Reason || existing.Message != condition.Message
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dscv1.DataScienceCluster) {
+		for name, condition := range conditions {
+			conditionType := name + status.RoutesHealthySuffix
+			if condition == nil {
+				conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, conditionsv1.ConditionType(conditionType))
+				continue
+			}
+			status.SetCondition(&saved.Status.Conditions, conditionType, condition.Reason, condition.Message, condition.Status)
+		}
+	})
+
+	return err
+}
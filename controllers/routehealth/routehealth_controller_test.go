@@ -0,0 +1,176 @@
+package routehealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func respondWith(statusCode int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(statusCode)
+	})
+}
+
+var _ = Describe("Probing hosts", func() {
+	dashboard := func(url string) target {
+		return target{kind: "Route", name: "opendatahub/odh-dashboard", url: url}
+	}
+
+	It("should report TLS hosts reachable with the expiry of their certificate", func(ctx context.Context) {
+		server := httptest.NewTLSServer(respondWith(http.StatusForbidden))
+		DeferCleanup(server.Close)
+
+		res := probe(ctx, server.Client(), dashboard(server.URL))
+		Expect(res.reachable).To(BeTrue())
+		Expect(res.notAfter).ToNot(BeZero())
+	})
+
+	It("should report hosts responding with server errors unreachable", func(ctx context.Context) {
+		server := httptest.NewServer(respondWith(http.StatusServiceUnavailable))
+		DeferCleanup(server.Close)
+
+		res := probe(ctx, server.Client(), dashboard(server.URL))
+		Expect(res.reachable).To(BeFalse())
+		Expect(res.notAfter).To(BeZero())
+		Expect(res.message).To(Equal("responded with 503 Service Unavailable"))
+	})
+
+	It("should report hosts which cannot be connected to unreachable", func(ctx context.Context) {
+		server := httptest.NewServer(respondWith(http.StatusOK))
+		server.Close()
+
+		res := probe(ctx, http.DefaultClient, dashboard(server.URL))
+		Expect(res.reachable).To(BeFalse())
+		Expect(res.message).To(ContainSubstring("connection refused"))
+	})
+
+	DescribeTable("should only probe hosts of VirtualServices exposed outside of the mesh",
+		func(host string, expected bool) {
+			Expect(externalHost(host)).To(Equal(expected))
+		},
+		Entry("routed hosts", "kserve.apps.example.com", true),
+		Entry("wildcard hosts", "*.apps.example.com", false),
+		Entry("short names", "kserve-controller", false),
+		Entry("service hosts", "kserve.opendatahub.svc", false),
+		Entry("fully qualified service hosts", "kserve.opendatahub.svc.cluster.local", false),
+	)
+})
+
+var _ = Describe("Route health condition", func() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	route := target{kind: "Route", name: "opendatahub/odh-dashboard", url: "https://odh-dashboard.apps.example.com"}
+
+	DescribeTable("should report the results of the component",
+		func(results []result, conditionStatus corev1.ConditionStatus, reason, message string) {
+			Expect(evaluate(results, now)).To(Equal(&conditionsv1.Condition{Status: conditionStatus, Reason: reason, Message: message}))
+		},
+		Entry("when reachable", []result{{target: route, reachable: true, notAfter: now.Add(90 * 24 * time.Hour)}},
+			corev1.ConditionTrue, status.RoutesReachable, "1 hosts reachable"),
+		Entry("when a certificate expires soon", []result{{target: route, reachable: true, notAfter: now.Add(24 * time.Hour)}},
+			corev1.ConditionFalse, status.RouteCertificateExpiring,
+			"certificate of Route opendatahub/odh-dashboard expires at 2024-05-02T12:00:00Z"),
+		Entry("when unreachable, before expiring certificates", []result{
+			{target: route, reachable: true, notAfter: now.Add(24 * time.Hour)},
+			{target: route, message: "responded with 503 Service Unavailable"},
+		}, corev1.ConditionFalse, status.RouteUnreachable,
+			"Route opendatahub/odh-dashboard is unreachable at https://odh-dashboard.apps.example.com: responded with 503 Service Unavailable"),
+	)
+})
+
+var _ = Describe("Route health controller", func() {
+	var (
+		server *httptest.Server
+		dsc    *dscv1.DataScienceCluster
+		cli    client.Client
+		req    = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsc"}}
+	)
+
+	reconcile := func(ctx context.Context) ctrl.Result {
+		GinkgoHelper()
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(routev1.AddToScheme(scheme)).To(Succeed())
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			route := &routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "odh-dashboard", Namespace: "opendatahub", Labels: map[string]string{labels.ODH.Component("dashboard"): "true"}},
+				Spec:       routev1.RouteSpec{Host: strings.TrimPrefix(server.URL, "http://")},
+			}
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(dsc, route).WithStatusSubresource(dsc).Build()
+		}
+		r := &RouteHealthReconciler{Client: cli, Log: logr.Discard(), HTTPClient: server.Client()}
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+	condition := func(ctx context.Context) *conditionsv1.Condition {
+		GinkgoHelper()
+		saved := &dscv1.DataScienceCluster{}
+		Expect(cli.Get(ctx, req.NamespacedName, saved)).To(Succeed())
+		return conditionsv1.FindStatusCondition(saved.Status.Conditions, "dashboard"+status.RoutesHealthySuffix)
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(respondWith(http.StatusServiceUnavailable))
+		DeferCleanup(server.Close)
+		dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Managed
+		cli = nil
+	})
+
+	It("should report the health of the Routes of Managed components and probe them again later on", func(ctx context.Context) {
+		Expect(reconcile(ctx).RequeueAfter).To(Equal(probeInterval))
+
+		Expect(condition(ctx)).To(And(
+			HaveField("Status", corev1.ConditionFalse),
+			HaveField("Reason", status.RouteUnreachable),
+		))
+		Expect(testutil.ToFloat64(routeReachable.WithLabelValues("dashboard", "Route", "opendatahub/odh-dashboard", server.URL))).To(BeZero())
+	})
+
+	It("should remove the condition once the component is removed", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(cli.Get(ctx, req.NamespacedName, dsc)).To(Succeed())
+		dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+		Expect(cli.Update(ctx, dsc)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(condition(ctx)).To(BeNil())
+	})
+
+	When("the feature gate is disabled", func() {
+		BeforeEach(func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.RouteHealthMonitoring: false})).To(Succeed())
+			DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+		})
+
+		It("should neither probe nor requeue", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+			Expect(condition(ctx)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package routehealth
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRouteHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Route health controller suite")
+}
@@ -0,0 +1,227 @@
+// Package selfhealing contains controller logic remediating component Deployments which stay unavailable,
+// according to the self-healing policy set for the component in the DataScienceCluster.
+package selfhealing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const defaultUnhealthyThreshold = 10 * time.Minute
+
+// SelfHealingReconciler holds the controller configuration.
+type SelfHealingReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SelfHealingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for component self-healing.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("self-healing-controller").
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return len(componentLabels(obj)) > 0
+		}))).
+		Complete(r)
+}
+
+// Reconcile applies the self-healing action of the component owning the Deployment once it has been unavailable
+// for longer than the configured threshold, and requeues until then.
+func (r *SelfHealingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("deployment", req.NamespacedName)
+	if !featuregate.Enabled(featuregate.ComponentSelfHealing) {
+		return ctrl.Result{}, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, req.NamespacedName, deployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if deployment.GetDeletionTimestamp() != nil || cluster.IsUnmanaged(deployment) {
+		return ctrl.Result{}, nil
+	}
+
+	dsc, policy, err := r.getPolicy(ctx, deployment)
+	if err != nil || policy == nil {
+		return ctrl.Result{}, err
+	}
+
+	wait, remediate := evaluate(deployment, policy, time.Now())
+	if !remediate {
+		if wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Deployment unavailable for longer than threshold, remediating", "action", policy.Action)
+	if err := r.remediate(ctx, dsc, deployment, policy.Action); err != nil {
+		r.Recorder.Eventf(deployment, corev1.EventTypeWarning, "ComponentRemediationFailed",
+			"Failed to remediate Deployment with %s: %v", policy.Action, err)
+		return ctrl.Result{}, err
+	}
+
+	message := fmt.Sprintf("Deployment %s/%s was unavailable for longer than %s, remediated with %s",
+		deployment.Namespace, deployment.Name, threshold(policy), policy.Action)
+	r.Recorder.Event(deployment, corev1.EventTypeWarning, "ComponentRemediation", message)
+	r.Recorder.Event(dsc, corev1.EventTypeWarning, "ComponentRemediation", message)
+
+	return ctrl.Result{RequeueAfter: threshold(policy)}, nil
+}
+
+// getPolicy returns the DataScienceCluster and the self-healing policy of the component the Deployment belongs to.
+// Policy is nil when the component is not managed or has no self-healing configured.
+func (r *SelfHealingReconciler) getPolicy(ctx context.Context, deployment *appsv1.Deployment) (*dscv1.DataScienceCluster, *components.SelfHealing, error) {
+	instances := &dscv1.DataScienceClusterList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		return nil, nil, err
+	}
+	if len(instances.Items) != 1 {
+		return nil, nil, nil
+	}
+	dsc := &instances.Items[0]
+	if err := dsc.ApplyProfile(); err != nil {
+		return nil, nil, err
+	}
+
+	allComponents, err := dsc.GetComponents()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := componentLabels(deployment)
+	for _, component := range allComponents {
+		for _, name := range names {
+			if component.GetComponentName() == name && component.GetManagementState() == operatorv1.Managed {
+				return dsc, component.GetSelfHealing(), nil
+			}
+		}
+	}
+
+	return dsc, nil, nil
+}
+
+func (r *SelfHealingReconciler) remediate(ctx context.Context, dsc *dscv1.DataScienceCluster, deployment *appsv1.Deployment,
+	action components.SelfHealingAction,
+) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	switch action {
+	case components.RecreateDeployment:
+		// DataScienceCluster controller watches owned Deployments and creates it again
+		return client.IgnoreNotFound(r.Client.Delete(ctx, deployment, client.PropagationPolicy(metav1.DeletePropagationForeground)))
+	case components.RestartPods:
+		selector, err := podSelector(deployment)
+		if err != nil {
+			return err
+		}
+		if err := r.Client.DeleteAllOf(ctx, &corev1.Pod{},
+			client.InNamespace(deployment.Namespace),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil && !k8serr.IsNotFound(err) {
+			return err
+		}
+	case components.ReapplyManifests:
+		// Changing annotation of the DataScienceCluster triggers its reconciliation
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, annotations.RemediationRequested, now)
+		if err := r.Client.Patch(ctx, dsc, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported self-healing action %q", action)
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, annotations.LastRemediation, now)
+	return r.Client.Patch(ctx, deployment, client.RawPatch(types.MergePatchType, []byte(patch)))
+}
+
+// podSelector returns the selector of the pods of the Deployment, with its label requirements as well as its
+// expressions. An empty selector is refused, as it would select every pod of the namespace.
+func podSelector(deployment *appsv1.Deployment) (k8slabels.Selector, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of Deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("refusing to restart pods of Deployment %s/%s, its selector is empty", deployment.Namespace, deployment.Name)
+	}
+
+	return selector, nil
+}
+
+// evaluate tells whether the Deployment should be remediated now, or for how long to wait before checking it again.
+// A Deployment is considered unhealthy from the moment its Available condition stopped being true.
+// Remediation is not repeated within the threshold since the last one, to give the Deployment the time to recover.
+func evaluate(deployment *appsv1.Deployment, policy *components.SelfHealing, now time.Time) (time.Duration, bool) {
+	since, unhealthy := unhealthySince(deployment)
+	if !unhealthy {
+		return 0, false
+	}
+
+	if last, err := time.Parse(time.RFC3339, deployment.GetAnnotations()[annotations.LastRemediation]); err == nil && last.After(since) {
+		since = last
+	}
+
+	if elapsed := now.Sub(since); elapsed < threshold(policy) {
+		return threshold(policy) - elapsed, false
+	}
+
+	return 0, true
+}
+
+func unhealthySince(deployment *appsv1.Deployment) (time.Time, bool) {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return condition.LastTransitionTime.Time, condition.Status != corev1.ConditionTrue
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func threshold(policy *components.SelfHealing) time.Duration {
+	if policy.UnhealthyThresholdMinutes <= 0 {
+		return defaultUnhealthyThreshold
+	}
+
+	return time.Duration(policy.UnhealthyThresholdMinutes) * time.Minute
+}
+
+// componentLabels returns names of the components the object has been deployed for.
+func componentLabels(obj client.Object) []string {
+	var names []string
+	for k, v := range obj.GetLabels() {
+		if name, found := strings.CutPrefix(k, labels.ODHAppPrefix+"/"); found && v == "true" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,120 @@
+package selfhealing
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluating unhealthy Deployments", func() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	policy := &components.SelfHealing{Action: components.RestartPods, UnhealthyThresholdMinutes: 5}
+
+	deployment := func(status corev1.ConditionStatus, since time.Duration, lastRemediation string) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:               appsv1.DeploymentAvailable,
+					Status:             status,
+					LastTransitionTime: metav1.NewTime(now.Add(-since)),
+				}},
+			},
+		}
+		if lastRemediation != "" {
+			d.SetAnnotations(map[string]string{annotations.LastRemediation: lastRemediation})
+		}
+		return d
+	}
+
+	DescribeTable("should remediate once the threshold is exceeded",
+		func(d *appsv1.Deployment, policy *components.SelfHealing, remediate bool, wait time.Duration) {
+			actualWait, actualRemediate := evaluate(d, policy, now)
+			Expect(actualRemediate).To(Equal(remediate))
+			Expect(actualWait).To(Equal(wait))
+		},
+		Entry("available deployment", deployment(corev1.ConditionTrue, time.Hour, ""), policy, false, time.Duration(0)),
+		Entry("deployment without conditions", &appsv1.Deployment{}, policy, false, time.Duration(0)),
+		Entry("unavailable within threshold", deployment(corev1.ConditionFalse, 2*time.Minute, ""), policy, false, 3*time.Minute),
+		Entry("unavailable longer than threshold", deployment(corev1.ConditionFalse, 6*time.Minute, ""), policy, true, time.Duration(0)),
+		Entry("recently remediated",
+			deployment(corev1.ConditionFalse, time.Hour, now.Add(-time.Minute).Format(time.RFC3339)), policy, false, 4*time.Minute),
+		Entry("default threshold",
+			deployment(corev1.ConditionFalse, 6*time.Minute, ""), &components.SelfHealing{Action: components.RecreateDeployment}, false, 4*time.Minute),
+	)
+})
+
+var _ = Describe("Restarting the pods of a Deployment", func() {
+	var (
+		cli        client.Client
+		reconciler *SelfHealingReconciler
+	)
+
+	pod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opendatahub", Labels: podLabels}}
+	}
+	deployment := func(selector *metav1.LabelSelector) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "odh-dashboard", Namespace: "opendatahub"},
+			Spec:       appsv1.DeploymentSpec{Selector: selector},
+		}
+	}
+	remainingPods := func(ctx context.Context) []string {
+		pods := &corev1.PodList{}
+		Expect(cli.List(ctx, pods)).To(Succeed())
+		names := []string{}
+		for _, p := range pods.Items {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithObjects(
+			pod("dashboard", map[string]string{"app": "odh-dashboard"}),
+			pod("dashboard-canary", map[string]string{"app": "odh-dashboard", "track": "canary"}),
+			pod("notebook-controller", map[string]string{"app": "notebook-controller"}),
+		).Build()
+		reconciler = &SelfHealingReconciler{Client: cli}
+	})
+
+	It("should only delete the pods matching the labels of the selector", func(ctx context.Context) {
+		d := deployment(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "odh-dashboard"}})
+		Expect(cli.Create(ctx, d)).To(Succeed())
+
+		Expect(reconciler.remediate(ctx, nil, d, components.RestartPods)).To(Succeed())
+
+		Expect(remainingPods(ctx)).To(ConsistOf("notebook-controller"))
+	})
+
+	It("should only delete the pods matching the expressions of the selector", func(ctx context.Context) {
+		d := deployment(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"odh-dashboard"}},
+			{Key: "track", Operator: metav1.LabelSelectorOpDoesNotExist},
+		}})
+		Expect(cli.Create(ctx, d)).To(Succeed())
+
+		Expect(reconciler.remediate(ctx, nil, d, components.RestartPods)).To(Succeed())
+
+		Expect(remainingPods(ctx)).To(ConsistOf("dashboard-canary", "notebook-controller"))
+	})
+
+	It("should refuse to restart pods with an empty selector", func(ctx context.Context) {
+		d := deployment(&metav1.LabelSelector{})
+		Expect(cli.Create(ctx, d)).To(Succeed())
+
+		Expect(reconciler.remediate(ctx, nil, d, components.RestartPods)).To(MatchError(ContainSubstring("selector is empty")))
+
+		Expect(remainingPods(ctx)).To(HaveLen(3))
+	})
+})
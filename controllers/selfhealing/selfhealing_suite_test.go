@@ -0,0 +1,13 @@
+package selfhealing
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSelfHealing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Self-healing controller suite")
+}
@@ -0,0 +1,281 @@
+// Package servingcatalog contains controller logic listing the models served in data science projects in a
+// cluster-scoped ServingCatalog, for the dashboard and discovery tooling to find them without scanning every project.
+package servingcatalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// CatalogName is the name of the ServingCatalog maintained by the operator.
+	CatalogName = "default-serving-catalog"
+	// InferenceServices are not watched, data science projects are scanned periodically.
+	scanInterval = 5 * time.Minute
+
+	enableAuthAnnotation     = "security.opendatahub.io/enable-auth"
+	runtimeVersionAnnotation = "opendatahub.io/runtime-version"
+)
+
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=servingcatalogs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="inventory.opendatahub.io",resources=servingcatalogs/status,verbs=get;update;patch
+
+// ServingCatalogReconciler holds the controller configuration.
+type ServingCatalogReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader lists InferenceServices and ServingRuntimes of data science projects, which are not cached.
+	APIReader client.Reader
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServingCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for the catalog of serving endpoints.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("serving-catalog-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&inventoryv1alpha1.ServingCatalog{}, handler.EnqueueRequestsFromMapFunc(r.watchCatalog),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile lists the models served in the data science projects selected by the ServingCatalog in its status.
+// It requeues to list models deployed or changed in the meantime.
+func (r *ServingCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !featuregate.Enabled(featuregate.ServingCatalog) {
+		catalog := &inventoryv1alpha1.ServingCatalog{ObjectMeta: metav1.ObjectMeta{Name: CatalogName}}
+		return ctrl.Result{}, client.IgnoreNotFound(r.Client.Delete(ctx, catalog))
+	}
+
+	catalog, err := r.getOrCreate(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	endpoints, err := r.scan(ctx, catalog.Spec.NamespaceSelector)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(endpoints) != catalog.Status.Total {
+		r.Log.Info("Serving endpoints changed", "total", len(endpoints))
+	}
+	now := metav1.Now()
+	catalog.Status = inventoryv1alpha1.ServingCatalogStatus{
+		LastScanTime: &now,
+		Total:        len(endpoints),
+		Endpoints:    endpoints,
+	}
+	if err := r.Client.Status().Update(ctx, catalog); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update ServingCatalog: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: scanInterval}, nil
+}
+
+// getOrCreate returns the ServingCatalog, created without selector if needed.
+func (r *ServingCatalogReconciler) getOrCreate(ctx context.Context) (*inventoryv1alpha1.ServingCatalog, error) {
+	catalog := &inventoryv1alpha1.ServingCatalog{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: CatalogName}, catalog)
+	if !k8serr.IsNotFound(err) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ServingCatalog: %w", err)
+		}
+		return catalog, nil
+	}
+
+	catalog = &inventoryv1alpha1.ServingCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   CatalogName,
+			Labels: map[string]string{labels.K8SCommon.PartOf: "opendatahub-operator"},
+		},
+	}
+	if err := r.Client.Create(ctx, catalog); err != nil {
+		return nil, fmt.Errorf("failed to create ServingCatalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+// scan returns the endpoints of the InferenceServices of the selected data science projects, sorted by namespace and
+// name.
+func (r *ServingCatalogReconciler) scan(ctx context.Context, namespaceSelector *metav1.LabelSelector) ([]inventoryv1alpha1.ServingEndpoint, error) {
+	selector := k8slabels.Everything()
+	if namespaceSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(namespaceSelector); err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+	}
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+	selected := make(map[string]bool, len(projects.Items))
+	for _, project := range projects.Items {
+		selected[project.Name] = selector.Matches(k8slabels.Set(project.Labels))
+	}
+
+	isvcs, err := r.list(ctx, gvk.InferenceService)
+	if err != nil {
+		return nil, err
+	}
+	runtimes, err := r.runtimeVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []inventoryv1alpha1.ServingEndpoint{}
+	for i := range isvcs {
+		isvc := &isvcs[i]
+		if !selected[isvc.GetNamespace()] {
+			continue
+		}
+		endpoint := inventoryv1alpha1.ServingEndpoint{
+			Namespace:      isvc.GetNamespace(),
+			Name:           isvc.GetName(),
+			AuthRequired:   isvc.GetAnnotations()[enableAuthAnnotation] == "true",
+			DeploymentMode: isvc.GetAnnotations()[annotations.DeploymentMode],
+			Ready:          ready(isvc),
+		}
+		endpoint.URL, _, _ = unstructured.NestedString(isvc.Object, "status", "url")
+		endpoint.InternalURL, _, _ = unstructured.NestedString(isvc.Object, "status", "address", "url")
+		endpoint.ModelFormat, _, _ = unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "modelFormat", "name")
+		endpoint.Runtime, _, _ = unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "runtime")
+		if endpoint.Runtime != "" {
+			// ServingRuntimes of the project take precedence over ClusterServingRuntimes of the same name
+			endpoint.RuntimeVersion = runtimes[isvc.GetNamespace()+"/"+endpoint.Runtime]
+			if endpoint.RuntimeVersion == "" {
+				endpoint.RuntimeVersion = runtimes[endpoint.Runtime]
+			}
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Namespace != endpoints[j].Namespace {
+			return endpoints[i].Namespace < endpoints[j].Namespace
+		}
+		return endpoints[i].Name < endpoints[j].Name
+	})
+
+	return endpoints, nil
+}
+
+// runtimeVersions returns the versions of ServingRuntimes, by namespace and name, and of ClusterServingRuntimes, by
+// name.
+func (r *ServingCatalogReconciler) runtimeVersions(ctx context.Context) (map[string]string, error) {
+	versions := map[string]string{}
+	for _, kind := range []schema.GroupVersionKind{gvk.ServingRuntime, gvk.ClusterServingRuntime} {
+		items, err := r.list(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			key := items[i].GetName()
+			if namespace := items[i].GetNamespace(); namespace != "" {
+				key = namespace + "/" + key
+			}
+			versions[key] = runtimeVersion(&items[i])
+		}
+	}
+
+	return versions, nil
+}
+
+// list returns the resources of the kind, none when its CRD is not installed.
+func (r *ServingCatalogReconciler) list(ctx context.Context, kind schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(kind)
+	if err := r.APIReader.List(ctx, list); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+	}
+
+	return list.Items, nil
+}
+
+// runtimeVersion returns the version the runtime is annotated with, or the tag of the image of its first container.
+func runtimeVersion(servingRuntime *unstructured.Unstructured) string {
+	if version := servingRuntime.GetAnnotations()[runtimeVersionAnnotation]; version != "" {
+		return version
+	}
+	containers, _, _ := unstructured.NestedSlice(servingRuntime.Object, "spec", "containers")
+	if len(containers) == 0 {
+		return ""
+	}
+	container, _ := containers[0].(map[string]any)
+	image, _, _ := unstructured.NestedString(container, "image")
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+
+	return ""
+}
+
+// ready tells whether the Ready condition of the InferenceService is true.
+func ready(isvc *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(isvc.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, _ := item.(map[string]any)
+		if condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+
+	return false
+}
+
+// watchCatalog reconciles the DSCInitializations when the selector of the catalog changes.
+func (r *ServingCatalogReconciler) watchCatalog(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DSCInitializations")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
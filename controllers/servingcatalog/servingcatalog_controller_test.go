@@ -0,0 +1,240 @@
+package servingcatalog
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func resource(kind schema.GroupVersionKind, namespace, name string, fields map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetGroupVersionKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	return obj
+}
+
+func project(name, team string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.ODH.Dashboard: "true", "team": team}}}
+}
+
+// servingRuntime returns a ServingRuntime running the given image.
+func servingRuntime(kind schema.GroupVersionKind, namespace, name, image string) *unstructured.Unstructured {
+	return resource(kind, namespace, name, map[string]any{
+		"spec": map[string]any{"containers": []any{map[string]any{"name": name, "image": image}}},
+	})
+}
+
+var _ = Describe("Serving catalog controller", func() {
+	var (
+		objects []client.Object
+		funcs   interceptor.Funcs
+		cli     client.Client
+		req     = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	reconciler := func() *ServingCatalogReconciler {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			Expect(inventoryv1alpha1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&inventoryv1alpha1.ServingCatalog{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return &ServingCatalogReconciler{Client: cli, Scheme: cli.Scheme(), APIReader: cli, Log: logr.Discard()}
+	}
+	scan := func(ctx context.Context, selector *metav1.LabelSelector) []inventoryv1alpha1.ServingEndpoint {
+		GinkgoHelper()
+		endpoints, err := reconciler().scan(ctx, selector)
+		Expect(err).ToNot(HaveOccurred())
+		return endpoints
+	}
+	catalog := func(ctx context.Context) (*inventoryv1alpha1.ServingCatalog, error) {
+		catalog := &inventoryv1alpha1.ServingCatalog{}
+		return catalog, cli.Get(ctx, client.ObjectKey{Name: CatalogName}, catalog)
+	}
+
+	BeforeEach(func() {
+		detector := resource(gvk.InferenceService, "fraud", "detector", map[string]any{
+			"spec": map[string]any{"predictor": map[string]any{"model": map[string]any{
+				"modelFormat": map[string]any{"name": "onnx"},
+				"runtime":     "ovms",
+			}}},
+			"status": map[string]any{
+				"url":        "https://detector-fraud.apps.example.com",
+				"address":    map[string]any{"url": "http://detector-predictor.fraud.svc.cluster.local"},
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+			},
+		})
+		detector.SetAnnotations(map[string]string{enableAuthAnnotation: "true"})
+		vllm := resource(gvk.ClusterServingRuntime, "", "vllm", map[string]any{})
+		vllm.SetAnnotations(map[string]string{runtimeVersionAnnotation: "v0.4.2"})
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}},
+			project("fraud", "risk"),
+			project("sales", "marketing"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}},
+			detector,
+			servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "quay.io/opendatahub/openvino_model_server:2024.1"),
+			vllm,
+			resource(gvk.InferenceService, "fraud", "llm", map[string]any{
+				"spec": map[string]any{"predictor": map[string]any{"model": map[string]any{"runtime": "vllm"}}},
+			}),
+			resource(gvk.InferenceService, "sales", "forecast", map[string]any{}),
+			resource(gvk.InferenceService, "platform", "internal", map[string]any{}),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	Context("scanning data science projects", func() {
+		It("should list the endpoints of all projects, sorted", func(ctx context.Context) {
+			Expect(scan(ctx, nil)).To(Equal([]inventoryv1alpha1.ServingEndpoint{
+				{
+					Namespace:      "fraud",
+					Name:           "detector",
+					URL:            "https://detector-fraud.apps.example.com",
+					InternalURL:    "http://detector-predictor.fraud.svc.cluster.local",
+					AuthRequired:   true,
+					ModelFormat:    "onnx",
+					Runtime:        "ovms",
+					RuntimeVersion: "2024.1",
+					Ready:          true,
+				},
+				{Namespace: "fraud", Name: "llm", Runtime: "vllm", RuntimeVersion: "v0.4.2"},
+				{Namespace: "sales", Name: "forecast"},
+			}))
+		})
+
+		It("should only list the endpoints of selected projects", func(ctx context.Context) {
+			Expect(scan(ctx, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "marketing"}})).
+				To(HaveExactElements(HaveField("Name", "forecast")))
+		})
+
+		It("should prefer the runtimes of the project over cluster runtimes of the same name", func(ctx context.Context) {
+			objects = append(objects, servingRuntime(gvk.ServingRuntime, "fraud", "vllm", "quay.io/example/vllm:v0.5.0"))
+
+			Expect(scan(ctx, nil)).To(ContainElement(And(HaveField("Name", "llm"), HaveField("RuntimeVersion", "v0.5.0"))))
+		})
+
+		It("should list nothing while KServe is not installed", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Group == gvk.InferenceService.Group {
+					return &meta.NoKindMatchError{GroupKind: gvk.InferenceService.GroupKind()}
+				}
+				return cli.List(ctx, list, opts...)
+			}
+
+			Expect(scan(ctx, nil)).To(BeEmpty())
+		})
+
+		It("should reject invalid selectors", func(ctx context.Context) {
+			_, err := reconciler().scan(ctx, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Unknown"},
+			}})
+			Expect(err).To(MatchError(ContainSubstring("invalid namespaceSelector")))
+		})
+	})
+
+	DescribeTable("should report the version of runtimes",
+		func(obj *unstructured.Unstructured, expected string) {
+			Expect(runtimeVersion(obj)).To(Equal(expected))
+		},
+		Entry("from the tag of their image", servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "quay.io/example/ovms:2024.1"), "2024.1"),
+		Entry("from the tag of an image of a registry with a port", servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "registry:5000/ovms:2024.1"), "2024.1"),
+		Entry("unless their image has no tag", servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "registry:5000/ovms"), ""),
+		Entry("unless their image is pinned by digest", servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "quay.io/example/ovms@sha256:abc"), ""),
+		Entry("unless they have no container", resource(gvk.ServingRuntime, "fraud", "ovms", map[string]any{}), ""),
+	)
+
+	It("should report the version runtimes are annotated with over the tag of their image", func() {
+		obj := servingRuntime(gvk.ServingRuntime, "fraud", "ovms", "quay.io/example/ovms:latest")
+		obj.SetAnnotations(map[string]string{runtimeVersionAnnotation: "2024.1"})
+
+		Expect(runtimeVersion(obj)).To(Equal("2024.1"))
+	})
+
+	Context("reconciling", func() {
+		It("should delete the catalog while the feature gate is disabled", func(ctx context.Context) {
+			objects = append(objects, &inventoryv1alpha1.ServingCatalog{ObjectMeta: metav1.ObjectMeta{Name: CatalogName}})
+
+			Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+			_, err := catalog(ctx)
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the feature gate is enabled", func() {
+			BeforeEach(func() {
+				Expect(featuregate.Set(map[string]bool{featuregate.ServingCatalog: true})).To(Succeed())
+				DeferCleanup(func() {
+					Expect(featuregate.Set(nil)).To(Succeed())
+				})
+			})
+
+			It("should create the catalog and list the endpoints", func(ctx context.Context) {
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{RequeueAfter: scanInterval}))
+
+				saved, err := catalog(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "opendatahub-operator"))
+				Expect(saved.Status.Total).To(Equal(3))
+				Expect(saved.Status.Endpoints).To(HaveLen(3))
+				Expect(saved.Status.LastScanTime).ToNot(BeNil())
+			})
+
+			It("should list the endpoints of the projects selected by the catalog", func(ctx context.Context) {
+				objects = append(objects, &inventoryv1alpha1.ServingCatalog{
+					ObjectMeta: metav1.ObjectMeta{Name: CatalogName},
+					Spec: inventoryv1alpha1.ServingCatalogSpec{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "risk"}},
+					},
+				})
+
+				_, err := reconciler().Reconcile(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+
+				saved, err := catalog(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved.Status.Endpoints).To(HaveExactElements(HaveField("Name", "detector"), HaveField("Name", "llm")))
+			})
+
+			It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+				objects = objects[1:]
+
+				Expect(reconciler().Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+				_, err := catalog(ctx)
+				Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	It("should reconcile the DSCInitializations when the catalog changes", func(ctx context.Context) {
+		Expect(reconciler().watchCatalog(ctx, &inventoryv1alpha1.ServingCatalog{})).To(ConsistOf(req))
+	})
+})
@@ -0,0 +1,13 @@
+package servingcatalog
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestServingCatalog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Serving catalog suite")
+}
@@ -0,0 +1,198 @@
+// Package sidecarinjection contains controller logic labeling namespaces for Istio sidecar injection,
+// according to the sidecar injection policy set in the DSCInitialization.
+package sidecarinjection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// SidecarInjectionReconciler holds the controller configuration.
+type SidecarInjectionReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SidecarInjectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for Istio sidecar injection.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("sidecar-injection-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.watchNamespaces), builder.WithPredicates(namespaceLabelsChanged)).
+		Complete(r)
+}
+
+// Reconcile labels namespaces listed in the sidecar injection policy of the DSCInitialization for injection by the
+// configured Istio revision, and removes injection labels from namespaces the policy does not manage anymore.
+func (r *SidecarInjectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("dsci", req.Name)
+
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var policy *infrav1.SidecarInjectionSpec
+	if instance.GetDeletionTimestamp() == nil && instance.Spec.ServiceMesh != nil &&
+		instance.Spec.ServiceMesh.ManagementState == operatorv1.Managed {
+		policy = instance.Spec.ServiceMesh.SidecarInjection
+	}
+	desired := desiredNamespaces(policy)
+
+	var errs []error
+	for name, injectionLabels := range desired {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+			// namespaces are labeled once they are created
+			errs = append(errs, client.IgnoreNotFound(err))
+			continue
+		}
+		if err := r.patchLabels(ctx, namespace, injectionLabels); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	managed := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, managed, client.HasLabels{labels.ODH.SidecarInjection}); err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range managed.Items {
+		namespace := &managed.Items[i]
+		if _, exists := desired[namespace.Name]; exists {
+			continue
+		}
+		log.Info("Removing sidecar injection labels", "namespace", namespace.Name)
+		if err := r.patchLabels(ctx, namespace, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "SidecarInjectionFailed", "Failed to label namespaces for sidecar injection: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// patchLabels replaces the injection labels of the namespace with the given ones, removing all of them when nil.
+func (r *SidecarInjectionReconciler) patchLabels(ctx context.Context, namespace *corev1.Namespace, injectionLabels map[string]string) error {
+	original := namespace.DeepCopy()
+	if !setInjectionLabels(namespace, injectionLabels) {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, namespace, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update sidecar injection labels of namespace %s: %w", namespace.Name, err)
+	}
+
+	return nil
+}
+
+// desiredNamespaces returns the injection labels of each namespace managed by the policy.
+// With a revision, namespaces are labeled with "istio.io/rev" only: "istio-injection" takes precedence over it
+// and would keep injecting sidecars of the previous revision.
+func desiredNamespaces(policy *infrav1.SidecarInjectionSpec) map[string]map[string]string {
+	desired := map[string]map[string]string{}
+	if policy == nil {
+		return desired
+	}
+	for _, namespace := range policy.Namespaces {
+		injectionLabels := map[string]string{labels.ODH.SidecarInjection: "true"}
+		switch {
+		case namespace.Policy == infrav1.SidecarInjectionDisabled:
+			injectionLabels[labels.IstioInjection] = "disabled"
+		case policy.Revision != "":
+			injectionLabels[labels.IstioRevision] = policy.Revision
+		default:
+			injectionLabels[labels.IstioInjection] = "enabled"
+		}
+		desired[namespace.Name] = injectionLabels
+	}
+
+	return desired
+}
+
+// setInjectionLabels sets the injection labels of the namespace to the given ones, and tells whether any changed.
+func setInjectionLabels(namespace *corev1.Namespace, injectionLabels map[string]string) bool {
+	nsLabels := namespace.GetLabels()
+	if nsLabels == nil {
+		nsLabels = map[string]string{}
+	}
+
+	changed := false
+	for _, key := range []string{labels.IstioInjection, labels.IstioRevision, labels.ODH.SidecarInjection} {
+		current, exists := nsLabels[key]
+		value, desired := injectionLabels[key]
+		switch {
+		case desired && (!exists || current != value):
+			nsLabels[key] = value
+			changed = true
+		case !desired && exists:
+			delete(nsLabels, key)
+			changed = true
+		}
+	}
+	namespace.SetLabels(nsLabels)
+
+	return changed
+}
+
+// watchNamespaces reconciles all DSCInitializations, as the one managing the namespace is not known from the namespace.
+func (r *SidecarInjectionReconciler) watchNamespaces(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DSCInitializations")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
+
+// namespaceLabelsChanged passes created namespaces and namespaces whose injection labels changed, e.g. edited by hand.
+var namespaceLabelsChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		for _, key := range []string{labels.IstioInjection, labels.IstioRevision, labels.ODH.SidecarInjection} {
+			if e.ObjectOld.GetLabels()[key] != e.ObjectNew.GetLabels()[key] {
+				return true
+			}
+		}
+		return false
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
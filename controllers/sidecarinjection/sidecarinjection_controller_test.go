@@ -0,0 +1,211 @@
+package sidecarinjection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar injection labels", func() {
+	namespaces := []infrav1.NamespaceSidecarInjection{
+		{Name: "models", Policy: infrav1.SidecarInjectionEnabled},
+		{Name: "pipelines", Policy: infrav1.SidecarInjectionDisabled},
+	}
+
+	DescribeTable("should be derived from the policy",
+		func(policy *infrav1.SidecarInjectionSpec, expected map[string]map[string]string) {
+			Expect(desiredNamespaces(policy)).To(Equal(expected))
+		},
+		Entry("without policy", nil, map[string]map[string]string{}),
+		Entry("for the default revision", &infrav1.SidecarInjectionSpec{Namespaces: namespaces}, map[string]map[string]string{
+			"models":    {labels.ODH.SidecarInjection: "true", labels.IstioInjection: "enabled"},
+			"pipelines": {labels.ODH.SidecarInjection: "true", labels.IstioInjection: "disabled"},
+		}),
+		Entry("for a revision", &infrav1.SidecarInjectionSpec{Revision: "v2-5", Namespaces: namespaces}, map[string]map[string]string{
+			"models":    {labels.ODH.SidecarInjection: "true", labels.IstioRevision: "v2-5"},
+			"pipelines": {labels.ODH.SidecarInjection: "true", labels.IstioInjection: "disabled"},
+		}),
+	)
+
+	Context("set on a namespace", func() {
+		var namespace *corev1.Namespace
+		revision := map[string]string{labels.ODH.SidecarInjection: "true", labels.IstioRevision: "v2-5"}
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{}
+			namespace.SetLabels(map[string]string{"team": "a", labels.IstioInjection: "enabled"})
+		})
+
+		It("should drop the labels of the default revision when moving to a revision", func() {
+			Expect(setInjectionLabels(namespace, revision)).To(BeTrue())
+
+			Expect(namespace.GetLabels()).To(Equal(map[string]string{"team": "a", labels.ODH.SidecarInjection: "true", labels.IstioRevision: "v2-5"}))
+		})
+
+		It("should not change labels already set", func() {
+			setInjectionLabels(namespace, revision)
+
+			Expect(setInjectionLabels(namespace, revision)).To(BeFalse())
+		})
+
+		It("should only keep labels not managed by the operator once removed", func() {
+			setInjectionLabels(namespace, revision)
+
+			Expect(setInjectionLabels(namespace, nil)).To(BeTrue())
+			Expect(namespace.GetLabels()).To(Equal(map[string]string{"team": "a"}))
+		})
+
+		It("should label a namespace without labels", func() {
+			namespace.SetLabels(nil)
+
+			Expect(setInjectionLabels(namespace, revision)).To(BeTrue())
+			Expect(namespace.GetLabels()).To(Equal(revision))
+		})
+	})
+
+	DescribeTable("should be watched on namespaces",
+		func(old, updated map[string]string, expected bool) {
+			e := event.UpdateEvent{
+				ObjectOld: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: old}},
+				ObjectNew: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: updated}},
+			}
+			Expect(namespaceLabelsChanged.Update(e)).To(Equal(expected))
+		},
+		Entry("when an injection label changed", map[string]string{labels.IstioInjection: "enabled"}, map[string]string{labels.IstioInjection: "disabled"}, true),
+		Entry("when an injection label was removed", map[string]string{labels.IstioRevision: "v2-5"}, nil, true),
+		Entry("but not when other labels changed", map[string]string{"team": "a"}, map[string]string{"team": "b"}, false),
+	)
+})
+
+var _ = Describe("Sidecar injection controller", func() {
+	var (
+		dsci     *dsciv1.DSCInitialization
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	namespaceLabels := func(ctx context.Context, name string) map[string]string {
+		GinkgoHelper()
+		namespace := &corev1.Namespace{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: name}, namespace)).To(Succeed())
+		return namespace.Labels
+	}
+	reconcile := func(ctx context.Context) error {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, dsci)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &SidecarInjectionReconciler{Client: cli, Log: logr.Discard(), Recorder: recorder}
+		_, err := r.Reconcile(ctx, req)
+		return err
+	}
+	update := func(ctx context.Context, mutate func(*dsciv1.DSCInitialization)) {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, req.NamespacedName, dsci)).To(Succeed())
+		mutate(dsci)
+		Expect(cli.Update(ctx, dsci)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dsci = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{ServiceMesh: &infrav1.ServiceMeshSpec{
+				ManagementState: operatorv1.Managed,
+				SidecarInjection: &infrav1.SidecarInjectionSpec{Namespaces: []infrav1.NamespaceSidecarInjection{
+					{Name: "models", Policy: infrav1.SidecarInjectionEnabled},
+					{Name: "not-created-yet", Policy: infrav1.SidecarInjectionEnabled},
+				}},
+			}},
+		}
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "models", Labels: map[string]string{"team": "a"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{labels.IstioInjection: "enabled"}}},
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should label the namespaces of the policy, once they exist", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Succeed())
+
+		Expect(namespaceLabels(ctx, "models")).To(Equal(map[string]string{
+			"team": "a", labels.ODH.SidecarInjection: "true", labels.IstioInjection: "enabled",
+		}))
+		Expect(namespaceLabels(ctx, "other")).To(Equal(map[string]string{labels.IstioInjection: "enabled"}))
+	})
+
+	It("should move namespaces to the revision of the policy", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Succeed())
+		update(ctx, func(dsci *dsciv1.DSCInitialization) { dsci.Spec.ServiceMesh.SidecarInjection.Revision = "v2-5" })
+
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(namespaceLabels(ctx, "models")).To(Equal(map[string]string{
+			"team": "a", labels.ODH.SidecarInjection: "true", labels.IstioRevision: "v2-5",
+		}))
+	})
+
+	DescribeTable("should remove labels of namespaces no longer managed",
+		func(ctx context.Context, mutate func(*dsciv1.DSCInitialization)) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, mutate)
+
+			Expect(reconcile(ctx)).To(Succeed())
+			Expect(namespaceLabels(ctx, "models")).To(Equal(map[string]string{"team": "a"}))
+			Expect(namespaceLabels(ctx, "other")).To(Equal(map[string]string{labels.IstioInjection: "enabled"}))
+		},
+		Entry("when removed from the policy", func(dsci *dsciv1.DSCInitialization) {
+			dsci.Spec.ServiceMesh.SidecarInjection.Namespaces = nil
+		}),
+		Entry("when the policy is removed", func(dsci *dsciv1.DSCInitialization) {
+			dsci.Spec.ServiceMesh.SidecarInjection = nil
+		}),
+		Entry("when Service Mesh is not managed anymore", func(dsci *dsciv1.DSCInitialization) {
+			dsci.Spec.ServiceMesh.ManagementState = operatorv1.Removed
+		}),
+	)
+
+	When("labeling a namespace fails", func() {
+		BeforeEach(func() {
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return errors.New("forbidden")
+			}
+		})
+
+		It("should return the error and emit an event", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(MatchError(ContainSubstring("failed to update sidecar injection labels of namespace models")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("SidecarInjectionFailed")))
+		})
+	})
+
+	It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Succeed())
+		Expect(cli.Delete(ctx, dsci)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Succeed())
+	})
+})
@@ -0,0 +1,13 @@
+package sidecarinjection
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSidecarInjection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sidecar injection controller suite")
+}
@@ -0,0 +1,23 @@
+package status
+
+// AcceleratorStatus reports the readiness of the GPU operator of a vendor and the accelerators nodes advertise.
+// +kubebuilder:object:generate=true
+type AcceleratorStatus struct {
+	// Vendor of the accelerators, e.g. "NVIDIA" or "AMD"
+	Vendor string `json:"vendor"`
+	// Resource name of the accelerators on nodes, e.g. "nvidia.com/gpu"
+	Resource string `json:"resource"`
+	// OperatorInstalled tells whether the GPU operator of the vendor is installed
+	OperatorInstalled bool `json:"operatorInstalled"`
+	// Ready tells whether the GPU operator is ready, when installed, and nodes advertise accelerators
+	Ready bool `json:"ready"`
+	// Nodes advertising accelerators of the vendor
+	// +optional
+	Nodes int32 `json:"nodes,omitempty"`
+	// Allocatable is the number of accelerators of the vendor allocatable over all nodes
+	// +optional
+	Allocatable int64 `json:"allocatable,omitempty"`
+	// Message explains what is missing for the accelerators to be ready
+	// +optional
+	Message string `json:"message,omitempty"`
+}
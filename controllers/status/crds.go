@@ -0,0 +1,38 @@
+package status
+
+// CRDUpdate records the incompatible changes between a CRD in the cluster and the one of the manifests of a component.
+// +kubebuilder:object:generate=true
+type CRDUpdate struct {
+	// Name of the component deploying the CRD
+	Component string `json:"component"`
+	// Name of the CRD
+	Name string `json:"name"`
+	// Applied tells whether the CRD was updated nonetheless, as allowed by the crdUpdatePolicy of the component
+	// +optional
+	Applied bool `json:"applied,omitempty"`
+	// Changes breaking existing resources of the CRD, or clients of the removed fields
+	Changes []string `json:"changes"`
+}
+
+// SetCRDUpdates replaces the CRD updates of the component with the given entries.
+func SetCRDUpdates(updates *[]CRDUpdate, component string, entries []CRDUpdate) {
+	RemoveCRDUpdates(updates, component)
+	for _, entry := range entries {
+		entry.Component = component
+		*updates = append(*updates, entry)
+	}
+}
+
+// RemoveCRDUpdates removes the CRD updates of the component from the list.
+func RemoveCRDUpdates(updates *[]CRDUpdate, component string) {
+	filtered := (*updates)[:0]
+	for _, entry := range *updates {
+		if entry.Component != component {
+			filtered = append(filtered, entry)
+		}
+	}
+	if len(filtered) == 0 {
+		filtered = nil
+	}
+	*updates = filtered
+}
@@ -0,0 +1,44 @@
+package status
+
+// Sources of manifests other than the URI they were downloaded from.
+const (
+	ManifestsSourceEmbedded = "embedded"
+)
+
+// ManifestsProvenance records which manifests a component was deployed from.
+type ManifestsProvenance struct {
+	// Name of the component deployed from the manifests
+	Component string `json:"component"`
+	// Path of the manifests, relative to the manifests directory of the operator
+	Path string `json:"path"`
+	// Source of the manifests, either embedded in the operator image or the URI they were downloaded from
+	Source string `json:"source"`
+	// Digest of the manifests, e.g. sha256:<hex>
+	Digest string `json:"digest"`
+	// Verified tells whether the digest matched the one expected in devFlags
+	// +optional
+	Verified bool `json:"verified,omitempty"`
+}
+
+// SetManifestsProvenance replaces the provenance of the manifests of the component with the given entries.
+func SetManifestsProvenance(provenance *[]ManifestsProvenance, component string, entries []ManifestsProvenance) {
+	RemoveManifestsProvenance(provenance, component)
+	for _, entry := range entries {
+		entry.Component = component
+		*provenance = append(*provenance, entry)
+	}
+}
+
+// RemoveManifestsProvenance removes the provenance of the manifests of the component from the list.
+func RemoveManifestsProvenance(provenance *[]ManifestsProvenance, component string) {
+	filtered := (*provenance)[:0]
+	for _, entry := range *provenance {
+		if entry.Component != component {
+			filtered = append(filtered, entry)
+		}
+	}
+	if len(filtered) == 0 {
+		filtered = nil
+	}
+	*provenance = filtered
+}
@@ -0,0 +1,20 @@
+package status
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// skippedStatusUpdates counts status updates not sent to the API server because they would not have changed the
+// status, each of which would otherwise have been a write to etcd and a watch event for every client of the kind.
+var skippedStatusUpdates = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "odh_status_updates_skipped_total",
+		Help: "Number of status updates skipped because the status was unchanged, labeled by kind.",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(skippedStatusUpdates)
+}
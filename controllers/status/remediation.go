@@ -0,0 +1,98 @@
+package status
+
+import (
+	"errors"
+)
+
+// Remediation codes surfaced in status.remediation of DataScienceCluster and DSCInitialization.
+const (
+	RemediationMissingOperator      = "MissingOperator"
+	RemediationConflictingOperator  = "ConflictingOperator"
+	RemediationServiceMeshRequired  = "ServiceMeshRequired"
+	RemediationConflictingCRD       = "ConflictingCRD"
+	RemediationNamespaceNotReady    = "NamespaceNotReady"
+	RemediationDeploymentNotReady   = "DeploymentNotReady"
+	RemediationFeatureGateDisabled  = "FeatureGateDisabled"
+	RemediationInvalidManifests     = "InvalidManifests"
+	RemediationInvalidConfiguration = "InvalidConfiguration"
+	RemediationUnknown              = "Unknown"
+)
+
+// Remediation describes how to fix a failing component or capability.
+type Remediation struct {
+	// Name of the failing component or capability
+	Target string `json:"target"`
+	// Machine-readable error code
+	Code string `json:"code"`
+	// Human-readable next steps for the admin
+	Message string `json:"message"`
+}
+
+// RemediationHinter is implemented by errors which know how they can be fixed.
+type RemediationHinter interface {
+	RemediationHint() (string, string)
+}
+
+// RemediationError attaches a remediation code and next steps to an error.
+type RemediationError struct {
+	code string
+	hint string
+	err  error
+}
+
+func NewRemediationError(code, hint string, err error) *RemediationError {
+	return &RemediationError{
+		code: code,
+		hint: hint,
+		err:  err,
+	}
+}
+
+func (e *RemediationError) Unwrap() error {
+	return e.err
+}
+
+func (e *RemediationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *RemediationError) RemediationHint() (string, string) {
+	return e.code, e.hint
+}
+
+// RemediationFor builds the remediation of the target out of the first hint found in the error chain.
+// Errors without any hint fall back to the Unknown code, with the error itself as the message.
+func RemediationFor(target string, err error) Remediation {
+	var hinter RemediationHinter
+	if errors.As(err, &hinter) {
+		code, hint := hinter.RemediationHint()
+		return Remediation{Target: target, Code: code, Message: hint}
+	}
+
+	return Remediation{Target: target, Code: RemediationUnknown, Message: err.Error()}
+}
+
+// SetRemediation adds the remediation to the list, replacing the existing one of the same target.
+func SetRemediation(remediations *[]Remediation, remediation Remediation) {
+	for i := range *remediations {
+		if (*remediations)[i].Target == remediation.Target {
+			(*remediations)[i] = remediation
+			return
+		}
+	}
+	*remediations = append(*remediations, remediation)
+}
+
+// RemoveRemediation removes the remediation of the target from the list.
+func RemoveRemediation(remediations *[]Remediation, target string) {
+	filtered := (*remediations)[:0]
+	for _, remediation := range *remediations {
+		if remediation.Target != target {
+			filtered = append(filtered, remediation)
+		}
+	}
+	if len(filtered) == 0 {
+		filtered = nil
+	}
+	*remediations = filtered
+}
@@ -0,0 +1,83 @@
+package status_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Remediation", func() {
+	Context("of an error", func() {
+		It("should use the hint of the error", func() {
+			err := status.NewRemediationError(status.RemediationMissingOperator, "Install the operator", errors.New("operator not found"))
+
+			Expect(status.RemediationFor("kserve", err)).To(Equal(status.Remediation{
+				Target: "kserve", Code: status.RemediationMissingOperator, Message: "Install the operator",
+			}))
+			Expect(err).To(MatchError("operator not found"))
+		})
+
+		It("should use the hint of a wrapped error", func() {
+			hinted := status.NewRemediationError(status.RemediationMissingOperator, "Install the operator", errors.New("operator not found"))
+
+			remediation := status.RemediationFor("kserve", fmt.Errorf("failed to reconcile: %w", hinted))
+			Expect(remediation.Code).To(Equal(status.RemediationMissingOperator))
+			Expect(remediation.Message).To(Equal("Install the operator"))
+		})
+
+		It("should use the outermost hint of the chain", func() {
+			inner := status.NewRemediationError(status.RemediationDeploymentNotReady, "Check the deployment", errors.New("not ready"))
+			outer := status.NewRemediationError(status.RemediationServiceMeshRequired, "Install Service Mesh", inner)
+
+			Expect(status.RemediationFor("kserve", outer).Code).To(Equal(status.RemediationServiceMeshRequired))
+		})
+
+		It("should fall back to the Unknown code with the error as message", func() {
+			Expect(status.RemediationFor("kserve", errors.New("boom"))).To(Equal(status.Remediation{
+				Target: "kserve", Code: status.RemediationUnknown, Message: "boom",
+			}))
+		})
+	})
+
+	Context("in a list", func() {
+		var remediations []status.Remediation
+
+		BeforeEach(func() {
+			remediations = nil
+			status.SetRemediation(&remediations, status.Remediation{Target: "kserve", Code: status.RemediationUnknown})
+			status.SetRemediation(&remediations, status.Remediation{Target: "ray", Code: status.RemediationUnknown})
+		})
+
+		It("should replace the remediation of the same target", func() {
+			status.SetRemediation(&remediations, status.Remediation{Target: "kserve", Code: status.RemediationMissingOperator})
+
+			Expect(remediations).To(Equal([]status.Remediation{
+				{Target: "kserve", Code: status.RemediationMissingOperator},
+				{Target: "ray", Code: status.RemediationUnknown},
+			}))
+		})
+
+		It("should remove the remediation of the target only", func() {
+			status.RemoveRemediation(&remediations, "kserve")
+
+			Expect(remediations).To(ConsistOf(HaveField("Target", "ray")))
+		})
+
+		It("should leave the list untouched when removing an unknown target", func() {
+			status.RemoveRemediation(&remediations, "workbenches")
+
+			Expect(remediations).To(HaveLen(2))
+		})
+
+		It("should be nil once the last remediation is removed", func() {
+			status.RemoveRemediation(&remediations, "kserve")
+			status.RemoveRemediation(&remediations, "ray")
+
+			Expect(remediations).To(BeNil())
+		})
+	})
+})
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 // Reporter handles condition reporting for a given object.
@@ -40,6 +43,7 @@ func (r *Reporter[T]) ReportCondition(ctx context.Context, optionalErr error) (T
 type SaveStatusFunc[T client.Object] func(saved T)
 
 // UpdateWithRetry updates the status of object using passed function and retries on conflict.
+// The update is skipped when it does not change the status, besides the heartbeat of its conditions.
 func UpdateWithRetry[T client.Object](ctx context.Context, cli client.Client, original T, update SaveStatusFunc[T]) (T, error) {
 	saved, ok := original.DeepCopyObject().(T)
 	if !ok {
@@ -50,7 +54,12 @@ func UpdateWithRetry[T client.Object](ctx context.Context, cli client.Client, or
 			return err
 		}
 
+		current := saved.DeepCopyObject()
 		update(saved)
+		if changed, err := statusChanged(current, saved); err == nil && !changed {
+			skippedStatusUpdates.WithLabelValues(kindOf(cli, saved)).Inc()
+			return nil
+		}
 
 		// Return err itself here (not wrapped inside another error)
 		// so that Retry can identify it correctly.
@@ -60,6 +69,54 @@ func UpdateWithRetry[T client.Object](ctx context.Context, cli client.Client, or
 	return saved, err
 }
 
+// statusChanged tells whether the status of the updated object differs from the current one. Heartbeats of conditions
+// are not taken into account, conditionsv1.SetStatusCondition bumps them even when the condition is unchanged.
+func statusChanged(current, updated runtime.Object) (bool, error) {
+	currentStatus, err := comparableStatus(current)
+	if err != nil {
+		return true, err
+	}
+	updatedStatus, err := comparableStatus(updated)
+	if err != nil {
+		return true, err
+	}
+
+	return !equality.Semantic.DeepEqual(currentStatus, updatedStatus), nil
+}
+
+func comparableStatus(obj runtime.Object) (interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	status := content["status"]
+	removeHeartbeats(status)
+
+	return status, nil
+}
+
+func removeHeartbeats(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		delete(v, "lastHeartbeatTime")
+		for _, field := range v {
+			removeHeartbeats(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			removeHeartbeats(item)
+		}
+	}
+}
+
+func kindOf(cli client.Client, obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, cli.Scheme())
+	if err != nil {
+		return obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	return gvk.Kind
+}
+
 func retryOnNotFoundOrConflict(err error) bool {
 	// We are now sharing the client, read/write can occur on delay
 	return k8serr.IsConflict(err) || k8serr.IsNotFound(err)
@@ -0,0 +1,119 @@
+package status_test
+
+import (
+	"context"
+	"errors"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updating the status", func() {
+	var (
+		instance *dscv1.DataScienceCluster
+		funcs    interceptor.Funcs
+		cli      client.Client
+		updates  int
+	)
+
+	setReady := func(saved *dscv1.DataScienceCluster) {
+		status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, "DataScienceCluster resource reconciled successfully")
+	}
+	setFailed := func(saved *dscv1.DataScienceCluster) {
+		status.SetErrorCondition(&saved.Status.Conditions, status.ReconcileFailed, "failed to deploy kserve")
+	}
+	update := func(ctx context.Context, mutate status.SaveStatusFunc[*dscv1.DataScienceCluster]) *dscv1.DataScienceCluster {
+		GinkgoHelper()
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance).WithStatusSubresource(instance).WithInterceptorFuncs(funcs).Build()
+		}
+		updated, err := status.UpdateWithRetry(ctx, cli, instance, mutate)
+		Expect(err).ToNot(HaveOccurred())
+		return updated
+	}
+
+	BeforeEach(func() {
+		instance = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		updates = 0
+		funcs = interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, cli client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				updates++
+				return cli.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}
+		cli = nil
+	})
+
+	It("should update a changed status", func(ctx context.Context) {
+		updated := update(ctx, setFailed)
+
+		Expect(updates).To(Equal(1))
+		Expect(conditionsv1.IsStatusConditionPresentAndEqual(updated.Status.Conditions, conditionsv1.ConditionDegraded, corev1.ConditionTrue)).To(BeTrue())
+	})
+
+	When("the status is up to date", func() {
+		BeforeEach(func(ctx context.Context) {
+			instance = update(ctx, setReady)
+			updates = 0
+		})
+
+		It("should not update it again, although the heartbeat of its conditions is bumped", func(ctx context.Context) {
+			updated := update(ctx, setReady)
+
+			Expect(updates).To(BeZero())
+			Expect(updated.ResourceVersion).To(Equal(instance.ResourceVersion))
+		})
+
+		It("should update it once it changes", func(ctx context.Context) {
+			updated := update(ctx, setFailed)
+
+			Expect(updates).To(Equal(1))
+			Expect(updated.ResourceVersion).ToNot(Equal(instance.ResourceVersion))
+		})
+	})
+
+	It("should retry on conflict", func(ctx context.Context) {
+		funcs.SubResourceUpdate = func(ctx context.Context, cli client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			updates++
+			if updates == 1 {
+				return k8serr.NewConflict(schema.GroupResource{Resource: "datascienceclusters"}, obj.GetName(), errors.New("modified"))
+			}
+			return cli.SubResource(subResourceName).Update(ctx, obj, opts...)
+		}
+
+		updated := update(ctx, setFailed)
+
+		Expect(updates).To(Equal(2))
+		Expect(conditionsv1.FindStatusCondition(updated.Status.Conditions, conditionsv1.ConditionDegraded)).ToNot(BeNil())
+	})
+
+	It("should report the condition determined from the error", func(ctx context.Context) {
+		update(ctx, setReady)
+		reporter := status.NewStatusReporter(cli, instance, func(err error) status.SaveStatusFunc[*dscv1.DataScienceCluster] {
+			if err != nil {
+				return setFailed
+			}
+			return setReady
+		})
+
+		updated, err := reporter.ReportCondition(ctx, errors.New("failed to deploy kserve"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(conditionsv1.IsStatusConditionPresentAndEqual(updated.Status.Conditions, conditionsv1.ConditionDegraded, corev1.ConditionTrue)).To(BeTrue())
+	})
+})
@@ -0,0 +1,28 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentReset records the customizations discarded when a component was reset to its defaults.
+// +kubebuilder:object:generate=true
+type ComponentReset struct {
+	// Name of the reset component
+	Component string `json:"component"`
+	// Time of the reset
+	Time metav1.Time `json:"time"`
+	// Discarded devFlags and extraParams of the component, as JSON
+	// +optional
+	Discarded string `json:"discarded,omitempty"`
+}
+
+// SetComponentReset adds the reset to the list, replacing the previous one of the same component.
+func SetComponentReset(resets *[]ComponentReset, reset ComponentReset) {
+	for i := range *resets {
+		if (*resets)[i].Component == reset.Component {
+			(*resets)[i] = reset
+			return
+		}
+	}
+	*resets = append(*resets, reset)
+}
@@ -63,24 +63,53 @@ const (
 
 	// ConditionReconcileComplete represents extra Condition Type, used by .Condition.Type.
 	ConditionReconcileComplete conditionsv1.ConditionType = "ReconcileComplete"
+	// ConditionConflictingManagers is set while other controllers keep changing resources managed by the operator.
+	ConditionConflictingManagers conditionsv1.ConditionType = "ConflictingManagers"
+	// ConditionCompatible is set while the resource has fields or settings the running operator does not support.
+	ConditionCompatible conditionsv1.ConditionType = "Compatible"
+	// ConditionDashboardAccessSynced reports whether the groups of the dashboard access are bound to the personas.
+	ConditionDashboardAccessSynced conditionsv1.ConditionType = "DashboardAccessSynced"
+	// ConditionWebhooksAvailable reports whether the API server can call the webhooks of the operator.
+	ConditionWebhooksAvailable conditionsv1.ConditionType = "WebhooksAvailable"
 )
 
 const (
-	CapabilityServiceMesh              conditionsv1.ConditionType = "CapabilityServiceMesh"
-	CapabilityServiceMeshAuthorization conditionsv1.ConditionType = "CapabilityServiceMeshAuthorization"
-	CapabilityDSPv2Argo                conditionsv1.ConditionType = "CapabilityDSPv2Argo"
+	CapabilityServiceMesh                 conditionsv1.ConditionType = "CapabilityServiceMesh"
+	CapabilityServiceMeshAuthorization    conditionsv1.ConditionType = "CapabilityServiceMeshAuthorization"
+	CapabilityDSPv2Argo                   conditionsv1.ConditionType = "CapabilityDSPv2Argo"
+	CapabilityServiceMeshMTLS             conditionsv1.ConditionType = "CapabilityServiceMeshMTLS"
+	CapabilityDistributedWorkloadsMetrics conditionsv1.ConditionType = "CapabilityDistributedWorkloadsMetrics"
+	CapabilityDistributedWorkloads        conditionsv1.ConditionType = "CapabilityDistributedWorkloads"
+	CapabilityAccelerators                conditionsv1.ConditionType = "CapabilityAccelerators"
 )
 
 const (
-	MissingOperatorReason string = "MissingOperator"
-	ConfiguredReason      string = "Configured"
-	RemovedReason         string = "Removed"
-	CapabilityFailed      string = "CapabilityFailed"
-	ArgoWorkflowExist     string = "ArgoWorkflowExist"
+	MissingOperatorReason    string = "MissingOperator"
+	ConfiguredReason         string = "Configured"
+	RemovedReason            string = "Removed"
+	CapabilityFailed         string = "CapabilityFailed"
+	ArgoWorkflowExist        string = "ArgoWorkflowExist"
+	NamespaceNotReady        string = "NamespaceNotReady"
+	NonCompliantWorkloads    string = "NonCompliantWorkloads"
+	ReadinessGatesPending    string = "ReadinessGatesPending"
+	ReadinessGatesTimeout    string = "ReadinessGatesTimeout"
+	ConflictingFieldManagers string = "ConflictingFieldManagers"
+	IncompatibleSpec         string = "IncompatibleSpec"
+	RoutesReachable          string = "RoutesReachable"
+	RouteUnreachable         string = "RouteUnreachable"
+	RouteCertificateExpiring string = "RouteCertificateExpiring"
+	AccessSynced             string = "AccessSynced"
+	AccessSyncFailed         string = "AccessSyncFailed"
+	ApplyingResources        string = "ApplyingResources"
+	WebhooksAvailable        string = "WebhooksAvailable"
+	WebhooksUnavailable      string = "WebhooksUnavailable"
+	AcceleratorsNotReady     string = "AcceleratorsNotReady"
 )
 
 const (
 	ReadySuffix = "Ready"
+	// RoutesHealthySuffix is appended to the component name for the condition reporting the health of its Routes.
+	RoutesHealthySuffix = "RoutesHealthy"
 )
 
 // SetProgressingCondition sets the ProgressingCondition to True and other conditions to false or
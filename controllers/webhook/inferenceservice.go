@@ -0,0 +1,168 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+)
+
+const (
+	enableAuthAnnotation  = "security.opendatahub.io/enable-auth"
+	kserveVisibilityLabel = "networking.kserve.io/visibility"
+	knativeVisibility     = "networking.knative.dev/visibility"
+)
+
+//+kubebuilder:webhook:path=/mutate-serving-kserve-io-v1beta1-inferenceservice,mutating=true,failurePolicy=ignore,sideEffects=None,groups=serving.kserve.io,resources=inferenceservices,verbs=create,versions=v1beta1,name=inferenceservice-defaults.opendatahub.io,admissionReviewVersions=v1
+//nolint:lll
+
+// InferenceServiceDefaulter applies the InferenceService defaults set in the DataScienceCluster to new InferenceServices.
+// Failures are ignored by the API server, so that model deployments are not blocked by the operator.
+type InferenceServiceDefaulter struct {
+	Client client.Client
+	Name   string
+}
+
+func (d *InferenceServiceDefaulter) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register("/mutate-serving-kserve-io-v1beta1-inferenceservice", &webhook.Admission{
+		Handler:        d,
+		LogConstructor: newLogConstructor(d.Name),
+	})
+}
+
+func (d *InferenceServiceDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx).WithName(d.Name)
+
+	defaults, err := d.defaults(ctx)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if defaults == nil {
+		return admission.Allowed("No InferenceService defaults set")
+	}
+
+	isvc := &unstructured.Unstructured{}
+	if err := json.Unmarshal(req.Object.Raw, &isvc.Object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := applyInferenceServiceDefaults(isvc, defaults); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	defaulted, err := json.Marshal(isvc.Object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	log.V(1).Info("Applying InferenceService defaults", "namespace", req.Namespace, "name", req.Name)
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+// defaults returns the InferenceService defaults of the DataScienceCluster, nil when they are not to be applied.
+func (d *InferenceServiceDefaulter) defaults(ctx context.Context) (*kserve.InferenceServiceDefaults, error) {
+	instances := &dscv1.DataScienceClusterList{}
+	if err := d.Client.List(ctx, instances); err != nil {
+		return nil, err
+	}
+	if len(instances.Items) == 0 {
+		return nil, nil
+	}
+
+	component := instances.Items[0].Spec.Components.Kserve
+	if component.ManagementState != operatorv1.Managed || component.InferenceServiceDefaults == nil ||
+		component.InferenceServiceDefaults.ManagementState != operatorv1.Managed {
+		return nil, nil
+	}
+
+	return component.InferenceServiceDefaults, nil
+}
+
+// applyInferenceServiceDefaults sets the defaults on fields of the InferenceService which are not set.
+func applyInferenceServiceDefaults(isvc *unstructured.Unstructured, defaults *kserve.InferenceServiceDefaults) error {
+	if defaults.EnableAuth != nil {
+		isvcAnnotations := isvc.GetAnnotations()
+		if _, exists := isvcAnnotations[enableAuthAnnotation]; !exists {
+			if isvcAnnotations == nil {
+				isvcAnnotations = map[string]string{}
+			}
+			isvcAnnotations[enableAuthAnnotation] = strconv.FormatBool(*defaults.EnableAuth)
+			isvc.SetAnnotations(isvcAnnotations)
+		}
+	}
+
+	if defaults.RouteVisibility != "" {
+		isvcLabels := isvc.GetLabels()
+		_, kserveSet := isvcLabels[kserveVisibilityLabel]
+		_, knativeSet := isvcLabels[knativeVisibility]
+		if !kserveSet && !knativeSet {
+			if isvcLabels == nil {
+				isvcLabels = map[string]string{}
+			}
+			// raw deployments are only exposed on request, serverless ones unless made cluster-local
+			if defaults.RouteVisibility == kserve.Exposed {
+				isvcLabels[kserveVisibilityLabel] = "exposed"
+			} else {
+				isvcLabels[knativeVisibility] = "cluster-local"
+			}
+			isvc.SetLabels(isvcLabels)
+		}
+	}
+
+	// only predictors declaring their model format select a runtime and have their resources defaulted, others
+	// embed their own containers
+	model, found, err := unstructured.NestedMap(isvc.Object, "spec", "predictor", "model")
+	if err != nil || !found {
+		return err
+	}
+
+	if defaults.Runtime != "" {
+		if runtime, _ := model["runtime"].(string); runtime == "" {
+			model["runtime"] = defaults.Runtime
+		}
+	}
+
+	if defaults.Resources != nil {
+		resources, _ := model["resources"].(map[string]interface{})
+		if resources == nil {
+			resources = map[string]interface{}{}
+		}
+		setDefaultResources(resources, "requests", defaults.Resources.Requests)
+		setDefaultResources(resources, "limits", defaults.Resources.Limits)
+		if len(resources) > 0 {
+			model["resources"] = resources
+		}
+	}
+
+	return unstructured.SetNestedMap(isvc.Object, model, "spec", "predictor", "model")
+}
+
+// setDefaultResources sets the quantity of each resource of the list which is not set under the given key.
+func setDefaultResources(resources map[string]interface{}, key string, defaults corev1.ResourceList) {
+	if len(defaults) == 0 {
+		return
+	}
+	quantities, _ := resources[key].(map[string]interface{})
+	if quantities == nil {
+		quantities = map[string]interface{}{}
+	}
+	for name, quantity := range defaults {
+		if _, exists := quantities[string(name)]; !exists {
+			quantities[string(name)] = quantity.String()
+		}
+	}
+	resources[key] = quantities
+}
@@ -0,0 +1,172 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InferenceService defaults", func() {
+	var (
+		defaults *kserve.InferenceServiceDefaults
+		isvc     *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		enableAuth := true
+		defaults = &kserve.InferenceServiceDefaults{
+			ManagementState: operatorv1.Managed,
+			Runtime:         "vllm-runtime",
+			Resources: &kserve.ResourceDefaults{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			EnableAuth:      &enableAuth,
+			RouteVisibility: kserve.Exposed,
+		}
+		isvc = &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "llm"},
+			"spec": map[string]interface{}{
+				"predictor": map[string]interface{}{
+					"model": map[string]interface{}{
+						"modelFormat": map[string]interface{}{"name": "vLLM"},
+						"resources": map[string]interface{}{
+							"requests": map[string]interface{}{"memory": "8Gi"},
+						},
+					},
+				},
+			},
+		}}
+	})
+
+	field := func(fields ...string) interface{} {
+		GinkgoHelper()
+		value, found, err := unstructured.NestedFieldCopy(isvc.Object, fields...)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue(), "%v is not set", fields)
+		return value
+	}
+
+	It("should be set on fields which are not set", func() {
+		Expect(applyInferenceServiceDefaults(isvc, defaults)).To(Succeed())
+
+		Expect(field("spec", "predictor", "model", "runtime")).To(Equal("vllm-runtime"))
+		Expect(field("spec", "predictor", "model", "resources", "requests")).To(Equal(map[string]interface{}{"cpu": "1", "memory": "8Gi"}))
+		Expect(isvc.GetAnnotations()).To(HaveKeyWithValue(enableAuthAnnotation, "true"))
+		Expect(isvc.GetLabels()).To(Equal(map[string]string{kserveVisibilityLabel: "exposed"}))
+	})
+
+	It("should keep the settings of users", func() {
+		isvc.SetAnnotations(map[string]string{enableAuthAnnotation: "false"})
+		isvc.SetLabels(map[string]string{knativeVisibility: "cluster-local"})
+		Expect(unstructured.SetNestedField(isvc.Object, "custom-runtime", "spec", "predictor", "model", "runtime")).To(Succeed())
+
+		Expect(applyInferenceServiceDefaults(isvc, defaults)).To(Succeed())
+
+		Expect(field("spec", "predictor", "model", "runtime")).To(Equal("custom-runtime"))
+		Expect(isvc.GetAnnotations()).To(HaveKeyWithValue(enableAuthAnnotation, "false"))
+		Expect(isvc.GetLabels()).To(Equal(map[string]string{knativeVisibility: "cluster-local"}))
+	})
+
+	It("should make serverless models cluster-local", func() {
+		defaults.RouteVisibility = kserve.ClusterLocal
+
+		Expect(applyInferenceServiceDefaults(isvc, defaults)).To(Succeed())
+		Expect(isvc.GetLabels()).To(Equal(map[string]string{knativeVisibility: "cluster-local"}))
+	})
+
+	It("should set limits on models which set no resources", func() {
+		defaults.Resources = &kserve.ResourceDefaults{Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}}
+		unstructured.RemoveNestedField(isvc.Object, "spec", "predictor", "model", "resources")
+
+		Expect(applyInferenceServiceDefaults(isvc, defaults)).To(Succeed())
+		Expect(field("spec", "predictor", "model", "resources")).To(Equal(map[string]interface{}{
+			"limits": map[string]interface{}{"nvidia.com/gpu": "1"},
+		}))
+	})
+
+	It("should only set metadata on predictors embedding their own containers", func() {
+		Expect(unstructured.SetNestedField(isvc.Object, map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "kserve-container"}},
+		}, "spec", "predictor")).To(Succeed())
+
+		Expect(applyInferenceServiceDefaults(isvc, defaults)).To(Succeed())
+
+		_, found, err := unstructured.NestedMap(isvc.Object, "spec", "predictor", "model")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(isvc.GetAnnotations()).To(HaveKey(enableAuthAnnotation))
+	})
+
+	Context("applied on admission", func() {
+		var dsc *dscv1.DataScienceCluster
+
+		handle := func(ctx context.Context) admission.Response {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if dsc != nil {
+				builder = builder.WithObjects(dsc)
+			}
+			raw, err := json.Marshal(isvc.Object)
+			Expect(err).ToNot(HaveOccurred())
+
+			defaulter := &InferenceServiceDefaulter{Client: builder.Build(), Name: "inferenceservice-defaults"}
+			return defaulter.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:   "llm",
+				Object: runtime.RawExtension{Raw: raw},
+			}})
+		}
+
+		BeforeEach(func() {
+			dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+			dsc.Spec.Components.Kserve.ManagementState = operatorv1.Managed
+			dsc.Spec.Components.Kserve.InferenceServiceDefaults = defaults
+		})
+
+		It("should patch new InferenceServices", func(ctx context.Context) {
+			response := handle(ctx)
+
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(ContainElement(HaveField("Path", "/spec/predictor/model/runtime")))
+		})
+
+		DescribeTable("should allow InferenceServices as they are",
+			func(ctx context.Context, mutate func(dsc **dscv1.DataScienceCluster)) {
+				mutate(&dsc)
+
+				response := handle(ctx)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Patches).To(BeEmpty())
+			},
+			Entry("without DataScienceCluster", func(dsc **dscv1.DataScienceCluster) {
+				*dsc = nil
+			}),
+			Entry("when kserve is removed", func(dsc **dscv1.DataScienceCluster) {
+				(*dsc).Spec.Components.Kserve.ManagementState = operatorv1.Removed
+			}),
+			Entry("when defaults are removed", func(dsc **dscv1.DataScienceCluster) {
+				(*dsc).Spec.Components.Kserve.InferenceServiceDefaults.ManagementState = operatorv1.Removed
+			}),
+			Entry("without defaults", func(dsc **dscv1.DataScienceCluster) {
+				(*dsc).Spec.Components.Kserve.InferenceServiceDefaults = nil
+			}),
+		)
+	})
+})
@@ -0,0 +1,121 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// checkRemoval reports the workloads of the components switched to Removed, which stop working once the components
+// are uninstalled. Removing a component with workloads is denied unless confirmed with the ConfirmRemoval annotation.
+func (w *OpenDataHubValidatingWebhook) checkRemoval(ctx context.Context, req admission.Request) admission.Response {
+	if req.Kind.Kind != "DataScienceCluster" {
+		return admission.Allowed("")
+	}
+
+	oldDSC := &dscv1.DataScienceCluster{}
+	if err := w.Decoder.DecodeRaw(req.OldObject, oldDSC); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	newDSC := &dscv1.DataScienceCluster{}
+	if err := w.Decoder.DecodeRaw(req.Object, newDSC); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	removed, err := removedComponents(oldDSC, newDSC)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	confirmed := confirmedRemovals(newDSC)
+
+	var impacts, unconfirmed []string
+	for _, component := range removed {
+		counter, ok := component.(components.WorkloadCounter)
+		if !ok {
+			continue
+		}
+		workloads, err := counter.Workloads(ctx, w.APIReader)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		impact := describeImpact(workloads)
+		if impact == "" {
+			continue
+		}
+
+		name := component.GetComponentName()
+		impacts = append(impacts, fmt.Sprintf("%s depend on %s", impact, name))
+		if _, ok := confirmed[name]; !ok {
+			unconfirmed = append(unconfirmed, name)
+		}
+	}
+
+	if len(unconfirmed) > 0 {
+		return admission.Denied(fmt.Sprintf("Removing %s would break running workloads: %s. Set the %q annotation to %q to confirm",
+			strings.Join(unconfirmed, ", "), strings.Join(impacts, "; "), annotations.ConfirmRemoval, strings.Join(unconfirmed, ",")))
+	}
+
+	return admission.Allowed("").WithWarnings(impacts...)
+}
+
+// removedComponents returns the components which are Managed in the old DataScienceCluster and Removed in the new one.
+// Components without management state follow the profile of each DataScienceCluster.
+func removedComponents(oldDSC, newDSC *dscv1.DataScienceCluster) ([]components.ComponentInterface, error) {
+	if err := oldDSC.ApplyProfile(); err != nil {
+		return nil, err
+	}
+	if err := newDSC.ApplyProfile(); err != nil {
+		return nil, err
+	}
+	oldComponents, err := oldDSC.GetComponents()
+	if err != nil {
+		return nil, err
+	}
+	newComponents, err := newDSC.GetComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []components.ComponentInterface
+	for i, component := range newComponents {
+		if oldComponents[i].GetManagementState() == operatorv1.Managed && component.GetManagementState() == operatorv1.Removed {
+			removed = append(removed, component)
+		}
+	}
+
+	return removed, nil
+}
+
+// confirmedRemovals returns the names of the components whose removal is confirmed by the ConfirmRemoval annotation.
+func confirmedRemovals(dsc *dscv1.DataScienceCluster) map[string]struct{} {
+	confirmed := map[string]struct{}{}
+	for _, name := range strings.Split(dsc.GetAnnotations()[annotations.ConfirmRemoval], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			confirmed[name] = struct{}{}
+		}
+	}
+
+	return confirmed
+}
+
+// describeImpact returns the workloads found, e.g. "14 InferenceServices across 5 namespaces", empty when none.
+func describeImpact(workloads []components.Workloads) string {
+	var found []string
+	for _, w := range workloads {
+		if w.Count > 0 {
+			found = append(found, w.String())
+		}
+	}
+
+	return strings.Join(found, ", ")
+}
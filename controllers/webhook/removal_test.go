@@ -0,0 +1,166 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Component removal", func() {
+	var oldDSC, newDSC *dscv1.DataScienceCluster
+
+	componentNames := func(removed []components.ComponentInterface) []string {
+		names := make([]string, 0, len(removed))
+		for _, component := range removed {
+			names = append(names, component.GetComponentName())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		oldDSC = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		oldDSC.Spec.Components.Kserve.ManagementState = operatorv1.Managed
+		oldDSC.Spec.Components.Ray.ManagementState = operatorv1.Managed
+		oldDSC.Spec.Components.Kueue.ManagementState = operatorv1.Removed
+		newDSC = oldDSC.DeepCopy()
+	})
+
+	It("should be found for components switched from Managed to Removed", func() {
+		newDSC.Spec.Components.Kserve.ManagementState = operatorv1.Removed
+
+		removed, err := removedComponents(oldDSC, newDSC)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(componentNames(removed)).To(ConsistOf("kserve"))
+	})
+
+	It("should not be found for components added", func() {
+		newDSC.Spec.Components.Kueue.ManagementState = operatorv1.Managed
+
+		Expect(removedComponents(oldDSC, newDSC)).To(BeEmpty())
+	})
+
+	It("should follow the profiles for components without management state", func() {
+		oldDSC = &dscv1.DataScienceCluster{}
+		oldDSC.Spec.Profile = dscv1.ProfileFull
+		oldDSC.Spec.Components.Workbenches.ManagementState = operatorv1.Removed
+		// components set explicitly are kept, the other ones follow the new profile
+		newDSC = oldDSC.DeepCopy()
+		newDSC.Spec.Profile = dscv1.ProfileEdge
+		newDSC.Spec.Components.Ray.ManagementState = operatorv1.Managed
+
+		removed, err := removedComponents(oldDSC, newDSC)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(HaveLen(8))
+		Expect(componentNames(removed)).ToNot(ContainElements("kserve", "ray", "workbenches"))
+	})
+
+	DescribeTable("should be confirmed by the annotation",
+		func(annotation string, expected []string) {
+			newDSC.SetAnnotations(map[string]string{annotations.ConfirmRemoval: annotation})
+
+			confirmed := confirmedRemovals(newDSC)
+			Expect(confirmed).To(HaveLen(len(expected)))
+			for _, name := range expected {
+				Expect(confirmed).To(HaveKey(name))
+			}
+		},
+		Entry("with spaces and trailing commas", "kserve, modelmeshserving,", []string{"kserve", "modelmeshserving"}),
+		Entry("but not when empty", "", nil),
+	)
+
+	DescribeTable("should describe the impact on workloads",
+		func(workloads []components.Workloads, expected string) {
+			Expect(describeImpact(workloads)).To(Equal(expected))
+		},
+		Entry("found", []components.Workloads{
+			{Kind: "PyTorchJob", Count: 1, Namespaces: 1},
+			{Kind: "TFJob"},
+			{Kind: "MPIJob", Count: 3, Namespaces: 2},
+		}, "1 PyTorchJob across 1 namespace, 3 MPIJobs across 2 namespaces"),
+		Entry("as none without workloads", []components.Workloads{{Kind: "InferenceService"}}, ""),
+	)
+
+	Context("checked on admission", func() {
+		var objects []client.Object
+
+		check := func(ctx context.Context) admission.Response {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(gvk.RayCluster, meta.RESTScopeNamespace)
+			oldRaw, err := json.Marshal(oldDSC)
+			Expect(err).ToNot(HaveOccurred())
+			newRaw, err := json.Marshal(newDSC)
+			Expect(err).ToNot(HaveOccurred())
+
+			w := &OpenDataHubValidatingWebhook{
+				APIReader: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objects...).Build(),
+				Decoder:   admission.NewDecoder(scheme),
+			}
+			return w.checkRemoval(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: "datasciencecluster.opendatahub.io", Version: "v1", Kind: "DataScienceCluster"},
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: oldRaw},
+				Object:    runtime.RawExtension{Raw: newRaw},
+			}})
+		}
+		rayCluster := func(namespace string) *unstructured.Unstructured {
+			rayCluster := &unstructured.Unstructured{}
+			rayCluster.SetGroupVersionKind(gvk.RayCluster)
+			rayCluster.SetName("cluster")
+			rayCluster.SetNamespace(namespace)
+			return rayCluster
+		}
+
+		BeforeEach(func() {
+			objects = []client.Object{rayCluster("team-a"), rayCluster("team-b")}
+			newDSC.Spec.Components.Ray.ManagementState = operatorv1.Removed
+		})
+
+		It("should deny removing components with workloads", func(ctx context.Context) {
+			response := check(ctx)
+
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Message).To(And(
+				ContainSubstring("Removing ray would break running workloads: 2 RayClusters across 2 namespaces depend on ray"),
+				ContainSubstring(`Set the "opendatahub.io/confirm-removal" annotation to "ray" to confirm`),
+			))
+		})
+
+		It("should allow confirmed removals with a warning", func(ctx context.Context) {
+			newDSC.SetAnnotations(map[string]string{annotations.ConfirmRemoval: "ray"})
+
+			response := check(ctx)
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf("2 RayClusters across 2 namespaces depend on ray"))
+		})
+
+		It("should allow removing components without workloads", func(ctx context.Context) {
+			objects = nil
+			newDSC.Spec.Components.Kserve.ManagementState = operatorv1.Removed
+
+			response := check(ctx)
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,127 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/trainingoperator"
+)
+
+const kueueQueueNameLabel = "kueue.x-k8s.io/queue-name"
+
+//+kubebuilder:webhook:path=/mutate-kubeflow-org-v1-trainingjob,mutating=true,failurePolicy=ignore,sideEffects=None,groups=kubeflow.org,resources=pytorchjobs;tfjobs;mpijobs;xgboostjobs;paddlejobs,verbs=create,versions=v1,name=trainingjob-defaults.opendatahub.io,admissionReviewVersions=v1
+//nolint:lll
+
+// TrainingJobDefaulter applies the Training Operator configuration of the DataScienceCluster to new jobs: jobs are
+// queued to the default Kueue LocalQueue and PyTorchJobs get the default run policy.
+// Failures are ignored by the API server, so that jobs are not blocked by the operator.
+type TrainingJobDefaulter struct {
+	Client client.Client
+	Name   string
+}
+
+func (d *TrainingJobDefaulter) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register("/mutate-kubeflow-org-v1-trainingjob", &webhook.Admission{
+		Handler:        d,
+		LogConstructor: newLogConstructor(d.Name),
+	})
+}
+
+func (d *TrainingJobDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx).WithName(d.Name)
+
+	config, err := d.config(ctx)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if config == nil {
+		return admission.Allowed("No training job defaults set")
+	}
+
+	job := &unstructured.Unstructured{}
+	if err := json.Unmarshal(req.Object.Raw, &job.Object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := applyTrainingJobDefaults(job, config); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	defaulted, err := json.Marshal(job.Object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	log.V(1).Info("Applying training job defaults", "kind", req.Kind.Kind, "namespace", req.Namespace, "name", req.Name)
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+// config returns the Training Operator configuration of the DataScienceCluster, nil when it is not to be applied.
+func (d *TrainingJobDefaulter) config(ctx context.Context) (*trainingoperator.TrainingOperatorConfig, error) {
+	instances := &dscv1.DataScienceClusterList{}
+	if err := d.Client.List(ctx, instances); err != nil {
+		return nil, err
+	}
+	if len(instances.Items) == 0 {
+		return nil, nil
+	}
+
+	component := instances.Items[0].Spec.Components.TrainingOperator
+	if component.ManagementState != operatorv1.Managed {
+		return nil, nil
+	}
+
+	return component.Config, nil
+}
+
+// applyTrainingJobDefaults sets the defaults on fields of the job which are not set.
+func applyTrainingJobDefaults(job *unstructured.Unstructured, config *trainingoperator.TrainingOperatorConfig) error {
+	if gang := config.GangScheduling; gang != nil && gang.Scheduler == trainingoperator.Kueue && gang.DefaultLocalQueue != "" {
+		jobLabels := job.GetLabels()
+		if _, exists := jobLabels[kueueQueueNameLabel]; !exists {
+			if jobLabels == nil {
+				jobLabels = map[string]string{}
+			}
+			jobLabels[kueueQueueNameLabel] = gang.DefaultLocalQueue
+			job.SetLabels(jobLabels)
+		}
+	}
+
+	defaults := config.PyTorchJobDefaults
+	if defaults == nil || job.GetKind() != "PyTorchJob" {
+		return nil
+	}
+
+	runPolicy, _, err := unstructured.NestedMap(job.Object, "spec", "runPolicy")
+	if err != nil {
+		return err
+	}
+	if runPolicy == nil {
+		runPolicy = map[string]interface{}{}
+	}
+	if _, exists := runPolicy["cleanPodPolicy"]; !exists && defaults.CleanPodPolicy != "" {
+		runPolicy["cleanPodPolicy"] = string(defaults.CleanPodPolicy)
+	}
+	if _, exists := runPolicy["ttlSecondsAfterFinished"]; !exists && defaults.TTLSecondsAfterFinished != nil {
+		runPolicy["ttlSecondsAfterFinished"] = int64(*defaults.TTLSecondsAfterFinished)
+	}
+	if _, exists := runPolicy["backoffLimit"]; !exists && defaults.BackoffLimit != nil {
+		runPolicy["backoffLimit"] = int64(*defaults.BackoffLimit)
+	}
+	if len(runPolicy) == 0 {
+		return nil
+	}
+
+	return unstructured.SetNestedMap(job.Object, runPolicy, "spec", "runPolicy")
+}
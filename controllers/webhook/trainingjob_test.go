@@ -0,0 +1,150 @@
+//go:build !nowebhook
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/trainingoperator"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Training job defaults", func() {
+	var (
+		config *trainingoperator.TrainingOperatorConfig
+		job    *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		ttl := int32(3600)
+		backoffLimit := int32(3)
+		config = &trainingoperator.TrainingOperatorConfig{
+			GangScheduling: &trainingoperator.GangSchedulingSpec{Scheduler: trainingoperator.Kueue, DefaultLocalQueue: "default"},
+			PyTorchJobDefaults: &trainingoperator.PyTorchJobDefaults{
+				CleanPodPolicy:          "All",
+				TTLSecondsAfterFinished: &ttl,
+				BackoffLimit:            &backoffLimit,
+			},
+		}
+		job = &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":     "PyTorchJob",
+			"metadata": map[string]interface{}{"name": "fine-tune"},
+			"spec": map[string]interface{}{
+				"runPolicy": map[string]interface{}{"backoffLimit": int64(0)},
+			},
+		}}
+	})
+
+	runPolicy := func() map[string]interface{} {
+		GinkgoHelper()
+		runPolicy, _, err := unstructured.NestedMap(job.Object, "spec", "runPolicy")
+		Expect(err).ToNot(HaveOccurred())
+		return runPolicy
+	}
+
+	It("should queue jobs to the default LocalQueue and default the run policy of PyTorchJobs", func() {
+		Expect(applyTrainingJobDefaults(job, config)).To(Succeed())
+
+		Expect(job.GetLabels()).To(Equal(map[string]string{kueueQueueNameLabel: "default"}))
+		Expect(runPolicy()).To(Equal(map[string]interface{}{
+			"cleanPodPolicy":          "All",
+			"ttlSecondsAfterFinished": int64(3600),
+			"backoffLimit":            int64(0),
+		}))
+	})
+
+	It("should keep the LocalQueue of jobs", func() {
+		job.SetLabels(map[string]string{kueueQueueNameLabel: "team-a"})
+
+		Expect(applyTrainingJobDefaults(job, config)).To(Succeed())
+		Expect(job.GetLabels()).To(Equal(map[string]string{kueueQueueNameLabel: "team-a"}))
+	})
+
+	It("should only apply the run policy defaults to PyTorchJobs", func() {
+		job.SetKind("TFJob")
+		unstructured.RemoveNestedField(job.Object, "spec", "runPolicy")
+
+		Expect(applyTrainingJobDefaults(job, config)).To(Succeed())
+		Expect(runPolicy()).To(BeNil())
+		Expect(job.GetLabels()).To(HaveKeyWithValue(kueueQueueNameLabel, "default"))
+	})
+
+	DescribeTable("should not queue jobs",
+		func(gang *trainingoperator.GangSchedulingSpec) {
+			config.GangScheduling = gang
+
+			Expect(applyTrainingJobDefaults(job, config)).To(Succeed())
+			Expect(job.GetLabels()).To(BeEmpty())
+		},
+		Entry("without gang scheduling", nil),
+		Entry("gang scheduled by another scheduler", &trainingoperator.GangSchedulingSpec{Scheduler: trainingoperator.SchedulerPlugins, DefaultLocalQueue: "default"}),
+		Entry("without default LocalQueue", &trainingoperator.GangSchedulingSpec{Scheduler: trainingoperator.Kueue}),
+	)
+
+	It("should not set an empty run policy", func() {
+		config.PyTorchJobDefaults = &trainingoperator.PyTorchJobDefaults{}
+		unstructured.RemoveNestedField(job.Object, "spec", "runPolicy")
+
+		Expect(applyTrainingJobDefaults(job, config)).To(Succeed())
+		Expect(job.Object["spec"]).To(BeEmpty())
+	})
+
+	Context("applied on admission", func() {
+		var dsc *dscv1.DataScienceCluster
+
+		handle := func(ctx context.Context) admission.Response {
+			scheme := runtime.NewScheme()
+			Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+			raw, err := json.Marshal(job.Object)
+			Expect(err).ToNot(HaveOccurred())
+
+			defaulter := &TrainingJobDefaulter{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dsc).Build(), Name: "trainingjob-defaults"}
+			return defaulter.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Kind:   metav1.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "PyTorchJob"},
+				Name:   "fine-tune",
+				Object: runtime.RawExtension{Raw: raw},
+			}})
+		}
+
+		BeforeEach(func() {
+			dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+			dsc.Spec.Components.TrainingOperator.ManagementState = operatorv1.Managed
+			dsc.Spec.Components.TrainingOperator.Config = config
+		})
+
+		It("should patch new jobs", func(ctx context.Context) {
+			response := handle(ctx)
+
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(ContainElement(HaveField("Path", "/metadata/labels")))
+		})
+
+		DescribeTable("should allow jobs as they are",
+			func(ctx context.Context, mutate func(*dscv1.DataScienceCluster)) {
+				mutate(dsc)
+
+				response := handle(ctx)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Patches).To(BeEmpty())
+			},
+			Entry("when the Training Operator is removed", func(dsc *dscv1.DataScienceCluster) {
+				dsc.Spec.Components.TrainingOperator.ManagementState = operatorv1.Removed
+			}),
+			Entry("without configuration", func(dsc *dscv1.DataScienceCluster) {
+				dsc.Spec.Components.TrainingOperator.Config = nil
+			}),
+		)
+	})
+})
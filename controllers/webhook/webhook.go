@@ -40,26 +40,39 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 )
 
-//+kubebuilder:webhook:path=/validate-opendatahub-io-v1,mutating=false,failurePolicy=fail,sideEffects=None,groups=datasciencecluster.opendatahub.io;dscinitialization.opendatahub.io,resources=datascienceclusters;dscinitializations,verbs=create;delete,versions=v1,name=operator.opendatahub.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-opendatahub-io-v1,mutating=false,failurePolicy=fail,sideEffects=None,groups=datasciencecluster.opendatahub.io;dscinitialization.opendatahub.io,resources=datascienceclusters;dscinitializations,verbs=create;update;delete,versions=v1,name=operator.opendatahub.io,admissionReviewVersions=v1
 //nolint:lll
 
 // TODO: Get rid of platform in name, rename to ValidatingWebhook.
 type OpenDataHubValidatingWebhook struct {
-	Client  client.Client
-	Decoder *admission.Decoder
-	Name    string
+	Client client.Client
+	// APIReader counts workloads of components, which are not cached.
+	APIReader client.Reader
+	Decoder   *admission.Decoder
+	Name      string
 }
 
 func Init(mgr ctrl.Manager) {
 	(&OpenDataHubValidatingWebhook{
-		Client:  mgr.GetClient(),
-		Decoder: admission.NewDecoder(mgr.GetScheme()),
-		Name:    "ValidatingWebhook",
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Decoder:   admission.NewDecoder(mgr.GetScheme()),
+		Name:      "ValidatingWebhook",
 	}).SetupWithManager(mgr)
 
 	(&DSCDefaulter{
 		Name: "DefaultingWebhook",
 	}).SetupWithManager(mgr)
+
+	(&InferenceServiceDefaulter{
+		Client: mgr.GetClient(),
+		Name:   "InferenceServiceDefaultingWebhook",
+	}).SetupWithManager(mgr)
+
+	(&TrainingJobDefaulter{
+		Client: mgr.GetClient(),
+		Name:   "TrainingJobDefaultingWebhook",
+	}).SetupWithManager(mgr)
 }
 
 // newLogConstructor creates a new logger constructor for a webhook.
@@ -147,6 +160,8 @@ func (w *OpenDataHubValidatingWebhook) Handle(ctx context.Context, req admission
 	switch req.Operation {
 	case admissionv1.Create:
 		resp = w.checkDupCreation(ctx, req)
+	case admissionv1.Update:
+		resp = w.checkRemoval(ctx, req)
 	case admissionv1.Delete:
 		resp = w.checkDeletion(ctx, req)
 	default: // for other operations by default it is admission.Allowed("")
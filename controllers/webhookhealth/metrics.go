@@ -0,0 +1,41 @@
+package webhookhealth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// webhookAvailable backs the alerts on webhooks of the operator the API server cannot call.
+	webhookAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_webhook_available",
+			Help: "Whether a webhook of the operator responded to the last probe (1) or not (0).",
+		},
+		[]string{"configuration", "webhook"},
+	)
+	// webhookDowngraded backs the alerts on webhooks temporarily ignoring failures.
+	webhookDowngraded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "odh_webhook_failure_policy_downgraded",
+			Help: "Whether the failurePolicy of a webhook of the operator is downgraded to Ignore while it is unavailable (1) or not (0).",
+		},
+		[]string{"configuration", "webhook"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(webhookAvailable, webhookDowngraded)
+}
+
+func observe(w *webhook) {
+	available, downgraded := 0.0, 0.0
+	if w.err == nil {
+		available = 1
+	}
+	if w.downgraded {
+		downgraded = 1
+	}
+	webhookAvailable.WithLabelValues(w.configuration, w.name).Set(available)
+	webhookDowngraded.WithLabelValues(w.configuration, w.name).Set(downgraded)
+}
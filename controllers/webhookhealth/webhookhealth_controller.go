@@ -0,0 +1,406 @@
+// Package webhookhealth contains controller logic probing the webhooks of the operator, reporting their availability
+// in the DSCInitialization and in metrics, and downgrading the failurePolicy of non-critical ones to Ignore while they
+// are unavailable, so that admission of the resources they handle is not blocked.
+package webhookhealth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	probeInterval = time.Minute
+	probeTimeout  = 5 * time.Second
+	// downgradeAfter is how long a webhook has to be unavailable before its failurePolicy is downgraded, so that
+	// restarts of the operator do not change it.
+	downgradeAfter = 3 * time.Minute
+)
+
+// operatorWebhooks are the webhooks served by the operator, as named in controllers/webhook. Components serve webhooks
+// in the same domain, e.g. notebooks.opendatahub.io, which are left alone.
+var operatorWebhooks = map[string]bool{
+	"operator.opendatahub.io":                  true,
+	"mutate.operator.opendatahub.io":           true,
+	"inferenceservice-defaults.opendatahub.io": true,
+	"trainingjob-defaults.opendatahub.io":      true,
+}
+
+// critical tells whether the webhook has to keep failing admission while it is unavailable: the validating webhook
+// guards the single DataScienceCluster and DSCInitialization instances and confirms the removal of components with
+// workloads depending on them, which ValidatingAdmissionPolicies do not.
+func critical(name string) bool {
+	return name == "operator.opendatahub.io"
+}
+
+// +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;patch
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get
+
+// WebhookHealthReconciler holds the controller configuration.
+type WebhookHealthReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads webhook configurations and Endpoints, which are only cached as metadata or not at all.
+	APIReader client.Reader
+	// OperatorNamespace is where the webhook Service of the operator lives, no webhook is probed when empty.
+	OperatorNamespace string
+	// HTTPClient probes the webhooks, a client not verifying certificates is used when nil.
+	HTTPClient *http.Client
+
+	mu sync.Mutex
+	// unavailableSince tracks when each webhook stopped responding, by configuration and name.
+	unavailableSince map[string]time.Time
+}
+
+// webhook is a webhook of a configuration of the operator.
+type webhook struct {
+	configuration string
+	name          string
+	service       *admissionregistrationv1.ServiceReference
+	// failurePolicy points to the policy of the webhook in its configuration, to be changed in place.
+	failurePolicy **admissionregistrationv1.FailurePolicyType
+	// downgraded tells whether the failurePolicy of the webhook has been downgraded by the operator.
+	downgraded bool
+	// err is why the webhook is unavailable, nil when it responded.
+	err error
+}
+
+func (w *webhook) key() string {
+	return w.configuration + "/" + w.name
+}
+
+func (w *webhook) policy() admissionregistrationv1.FailurePolicyType {
+	if *w.failurePolicy == nil {
+		return admissionregistrationv1.Fail
+	}
+	return **w.failurePolicy
+}
+
+func (w *webhook) setPolicy(policy admissionregistrationv1.FailurePolicyType) {
+	*w.failurePolicy = &policy
+}
+
+// configuration is a webhook configuration of the operator, along with its webhooks.
+type configuration struct {
+	obj      client.Object
+	original client.Object
+	webhooks []*webhook
+	changed  bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WebhookHealthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for webhook health.")
+
+	if r.HTTPClient == nil {
+		r.HTTPClient = &http.Client{
+			Timeout: probeTimeout,
+			Transport: &http.Transport{
+				// webhooks are served with certificates signed by the service CA, only reachability is checked
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			},
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhook-health-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile probes the webhooks of the operator, downgrades the failurePolicy of non-critical ones unavailable for
+// too long when the gate is enabled, restores it once they respond again, and reports their availability in the
+// WebhooksAvailable condition of the DSCInitialization. It requeues to probe them again periodically.
+func (r *WebhookHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	configurations, err := r.configurations(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	webhookAvailable.Reset()
+	webhookDowngraded.Reset()
+	probed := map[string]error{}
+	var webhooks []*webhook
+	var errs []error
+	for _, c := range configurations {
+		for _, w := range c.webhooks {
+			serviceKey := fmt.Sprintf("%s/%s:%d%s", w.service.Namespace, w.service.Name, port(w.service), path(w.service))
+			probeErr, done := probed[serviceKey]
+			if !done {
+				probeErr = r.probe(ctx, w.service)
+				probed[serviceKey] = probeErr
+			}
+			w.err = probeErr
+			c.changed = r.decide(w, time.Now()) || c.changed
+			webhooks = append(webhooks, w)
+		}
+		if c.changed {
+			if err := r.patch(ctx, c); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, w := range c.webhooks {
+			observe(w)
+		}
+	}
+
+	if err := r.updateCondition(ctx, instance, evaluate(webhooks)); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: probeInterval}, nil
+}
+
+// configurations returns the webhook configurations with webhooks of the operator, with only those webhooks. A webhook
+// is the operator's when it has one of its names and calls a Service of the operator namespace.
+func (r *WebhookHealthReconciler) configurations(ctx context.Context) ([]*configuration, error) {
+	var configurations []*configuration
+	add := func(obj client.Object, name string, clientConfig admissionregistrationv1.WebhookClientConfig, failurePolicy **admissionregistrationv1.FailurePolicyType) {
+		if !operatorWebhooks[name] || clientConfig.Service == nil || clientConfig.Service.Namespace != r.OperatorNamespace {
+			return
+		}
+		if len(configurations) == 0 || configurations[len(configurations)-1].obj != obj {
+			configurations = append(configurations, &configuration{obj: obj, original: obj.DeepCopyObject().(client.Object)})
+		}
+		downgraded := strings.Split(obj.GetAnnotations()[annotations.DowngradedWebhooks], ",")
+		c := configurations[len(configurations)-1]
+		c.webhooks = append(c.webhooks, &webhook{
+			configuration: obj.GetName(),
+			name:          name,
+			service:       clientConfig.Service,
+			failurePolicy: failurePolicy,
+			downgraded:    contains(downgraded, name),
+		})
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := r.APIReader.List(ctx, validating); err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+	for i := range validating.Items {
+		obj := &validating.Items[i]
+		for j := range obj.Webhooks {
+			add(obj, obj.Webhooks[j].Name, obj.Webhooks[j].ClientConfig, &obj.Webhooks[j].FailurePolicy)
+		}
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := r.APIReader.List(ctx, mutating); err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+	for i := range mutating.Items {
+		obj := &mutating.Items[i]
+		for j := range obj.Webhooks {
+			add(obj, obj.Webhooks[j].Name, obj.Webhooks[j].ClientConfig, &obj.Webhooks[j].FailurePolicy)
+		}
+	}
+
+	return configurations, nil
+}
+
+// probe returns why the service of the webhook cannot be called, nil when it responded.
+func (r *WebhookHealthReconciler) probe(ctx context.Context, service *admissionregistrationv1.ServiceReference) error {
+	endpoints := &corev1.Endpoints{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Name: service.Name, Namespace: service.Namespace}, endpoints); err != nil {
+		if k8serr.IsNotFound(err) {
+			return fmt.Errorf("service %s/%s has no endpoints", service.Namespace, service.Name)
+		}
+		return fmt.Errorf("failed to get endpoints of service %s/%s: %w", service.Namespace, service.Name, err)
+	}
+	ready := false
+	for _, subset := range endpoints.Subsets {
+		ready = ready || len(subset.Addresses) > 0
+	}
+	if !ready {
+		return fmt.Errorf("service %s/%s has no ready endpoints", service.Namespace, service.Name)
+	}
+
+	url := fmt.Sprintf("https://%s.%s.svc:%d%s", service.Name, service.Namespace, port(service), path(service))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// requests without an AdmissionReview are rejected by a webhook able to serve
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s responded with %s", url, resp.Status)
+	}
+
+	return nil
+}
+
+// decide downgrades or restores the failurePolicy of the webhook according to its availability, and tells whether it
+// changed.
+func (r *WebhookHealthReconciler) decide(w *webhook, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.unavailableSince == nil {
+		r.unavailableSince = map[string]time.Time{}
+	}
+
+	if w.err == nil || !featuregate.Enabled(featuregate.WebhookFailurePolicyDowngrade) {
+		if w.err == nil {
+			delete(r.unavailableSince, w.key())
+		}
+		if !w.downgraded {
+			return false
+		}
+		r.Log.Info("Restoring failurePolicy of webhook", "configuration", w.configuration, "webhook", w.name)
+		w.setPolicy(admissionregistrationv1.Fail)
+		w.downgraded = false
+		return true
+	}
+
+	since, unavailable := r.unavailableSince[w.key()]
+	if !unavailable {
+		r.unavailableSince[w.key()] = now
+		return false
+	}
+	if w.downgraded || critical(w.name) || w.policy() != admissionregistrationv1.Fail || now.Sub(since) < downgradeAfter {
+		return false
+	}
+	r.Log.Info("Downgrading failurePolicy of unavailable webhook", "configuration", w.configuration, "webhook", w.name, "error", w.err.Error())
+	w.setPolicy(admissionregistrationv1.Ignore)
+	w.downgraded = true
+
+	return true
+}
+
+// patch applies the failurePolicies of the webhooks of the configuration, and records the downgraded ones in its
+// annotation.
+func (r *WebhookHealthReconciler) patch(ctx context.Context, c *configuration) error {
+	var downgraded []string
+	for _, w := range c.webhooks {
+		if w.downgraded {
+			downgraded = append(downgraded, w.name)
+		}
+	}
+	sort.Strings(downgraded)
+	configurationAnnotations := c.obj.GetAnnotations()
+	if configurationAnnotations == nil {
+		configurationAnnotations = map[string]string{}
+	}
+	if len(downgraded) > 0 {
+		configurationAnnotations[annotations.DowngradedWebhooks] = strings.Join(downgraded, ",")
+	} else {
+		delete(configurationAnnotations, annotations.DowngradedWebhooks)
+	}
+	c.obj.SetAnnotations(configurationAnnotations)
+
+	// webhooks are replaced as a whole, changes made since they were read are not to be overwritten
+	if err := r.Client.Patch(ctx, c.obj, client.MergeFromWithOptions(c.original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to update failurePolicy of webhooks of %s: %w", c.obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// evaluate returns the condition reporting the availability of the webhooks, nil when the operator serves none.
+func evaluate(webhooks []*webhook) *conditionsv1.Condition {
+	if len(webhooks) == 0 {
+		return nil
+	}
+	var unavailable []string
+	for _, w := range webhooks {
+		if w.err == nil {
+			continue
+		}
+		message := fmt.Sprintf("webhook %s of %s is unavailable: %v", w.name, w.configuration, w.err)
+		if w.downgraded {
+			message += ", its failures are ignored"
+		}
+		unavailable = append(unavailable, message)
+	}
+	if len(unavailable) == 0 {
+		return &conditionsv1.Condition{Status: corev1.ConditionTrue, Reason: status.WebhooksAvailable,
+			Message: fmt.Sprintf("%d webhooks responded", len(webhooks))}
+	}
+	sort.Strings(unavailable)
+
+	return &conditionsv1.Condition{Status: corev1.ConditionFalse, Reason: status.WebhooksUnavailable, Message: strings.Join(unavailable, "; ")}
+}
+
+// updateCondition sets the WebhooksAvailable condition, removing it when nil. Status is only updated on changes, to
+// not bump the heartbeat of the condition with every probe.
+func (r *WebhookHealthReconciler) updateCondition(ctx context.Context, instance *dsciv1.DSCInitialization, condition *conditionsv1.Condition) error {
+	existing := conditionsv1.FindStatusCondition(instance.Status.Conditions, status.ConditionWebhooksAvailable)
+	switch {
+	case condition == nil && existing == nil:
+		return nil
+	case condition != nil && existing != nil &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message:
+		return nil
+	}
+
+	_, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+		if condition == nil {
+			conditionsv1.RemoveStatusCondition(&saved.Status.Conditions, status.ConditionWebhooksAvailable)
+			return
+		}
+		status.SetCondition(&saved.Status.Conditions, string(status.ConditionWebhooksAvailable), condition.Reason, condition.Message, condition.Status)
+	})
+
+	return err
+}
+
+func port(service *admissionregistrationv1.ServiceReference) int32 {
+	if service.Port == nil {
+		return 443
+	}
+	return *service.Port
+}
+
+func path(service *admissionregistrationv1.ServiceReference) string {
+	if service.Path == nil {
+		return ""
+	}
+	return *service.Path
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
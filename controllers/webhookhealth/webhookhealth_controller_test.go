@@ -0,0 +1,306 @@
+package webhookhealth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	operatorNamespace     = "opendatahub-operator-system"
+	applicationsNamespace = "opendatahub"
+)
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func clientConfig(namespace, service, path string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{Name: service, Namespace: namespace, Path: &path},
+	}
+}
+
+func readyEndpoints(namespace, name string) *corev1.Endpoints {
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+}
+
+var _ = Describe("Webhook health controller", func() {
+	var (
+		cli       client.Client
+		r         *WebhookHealthReconciler
+		objects   []client.Object
+		available bool
+		probed    []string
+		req       = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	fail := admissionregistrationv1.Fail
+	ignore := admissionregistrationv1.Ignore
+
+	BeforeEach(func() {
+		Expect(featuregate.Set(map[string]bool{featuregate.WebhookFailurePolicyDowngrade: true})).To(Succeed())
+		DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+
+		available = false
+		probed = nil
+		objects = []client.Object{
+			&dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}},
+			&admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+					Name:          "operator.opendatahub.io",
+					ClientConfig:  clientConfig(operatorNamespace, "webhook-service", "/validate-opendatahub-io-v1"),
+					FailurePolicy: &fail,
+				}},
+			},
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "mutating-webhook-configuration"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{
+						Name:          "mutate.operator.opendatahub.io",
+						ClientConfig:  clientConfig(operatorNamespace, "webhook-service", "/mutate-opendatahub-io-v1"),
+						FailurePolicy: &fail,
+					},
+					{
+						Name:          "trainingjob-defaults.opendatahub.io",
+						ClientConfig:  clientConfig(operatorNamespace, "webhook-service", "/mutate-kubeflow-org-v1-trainingjob"),
+						FailurePolicy: &ignore,
+					},
+				},
+			},
+			// webhooks of components, in the domain of the operator or not
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "odh-notebook-controller-mutating-webhook-configuration"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{{
+					Name:          "notebooks.opendatahub.io",
+					ClientConfig:  clientConfig(applicationsNamespace, "odh-notebook-controller-webhook-service", "/mutate-notebook-v1"),
+					FailurePolicy: &fail,
+				}},
+			},
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "inferenceservice.serving.kserve.io"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{{
+					Name:          "inferenceservice.kserve-webhook-server.defaulter",
+					ClientConfig:  clientConfig(applicationsNamespace, "kserve-webhook-server-service", "/mutate"),
+					FailurePolicy: &fail,
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+		cli = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&dsciv1.DSCInitialization{}).
+			WithObjects(objects...).
+			Build()
+		r = &WebhookHealthReconciler{
+			Client:            cli,
+			APIReader:         cli,
+			Log:               logr.Discard(),
+			OperatorNamespace: operatorNamespace,
+			HTTPClient: &http.Client{Transport: roundTripper(func(req *http.Request) (*http.Response, error) {
+				probed = append(probed, req.URL.Host)
+				if !available {
+					return nil, errors.New("connection refused")
+				}
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})},
+		}
+	})
+
+	reconcile := func(ctx context.Context) {
+		GinkgoHelper()
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	condition := func(ctx context.Context) *conditionsv1.Condition {
+		GinkgoHelper()
+		instance := &dsciv1.DSCInitialization{}
+		Expect(cli.Get(ctx, req.NamespacedName, instance)).To(Succeed())
+		return conditionsv1.FindStatusCondition(instance.Status.Conditions, status.ConditionWebhooksAvailable)
+	}
+
+	mutating := func(ctx context.Context, name string) *admissionregistrationv1.MutatingWebhookConfiguration {
+		GinkgoHelper()
+		obj := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: name}, obj)).To(Succeed())
+		return obj
+	}
+
+	validatingPolicy := func(ctx context.Context) admissionregistrationv1.FailurePolicyType {
+		GinkgoHelper()
+		obj := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "validating-webhook-configuration"}, obj)).To(Succeed())
+		return *obj.Webhooks[0].FailurePolicy
+	}
+
+	unavailableForLong := func() {
+		for key := range r.unavailableSince {
+			r.unavailableSince[key] = time.Now().Add(-downgradeAfter)
+		}
+	}
+
+	When("the webhooks of the operator are unavailable", func() {
+		It("should report them without downgrading them right away", func(ctx context.Context) {
+			reconcile(ctx)
+
+			c := condition(ctx)
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(corev1.ConditionFalse))
+			Expect(c.Message).To(ContainSubstring("has no endpoints"))
+			Expect(c.Message).NotTo(ContainSubstring("notebooks.opendatahub.io"))
+			Expect(mutating(ctx, "mutating-webhook-configuration").Webhooks[0].FailurePolicy).To(HaveValue(Equal(fail)))
+		})
+
+		It("should downgrade the non-critical ones once unavailable for long", func(ctx context.Context) {
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+
+			obj := mutating(ctx, "mutating-webhook-configuration")
+			Expect(obj.Annotations).To(HaveKeyWithValue(annotations.DowngradedWebhooks, "mutate.operator.opendatahub.io"))
+			Expect(obj.Webhooks[0].FailurePolicy).To(HaveValue(Equal(ignore)))
+			Expect(obj.Webhooks[1].FailurePolicy).To(HaveValue(Equal(ignore)))
+			Expect(validatingPolicy(ctx)).To(Equal(fail))
+			Expect(condition(ctx).Message).To(ContainSubstring("its failures are ignored"))
+		})
+
+		It("should keep the validating webhook critical with admission policies enabled", func(ctx context.Context) {
+			Expect(featuregate.Set(map[string]bool{
+				featuregate.WebhookFailurePolicyDowngrade: true,
+				featuregate.AdmissionPolicies:             true,
+			})).To(Succeed())
+
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+
+			Expect(validatingPolicy(ctx)).To(Equal(fail))
+		})
+
+		It("should not downgrade them with the gate disabled", func(ctx context.Context) {
+			Expect(featuregate.Set(nil)).To(Succeed())
+
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+
+			obj := mutating(ctx, "mutating-webhook-configuration")
+			Expect(obj.Annotations).NotTo(HaveKey(annotations.DowngradedWebhooks))
+			Expect(obj.Webhooks[0].FailurePolicy).To(HaveValue(Equal(fail)))
+		})
+	})
+
+	When("the webhooks of the operator serve again", func() {
+		BeforeEach(func() {
+			objects = append(objects, readyEndpoints(operatorNamespace, "webhook-service"))
+		})
+
+		It("should restore the downgraded ones", func(ctx context.Context) {
+			Expect(cli.Delete(ctx, readyEndpoints(operatorNamespace, "webhook-service"))).To(Succeed())
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+			Expect(mutating(ctx, "mutating-webhook-configuration").Webhooks[0].FailurePolicy).To(HaveValue(Equal(ignore)))
+
+			Expect(cli.Create(ctx, readyEndpoints(operatorNamespace, "webhook-service"))).To(Succeed())
+			available = true
+			reconcile(ctx)
+
+			obj := mutating(ctx, "mutating-webhook-configuration")
+			Expect(obj.Annotations).NotTo(HaveKey(annotations.DowngradedWebhooks))
+			Expect(obj.Webhooks[0].FailurePolicy).To(HaveValue(Equal(fail)))
+			c := condition(ctx)
+			Expect(c.Status).To(Equal(corev1.ConditionTrue))
+			Expect(c.Message).To(Equal("3 webhooks responded"))
+		})
+
+		It("should probe each service path once", func(ctx context.Context) {
+			available = true
+			reconcile(ctx)
+
+			Expect(probed).To(HaveLen(3))
+			Expect(probed).To(HaveEach("webhook-service." + operatorNamespace + ".svc:443"))
+		})
+	})
+
+	When("components serve webhooks", func() {
+		BeforeEach(func() {
+			objects = append(objects,
+				readyEndpoints(applicationsNamespace, "odh-notebook-controller-webhook-service"),
+				readyEndpoints(applicationsNamespace, "kserve-webhook-server-service"))
+		})
+
+		It("should leave them unchanged", func(ctx context.Context) {
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+
+			for _, name := range []string{"odh-notebook-controller-mutating-webhook-configuration", "inferenceservice.serving.kserve.io"} {
+				obj := mutating(ctx, name)
+				Expect(obj.Annotations).NotTo(HaveKey(annotations.DowngradedWebhooks))
+				Expect(obj.Webhooks[0].FailurePolicy).To(HaveValue(Equal(fail)))
+			}
+			Expect(probed).To(BeEmpty())
+		})
+
+		It("should leave those named like webhooks of the operator outside of its namespace", func(ctx context.Context) {
+			r.OperatorNamespace = "redhat-ods-operator"
+
+			reconcile(ctx)
+			unavailableForLong()
+			reconcile(ctx)
+
+			Expect(mutating(ctx, "mutating-webhook-configuration").Webhooks[0].FailurePolicy).To(HaveValue(Equal(fail)))
+			Expect(condition(ctx)).To(BeNil())
+			Expect(probed).To(BeEmpty())
+		})
+	})
+
+	When("the operator serves no webhooks", func() {
+		BeforeEach(func() {
+			objects = objects[:1]
+			objects[0].(*dsciv1.DSCInitialization).Status.Conditions = []conditionsv1.Condition{{
+				Type: status.ConditionWebhooksAvailable, Status: corev1.ConditionTrue, Reason: status.WebhooksAvailable,
+			}}
+		})
+
+		It("should remove the condition", func(ctx context.Context) {
+			reconcile(ctx)
+
+			Expect(condition(ctx)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package webhookhealth
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWebhookHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Webhook health controller suite")
+}
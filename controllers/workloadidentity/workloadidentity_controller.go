@@ -0,0 +1,246 @@
+// Package workloadidentity contains controller logic federating the service accounts of data science projects with
+// cloud identities, e.g. IAM roles for service accounts on AWS, so that pipelines and model servers access cloud
+// storage without static credentials. It annotates the service accounts, and verifies the token of the identity is
+// projected in their pods.
+package workloadidentity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cloud"
+	odhlabels "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// service accounts and their pods are not watched, they are checked periodically
+const checkInterval = 5 * time.Minute
+
+// WorkloadIdentityReconciler holds the controller configuration.
+type WorkloadIdentityReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIReader reads the service accounts of data science projects and their pods, which are not cached.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkloadIdentityReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for workload identities.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workload-identity-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile annotates the service accounts set in the DSCInitialization with their cloud identity, removes the
+// annotations of service accounts no longer set, and reports whether the token of the identity is projected in their
+// pods. It requeues to account for service accounts and pods created in the meantime.
+func (r *WorkloadIdentityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	desired, err := r.desiredIdentities(ctx, instance.Spec.Cloud)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	keys := make([]types.NamespacedName, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	integration := cloud.For(&instance.Spec)
+	var errs []error
+	var identities []dsciv1.WorkloadIdentityStatus
+	for _, key := range keys {
+		identity, err := r.federate(ctx, integration, key, desired[key])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		identities = append(identities, identity)
+	}
+	errs = append(errs, r.release(ctx, desired))
+
+	if !equality.Semantic.DeepEqual(instance.Status.WorkloadIdentities, identities) {
+		if _, err := status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv1.DSCInitialization) {
+			saved.Status.WorkloadIdentities = identities
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to report workload identities: %w", err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "WorkloadIdentityFailed", "Failed to federate service accounts with cloud identities: %v", err)
+		return ctrl.Result{}, err
+	}
+	if len(desired) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: checkInterval}, nil
+}
+
+// desiredIdentities returns the cloud identity of each service account set, the service accounts set without
+// namespace being looked up in every data science project.
+func (r *WorkloadIdentityReconciler) desiredIdentities(ctx context.Context, spec *dsciv1.CloudSpec) (map[types.NamespacedName]string, error) {
+	desired := map[types.NamespacedName]string{}
+	if spec == nil || len(spec.ServiceAccountIdentities) == 0 {
+		return desired, nil
+	}
+
+	var projects []string
+	if slices.ContainsFunc(spec.ServiceAccountIdentities, func(identity dsciv1.ServiceAccountIdentity) bool { return identity.Namespace == "" }) {
+		namespaces := &corev1.NamespaceList{}
+		if err := r.Client.List(ctx, namespaces, client.MatchingLabels{odhlabels.ODH.Dashboard: "true"}); err != nil {
+			return nil, fmt.Errorf("failed to list data science projects: %w", err)
+		}
+		for _, namespace := range namespaces.Items {
+			if namespace.GetDeletionTimestamp() == nil {
+				projects = append(projects, namespace.Name)
+			}
+		}
+	}
+
+	for _, identity := range spec.ServiceAccountIdentities {
+		namespaces := projects
+		if identity.Namespace != "" {
+			namespaces = []string{identity.Namespace}
+		}
+		for _, namespace := range namespaces {
+			// service accounts set in a namespace take precedence over the ones set for every project
+			key := types.NamespacedName{Namespace: namespace, Name: identity.ServiceAccount}
+			if _, found := desired[key]; !found || identity.Namespace != "" {
+				desired[key] = identity.Identity
+			}
+		}
+	}
+
+	return desired, nil
+}
+
+// federate annotates the service account with the cloud identity, and checks the token of the identity is projected
+// in its running pods. Service accounts not created yet are reported, but not an error.
+func (r *WorkloadIdentityReconciler) federate(ctx context.Context, integration cloud.Integration, key types.NamespacedName, identity string,
+) (dsciv1.WorkloadIdentityStatus, error) {
+	result := dsciv1.WorkloadIdentityStatus{Namespace: key.Namespace, ServiceAccount: key.Name, Identity: identity}
+	annotations := integration.IdentityAnnotations(identity)
+	if annotations == nil {
+		result.Message = "The cloud provider of the cluster does not support workload identities"
+		return result, nil
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	if err := r.APIReader.Get(ctx, key, serviceAccount); err != nil {
+		if k8serr.IsNotFound(err) {
+			result.Message = "The service account does not exist yet"
+			return result, nil
+		}
+		result.Message = "Failed to get the service account"
+		return result, fmt.Errorf("failed to get service account %s: %w", key, err)
+	}
+
+	original := serviceAccount.DeepCopy()
+	serviceAccount.SetLabels(merge(serviceAccount.GetLabels(), map[string]string{odhlabels.ODH.WorkloadIdentity: "true"}))
+	serviceAccount.SetAnnotations(merge(serviceAccount.GetAnnotations(), annotations))
+	if !equality.Semantic.DeepEqual(original.ObjectMeta, serviceAccount.ObjectMeta) {
+		if err := r.Client.Patch(ctx, serviceAccount, client.MergeFrom(original)); err != nil {
+			result.Message = "Failed to annotate the service account"
+			return result, fmt.Errorf("failed to annotate service account %s: %w", key, err)
+		}
+		r.Log.Info("federated service account with cloud identity", "namespace", key.Namespace, "name", key.Name, "identity", identity)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, pods, client.InNamespace(key.Namespace)); err != nil {
+		result.Message = "Failed to list the pods of the service account"
+		return result, fmt.Errorf("failed to list pods of %s: %w", key.Namespace, err)
+	}
+	var unprojected []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.ServiceAccountName != key.Name || pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if !integration.TokenProjected(pod) {
+			unprojected = append(unprojected, pod.Name)
+		}
+	}
+	result.TokenProjected = len(unprojected) == 0
+	if !result.TokenProjected {
+		sort.Strings(unprojected)
+		result.Message = fmt.Sprintf("The token of the identity is not projected in pods %s: restart them once the workload identity webhook "+
+			"of the cloud is installed", strings.Join(unprojected, ", "))
+		if podLabels := integration.IdentityPodLabels(); len(podLabels) != 0 {
+			result.Message += fmt.Sprintf(", and the pods labelled %s", labels.SelectorFromSet(podLabels).String())
+		}
+	}
+
+	return result, nil
+}
+
+// release removes the cloud identity from the service accounts annotated by the operator which are no longer set.
+func (r *WorkloadIdentityReconciler) release(ctx context.Context, desired map[types.NamespacedName]string) error {
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := r.APIReader.List(ctx, serviceAccounts, client.MatchingLabels{odhlabels.ODH.WorkloadIdentity: "true"}); err != nil {
+		return fmt.Errorf("failed to list service accounts federated with cloud identities: %w", err)
+	}
+
+	var errs []error
+	for i := range serviceAccounts.Items {
+		serviceAccount := &serviceAccounts.Items[i]
+		key := client.ObjectKeyFromObject(serviceAccount)
+		if _, found := desired[key]; found {
+			continue
+		}
+		original := serviceAccount.DeepCopy()
+		delete(serviceAccount.Labels, odhlabels.ODH.WorkloadIdentity)
+		for _, annotation := range cloud.IdentityAnnotationKeys() {
+			delete(serviceAccount.Annotations, annotation)
+		}
+		if err := r.Client.Patch(ctx, serviceAccount, client.MergeFrom(original)); err != nil && !k8serr.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to remove the cloud identity of service account %s: %w", key, err))
+			continue
+		}
+		r.Log.Info("removed cloud identity of service account", "namespace", key.Namespace, "name", key.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+func merge(values, added map[string]string) map[string]string {
+	if values == nil {
+		values = map[string]string{}
+	}
+	for key, value := range added {
+		values[key] = value
+	}
+
+	return values
+}
@@ -0,0 +1,304 @@
+package workloadidentity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	roleAnnotation = "eks.amazonaws.com/role-arn"
+	pipelinesRole  = "arn:aws:iam::123456789012:role/pipelines"
+	fraudRole      = "arn:aws:iam::123456789012:role/fraud-pipelines"
+)
+
+func runningPod(namespace, name, serviceAccount, tokenAudience string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.PodSpec{ServiceAccountName: serviceAccount, Containers: []corev1.Container{{Name: "main"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if tokenAudience != "" {
+		pod.Spec.Volumes = []corev1.Volume{{
+			Name: "aws-iam-token",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: tokenAudience, Path: "token"},
+			}}}},
+		}}
+	}
+
+	return pod
+}
+
+func serviceAccount(namespace, name string, objLabels, annotations map[string]string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: objLabels, Annotations: annotations}}
+}
+
+func project(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.ODH.Dashboard: "true"}}}
+}
+
+var _ = Describe("Workload identity controller", func() {
+	var (
+		instance *dsciv1.DSCInitialization
+		demoPod  *corev1.Pod
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		instance = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{
+				ApplicationsNamespace: "opendatahub",
+				Cloud: &dsciv1.CloudSpec{
+					Provider: "AWS",
+					ServiceAccountIdentities: []dsciv1.ServiceAccountIdentity{
+						{ServiceAccount: "pipeline-runner-dspa", Identity: pipelinesRole},
+						{ServiceAccount: "pipeline-runner-dspa", Namespace: "fraud", Identity: fraudRole},
+					},
+				},
+			},
+		}
+		demoPod = runningPod("demo", "ds-pipeline-dspa-0", "pipeline-runner-dspa", "")
+		objects = []client.Object{
+			project("fraud"),
+			project("demo"),
+			project("empty"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			serviceAccount("fraud", "pipeline-runner-dspa", nil, nil),
+			serviceAccount("demo", "pipeline-runner-dspa", nil, nil),
+			serviceAccount("kube-system", "pipeline-runner-dspa", nil, nil),
+			runningPod("fraud", "ds-pipeline-dspa-0", "pipeline-runner-dspa", "sts.amazonaws.com"),
+			demoPod,
+			runningPod("demo", "notebook-0", "notebook", ""),
+		}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	reconcile := func(ctx context.Context) (ctrl.Result, error) {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dsciv1.DSCInitialization{}).
+				WithObjects(append(objects, instance)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &WorkloadIdentityReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard(), APIReader: cli, Recorder: recorder}
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)})
+	}
+	saved := func(ctx context.Context, namespace string) *corev1.ServiceAccount {
+		GinkgoHelper()
+		saved := &corev1.ServiceAccount{}
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "pipeline-runner-dspa"}, saved)).To(Succeed())
+		return saved
+	}
+	identities := func(ctx context.Context) []dsciv1.WorkloadIdentityStatus {
+		GinkgoHelper()
+		saved := &dsciv1.DSCInitialization{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(instance), saved)).To(Succeed())
+		return saved.Status.WorkloadIdentities
+	}
+	identity := func(ctx context.Context, namespace string) dsciv1.WorkloadIdentityStatus {
+		GinkgoHelper()
+		for _, identity := range identities(ctx) {
+			if identity.Namespace == namespace {
+				return identity
+			}
+		}
+		Fail("no workload identity reported in " + namespace)
+		return dsciv1.WorkloadIdentityStatus{}
+	}
+
+	DescribeTable("should federate the service accounts of data science projects with their identity",
+		func(ctx context.Context, namespace, role string) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+			federated := saved(ctx, namespace)
+			Expect(federated.Annotations).To(HaveKeyWithValue(roleAnnotation, role))
+			Expect(federated.Labels).To(HaveKeyWithValue(labels.ODH.WorkloadIdentity, "true"))
+		},
+		Entry("identity set for every project", "demo", pipelinesRole),
+		Entry("identity set for the namespace taking precedence", "fraud", fraudRole),
+	)
+
+	It("should not federate the service accounts of other namespaces", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+		Expect(saved(ctx, "kube-system").Annotations).To(BeEmpty())
+	})
+
+	It("should federate the service accounts of namespaces set explicitly", func(ctx context.Context) {
+		instance.Spec.Cloud.ServiceAccountIdentities = []dsciv1.ServiceAccountIdentity{
+			{ServiceAccount: "pipeline-runner-dspa", Namespace: "kube-system", Identity: pipelinesRole},
+		}
+		listedProjects := false
+		funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, isNamespaceList := list.(*corev1.NamespaceList); isNamespaceList {
+				listedProjects = true
+			}
+			return cli.List(ctx, list, opts...)
+		}
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(saved(ctx, "kube-system").Annotations).To(HaveKeyWithValue(roleAnnotation, pipelinesRole))
+		Expect(listedProjects).To(BeFalse())
+	})
+
+	It("should report the workload identities sorted", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+		Expect(identities(ctx)).To(HaveExactElements(
+			HaveField("Namespace", "demo"), HaveField("Namespace", "empty"), HaveField("Namespace", "fraud"),
+		))
+	})
+
+	It("should report the token projected in the pods of the service account", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+		Expect(identity(ctx, "fraud")).To(Equal(dsciv1.WorkloadIdentityStatus{
+			Namespace: "fraud", ServiceAccount: "pipeline-runner-dspa", Identity: fraudRole, TokenProjected: true,
+		}))
+	})
+
+	It("should report the pods the token is not projected in", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+		demo := identity(ctx, "demo")
+		Expect(demo.TokenProjected).To(BeFalse())
+		Expect(demo.Message).To(Equal("The token of the identity is not projected in pods ds-pipeline-dspa-0: " +
+			"restart them once the workload identity webhook of the cloud is installed"))
+	})
+
+	It("should ignore the pods not running", func(ctx context.Context) {
+		demoPod.Status.Phase = corev1.PodSucceeded
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(identity(ctx, "demo").TokenProjected).To(BeTrue())
+	})
+
+	It("should report the service accounts not created yet", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+
+		empty := identity(ctx, "empty")
+		Expect(empty.TokenProjected).To(BeFalse())
+		Expect(empty.Message).To(Equal("The service account does not exist yet"))
+	})
+
+	It("should tell the labels the pods require on Azure", func(ctx context.Context) {
+		instance.Spec.Cloud.Provider = "Azure"
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(saved(ctx, "demo").Annotations).To(HaveKeyWithValue("azure.workload.identity/client-id", pipelinesRole))
+		Expect(identity(ctx, "demo").Message).To(HaveSuffix(", and the pods labelled azure.workload.identity/use=true"))
+	})
+
+	It("should report the cloud provider not supporting workload identities", func(ctx context.Context) {
+		instance.Spec.Cloud.Provider = "None"
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(saved(ctx, "demo").Annotations).To(BeEmpty())
+		Expect(identity(ctx, "demo").Message).To(Equal("The cloud provider of the cluster does not support workload identities"))
+	})
+
+	It("should not patch the service accounts federated already", func(ctx context.Context) {
+		patches := 0
+		funcs.Patch = func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			patches++
+			return cli.Patch(ctx, obj, patch, opts...)
+		}
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(patches).To(Equal(2))
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(patches).To(Equal(2))
+	})
+
+	It("should report the service accounts failing to be annotated", func(ctx context.Context) {
+		funcs.Patch = func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if obj.GetNamespace() == "demo" {
+				return errors.New("forbidden")
+			}
+			return cli.Patch(ctx, obj, patch, opts...)
+		}
+
+		_, err := reconcile(ctx)
+		Expect(err).To(MatchError("failed to annotate service account demo/pipeline-runner-dspa: forbidden"))
+		Expect(identity(ctx, "demo").Message).To(Equal("Failed to annotate the service account"))
+		Expect(saved(ctx, "fraud").Annotations).To(HaveKey(roleAnnotation))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning WorkloadIdentityFailed")))
+	})
+
+	It("should remove the identity of the service accounts no longer set", func(ctx context.Context) {
+		objects = append(objects, serviceAccount("legacy", "pipeline-runner-dspa", map[string]string{labels.ODH.WorkloadIdentity: "true"},
+			map[string]string{roleAnnotation: pipelinesRole, "azure.workload.identity/client-id": "client", "owner": "data-science"}))
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		released := saved(ctx, "legacy")
+		Expect(released.Annotations).To(Equal(map[string]string{"owner": "data-science"}))
+		Expect(released.Labels).ToNot(HaveKey(labels.ODH.WorkloadIdentity))
+	})
+
+	When("the identities are removed", func() {
+		BeforeEach(func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+			instance = &dsciv1.DSCInitialization{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "default-dsci"}, instance)).To(Succeed())
+			instance.Spec.Cloud.ServiceAccountIdentities = nil
+			Expect(cli.Update(ctx, instance)).To(Succeed())
+		})
+
+		It("should remove the identity of the service accounts and not check them again", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+
+			for _, namespace := range []string{"fraud", "demo"} {
+				Expect(saved(ctx, namespace).Annotations).To(BeEmpty())
+				Expect(saved(ctx, namespace).Labels).To(BeEmpty())
+			}
+			Expect(identities(ctx)).To(BeNil())
+		})
+	})
+
+	It("should not check service accounts without cloud settings", func(ctx context.Context) {
+		instance.Spec.Cloud = nil
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+		Expect(identities(ctx)).To(BeNil())
+	})
+
+	It("should fail when data science projects cannot be listed", func(ctx context.Context) {
+		funcs.List = func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+			return errors.New("timeout")
+		}
+
+		_, err := reconcile(ctx)
+		Expect(err).To(MatchError("failed to list data science projects: timeout"))
+	})
+
+	It("should ignore a deleted DSCInitialization", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{RequeueAfter: checkInterval}))
+		Expect(cli.Delete(ctx, instance)).To(Succeed())
+
+		Expect(reconcile(ctx)).To(Equal(ctrl.Result{}))
+	})
+})
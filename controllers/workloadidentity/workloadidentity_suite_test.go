@@ -0,0 +1,13 @@
+package workloadidentity
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkloadIdentity(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workload identity suite")
+}
@@ -0,0 +1,404 @@
+// Package workloadpolicy contains controller logic enforcing the egress and image policies of the DSCInitialization
+// in data science projects.
+package workloadpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// EgressFirewallName is the name of the EgressFirewall, OVN-Kubernetes only honors one named "default" per namespace.
+	EgressFirewallName = "default"
+	// NetworkPolicyName is the name of the NetworkPolicy restricting egress where EgressFirewalls are not available.
+	NetworkPolicyName = "odh-allowed-egress"
+	// AdmissionPolicyName is the name of the ValidatingAdmissionPolicy restricting images, and of its binding.
+	AdmissionPolicyName = "odh-allowed-registries"
+)
+
+// +kubebuilder:rbac:groups="k8s.ovn.org",resources=egressfirewalls,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update;delete
+
+// WorkloadPolicyReconciler holds the controller configuration.
+type WorkloadPolicyReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkloadPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Log.Info("Adding controller for workload policies.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workload-policy-controller").
+		For(&dsciv1.DSCInitialization{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.watchNamespaces), builder.WithPredicates(projectLabelChanged)).
+		Complete(r)
+}
+
+// Reconcile renders the workload policy of the DSCInitialization in each data science project: an EgressFirewall,
+// or a NetworkPolicy where OVN-Kubernetes is not the network plugin, allowing egress to the listed destinations
+// only, and a ValidatingAdmissionPolicy rejecting pods with images of other registries. Resources are removed from
+// namespaces which are not data science projects anymore, and everywhere once the policy is removed.
+func (r *WorkloadPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var egress []dsciv1.EgressDestination
+	var registries []string
+	if instance.GetDeletionTimestamp() == nil && instance.Spec.WorkloadPolicyEnabled() {
+		egress = instance.Spec.WorkloadPolicy.AllowedEgress
+		registries = instance.Spec.WorkloadPolicy.AllowedRegistries
+	}
+
+	projects := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, projects, client.MatchingLabels{labels.ODH.Dashboard: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list data science projects: %w", err)
+	}
+	var namespaces []string
+	for _, namespace := range projects.Items {
+		if namespace.GetDeletionTimestamp() == nil {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+
+	if err := errors.Join(
+		r.reconcileEgress(ctx, instance, namespaces, egress),
+		r.reconcileRegistries(ctx, instance, registries),
+	); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "WorkloadPolicyFailed", "Failed to enforce the workload policy: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileEgress restricts the egress of the namespaces to the given destinations, and removes the restrictions
+// from other namespaces. Egress is not restricted when no destination is given.
+func (r *WorkloadPolicyReconciler) reconcileEgress(ctx context.Context, instance *dsciv1.DSCInitialization, namespaces []string,
+	egress []dsciv1.EgressDestination,
+) error {
+	firewalls := &unstructured.UnstructuredList{}
+	firewalls.SetGroupVersionKind(gvk.EgressFirewall)
+	err := r.Client.List(ctx, firewalls, client.HasLabels{labels.ODH.WorkloadPolicy})
+	if err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to list EgressFirewalls: %w", err)
+	}
+	ovn := err == nil
+
+	var errs []error
+	desired := map[string]bool{}
+	if len(egress) > 0 {
+		if !ovn && hasDNSNames(egress) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "EgressDNSNamesIgnored",
+				"DNS names of the allowed egress are only enforced by EgressFirewalls of OVN-Kubernetes")
+		}
+		for _, namespace := range namespaces {
+			desired[namespace] = true
+			if ovn {
+				errs = append(errs, r.applyEgressFirewall(ctx, instance, namespace, egress))
+			} else {
+				errs = append(errs, r.applyNetworkPolicy(ctx, namespace, egress))
+			}
+		}
+	}
+
+	for i := range firewalls.Items {
+		firewall := &firewalls.Items[i]
+		if !desired[firewall.GetNamespace()] {
+			r.Log.Info("Removing EgressFirewall", "namespace", firewall.GetNamespace())
+			errs = append(errs, client.IgnoreNotFound(r.Client.Delete(ctx, firewall)))
+		}
+	}
+
+	policies := &networkingv1.NetworkPolicyList{}
+	if err := r.Client.List(ctx, policies, client.HasLabels{labels.ODH.WorkloadPolicy}); err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list NetworkPolicies: %w", err))...)
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		// a NetworkPolicy left over from before OVN-Kubernetes is removed as well
+		if !desired[policy.Namespace] || ovn {
+			r.Log.Info("Removing egress NetworkPolicy", "namespace", policy.Namespace)
+			errs = append(errs, client.IgnoreNotFound(r.Client.Delete(ctx, policy)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyEgressFirewall allows egress of the namespace to the destinations only. An EgressFirewall not created by the
+// operator is left untouched, as there can be only one per namespace, and reported with a Warning event rather than
+// an error, as retrying would not help until it is removed.
+func (r *WorkloadPolicyReconciler) applyEgressFirewall(ctx context.Context, instance *dsciv1.DSCInitialization, namespace string,
+	egress []dsciv1.EgressDestination,
+) error {
+	firewall := &unstructured.Unstructured{}
+	firewall.SetGroupVersionKind(gvk.EgressFirewall)
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: EgressFirewallName}, firewall)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && firewall.GetLabels()[labels.ODH.WorkloadPolicy] != "true" {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "EgressFirewallNotManaged",
+			"Egress of namespace %s is not restricted, its EgressFirewall is not managed by the operator", namespace)
+		return nil
+	}
+
+	spec := map[string]any{"egress": egressFirewallRules(egress)}
+	if exists && reflect.DeepEqual(firewall.Object["spec"], spec) {
+		return nil
+	}
+	firewall.SetName(EgressFirewallName)
+	firewall.SetNamespace(namespace)
+	firewall.SetLabels(map[string]string{labels.ODH.WorkloadPolicy: "true"})
+	firewall.Object["spec"] = spec
+	if exists {
+		return r.Client.Update(ctx, firewall)
+	}
+
+	return r.Client.Create(ctx, firewall)
+}
+
+// egressFirewallRules allows each destination, in order, and denies anything else outside of the cluster.
+func egressFirewallRules(egress []dsciv1.EgressDestination) []any {
+	rules := make([]any, 0, len(egress)+1)
+	for _, destination := range egress {
+		to := map[string]any{"cidrSelector": destination.CIDR}
+		if destination.DNSName != "" {
+			to = map[string]any{"dnsName": destination.DNSName}
+		}
+		rules = append(rules, map[string]any{"type": "Allow", "to": to})
+	}
+
+	return append(rules, map[string]any{"type": "Deny", "to": map[string]any{"cidrSelector": "0.0.0.0/0"}})
+}
+
+// applyNetworkPolicy allows egress of the pods of the namespace to pods of the cluster and to the CIDRs of the
+// destinations. NetworkPolicies cannot match DNS names, such destinations are denied.
+func (r *WorkloadPolicyReconciler) applyNetworkPolicy(ctx context.Context, namespace string, egress []dsciv1.EgressDestination) error {
+	desired := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName,
+			Namespace: namespace,
+			Labels:    map[string]string{labels.ODH.WorkloadPolicy: "true"},
+		},
+		Spec: networkPolicySpec(egress),
+	}
+
+	found := &networkingv1.NetworkPolicy{}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), found)
+	switch {
+	case k8serr.IsNotFound(err):
+		return r.Client.Create(ctx, desired)
+	case err != nil:
+		return err
+	case equality.Semantic.DeepEqual(found.Spec, desired.Spec):
+		return nil
+	}
+	found.Spec = desired.Spec
+
+	return r.Client.Update(ctx, found)
+}
+
+func networkPolicySpec(egress []dsciv1.EgressDestination) networkingv1.NetworkPolicySpec {
+	spec := networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}},
+		},
+	}
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, destination := range egress {
+		if destination.CIDR != "" {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: destination.CIDR}})
+		}
+	}
+	// a rule without peers would allow egress to any destination
+	if len(peers) > 0 {
+		spec.Egress = append(spec.Egress, networkingv1.NetworkPolicyEgressRule{To: peers})
+	}
+
+	return spec
+}
+
+func hasDNSNames(egress []dsciv1.EgressDestination) bool {
+	for _, destination := range egress {
+		if destination.DNSName != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reconcileRegistries rejects pods of data science projects with images of registries other than the given ones,
+// or lifts the restriction when none is given. Clusters not serving ValidatingAdmissionPolicies are reported.
+func (r *WorkloadPolicyReconciler) reconcileRegistries(ctx context.Context, instance *dsciv1.DSCInitialization, registries []string) error {
+	policy, binding := admissionPolicy(registries)
+	if len(registries) == 0 {
+		var errs []error
+		for _, obj := range []client.Object{binding, policy} {
+			if err := r.Client.Delete(ctx, obj); err != nil && !k8serr.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	err := r.applyAdmissionPolicy(ctx, policy, binding)
+	if meta.IsNoMatchError(err) {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "AllowedRegistriesIgnored",
+			"ValidatingAdmissionPolicies are not served by the cluster, images of workloads are not restricted")
+		return nil
+	}
+
+	return err
+}
+
+func (r *WorkloadPolicyReconciler) applyAdmissionPolicy(ctx context.Context, policy *admissionregistrationv1beta1.ValidatingAdmissionPolicy,
+	binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding,
+) error {
+	foundPolicy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(policy), foundPolicy)
+	switch {
+	case k8serr.IsNotFound(err):
+		err = r.Client.Create(ctx, policy)
+	case err == nil && !equality.Semantic.DeepDerivative(policy.Spec, foundPolicy.Spec):
+		foundPolicy.Spec = policy.Spec
+		err = r.Client.Update(ctx, foundPolicy)
+	}
+	if err != nil {
+		return err
+	}
+
+	foundBinding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(binding), foundBinding)
+	switch {
+	case k8serr.IsNotFound(err):
+		return r.Client.Create(ctx, binding)
+	case err != nil:
+		return err
+	case equality.Semantic.DeepDerivative(binding.Spec, foundBinding.Spec):
+		return nil
+	}
+	foundBinding.Spec = binding.Spec
+
+	return r.Client.Update(ctx, foundBinding)
+}
+
+// admissionPolicy returns the ValidatingAdmissionPolicy rejecting pods with images of other registries than the
+// given ones, and its binding to the data science projects.
+func admissionPolicy(registries []string) (*admissionregistrationv1beta1.ValidatingAdmissionPolicy, *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding) {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: AdmissionPolicyName, Labels: map[string]string{labels.ODH.WorkloadPolicy: "true"}},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+						Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+					},
+				}},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{{
+				Expression: imagesExpression(registries),
+				Message:    "images must be pulled from one of the allowed registries: " + strings.Join(registries, ", "),
+			}},
+		},
+	}
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: AdmissionPolicyName, Labels: map[string]string{labels.ODH.WorkloadPolicy: "true"}},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        AdmissionPolicyName,
+			ValidationActions: []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Deny},
+			MatchResources: &admissionregistrationv1beta1.MatchResources{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{labels.ODH.Dashboard: "true"}},
+			},
+		},
+	}
+
+	return policy, binding
+}
+
+// imagesExpression returns the CEL expression checking that the images of all containers, and init containers, are
+// in one of the registries. Registries are validated by the DSCInitialization CRD and safe to quote.
+func imagesExpression(registries []string) string {
+	quoted := make([]string, 0, len(registries))
+	for _, registry := range registries {
+		quoted = append(quoted, "'"+registry+"'")
+	}
+	allowed := fmt.Sprintf("[%s].exists(r, c.image.startsWith(r + '/') || c.image.startsWith(r + ':') || c.image.startsWith(r + '@'))",
+		strings.Join(quoted, ", "))
+
+	return fmt.Sprintf("object.spec.containers.all(c, %[1]s) && (!has(object.spec.initContainers) || object.spec.initContainers.all(c, %[1]s))",
+		allowed)
+}
+
+// watchNamespaces reconciles all DSCInitializations, as the policy applies to every data science project.
+func (r *WorkloadPolicyReconciler) watchNamespaces(ctx context.Context, _ client.Object) []reconcile.Request {
+	instances := &dsciv1.DSCInitializationList{}
+	if err := r.Client.List(ctx, instances); err != nil {
+		r.Log.Error(err, "failed to list DSCInitializations")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name}})
+	}
+
+	return requests
+}
+
+// projectLabelChanged passes namespaces created as data science projects, and namespaces becoming or ceasing to be
+// data science projects.
+var projectLabelChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetLabels()[labels.ODH.Dashboard] == "true"
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetLabels()[labels.ODH.Dashboard] != e.ObjectNew.GetLabels()[labels.ODH.Dashboard]
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
@@ -0,0 +1,257 @@
+package workloadpolicy
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Egress rules", func() {
+	It("should allow each destination in order and deny anything else", func() {
+		rules := egressFirewallRules([]dsciv1.EgressDestination{{CIDR: "10.0.0.0/16"}, {DNSName: "s3.amazonaws.com"}})
+
+		Expect(rules).To(Equal([]any{
+			map[string]any{"type": "Allow", "to": map[string]any{"cidrSelector": "10.0.0.0/16"}},
+			map[string]any{"type": "Allow", "to": map[string]any{"dnsName": "s3.amazonaws.com"}},
+			map[string]any{"type": "Deny", "to": map[string]any{"cidrSelector": "0.0.0.0/0"}},
+		}))
+	})
+
+	It("should only allow egress within the cluster to DNS names in NetworkPolicies", func() {
+		// an empty peer list would allow any destination
+		spec := networkPolicySpec([]dsciv1.EgressDestination{{DNSName: "s3.amazonaws.com"}})
+
+		Expect(spec.Egress).To(HaveLen(1))
+		Expect(spec.Egress[0].To[0].NamespaceSelector).NotTo(BeNil())
+	})
+
+	It("should allow egress to the CIDRs in NetworkPolicies", func() {
+		spec := networkPolicySpec([]dsciv1.EgressDestination{{CIDR: "10.0.0.0/16"}, {DNSName: "s3.amazonaws.com"}})
+
+		Expect(spec.Egress).To(HaveLen(2))
+		Expect(spec.Egress[1].To).To(ConsistOf(networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16"}}))
+	})
+})
+
+var _ = Describe("Image restrictions", func() {
+	It("should only accept images of the registries in containers and init containers", func() {
+		Expect(imagesExpression([]string{"quay.io/opendatahub"})).To(Equal(
+			"object.spec.containers.all(c, ['quay.io/opendatahub'].exists(r, c.image.startsWith(r + '/') || " +
+				"c.image.startsWith(r + ':') || c.image.startsWith(r + '@'))) && (!has(object.spec.initContainers) || " +
+				"object.spec.initContainers.all(c, ['quay.io/opendatahub'].exists(r, c.image.startsWith(r + '/') || " +
+				"c.image.startsWith(r + ':') || c.image.startsWith(r + '@'))))"))
+	})
+})
+
+var _ = Describe("Workload policy controller", func() {
+	var (
+		dsci     *dsciv1.DSCInitialization
+		objects  []client.Object
+		funcs    interceptor.Funcs
+		cli      client.Client
+		recorder *record.FakeRecorder
+		req      = ctrl.Request{NamespacedName: client.ObjectKey{Name: "default-dsci"}}
+	)
+
+	project := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.ODH.Dashboard: "true"}}}
+	}
+	egressFirewall := func() *unstructured.Unstructured {
+		firewall := &unstructured.Unstructured{}
+		firewall.SetGroupVersionKind(gvk.EgressFirewall)
+		return firewall
+	}
+	isEgressFirewall := func(obj runtime.Object) bool {
+		return obj.GetObjectKind().GroupVersionKind().Kind == gvk.EgressFirewall.Kind ||
+			obj.GetObjectKind().GroupVersionKind().Kind == gvk.EgressFirewall.Kind+"List"
+	}
+	noEgressFirewalls := &meta.NoKindMatchError{GroupKind: gvk.EgressFirewall.GroupKind()}
+
+	reconcile := func(ctx context.Context) error {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+		if cli == nil {
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, dsci)...).WithInterceptorFuncs(funcs).Build()
+		}
+		r := &WorkloadPolicyReconciler{Client: cli, Log: logr.Discard(), Recorder: recorder}
+		_, err := r.Reconcile(ctx, req)
+		return err
+	}
+	update := func(ctx context.Context, mutate func(*dsciv1.DSCInitialization)) {
+		Expect(cli.Get(ctx, req.NamespacedName, dsci)).To(Succeed())
+		mutate(dsci)
+		Expect(cli.Update(ctx, dsci)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dsci = &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec: dsciv1.DSCInitializationSpec{
+				WorkloadPolicy: &dsciv1.WorkloadPolicySpec{
+					ManagementState:   operatorv1.Managed,
+					AllowedEgress:     []dsciv1.EgressDestination{{CIDR: "10.0.0.0/16"}},
+					AllowedRegistries: []string{"quay.io/opendatahub"},
+				},
+			},
+		}
+		objects = []client.Object{project("project-a"), project("project-b"), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}}
+		funcs = interceptor.Funcs{}
+		cli = nil
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	Context("with OVN-Kubernetes", func() {
+		It("should restrict the egress of data science projects only", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			for _, namespace := range []string{"project-a", "project-b"} {
+				Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: EgressFirewallName}, egressFirewall())).To(Succeed())
+			}
+			err := cli.Get(ctx, client.ObjectKey{Namespace: "other", Name: EgressFirewallName}, egressFirewall())
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should replace NetworkPolicies left over from before OVN-Kubernetes", func(ctx context.Context) {
+			leftover := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+				Name: NetworkPolicyName, Namespace: "project-a", Labels: map[string]string{labels.ODH.WorkloadPolicy: "true"},
+			}}
+			objects = append(objects, leftover)
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			err := cli.Get(ctx, client.ObjectKeyFromObject(leftover), &networkingv1.NetworkPolicy{})
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave EgressFirewalls not managed by the operator alone and report them", func(ctx context.Context) {
+			unmanaged := egressFirewall()
+			unmanaged.SetNamespace("project-a")
+			unmanaged.SetName(EgressFirewallName)
+			unmanaged.Object["spec"] = map[string]any{"egress": []any{}}
+			objects = append(objects, unmanaged)
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			found := egressFirewall()
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(unmanaged), found)).To(Succeed())
+			Expect(found.GetLabels()).NotTo(HaveKey(labels.ODH.WorkloadPolicy))
+			Expect(found.Object["spec"]).To(Equal(map[string]any{"egress": []any{}}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("EgressFirewallNotManaged")))
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: "project-b", Name: EgressFirewallName}, egressFirewall())).To(Succeed())
+		})
+
+		It("should remove EgressFirewalls of namespaces which are not data science projects anymore", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			namespace := &corev1.Namespace{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "project-b"}, namespace)).To(Succeed())
+			namespace.Labels = nil
+			Expect(cli.Update(ctx, namespace)).To(Succeed())
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			err := cli.Get(ctx, client.ObjectKey{Namespace: "project-b", Name: EgressFirewallName}, egressFirewall())
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: "project-a", Name: EgressFirewallName}, egressFirewall())).To(Succeed())
+		})
+	})
+
+	Context("without OVN-Kubernetes", func() {
+		BeforeEach(func() {
+			funcs = interceptor.Funcs{
+				Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if isEgressFirewall(obj) {
+						return noEgressFirewalls
+					}
+					return cli.Get(ctx, key, obj, opts...)
+				},
+				List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if isEgressFirewall(list) {
+						return noEgressFirewalls
+					}
+					return cli.List(ctx, list, opts...)
+				},
+			}
+		})
+
+		It("should restrict egress with NetworkPolicies and report DNS names as not enforced", func(ctx context.Context) {
+			dsci.Spec.WorkloadPolicy.AllowedEgress = append(dsci.Spec.WorkloadPolicy.AllowedEgress, dsciv1.EgressDestination{DNSName: "s3.amazonaws.com"})
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			policy := &networkingv1.NetworkPolicy{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: "project-a", Name: NetworkPolicyName}, policy)).To(Succeed())
+			Expect(policy.Spec).To(Equal(networkPolicySpec(dsci.Spec.WorkloadPolicy.AllowedEgress)))
+			Expect(recorder.Events).To(Receive(ContainSubstring("EgressDNSNamesIgnored")))
+		})
+	})
+
+	Context("restricting images", func() {
+		It("should bind the admission policy to data science projects", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+
+			binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: AdmissionPolicyName}, binding)).To(Succeed())
+			Expect(binding.Spec.MatchResources.NamespaceSelector.MatchLabels).To(HaveKeyWithValue(labels.ODH.Dashboard, "true"))
+		})
+
+		It("should remove the admission policy once no registry is listed", func(ctx context.Context) {
+			Expect(reconcile(ctx)).To(Succeed())
+			update(ctx, func(dsci *dsciv1.DSCInitialization) { dsci.Spec.WorkloadPolicy.AllowedRegistries = nil })
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			err := cli.Get(ctx, client.ObjectKey{Name: AdmissionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicy{})
+			Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should report clusters not serving admission policies", func(ctx context.Context) {
+			funcs = interceptor.Funcs{
+				Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*admissionregistrationv1beta1.ValidatingAdmissionPolicy); ok {
+						return &meta.NoKindMatchError{GroupKind: admissionregistrationv1beta1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicy").GroupKind()}
+					}
+					return cli.Get(ctx, key, obj, opts...)
+				},
+			}
+
+			Expect(reconcile(ctx)).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("AllowedRegistriesIgnored")))
+		})
+	})
+
+	It("should lift all restrictions once the policy is removed", func(ctx context.Context) {
+		Expect(reconcile(ctx)).To(Succeed())
+		update(ctx, func(dsci *dsciv1.DSCInitialization) { dsci.Spec.WorkloadPolicy.ManagementState = operatorv1.Removed })
+
+		Expect(reconcile(ctx)).To(Succeed())
+
+		err := cli.Get(ctx, client.ObjectKey{Namespace: "project-a", Name: EgressFirewallName}, egressFirewall())
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		err = cli.Get(ctx, client.ObjectKey{Name: AdmissionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{})
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+})
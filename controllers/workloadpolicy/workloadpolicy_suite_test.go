@@ -0,0 +1,13 @@
+package workloadpolicy
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkloadPolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workload policy controller suite")
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	addonv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
@@ -50,6 +51,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -57,21 +60,63 @@ import (
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	dataconnectionv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dataconnection/v1alpha1"
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	diagnosticsv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/diagnostics/v1alpha1"
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	federationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/federation/v1alpha1"
+	inventoryv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/inventory/v1alpha1"
+	migrationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/migration/v1alpha1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	projectv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/project/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelregistry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/admissionpolicy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/certconfigmapgenerator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/configrollout"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/connectioncheck"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/costattribution"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/dashboardaccess"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/dashboardconfigsync"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/dataconnection"
 	dscctrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/datasciencecluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/deprecationreport"
 	dscictrl "github.com/opendatahub-io/opendatahub-operator/v2/controllers/dscinitialization"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/federation"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/idlereaper"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/logconfig"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/migration"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/modelregistrysync"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/oauthclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/operatorconfig"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/pipelineserver"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/policyreport"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/profile"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/projecttemplate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/rotationreport"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/routehealth"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/secretgenerator"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/selfhealing"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/servingcatalog"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/sidecarinjection"
 	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/webhook"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/webhookhealth"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/workloadidentity"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/workloadpolicy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/journal"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/startup"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 )
 
-const controllerNum = 4 // we should keep this updated if we have new controllers to add
+// controllerNum sizes the rate limits of the setup client, we should keep this updated if we have new controllers to
+// add: DSCInitialization, DataScienceCluster, LogConfig and OperatorConfig, and the ones added to deferred.
+const controllerNum = 32
 
 var (
 	scheme   = runtime.NewScheme()
@@ -84,6 +129,13 @@ func init() { //nolint:gochecknoinits
 	utilruntime.Must(dsciv1.AddToScheme(scheme))
 	utilruntime.Must(dscv1.AddToScheme(scheme))
 	utilruntime.Must(featurev1.AddToScheme(scheme))
+	utilruntime.Must(operatorconfigv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(dataconnectionv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(migrationv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(inventoryv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(diagnosticsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(federationv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(projectv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(networkingv1.AddToScheme(scheme))
 	utilruntime.Must(addonv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(rbacv1.AddToScheme(scheme))
@@ -128,6 +180,11 @@ func main() { //nolint:funlen,maintidx
 	var dscMonitoringNamespace string
 	var operatorName string
 	var logmode string
+	var resyncInterval time.Duration
+	var readinessTimeout time.Duration
+	var oauthClientSecretRotation time.Duration
+	var renewSelfSignedCertificates bool
+	var devManifestsPath string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -140,6 +197,16 @@ func main() { //nolint:funlen,maintidx
 		"monitoring stack will be deployed")
 	flag.StringVar(&operatorName, "operator-name", "opendatahub", "The name of the operator")
 	flag.StringVar(&logmode, "log-mode", "", "Log mode ('', prod, devel), default to ''")
+	flag.DurationVar(&resyncInterval, "resync-interval", 0, "Interval of full DataScienceCluster reconciles restoring resources "+
+		"deleted out-of-band, e.g. 1h. Disabled by default")
+	flag.DurationVar(&readinessTimeout, "readiness-timeout", 10*time.Minute, "How long components can fail their readiness gates "+
+		"before the DataScienceCluster is reported Degraded")
+	flag.DurationVar(&oauthClientSecretRotation, "oauth-client-secret-rotation", 90*24*time.Hour, "Age after which secrets of "+
+		"OAuthClients of components are rotated, 0 to never rotate them")
+	flag.BoolVar(&renewSelfSignedCertificates, "renew-self-signed-certificates", false, "Renew the self-signed certificates "+
+		"generated by the operator 30 days before they expire, with the SecretRotationReport feature gate enabled")
+	flag.StringVar(&devManifestsPath, "dev-manifests-path", "", "Local directory components are deployed from, laid out as "+
+		"the manifests directory and synced again on change. For manifest development only")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -148,6 +215,11 @@ func main() { //nolint:funlen,maintidx
 
 	ctrl.SetLogger(logger.NewLoggerWithOptions(logmode, &opts))
 
+	if err := featuregate.LoadEnv(); err != nil {
+		setupLog.Error(err, "unable to load feature gates")
+		os.Exit(1)
+	}
+
 	// root context
 	ctx := ctrl.SetupSignalHandler()
 	ctx = logf.IntoContext(ctx, setupLog)
@@ -158,8 +230,8 @@ func main() { //nolint:funlen,maintidx
 		os.Exit(1)
 	}
 	// uplift default limiataions
-	setupCfg.QPS = rest.DefaultQPS * controllerNum     // 5 * 4 controllers
-	setupCfg.Burst = rest.DefaultBurst * controllerNum // 10 * 4 controllers
+	setupCfg.QPS = rest.DefaultQPS * controllerNum     // 5 * 32 controllers
+	setupCfg.Burst = rest.DefaultBurst * controllerNum // 10 * 32 controllers
 
 	setupClient, err := client.New(setupCfg, client.Options{Scheme: scheme})
 	if err != nil {
@@ -177,13 +249,31 @@ func main() { //nolint:funlen,maintidx
 	release := cluster.GetRelease()
 	platform := release.Name
 
+	// Settings of the OperatorConfig which can only be applied on startup
+	operatorConfigSpec := operatorconfigv1alpha1.OperatorConfigSpec{}
+	if operatorConfig, err := operatorconfig.Get(ctx, setupClient); err != nil {
+		setupLog.Error(err, "unable to get operator configuration")
+		os.Exit(1)
+	} else if operatorConfig != nil {
+		operatorConfigSpec = operatorConfig.Spec
+	}
+	if err := featuregate.Set(operatorConfigSpec.FeatureGates); err != nil {
+		// Reported in the OperatorConfig status, the operator carries on with gates from the environment
+		setupLog.Error(err, "invalid feature gates in operator configuration")
+	}
+	for _, gate := range featuregate.All() {
+		setupLog.Info("feature gate", "name", gate.Name, "stage", gate.Stage, "enabled", gate.Enabled)
+	}
+
 	secretCache := createSecretCacheConfig(platform)
 	deploymentCache := createDeploymentCacheConfig(platform)
+	for _, ns := range operatorConfigSpec.Cache.Namespaces {
+		secretCache[ns] = cache.Config{}
+		deploymentCache[ns] = cache.Config{}
+	}
 	cacheOptions := cache.Options{
 		Scheme: scheme,
 		ByObject: map[client.Object]cache.ByObject{
-			// all CRD: mainly for pipeline v1 teckon and v2 argo and dashboard's own CRD
-			&apiextensionsv1.CustomResourceDefinition{}: {},
 			// Cannot find a label on various screts, so we need to watch all secrets
 			// this include, monitoring, dashboard, trustcabundle default cert etc for these NS
 			&corev1.Secret{}: {
@@ -211,7 +301,17 @@ func main() { //nolint:funlen,maintidx
 		},
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{ // single pod does not need to have LeaderElection
+	// Load the operator puts on the API server, as set in the OperatorConfig
+	mgrCfg := ctrl.GetConfigOrDie()
+	if operatorConfigSpec.Client.QPS > 0 {
+		mgrCfg.QPS = float32(operatorConfigSpec.Client.QPS)
+	}
+	if operatorConfigSpec.Client.Burst > 0 {
+		mgrCfg.Burst = int(operatorConfigSpec.Client.Burst)
+	}
+	setupLog.Info("API client rate", "qps", mgrCfg.QPS, "burst", mgrCfg.Burst)
+
+	mgr, err := ctrl.NewManager(mgrCfg, ctrl.Options{ // single pod does not need to have LeaderElection
 		Scheme:  scheme,
 		Metrics: ctrlmetrics.Options{BindAddress: metricsAddr},
 		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
@@ -220,8 +320,13 @@ func main() { //nolint:funlen,maintidx
 		}),
 		HealthProbeBindAddress: probeAddr,
 		Cache:                  cacheOptions,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "07ed84f7.opendatahub.io",
+		NewCache:               cluster.NewCacheWithStats,
+		Controller: ctrlconfig.Controller{
+			MaxConcurrentReconciles: operatorConfigSpec.Concurrency.MaxConcurrentReconciles,
+			GroupKindConcurrency:    operatorConfigSpec.Concurrency.ByKind,
+		},
+		LeaderElection:   enableLeaderElection,
+		LeaderElectionID: "07ed84f7.opendatahub.io",
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -241,17 +346,32 @@ func main() { //nolint:funlen,maintidx
 
 	webhook.Init(mgr)
 
+	// Lifecycle events are sent to the sinks of the OperatorConfig, whose settings live in the operator namespace
+	var notifier *notification.Notifier
+	if operatorNamespace, err := cluster.GetOperatorNamespace(); err == nil {
+		notifier = notification.New(mgr.GetAPIReader(), operatorNamespace)
+	} else {
+		setupLog.Info("notifications disabled, operator namespace is unknown")
+	}
+
 	if err = (&dscictrl.DSCInitializationReconciler{
 		Client:                mgr.GetClient(),
 		Scheme:                mgr.GetScheme(),
 		Log:                   ctrl.Log.WithName(operatorName).WithName("controllers").WithName("DSCInitialization"),
 		Recorder:              mgr.GetEventRecorderFor("dscinitialization-controller"),
 		ApplicationsNamespace: dscApplicationsNamespace,
+		Notifier:              notifier,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DSCInitiatlization")
 		os.Exit(1)
 	}
 
+	// In developer mode, components are deployed again whenever their local manifests change
+	var manifestsChanged chan event.GenericEvent
+	if devManifestsPath != "" {
+		manifestsChanged = make(chan event.GenericEvent, 1)
+	}
+
 	if err = (&dscctrl.DataScienceClusterReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -261,30 +381,269 @@ func main() { //nolint:funlen,maintidx
 				ApplicationsNamespace: dscApplicationsNamespace,
 			},
 		},
-		Recorder: mgr.GetEventRecorderFor("datasciencecluster-controller"),
+		Recorder:         mgr.GetEventRecorderFor("datasciencecluster-controller"),
+		ResyncInterval:   resyncInterval,
+		ReadinessTimeout: readinessTimeout,
+		APIReader:        mgr.GetAPIReader(),
+		Notifier:         notifier,
+		ManifestsChanged: manifestsChanged,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DataScienceCluster")
 		os.Exit(1)
 	}
 
-	if err = (&secretgenerator.SecretGeneratorReconciler{
+	if err = (&logconfig.LogConfigReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("SecretGenerator"),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("LogConfig"),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "SecretGenerator")
+		setupLog.Error(err, "unable to create controller", "controller", "LogConfig")
 		os.Exit(1)
 	}
 
-	if err = (&certconfigmapgenerator.CertConfigmapGeneratorReconciler{
+	if err = (&operatorconfig.OperatorConfigReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Log:     ctrl.Log.WithName(operatorName).WithName("controllers").WithName("OperatorConfig"),
+		Startup: operatorConfigSpec,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OperatorConfig")
+		os.Exit(1)
+	}
+
+	// Secondary controllers are set up once the caches of the DSCInitialization and DataScienceCluster controllers are
+	// synced, not to delay their first reconciliation after a restart of the operator
+	deferred := startup.NewDeferred(mgr, setupLog, &dsciv1.DSCInitialization{}, &dscv1.DataScienceCluster{})
+
+	deferred.Add("SecretGenerator", (&secretgenerator.SecretGeneratorReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("SecretGenerator"),
+	}).SetupWithManager)
+
+	deferred.Add("CertConfigmapGenerator", (&certconfigmapgenerator.CertConfigmapGeneratorReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("CertConfigmapGenerator"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "CertConfigmapGenerator")
+	}).SetupWithManager)
+
+	deferred.Add("SelfHealing", (&selfhealing.SelfHealingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName(operatorName).WithName("controllers").WithName("SelfHealing"),
+		Recorder: mgr.GetEventRecorderFor("self-healing-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("SidecarInjection", (&sidecarinjection.SidecarInjectionReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName(operatorName).WithName("controllers").WithName("SidecarInjection"),
+		Recorder: mgr.GetEventRecorderFor("sidecar-injection-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("DataConnection", (&dataconnection.DataConnectionReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("DataConnection"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("data-connection-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("PipelineServer", (&pipelineserver.PipelineServerReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("PipelineServer"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("pipeline-server-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("ModelMeshMigration", (&migration.ModelMeshMigrationReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ModelMeshMigration"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("modelmesh-migration-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("ConfigRollout", (&configrollout.ConfigRolloutReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ConfigRollout"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("config-rollout-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("OAuthClient", (&oauthclient.OAuthClientReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Log:              ctrl.Log.WithName(operatorName).WithName("controllers").WithName("OAuthClient"),
+		Recorder:         mgr.GetEventRecorderFor("oauth-client-controller"),
+		RotationInterval: oauthClientSecretRotation,
+	}).SetupWithManager)
+
+	deferred.Add("RouteHealth", (&routehealth.RouteHealthReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName(operatorName).WithName("controllers").WithName("RouteHealth"),
+		Notifier: notifier,
+	}).SetupWithManager)
+
+	deferred.Add("ModelRegistrySync", (&modelregistrysync.ModelRegistrySyncReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ModelRegistrySync"),
+	}).SetupWithManager)
+
+	deferred.Add("PolicyReport", (&policyreport.PolicyReportReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("PolicyReport"),
+	}).SetupWithManager)
+
+	deferred.Add("DashboardAccess", (&dashboardaccess.DashboardAccessReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("DashboardAccess"),
+	}).SetupWithManager)
+
+	deferred.Add("DashboardConfigSync", (&dashboardconfigsync.DashboardConfigSyncReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("DashboardConfigSync"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("dashboard-config-sync-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("Fleet", (&federation.FleetReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("Fleet"),
+	}).SetupWithManager)
+
+	deferred.Add("ClusterClaim", (&federation.ClusterClaimReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ClusterClaim"),
+	}).SetupWithManager)
+
+	deferred.Add("DeprecationReport", (&deprecationreport.DeprecationReportReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("DeprecationReport"),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager)
+
+	deferred.Add("RotationReport", (&rotationreport.RotationReportReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Log:               ctrl.Log.WithName(operatorName).WithName("controllers").WithName("RotationReport"),
+		APIReader:         mgr.GetAPIReader(),
+		Recorder:          mgr.GetEventRecorderFor("rotation-report-controller"),
+		MaxAge:            oauthClientSecretRotation,
+		RenewCertificates: renewSelfSignedCertificates,
+	}).SetupWithManager)
+
+	deferred.Add("ProjectTemplate", (&projecttemplate.ProjectTemplateReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ProjectTemplate"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("project-template-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("ServingCatalog", (&servingcatalog.ServingCatalogReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ServingCatalog"),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager)
+
+	operatorNamespace, _ := cluster.GetOperatorNamespace()
+	deferred.Add("WebhookHealth", (&webhookhealth.WebhookHealthReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Log:               ctrl.Log.WithName(operatorName).WithName("controllers").WithName("WebhookHealth"),
+		APIReader:         mgr.GetAPIReader(),
+		OperatorNamespace: operatorNamespace,
+	}).SetupWithManager)
+
+	deferred.Add("AdmissionPolicy", (&admissionpolicy.AdmissionPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName(operatorName).WithName("controllers").WithName("AdmissionPolicy"),
+		Recorder: mgr.GetEventRecorderFor("admission-policy-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("WorkloadPolicy", (&workloadpolicy.WorkloadPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName(operatorName).WithName("controllers").WithName("WorkloadPolicy"),
+		Recorder: mgr.GetEventRecorderFor("workload-policy-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("ConnectionCheck", (&connectioncheck.ConnectionCheckReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("ConnectionCheck"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("connection-check-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("IdleReaper", (&idlereaper.IdleReaperReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("IdleReaper"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("idle-reaper-controller"),
+		Requests:  idlereaper.NewMonitoringRequestCounter(),
+	}).SetupWithManager)
+
+	deferred.Add("CostAttribution", (&costattribution.CostAttributionReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("CostAttribution"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("cost-attribution-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("WorkloadIdentity", (&workloadidentity.WorkloadIdentityReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("WorkloadIdentity"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("workload-identity-controller"),
+	}).SetupWithManager)
+
+	deferred.Add("Profile", (&profile.ProfileReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName(operatorName).WithName("controllers").WithName("Profile"),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("profile-controller"),
+	}).SetupWithManager)
+
+	if err := mgr.Add(deferred); err != nil {
+		setupLog.Error(err, "unable to schedule setup of deferred controllers")
 		os.Exit(1)
 	}
 
+	if devManifestsPath != "" {
+		setupLog.Info("deploying components from local manifests, for development only", "path", devManifestsPath)
+		if err := mgr.Add(&deploy.LocalManifests{
+			Path: devManifestsPath,
+			Log:  ctrl.Log.WithName(operatorName).WithName("LocalManifests"),
+			Synced: func(string) {
+				select { // an event already pending reconciles the components with all the synced manifests
+				case manifestsChanged <- event.GenericEvent{Object: &dscv1.DataScienceCluster{}}:
+				default:
+				}
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to schedule sync of local manifests")
+			os.Exit(1)
+		}
+	}
+
 	// get old release version before we create default DSCI CR
 	oldReleaseVersion, _ := upgrade.GetDeployedRelease(ctx, setupClient)
 
@@ -322,9 +681,16 @@ func main() { //nolint:funlen,maintidx
 			os.Exit(1)
 		}
 	}
+	// Operations recorded in the journal are resumed after a restart of the operator
+	var operationJournal *journal.Journal
+	if operatorNamespace, err := cluster.GetOperatorNamespace(); err == nil {
+		operationJournal = journal.New(setupClient, operatorNamespace)
+	} else {
+		setupLog.Info("operation journal disabled, interrupted operations start over after a restart")
+	}
 	// Cleanup resources from previous v2 releases
 	var cleanExistingResourceFunc manager.RunnableFunc = func(ctx context.Context) error {
-		if err = upgrade.CleanupExistingResource(ctx, setupClient, platform, dscApplicationsNamespace, dscMonitoringNamespace, oldReleaseVersion); err != nil {
+		if err = upgrade.CleanupExistingResource(ctx, setupClient, operationJournal, platform, dscApplicationsNamespace, dscMonitoringNamespace, oldReleaseVersion); err != nil {
 			setupLog.Error(err, "unable to perform cleanup")
 		}
 		return err
@@ -343,13 +709,29 @@ func main() { //nolint:funlen,maintidx
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// Digests of the embedded manifests are taken before components update their params.env files
+	if err := deploy.RecordEmbeddedManifests(); err != nil {
+		setupLog.Error(err, "unable to record digests of embedded manifests")
+	}
 	if err := initComponents(ctx, platform); err != nil {
 		setupLog.Error(err, "unable to init components")
 		os.Exit(1)
 	}
 
+	// Report existing resources made incompatible by the upgrade before reconciling them, not failing the operator
+	if err := upgrade.VerifyCompatibility(ctx, setupClient); err != nil {
+		setupLog.Error(err, "unable to verify compatibility of existing resources")
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	// spans of the last reconciliations are flushed whether the manager stopped on a signal or an error
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if errTracing := tracing.Shutdown(shutdownCtx); errTracing != nil {
+		setupLog.Error(errTracing, "unable to flush traces")
+	}
+	cancel()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,57 @@
+// Package cleanup lets the cleanup run when the DataScienceCluster or DSCInitialization is deleted be forced, for them
+// not to be stuck terminating when resources cannot be cleaned up, e.g. once prerequisite operators are uninstalled.
+package cleanup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// Tracker runs the best-effort cleanup steps of an object being deleted. Once the cleanup is forced, steps which fail
+// are skipped and recorded instead of blocking the removal of the finalizer.
+type Tracker struct {
+	forced  bool
+	skipped []string
+}
+
+// NewTracker returns a Tracker for the object being deleted. Its cleanup is forced when the ForceCleanupAfter
+// annotation is set and the timeout it holds has elapsed since the deletion was requested.
+func NewTracker(obj client.Object) (*Tracker, error) {
+	value, found := obj.GetAnnotations()[annotations.ForceCleanupAfter]
+	if !found || obj.GetDeletionTimestamp() == nil {
+		return &Tracker{}, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return &Tracker{}, fmt.Errorf("invalid %s annotation: %w", annotations.ForceCleanupAfter, err)
+	}
+
+	return &Tracker{forced: time.Since(obj.GetDeletionTimestamp().Time) >= timeout}, nil
+}
+
+// Run runs the named cleanup step. Its error is returned unless the cleanup is forced, the step being skipped then.
+func (t *Tracker) Run(name string, step func() error) error {
+	err := step()
+	if err == nil || !t.forced {
+		return err
+	}
+	t.skipped = append(t.skipped, fmt.Sprintf("%s (%v)", name, err))
+
+	return nil
+}
+
+// Skipped lists the steps skipped, with the error they failed with.
+func (t *Tracker) Skipped() []string {
+	return t.skipped
+}
+
+// Message describes the steps skipped, for it to be reported in an event.
+func (t *Tracker) Message() string {
+	return "Cleanup forced, skipped: " + strings.Join(t.skipped, ", ")
+}
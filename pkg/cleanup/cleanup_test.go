@@ -0,0 +1,79 @@
+package cleanup
+
+import (
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func deleted(since time.Duration, forceCleanupAfter string) *corev1.ConfigMap {
+	deletionTimestamp := metav1.NewTime(time.Now().Add(-since))
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "default", DeletionTimestamp: &deletionTimestamp}}
+	if forceCleanupAfter != "" {
+		obj.Annotations = map[string]string{annotations.ForceCleanupAfter: forceCleanupAfter}
+	}
+
+	return obj
+}
+
+var _ = Describe("Cleanup tracker", func() {
+	var (
+		errGone = errors.New("no matches for kind \"KnativeServing\"")
+		fail    = func() error { return errGone }
+		succeed = func() error { return nil }
+	)
+
+	tracker := func(obj *corev1.ConfigMap) *Tracker {
+		GinkgoHelper()
+		tracker, err := NewTracker(obj)
+		Expect(err).ToNot(HaveOccurred())
+		return tracker
+	}
+
+	DescribeTable("should report failing steps until the cleanup is forced",
+		func(obj *corev1.ConfigMap) {
+			tracker := tracker(obj)
+
+			Expect(tracker.Run("serverless", succeed)).To(Succeed())
+			Expect(tracker.Run("kserve", fail)).To(MatchError(errGone))
+			Expect(tracker.Skipped()).To(BeEmpty())
+		},
+		Entry("without annotation", deleted(time.Hour, "")),
+		Entry("before the timeout", deleted(time.Minute, "10m")),
+		Entry("while the object is not deleted", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "default", Annotations: map[string]string{annotations.ForceCleanupAfter: "0s"},
+		}}),
+	)
+
+	DescribeTable("should skip failing steps once the cleanup is forced",
+		func(obj *corev1.ConfigMap) {
+			tracker := tracker(obj)
+
+			Expect(tracker.Run("serverless", succeed)).To(Succeed())
+			Expect(tracker.Run("kserve", fail)).To(Succeed())
+			Expect(tracker.Run("servicemesh", fail)).To(Succeed())
+			Expect(tracker.Skipped()).To(Equal([]string{
+				`kserve (no matches for kind "KnativeServing")`,
+				`servicemesh (no matches for kind "KnativeServing")`,
+			}))
+			Expect(tracker.Message()).To(Equal(`Cleanup forced, skipped: kserve (no matches for kind "KnativeServing"), ` +
+				`servicemesh (no matches for kind "KnativeServing")`))
+		},
+		Entry("after the timeout", deleted(time.Hour, "10m")),
+		Entry("immediately", deleted(0, "0s")),
+	)
+
+	It("should report an invalid timeout, without forcing the cleanup", func() {
+		tracker, err := NewTracker(deleted(time.Hour, "soon"))
+		Expect(err).To(MatchError(ContainSubstring("invalid " + annotations.ForceCleanupAfter + " annotation")))
+
+		Expect(tracker.Run("kserve", fail)).To(MatchError(errGone))
+	})
+})
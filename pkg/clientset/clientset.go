@@ -0,0 +1,188 @@
+// Package clientset provides typed clients for the APIs served by the operator, e.g. DataScienceCluster and
+// DSCInitialization, so that external Go tooling and other operators can integrate without unstructured clients
+// or copies of the type definitions. Clients are built on controller-runtime, reading either from the API server
+// or from the shared informers of a cache.
+package clientset
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dataconnectionv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dataconnection/v1alpha1"
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	migrationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/migration/v1alpha1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+)
+
+var schemeBuilder = runtime.NewSchemeBuilder(
+	dscv1.AddToScheme,
+	dsciv1.AddToScheme,
+	featurev1.AddToScheme,
+	operatorconfigv1alpha1.AddToScheme,
+	dataconnectionv1alpha1.AddToScheme,
+	migrationv1alpha1.AddToScheme,
+)
+
+// AddToScheme adds the APIs of the operator to a scheme.
+func AddToScheme(scheme *runtime.Scheme) error {
+	return schemeBuilder.AddToScheme(scheme)
+}
+
+// Scheme holds the APIs of the operator only, clients of other APIs need a scheme of their own.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(AddToScheme(Scheme))
+}
+
+// Interface gives typed clients of the APIs of the operator. All of them are cluster scoped.
+type Interface interface {
+	DataScienceClusters() *Resource[*dscv1.DataScienceCluster, *dscv1.DataScienceClusterList]
+	DSCInitializations() *Resource[*dsciv1.DSCInitialization, *dsciv1.DSCInitializationList]
+	FeatureTrackers() *Resource[*featurev1.FeatureTracker, *featurev1.FeatureTrackerList]
+	OperatorConfigs() *Resource[*operatorconfigv1alpha1.OperatorConfig, *operatorconfigv1alpha1.OperatorConfigList]
+	DataConnections() *Resource[*dataconnectionv1alpha1.DataConnection, *dataconnectionv1alpha1.DataConnectionList]
+	ModelMeshMigrations() *Resource[*migrationv1alpha1.ModelMeshMigration, *migrationv1alpha1.ModelMeshMigrationList]
+}
+
+// Clientset implements Interface on top of a controller-runtime client.
+type Clientset struct {
+	cli client.Client
+}
+
+var _ Interface = (*Clientset)(nil)
+
+// NewForConfig returns a Clientset reading from and writing to the API server. The client supports Watch.
+func NewForConfig(cfg *rest.Config) (*Clientset, error) {
+	cli, err := client.NewWithWatch(cfg, client.Options{Scheme: Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return New(cli), nil
+}
+
+// New returns a Clientset using the given client, whose scheme must include the APIs of the operator (see AddToScheme).
+// Watch is only supported when the client implements client.WithWatch.
+func New(cli client.Client) *Clientset {
+	return &Clientset{cli: cli}
+}
+
+func (c *Clientset) DataScienceClusters() *Resource[*dscv1.DataScienceCluster, *dscv1.DataScienceClusterList] {
+	return newResource[dscv1.DataScienceCluster, dscv1.DataScienceClusterList](c.cli)
+}
+
+func (c *Clientset) DSCInitializations() *Resource[*dsciv1.DSCInitialization, *dsciv1.DSCInitializationList] {
+	return newResource[dsciv1.DSCInitialization, dsciv1.DSCInitializationList](c.cli)
+}
+
+func (c *Clientset) FeatureTrackers() *Resource[*featurev1.FeatureTracker, *featurev1.FeatureTrackerList] {
+	return newResource[featurev1.FeatureTracker, featurev1.FeatureTrackerList](c.cli)
+}
+
+func (c *Clientset) OperatorConfigs() *Resource[*operatorconfigv1alpha1.OperatorConfig, *operatorconfigv1alpha1.OperatorConfigList] {
+	return newResource[operatorconfigv1alpha1.OperatorConfig, operatorconfigv1alpha1.OperatorConfigList](c.cli)
+}
+
+func (c *Clientset) DataConnections() *Resource[*dataconnectionv1alpha1.DataConnection, *dataconnectionv1alpha1.DataConnectionList] {
+	return newResource[dataconnectionv1alpha1.DataConnection, dataconnectionv1alpha1.DataConnectionList](c.cli)
+}
+
+func (c *Clientset) ModelMeshMigrations() *Resource[*migrationv1alpha1.ModelMeshMigration, *migrationv1alpha1.ModelMeshMigrationList] {
+	return newResource[migrationv1alpha1.ModelMeshMigration, migrationv1alpha1.ModelMeshMigrationList](c.cli)
+}
+
+// object constrains T to pointers of API types, so that empty objects can be created for reads.
+type object[T any] interface {
+	*T
+	client.Object
+}
+
+// list constrains L to pointers of API list types.
+type list[L any] interface {
+	*L
+	client.ObjectList
+}
+
+// Resource is a typed client of a cluster scoped API of the operator.
+type Resource[T client.Object, L client.ObjectList] struct {
+	cli     client.Client
+	newObj  func() T
+	newList func() L
+}
+
+func newResource[O any, LO any, T object[O], L list[LO]](cli client.Client) *Resource[T, L] {
+	return &Resource[T, L]{
+		cli:     cli,
+		newObj:  func() T { return T(new(O)) },
+		newList: func() L { return L(new(LO)) },
+	}
+}
+
+// Get returns the object with the given name.
+func (r *Resource[T, L]) Get(ctx context.Context, name string, opts ...client.GetOption) (T, error) {
+	obj := r.newObj()
+	if err := r.cli.Get(ctx, client.ObjectKey{Name: name}, obj, opts...); err != nil {
+		var empty T
+		return empty, err
+	}
+
+	return obj, nil
+}
+
+// List returns the objects matching the options, e.g. client.MatchingLabels.
+func (r *Resource[T, L]) List(ctx context.Context, opts ...client.ListOption) (L, error) {
+	objs := r.newList()
+	if err := r.cli.List(ctx, objs, opts...); err != nil {
+		var empty L
+		return empty, err
+	}
+
+	return objs, nil
+}
+
+// Create creates the object, updating it with the response of the API server.
+func (r *Resource[T, L]) Create(ctx context.Context, obj T, opts ...client.CreateOption) error {
+	return r.cli.Create(ctx, obj, opts...)
+}
+
+// Update updates the spec and metadata of the object.
+func (r *Resource[T, L]) Update(ctx context.Context, obj T, opts ...client.UpdateOption) error {
+	return r.cli.Update(ctx, obj, opts...)
+}
+
+// UpdateStatus updates the status of the object.
+func (r *Resource[T, L]) UpdateStatus(ctx context.Context, obj T, opts ...client.SubResourceUpdateOption) error {
+	return r.cli.Status().Update(ctx, obj, opts...)
+}
+
+// Patch patches the object, e.g. with client.MergeFrom.
+func (r *Resource[T, L]) Patch(ctx context.Context, obj T, patch client.Patch, opts ...client.PatchOption) error {
+	return r.cli.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete deletes the object with the given name.
+func (r *Resource[T, L]) Delete(ctx context.Context, name string, opts ...client.DeleteOption) error {
+	obj := r.newObj()
+	obj.SetName(name)
+
+	return r.cli.Delete(ctx, obj, opts...)
+}
+
+// Watch watches the objects matching the options. Events carry objects of type T.
+func (r *Resource[T, L]) Watch(ctx context.Context, opts ...client.ListOption) (watch.Interface, error) {
+	watcher, ok := r.cli.(client.WithWatch)
+	if !ok {
+		return nil, fmt.Errorf("client %T does not support watch", r.cli)
+	}
+
+	return watcher.Watch(ctx, r.newList(), opts...)
+}
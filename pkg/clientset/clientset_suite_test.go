@@ -0,0 +1,13 @@
+package clientset_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClientset(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Clientset suite")
+}
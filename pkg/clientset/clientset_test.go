@@ -0,0 +1,95 @@
+package clientset_test
+
+import (
+	"context"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/clientset"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Typed clients", func() {
+	var (
+		cli  client.WithWatch
+		dscs *clientset.Resource[*dscv1.DataScienceCluster, *dscv1.DataScienceClusterList]
+	)
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithScheme(clientset.Scheme).WithStatusSubresource(&dscv1.DataScienceCluster{}).Build()
+		dscs = clientset.New(cli).DataScienceClusters()
+	})
+
+	It("should create, read, update and delete objects", func(ctx context.Context) {
+		Expect(dscs.Create(ctx, &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}})).To(Succeed())
+
+		dsc, err := dscs.Get(ctx, "default-dsc")
+		Expect(err).ToNot(HaveOccurred())
+		dsc.Labels = map[string]string{"team": "a"}
+		Expect(dscs.Update(ctx, dsc)).To(Succeed())
+		dsc.Status.Phase = "Ready"
+		Expect(dscs.UpdateStatus(ctx, dsc)).To(Succeed())
+
+		list, err := dscs.List(ctx, client.MatchingLabels{"team": "a"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Items).To(ConsistOf(HaveField("Status.Phase", "Ready")))
+
+		Expect(dscs.Delete(ctx, "default-dsc")).To(Succeed())
+		_, err = dscs.Get(ctx, "default-dsc")
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should patch objects", func(ctx context.Context) {
+		dsci := &dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}
+		dscis := clientset.New(cli).DSCInitializations()
+		Expect(dscis.Create(ctx, dsci)).To(Succeed())
+
+		original := dsci.DeepCopy()
+		dsci.Spec.ApplicationsNamespace = "opendatahub"
+		Expect(dscis.Patch(ctx, dsci, client.MergeFrom(original))).To(Succeed())
+
+		patched, err := dscis.Get(ctx, "default-dsci")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patched.Spec.ApplicationsNamespace).To(Equal("opendatahub"))
+	})
+
+	It("should return no object when it is not found", func(ctx context.Context) {
+		dsc, err := dscs.Get(ctx, "missing")
+
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+		Expect(dsc).To(BeNil())
+	})
+
+	It("should watch objects of their type", func(ctx context.Context) {
+		watcher, err := dscs.Watch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(watcher.Stop)
+
+		Expect(dscs.Create(ctx, &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}})).To(Succeed())
+
+		var event watch.Event
+		Eventually(watcher.ResultChan()).Should(Receive(&event))
+		Expect(event.Type).To(Equal(watch.Added))
+		Expect(event.Object).To(BeAssignableToTypeOf(&dscv1.DataScienceCluster{}))
+		Expect(event.Object.(*dscv1.DataScienceCluster).Name).To(Equal("default-dsc"))
+	})
+
+	It("should not watch with clients which do not support it", func(ctx context.Context) {
+		_, err := clientset.New(readOnly{cli}).DataScienceClusters().Watch(ctx)
+
+		Expect(err).To(MatchError(ContainSubstring("does not support watch")))
+	})
+})
+
+// readOnly hides the Watch method of the client it embeds.
+type readOnly struct {
+	client.Client
+}
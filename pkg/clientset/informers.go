@@ -0,0 +1,82 @@
+package clientset
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dataconnectionv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dataconnection/v1alpha1"
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	migrationv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/migration/v1alpha1"
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+)
+
+// Informers gives shared informers of the APIs of the operator, started on first use, and listers reading from them.
+type Informers struct {
+	cache  cache.Cache
+	scheme *runtime.Scheme
+}
+
+// NewInformers returns Informers watching the API server. The scheme of the options defaults to Scheme.
+func NewInformers(cfg *rest.Config, opts cache.Options) (*Informers, error) {
+	if opts.Scheme == nil {
+		opts.Scheme = Scheme
+	}
+	c, err := cache.New(cfg, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	return &Informers{cache: c, scheme: opts.Scheme}, nil
+}
+
+// Start runs the informers until the context is done.
+func (i *Informers) Start(ctx context.Context) error {
+	return i.cache.Start(ctx)
+}
+
+// WaitForCacheSync waits until the informers requested so far are synced, and tells whether they are.
+func (i *Informers) WaitForCacheSync(ctx context.Context) bool {
+	return i.cache.WaitForCacheSync(ctx)
+}
+
+// Listers returns typed clients reading from the informers, writes still go to the API server.
+// Watch is not supported by the listers, use the event handlers of the informers instead.
+func (i *Informers) Listers(cfg *rest.Config) (*Clientset, error) {
+	cli, err := client.New(cfg, client.Options{Scheme: i.scheme, Cache: &client.CacheOptions{Reader: i.cache}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return New(cli), nil
+}
+
+func (i *Informers) DataScienceClusters(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &dscv1.DataScienceCluster{})
+}
+
+func (i *Informers) DSCInitializations(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &dsciv1.DSCInitialization{})
+}
+
+func (i *Informers) FeatureTrackers(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &featurev1.FeatureTracker{})
+}
+
+func (i *Informers) OperatorConfigs(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &operatorconfigv1alpha1.OperatorConfig{})
+}
+
+func (i *Informers) DataConnections(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &dataconnectionv1alpha1.DataConnection{})
+}
+
+func (i *Informers) ModelMeshMigrations(ctx context.Context) (cache.Informer, error) {
+	return i.cache.GetInformer(ctx, &migrationv1alpha1.ModelMeshMigration{})
+}
@@ -0,0 +1,180 @@
+// Package cloud selects the integrations of components with the cloud the cluster runs on: the storage class of the
+// volumes of databases, the annotations of load balancers and the federation of service accounts with cloud identities.
+package cloud
+
+import (
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+// provider holds the defaults of a cloud provider.
+type provider struct {
+	// storageClassName is the storage class of OpenShift installations on the provider backed by its disks
+	storageClassName string
+	// internalLoadBalancer annotations provision load balancers only reachable from the virtual network
+	internalLoadBalancer map[string]string
+	// identityAnnotation is set on service accounts with the cloud identity they are federated with
+	identityAnnotation string
+	// identityPodLabels are required on pods for the identity of their service account to be injected
+	identityPodLabels map[string]string
+	// tokenAudience prefixes the audience of the service account token projected by the workload identity webhook
+	tokenAudience string
+}
+
+var providers = map[configv1.PlatformType]provider{
+	configv1.AWSPlatformType: {
+		identityAnnotation: "eks.amazonaws.com/role-arn",
+		tokenAudience:      "sts.amazonaws.com",
+	},
+	configv1.AzurePlatformType: {
+		storageClassName:     "managed-csi",
+		internalLoadBalancer: map[string]string{"service.beta.kubernetes.io/azure-load-balancer-internal": "true"},
+		identityAnnotation:   "azure.workload.identity/client-id",
+		identityPodLabels:    map[string]string{"azure.workload.identity/use": "true"},
+		tokenAudience:        "api://AzureADTokenExchange",
+	},
+	configv1.GCPPlatformType: {
+		storageClassName:     "standard-csi",
+		internalLoadBalancer: map[string]string{"networking.gke.io/load-balancer-type": "Internal"},
+		identityAnnotation:   "iam.gke.io/gcp-service-account",
+		tokenAudience:        "//iam.googleapis.com/",
+	},
+}
+
+// Integration holds the settings of components specific to the cloud the cluster runs on.
+type Integration struct {
+	// Provider the integrations are selected for, empty when the cloud has none.
+	Provider configv1.PlatformType
+	// StorageClassName is the storage class of the volumes of databases which do not set one, empty for the default
+	// storage class of the cluster.
+	StorageClassName string
+	// LoadBalancerAnnotations are set on the Services of type LoadBalancer deployed by components.
+	LoadBalancerAnnotations map[string]string
+
+	identityAnnotation string
+	identityPodLabels  map[string]string
+	tokenAudience      string
+	identities         map[string]string
+}
+
+// For returns the integrations for the cloud provider set in the DSCInitialization, or the one detected on startup.
+func For(spec *dsciv1.DSCInitializationSpec) Integration {
+	var cloud *dsciv1.CloudSpec
+	if spec != nil {
+		cloud = spec.Cloud
+	}
+
+	return forProvider(cluster.GetPlatformInfo().CloudProvider, cloud)
+}
+
+func forProvider(detected string, cloud *dsciv1.CloudSpec) Integration {
+	if cloud == nil {
+		cloud = &dsciv1.CloudSpec{}
+	}
+	name := configv1.PlatformType(detected)
+	if cloud.Provider != "" {
+		name = configv1.PlatformType(cloud.Provider)
+	}
+	defaults, known := providers[name]
+	integration := Integration{
+		StorageClassName:   defaults.storageClassName,
+		identityAnnotation: defaults.identityAnnotation,
+		identityPodLabels:  defaults.identityPodLabels,
+		tokenAudience:      defaults.tokenAudience,
+		identities:         cloud.WorkloadIdentities,
+	}
+	if known {
+		integration.Provider = name
+	}
+	if cloud.StorageClassName != "" {
+		integration.StorageClassName = cloud.StorageClassName
+	}
+
+	loadBalancerAnnotations := map[string]string{}
+	if cloud.InternalLoadBalancer {
+		for key, value := range defaults.internalLoadBalancer {
+			loadBalancerAnnotations[key] = value
+		}
+	}
+	for key, value := range cloud.LoadBalancerAnnotations {
+		loadBalancerAnnotations[key] = value
+	}
+	if len(loadBalancerAnnotations) != 0 {
+		integration.LoadBalancerAnnotations = loadBalancerAnnotations
+	}
+
+	return integration
+}
+
+// StorageClass returns the storage class set for a volume, or the one of the cloud when it sets none.
+func (i Integration) StorageClass(requested string) string {
+	if requested != "" {
+		return requested
+	}
+
+	return i.StorageClassName
+}
+
+// WorkloadIdentity returns the annotations federating the service accounts of the component with its cloud identity,
+// and the labels its pods require for the identity to be injected. Both are nil when the component has no identity, or
+// the provider does not support workload identities.
+func (i Integration) WorkloadIdentity(componentName string) (map[string]string, map[string]string) {
+	annotations := i.IdentityAnnotations(i.identities[componentName])
+	if annotations == nil {
+		return nil, nil
+	}
+
+	return annotations, i.identityPodLabels
+}
+
+// IdentityAnnotations returns the annotations federating a service account with the cloud identity, nil when the
+// identity is empty or the provider does not support workload identities.
+func (i Integration) IdentityAnnotations(identity string) map[string]string {
+	if identity == "" || i.identityAnnotation == "" {
+		return nil
+	}
+
+	return map[string]string{i.identityAnnotation: identity}
+}
+
+// IdentityPodLabels returns the labels pods require for the identity of their service account to be injected.
+func (i Integration) IdentityPodLabels() map[string]string {
+	return i.identityPodLabels
+}
+
+// TokenProjected tells whether the workload identity webhook of the cloud projected the service account token of the
+// identity in the pod.
+func (i Integration) TokenProjected(pod *corev1.Pod) bool {
+	if i.tokenAudience == "" {
+		return false
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if token := source.ServiceAccountToken; token != nil && strings.HasPrefix(token.Audience, i.tokenAudience) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IdentityAnnotationKeys returns the annotations federating service accounts with the identities of all providers.
+func IdentityAnnotationKeys() []string {
+	keys := make([]string, 0, len(providers))
+	for _, defaults := range providers {
+		keys = append(keys, defaults.identityAnnotation)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,133 @@
+package cloud
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func projectedTokenPod(audience string) *corev1.Pod {
+	return &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+		Name: "token",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: audience, Path: "token"},
+		}}}},
+	}}}}
+}
+
+var _ = Describe("Cloud integrations", func() {
+	DescribeTable("should select the integrations of the provider",
+		func(detected string, cloud *dsciv1.CloudSpec, expected Integration) {
+			integration := forProvider(detected, cloud)
+			Expect(integration.Provider).To(Equal(expected.Provider))
+			Expect(integration.StorageClassName).To(Equal(expected.StorageClassName))
+			Expect(integration.LoadBalancerAnnotations).To(Equal(expected.LoadBalancerAnnotations))
+		},
+		Entry("detected Azure", "Azure", nil,
+			Integration{Provider: configv1.AzurePlatformType, StorageClassName: "managed-csi"}),
+		Entry("detected AWS without storage class nor load balancer annotations", "AWS", &dsciv1.CloudSpec{InternalLoadBalancer: true},
+			Integration{Provider: configv1.AWSPlatformType}),
+		Entry("unknown provider", "BareMetal", &dsciv1.CloudSpec{InternalLoadBalancer: true},
+			Integration{}),
+		Entry("unknown provider with load balancer annotations", "BareMetal",
+			&dsciv1.CloudSpec{LoadBalancerAnnotations: map[string]string{"metallb.universe.tf/address-pool": "internal"}},
+			Integration{LoadBalancerAnnotations: map[string]string{"metallb.universe.tf/address-pool": "internal"}}),
+		Entry("detected provider turned off", "GCP", &dsciv1.CloudSpec{Provider: "None", InternalLoadBalancer: true},
+			Integration{}),
+		Entry("provider set overriding the detected one", "Azure", &dsciv1.CloudSpec{Provider: "GCP"},
+			Integration{Provider: configv1.GCPPlatformType, StorageClassName: "standard-csi"}),
+		Entry("GCP set with internal load balancers", "None",
+			&dsciv1.CloudSpec{
+				Provider:                "GCP",
+				InternalLoadBalancer:    true,
+				LoadBalancerAnnotations: map[string]string{"cloud.google.com/l4-rbs": "enabled"},
+			},
+			Integration{
+				Provider:         configv1.GCPPlatformType,
+				StorageClassName: "standard-csi",
+				LoadBalancerAnnotations: map[string]string{
+					"networking.gke.io/load-balancer-type": "Internal",
+					"cloud.google.com/l4-rbs":              "enabled",
+				},
+			}),
+		Entry("load balancer annotations overriding the internal ones", "Azure",
+			&dsciv1.CloudSpec{
+				InternalLoadBalancer:    true,
+				LoadBalancerAnnotations: map[string]string{"service.beta.kubernetes.io/azure-load-balancer-internal": "false"},
+			},
+			Integration{
+				Provider:                configv1.AzurePlatformType,
+				StorageClassName:        "managed-csi",
+				LoadBalancerAnnotations: map[string]string{"service.beta.kubernetes.io/azure-load-balancer-internal": "false"},
+			}),
+		Entry("Azure with its storage class overridden", "Azure", &dsciv1.CloudSpec{StorageClassName: "managed-csi-premium"},
+			Integration{Provider: configv1.AzurePlatformType, StorageClassName: "managed-csi-premium"}),
+	)
+
+	DescribeTable("should select the storage class of volumes",
+		func(detected, requested, expected string) {
+			Expect(forProvider(detected, nil).StorageClass(requested)).To(Equal(expected))
+		},
+		Entry("storage class of the cloud", "Azure", "", "managed-csi"),
+		Entry("storage class set for the volume", "Azure", "gp3-csi", "gp3-csi"),
+		Entry("default storage class of the cluster", "AWS", "", ""),
+	)
+
+	DescribeTable("should federate the service accounts of components with their cloud identity",
+		func(detected string, identity string, annotations, podLabels map[string]string) {
+			integration := forProvider(detected, &dsciv1.CloudSpec{WorkloadIdentities: map[string]string{"datasciencepipelines": identity}})
+
+			identityAnnotations, identityLabels := integration.WorkloadIdentity("datasciencepipelines")
+			Expect(identityAnnotations).To(Equal(annotations))
+			Expect(identityLabels).To(Equal(podLabels))
+			identityAnnotations, identityLabels = integration.WorkloadIdentity("kserve")
+			Expect(identityAnnotations).To(BeNil())
+			Expect(identityLabels).To(BeNil())
+		},
+		Entry("AWS", "AWS", "arn:aws:iam::123456789012:role/pipelines",
+			map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/pipelines"}, nil),
+		Entry("Azure", "Azure", "00000000-0000-0000-0000-000000000000",
+			map[string]string{"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000000"},
+			map[string]string{"azure.workload.identity/use": "true"}),
+		Entry("GCP", "GCP", "pipelines@project.iam.gserviceaccount.com",
+			map[string]string{"iam.gke.io/gcp-service-account": "pipelines@project.iam.gserviceaccount.com"}, nil),
+		Entry("unknown provider", "BareMetal", "pipelines", nil, nil),
+		Entry("empty identity", "Azure", "", nil, nil),
+	)
+
+	It("should return the labels pods require for the identity to be injected", func() {
+		Expect(forProvider("Azure", nil).IdentityPodLabels()).To(Equal(map[string]string{"azure.workload.identity/use": "true"}))
+		Expect(forProvider("AWS", nil).IdentityPodLabels()).To(BeNil())
+	})
+
+	It("should return the identity annotations of all providers", func() {
+		Expect(IdentityAnnotationKeys()).To(Equal([]string{
+			"azure.workload.identity/client-id",
+			"eks.amazonaws.com/role-arn",
+			"iam.gke.io/gcp-service-account",
+		}))
+	})
+
+	DescribeTable("should tell whether the token of the identity is projected",
+		func(detected string, pod *corev1.Pod, expected bool) {
+			Expect(forProvider(detected, nil).TokenProjected(pod)).To(Equal(expected))
+		},
+		Entry("IAM role on AWS", "AWS", projectedTokenPod("sts.amazonaws.com"), true),
+		Entry("token of another audience on AWS", "AWS", projectedTokenPod("api://AzureADTokenExchange"), false),
+		Entry("pod without token on AWS", "AWS", &corev1.Pod{}, false),
+		Entry("workload identity pool on GCP", "GCP",
+			projectedTokenPod("//iam.googleapis.com/projects/42/locations/global/workloadIdentityPools/ocp/providers/ocp"), true),
+		Entry("managed identity on Azure", "Azure", projectedTokenPod("api://AzureADTokenExchange"), true),
+		Entry("projected volume without token", "Azure", &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}},
+			}}}},
+		}}}}, false),
+		Entry("no provider", "None", projectedTokenPod("sts.amazonaws.com"), false),
+	)
+})
@@ -0,0 +1,94 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// AdoptionPolicy tells how resources to be created which already exist without being owned by the operator, e.g.
+// created by hand, are handled.
+type AdoptionPolicy string
+
+const (
+	// AdoptExisting takes over and updates existing resources.
+	AdoptExisting AdoptionPolicy = "Adopt"
+	// SkipExisting leaves existing resources as they are.
+	SkipExisting AdoptionPolicy = "Skip"
+	// FailOnExisting reports existing resources as an error.
+	FailOnExisting AdoptionPolicy = "Fail"
+)
+
+type adoptionPolicyKey struct{}
+
+// WithAdoptionPolicy sets how existing resources not owned by the operator are handled when applying resources with
+// the returned context. They are adopted when not set.
+func WithAdoptionPolicy(ctx context.Context, policy AdoptionPolicy) context.Context {
+	if policy == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, adoptionPolicyKey{}, policy)
+}
+
+// AdoptionError is returned for an existing resource not owned by the operator when the adoption policy is
+// FailOnExisting.
+type AdoptionError struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (e *AdoptionError) Error() string {
+	return fmt.Sprintf("%s %s/%s already exists and is not managed by the operator, delete it or annotate it with %s=true for the operator to adopt it",
+		e.Kind, e.Namespace, e.Name, annotations.ManagedByODHOperator)
+}
+
+// ShouldAdopt tells whether the existing object is to be updated by the operator. Objects owned by the operator, i.e.
+// labeled with a component, owned by a DataScienceCluster, DSCInitialization or FeatureTracker, or annotated as
+// managed, are always updated. Other ones are handled according to the adoption policy of the context.
+func ShouldAdopt(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	if IsOwnedByOperator(obj) {
+		return true, nil
+	}
+
+	policy, _ := ctx.Value(adoptionPolicyKey{}).(AdoptionPolicy)
+	switch policy {
+	case SkipExisting:
+		logf.FromContext(ctx).Info("skipping existing resource not managed by the operator", "kind", obj.GetKind(),
+			"name", obj.GetName(), "namespace", obj.GetNamespace())
+		return false, nil
+	case FailOnExisting:
+		return false, &AdoptionError{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	default:
+		logf.FromContext(ctx).Info("adopting existing resource not managed by the operator", "kind", obj.GetKind(),
+			"name", obj.GetName(), "namespace", obj.GetNamespace())
+		return true, nil
+	}
+}
+
+// IsOwnedByOperator tells whether the object was created or adopted by the operator.
+func IsOwnedByOperator(obj metav1.Object) bool {
+	if obj.GetAnnotations()[annotations.ManagedByODHOperator] == "true" {
+		return true
+	}
+	for key := range obj.GetLabels() {
+		if strings.HasPrefix(key, labels.ODHAppPrefix+"/") {
+			return true
+		}
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "DataScienceCluster" || ref.Kind == "DSCInitialization" || ref.Kind == "FeatureTracker" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// cachedObjects shows which informers of the operator hold the most objects, to tell which kinds to cache as
+// metadata only or to restrict to fewer namespaces when the memory of the operator grows with the cluster.
+var cachedObjects = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "odh_operator_cache_objects",
+		Help: "Number of objects held in the cache of the operator, labeled by kind and whether only their metadata is cached.",
+	},
+	[]string{"kind", "metadata_only"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(cachedObjects)
+}
+
+// NewCacheWithStats is a cache.NewCacheFunc creating the cache of the manager, and counting the objects held by each
+// of its informers in the odh_operator_cache_objects metric.
+func NewCacheWithStats(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+	c, err := cache.New(config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statsCache{Cache: c, scheme: opts.Scheme, counted: map[cacheStatsKey]bool{}}, nil
+}
+
+type cacheStatsKey struct {
+	gvk          schema.GroupVersionKind
+	metadataOnly bool
+}
+
+// statsCache counts the objects of each informer once it has been started, whether by a watch of a controller or
+// by the first read of the kind from the cache.
+type statsCache struct {
+	cache.Cache
+	scheme *runtime.Scheme
+
+	mu      sync.Mutex
+	counted map[cacheStatsKey]bool
+}
+
+func (c *statsCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Cache.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	c.countObjectsOf(ctx, obj)
+	return nil
+}
+
+func (c *statsCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Cache.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return nil
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	if _, ok := list.(*metav1.PartialObjectMetadataList); ok {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(gvk)
+		c.countObjectsOf(ctx, obj)
+		return nil
+	}
+	if obj, err := c.scheme.New(gvk); err == nil {
+		if obj, ok := obj.(client.Object); ok {
+			c.countObjectsOf(ctx, obj)
+		}
+	}
+	return nil
+}
+
+func (c *statsCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	informer, err := c.Cache.GetInformer(ctx, obj, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if gvk, err := apiutil.GVKForObject(obj, c.scheme); err == nil {
+		c.countObjects(gvk, isMetadataOnly(obj), informer)
+	}
+	return informer, nil
+}
+
+func (c *statsCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	informer, err := c.Cache.GetInformerForKind(ctx, gvk, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.countObjects(gvk, false, informer)
+	return informer, nil
+}
+
+// countObjectsOf counts the objects of the informer the object has just been read from.
+func (c *statsCache) countObjectsOf(ctx context.Context, obj client.Object) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return
+	}
+	if c.isCounted(gvk, isMetadataOnly(obj)) {
+		return
+	}
+	// the informer has been started by the read already, so this only looks it up
+	informer, err := c.Cache.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	if err != nil {
+		return
+	}
+	c.countObjects(gvk, isMetadataOnly(obj), informer)
+}
+
+func (c *statsCache) isCounted(gvk schema.GroupVersionKind, metadataOnly bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counted[cacheStatsKey{gvk: gvk, metadataOnly: metadataOnly}]
+}
+
+// countObjects counts the objects added to and deleted from the informer, once per kind.
+func (c *statsCache) countObjects(gvk schema.GroupVersionKind, metadataOnly bool, informer cache.Informer) {
+	key := cacheStatsKey{gvk: gvk, metadataOnly: metadataOnly}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counted[key] {
+		return
+	}
+	if err := countInformerObjects(informer, cachedObjects.WithLabelValues(gvk.GroupKind().String(), strconv.FormatBool(metadataOnly))); err != nil {
+		return
+	}
+	c.counted[key] = true
+}
+
+func countInformerObjects(informer cache.Informer, gauge prometheus.Gauge) error {
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { gauge.Inc() },
+		DeleteFunc: func(interface{}) { gauge.Dec() },
+	})
+	return err
+}
+
+func isMetadataOnly(obj client.Object) bool {
+	_, ok := obj.(*metav1.PartialObjectMetadata)
+	return ok
+}
+
+type apiReaderKey struct{}
+
+// WithAPIReader sets the reader of resources which are not worth caching, e.g. read from the API server rather than
+// the cache of the manager, for the code reconciling resources with the returned context.
+func WithAPIReader(ctx context.Context, reader client.Reader) context.Context {
+	if reader == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, apiReaderKey{}, reader)
+}
+
+// APIReader returns the reader set with WithAPIReader, or the given client when none is set.
+func APIReader(ctx context.Context, cli client.Reader) client.Reader {
+	if reader, ok := ctx.Value(apiReaderKey{}).(client.Reader); ok {
+		return reader
+	}
+
+	return cli
+}
@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache statistics", func() {
+	var (
+		informers *informertest.FakeInformers
+		c         *statsCache
+	)
+
+	cached := func(kind string, metadataOnly bool) float64 {
+		label := "false"
+		if metadataOnly {
+			label = "true"
+		}
+		return testutil.ToFloat64(cachedObjects.WithLabelValues(kind, label))
+	}
+	informerOf := func(ctx context.Context, obj client.Object) *controllertest.FakeInformer {
+		GinkgoHelper()
+		informer, err := informers.FakeInformerFor(ctx, obj)
+		Expect(err).ToNot(HaveOccurred())
+		return informer
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opendatahub"}}
+	}
+
+	BeforeEach(func() {
+		cachedObjects.Reset()
+		informers = &informertest.FakeInformers{Scheme: clientgoscheme.Scheme}
+		c = &statsCache{Cache: informers, scheme: clientgoscheme.Scheme, counted: map[cacheStatsKey]bool{}}
+	})
+
+	It("should count the objects added to and deleted from an informer", func() {
+		informer := &controllertest.FakeInformer{}
+		c.countObjects(gvk.CustomResourceDefinition, true, informer)
+
+		for _, name := range []string{"workflows.argoproj.io", "odhapplications.dashboard.opendatahub.io"} {
+			informer.Add(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		informer.Delete(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "workflows.argoproj.io"}})
+
+		Expect(cached("CustomResourceDefinition.apiextensions.k8s.io", true)).To(BeEquivalentTo(1))
+	})
+
+	It("should count the objects of a kind once, however many times its informer is looked up", func() {
+		informer := &controllertest.FakeInformer{}
+		c.countObjects(gvk.CustomResourceDefinition, true, informer)
+		c.countObjects(gvk.CustomResourceDefinition, true, informer)
+
+		informer.Add(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "workflows.argoproj.io"}})
+
+		Expect(cached("CustomResourceDefinition.apiextensions.k8s.io", true)).To(BeEquivalentTo(1))
+	})
+
+	It("should count kinds cached as metadata only apart", func() {
+		c.countObjects(gvk.CustomResourceDefinition, true, &controllertest.FakeInformer{})
+		informer := &controllertest.FakeInformer{}
+		c.countObjects(gvk.CustomResourceDefinition, false, informer)
+
+		informer.Add(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "workflows.argoproj.io"}})
+
+		Expect(cached("CustomResourceDefinition.apiextensions.k8s.io", false)).To(BeEquivalentTo(1))
+		Expect(cached("CustomResourceDefinition.apiextensions.k8s.io", true)).To(BeEquivalentTo(0))
+	})
+
+	It("should count the objects of the kinds read from the cache", func(ctx context.Context) {
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "opendatahub", Name: "a"}, &corev1.Secret{})).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "opendatahub", Name: "b"}, &corev1.Secret{})).To(Succeed())
+
+		informerOf(ctx, &corev1.Secret{}).Add(secret("a"))
+
+		Expect(cached("Secret", false)).To(BeEquivalentTo(1))
+	})
+
+	It("should count the objects of the kinds listed from the cache", func(ctx context.Context) {
+		Expect(c.List(ctx, &corev1.ConfigMapList{})).To(Succeed())
+
+		informerOf(ctx, &corev1.ConfigMap{}).Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+
+		Expect(cached("ConfigMap", false)).To(BeEquivalentTo(1))
+	})
+
+	It("should count the objects of the informers started by watches", func(ctx context.Context) {
+		informer, err := c.GetInformer(ctx, &corev1.Secret{})
+		Expect(err).ToNot(HaveOccurred())
+
+		informer.(*controllertest.FakeInformer).Add(secret("a"))
+
+		Expect(cached("Secret", false)).To(BeEquivalentTo(1))
+	})
+
+	It("should not count anything when the informer cannot be started", func(ctx context.Context) {
+		informers.Error = context.DeadlineExceeded
+
+		_, err := c.GetInformer(ctx, &corev1.Secret{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		Expect(c.counted).To(BeEmpty())
+	})
+
+	DescribeTable("should tell objects cached as metadata only",
+		func(obj client.Object, expected bool) {
+			Expect(isMetadataOnly(obj)).To(Equal(expected))
+		},
+		Entry("for PartialObjectMetadata", &metav1.PartialObjectMetadata{}, true),
+		Entry("for typed objects", &corev1.Secret{}, false),
+	)
+})
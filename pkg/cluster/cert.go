@@ -22,6 +22,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// SelfSignedOrganization is the organization in the subject of the self-signed certificates generated by the operator.
+const SelfSignedOrganization = "opendatahub-self-signed"
+
 func CreateSelfSignedCertificate(ctx context.Context, c client.Client, secretName, domain, namespace string, metaOptions ...MetaOptions) error {
 	certSecret, err := GenerateSelfSignedCertificateAsSecret(secretName, domain, namespace)
 	if err != nil {
@@ -74,7 +77,7 @@ func generateCertificate(addr string) ([]byte, []byte, error) {
 		SerialNumber: seededRand,
 		Subject: pkix.Name{
 			CommonName:   addr,
-			Organization: []string{"opendatahub-self-signed"},
+			Organization: []string{SelfSignedOrganization},
 		},
 		NotBefore:             now.UTC(),
 		NotAfter:              now.Add(time.Second * 60 * 60 * 24 * 365).UTC(),
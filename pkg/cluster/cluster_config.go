@@ -16,6 +16,7 @@ import (
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -33,8 +34,10 @@ type Release struct {
 }
 
 var clusterConfig struct {
-	Namespace string
-	Release   Release
+	Namespace      string
+	ServiceAccount string
+	Release        Release
+	Platform       PlatformInfo
 }
 
 // Init initializes cluster configuration variables on startup
@@ -49,11 +52,24 @@ func Init(ctx context.Context, cli client.Client) error {
 		// not fatal, fallback to ""
 	}
 
+	clusterConfig.ServiceAccount = os.Getenv("OPERATOR_SERVICE_ACCOUNT")
+
 	clusterConfig.Release, err = getRelease(ctx, cli)
 	if err != nil {
 		return err
 	}
 
+	clusterConfig.Platform = getPlatformInfo(ctx, cli, clusterConfig.Release.Name)
+	override, err := getPlatformOverride(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if override != Unknown {
+		clusterConfig.Release.Name = override
+		clusterConfig.Platform.Type = override
+		clusterConfig.Platform.Managed = override == ManagedRhods
+	}
+
 	printClusterConfig(log)
 
 	return nil
@@ -62,7 +78,9 @@ func Init(ctx context.Context, cli client.Client) error {
 func printClusterConfig(log logr.Logger) {
 	log.Info("Cluster config",
 		"Namespace", clusterConfig.Namespace,
-		"Release", clusterConfig.Release)
+		"ServiceAccount", clusterConfig.ServiceAccount,
+		"Release", clusterConfig.Release,
+		"Platform", clusterConfig.Platform)
 }
 
 func GetOperatorNamespace() (string, error) {
@@ -72,6 +90,15 @@ func GetOperatorNamespace() (string, error) {
 	return clusterConfig.Namespace, nil
 }
 
+// GetOperatorServiceAccount returns the name of the ServiceAccount the operator runs with,
+// as exposed through OPERATOR_SERVICE_ACCOUNT env variable.
+func GetOperatorServiceAccount() (string, error) {
+	if clusterConfig.ServiceAccount == "" {
+		return "", errors.New("unable to find operator service account")
+	}
+	return clusterConfig.ServiceAccount, nil
+}
+
 func GetRelease() Release {
 	return clusterConfig.Release
 }
@@ -95,6 +122,48 @@ func GetDomain(ctx context.Context, c client.Client) (string, error) {
 	return domain, err
 }
 
+// GetApplicationsNamespace returns the applications namespace of the DSCInitialization. It fails when there is no
+// DSCInitialization, or more than one, the namespace being unknown then. The DSCInitialization is read as a typed object
+// of the scheme of the client, so that it comes from the cache.
+func GetApplicationsNamespace(ctx context.Context, cli client.Client) (string, error) {
+	obj, err := cli.Scheme().New(gvk.DSCInitialization.GroupVersion().WithKind(gvk.DSCInitialization.Kind + "List"))
+	if err != nil {
+		return "", err
+	}
+	list, ok := obj.(client.ObjectList)
+	if !ok {
+		return "", fmt.Errorf("unexpected type %T of DSCInitialization list", obj)
+	}
+	if err := cli.List(ctx, list); err != nil {
+		return "", fmt.Errorf("failed to list DSCInitializations: %w", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return "", err
+	}
+	switch len(items) {
+	case 0:
+		return "", errors.New("no DSCInitialization found to get the applications namespace from")
+	case 1:
+	default:
+		return "", fmt.Errorf("%d DSCInitializations found, only one is expected to get the applications namespace from", len(items))
+	}
+
+	dsci, err := runtime.DefaultUnstructuredConverter.ToUnstructured(items[0])
+	if err != nil {
+		return "", err
+	}
+	namespace, _, err := unstructured.NestedString(dsci, "spec", "applicationsNamespace")
+	if err != nil {
+		return "", err
+	}
+	if namespace == "" {
+		return "", errors.New("applications namespace of the DSCInitialization is not set")
+	}
+
+	return namespace, nil
+}
+
 func getOperatorNamespace() (string, error) {
 	operatorNS, exist := os.LookupEnv("OPERATOR_NAMESPACE")
 	if exist && operatorNS != "" {
@@ -172,22 +241,16 @@ func detectManagedRHODS(ctx context.Context, cli client.Client) (Platform, error
 }
 
 func getPlatform(ctx context.Context, cli client.Client) (Platform, error) {
-	switch os.Getenv("ODH_PLATFORM_TYPE") {
-	case "OpenDataHub":
-		return OpenDataHub, nil
-	case "ManagedRHOAI":
+	if platform, found := platformTypes[os.Getenv("ODH_PLATFORM_TYPE")]; found {
+		return platform, nil
+	}
+	// fall back to detect platform if ODH_PLATFORM_TYPE env is not provided in CSV or set to ""
+	if platform, err := detectManagedRHODS(ctx, cli); err != nil {
+		return Unknown, err
+	} else if platform == ManagedRhods {
 		return ManagedRhods, nil
-	case "SelfManagedRHOAI":
-		return SelfManagedRhods, nil
-	default:
-		// fall back to detect platform if ODH_PLATFORM_TYPE env is not provided in CSV or set to ""
-		if platform, err := detectManagedRHODS(ctx, cli); err != nil {
-			return Unknown, err
-		} else if platform == ManagedRhods {
-			return ManagedRhods, nil
-		}
-		return detectSelfManaged(ctx, cli)
 	}
+	return detectSelfManaged(ctx, cli)
 }
 
 func getRelease(ctx context.Context, cli client.Client) (Release, error) {
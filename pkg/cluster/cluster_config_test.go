@@ -0,0 +1,75 @@
+package cluster_test
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Getting the applications namespace", func() {
+	var objects []client.Object
+
+	dsci := func(name, namespace string) *dsciv1.DSCInitialization {
+		return &dsciv1.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       dsciv1.DSCInitializationSpec{ApplicationsNamespace: namespace},
+		}
+	}
+
+	getApplicationsNamespace := func(ctx context.Context) (string, error) {
+		scheme := runtime.NewScheme()
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		return cluster.GetApplicationsNamespace(ctx, cli)
+	}
+
+	BeforeEach(func() {
+		objects = nil
+	})
+
+	It("should return the namespace of the DSCInitialization", func(ctx context.Context) {
+		objects = append(objects, dsci("default-dsci", "opendatahub"))
+
+		Expect(getApplicationsNamespace(ctx)).To(Equal("opendatahub"))
+	})
+
+	It("should fail without DSCInitialization", func(ctx context.Context) {
+		_, err := getApplicationsNamespace(ctx)
+
+		Expect(err).To(MatchError(ContainSubstring("no DSCInitialization found")))
+	})
+
+	It("should fail with more than one DSCInitialization", func(ctx context.Context) {
+		objects = append(objects, dsci("default-dsci", "opendatahub"), dsci("other-dsci", "other"))
+
+		_, err := getApplicationsNamespace(ctx)
+
+		Expect(err).To(MatchError(ContainSubstring("2 DSCInitializations found")))
+	})
+
+	It("should fail when the namespace is not set", func(ctx context.Context) {
+		objects = append(objects, dsci("default-dsci", ""))
+
+		_, err := getApplicationsNamespace(ctx)
+
+		Expect(err).To(MatchError(ContainSubstring("not set")))
+	})
+
+	It("should fail when the DSCInitialization kind is not in the scheme", func(ctx context.Context) {
+		cli := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+		_, err := cluster.GetApplicationsNamespace(ctx, cli)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -9,6 +9,12 @@ var (
 		Kind:    "ClusterServiceVersion",
 	}
 
+	CustomResourceDefinition = schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	}
+
 	DataScienceCluster = schema.GroupVersionKind{
 		Group:   "datasciencecluster.opendatahub.io",
 		Version: "v1",
@@ -44,6 +50,18 @@ var (
 		Kind:    "ServiceMeshControlPlane",
 	}
 
+	ServiceMeshMember = schema.GroupVersionKind{
+		Group:   "maistra.io",
+		Version: "v1",
+		Kind:    "ServiceMeshMember",
+	}
+
+	VirtualService = schema.GroupVersionKind{
+		Group:   "networking.istio.io",
+		Version: "v1beta1",
+		Kind:    "VirtualService",
+	}
+
 	OdhApplication = schema.GroupVersionKind{
 		Group:   "dashboard.opendatahub.io",
 		Version: "v1",
@@ -67,4 +85,150 @@ var (
 		Version: "v1alpha",
 		Kind:    "OdhDashboardConfig",
 	}
+
+	AcceleratorProfile = schema.GroupVersionKind{
+		Group:   "dashboard.opendatahub.io",
+		Version: "v1",
+		Kind:    "AcceleratorProfile",
+	}
+
+	// ClusterPolicy configures the NVIDIA GPU Operator.
+	ClusterPolicy = schema.GroupVersionKind{
+		Group:   "nvidia.com",
+		Version: "v1",
+		Kind:    "ClusterPolicy",
+	}
+
+	// DeviceConfig configures the AMD GPU Operator.
+	DeviceConfig = schema.GroupVersionKind{
+		Group:   "amd.com",
+		Version: "v1alpha1",
+		Kind:    "DeviceConfig",
+	}
+
+	ExternalSecret = schema.GroupVersionKind{
+		Group:   "external-secrets.io",
+		Version: "v1beta1",
+		Kind:    "ExternalSecret",
+	}
+
+	DataSciencePipelinesApplication = schema.GroupVersionKind{
+		Group:   "datasciencepipelinesapplications.opendatahub.io",
+		Version: "v1alpha1",
+		Kind:    "DataSciencePipelinesApplication",
+	}
+
+	ObjectBucketClaim = schema.GroupVersionKind{
+		Group:   "objectbucket.io",
+		Version: "v1alpha1",
+		Kind:    "ObjectBucketClaim",
+	}
+
+	InferenceService = schema.GroupVersionKind{
+		Group:   "serving.kserve.io",
+		Version: "v1beta1",
+		Kind:    "InferenceService",
+	}
+
+	ServingRuntime = schema.GroupVersionKind{
+		Group:   "serving.kserve.io",
+		Version: "v1alpha1",
+		Kind:    "ServingRuntime",
+	}
+
+	ClusterServingRuntime = schema.GroupVersionKind{
+		Group:   "serving.kserve.io",
+		Version: "v1alpha1",
+		Kind:    "ClusterServingRuntime",
+	}
+
+	Notebook = schema.GroupVersionKind{
+		Group:   "kubeflow.org",
+		Version: "v1",
+		Kind:    "Notebook",
+	}
+
+	RayCluster = schema.GroupVersionKind{
+		Group:   "ray.io",
+		Version: "v1",
+		Kind:    "RayCluster",
+	}
+
+	AppWrapper = schema.GroupVersionKind{
+		Group:   "workload.codeflare.dev",
+		Version: "v1beta2",
+		Kind:    "AppWrapper",
+	}
+
+	KueueWorkload = schema.GroupVersionKind{
+		Group:   "kueue.x-k8s.io",
+		Version: "v1beta1",
+		Kind:    "Workload",
+	}
+
+	ModelRegistry = schema.GroupVersionKind{
+		Group:   "modelregistry.opendatahub.io",
+		Version: "v1alpha1",
+		Kind:    "ModelRegistry",
+	}
+
+	CloudNativePGCluster = schema.GroupVersionKind{
+		Group:   "postgresql.cnpg.io",
+		Version: "v1",
+		Kind:    "Cluster",
+	}
+
+	CrunchyPostgresCluster = schema.GroupVersionKind{
+		Group:   "postgres-operator.crunchydata.com",
+		Version: "v1beta1",
+		Kind:    "PostgresCluster",
+	}
+
+	TrustyAIService = schema.GroupVersionKind{
+		Group:   "trustyai.opendatahub.io",
+		Version: "v1alpha1",
+		Kind:    "TrustyAIService",
+	}
+
+	PolicyReport = schema.GroupVersionKind{
+		Group:   "wgpolicyk8s.io",
+		Version: "v1alpha2",
+		Kind:    "PolicyReport",
+	}
+
+	ClusterClaim = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1alpha1",
+		Kind:    "ClusterClaim",
+	}
+
+	ManagedCluster = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManagedCluster",
+	}
+
+	ManifestWork = schema.GroupVersionKind{
+		Group:   "work.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManifestWork",
+	}
+
+	EgressFirewall = schema.GroupVersionKind{
+		Group:   "k8s.ovn.org",
+		Version: "v1",
+		Kind:    "EgressFirewall",
+	}
+
+	AuthorizationPolicy = schema.GroupVersionKind{
+		Group:   "security.istio.io",
+		Version: "v1beta1",
+		Kind:    "AuthorizationPolicy",
+	}
+
+	PodMetrics = schema.GroupVersionKind{
+		Group:   "metrics.k8s.io",
+		Version: "v1beta1",
+		Kind:    "PodMetrics",
+	}
 )
@@ -1,11 +1,15 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
 // MetaOptions allows to add additional settings for the object being created through a chain
@@ -22,6 +26,13 @@ func ApplyMetaOptions(obj metav1.Object, opts ...MetaOptions) error {
 	return nil
 }
 
+// IsUnmanaged tells whether the object opted out of reconciliation by the operator, by having the
+// "opendatahub.io/managed" annotation or label set to "false". The operator neither updates nor deletes such objects.
+func IsUnmanaged(obj metav1.Object) bool {
+	return obj.GetAnnotations()[annotations.ManagedByODHOperator] == "false" ||
+		obj.GetLabels()[annotations.ManagedByODHOperator] == "false"
+}
+
 func WithOwnerReference(ownerReferences ...metav1.OwnerReference) MetaOptions {
 	return func(obj metav1.Object) error {
 		obj.SetOwnerReferences(ownerReferences)
@@ -83,3 +94,67 @@ func extractKeyValues(keyValues []string) (map[string]string, error) {
 
 	return kvMap, nil
 }
+
+type resourceMetadataKey struct{}
+
+// resourceMetadata holds the labels and annotations set on the resources deployed with a context.
+type resourceMetadata struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// WithResourceMetadata sets the given labels and annotations on every resource deployed with the returned context,
+// e.g. from the manifests of a component or by its features, where the resource does not set them itself. Keys of the
+// opendatahub.io domain are reserved to the operator and left out.
+func WithResourceMetadata(ctx context.Context, labels, annotations map[string]string) context.Context {
+	metadata := resourceMetadata{labels: unreserved(labels), annotations: unreserved(annotations)}
+	if len(metadata.labels) == 0 && len(metadata.annotations) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, resourceMetadataKey{}, metadata)
+}
+
+// ResourceMetadata returns the labels and annotations to set on the resources deployed with the context.
+func ResourceMetadata(ctx context.Context) (map[string]string, map[string]string) {
+	metadata, _ := ctx.Value(resourceMetadataKey{}).(resourceMetadata)
+	return metadata.labels, metadata.annotations
+}
+
+// WithResourceMetadataFrom sets the labels and annotations of the context the object does not set itself.
+func WithResourceMetadataFrom(ctx context.Context) MetaOptions {
+	return func(obj metav1.Object) error {
+		labels, annotations := ResourceMetadata(ctx)
+		obj.SetLabels(withDefaults(obj.GetLabels(), labels))
+		obj.SetAnnotations(withDefaults(obj.GetAnnotations(), annotations))
+		return nil
+	}
+}
+
+func withDefaults(values, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+	result := make(map[string]string, len(values)+len(defaults))
+	for key, value := range defaults {
+		result[key] = value
+	}
+	for key, value := range values {
+		result[key] = value
+	}
+
+	return result
+}
+
+func unreserved(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		domain, _, found := strings.Cut(key, "/")
+		if found && (domain == "opendatahub.io" || strings.HasSuffix(domain, ".opendatahub.io")) {
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
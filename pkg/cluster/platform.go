@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// platformTypes maps the values of the ODH_PLATFORM_TYPE env variable and of the platform override
+// of the DSCInitialization to the platforms.
+var platformTypes = map[string]Platform{
+	"OpenDataHub":      OpenDataHub,
+	"ManagedRHOAI":     ManagedRhods,
+	"SelfManagedRHOAI": SelfManagedRhods,
+}
+
+// PlatformInfo describes the platform the operator runs on, as detected on startup.
+// +kubebuilder:object:generate=true
+type PlatformInfo struct {
+	// Type of platform the operator behaves as, the detected one unless overridden
+	Type Platform `json:"type,omitempty"`
+	// DetectedType is the type of platform found on startup, regardless of the override
+	DetectedType Platform `json:"detectedType,omitempty"`
+	// OpenShiftVersion is the version the cluster was last updated to
+	OpenShiftVersion string `json:"openshiftVersion,omitempty"`
+	// Managed tells whether the platform is a managed service
+	Managed bool `json:"managed"`
+	// CloudProvider is the infrastructure the cluster runs on, e.g. "AWS", "Azure" or "BareMetal"
+	CloudProvider string `json:"cloudProvider,omitempty"`
+}
+
+// GetPlatformInfo returns the platform as detected on startup, with the override of the DSCInitialization applied.
+func GetPlatformInfo() PlatformInfo {
+	return clusterConfig.Platform
+}
+
+// getPlatformInfo completes the detected platform with the details of the cluster. Those are informational,
+// failing to read them is not fatal.
+func getPlatformInfo(ctx context.Context, cli client.Client, platform Platform) PlatformInfo {
+	log := logf.FromContext(ctx)
+	info := PlatformInfo{
+		Type:         platform,
+		DetectedType: platform,
+		Managed:      platform == ManagedRhods,
+	}
+
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion); err != nil {
+		log.Error(err, "unable to find OpenShift version")
+	} else {
+		info.OpenShiftVersion = openShiftVersion(clusterVersion)
+	}
+
+	infrastructure := &configv1.Infrastructure{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: "cluster"}, infrastructure); err != nil {
+		log.Error(err, "unable to find cloud provider")
+	} else if infrastructure.Status.PlatformStatus != nil {
+		info.CloudProvider = string(infrastructure.Status.PlatformStatus.Type)
+	}
+
+	return info
+}
+
+// openShiftVersion returns the version of the latest completed update, or the desired one while the cluster
+// is being installed.
+func openShiftVersion(clusterVersion *configv1.ClusterVersion) string {
+	for _, update := range clusterVersion.Status.History {
+		if update.State == configv1.CompletedUpdate {
+			return update.Version
+		}
+	}
+
+	return clusterVersion.Status.Desired.Version
+}
+
+// getPlatformOverride returns the platform set in spec.platformOverride of the DSCInitialization, if any.
+// The DSCInitialization is read as unstructured, its API depending on this package.
+func getPlatformOverride(ctx context.Context, cli client.Client) (Platform, error) {
+	instances := &unstructured.UnstructuredList{}
+	instances.SetGroupVersionKind(gvk.DSCInitialization)
+	if err := cli.List(ctx, instances); err != nil {
+		if meta.IsNoMatchError(err) { // first install, the CRD is not there yet
+			return Unknown, nil
+		}
+		return Unknown, fmt.Errorf("failed reading platform override: %w", err)
+	}
+
+	for _, instance := range instances.Items {
+		override, _, err := unstructured.NestedString(instance.Object, "spec", "platformOverride")
+		if err != nil || override == "" {
+			continue
+		}
+		platform, found := platformTypes[override]
+		if !found {
+			return Unknown, fmt.Errorf("unknown platform override %q", override)
+		}
+		return platform, nil
+	}
+
+	return Unknown, nil
+}
+
+// PlatformOverridePending tells whether the given override differs from the one applied on startup, the platform
+// being only detected then.
+func PlatformOverridePending(override string) bool {
+	expected := clusterConfig.Platform.DetectedType
+	if override != "" {
+		expected = platformTypes[override]
+	}
+
+	return expected != clusterConfig.Platform.Type
+}
@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func dscInitialization(override string) *unstructured.Unstructured {
+	GinkgoHelper()
+	dsci := &unstructured.Unstructured{}
+	dsci.SetGroupVersionKind(gvk.DSCInitialization)
+	dsci.SetName("default-dsci")
+	if override != "" {
+		Expect(unstructured.SetNestedField(dsci.Object, override, "spec", "platformOverride")).To(Succeed())
+	}
+
+	return dsci
+}
+
+var _ = Describe("Platform detection", func() {
+	Context("getting the details of the platform", func() {
+		var (
+			clusterVersion *configv1.ClusterVersion
+			objects        []client.Object
+		)
+
+		platformInfo := func(ctx context.Context, platform Platform) PlatformInfo {
+			scheme := runtime.NewScheme()
+			Expect(configv1.AddToScheme(scheme)).To(Succeed())
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			return getPlatformInfo(ctx, cli, platform)
+		}
+
+		BeforeEach(func() {
+			clusterVersion = &configv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "version"},
+				Status: configv1.ClusterVersionStatus{
+					Desired: configv1.Release{Version: "4.15.2"},
+					History: []configv1.UpdateHistory{
+						{State: configv1.PartialUpdate, Version: "4.15.2"},
+						{State: configv1.CompletedUpdate, Version: "4.14.10"},
+					},
+				},
+			}
+			objects = []client.Object{
+				clusterVersion,
+				&configv1.Infrastructure{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					Status:     configv1.InfrastructureStatus{PlatformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType}},
+				},
+			}
+		})
+
+		It("should report the last completed OpenShift update and the cloud provider", func(ctx context.Context) {
+			Expect(platformInfo(ctx, ManagedRhods)).To(Equal(PlatformInfo{
+				Type:             ManagedRhods,
+				DetectedType:     ManagedRhods,
+				OpenShiftVersion: "4.14.10",
+				Managed:          true,
+				CloudProvider:    "AWS",
+			}))
+		})
+
+		It("should report the desired version while the cluster is being installed", func(ctx context.Context) {
+			clusterVersion.Status.History = []configv1.UpdateHistory{{State: configv1.PartialUpdate, Version: "4.15.2"}}
+
+			Expect(platformInfo(ctx, OpenDataHub).OpenShiftVersion).To(Equal("4.15.2"))
+		})
+
+		It("should only report the platform when the cluster details cannot be read", func(ctx context.Context) {
+			objects = nil
+
+			Expect(platformInfo(ctx, SelfManagedRhods)).To(Equal(PlatformInfo{Type: SelfManagedRhods, DetectedType: SelfManagedRhods}))
+		})
+	})
+
+	Context("getting the platform override", func() {
+		var (
+			objects []client.Object
+			funcs   interceptor.Funcs
+		)
+
+		override := func(ctx context.Context) (Platform, error) {
+			cli := fake.NewClientBuilder().WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+			return getPlatformOverride(ctx, cli)
+		}
+
+		BeforeEach(func() {
+			objects = nil
+			funcs = interceptor.Funcs{}
+		})
+
+		DescribeTable("should read the override of the DSCInitialization",
+			func(ctx context.Context, value string, expected Platform) {
+				objects = append(objects, dscInitialization(value))
+
+				Expect(override(ctx)).To(Equal(expected))
+			},
+			Entry("when not set", "", Unknown),
+			Entry("for Open Data Hub", "OpenDataHub", OpenDataHub),
+			Entry("for managed OpenShift AI", "ManagedRHOAI", ManagedRhods),
+			Entry("for self-managed OpenShift AI", "SelfManagedRHOAI", SelfManagedRhods),
+		)
+
+		It("should reject unknown platforms", func(ctx context.Context) {
+			objects = append(objects, dscInitialization("Kubernetes"))
+
+			_, err := override(ctx)
+			Expect(err).To(MatchError(ContainSubstring(`unknown platform override "Kubernetes"`)))
+		})
+
+		It("should not be overridden before the DSCInitialization CRD is installed", func(ctx context.Context) {
+			funcs.List = func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return &meta.NoKindMatchError{GroupKind: gvk.DSCInitialization.GroupKind()}
+			}
+
+			Expect(override(ctx)).To(Equal(Unknown))
+		})
+	})
+
+	DescribeTable("should tell whether a change of the override is pending a restart",
+		func(applied Platform, override string, expected bool) {
+			platform := clusterConfig.Platform
+			DeferCleanup(func() { clusterConfig.Platform = platform })
+			clusterConfig.Platform = PlatformInfo{Type: applied, DetectedType: OpenDataHub}
+
+			Expect(PlatformOverridePending(override)).To(Equal(expected))
+		},
+		Entry("unless no override is applied nor set", OpenDataHub, "", false),
+		Entry("when an override is set", OpenDataHub, "SelfManagedRHOAI", true),
+		Entry("unless the override set is applied", SelfManagedRhods, "SelfManagedRHOAI", false),
+		Entry("when the override applied is removed", SelfManagedRhods, "", true),
+	)
+})
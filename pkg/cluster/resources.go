@@ -16,6 +16,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
@@ -181,7 +182,7 @@ func WaitForDeploymentAvailable(ctx context.Context, c client.Client, componentN
 	resourceInterval := time.Duration(interval) * time.Second
 	resourceTimeout := time.Duration(timeout) * time.Minute
 
-	return wait.PollUntilContextTimeout(ctx, resourceInterval, resourceTimeout, true, func(ctx context.Context) (bool, error) {
+	err := wait.PollUntilContextTimeout(ctx, resourceInterval, resourceTimeout, true, func(ctx context.Context) (bool, error) {
 		componentDeploymentList := &appsv1.DeploymentList{}
 		err := c.List(ctx, componentDeploymentList, client.InNamespace(namespace), client.HasLabels{labels.ODH.Component(componentName)})
 		if err != nil {
@@ -197,6 +198,13 @@ func WaitForDeploymentAvailable(ctx context.Context, c client.Client, componentN
 
 		return true, nil
 	})
+	if wait.Interrupted(err) {
+		return status.NewRemediationError(status.RemediationDeploymentNotReady,
+			fmt.Sprintf("Check pods of Deployments labeled %s in namespace %s for scheduling, image pull or crash loop issues",
+				labels.ODH.Component(componentName), namespace), err)
+	}
+
+	return err
 }
 
 func CreateWithRetry(ctx context.Context, cli client.Client, obj client.Object, timeoutMin int) error {
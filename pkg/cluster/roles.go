@@ -36,6 +36,41 @@ func CreateOrUpdateClusterRole(ctx context.Context, cli client.Client, name stri
 	return foundClusterRole, cli.Update(ctx, foundClusterRole)
 }
 
+// CreateOrUpdateAggregatedClusterRole creates cluster role aggregating rules of cluster roles matching the selector,
+// and updates the aggregation rule if it already exists. Rules of the cluster role are managed by the aggregation controller.
+func CreateOrUpdateAggregatedClusterRole(ctx context.Context, cli client.Client, name string, selector metav1.LabelSelector,
+	metaOptions ...MetaOptions) (*rbacv1.ClusterRole, error) {
+	aggregationRule := &rbacv1.AggregationRule{
+		ClusterRoleSelectors: []metav1.LabelSelector{selector},
+	}
+	desiredClusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		AggregationRule: aggregationRule,
+	}
+
+	if err := ApplyMetaOptions(desiredClusterRole, metaOptions...); err != nil {
+		return nil, err
+	}
+
+	foundClusterRole := &rbacv1.ClusterRole{}
+	err := cli.Get(ctx, client.ObjectKeyFromObject(desiredClusterRole), foundClusterRole)
+	if k8serr.IsNotFound(err) {
+		return desiredClusterRole, cli.Create(ctx, desiredClusterRole)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ApplyMetaOptions(foundClusterRole, metaOptions...); err != nil {
+		return nil, err
+	}
+	foundClusterRole.AggregationRule = aggregationRule
+
+	return foundClusterRole, cli.Update(ctx, foundClusterRole)
+}
+
 // DeleteClusterRole simply calls delete on a ClusterRole with the given name. Any error is returned. Check for IsNotFound.
 func DeleteClusterRole(ctx context.Context, cli client.Client, name string) error {
 	desiredClusterRole := &rbacv1.ClusterRole{
@@ -87,3 +122,47 @@ func DeleteClusterRoleBinding(ctx context.Context, cli client.Client, name strin
 
 	return cli.Delete(ctx, desiredClusterRoleBinding)
 }
+
+// GrantOperatorPermissions binds given rules to the operator's ServiceAccount, through a ClusterRole and ClusterRoleBinding
+// of the given name. This is used to hold permissions required only while a particular component is enabled.
+func GrantOperatorPermissions(ctx context.Context, cli client.Client, name string, rules []rbacv1.PolicyRule, metaOptions ...MetaOptions) error {
+	serviceAccount, err := GetOperatorServiceAccount()
+	if err != nil {
+		return err
+	}
+	operatorNs, err := GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+
+	clusterRole, err := CreateOrUpdateClusterRole(ctx, cli, name, rules, metaOptions...)
+	if err != nil {
+		return err
+	}
+
+	subjects := []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccount,
+			Namespace: operatorNs,
+		},
+	}
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     clusterRole.GetName(),
+	}
+	_, err = CreateOrUpdateClusterRoleBinding(ctx, cli, name, subjects, roleRef, metaOptions...)
+
+	return err
+}
+
+// RevokeOperatorPermissions removes ClusterRole and ClusterRoleBinding created by GrantOperatorPermissions.
+// It is a no-op when they do not exist.
+func RevokeOperatorPermissions(ctx context.Context, cli client.Client, name string) error {
+	if err := DeleteClusterRoleBinding(ctx, cli, name); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	return client.IgnoreNotFound(DeleteClusterRole(ctx, cli, name))
+}
@@ -22,6 +22,21 @@ package cluster
 
 import ()
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformInfo) DeepCopyInto(out *PlatformInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformInfo.
+func (in *PlatformInfo) DeepCopy() *PlatformInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
@@ -0,0 +1,88 @@
+// Package componenthooks lets extensions of the operator run steps before and after the manifests of a component are
+// applied, e.g. custom validation, labeling or notifications, without changing the reconciler of the component.
+// Hooks are registered in-process, typically from the init function of a package imported by the operator main package.
+package componenthooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
+
+// Phase tells when a hook runs during the reconciliation of a component.
+type Phase string
+
+const (
+	// PreApply hooks run before the component is reconciled. A failing hook stops the reconciliation of the component,
+	// which is retried with the next reconciliation of the DataScienceCluster.
+	PreApply Phase = "PreApply"
+	// PostApply hooks run once the component has been reconciled successfully. A failing hook fails the reconciliation
+	// of the component.
+	PostApply Phase = "PostApply"
+)
+
+// Func is a hook run for a component, whether it is Managed or Removed. The context carries the logger of the component.
+type Func func(ctx context.Context, cli client.Client, component components.ComponentInterface, dscispec *dsciv1.DSCInitializationSpec) error
+
+type hook struct {
+	name       string
+	phase      Phase
+	components []string
+	run        Func
+}
+
+var (
+	mu    sync.RWMutex
+	hooks []hook
+)
+
+// Register adds a hook run in the given phase for the components with the given names, or for all components when
+// none is given. Hooks of a phase run in registration order. Registering a hook with the name of a hook already
+// registered for the same phase replaces it.
+func Register(name string, phase Phase, run Func, componentNames ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	registered := hook{name: name, phase: phase, components: componentNames, run: run}
+	for i := range hooks {
+		if hooks[i].name == name && hooks[i].phase == phase {
+			hooks[i] = registered
+			return
+		}
+	}
+	hooks = append(hooks, registered)
+}
+
+// Run runs the hooks registered for the phase and the component, stopping at the first failing hook.
+func Run(ctx context.Context, cli client.Client, phase Phase, component components.ComponentInterface, dscispec *dsciv1.DSCInitializationSpec) error {
+	log := logf.FromContext(ctx)
+	for _, h := range registered(phase, component.GetComponentName()) {
+		log.V(1).Info("running component hook", "hook", h.name, "phase", phase)
+		if err := h.run(ctx, cli, component, dscispec); err != nil {
+			return fmt.Errorf("%s hook %s failed: %w", phase, h.name, err)
+		}
+	}
+
+	return nil
+}
+
+func registered(phase Phase, componentName string) []hook {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var matching []hook
+	for _, h := range hooks {
+		if h.phase == phase && (len(h.components) == 0 || slices.Contains(h.components, componentName)) {
+			matching = append(matching, h)
+		}
+	}
+
+	return matching
+}
@@ -0,0 +1,13 @@
+package componenthooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestComponentHooks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Component hooks suite")
+}
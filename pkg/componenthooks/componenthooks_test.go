@@ -0,0 +1,65 @@
+package componenthooks
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Component hooks", func() {
+	var ran []string
+
+	record := func(name string, err error) Func {
+		return func(_ context.Context, _ client.Client, component components.ComponentInterface, _ *dsciv1.DSCInitializationSpec) error {
+			ran = append(ran, name+":"+component.GetComponentName())
+			return err
+		}
+	}
+
+	BeforeEach(func() {
+		ran = nil
+		DeferCleanup(func() { hooks = nil })
+
+		Register("label", PreApply, record("label", nil))
+		Register("validate", PreApply, record("validate", nil), kserve.ComponentName)
+		Register("notify", PostApply, record("notify", nil))
+	})
+
+	It("should run in registration order for the components they are registered for", func(ctx context.Context) {
+		Expect(Run(ctx, nil, PreApply, &dashboard.Dashboard{}, nil)).To(Succeed())
+		Expect(Run(ctx, nil, PreApply, &kserve.Kserve{}, nil)).To(Succeed())
+
+		Expect(ran).To(Equal([]string{"label:dashboard", "label:kserve", "validate:kserve"}))
+	})
+
+	It("should only run in the phase they are registered for", func(ctx context.Context) {
+		Expect(Run(ctx, nil, PostApply, &kserve.Kserve{}, nil)).To(Succeed())
+
+		Expect(ran).To(Equal([]string{"notify:kserve"}))
+	})
+
+	It("should be replaced when registered again with the same name and phase", func(ctx context.Context) {
+		Register("label", PreApply, record("relabel", nil))
+		Register("label", PostApply, record("label-after", nil))
+
+		Expect(Run(ctx, nil, PreApply, &kserve.Kserve{}, nil)).To(Succeed())
+		Expect(Run(ctx, nil, PostApply, &kserve.Kserve{}, nil)).To(Succeed())
+		Expect(ran).To(Equal([]string{"relabel:kserve", "validate:kserve", "notify:kserve", "label-after:kserve"}))
+	})
+
+	It("should stop at the first failing hook", func(ctx context.Context) {
+		Register("label", PreApply, record("label", errors.New("denied")))
+
+		Expect(Run(ctx, nil, PreApply, &kserve.Kserve{}, nil)).To(MatchError("PreApply hook label failed: denied"))
+		Expect(ran).To(Equal([]string{"label:kserve"}))
+	})
+})
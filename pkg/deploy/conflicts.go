@@ -0,0 +1,166 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Conflict is a resource of a component modified by another field manager in fields set by the manifests,
+// so the change is reverted when the manifests are applied.
+type Conflict struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Manager   string
+}
+
+// ConflictRecorder collects conflicts found while deploying manifests of a component.
+type ConflictRecorder struct {
+	mu        sync.Mutex
+	conflicts []Conflict
+}
+
+// Conflicts returns the conflicts recorded so far.
+func (r *ConflictRecorder) Conflicts() []Conflict {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Conflict(nil), r.conflicts...)
+}
+
+func (r *ConflictRecorder) add(conflict Conflict) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conflicts = append(r.conflicts, conflict)
+}
+
+type conflictRecorderKey struct{}
+
+// WithConflictRecorder makes conflicts found while deploying manifests with the returned context available in the recorder.
+func WithConflictRecorder(ctx context.Context, recorder *ConflictRecorder) context.Context {
+	return context.WithValue(ctx, conflictRecorderKey{}, recorder)
+}
+
+// recordConflicts records managers other than the operator which changed fields of the found resource to values
+// differing from the desired ones, as these changes are about to be reverted.
+func recordConflicts(ctx context.Context, componentName string, found, desired *unstructured.Unstructured, fieldOwner string) {
+	recorder, _ := ctx.Value(conflictRecorderKey{}).(*ConflictRecorder)
+	for _, manager := range conflictingManagers(found, desired, fieldOwner) {
+		logf.FromContext(ctx).Info("reverting change of another field manager", "component", componentName, "kind", found.GetKind(),
+			"name", found.GetName(), "namespace", found.GetNamespace(), "manager", manager)
+		revertedChanges.WithLabelValues(componentName, found.GetKind(), manager).Inc()
+		if recorder != nil {
+			recorder.add(Conflict{Kind: found.GetKind(), Namespace: found.GetNamespace(), Name: found.GetName(), Manager: manager})
+		}
+	}
+}
+
+// conflictingManagers returns, sorted by name, field managers of the found resource other than the given owner
+// which own fields set in the desired resource to a different value.
+func conflictingManagers(found, desired *unstructured.Unstructured, fieldOwner string) []string {
+	var managers []string
+	for _, entry := range found.GetManagedFields() {
+		// status and scale subresources are not part of the manifests
+		if entry.Manager == fieldOwner || entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]any{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if changedFields(fields, found.Object, desired.Object) {
+			managers = append(managers, entry.Manager)
+		}
+	}
+	sort.Strings(managers)
+
+	return managers
+}
+
+// changedFields walks the field set of a manager, as found in managedFields, and tells whether any of the fields
+// is set in desired to a value other than the found one. Fields of list items are matched by their keys,
+// sets of values and list items identified by their index are not compared.
+func changedFields(fields map[string]any, found, desired any) bool {
+	for key, value := range fields {
+		var foundChild, desiredChild any
+		var exists bool
+		switch {
+		case strings.HasPrefix(key, "f:"):
+			desiredChild, exists = mapField(desired, strings.TrimPrefix(key, "f:"))
+			foundChild, _ = mapField(found, strings.TrimPrefix(key, "f:"))
+		case strings.HasPrefix(key, "k:"):
+			desiredChild, exists = listItem(desired, strings.TrimPrefix(key, "k:"))
+			foundChild, _ = listItem(found, strings.TrimPrefix(key, "k:"))
+		default:
+			continue
+		}
+		if !exists {
+			continue
+		}
+
+		children, _ := value.(map[string]any)
+		delete(children, ".")
+		if len(children) == 0 {
+			if !equalJSON(foundChild, desiredChild) {
+				return true
+			}
+			continue
+		}
+		if changedFields(children, foundChild, desiredChild) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mapField(obj any, name string) (any, bool) {
+	fields, isMap := obj.(map[string]any)
+	if !isMap {
+		return nil, false
+	}
+	value, exists := fields[name]
+
+	return value, exists
+}
+
+func listItem(obj any, rawKey string) (any, bool) {
+	items, isList := obj.([]any)
+	if !isList {
+		return nil, false
+	}
+	key := map[string]any{}
+	if err := json.Unmarshal([]byte(rawKey), &key); err != nil {
+		return nil, false
+	}
+	for _, item := range items {
+		matches := true
+		for name, keyValue := range key {
+			value, exists := mapField(item, name)
+			if !exists || !equalJSON(value, keyValue) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return item, true
+		}
+	}
+
+	return nil, false
+}
+
+// equalJSON compares values by their JSON representation, as numbers of manifests and of the cluster are decoded to different types.
+func equalJSON(a, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,110 @@
+package deploy
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func managedFields(manager, subresource, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:     manager,
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: subresource,
+		FieldsType:  "FieldsV1",
+		FieldsV1:    &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+var _ = Describe("Conflicting field managers", func() {
+	const (
+		imageFields    = `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"manager\"}":{"f:image":{}}}}}}}`
+		replicasFields = `{"f:spec":{"f:replicas":{}}}`
+	)
+	var desired, found *unstructured.Unstructured
+
+	deployment := func(replicas any, container map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"replicas": replicas,
+				"template": map[string]any{"spec": map[string]any{"containers": []any{container}}},
+			},
+		}}
+		obj.SetKind("Deployment")
+		obj.SetNamespace("opendatahub")
+		obj.SetName("odh-dashboard")
+		return obj
+	}
+
+	BeforeEach(func() {
+		desired = deployment(1, map[string]any{"name": "manager", "image": "quay.io/opendatahub/dashboard:v1"})
+		found = deployment(int64(1), map[string]any{"name": "manager", "image": "quay.io/someone/dashboard:latest", "imagePullPolicy": "Always"})
+	})
+
+	It("should only report managers changing fields set by the manifests", func() {
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{
+			managedFields("default-dsc", "", `{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"manager\"}":{".":{},"f:name":{}}}}}}}`),
+			managedFields("argocd-controller", "", imageFields),
+			managedFields("kubectl-edit", "", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"manager\"}":{"f:imagePullPolicy":{}}}}}}}`),
+		})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(Equal([]string{"argocd-controller"}))
+	})
+
+	It("should not report managers setting fields to the values of the manifests", func() {
+		// numbers of the manifests and of the cluster are decoded to different types
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{managedFields("kubectl-edit", "", replicasFields)})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(BeEmpty())
+	})
+
+	It("should report the managers sorted by name", func() {
+		found.Object["spec"].(map[string]any)["replicas"] = int64(3)
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{
+			managedFields("kubectl-edit", "", replicasFields),
+			managedFields("argocd-controller", "", imageFields),
+		})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(Equal([]string{"argocd-controller", "kubectl-edit"}))
+	})
+
+	It("should ignore the operator itself and subresources", func() {
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{
+			managedFields("default-dsc", "", imageFields),
+			managedFields("kube-controller-manager", "status", imageFields),
+			managedFields("hpa-controller", "scale", imageFields),
+		})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(BeEmpty())
+	})
+
+	It("should ignore list items not found in the manifests", func() {
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{
+			managedFields("istio", "", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"istio-proxy\"}":{"f:image":{}}}}}}}`),
+		})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(BeEmpty())
+	})
+
+	It("should ignore malformed field sets", func() {
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{managedFields("argocd-controller", "", `{"f:spec":`)})
+
+		Expect(conflictingManagers(found, desired, "default-dsc")).To(BeEmpty())
+	})
+
+	It("should record conflicts in the recorder of the context", func() {
+		found.SetManagedFields([]metav1.ManagedFieldsEntry{managedFields("argocd-controller", "", imageFields)})
+		recorder := &ConflictRecorder{}
+
+		recordConflicts(WithConflictRecorder(context.Background(), recorder), "dashboard", found, desired, "default-dsc")
+		recordConflicts(context.Background(), "dashboard", found, desired, "default-dsc")
+
+		Expect(recorder.Conflicts()).To(ConsistOf(Conflict{
+			Kind: "Deployment", Namespace: "opendatahub", Name: "odh-dashboard", Manager: "argocd-controller",
+		}))
+	})
+})
@@ -0,0 +1,227 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// CRDRecorder collects the CRDs with incompatible changes found while deploying manifests of a component.
+type CRDRecorder struct {
+	mu      sync.Mutex
+	updates []status.CRDUpdate
+}
+
+// Updates returns the CRD updates recorded so far, sorted by name.
+func (r *CRDRecorder) Updates() []status.CRDUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updates := append([]status.CRDUpdate(nil), r.updates...)
+	sort.Slice(updates, func(i, j int) bool { return updates[i].Name < updates[j].Name })
+
+	return updates
+}
+
+func (r *CRDRecorder) add(update status.CRDUpdate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updates = append(r.updates, update)
+}
+
+type crdRecorderKey struct{}
+
+// WithCRDRecorder makes CRDs with incompatible changes found while deploying manifests with the returned context
+// available in the recorder.
+func WithCRDRecorder(ctx context.Context, recorder *CRDRecorder) context.Context {
+	return context.WithValue(ctx, crdRecorderKey{}, recorder)
+}
+
+type crdUpdatePolicyKey struct{}
+
+// WithCRDUpdatePolicy sets how CRDs are applied when deploying manifests with the returned context.
+// CRDs are always created and updated when not set.
+func WithCRDUpdatePolicy(ctx context.Context, policy components.CRDUpdatePolicy) context.Context {
+	if policy == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, crdUpdatePolicyKey{}, policy)
+}
+
+func crdUpdatePolicy(ctx context.Context) components.CRDUpdatePolicy {
+	if policy, ok := ctx.Value(crdUpdatePolicyKey{}).(components.CRDUpdatePolicy); ok {
+		return policy
+	}
+
+	return components.CRDUpdateAlways
+}
+
+// shouldUpdateCRD compares the found CRD to the desired one, records incompatible changes, and tells whether the CRD
+// is to be updated according to the policy.
+func shouldUpdateCRD(ctx context.Context, componentName string, found, desired *unstructured.Unstructured) bool {
+	policy := crdUpdatePolicy(ctx)
+	changes := incompatibleCRDChanges(found, desired)
+	update := policy == components.CRDUpdateAlways || (policy == components.CRDUpdateIfCompatible && len(changes) == 0)
+	if len(changes) == 0 {
+		return update
+	}
+
+	logf.FromContext(ctx).Info("incompatible changes in CRD", "component", componentName, "name", found.GetName(),
+		"policy", policy, "applied", update, "changes", changes)
+	if recorder, _ := ctx.Value(crdRecorderKey{}).(*CRDRecorder); recorder != nil {
+		recorder.add(status.CRDUpdate{Name: found.GetName(), Applied: update, Changes: changes})
+	}
+
+	return update
+}
+
+// incompatibleCRDChanges returns, sorted, the changes of the desired CRD which break resources stored with the found
+// one or clients of its served versions: a change of scope, removed versions, and removed properties, changed types,
+// removed enum values or new required properties in the schema of versions served by both.
+func incompatibleCRDChanges(found, desired *unstructured.Unstructured) []string {
+	var changes []string
+	foundScope, _, _ := unstructured.NestedString(found.Object, "spec", "scope")
+	desiredScope, _, _ := unstructured.NestedString(desired.Object, "spec", "scope")
+	if foundScope != desiredScope && desiredScope != "" {
+		changes = append(changes, fmt.Sprintf("scope changes from %s to %s", foundScope, desiredScope))
+	}
+
+	desiredVersions := crdVersions(desired)
+	storedVersions, _, _ := unstructured.NestedStringSlice(found.Object, "status", "storedVersions")
+	for _, version := range storedVersions {
+		if _, exists := desiredVersions[version]; !exists {
+			changes = append(changes, fmt.Sprintf("stored version %s is removed", version))
+		}
+	}
+	for name, version := range crdVersions(found) {
+		if served, _, _ := unstructured.NestedBool(version, "served"); !served {
+			continue
+		}
+		desiredVersion, exists := desiredVersions[name]
+		if served, _, _ := unstructured.NestedBool(desiredVersion, "served"); !exists || !served {
+			changes = append(changes, fmt.Sprintf("version %s is no longer served", name))
+			continue
+		}
+		foundSchema, _, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+		desiredSchema, _, _ := unstructured.NestedMap(desiredVersion, "schema", "openAPIV3Schema")
+		for _, change := range incompatibleSchemaChanges("", foundSchema, desiredSchema) {
+			changes = append(changes, name+": "+change)
+		}
+	}
+	sort.Strings(changes)
+
+	return changes
+}
+
+func crdVersions(crd *unstructured.Unstructured) map[string]map[string]any {
+	versions := map[string]map[string]any{}
+	items, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, item := range items {
+		version, isMap := item.(map[string]any)
+		if !isMap {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(version, "name"); name != "" {
+			versions[name] = version
+		}
+	}
+
+	return versions
+}
+
+// incompatibleSchemaChanges walks the found structural schema and returns the changes of the desired one at the
+// given path breaking resources valid against the found schema.
+func incompatibleSchemaChanges(path string, found, desired map[string]any) []string {
+	var changes []string
+	foundType, _, _ := unstructured.NestedString(found, "type")
+	desiredType, _, _ := unstructured.NestedString(desired, "type")
+	if foundType != desiredType {
+		return []string{fmt.Sprintf("type of %s changes from %s to %s", displayPath(path), foundType, desiredType)}
+	}
+
+	if desiredEnum, hasEnum := nestedValues(desired, "enum"); hasEnum {
+		foundEnum, restricted := nestedValues(found, "enum")
+		var removed []string
+		for _, value := range foundEnum {
+			if !contains(desiredEnum, value) {
+				removed = append(removed, value)
+			}
+		}
+		if !restricted {
+			changes = append(changes, fmt.Sprintf("values of %s are restricted to %s", displayPath(path), strings.Join(desiredEnum, ", ")))
+		} else if len(removed) > 0 {
+			changes = append(changes, fmt.Sprintf("values %s of %s are no longer allowed", strings.Join(removed, ", "), displayPath(path)))
+		}
+	}
+
+	foundRequired, _ := nestedValues(found, "required")
+	desiredRequired, _ := nestedValues(desired, "required")
+	for _, property := range desiredRequired {
+		if !contains(foundRequired, property) {
+			changes = append(changes, fmt.Sprintf("%s becomes required", displayPath(path+"."+property)))
+		}
+	}
+
+	foundProperties, _, _ := unstructured.NestedMap(found, "properties")
+	desiredProperties, _, _ := unstructured.NestedMap(desired, "properties")
+	preservesUnknown, _, _ := unstructured.NestedBool(desired, "x-kubernetes-preserve-unknown-fields")
+	for name, foundProperty := range foundProperties {
+		desiredProperty, exists := desiredProperties[name]
+		if !exists {
+			if !preservesUnknown {
+				changes = append(changes, fmt.Sprintf("%s is removed", displayPath(path+"."+name)))
+			}
+			continue
+		}
+		foundSchema, _ := foundProperty.(map[string]any)
+		desiredSchema, _ := desiredProperty.(map[string]any)
+		changes = append(changes, incompatibleSchemaChanges(path+"."+name, foundSchema, desiredSchema)...)
+	}
+
+	foundItems, hasItems, _ := unstructured.NestedMap(found, "items")
+	desiredItems, _, _ := unstructured.NestedMap(desired, "items")
+	if hasItems {
+		changes = append(changes, incompatibleSchemaChanges(path+"[]", foundItems, desiredItems)...)
+	}
+
+	return changes
+}
+
+// nestedValues returns the values of a list of the schema, e.g. enum or required, as strings.
+func nestedValues(schema map[string]any, field string) ([]string, bool) {
+	items, found, _ := unstructured.NestedSlice(schema, field)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, fmt.Sprint(item))
+	}
+
+	return values, found
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "the resource"
+	}
+
+	return strings.TrimPrefix(path, ".")
+}
@@ -0,0 +1,170 @@
+package deploy
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func crd(versions ...map[string]any) *unstructured.Unstructured {
+	items := make([]any, 0, len(versions))
+	for _, version := range versions {
+		items = append(items, version)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"spec":       map[string]any{"scope": "Namespaced", "versions": items},
+	}}
+	obj.SetName("widgets.example.com")
+
+	return obj
+}
+
+func crdVersion(name string, served bool, spec map[string]any) map[string]any {
+	return map[string]any{
+		"name":   name,
+		"served": served,
+		"schema": map[string]any{"openAPIV3Schema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"spec": spec},
+		}},
+	}
+}
+
+// specSchema returns the schema of a spec with the given properties.
+func specSchema(properties map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+var _ = Describe("CRD updates", func() {
+	var foundSpec map[string]any
+
+	BeforeEach(func() {
+		foundSpec = specSchema(map[string]any{
+			"mode":     map[string]any{"type": "string", "enum": []any{"Fast", "Safe"}},
+			"replicas": map[string]any{"type": "integer"},
+			"legacy":   map[string]any{"type": "string"},
+			"ports":    map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+		})
+	})
+
+	It("should list the incompatible changes, sorted", func() {
+		found := crd(crdVersion("v1alpha1", true, foundSpec), crdVersion("v1", true, foundSpec))
+		Expect(unstructured.SetNestedStringSlice(found.Object, []string{"v1alpha1", "v1"}, "status", "storedVersions")).To(Succeed())
+		desiredSpec := specSchema(map[string]any{
+			"mode":     map[string]any{"type": "string", "enum": []any{"Safe"}},
+			"replicas": map[string]any{"type": "integer"},
+			"ports":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"added":    map[string]any{"type": "string"},
+		})
+		desiredSpec["required"] = []any{"replicas"}
+		desired := crd(crdVersion("v1", true, desiredSpec))
+
+		Expect(incompatibleCRDChanges(found, desired)).To(Equal([]string{
+			"stored version v1alpha1 is removed",
+			"v1: spec.legacy is removed",
+			"v1: spec.replicas becomes required",
+			"v1: type of spec.ports[] changes from integer to string",
+			"v1: values Fast of spec.mode are no longer allowed",
+			"version v1alpha1 is no longer served",
+		}))
+	})
+
+	It("should report a change of scope", func() {
+		desired := crd(crdVersion("v1", true, foundSpec))
+		Expect(unstructured.SetNestedField(desired.Object, "Cluster", "spec", "scope")).To(Succeed())
+
+		Expect(incompatibleCRDChanges(crd(crdVersion("v1", true, foundSpec)), desired)).To(Equal([]string{
+			"scope changes from Namespaced to Cluster",
+		}))
+	})
+
+	It("should report values newly restricted to an enum", func() {
+		desired := crd(crdVersion("v1", true, specSchema(map[string]any{
+			"mode":     map[string]any{"type": "string", "enum": []any{"Fast", "Safe"}},
+			"replicas": map[string]any{"type": "integer", "enum": []any{int64(1), int64(3)}},
+			"legacy":   map[string]any{"type": "string"},
+			"ports":    map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+		})))
+
+		Expect(incompatibleCRDChanges(crd(crdVersion("v1", true, foundSpec)), desired)).To(Equal([]string{
+			"v1: values of spec.replicas are restricted to 1, 3",
+		}))
+	})
+
+	DescribeTable("should accept compatible changes",
+		func(found, desired func(foundSpec map[string]any) *unstructured.Unstructured) {
+			Expect(incompatibleCRDChanges(found(foundSpec), desired(foundSpec))).To(BeEmpty())
+		},
+		Entry("when new versions are served",
+			func(spec map[string]any) *unstructured.Unstructured { return crd(crdVersion("v1", true, spec)) },
+			func(spec map[string]any) *unstructured.Unstructured {
+				return crd(crdVersion("v1alpha1", false, spec), crdVersion("v1", true, spec), crdVersion("v2", true, map[string]any{"type": "object"}))
+			}),
+		Entry("when versions no longer served are changed",
+			func(spec map[string]any) *unstructured.Unstructured {
+				return crd(crdVersion("v1alpha1", false, spec), crdVersion("v1", true, spec))
+			},
+			func(spec map[string]any) *unstructured.Unstructured {
+				return crd(crdVersion("v1alpha1", false, map[string]any{"type": "object"}), crdVersion("v1", true, spec))
+			}),
+		Entry("when optional properties are added",
+			func(spec map[string]any) *unstructured.Unstructured { return crd(crdVersion("v1", true, spec)) },
+			func(spec map[string]any) *unstructured.Unstructured {
+				properties := map[string]any{"added": map[string]any{"type": "string"}}
+				for name, property := range spec["properties"].(map[string]any) {
+					properties[name] = property
+				}
+				return crd(crdVersion("v1", true, specSchema(properties)))
+			}),
+		Entry("when removed properties are preserved as unknown fields",
+			func(spec map[string]any) *unstructured.Unstructured { return crd(crdVersion("v1", true, spec)) },
+			func(spec map[string]any) *unstructured.Unstructured {
+				preserved := specSchema(map[string]any{})
+				preserved["x-kubernetes-preserve-unknown-fields"] = true
+				return crd(crdVersion("v1", true, preserved))
+			}),
+	)
+
+	DescribeTable("should apply the CRD update policy",
+		func(ctx context.Context, policy components.CRDUpdatePolicy, compatible, update bool) {
+			found := crd(crdVersion("v1", true, foundSpec))
+			desired := found
+			if !compatible {
+				desired = crd(crdVersion("v1", true, map[string]any{"type": "object"}))
+			}
+			recorder := &CRDRecorder{}
+			ctx = WithCRDRecorder(WithCRDUpdatePolicy(ctx, policy), recorder)
+
+			Expect(shouldUpdateCRD(ctx, "kserve", found, desired)).To(Equal(update))
+
+			if compatible {
+				Expect(recorder.Updates()).To(BeEmpty())
+			} else {
+				Expect(recorder.Updates()).To(HaveExactElements(And(
+					HaveField("Name", "widgets.example.com"),
+					HaveField("Applied", update),
+					HaveField("Changes", Not(BeEmpty())),
+				)))
+			}
+		},
+		Entry("updating incompatible CRDs by default", components.CRDUpdatePolicy(""), false, true),
+		Entry("updating incompatible CRDs when always updated", components.CRDUpdateAlways, false, true),
+		Entry("keeping incompatible CRDs when only compatible ones are updated", components.CRDUpdateIfCompatible, false, false),
+		Entry("updating compatible CRDs when only compatible ones are updated", components.CRDUpdateIfCompatible, true, true),
+		Entry("never updating compatible CRDs", components.CRDUpdateNever, true, false),
+		Entry("never updating incompatible CRDs", components.CRDUpdateNever, false, false),
+	)
+
+	It("should not require a recorder", func(ctx context.Context) {
+		ctx = WithCRDUpdatePolicy(ctx, components.CRDUpdateIfCompatible)
+
+		Expect(shouldUpdateCRD(ctx, "kserve", crd(crdVersion("v1", true, foundSpec)), crd(crdVersion("v1", true, map[string]any{"type": "object"})))).To(BeFalse())
+	})
+})
@@ -19,8 +19,10 @@ package deploy
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +32,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/maps"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -39,16 +42,20 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/kustomize/api/krusty"
 	"sigs.k8s.io/kustomize/api/resmap"
 	"sigs.k8s.io/kustomize/api/resource"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/conversion"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 )
 
 var (
@@ -57,7 +64,9 @@ var (
 
 // DownloadManifests function performs following tasks:
 // 1. It takes component URI and only downloads folder specified by component.ContextDir field
-// 2. It saves the manifests in the odh-manifests/component-name/ folder.
+// 2. It verifies the digest of the tarball against component.Digest, if set
+// 3. It backs up the embedded manifests of the component, to restore them when its devFlags are reset
+// 4. It saves the manifests in the odh-manifests/component-name/ folder.
 func DownloadManifests(ctx context.Context, componentName string, manifestConfig components.ManifestsConfig) error {
 	// Get the component repo from the given url
 	// e.g.  https://github.com/example/tarball/master
@@ -75,8 +84,30 @@ func DownloadManifests(ctx context.Context, componentName string, manifestConfig
 		return fmt.Errorf("error downloading manifests: %v HTTP status", resp.StatusCode)
 	}
 
+	// The tarball is verified before anything gets extracted over the existing manifests
+	tarball, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error downloading manifests: %w", err)
+	}
+	sum := sha256.Sum256(tarball)
+	provenance := status.ManifestsProvenance{Path: componentName, Source: manifestConfig.URI, Digest: digestOf(sum[:])}
+	if manifestConfig.Digest != "" {
+		provenance.Verified = provenance.Digest == manifestConfig.Digest
+		if !provenance.Verified && !manifestConfig.AllowDigestMismatch {
+			return fmt.Errorf("digest %s of manifests downloaded from %s does not match %s", provenance.Digest, manifestConfig.URI, manifestConfig.Digest)
+		}
+		if !provenance.Verified {
+			logf.FromContext(ctx).Info("deploying manifests not matching their digest", "uri", manifestConfig.URI,
+				"digest", provenance.Digest, "expected", manifestConfig.Digest)
+		}
+	}
+
+	if err := backupEmbeddedManifests(componentName); err != nil {
+		return err
+	}
+
 	// Create a new gzip reader
-	gzipReader, err := gzip.NewReader(resp.Body)
+	gzipReader, err := gzip.NewReader(bytes.NewReader(tarball))
 	if err != nil {
 		return fmt.Errorf("error creating gzip reader: %w", err)
 	}
@@ -143,6 +174,7 @@ func DownloadManifests(ctx context.Context, componentName string, manifestConfig
 			}
 		}
 	}
+	recordBundle(provenance)
 
 	return err
 }
@@ -156,46 +188,293 @@ func DeployManifestsFromPath(
 	componentName string,
 	componentEnabled bool,
 ) error {
+	renderCtx, renderSpan := tracing.Start(ctx, "render manifests",
+		attribute.String("component", componentName), attribute.String("manifests.path", manifestPath))
+	resMap, manifestPath, err := renderManifests(renderCtx, manifestPath, namespace, componentName, componentEnabled)
+	tracing.End(renderSpan, err)
+	if err != nil {
+		return err
+	}
+
+	recordImages(manifestPath, resMap, componentEnabled)
+	if componentEnabled {
+		recordManifests(ctx, manifestPath)
+	}
+
+	// Publishing is a debugging aid only, it should never block deployment of the component
+	if err := publishRenderedManifests(ctx, cli, owner, resMap, namespace, componentName, manifestPath, componentEnabled); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to publish rendered manifests", "component", componentName)
+	}
+
+	applyCtx, applySpan := tracing.Start(ctx, "apply manifests",
+		attribute.String("component", componentName), attribute.String("manifests.path", manifestPath),
+		attribute.Int("resources", resMap.Size()), attribute.Bool("enabled", componentEnabled))
+	err = applyManifests(applyCtx, cli, owner, resMap, manifestPath, namespace, componentName, componentEnabled)
+	tracing.End(applySpan, err)
+
+	return err
+}
+
+// renderManifests renders the Kustomize manifests of the path, or of its default overlay, for the component, and
+// tells the path they were rendered from.
+func renderManifests(ctx context.Context, manifestPath, namespace, componentName string, componentEnabled bool) (resmap.ResMap, string, error) {
 	// Render the Kustomize manifests
 	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
 	fs := filesys.MakeFsOnDisk()
 	// Create resmap
 	// Use kustomization file under manifestPath or use `default` overlay
-	var resMap resmap.ResMap
 	_, err := os.Stat(filepath.Join(manifestPath, "kustomization.yaml"))
 	if err != nil {
 		if !os.IsNotExist(err) {
-			return err
+			return nil, "", err
 		}
 		manifestPath = filepath.Join(manifestPath, "default")
 	}
 
-	resMap, err = k.Run(fs, manifestPath)
+	// custom manifests are validated before any of their resources are applied, not to leave the component half
+	// deployed; removing a component deletes whatever it finds
+	customManifests := componentEnabled && isCustomManifests(manifestPath)
+	resMap, err := k.Run(fs, manifestPath)
 	if err != nil {
-		return err
+		if customManifests {
+			return nil, "", invalidManifests(manifestPath, fmt.Sprintf("kustomize build failed: %v", err))
+		}
+		return nil, "", err
+	}
+
+	// budgets are added first, so they get the namespace and labels of the component
+	for _, pdbPlugin := range podDisruptionBudgets(ctx) {
+		if err := pdbPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying PodDisruptionBudget plugin when preparing Kustomize resources. %w", err)
+		}
+	}
+
+	// labels and annotations of the DataScienceCluster are set first, not to override the ones of the component
+	if resourceLabels, resourceAnnotations := cluster.ResourceMetadata(ctx); len(resourceLabels) != 0 || len(resourceAnnotations) != 0 {
+		metadataPlugin := &plugins.MetadataPlugin{Labels: resourceLabels, Annotations: resourceAnnotations}
+		if err := metadataPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying metadata plugin when preparing Kustomize resources. %w", err)
+		}
 	}
 
 	nsPlugin := plugins.CreateNamespaceApplierPlugin(namespace)
 	if err := nsPlugin.Transform(resMap); err != nil {
-		return fmt.Errorf("failed applying namespace plugin when preparing Kustomize resources. %w", err)
+		return nil, "", fmt.Errorf("failed applying namespace plugin when preparing Kustomize resources. %w", err)
 	}
 
 	labelsPlugin := plugins.CreateAddLabelsPlugin(componentName)
 	if err := labelsPlugin.Transform(resMap); err != nil {
-		return fmt.Errorf("failed applying labels plugin when preparing Kustomize resources. %w", err)
+		return nil, "", fmt.Errorf("failed applying labels plugin when preparing Kustomize resources. %w", err)
+	}
+
+	for _, envPlugin := range deploymentEnv(ctx) {
+		if err := envPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying env plugin when preparing Kustomize resources. %w", err)
+		}
 	}
 
+	for _, argsPlugin := range deploymentArgs(ctx) {
+		if err := argsPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying args plugin when preparing Kustomize resources. %w", err)
+		}
+	}
+
+	if securityContextPlugin := securityContext(ctx); securityContextPlugin != nil {
+		if err := securityContextPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying security context plugin when preparing Kustomize resources. %w", err)
+		}
+	}
+
+	if oauthClientPlugin := oauthClients(ctx); oauthClientPlugin != nil {
+		if err := oauthClientPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying OAuthClient plugin when preparing Kustomize resources. %w", err)
+		}
+	}
+
+	if cloudPlugin := cloudIntegration(ctx); cloudPlugin != nil {
+		if err := cloudPlugin.Transform(resMap); err != nil {
+			return nil, "", fmt.Errorf("failed applying cloud plugin when preparing Kustomize resources. %w", err)
+		}
+	}
+
+	if customManifests {
+		if err := validateManifests(manifestPath, resMap, namespace); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return resMap, manifestPath, nil
+}
+
+// applyManifests creates, updates or deletes the rendered resources in the cluster.
+func applyManifests(ctx context.Context, cli client.Client, owner metav1.Object, resMap resmap.ResMap,
+	manifestPath, namespace, componentName string, componentEnabled bool,
+) error {
 	// Create / apply / delete resources in the cluster
-	for _, res := range resMap.Resources() {
-		err = manageResource(ctx, cli, res, owner, namespace, componentName, componentEnabled)
+	throttle := applyThrottleFrom(ctx)
+	resCli := throttle.client(cli)
+	resources := resMap.Resources()
+	for i, res := range resources {
+		err := manageResource(ctx, resCli, res, owner, namespace, componentName, componentEnabled)
 		if err != nil {
 			return err
 		}
+		// the applications namespace is skipped by manageResource, it does not belong to the component
+		if componentEnabled && (res.GetKind() != "Namespace" || res.GetName() != namespace) {
+			recordResource(ctx, res)
+		}
+		if componentEnabled {
+			throttle.batchApplied(manifestPath, i+1, len(resources))
+		}
 	}
 
 	return nil
 }
 
+type deploymentEnvKey struct{}
+
+// WithDeploymentEnv sets environment variables in containers of the Deployment with the given name when deploying
+// manifests with the returned context, overriding the values of the manifests.
+func WithDeploymentEnv(ctx context.Context, deploymentName string, env map[string]string) context.Context {
+	if len(env) == 0 {
+		return ctx
+	}
+	// copied, so contexts derived from the same parent do not share plugins
+	envPlugins := append([]*plugins.EnvPlugin{}, deploymentEnv(ctx)...)
+	envPlugins = append(envPlugins, &plugins.EnvPlugin{DeploymentName: deploymentName, Env: env})
+
+	return context.WithValue(ctx, deploymentEnvKey{}, envPlugins)
+}
+
+func deploymentEnv(ctx context.Context) []*plugins.EnvPlugin {
+	envPlugins, _ := ctx.Value(deploymentEnvKey{}).([]*plugins.EnvPlugin)
+	return envPlugins
+}
+
+type deploymentArgsKey struct{}
+
+// WithDeploymentArgs sets command line flags of the main container of the Deployment with the given name when
+// deploying manifests with the returned context, overriding the flags of the manifests.
+func WithDeploymentArgs(ctx context.Context, deploymentName string, args map[string][]string) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+	argsPlugins := append([]*plugins.ArgsPlugin{}, deploymentArgs(ctx)...)
+	argsPlugins = append(argsPlugins, &plugins.ArgsPlugin{DeploymentName: deploymentName, Args: args})
+
+	return context.WithValue(ctx, deploymentArgsKey{}, argsPlugins)
+}
+
+func deploymentArgs(ctx context.Context) []*plugins.ArgsPlugin {
+	argsPlugins, _ := ctx.Value(deploymentArgsKey{}).([]*plugins.ArgsPlugin)
+	return argsPlugins
+}
+
+// WithLogLevel sets the log level of the Deployments of a component, through the environment variable or the command
+// line flag each of them reads it from, when deploying manifests with the returned context.
+func WithLogLevel(ctx context.Context, level components.LogLevel, logging []components.DeploymentLogging) context.Context {
+	if level == "" {
+		return ctx
+	}
+	value := strings.ToLower(string(level))
+	for _, deployment := range logging {
+		if deployment.Env != "" {
+			ctx = WithDeploymentEnv(ctx, deployment.Deployment, map[string]string{deployment.Env: value})
+		}
+		if deployment.Flag != "" {
+			ctx = WithDeploymentArgs(ctx, deployment.Deployment, map[string][]string{deployment.Flag: {value}})
+		}
+	}
+
+	return ctx
+}
+
+type securityContextKey struct{}
+
+// WithSecurityContext sets the security context of the pods of the Deployments of a component, and the
+// SecurityContextConstraints they require, when deploying manifests with the returned context.
+func WithSecurityContext(ctx context.Context, securityContext *components.SecurityContext) context.Context {
+	if securityContext == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, securityContextKey{}, &plugins.SecurityContextPlugin{
+		Restricted:     securityContext.PodSecurityLevel == components.PodSecurityRestricted,
+		SCCAnnotation:  components.RequiredSCCAnnotation,
+		SCC:            securityContext.SCC,
+		RunAsUser:      securityContext.RunAsUser,
+		RunAsGroup:     securityContext.RunAsGroup,
+		FSGroup:        securityContext.FSGroup,
+		SeccompProfile: securityContext.SeccompProfile,
+	})
+}
+
+func securityContext(ctx context.Context) *plugins.SecurityContextPlugin {
+	securityContextPlugin, _ := ctx.Value(securityContextKey{}).(*plugins.SecurityContextPlugin)
+	return securityContextPlugin
+}
+
+type cloudIntegrationKey struct{}
+
+// WithCloudIntegration sets the annotations of the Services of type LoadBalancer and of the ServiceAccounts of a
+// component, and the labels of the pods of its Deployments, when deploying manifests with the returned context.
+func WithCloudIntegration(ctx context.Context, loadBalancerAnnotations, serviceAccountAnnotations, podLabels map[string]string) context.Context {
+	if len(loadBalancerAnnotations) == 0 && len(serviceAccountAnnotations) == 0 && len(podLabels) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, cloudIntegrationKey{}, &plugins.CloudPlugin{
+		LoadBalancerAnnotations:   loadBalancerAnnotations,
+		ServiceAccountAnnotations: serviceAccountAnnotations,
+		PodLabels:                 podLabels,
+	})
+}
+
+func cloudIntegration(ctx context.Context) *plugins.CloudPlugin {
+	cloudPlugin, _ := ctx.Value(cloudIntegrationKey{}).(*plugins.CloudPlugin)
+	return cloudPlugin
+}
+
+type podDisruptionBudgetKey struct{}
+
+// WithPodDisruptionBudget adds a PodDisruptionBudget for each of the Deployments with the given names running more than
+// one replica when deploying manifests with the returned context, allowing one pod at a time to be disrupted unless
+// overridden by the given budget.
+func WithPodDisruptionBudget(ctx context.Context, budget *components.PodDisruptionBudget, deploymentNames ...string) context.Context {
+	pdbPlugin := &plugins.PodDisruptionBudgetPlugin{DeploymentNames: deploymentNames}
+	if budget != nil {
+		pdbPlugin.MinAvailable = budget.MinAvailable
+		pdbPlugin.MaxUnavailable = budget.MaxUnavailable
+	}
+	pdbPlugins := append([]*plugins.PodDisruptionBudgetPlugin{}, podDisruptionBudgets(ctx)...)
+	pdbPlugins = append(pdbPlugins, pdbPlugin)
+
+	return context.WithValue(ctx, podDisruptionBudgetKey{}, pdbPlugins)
+}
+
+func podDisruptionBudgets(ctx context.Context) []*plugins.PodDisruptionBudgetPlugin {
+	pdbPlugins, _ := ctx.Value(podDisruptionBudgetKey{}).([]*plugins.PodDisruptionBudgetPlugin)
+	return pdbPlugins
+}
+
+type oauthClientsKey struct{}
+
+// WithOAuthClients leaves the Secrets of the OAuthClients with the given names to the OAuthClient controller when
+// deploying manifests with the returned context, removing their secret generator annotations.
+func WithOAuthClients(ctx context.Context, oauthClients []components.OAuthClient) context.Context {
+	oauthClientPlugin := &plugins.OAuthClientPlugin{}
+	for _, oauthClient := range oauthClients {
+		oauthClientPlugin.Names = append(oauthClientPlugin.Names, oauthClient.Name)
+	}
+
+	return context.WithValue(ctx, oauthClientsKey{}, oauthClientPlugin)
+}
+
+func oauthClients(ctx context.Context) *plugins.OAuthClientPlugin {
+	oauthClientPlugin, _ := ctx.Value(oauthClientsKey{}).(*plugins.OAuthClientPlugin)
+	return oauthClientPlugin
+}
+
 func manageResource(ctx context.Context, cli client.Client, res *resource.Resource, owner metav1.Object, applicationNamespace, componentName string, enabled bool) error {
 	// Return if resource is of Kind: Namespace and Name: applicationsNamespace
 	if res.GetKind() == "Namespace" && res.GetName() == applicationNamespace {
@@ -206,14 +485,21 @@ func manageResource(ctx context.Context, cli client.Client, res *resource.Resour
 
 	if err == nil {
 		// when resource is found
+		// do not reconcile resources opted out with "opendatahub.io/managed: false", neither updating nor deleting them
+		if cluster.IsUnmanaged(found) {
+			logf.FromContext(ctx).V(1).Info("skipping unmanaged resource", "component", componentName, "kind", found.GetKind(),
+				"name", found.GetName(), "namespace", found.GetNamespace())
+			return nil
+		}
 		if enabled {
-			// Exception to not update kserve with managed annotation
-			// do not reconcile kserve resource with annotation "opendatahub.io/managed: false"
-			// TODO: remove this exception when we define managed annotation across odh
-			if found.GetAnnotations()[annotations.ManagedByODHOperator] == "false" && componentName == "kserve" {
-				return nil
+			// resources created by others are handled according to the adoption policy of the component, CRDs according
+			// to its CRD update policy
+			if found.GetKind() != "CustomResourceDefinition" {
+				if adopt, err := cluster.ShouldAdopt(ctx, found); err != nil || !adopt {
+					return err
+				}
 			}
-			return updateResource(ctx, cli, res, found, owner)
+			return updateResource(ctx, cli, res, found, owner, componentName)
 		}
 		// Delete resource if it exists or do nothing if not found
 		return handleDisabledComponent(ctx, cli, found, componentName)
@@ -225,7 +511,7 @@ func manageResource(ctx context.Context, cli client.Client, res *resource.Resour
 
 	// Create resource when component enabled
 	if enabled {
-		return createResource(ctx, cli, res, owner)
+		return createResource(ctx, cli, res, owner, componentName)
 	}
 	// Skip if resource doesn't exist and component is disabled
 	return nil
@@ -275,21 +561,35 @@ func deleteResource(ctx context.Context, cli client.Client, found *unstructured.
 	return nil
 }
 
-func createResource(ctx context.Context, cli client.Client, res *resource.Resource, owner metav1.Object) error {
+func createResource(ctx context.Context, cli client.Client, res *resource.Resource, owner metav1.Object, componentName string) error {
 	obj, err := conversion.ResourceToUnstructured(res)
 	if err != nil {
 		return err
 	}
+	if obj.GetKind() == "CustomResourceDefinition" && crdUpdatePolicy(ctx) == components.CRDUpdateNever {
+		logf.FromContext(ctx).Info("skipping missing CRD, it is to be installed beforehand", "component", componentName, "name", obj.GetName())
+		return nil
+	}
 	if obj.GetKind() != "CustomResourceDefinition" && obj.GetKind() != "OdhDashboardConfig" {
 		if err := ctrl.SetControllerReference(owner, metav1.Object(obj), cli.Scheme()); err != nil {
 			return err
 		}
 	}
-	return cli.Create(ctx, obj)
+	if err := cli.Create(ctx, obj); err != nil {
+		return err
+	}
+	if isComponentInstalled(ctx) {
+		logf.FromContext(ctx).Info("restored missing resource", "component", componentName, "kind", obj.GetKind(),
+			"name", obj.GetName(), "namespace", obj.GetNamespace())
+		restoredResources.WithLabelValues(componentName, obj.GetKind()).Inc()
+	}
+	return nil
 }
 
 // Exception to skip ODHDashboardConfig CR reconcile.
-func updateResource(ctx context.Context, cli client.Client, res *resource.Resource, found *unstructured.Unstructured, owner metav1.Object) error {
+func updateResource(ctx context.Context, cli client.Client, res *resource.Resource, found *unstructured.Unstructured, owner metav1.Object,
+	componentName string,
+) error {
 	if found.GetKind() == "OdhDashboardConfig" {
 		return nil
 	}
@@ -307,9 +607,17 @@ func updateResource(ctx context.Context, cli client.Client, res *resource.Resour
 		return err
 	}
 
+	// CRDs are only updated as allowed by the policy of the component, changes may break the resources they store
+	if obj.GetKind() == "CustomResourceDefinition" && !shouldUpdateCRD(ctx, componentName, found, obj) {
+		return nil
+	}
+
 	// Retain existing labels on update
 	updateLabels(found, obj)
 
+	// Changes of other controllers to fields set by the manifests are about to be reverted, make them visible
+	recordConflicts(ctx, componentName, found, obj, owner.GetName())
+
 	return performPatch(ctx, cli, obj, found, owner)
 }
 
@@ -0,0 +1,154 @@
+package deploy
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resource"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managing resources", func() {
+	var (
+		res      *resource.Resource
+		owner    *corev1.ConfigMap
+		existing *corev1.ConfigMap
+		cli      client.Client
+	)
+
+	manage := func(ctx context.Context, enabled bool) error {
+		if cli == nil {
+			cli = fake.NewClientBuilder().WithObjects(existing).Build()
+		}
+		return manageResource(ctx, cli, res, owner, "opendatahub", "dashboard", enabled)
+	}
+	found := func(ctx context.Context) (*corev1.ConfigMap, error) {
+		found := &corev1.ConfigMap{}
+		err := cli.Get(ctx, client.ObjectKeyFromObject(existing), found)
+		return found, err
+	}
+
+	BeforeEach(func() {
+		var err error
+		res, err = provider.NewDefaultDepProvider().GetResourceFactory().FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: odh-dashboard-config
+  namespace: opendatahub
+data:
+  setting: default
+`))
+		Expect(err).ToNot(HaveOccurred())
+		owner = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		existing = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "odh-dashboard-config",
+				Namespace: "opendatahub",
+				Labels:    map[string]string{labels.ODH.Component("dashboard"): "true"},
+			},
+			Data: map[string]string{"setting": "customized"},
+		}
+		cli = nil
+	})
+
+	It("should delete resources of disabled components", func(ctx context.Context) {
+		Expect(manage(ctx, false)).To(Succeed())
+
+		_, err := found(ctx)
+		Expect(k8serr.IsNotFound(err)).To(BeTrue())
+	})
+
+	DescribeTable("should neither update nor delete resources opted out",
+		func(ctx context.Context, optOut func(*corev1.ConfigMap)) {
+			optOut(existing)
+
+			for _, enabled := range []bool{true, false} {
+				Expect(manage(ctx, enabled)).To(Succeed())
+
+				kept, err := found(ctx)
+				Expect(err).ToNot(HaveOccurred(), "component enabled: %t", enabled)
+				Expect(kept.Data).To(HaveKeyWithValue("setting", "customized"), "component enabled: %t", enabled)
+			}
+		},
+		Entry("with the annotation", func(existing *corev1.ConfigMap) {
+			existing.SetAnnotations(map[string]string{annotations.ManagedByODHOperator: "false"})
+		}),
+		Entry("with the label", func(existing *corev1.ConfigMap) {
+			existing.Labels[annotations.ManagedByODHOperator] = "false"
+		}),
+	)
+
+	Context("created by others", func() {
+		BeforeEach(func() {
+			existing.Labels = nil
+			existing.Data["setting"] = "created by hand"
+		})
+
+		It("should skip them with the SkipExisting policy", func(ctx context.Context) {
+			Expect(manage(cluster.WithAdoptionPolicy(ctx, cluster.SkipExisting), true)).To(Succeed())
+
+			kept, err := found(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kept.Data).To(HaveKeyWithValue("setting", "created by hand"))
+		})
+
+		It("should fail with the FailOnExisting policy", func(ctx context.Context) {
+			err := manage(cluster.WithAdoptionPolicy(ctx, cluster.FailOnExisting), true)
+
+			var adoptionErr *cluster.AdoptionError
+			Expect(err).To(BeAssignableToTypeOf(adoptionErr))
+			Expect(err).To(MatchError(ContainSubstring("ConfigMap opendatahub/odh-dashboard-config already exists and is not managed by the operator")))
+		})
+
+		It("should not be deleted once the component is disabled", func(ctx context.Context) {
+			Expect(manage(cluster.WithAdoptionPolicy(ctx, cluster.FailOnExisting), false)).To(Succeed())
+
+			_, err := found(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Log level of component Deployments", func() {
+	logging := []components.DeploymentLogging{
+		{Deployment: "odh-dashboard", Env: "LOG_LEVEL"},
+		{Deployment: "kserve-controller-manager", Flag: "zap-log-level"},
+	}
+
+	It("should leave the context alone when not set", func(ctx context.Context) {
+		Expect(WithLogLevel(ctx, "", logging)).To(BeIdenticalTo(ctx))
+	})
+
+	It("should be set through the environment variable or the flag of each Deployment", func(ctx context.Context) {
+		ctx = WithLogLevel(ctx, components.LogLevelDebug, logging)
+
+		Expect(deploymentEnv(ctx)).To(ConsistOf(And(
+			HaveField("DeploymentName", "odh-dashboard"),
+			HaveField("Env", map[string]string{"LOG_LEVEL": "debug"}),
+		)))
+		Expect(deploymentArgs(ctx)).To(ConsistOf(And(
+			HaveField("DeploymentName", "kserve-controller-manager"),
+			HaveField("Args", map[string][]string{"zap-log-level": {"debug"}}),
+		)))
+	})
+
+	It("should not set anything for components without logging settings", func(ctx context.Context) {
+		ctx = WithLogLevel(ctx, components.LogLevelDebug, nil)
+
+		Expect(deploymentEnv(ctx)).To(BeEmpty())
+		Expect(deploymentArgs(ctx)).To(BeEmpty())
+	})
+})
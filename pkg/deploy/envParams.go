@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -121,3 +122,120 @@ func ApplyParams(componentPath string, imageParamsMap map[string]string, extraPa
 
 	return nil
 }
+
+/*
+ApplyExtraParams merges user provided parameters (.spec.components.<component>.extraParams in the DataScienceCluster)
+into params.env files found in the given component paths, after all other updates done by the operator.
+Only keys already defined in (at least one of) the params.env files are accepted, if any of the keys is unknown,
+no file is updated and an error is returned.
+Values overwritten this way are kept aside in a per-component file next to params.env, so that they are restored
+as soon as the key is removed from extraParams.
+*/
+func ApplyExtraParams(componentName string, extraParams map[string]string, componentPaths ...string) error {
+	type paramsFiles struct {
+		paramsFile, originalsFile string
+		params, originals         map[string]string
+	}
+
+	unknown := make(map[string]struct{}, len(extraParams))
+	for key := range extraParams {
+		unknown[key] = struct{}{}
+	}
+
+	var found []paramsFiles
+	for _, componentPath := range componentPaths {
+		paramsFile := filepath.Join(componentPath, "params.env")
+		paramsEnvMap, err := parseParams(paramsFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+
+		originalsFile := filepath.Join(componentPath, ".extra-params-"+componentName+".env")
+		originals, err := parseParams(originalsFile)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			originals = make(map[string]string)
+		}
+
+		for key := range extraParams {
+			if _, exists := paramsEnvMap[key]; exists {
+				delete(unknown, key)
+			}
+		}
+		found = append(found, paramsFiles{paramsFile: paramsFile, originalsFile: originalsFile, params: paramsEnvMap, originals: originals})
+	}
+
+	if len(unknown) != 0 {
+		keys := make([]string, 0, len(unknown))
+		for key := range unknown {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("unknown extraParams for %s, not defined in any params.env: %s", componentName, strings.Join(keys, ", "))
+	}
+
+	for _, f := range found {
+		updated := 0
+
+		// 1. Restore values of the keys which are no longer overridden
+		for key, original := range f.originals {
+			if _, overridden := extraParams[key]; !overridden {
+				updated |= updateMap(&f.params, key, original)
+				delete(f.originals, key)
+			}
+		}
+
+		// 2. Apply overrides, remembering the value they replace
+		for key, value := range extraParams {
+			current, exists := f.params[key]
+			if !exists {
+				continue
+			}
+			if _, saved := f.originals[key]; !saved {
+				f.originals[key] = current
+			}
+			updated |= updateMap(&f.params, key, value)
+		}
+
+		if err := replaceParamsFile(f.originalsFile, f.originals); err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			continue
+		}
+
+		if err := replaceParamsFile(f.paramsFile, f.params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceParamsFile writes params to the given file, removing it when there is nothing to write.
+func replaceParamsFile(fileName string, params map[string]string) error {
+	if len(params) == 0 {
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	tmp, err := writeParamsToTmp(params, filepath.Dir(fileName))
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp, fileName); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed rename %s to %s: %w", tmp, fileName, err)
+	}
+
+	return nil
+}
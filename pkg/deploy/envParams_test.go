@@ -0,0 +1,79 @@
+package deploy_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/deploy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Applying extraParams", func() {
+	var dir, otherDir string
+
+	writeParamsEnv := func(dir, content string) {
+		GinkgoHelper()
+		Expect(os.WriteFile(filepath.Join(dir, "params.env"), []byte(content), 0o600)).To(Succeed())
+	}
+	paramsEnv := func(dir string) []string {
+		GinkgoHelper()
+		content, err := os.ReadFile(filepath.Join(dir, "params.env"))
+		Expect(err).NotTo(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(content)), "\n")
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		otherDir = GinkgoT().TempDir()
+		writeParamsEnv(dir, "image=quay.io/org/image:v1\nreplicas=1\n")
+	})
+
+	It("should override the values of params.env", func() {
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "3"}, dir)).To(Succeed())
+
+		Expect(paramsEnv(dir)).To(ConsistOf("image=quay.io/org/image:v1", "replicas=3"))
+	})
+
+	It("should restore the original value once the key is removed", func() {
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "3"}, dir)).To(Succeed())
+		Expect(deploy.ApplyExtraParams("test", nil, dir)).To(Succeed())
+
+		Expect(paramsEnv(dir)).To(ConsistOf("image=quay.io/org/image:v1", "replicas=1"))
+		Expect(filepath.Join(dir, ".extra-params-test.env")).NotTo(BeAnExistingFile())
+	})
+
+	It("should restore the original value after the override changed", func() {
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "3"}, dir)).To(Succeed())
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "5"}, dir)).To(Succeed())
+		Expect(paramsEnv(dir)).To(ContainElement("replicas=5"))
+
+		Expect(deploy.ApplyExtraParams("test", nil, dir)).To(Succeed())
+		Expect(paramsEnv(dir)).To(ContainElement("replicas=1"))
+	})
+
+	It("should accept keys defined in any of the paths", func() {
+		writeParamsEnv(otherDir, "timeout=30\n")
+
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "2", "timeout": "60"}, dir, otherDir)).To(Succeed())
+
+		Expect(paramsEnv(dir)).To(ConsistOf("image=quay.io/org/image:v1", "replicas=2"))
+		Expect(paramsEnv(otherDir)).To(ConsistOf("timeout=60"))
+	})
+
+	It("should skip paths without params.env", func() {
+		Expect(deploy.ApplyExtraParams("test", map[string]string{"replicas": "2"}, otherDir, dir)).To(Succeed())
+
+		Expect(paramsEnv(dir)).To(ContainElement("replicas=2"))
+		Expect(filepath.Join(otherDir, "params.env")).NotTo(BeAnExistingFile())
+	})
+
+	It("should reject unknown keys and leave params.env untouched", func() {
+		err := deploy.ApplyExtraParams("test", map[string]string{"replicas": "2", "not-a-param": "value"}, dir)
+
+		Expect(err).To(MatchError(ContainSubstring("not-a-param")))
+		Expect(paramsEnv(dir)).To(ConsistOf("image=quay.io/org/image:v1", "replicas=1"))
+	})
+})
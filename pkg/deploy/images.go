@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	// ImageSetConfigMapName is the name of the ConfigMap holding images of the enabled components.
+	ImageSetConfigMapName = "odh-image-set"
+	// ImageSetConfigurationKey is the ConfigMap key holding the images in oc-mirror ImageSetConfiguration format.
+	ImageSetConfigurationKey = "imageset-config.yaml"
+)
+
+// renderedImages keeps images referenced by the manifests last applied from each manifests path,
+// so the full set can be published once all components have been reconciled.
+var renderedImages = struct {
+	sync.Mutex
+	byPath map[string][]string
+}{byPath: map[string][]string{}}
+
+func recordImages(manifestPath string, resMap resmap.ResMap, componentEnabled bool) {
+	renderedImages.Lock()
+	defer renderedImages.Unlock()
+
+	if !componentEnabled {
+		delete(renderedImages.byPath, manifestPath)
+		return
+	}
+
+	var images []string
+	for _, res := range resMap.Resources() {
+		obj, err := res.Map()
+		if err != nil {
+			continue
+		}
+		images = append(images, containerImages(obj)...)
+	}
+	renderedImages.byPath[manifestPath] = images
+}
+
+// containerImages walks the object and returns images of all containers and init containers in it,
+// regardless of the kind of workload (Deployment, StatefulSet, CronJob, etc.) defining them.
+func containerImages(obj any) []string {
+	var images []string
+	switch v := obj.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "containers" || key == "initContainers" {
+				if containers, ok := value.([]any); ok {
+					for _, container := range containers {
+						if c, isMap := container.(map[string]any); isMap {
+							if image, isString := c["image"].(string); isString && image != "" {
+								images = append(images, image)
+							}
+						}
+					}
+					continue
+				}
+			}
+			images = append(images, containerImages(value)...)
+		}
+	case []any:
+		for _, value := range v {
+			images = append(images, containerImages(value)...)
+		}
+	}
+
+	return images
+}
+
+// EnabledComponentImages returns sorted and de-duplicated images referenced by manifests of the enabled components.
+// Images are listed as referenced in the manifests, so they carry digests when these, or the RELATED_IMAGE_*
+// environment variables of the operator, pin them.
+func EnabledComponentImages() []string {
+	renderedImages.Lock()
+	defer renderedImages.Unlock()
+
+	unique := map[string]bool{}
+	for _, images := range renderedImages.byPath {
+		for _, image := range images {
+			unique[image] = true
+		}
+	}
+
+	images := make([]string, 0, len(unique))
+	for image := range unique {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	return images
+}
+
+// ImageSetConfiguration renders the images in oc-mirror ImageSetConfiguration format, ready to be used for mirroring
+// the images into a disconnected registry.
+func ImageSetConfiguration(images []string) ([]byte, error) {
+	additionalImages := make([]map[string]string, 0, len(images))
+	for _, image := range images {
+		additionalImages = append(additionalImages, map[string]string{"name": image})
+	}
+
+	return yaml.Marshal(map[string]any{
+		"kind":       "ImageSetConfiguration",
+		"apiVersion": "mirror.openshift.io/v1alpha2",
+		"mirror": map[string]any{
+			"additionalImages": additionalImages,
+		},
+	})
+}
+
+// PublishImageSet stores images of the enabled components in a ConfigMap in the ImageSetConfiguration format.
+// Publishing is opt-in by setting annotations.PublishImageSet to "true" on the owner, otherwise a previously
+// published ConfigMap is removed.
+func PublishImageSet(ctx context.Context, cli client.Client, owner metav1.Object, namespace string) error {
+	if owner.GetAnnotations()[annotations.PublishImageSet] != "true" {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ImageSetConfigMapName, Namespace: namespace}}
+		return client.IgnoreNotFound(cli.Delete(ctx, cm))
+	}
+
+	imageSet, err := ImageSetConfiguration(EnabledComponentImages())
+	if err != nil {
+		return err
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ImageSetConfigMapName,
+			Namespace: namespace,
+		},
+		Data: map[string]string{ImageSetConfigurationKey: string(imageSet)},
+	}
+
+	existing := &corev1.ConfigMap{}
+	err = cli.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case k8serr.IsNotFound(err):
+		if errMeta := cluster.ApplyMetaOptions(desired, cluster.OwnedBy(owner, cli.Scheme())); errMeta != nil {
+			return errMeta
+		}
+		return cli.Create(ctx, desired)
+	case err != nil:
+		return err
+	}
+
+	existing.Data = desired.Data
+	return cli.Update(ctx, existing)
+}
@@ -0,0 +1,147 @@
+package deploy
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const workloads = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-dashboard
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: quay.io/opendatahub/init:v1
+      containers:
+      - name: dashboard
+        image: quay.io/opendatahub/odh-dashboard@sha256:0123
+      - name: oauth-proxy
+        image: registry.redhat.io/openshift4/ose-oauth-proxy:v4.14
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: registry.redhat.io/openshift4/ose-cli:v4.14
+`
+
+var _ = Describe("Images of enabled components", func() {
+	resMap := func(manifests string) resmap.ResMap {
+		GinkgoHelper()
+		m, err := resmap.NewFactory(provider.NewDefaultDepProvider().GetResourceFactory()).NewResMapFromBytes([]byte(manifests))
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	BeforeEach(func() {
+		renderedImages.Lock()
+		renderedImages.byPath = map[string][]string{}
+		renderedImages.Unlock()
+	})
+
+	It("should list containers and init containers of any kind of workload", func() {
+		recordImages("/opt/manifests/dashboard", resMap(workloads), true)
+
+		Expect(EnabledComponentImages()).To(Equal([]string{
+			"quay.io/opendatahub/init:v1",
+			"quay.io/opendatahub/odh-dashboard@sha256:0123",
+			"registry.redhat.io/openshift4/ose-cli:v4.14",
+			"registry.redhat.io/openshift4/ose-oauth-proxy:v4.14",
+		}))
+	})
+
+	It("should list images shared by several paths once", func() {
+		recordImages("/opt/manifests/dashboard", resMap(workloads), true)
+		recordImages("/opt/manifests/workbenches", resMap(workloads), true)
+
+		Expect(EnabledComponentImages()).To(HaveLen(4))
+	})
+
+	It("should forget the images of disabled components", func() {
+		recordImages("/opt/manifests/dashboard", resMap(workloads), true)
+		recordImages("/opt/manifests/dashboard", resMap(workloads), false)
+
+		Expect(EnabledComponentImages()).To(BeEmpty())
+	})
+
+	It("should render them in ImageSetConfiguration format", func() {
+		imageSet, err := ImageSetConfiguration([]string{
+			"quay.io/opendatahub/odh-dashboard@sha256:0123",
+			"quay.io/opendatahub/kserve-controller:v0.12",
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(imageSet)).To(Equal(`apiVersion: mirror.openshift.io/v1alpha2
+kind: ImageSetConfiguration
+mirror:
+  additionalImages:
+  - name: quay.io/opendatahub/odh-dashboard@sha256:0123
+  - name: quay.io/opendatahub/kserve-controller:v0.12
+`))
+	})
+
+	It("should render an empty list without images", func() {
+		imageSet, err := ImageSetConfiguration(nil)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(imageSet)).To(ContainSubstring("additionalImages: []"))
+	})
+
+	Context("published in a ConfigMap", func() {
+		var (
+			owner *corev1.ConfigMap
+			cli   client.Client
+			key   = client.ObjectKey{Name: ImageSetConfigMapName, Namespace: "opendatahub"}
+		)
+
+		BeforeEach(func() {
+			owner = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", Annotations: map[string]string{
+				annotations.PublishImageSet: "true",
+			}}}
+			cli = fake.NewClientBuilder().Build()
+			recordImages("/opt/manifests/dashboard", resMap(workloads), true)
+		})
+
+		It("should be kept up to date", func(ctx context.Context) {
+			Expect(PublishImageSet(ctx, cli, owner, "opendatahub")).To(Succeed())
+			recordImages("/opt/manifests/dashboard", resMap(workloads), false)
+			Expect(PublishImageSet(ctx, cli, owner, "opendatahub")).To(Succeed())
+
+			cm := &corev1.ConfigMap{}
+			Expect(cli.Get(ctx, key, cm)).To(Succeed())
+			Expect(cm.OwnerReferences).To(ConsistOf(HaveField("Name", "default-dsc")))
+			Expect(cm.Data).To(HaveKeyWithValue(ImageSetConfigurationKey, ContainSubstring("additionalImages: []")))
+		})
+
+		It("should be deleted once the annotation is removed", func(ctx context.Context) {
+			Expect(PublishImageSet(ctx, cli, owner, "opendatahub")).To(Succeed())
+			owner.Annotations = nil
+			Expect(PublishImageSet(ctx, cli, owner, "opendatahub")).To(Succeed())
+
+			Expect(k8serr.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+	})
+})
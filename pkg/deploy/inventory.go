@@ -0,0 +1,85 @@
+package deploy
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/api/resource"
+)
+
+// InventoryEntry identifies a resource deployed for a component.
+type InventoryEntry struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+}
+
+// ResourcesRecorder collects the resources deployed for a component.
+type ResourcesRecorder struct {
+	mu      sync.Mutex
+	entries map[InventoryEntry]struct{}
+}
+
+// Resources returns the resources recorded so far, sorted by group, kind, namespace and name.
+func (r *ResourcesRecorder) Resources() []InventoryEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]InventoryEntry, 0, len(r.entries))
+	for entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	SortInventory(entries)
+
+	return entries
+}
+
+func (r *ResourcesRecorder) add(entry InventoryEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = map[InventoryEntry]struct{}{}
+	}
+	r.entries[entry] = struct{}{}
+}
+
+// SortInventory sorts the entries by group, kind, namespace and name.
+func SortInventory(entries []InventoryEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.GroupVersionKind.Group != b.GroupVersionKind.Group {
+			return a.GroupVersionKind.Group < b.GroupVersionKind.Group
+		}
+		if a.GroupVersionKind.Kind != b.GroupVersionKind.Kind {
+			return a.GroupVersionKind.Kind < b.GroupVersionKind.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+type resourcesRecorderKey struct{}
+
+// WithResourcesRecorder makes the resources deployed with the returned context available in the recorder.
+func WithResourcesRecorder(ctx context.Context, recorder *ResourcesRecorder) context.Context {
+	return context.WithValue(ctx, resourcesRecorderKey{}, recorder)
+}
+
+// recordResource records the resource as deployed for the component.
+func recordResource(ctx context.Context, res *resource.Resource) {
+	recorder, _ := ctx.Value(resourcesRecorderKey{}).(*ResourcesRecorder)
+	if recorder == nil {
+		return
+	}
+	resGvk := res.GetGvk()
+	recorder.add(InventoryEntry{
+		GroupVersionKind: schema.GroupVersionKind{Group: resGvk.Group, Version: resGvk.Version, Kind: resGvk.Kind},
+		Namespace:        res.GetNamespace(),
+		Name:             res.GetName(),
+	})
+}
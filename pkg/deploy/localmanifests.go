@@ -0,0 +1,187 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// localManifestsSyncDelay lets editors finish writing files before the manifests are synced.
+const localManifestsSyncDelay = 500 * time.Millisecond
+
+// LocalManifests syncs manifests from a local directory into DefaultManifestPath, for manifest developers to see their
+// changes deployed without rebuilding the operator image or serving devFlags tarballs. Each directory of Path, e.g.
+// "kserve", replaces the manifests directory of the same name on startup and again whenever one of its files changes.
+// It is meant for development only.
+type LocalManifests struct {
+	// Path of the local directory, laid out as DefaultManifestPath
+	Path string
+	// Synced is called with the name of each directory synced, e.g. to reconcile the components again
+	Synced func(dir string)
+	Log    logr.Logger
+}
+
+var _ manager.LeaderElectionRunnable = (*LocalManifests)(nil)
+
+// NeedLeaderElection is false, every replica of the operator renders the manifests from its own filesystem.
+func (m *LocalManifests) NeedLeaderElection() bool {
+	return false
+}
+
+// Start syncs the manifests and watches the local directory until the context is done.
+func (m *LocalManifests) Start(ctx context.Context) error {
+	source, err := filepath.Abs(m.Path)
+	if err != nil {
+		return err
+	}
+	target, err := filepath.Abs(DefaultManifestPath)
+	if err != nil {
+		return err
+	}
+	if source == target {
+		return errors.New("local manifests cannot be synced into the directory they are read from")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch local manifests: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(m.Path); err != nil {
+		return fmt.Errorf("failed to watch local manifests: %w", err)
+	}
+	entries, err := os.ReadDir(m.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read local manifests: %w", err)
+	}
+	pending := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			if err := watchDir(watcher, filepath.Join(m.Path, entry.Name())); err != nil {
+				return err
+			}
+			pending[entry.Name()] = true
+		}
+	}
+	m.syncPending(pending)
+
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			dir := m.topDir(event.Name)
+			if dir == "" {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watchDir(watcher, event.Name); err != nil {
+						m.Log.Error(err, "failed to watch local manifests", "path", event.Name)
+					}
+				}
+			}
+			pending[dir] = true
+			flush = time.After(localManifestsSyncDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			m.Log.Error(err, "error watching local manifests")
+		case <-flush:
+			m.syncPending(pending)
+			flush = nil
+		}
+	}
+}
+
+func (m *LocalManifests) syncPending(pending map[string]bool) {
+	for dir := range pending {
+		if err := m.sync(dir); err != nil {
+			m.Log.Error(err, "failed to sync local manifests", "dir", dir)
+			continue
+		}
+		m.Log.Info("synced local manifests", "dir", dir)
+		if m.Synced != nil {
+			m.Synced(dir)
+		}
+	}
+	clear(pending)
+}
+
+// sync replaces the manifests directory with the local one, or restores the embedded manifests once it is deleted.
+func (m *LocalManifests) sync(dir string) error {
+	source := filepath.Join(m.Path, dir)
+	target := filepath.Join(DefaultManifestPath, dir)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		if restored, err := RestoreEmbeddedManifests(dir); err != nil || restored {
+			return err
+		}
+		return os.RemoveAll(target)
+	}
+
+	if err := backupEmbeddedManifests(dir); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove manifests %s: %w", dir, err)
+	}
+	if err := copyDir(source, target); err != nil {
+		return fmt.Errorf("failed to copy local manifests %s: %w", dir, err)
+	}
+	digest, err := digestDir(target)
+	if err != nil {
+		return fmt.Errorf("failed to compute digest of manifests %s: %w", dir, err)
+	}
+	recordBundle(status.ManifestsProvenance{Path: dir, Source: "file://" + source, Digest: digest})
+
+	return nil
+}
+
+// topDir returns the directory of Path the changed file belongs to, or "" for files at its root or hidden ones.
+func (m *LocalManifests) topDir(name string) string {
+	rel, err := filepath.Rel(m.Path, name)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	dir, _, nested := strings.Cut(filepath.ToSlash(rel), "/")
+	if strings.HasPrefix(dir, ".") {
+		return ""
+	}
+	// only directories at the root hold manifests, deleted ones are synced for the embedded manifests to be restored
+	if info, err := os.Stat(name); !nested && err == nil && !info.IsDir() {
+		return ""
+	}
+
+	return dir
+}
+
+// watchDir watches the directory and its subdirectories, fsnotify not watching recursively.
+func watchDir(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if err := watcher.Add(path); err != nil {
+				return fmt.Errorf("failed to watch local manifests %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Local manifests", func() {
+	var (
+		local    string
+		embedded string
+		synced   chan string
+	)
+
+	// start syncs the local manifests until the spec ends, and waits for them to be synced on startup.
+	start := func(dirs ...string) {
+		GinkgoHelper()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			defer GinkgoRecover()
+			done <- (&LocalManifests{Path: local, Log: logr.Discard(), Synced: func(dir string) { synced <- dir }}).Start(ctx)
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+		for range dirs {
+			Eventually(synced).WithTimeout(5 * time.Second).Should(Receive(BeElementOf(dirs)))
+		}
+	}
+	waitSynced := func(dir string) {
+		GinkgoHelper()
+		Eventually(synced).WithTimeout(5 * time.Second).Should(Receive(Equal(dir)))
+	}
+	provenance := func(path string) status.ManifestsProvenance {
+		provenance, _ := bundleOf(path)
+		return provenance
+	}
+
+	BeforeEach(func() {
+		manifestPath := DefaultManifestPath
+		DefaultManifestPath = GinkgoT().TempDir()
+		DeferCleanup(func() { DefaultManifestPath = manifestPath })
+		embedded = filepath.Join(DefaultManifestPath, "codeflare", "base", "kustomization.yaml")
+		writeManifests(embedded, "resources: []\n")
+		Expect(RecordEmbeddedManifests()).To(Succeed())
+		local = GinkgoT().TempDir()
+		writeManifests(filepath.Join(local, "codeflare", "base", "kustomization.yaml"), "resources:\n- deployment.yaml\n")
+		synced = make(chan string, 10)
+	})
+
+	It("should sync the local manifests on startup", func() {
+		start("codeflare")
+
+		Expect(os.ReadFile(embedded)).To(BeEquivalentTo("resources:\n- deployment.yaml\n"))
+		Expect(provenance(filepath.Dir(embedded)).Source).To(Equal("file://" + filepath.Join(local, "codeflare")))
+		Expect(filepath.Join(DefaultManifestPath, embeddedBackupDir, "codeflare", "base", "kustomization.yaml")).To(BeAnExistingFile())
+	})
+
+	It("should sync changes, including in new directories", func() {
+		start("codeflare")
+
+		writeManifests(filepath.Join(local, "codeflare", "overlays", "odh", "kustomization.yaml"), "resources:\n- ../../base\n")
+
+		waitSynced("codeflare")
+		Expect(filepath.Join(DefaultManifestPath, "codeflare", "overlays", "odh", "kustomization.yaml")).To(BeAnExistingFile())
+	})
+
+	It("should sync new manifests directories", func() {
+		start("codeflare")
+
+		writeManifests(filepath.Join(local, "trainingoperator", "kustomization.yaml"), "resources: []\n")
+
+		waitSynced("trainingoperator")
+		Expect(filepath.Join(DefaultManifestPath, "trainingoperator", "kustomization.yaml")).To(BeAnExistingFile())
+	})
+
+	It("should restore the embedded manifests once the local ones are deleted", func() {
+		start("codeflare")
+
+		Expect(os.RemoveAll(filepath.Join(local, "codeflare"))).To(Succeed())
+
+		waitSynced("codeflare")
+		Expect(os.ReadFile(embedded)).To(BeEquivalentTo("resources: []\n"))
+		Expect(provenance(filepath.Dir(embedded)).Source).To(Equal(status.ManifestsSourceEmbedded))
+	})
+
+	It("should remove local manifests without embedded counterpart once deleted", func() {
+		writeManifests(filepath.Join(local, "trainingoperator", "kustomization.yaml"), "resources: []\n")
+		start("codeflare", "trainingoperator")
+
+		Expect(os.RemoveAll(filepath.Join(local, "trainingoperator"))).To(Succeed())
+
+		waitSynced("trainingoperator")
+		Expect(filepath.Join(DefaultManifestPath, "trainingoperator")).ToNot(BeADirectory())
+	})
+
+	It("should ignore hidden directories and files at the root", func() {
+		writeManifests(filepath.Join(local, ".git", "HEAD"), "ref: refs/heads/main\n")
+		start("codeflare")
+
+		writeManifests(filepath.Join(local, "README.md"), "# manifests\n")
+		writeManifests(filepath.Join(local, ".git", "HEAD"), "ref: refs/heads/dev\n")
+
+		Consistently(synced).WithTimeout(2 * localManifestsSyncDelay).ShouldNot(Receive())
+		Expect(filepath.Join(DefaultManifestPath, ".git")).ToNot(BeADirectory())
+	})
+
+	It("should not sync manifests into the directory they are read from", func(ctx context.Context) {
+		err := (&LocalManifests{Path: DefaultManifestPath, Log: logr.Discard()}).Start(ctx)
+
+		Expect(err).To(MatchError(ContainSubstring("cannot be synced into the directory they are read from")))
+	})
+
+	It("should run on every replica of the operator", func() {
+		Expect((&LocalManifests{}).NeedLeaderElection()).To(BeFalse())
+	})
+})
@@ -0,0 +1,47 @@
+package deploy
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// restoredResources counts resources which had to be created again for a component which is already installed,
+	// e.g. because they were deleted out-of-band. These are otherwise only noticed on the next reconcile.
+	restoredResources = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "odh_component_resources_restored_total",
+			Help: "Number of missing resources created again for an installed component, labeled by component name and kind.",
+		},
+		[]string{"component", "kind"},
+	)
+
+	// revertedChanges counts changes of other field managers to resources of a component which were reverted
+	// when applying its manifests. A steady increase means another controller keeps fighting over the resources.
+	revertedChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "odh_component_resources_reverted_total",
+			Help: "Number of changes of other field managers reverted on resources of a component, labeled by component name, kind and field manager.",
+		},
+		[]string{"component", "kind", "manager"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(restoredResources, revertedChanges)
+}
+
+type installedKey struct{}
+
+// WithComponentInstalled marks the context of reconciling a component which has already been installed,
+// so resources created while deploying its manifests are counted as restored.
+func WithComponentInstalled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, installedKey{}, true)
+}
+
+func isComponentInstalled(ctx context.Context) bool {
+	installed, _ := ctx.Value(installedKey{}).(bool)
+	return installed
+}
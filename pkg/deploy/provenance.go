@@ -0,0 +1,249 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// bundles holds the provenance of the manifests directories, keyed by their path relative to DefaultManifestPath.
+// Embedded directories are recorded at startup, before the operator modifies them, and downloaded ones replace
+// them as they are extracted to the same path.
+var bundles = struct {
+	sync.RWMutex
+	provenance map[string]status.ManifestsProvenance
+}{provenance: map[string]status.ManifestsProvenance{}}
+
+// embeddedBackupDir holds, under DefaultManifestPath, the embedded manifests directories replaced by downloaded ones,
+// for them to be restored when the devFlags of the component are reset.
+const embeddedBackupDir = ".embedded"
+
+// RecordEmbeddedManifests records the digest of each manifests directory shipped with the operator image.
+// It has to run before components update the params.env files of their manifests.
+func RecordEmbeddedManifests() error {
+	entries, err := os.ReadDir(DefaultManifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read manifests directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		digest, err := digestDir(filepath.Join(DefaultManifestPath, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to compute digest of manifests %s: %w", entry.Name(), err)
+		}
+		recordBundle(status.ManifestsProvenance{Path: entry.Name(), Source: status.ManifestsSourceEmbedded, Digest: digest})
+	}
+
+	return nil
+}
+
+func recordBundle(provenance status.ManifestsProvenance) {
+	bundles.Lock()
+	defer bundles.Unlock()
+
+	bundles.provenance[provenance.Path] = provenance
+}
+
+// backupEmbeddedManifests keeps a copy of the embedded manifests directory before downloaded manifests are extracted
+// over it. Directories already replaced by downloaded manifests are not backed up again.
+func backupEmbeddedManifests(path string) error {
+	bundles.RLock()
+	provenance, found := bundles.provenance[path]
+	bundles.RUnlock()
+	if !found || provenance.Source != status.ManifestsSourceEmbedded {
+		return nil
+	}
+
+	backup := filepath.Join(DefaultManifestPath, embeddedBackupDir, path)
+	if _, err := os.Stat(backup); err == nil {
+		return nil
+	}
+	if err := copyDir(filepath.Join(DefaultManifestPath, path), backup); err != nil {
+		return fmt.Errorf("failed to back up embedded manifests %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// RestoreEmbeddedManifests replaces the manifests downloaded to the path, relative to DefaultManifestPath, with the
+// embedded ones they were extracted over. It tells whether there was a backup to restore.
+func RestoreEmbeddedManifests(path string) (bool, error) {
+	backup := filepath.Join(DefaultManifestPath, embeddedBackupDir, path)
+	if _, err := os.Stat(backup); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	target := filepath.Join(DefaultManifestPath, path)
+	if err := os.RemoveAll(target); err != nil {
+		return false, fmt.Errorf("failed to remove downloaded manifests %s: %w", path, err)
+	}
+	if err := os.Rename(backup, target); err != nil {
+		return false, fmt.Errorf("failed to restore embedded manifests %s: %w", path, err)
+	}
+	digest, err := digestDir(target)
+	if err != nil {
+		return false, fmt.Errorf("failed to compute digest of manifests %s: %w", path, err)
+	}
+	recordBundle(status.ManifestsProvenance{Path: path, Source: status.ManifestsSourceEmbedded, Digest: digest})
+
+	return true, nil
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case d.Type().IsRegular():
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
+
+// bundleOf returns the provenance of the most specific manifests directory containing the path.
+func bundleOf(manifestPath string) (status.ManifestsProvenance, bool) {
+	rel, err := filepath.Rel(DefaultManifestPath, manifestPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return status.ManifestsProvenance{}, false
+	}
+
+	bundles.RLock()
+	defer bundles.RUnlock()
+
+	for dir := rel; dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		if provenance, found := bundles.provenance[dir]; found {
+			return provenance, true
+		}
+	}
+
+	return status.ManifestsProvenance{}, false
+}
+
+// digestDir computes a sha256 digest over the relative paths and contents of the regular files of the directory.
+func digestDir(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, path := range files {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00", filepath.ToSlash(rel))
+		if err := hashFile(hash, path); err != nil {
+			return "", err
+		}
+	}
+
+	return digestOf(hash.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+
+	return err
+}
+
+func digestOf(sum []byte) string {
+	return "sha256:" + hex.EncodeToString(sum)
+}
+
+// ManifestsRecorder collects the provenance of the manifests deployed for a component.
+type ManifestsRecorder struct {
+	mu         sync.Mutex
+	provenance map[string]status.ManifestsProvenance
+}
+
+// Provenance returns the provenance recorded so far, sorted by path.
+func (r *ManifestsRecorder) Provenance() []status.ManifestsProvenance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	provenance := make([]status.ManifestsProvenance, 0, len(r.provenance))
+	for _, entry := range r.provenance {
+		provenance = append(provenance, entry)
+	}
+	sort.Slice(provenance, func(i, j int) bool { return provenance[i].Path < provenance[j].Path })
+
+	return provenance
+}
+
+func (r *ManifestsRecorder) add(provenance status.ManifestsProvenance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.provenance == nil {
+		r.provenance = map[string]status.ManifestsProvenance{}
+	}
+	r.provenance[provenance.Path] = provenance
+}
+
+type manifestsRecorderKey struct{}
+
+// WithManifestsRecorder makes the provenance of manifests deployed with the returned context available in the recorder.
+func WithManifestsRecorder(ctx context.Context, recorder *ManifestsRecorder) context.Context {
+	return context.WithValue(ctx, manifestsRecorderKey{}, recorder)
+}
+
+// recordManifests records the provenance of the manifests rendered from the path, if known.
+func recordManifests(ctx context.Context, manifestPath string) {
+	recorder, _ := ctx.Value(manifestsRecorderKey{}).(*ManifestsRecorder)
+	if recorder == nil {
+		return
+	}
+	if provenance, found := bundleOf(manifestPath); found {
+		recorder.add(provenance)
+	}
+}
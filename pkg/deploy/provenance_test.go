@@ -0,0 +1,182 @@
+package deploy
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func tarball(dir string, files map[string]string) []byte {
+	GinkgoHelper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	Expect(tarWriter.WriteHeader(&tar.Header{Name: dir, Mode: 0o700, Typeflag: tar.TypeDir})).To(Succeed())
+	for name, content := range files {
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+// serve serves the content over HTTP for the duration of the spec and returns the URL to download it from.
+func serve(content []byte) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	DeferCleanup(server.Close)
+
+	return server.URL
+}
+
+func writeManifests(path string, content string) {
+	GinkgoHelper()
+	Expect(os.MkdirAll(filepath.Dir(path), os.ModePerm)).To(Succeed())
+	Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+}
+
+var _ = Describe("Manifests provenance", func() {
+	BeforeEach(func() {
+		manifestPath := DefaultManifestPath
+		DefaultManifestPath = GinkgoT().TempDir()
+		DeferCleanup(func() { DefaultManifestPath = manifestPath })
+	})
+
+	Context("downloading manifests", func() {
+		var (
+			uri      string
+			digest   string
+			tampered = "sha256:" + strings.Repeat("0", 64)
+		)
+
+		BeforeEach(func() {
+			content := tarball("repo/manifests/", map[string]string{"repo/manifests/kustomization.yaml": "resources: []\n"})
+			sum := sha256.Sum256(content)
+			digest = digestOf(sum[:])
+			uri = serve(content)
+		})
+
+		provenanceOf := func(ctx context.Context, path string) []status.ManifestsProvenance {
+			recorder := &ManifestsRecorder{}
+			recordManifests(WithManifestsRecorder(ctx, recorder), filepath.Join(DefaultManifestPath, path))
+			return recorder.Provenance()
+		}
+
+		It("should reject manifests not matching the digest", func(ctx context.Context) {
+			err := DownloadManifests(ctx, "dashboard", components.ManifestsConfig{URI: uri, ContextDir: "manifests", Digest: tampered})
+
+			Expect(err).To(MatchError(ContainSubstring("does not match " + tampered)))
+			Expect(filepath.Join(DefaultManifestPath, "dashboard", "kustomization.yaml")).NotTo(BeAnExistingFile())
+		})
+
+		It("should record manifests not matching the digest as unverified when the mismatch is allowed", func(ctx context.Context) {
+			Expect(DownloadManifests(ctx, "dashboard", components.ManifestsConfig{
+				URI: uri, ContextDir: "manifests", Digest: tampered, AllowDigestMismatch: true,
+			})).To(Succeed())
+
+			Expect(provenanceOf(ctx, filepath.Join("dashboard", "overlays"))).To(ConsistOf(
+				status.ManifestsProvenance{Path: "dashboard", Source: uri, Digest: digest},
+			))
+		})
+
+		It("should record manifests matching the digest as verified", func(ctx context.Context) {
+			Expect(DownloadManifests(ctx, "dashboard", components.ManifestsConfig{URI: uri, ContextDir: "manifests", Digest: digest})).To(Succeed())
+
+			Expect(filepath.Join(DefaultManifestPath, "dashboard", "kustomization.yaml")).To(BeAnExistingFile())
+			Expect(provenanceOf(ctx, "dashboard")).To(ConsistOf(
+				status.ManifestsProvenance{Path: "dashboard", Source: uri, Digest: digest, Verified: true},
+			))
+		})
+
+		It("should record manifests without digest to verify as unverified", func(ctx context.Context) {
+			Expect(DownloadManifests(ctx, "dashboard", components.ManifestsConfig{URI: uri, ContextDir: "manifests"})).To(Succeed())
+
+			Expect(provenanceOf(ctx, "dashboard")).To(ConsistOf(
+				status.ManifestsProvenance{Path: "dashboard", Source: uri, Digest: digest},
+			))
+		})
+
+		It("should not record anything without recorder in the context", func(ctx context.Context) {
+			Expect(DownloadManifests(ctx, "dashboard", components.ManifestsConfig{URI: uri, ContextDir: "manifests", Digest: digest})).To(Succeed())
+
+			Expect(func() { recordManifests(ctx, filepath.Join(DefaultManifestPath, "dashboard")) }).NotTo(Panic())
+		})
+	})
+
+	Context("embedded manifests", func() {
+		BeforeEach(func() {
+			writeManifests(filepath.Join(DefaultManifestPath, "ray", "openshift", "kustomization.yaml"), "resources: []\n")
+			Expect(RecordEmbeddedManifests()).To(Succeed())
+		})
+
+		It("should record the digest of each manifests directory", func() {
+			provenance, found := bundleOf(filepath.Join(DefaultManifestPath, "ray", "openshift"))
+			Expect(found).To(BeTrue())
+			Expect(provenance.Source).To(Equal(status.ManifestsSourceEmbedded))
+			Expect(provenance.Path).To(Equal("ray"))
+
+			digest, err := digestDir(filepath.Join(DefaultManifestPath, "ray"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provenance.Digest).To(Equal(digest))
+		})
+
+		It("should not know the provenance of unknown manifests", func() {
+			_, found := bundleOf(filepath.Join(DefaultManifestPath, "kueue"))
+			Expect(found).To(BeFalse())
+		})
+
+		It("should not know the provenance of paths outside of the manifests directory", func() {
+			_, found := bundleOf(filepath.Dir(DefaultManifestPath))
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("restoring embedded manifests", func() {
+		var embedded string
+
+		BeforeEach(func() {
+			embedded = filepath.Join(DefaultManifestPath, "trustyai", "kustomization.yaml")
+			writeManifests(embedded, "resources: []\n")
+			Expect(RecordEmbeddedManifests()).To(Succeed())
+		})
+
+		It("should have nothing to restore before manifests are downloaded", func() {
+			Expect(RestoreEmbeddedManifests("trustyai")).To(BeFalse())
+		})
+
+		It("should restore the embedded manifests and their provenance", func(ctx context.Context) {
+			embeddedProvenance, _ := bundleOf(filepath.Dir(embedded))
+			uri := serve(tarball("repo/manifests/", map[string]string{"repo/manifests/kustomization.yaml": "resources:\n- custom.yaml\n"}))
+			// downloading again does not back up the downloaded manifests over the embedded ones
+			for i := 0; i < 2; i++ {
+				Expect(DownloadManifests(ctx, "trustyai", components.ManifestsConfig{URI: uri, ContextDir: "manifests"})).To(Succeed())
+			}
+
+			Expect(RestoreEmbeddedManifests("trustyai")).To(BeTrue())
+			Expect(os.ReadFile(embedded)).To(BeEquivalentTo("resources: []\n"))
+			provenance, found := bundleOf(filepath.Dir(embedded))
+			Expect(found).To(BeTrue())
+			Expect(provenance).To(Equal(embeddedProvenance))
+
+			By("having nothing left to restore")
+			Expect(RestoreEmbeddedManifests("trustyai")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,158 @@
+package deploy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// renderedManifestsSuffix ends the ConfigMap keys holding the rendered manifests of a path as plain YAML.
+	renderedManifestsSuffix = ".yaml"
+	// renderedManifestsGzipSuffix ends the binary keys used instead when the plain YAML does not fit into the ConfigMap.
+	renderedManifestsGzipSuffix = ".yaml.gz"
+
+	// maxConfigMapDataSize leaves some room for metadata below the 1MiB object size limit enforced by etcd.
+	maxConfigMapDataSize = 1000 * 1024
+)
+
+var invalidKeyCharacters = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// RenderedManifestsConfigMapName returns the name of the ConfigMap holding rendered manifests of the given component.
+func RenderedManifestsConfigMapName(componentName string) string {
+	return componentName + "-rendered-manifests"
+}
+
+// RenderedManifestsKey returns the ConfigMap key holding the rendered manifests of the given path, e.g.
+// "dashboard.odh.yaml" for the odh overlay of the dashboard. Components deploying several paths get a key for each.
+func RenderedManifestsKey(manifestPath string) string {
+	relative := filepath.Clean(manifestPath)
+	if DefaultManifestPath != "" {
+		relative = strings.TrimPrefix(relative, filepath.Clean(DefaultManifestPath))
+	}
+	name := invalidKeyCharacters.ReplaceAllString(strings.ReplaceAll(strings.Trim(relative, "/"), "/", "."), "-")
+	if name == "" || name == "." {
+		name = "manifests"
+	}
+
+	return name + renderedManifestsSuffix
+}
+
+// publishRenderedManifests stores the manifests of the path exactly as they are about to be applied for the given
+// component, so users can compare them with what is running in the cluster (e.g. when testing devFlags or custom
+// overlays). Publishing is opt-in by setting annotations.PublishRenderedManifests to "true" on the owner. Otherwise, or
+// when the component is disabled, a previously published ConfigMap is removed.
+func publishRenderedManifests(ctx context.Context, cli client.Client, owner metav1.Object, resMap resmap.ResMap,
+	namespace, componentName, manifestPath string, componentEnabled bool,
+) error {
+	existing := &corev1.ConfigMap{}
+	err := cli.Get(ctx, client.ObjectKey{Name: RenderedManifestsConfigMapName(componentName), Namespace: namespace}, existing)
+	found := err == nil
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	if !componentEnabled || owner.GetAnnotations()[annotations.PublishRenderedManifests] != "true" {
+		if !found {
+			return nil
+		}
+		return client.IgnoreNotFound(cli.Delete(ctx, existing))
+	}
+
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return fmt.Errorf("failed to serialize rendered manifests of %s: %w", componentName, err)
+	}
+
+	// the keys of the other paths of the component are kept, the ones of this path are replaced, so switching between
+	// plain and compressed form does not leave stale keys behind
+	key := RenderedManifestsKey(manifestPath)
+	gzipKey := strings.TrimSuffix(key, renderedManifestsSuffix) + renderedManifestsGzipSuffix
+	data := map[string]string{}
+	binaryData := map[string][]byte{}
+	available := maxConfigMapDataSize
+	for k, v := range existing.Data {
+		if k != key {
+			data[k] = v
+			available -= len(k) + len(v)
+		}
+	}
+	for k, v := range existing.BinaryData {
+		if k != gzipKey {
+			binaryData[k] = v
+			available -= len(k) + len(v)
+		}
+	}
+	if len(rendered) <= available {
+		data[key] = string(rendered)
+	} else {
+		compressed, errGzip := gzipBytes(rendered)
+		if errGzip != nil {
+			return fmt.Errorf("failed to compress rendered manifests of %s: %w", componentName, errGzip)
+		}
+		if len(compressed) <= available {
+			binaryData[gzipKey] = compressed
+		} else {
+			logf.FromContext(ctx).Info("rendered manifests are too large to be published, skipping", "component", componentName,
+				"path", manifestPath, "size", len(rendered))
+		}
+	}
+	if len(data) == 0 {
+		data = nil
+	}
+	if len(binaryData) == 0 {
+		binaryData = nil
+	}
+
+	if !found {
+		desired := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      RenderedManifestsConfigMapName(componentName),
+				Namespace: namespace,
+			},
+			Data:       data,
+			BinaryData: binaryData,
+		}
+		if errMeta := cluster.ApplyMetaOptions(desired,
+			cluster.OwnedBy(owner, cli.Scheme()),
+			cluster.WithLabels(labels.ODH.Component(componentName), "true", labels.K8SCommon.PartOf, componentName),
+		); errMeta != nil {
+			return errMeta
+		}
+		return cli.Create(ctx, desired)
+	}
+	if reflect.DeepEqual(existing.Data, data) && reflect.DeepEqual(existing.BinaryData, binaryData) {
+		return nil
+	}
+	existing.Data = data
+	existing.BinaryData = binaryData
+
+	return cli.Update(ctx, existing)
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,201 @@
+package deploy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func renderedResMap(name, value string) resmap.ResMap {
+	GinkgoHelper()
+	resMap, err := resmap.NewFactory(provider.NewDefaultDepProvider().GetResourceFactory()).NewResMapFromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + name + `
+data:
+  setting: "` + value + `"
+`))
+	Expect(err).NotTo(HaveOccurred())
+
+	return resMap
+}
+
+var _ = Describe("Rendered manifests key", func() {
+	BeforeEach(func() {
+		defaultManifestPath := DefaultManifestPath
+		DefaultManifestPath = "/opt/manifests"
+		DeferCleanup(func() { DefaultManifestPath = defaultManifestPath })
+	})
+
+	DescribeTable("should be derived from the path relative to the manifests",
+		func(path, expected string) {
+			Expect(RenderedManifestsKey(path)).To(Equal(expected))
+		},
+		Entry("of a component", "/opt/manifests/dashboard/odh", "dashboard.odh.yaml"),
+		Entry("with a trailing slash", "/opt/manifests/kserve/overlays/odh/", "kserve.overlays.odh.yaml"),
+		Entry("with characters invalid in keys", "/opt/manifests/workbenches/kf-notebook@main", "workbenches.kf-notebook-main.yaml"),
+		Entry("of the manifests themselves", "/opt/manifests", "manifests.yaml"),
+		Entry("outside of the manifests", "/tmp/custom/odh", "tmp.custom.odh.yaml"),
+	)
+})
+
+var _ = Describe("Publishing rendered manifests", func() {
+	var (
+		owner   *corev1.ConfigMap
+		funcs   interceptor.Funcs
+		cli     client.Client
+		updates int
+		key     = client.ObjectKey{Name: RenderedManifestsConfigMapName("kserve"), Namespace: "opendatahub"}
+	)
+
+	publish := func(ctx context.Context, path, value string, enabled bool) error {
+		return publishRenderedManifests(ctx, cli, owner, renderedResMap(path, value), "opendatahub", "kserve", path, enabled)
+	}
+	published := func(ctx context.Context) *corev1.ConfigMap {
+		GinkgoHelper()
+		cm := &corev1.ConfigMap{}
+		Expect(cli.Get(ctx, key, cm)).To(Succeed())
+		return cm
+	}
+	uncompressed := func(data []byte) string {
+		GinkgoHelper()
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		owner = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc", Annotations: map[string]string{
+			annotations.PublishRenderedManifests: "true",
+		}}}
+		updates = 0
+		funcs = interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				updates++
+				return c.Update(ctx, obj, opts...)
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cli = fake.NewClientBuilder().WithInterceptorFuncs(funcs).Build()
+	})
+
+	It("should publish several paths of a component side by side", func(ctx context.Context) {
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+		Expect(publish(ctx, "odh-model-controller", "v1", true)).To(Succeed())
+
+		cm := published(ctx)
+		Expect(cm.Data).To(HaveLen(2))
+		Expect(cm.Data).To(HaveKeyWithValue("kserve.yaml", ContainSubstring("name: kserve")))
+		Expect(cm.Data).To(HaveKeyWithValue("odh-model-controller.yaml", ContainSubstring("name: odh-model-controller")))
+		Expect(cm.OwnerReferences).To(ConsistOf(HaveField("Name", "default-dsc")))
+		Expect(cm.Labels).To(HaveKeyWithValue(labels.K8SCommon.PartOf, "kserve"))
+	})
+
+	It("should replace the manifests of the path and keep the other ones", func(ctx context.Context) {
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+		Expect(publish(ctx, "odh-model-controller", "v1", true)).To(Succeed())
+		Expect(publish(ctx, "kserve", "v2", true)).To(Succeed())
+
+		cm := published(ctx)
+		Expect(cm.Data).To(HaveKeyWithValue("kserve.yaml", ContainSubstring("v2")))
+		Expect(cm.Data).To(HaveKeyWithValue("odh-model-controller.yaml", ContainSubstring("v1")))
+	})
+
+	It("should not update the ConfigMap when the manifests did not change", func(ctx context.Context) {
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+
+		Expect(updates).To(BeZero())
+	})
+
+	When("the manifests are too large for the ConfigMap", func() {
+		large := strings.Repeat("a", maxConfigMapDataSize)
+
+		It("should compress them", func(ctx context.Context) {
+			Expect(publish(ctx, "kserve", large, true)).To(Succeed())
+
+			cm := published(ctx)
+			Expect(cm.Data).NotTo(HaveKey("kserve.yaml"))
+			Expect(uncompressed(cm.BinaryData["kserve.yaml.gz"])).To(ContainSubstring(large))
+		})
+
+		It("should drop the compressed manifests once they fit again", func(ctx context.Context) {
+			Expect(publish(ctx, "kserve", large, true)).To(Succeed())
+			Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+
+			cm := published(ctx)
+			Expect(cm.BinaryData).To(BeEmpty())
+			Expect(cm.Data).To(HaveKeyWithValue("kserve.yaml", ContainSubstring("v1")))
+		})
+
+		It("should skip them when they do not fit even compressed", func(ctx context.Context) {
+			random := make([]byte, maxConfigMapDataSize)
+			_, err := rand.Read(random)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(publish(ctx, "odh-model-controller", "v1", true)).To(Succeed())
+			Expect(publish(ctx, "kserve", base64.StdEncoding.EncodeToString(random), true)).To(Succeed())
+
+			cm := published(ctx)
+			Expect(cm.Data).To(HaveKey("odh-model-controller.yaml"))
+			Expect(cm.Data).NotTo(HaveKey("kserve.yaml"))
+			Expect(cm.BinaryData).To(BeEmpty())
+		})
+	})
+
+	It("should delete the ConfigMap once the component is disabled", func(ctx context.Context) {
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+		Expect(publish(ctx, "kserve", "v1", false)).To(Succeed())
+
+		Expect(k8serr.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	It("should delete the ConfigMap once the annotation is removed", func(ctx context.Context) {
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+		owner.Annotations = nil
+		Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+
+		Expect(k8serr.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	When("the owner is not annotated", func() {
+		BeforeEach(func() {
+			owner.Annotations = map[string]string{annotations.PublishRenderedManifests: "false"}
+			funcs.Delete = func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				Fail("rendered manifests never published should not be deleted")
+				return nil
+			}
+		})
+
+		It("should neither publish nor delete anything", func(ctx context.Context) {
+			Expect(publish(ctx, "kserve", "v1", true)).To(Succeed())
+			Expect(publish(ctx, "kserve", "v1", false)).To(Succeed())
+
+			Expect(k8serr.IsNotFound(cli.Get(ctx, key, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+	})
+})
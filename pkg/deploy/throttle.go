@@ -0,0 +1,114 @@
+package deploy
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+)
+
+// ApplyProgressFunc is called after each batch of resources rendered from the manifests path has been applied.
+type ApplyProgressFunc func(manifestPath string, applied, total int)
+
+// applyThrottle holds the settings of a component to apply its resources with.
+type applyThrottle struct {
+	batchSize int
+	limiter   flowcontrol.RateLimiter
+	progress  ApplyProgressFunc
+}
+
+type applyThrottleKey struct{}
+
+// WithApplySettings applies resources deployed with the returned context in batches, calling progress after each of
+// them, and limits the rate of requests sent to the API server, as set in the settings of the component.
+// The rate is shared by all manifests deployed with the context.
+func WithApplySettings(ctx context.Context, settings *components.ApplySettings, progress ApplyProgressFunc) context.Context {
+	if settings == nil {
+		return ctx
+	}
+
+	throttle := &applyThrottle{batchSize: int(settings.BatchSize), progress: progress}
+	if settings.QPS > 0 {
+		burst := settings.Burst
+		if burst == 0 {
+			burst = settings.QPS
+		}
+		throttle.limiter = flowcontrol.NewTokenBucketRateLimiter(float32(settings.QPS), int(burst))
+	}
+
+	return context.WithValue(ctx, applyThrottleKey{}, throttle)
+}
+
+func applyThrottleFrom(ctx context.Context) *applyThrottle {
+	throttle, _ := ctx.Value(applyThrottleKey{}).(*applyThrottle)
+	return throttle
+}
+
+// client returns the client to apply resources with, waiting for the rate limiter before each request.
+func (t *applyThrottle) client(cli client.Client) client.Client {
+	if t == nil || t.limiter == nil {
+		return cli
+	}
+
+	return &throttledClient{Client: cli, limiter: t.limiter}
+}
+
+// batchApplied reports progress when the resources applied so far complete a batch, or all of them have been applied.
+func (t *applyThrottle) batchApplied(manifestPath string, applied, total int) {
+	if t == nil || t.batchSize == 0 || t.progress == nil || total <= t.batchSize {
+		return
+	}
+	if applied%t.batchSize == 0 || applied == total {
+		t.progress(manifestPath, applied, total)
+	}
+}
+
+// throttledClient waits for the rate limiter before sending requests to the API server.
+type throttledClient struct {
+	client.Client
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *throttledClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *throttledClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *throttledClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *throttledClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
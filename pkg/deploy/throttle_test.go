@@ -0,0 +1,131 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingLimiter counts the requests it lets through, or rejects them with err.
+type countingLimiter struct {
+	flowcontrol.RateLimiter
+	waits int
+	err   error
+}
+
+func (l *countingLimiter) Wait(_ context.Context) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.waits++
+	return nil
+}
+
+var _ = Describe("Applying resources with apply settings", func() {
+	var (
+		settings *components.ApplySettings
+		reported [][]int
+	)
+
+	throttle := func(ctx context.Context) *applyThrottle {
+		return applyThrottleFrom(WithApplySettings(ctx, settings, func(_ string, applied, total int) {
+			reported = append(reported, []int{applied, total})
+		}))
+	}
+
+	BeforeEach(func() {
+		settings = &components.ApplySettings{BatchSize: 2, QPS: 5}
+		reported = nil
+	})
+
+	Context("reporting progress", func() {
+		It("should report after each batch and at the end", func(ctx context.Context) {
+			t := throttle(ctx)
+			for applied := 1; applied <= 5; applied++ {
+				t.batchApplied("/opt/manifests/kserve", applied, 5)
+			}
+
+			Expect(reported).To(Equal([][]int{{2, 5}, {4, 5}, {5, 5}}))
+		})
+
+		It("should not report manifests applied in a single batch", func(ctx context.Context) {
+			throttle(ctx).batchApplied("/opt/manifests/kserve", 2, 2)
+
+			Expect(reported).To(BeEmpty())
+		})
+
+		It("should not report without batch size", func(ctx context.Context) {
+			settings.BatchSize = 0
+
+			throttle(ctx).batchApplied("/opt/manifests/kserve", 5, 5)
+
+			Expect(reported).To(BeEmpty())
+		})
+	})
+
+	Context("limiting the rate of requests", func() {
+		var (
+			limiter *countingLimiter
+			cm      *corev1.ConfigMap
+		)
+
+		BeforeEach(func() {
+			limiter = &countingLimiter{}
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "inferenceservice-config", Namespace: "opendatahub"}}
+		})
+
+		throttledClient := func(ctx context.Context) client.Client {
+			t := throttle(ctx)
+			Expect(t.limiter).ToNot(BeNil())
+			t.limiter = limiter
+			return t.client(fake.NewClientBuilder().Build())
+		}
+
+		It("should wait for the rate limiter before each request", func(ctx context.Context) {
+			cli := throttledClient(ctx)
+
+			Expect(cli.Create(ctx, cm)).To(Succeed())
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+			Expect(cli.Update(ctx, cm)).To(Succeed())
+			Expect(cli.List(ctx, &corev1.ConfigMapList{})).To(Succeed())
+			Expect(cli.Delete(ctx, cm)).To(Succeed())
+
+			Expect(limiter.waits).To(Equal(5))
+		})
+
+		It("should not send requests the rate limiter rejects", func(ctx context.Context) {
+			cli := throttledClient(ctx)
+			limiter.err = errors.New("context deadline exceeded")
+
+			Expect(cli.Create(ctx, cm)).To(MatchError("context deadline exceeded"))
+
+			limiter.err = nil
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(cm), cm)).ToNot(Succeed())
+		})
+
+		It("should limit requests to the QPS of the settings", func(ctx context.Context) {
+			Expect(throttle(ctx).limiter.QPS()).To(BeNumerically("==", 5))
+		})
+	})
+
+	DescribeTable("should not throttle requests",
+		func(ctx context.Context, update func()) {
+			update()
+			cli := fake.NewClientBuilder().Build()
+
+			Expect(throttle(ctx).client(cli)).To(BeIdenticalTo(cli))
+		},
+		Entry("without apply settings", func() { settings = nil }),
+		Entry("without QPS", func() { settings.QPS = 0 }),
+	)
+})
@@ -0,0 +1,82 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// allowedClusterScopedKinds are the cluster-scoped kinds components ship with their manifests. Custom manifests
+// holding other cluster-scoped resources, e.g. a StorageClass or a PersistentVolume, are rejected as they would affect
+// the whole cluster instead of the component.
+var allowedClusterScopedKinds = map[string]bool{
+	"CustomResourceDefinition":       true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
+	"APIService":                     true,
+	"PriorityClass":                  true,
+	"SecurityContextConstraints":     true,
+	"ConsoleLink":                    true,
+}
+
+// requiredKinds are the kinds every component deploys at least one resource of.
+var requiredKinds = []string{"Deployment"}
+
+// InvalidManifestsError lists why custom manifests of a component cannot be deployed.
+type InvalidManifestsError struct {
+	Path     string
+	Problems []string
+}
+
+func (e *InvalidManifestsError) Error() string {
+	return fmt.Sprintf("invalid manifests in %s: %s", e.Path, strings.Join(e.Problems, "; "))
+}
+
+// isCustomManifests tells whether the manifests of the path were downloaded through devFlags.
+func isCustomManifests(manifestPath string) bool {
+	provenance, found := bundleOf(manifestPath)
+
+	return found && provenance.Source != status.ManifestsSourceEmbedded
+}
+
+// invalidManifests wraps the problems found in custom manifests, for the component to fail before any of them are
+// applied, with the remediation surfaced in the status of the DataScienceCluster.
+func invalidManifests(manifestPath string, problems ...string) error {
+	return status.NewRemediationError(status.RemediationInvalidManifests,
+		"Fix the manifests referenced by the devFlags of the component, or remove the devFlags to deploy the embedded manifests",
+		&InvalidManifestsError{Path: manifestPath, Problems: problems})
+}
+
+// validateManifests checks rendered custom manifests contain the kinds required by a component, and no other
+// namespace nor cluster-scoped resources than the ones components are expected to ship.
+func validateManifests(manifestPath string, resMap resmap.ResMap, namespace string) error {
+	var problems []string
+	found := map[string]bool{}
+	for _, res := range resMap.Resources() {
+		kind := res.GetKind()
+		found[kind] = true
+		switch {
+		case kind == "Namespace":
+			if res.GetName() != namespace {
+				problems = append(problems, fmt.Sprintf("Namespace %s is not the applications namespace", res.GetName()))
+			}
+		case res.CurId().IsClusterScoped() && !allowedClusterScopedKinds[kind]:
+			problems = append(problems, fmt.Sprintf("cluster-scoped %s %s is not expected in component manifests", kind, res.GetName()))
+		}
+	}
+	for _, kind := range requiredKinds {
+		if !found[kind] {
+			problems = append(problems, fmt.Sprintf("no %s found", kind))
+		}
+	}
+	if len(problems) > 0 {
+		return invalidManifests(manifestPath, problems...)
+	}
+
+	return nil
+}
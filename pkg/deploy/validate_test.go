@@ -0,0 +1,114 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/onsi/gomega/types"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const validManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+`
+
+var _ = Describe("Custom manifests validation", func() {
+	var dir string
+
+	// manifests writes the given files to the manifests directory of the component.
+	manifests := func(files map[string]string) {
+		GinkgoHelper()
+		for file, content := range files {
+			writeManifests(filepath.Join(dir, file), content)
+		}
+	}
+	render := func(ctx context.Context, enabled bool) error {
+		_, _, err := renderManifests(ctx, dir, "opendatahub", "component", enabled)
+		return err
+	}
+
+	BeforeEach(func() {
+		manifestPath := DefaultManifestPath
+		DefaultManifestPath = GinkgoT().TempDir()
+		DeferCleanup(func() { DefaultManifestPath = manifestPath })
+		dir = filepath.Join(DefaultManifestPath, "component")
+		recordBundle(status.ManifestsProvenance{Path: "component", Source: "https://example.com/tarball/main"})
+	})
+
+	DescribeTable("should reject invalid manifests before applying any of them",
+		func(ctx context.Context, files map[string]string, problems types.GomegaMatcher) {
+			manifests(files)
+
+			// the client is never reached, invalid manifests are rejected before anything is applied
+			err := DeployManifestsFromPath(ctx, nil, nil, dir, "opendatahub", "component", true)
+
+			var invalid *InvalidManifestsError
+			Expect(errors.As(err, &invalid)).To(BeTrue())
+			Expect(invalid.Path).To(Equal(dir))
+			Expect(invalid.Problems).To(problems)
+			Expect(status.RemediationFor("component", err).Code).To(Equal(status.RemediationInvalidManifests))
+		},
+		Entry("when kustomize fails to build them",
+			map[string]string{"kustomization.yaml": "resources:\n- missing.yaml\n"},
+			// the error of kustomize is reported as is
+			ConsistOf(HavePrefix("kustomize build failed: "))),
+		Entry("when they have no Deployment",
+			map[string]string{
+				"kustomization.yaml": "resources:\n- sa.yaml\n",
+				"sa.yaml":            "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: controller\n",
+			},
+			Equal([]string{"no Deployment found"})),
+		Entry("when they hold other namespaces or unexpected cluster-scoped resources",
+			map[string]string{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n- cluster.yaml\n",
+				"deployment.yaml":    validManifest,
+				"cluster.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n---\n" +
+					"apiVersion: storage.k8s.io/v1\nkind: StorageClass\nmetadata:\n  name: fast\nprovisioner: example.com/fast\n",
+			},
+			Equal([]string{
+				"Namespace other is not the applications namespace",
+				"cluster-scoped StorageClass fast is not expected in component manifests",
+			})),
+	)
+
+	Context("with valid manifests", func() {
+		BeforeEach(func() {
+			manifests(map[string]string{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n- cluster.yaml\n",
+				"deployment.yaml":    validManifest,
+				"cluster.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: opendatahub\n---\n" +
+					"apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: controller\n",
+			})
+		})
+
+		It("should accept the applications namespace and the cluster-scoped kinds of components", func(ctx context.Context) {
+			Expect(render(ctx, true)).To(Succeed())
+		})
+	})
+
+	Context("with manifests which are not validated", func() {
+		BeforeEach(func() {
+			manifests(map[string]string{
+				"kustomization.yaml": "resources:\n- sa.yaml\n",
+				"sa.yaml":            "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: controller\n",
+			})
+		})
+
+		It("should not validate the manifests of disabled components, for whatever they hold to be removed", func(ctx context.Context) {
+			Expect(render(ctx, false)).To(Succeed())
+		})
+
+		It("should not validate embedded manifests", func(ctx context.Context) {
+			recordBundle(status.ManifestsProvenance{Path: "component", Source: status.ManifestsSourceEmbedded})
+
+			Expect(render(ctx, true)).To(Succeed())
+		})
+	})
+})
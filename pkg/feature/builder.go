@@ -10,6 +10,7 @@ import (
 
 	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
+	ctrlogger "github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
 )
 
 type partialBuilder func(f *Feature) error
@@ -196,7 +197,7 @@ func (fb *featureBuilder) Create() (*Feature, error) {
 		Name:    fb.featureName,
 		Managed: fb.managed,
 		Enabled: alwaysEnabled,
-		Log:     log.Log.WithName("features").WithValues("feature", fb.featureName),
+		Log:     ctrlogger.NewComponentLogger(log.Log, fb.featureName, "features", "").WithValues("feature", fb.featureName),
 		source:  &fb.source,
 		owner:   fb.owner,
 	}
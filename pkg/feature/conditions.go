@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 )
 
@@ -39,6 +40,10 @@ func (e *MissingOperatorError) Error() string {
 	return fmt.Sprintf("missing operator %q", e.operatorName)
 }
 
+func (e *MissingOperatorError) RemediationHint() (string, string) {
+	return status.RemediationMissingOperator, fmt.Sprintf("Install the %s operator from OperatorHub", e.operatorName)
+}
+
 func EnsureOperatorIsInstalled(operatorName string) Action {
 	return func(ctx context.Context, cli client.Client, f *Feature) error {
 		if found, err := cluster.SubscriptionExists(ctx, cli, operatorName); !found || err != nil {
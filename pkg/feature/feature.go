@@ -2,10 +2,13 @@ package feature
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
+	"go.opentelemetry.io/otel/attribute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/resource"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 )
 
 // Feature is a high-level abstraction that represents a collection of resources and actions
@@ -95,7 +99,11 @@ func (f *Feature) Apply(ctx context.Context, cli client.Client) error {
 		return updateErr
 	}
 
-	applyErr := f.applyFeature(ctx, cli)
+	start := time.Now()
+	applyCtx, span := tracing.Start(ctx, "apply feature", attribute.String("feature", f.Name))
+	applyErr := f.applyFeature(applyCtx, cli)
+	tracing.End(span, applyErr)
+	observeApply(f, time.Since(start).Seconds(), applyErr)
 	_, reportErr := createFeatureTrackerStatusReporter(cli, f).ReportCondition(ctx, applyErr)
 
 	return multierror.Append(applyErr, reportErr).ErrorOrNil()
@@ -124,9 +132,14 @@ func (f *Feature) applyFeature(ctx context.Context, cli client.Client) error {
 		}
 	}
 
+	metaOptions := append(DefaultMetaOptions(f), cluster.WithResourceMetadataFrom(ctx))
 	for i := range f.appliers {
 		r := f.appliers[i]
-		if processErr := r.Apply(ctx, cli, f.data, DefaultMetaOptions(f)...); processErr != nil {
+		if processErr := r.Apply(ctx, cli, f.data, metaOptions...); processErr != nil {
+			var conflictErr *resource.OwnershipConflictError
+			if errors.As(processErr, &conflictErr) {
+				return &withConditionReasonError{reason: featurev1.ConditionReason.OwnershipConflict, err: processErr}
+			}
 			return &withConditionReasonError{reason: featurev1.ConditionReason.ApplyManifests, err: processErr}
 		}
 	}
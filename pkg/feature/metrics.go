@@ -0,0 +1,31 @@
+package feature
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// applyDuration measures how long it takes to apply a feature, including its data providers,
+	// pre- and post-conditions, so the slow ones can be spotted on busy clusters.
+	applyDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "odh_feature_apply_duration_seconds",
+			Help:    "Time spent applying a feature, labeled by feature name and result.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"feature", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(applyDuration)
+}
+
+func observeApply(f *Feature, seconds float64, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	applyDuration.WithLabelValues(f.Name, result).Observe(seconds)
+}
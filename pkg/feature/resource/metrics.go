@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// reconcileDuration measures how long it takes to reconcile a single resource owned by a feature,
+	// such as AuthConfig, VirtualService or Gateway. The set of resources is bound by the embedded
+	// manifests, so labeling by target resource keeps the cardinality under control.
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "odh_feature_resource_reconcile_duration_seconds",
+			Help:    "Time spent reconciling a resource owned by a feature, labeled by target resource and result.",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		},
+		[]string{"group", "kind", "namespace", "name", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration)
+}
+
+func observeReconcile(obj *unstructured.Unstructured, seconds float64, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	gvk := obj.GroupVersionKind()
+	reconcileDuration.WithLabelValues(gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName(), result).Observe(seconds)
+}
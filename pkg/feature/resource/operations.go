@@ -3,14 +3,19 @@ package resource
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/tracing"
 )
 
 func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstructured, metaOptions ...cluster.MetaOptions) error {
@@ -21,29 +26,106 @@ func Apply(ctx context.Context, cli client.Client, objects []*unstructured.Unstr
 			}
 		}
 
-		target := source.DeepCopy()
+		start := time.Now()
+		gvk := source.GroupVersionKind()
+		applyCtx, span := tracing.Start(ctx, "reconcile feature resource",
+			attribute.String("group", gvk.Group), attribute.String("kind", gvk.Kind),
+			attribute.String("namespace", source.GetNamespace()), attribute.String("name", source.GetName()))
+		err := apply(applyCtx, cli, source)
+		tracing.End(span, err)
+		observeReconcile(source, time.Since(start).Seconds(), err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, cli client.Client, source *unstructured.Unstructured) error {
+	target := source.DeepCopy()
 
-		name := source.GetName()
-		namespace := source.GetNamespace()
+	name := source.GetName()
+	namespace := source.GetNamespace()
+
+	errGet := cli.Get(ctx, k8stypes.NamespacedName{Name: name, Namespace: namespace}, target)
+	if client.IgnoreNotFound(errGet) != nil {
+		return fmt.Errorf("failed to get resource %s/%s: %w", namespace, name, errGet)
+	}
 
-		errGet := cli.Get(ctx, k8stypes.NamespacedName{Name: name, Namespace: namespace}, target)
-		if client.IgnoreNotFound(errGet) != nil {
-			return fmt.Errorf("failed to get resource %s/%s: %w", namespace, name, errGet)
+	justCreated := false
+	if k8serr.IsNotFound(errGet) {
+		if errCreate := cli.Create(ctx, target); client.IgnoreAlreadyExists(errCreate) != nil {
+			return fmt.Errorf("failed to create source %s/%s: %w", namespace, name, errCreate)
 		}
 
-		justCreated := false
-		if k8serr.IsNotFound(errGet) {
-			if errCreate := cli.Create(ctx, target); client.IgnoreAlreadyExists(errCreate) != nil {
-				return fmt.Errorf("failed to create source %s/%s: %w", namespace, name, errCreate)
-			}
+		justCreated = true
+	}
 
-			justCreated = true
+	if !justCreated && shouldReconcile(source) && !cluster.IsUnmanaged(target) {
+		if adopt, errAdopt := cluster.ShouldAdopt(ctx, target); errAdopt != nil || !adopt {
+			return errAdopt
+		}
+		if errOwnership := checkOwnership(ctx, cli, source, target); errOwnership != nil {
+			return errOwnership
+		}
+		if errUpdate := patchUsingApplyStrategy(ctx, cli, source, target); errUpdate != nil {
+			return fmt.Errorf("failed to reconcile resource %s/%s: %w", namespace, name, errUpdate)
 		}
+	}
 
-		if !justCreated && shouldReconcile(source) {
-			if errUpdate := patchUsingApplyStrategy(ctx, cli, source, target); errUpdate != nil {
-				return fmt.Errorf("failed to reconcile resource %s/%s: %w", namespace, name, errUpdate)
-			}
+	return nil
+}
+
+// OwnershipConflictError is returned when a resource of a feature is owned by the FeatureTracker of another feature,
+// e.g. of another operator managing the same resources in a mixed installation, which has not handed it over.
+type OwnershipConflictError struct {
+	Resource string
+	Owner    string
+}
+
+func (e *OwnershipConflictError) Error() string {
+	return fmt.Sprintf("resource %s is managed by FeatureTracker %s, set the %s annotation on it to take it over",
+		e.Resource, e.Owner, annotations.TransferOwnershipTo)
+}
+
+// checkOwnership tells whether the target can be applied by the FeatureTrackers owning the source. FeatureTrackers
+// owning the target besides them keep it, unless they no longer exist, e.g. their operator is uninstalled, or the
+// target is handed over to one of the source owners with the TransferOwnershipTo annotation. Otherwise, both
+// operators would take the owner reference and fields of the target back from each other on every reconcile.
+func checkOwnership(ctx context.Context, cli client.Client, source, target *unstructured.Unstructured) error {
+	owners := map[k8stypes.UID]bool{}
+	var ownerNames []string
+	for _, ref := range source.GetOwnerReferences() {
+		if ref.Kind == "FeatureTracker" {
+			owners[ref.UID] = true
+			ownerNames = append(ownerNames, ref.Name)
+		}
+	}
+	if len(owners) == 0 || slices.Contains(ownerNames, target.GetAnnotations()[annotations.TransferOwnershipTo]) {
+		return nil
+	}
+
+	for _, ref := range target.GetOwnerReferences() {
+		if ref.Kind != "FeatureTracker" || owners[ref.UID] {
+			continue
+		}
+		tracker := &featurev1.FeatureTracker{}
+		err := cli.Get(ctx, client.ObjectKey{Name: ref.Name}, tracker)
+		if k8serr.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get FeatureTracker %s owning resource %s/%s: %w", ref.Name, target.GetNamespace(), target.GetName(), err)
+		}
+		// a FeatureTracker recreated with the same name is not the owner anymore
+		if tracker.GetUID() != ref.UID {
+			continue
+		}
+
+		return &OwnershipConflictError{
+			Resource: fmt.Sprintf("%s %s/%s", target.GetKind(), target.GetNamespace(), target.GetName()),
+			Owner:    ref.Name,
 		}
 	}
 
@@ -0,0 +1,161 @@
+package resource
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func featureTracker(appNamespace string, uid types.UID) *featurev1.FeatureTracker {
+	tracker := featurev1.NewFeatureTracker("mesh-control-plane-creation", appNamespace)
+	tracker.UID = uid
+
+	return tracker
+}
+
+// controlPlane returns the ServiceMeshControlPlane of a feature, owned by the references.
+func controlPlane(owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("maistra.io/v2")
+	obj.SetKind("ServiceMeshControlPlane")
+	obj.SetNamespace("istio-system")
+	obj.SetName("data-science-smcp")
+	obj.SetOwnerReferences(owners)
+
+	return obj
+}
+
+var _ = Describe("Ownership of feature resources", func() {
+	var (
+		ours    = featureTracker("redhat-ods-applications", "ours")
+		theirs  = featureTracker("opendatahub", "theirs")
+		objects []client.Object
+		funcs   interceptor.Funcs
+		cli     client.Client
+	)
+
+	BeforeEach(func() {
+		objects = []client.Object{theirs.DeepCopy()}
+		funcs = interceptor.Funcs{}
+		cli = nil
+	})
+
+	fakeClient := func() client.Client {
+		if cli == nil {
+			scheme := runtime.NewScheme()
+			Expect(featurev1.AddToScheme(scheme)).To(Succeed())
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		}
+		return cli
+	}
+	check := func(ctx context.Context, source, target *unstructured.Unstructured) error {
+		return checkOwnership(ctx, fakeClient(), source, target)
+	}
+
+	It("should report a conflict with the FeatureTracker of another operator", func(ctx context.Context) {
+		err := check(ctx, controlPlane(ours.ToOwnerReference()), controlPlane(theirs.ToOwnerReference()))
+
+		var conflictErr *OwnershipConflictError
+		Expect(errors.As(err, &conflictErr)).To(BeTrue())
+		Expect(conflictErr.Owner).To(Equal(theirs.Name))
+		Expect(conflictErr.Resource).To(Equal("ServiceMeshControlPlane istio-system/data-science-smcp"))
+		Expect(err).To(MatchError(ContainSubstring("set the " + annotations.TransferOwnershipTo + " annotation on it to take it over")))
+	})
+
+	It("should report a conflict while another FeatureTracker owns the resource along with ours", func(ctx context.Context) {
+		err := check(ctx, controlPlane(ours.ToOwnerReference()), controlPlane(ours.ToOwnerReference(), theirs.ToOwnerReference()))
+
+		var conflictErr *OwnershipConflictError
+		Expect(errors.As(err, &conflictErr)).To(BeTrue())
+		Expect(conflictErr.Owner).To(Equal(theirs.Name))
+	})
+
+	It("should report a conflict when the resource is handed over to another FeatureTracker", func(ctx context.Context) {
+		target := controlPlane(theirs.ToOwnerReference())
+		target.SetAnnotations(map[string]string{annotations.TransferOwnershipTo: "odh-legacy-mesh-control-plane-creation"})
+
+		Expect(check(ctx, controlPlane(ours.ToOwnerReference()), target)).To(BeAssignableToTypeOf(&OwnershipConflictError{}))
+	})
+
+	DescribeTable("should let the resource be applied",
+		func(ctx context.Context, source, target *unstructured.Unstructured) {
+			Expect(check(ctx, source, target)).To(Succeed())
+		},
+		Entry("owned by our FeatureTracker",
+			controlPlane(ours.ToOwnerReference()), controlPlane(ours.ToOwnerReference())),
+		Entry("not owned by any FeatureTracker",
+			controlPlane(ours.ToOwnerReference()), controlPlane()),
+		Entry("owned by a FeatureTracker which does not exist anymore",
+			controlPlane(ours.ToOwnerReference()), controlPlane(featureTracker("odh-legacy", "legacy").ToOwnerReference())),
+		Entry("owned by a FeatureTracker recreated since",
+			controlPlane(ours.ToOwnerReference()), controlPlane(featureTracker("opendatahub", "former").ToOwnerReference())),
+		Entry("owned by resources other than FeatureTrackers",
+			controlPlane(ours.ToOwnerReference()),
+			controlPlane(metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "mesh", UID: "configmap"})),
+		Entry("of a source not owned by a FeatureTracker",
+			controlPlane(), controlPlane(theirs.ToOwnerReference())),
+	)
+
+	It("should let the resource handed over to our FeatureTracker be applied", func(ctx context.Context) {
+		target := controlPlane(theirs.ToOwnerReference())
+		target.SetAnnotations(map[string]string{annotations.TransferOwnershipTo: ours.Name})
+
+		Expect(check(ctx, controlPlane(ours.ToOwnerReference()), target)).To(Succeed())
+	})
+
+	It("should fail when the owning FeatureTracker cannot be read", func(ctx context.Context) {
+		funcs.Get = func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+			return errors.New("forbidden")
+		}
+
+		err := check(ctx, controlPlane(ours.ToOwnerReference()), controlPlane(theirs.ToOwnerReference()))
+		Expect(err).To(MatchError(ContainSubstring("failed to get FeatureTracker " + theirs.Name + " owning resource istio-system/data-science-smcp")))
+		Expect(err).ToNot(BeAssignableToTypeOf(&OwnershipConflictError{}))
+	})
+
+	Describe("Apply", func() {
+		It("should not apply a managed resource owned by another FeatureTracker", func(ctx context.Context) {
+			objects = append(objects, controlPlane(theirs.ToOwnerReference()))
+			patches := 0
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				patches++
+				return nil
+			}
+
+			source := controlPlane(ours.ToOwnerReference())
+			source.SetAnnotations(map[string]string{annotations.ManagedByODHOperator: "true"})
+			err := Apply(ctx, fakeClient(), []*unstructured.Unstructured{source})
+			Expect(err).To(BeAssignableToTypeOf(&OwnershipConflictError{}))
+			Expect(patches).To(BeZero())
+		})
+
+		It("should leave the existing resources not reconciled by the operator as they are", func(ctx context.Context) {
+			objects = append(objects, controlPlane(theirs.ToOwnerReference()))
+			funcs.Patch = func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return errors.New("unexpected patch")
+			}
+
+			Expect(Apply(ctx, fakeClient(), []*unstructured.Unstructured{controlPlane(ours.ToOwnerReference())})).To(Succeed())
+		})
+
+		It("should create the resource when it does not exist", func(ctx context.Context) {
+			Expect(Apply(ctx, fakeClient(), []*unstructured.Unstructured{controlPlane(ours.ToOwnerReference())})).To(Succeed())
+			created := controlPlane()
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(created), created)).To(Succeed())
+			Expect(created.GetOwnerReferences()).To(ConsistOf(ours.ToOwnerReference()))
+		})
+	})
+})
@@ -14,6 +14,9 @@ import (
 
 const DefaultCertificateSecretName = "knative-serving-cert"
 
+// CertificateUIDLabel marks Secrets KNative Serving reads certificates from.
+const CertificateUIDLabel = "networking.internal.knative.dev/certificate-uid"
+
 const (
 	servingKey              = "Serving"
 	certificateKey          = "KnativeCertificateSecret"
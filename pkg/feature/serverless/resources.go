@@ -2,7 +2,10 @@ package serverless
 
 import (
 	"context"
+	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
@@ -67,3 +70,40 @@ func getSecretParams(f *feature.Feature) (*secretParams, error) {
 
 	return result, nil
 }
+
+// ServingDomains returns the custom domains of served models mapped by KNative.
+func ServingDomains(serving *infrav1.ServingSpec) []infrav1.ServingDomain {
+	if serving.Config == nil || serving.Config.DomainMapping == nil {
+		return nil
+	}
+
+	return serving.Config.DomainMapping.Domains
+}
+
+// ServingDomainCertificates labels TLS Secrets of custom serving domains, as KNative only watches Secrets
+// labeled with a certificate UID (see serving-net-istio-secret-filtering.patch.tmpl.yaml).
+func ServingDomainCertificates(ctx context.Context, cli client.Client, f *feature.Feature) error {
+	serving, err := FeatureData.Serving.Extract(f)
+	if err != nil {
+		return err
+	}
+
+	for _, domain := range ServingDomains(&serving) {
+		if domain.TLSSecretName == "" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: domain.Namespace, Name: domain.TLSSecretName}, secret); err != nil {
+			return fmt.Errorf("failed to get TLS secret %s/%s of domain %s: %w", domain.Namespace, domain.TLSSecretName, domain.Name, err)
+		}
+		if _, labeled := secret.GetLabels()[CertificateUIDLabel]; labeled {
+			continue
+		}
+		patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, CertificateUIDLabel, string(secret.GetUID()))
+		if err := cli.Patch(ctx, secret, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+			return fmt.Errorf("failed to label TLS secret %s/%s of domain %s: %w", domain.Namespace, domain.TLSSecretName, domain.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -4,3 +4,6 @@ const (
 	ConfigMapAuthRef = "auth-refs"
 	ConfigMapMeshRef = "service-mesh-refs"
 )
+
+// SidecarInject annotation, or label, makes pods join the mesh.
+const SidecarInject = "sidecar.istio.io/inject"
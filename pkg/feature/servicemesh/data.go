@@ -2,13 +2,19 @@ package servicemesh
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
 // These keys are used in FeatureData struct, as fields of a struct are not accessible in closures which we define for
@@ -19,13 +25,29 @@ const (
 	authProviderNsKey    string = "AuthNamespace"
 	authProviderNameKey  string = "AuthProviderName"
 	authExtensionNameKey string = "AuthExtensionName"
+	authExemptionsKey    string = "AuthorizationExemptions"
+	authorizedNsKey      string = "AuthorizedNamespaces"
+	mtlsKey              string = "MTLS"
+	valuesKey            string = "Values"
 )
 
+// DefaultTemplateValues are used unless supplied through the templateValues of the DSCInitialization. Values without
+// a default are optional, templates refer to them with {{ index .Values "name" }} instead of {{ .Values.name }}.
+var DefaultTemplateValues = map[string]string{
+	"ingressGatewaySelector":   "ingressgateway",
+	"terminationDrainDuration": "35s",
+}
+
+// templateValuePattern keeps values from changing the structure of the manifests they are rendered into.
+var templateValuePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
 // FeatureData is a convention to simplify how the data for the Service Mesh features is Defined and accessed.
 // Being a "singleton" it is based on anonymous struct concept.
 var FeatureData = struct {
 	ControlPlane  feature.DataDefinition[dsciv1.DSCInitializationSpec, infrav1.ControlPlaneSpec]
 	Authorization AuthorizationData
+	MTLS          feature.DataDefinition[dsciv1.DSCInitializationSpec, []infrav1.NamespaceMTLS]
+	Values        feature.DataDefinition[dsciv1.DSCInitializationSpec, map[string]string]
 }{
 	ControlPlane: feature.DataDefinition[dsciv1.DSCInitializationSpec, infrav1.ControlPlaneSpec]{
 		Define: func(source *dsciv1.DSCInitializationSpec) feature.DataEntry[infrav1.ControlPlaneSpec] {
@@ -43,6 +65,8 @@ var FeatureData = struct {
 		Namespace:             authNs,
 		Provider:              authProvider,
 		ExtensionProviderName: authExtensionName,
+		Exemptions:            authExemptions,
+		AuthorizedNamespaces:  authorizedNamespaces,
 		All: func(source *dsciv1.DSCInitializationSpec) []feature.Action {
 			return []feature.Action{
 				authSpec.Define(source).AsAction(),
@@ -52,6 +76,62 @@ var FeatureData = struct {
 			}
 		},
 	},
+	MTLS: feature.DataDefinition[dsciv1.DSCInitializationSpec, []infrav1.NamespaceMTLS]{
+		Define: func(source *dsciv1.DSCInitializationSpec) feature.DataEntry[[]infrav1.NamespaceMTLS] {
+			return feature.DataEntry[[]infrav1.NamespaceMTLS]{
+				Key: mtlsKey,
+				Value: func(_ context.Context, _ client.Client) ([]infrav1.NamespaceMTLS, error) {
+					return MTLSNamespaces(source), nil
+				},
+			}
+		},
+		Extract: feature.ExtractEntry[[]infrav1.NamespaceMTLS](mtlsKey),
+	},
+	Values: feature.DataDefinition[dsciv1.DSCInitializationSpec, map[string]string]{
+		Define: func(source *dsciv1.DSCInitializationSpec) feature.DataEntry[map[string]string] {
+			return feature.DataEntry[map[string]string]{
+				Key: valuesKey,
+				Value: func(_ context.Context, _ client.Client) (map[string]string, error) {
+					return TemplateValues(source)
+				},
+			}
+		},
+		Extract: feature.ExtractEntry[map[string]string](valuesKey),
+	},
+}
+
+// TemplateValues returns the values supplied through the DSCInitialization merged over DefaultTemplateValues.
+func TemplateValues(source *dsciv1.DSCInitializationSpec) (map[string]string, error) {
+	values := maps.Clone(DefaultTemplateValues)
+	for name, value := range source.TemplateValues {
+		if !templateValuePattern.MatchString(value) {
+			return nil, fmt.Errorf("invalid template value %s=%q", name, value)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// MTLSNamespaces returns the namespaces where mutual TLS is configured, starting with the applications namespace.
+func MTLSNamespaces(source *dsciv1.DSCInitializationSpec) []infrav1.NamespaceMTLS {
+	mtls := source.ServiceMesh.MTLS
+	if mtls == nil {
+		return nil
+	}
+
+	mode := mtls.Mode
+	if mode == "" {
+		mode = "STRICT"
+	}
+	namespaces := []infrav1.NamespaceMTLS{{Name: source.ApplicationsNamespace, Mode: mode}}
+	for _, ns := range mtls.Namespaces {
+		if ns.Name != source.ApplicationsNamespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	return namespaces
 }
 
 type AuthorizationData struct {
@@ -59,6 +139,8 @@ type AuthorizationData struct {
 	Namespace             feature.DataDefinition[dsciv1.DSCInitializationSpec, string]
 	Provider              feature.DataDefinition[dsciv1.DSCInitializationSpec, string]
 	ExtensionProviderName feature.DataDefinition[dsciv1.DSCInitializationSpec, string]
+	Exemptions            feature.DataDefinition[dsciv1.DSCInitializationSpec, []string]
+	AuthorizedNamespaces  feature.DataDefinition[dsciv1.DSCInitializationSpec, []string]
 	All                   func(source *dsciv1.DSCInitializationSpec) []feature.Action
 }
 
@@ -114,3 +196,63 @@ var authExtensionName = feature.DataDefinition[dsciv1.DSCInitializationSpec, str
 	},
 	Extract: feature.ExtractEntry[string](authExtensionNameKey),
 }
+
+var authExemptions = feature.DataDefinition[dsciv1.DSCInitializationSpec, []string]{
+	Define: func(source *dsciv1.DSCInitializationSpec) feature.DataEntry[[]string] {
+		return feature.DataEntry[[]string]{
+			Key: authExemptionsKey,
+			Value: func(_ context.Context, _ client.Client) ([]string, error) {
+				return AuthorizationExemptions(source), nil
+			},
+		}
+	},
+	Extract: feature.ExtractEntry[[]string](authExemptionsKey),
+}
+
+var authorizedNamespaces = feature.DataDefinition[dsciv1.DSCInitializationSpec, []string]{
+	Define: func(source *dsciv1.DSCInitializationSpec) feature.DataEntry[[]string] {
+		return feature.DataEntry[[]string]{
+			Key: authorizedNsKey,
+			Value: func(ctx context.Context, cli client.Client) ([]string, error) {
+				return AuthorizedNamespaces(ctx, cli, source)
+			},
+		}
+	},
+	Extract: feature.ExtractEntry[[]string](authorizedNsKey),
+}
+
+// AuthorizationExemptions returns the namespaces exempted from authorization, sorted and without duplicates.
+func AuthorizationExemptions(source *dsciv1.DSCInitializationSpec) []string {
+	if source.ServiceMesh == nil || len(source.ServiceMesh.Auth.ExemptNamespaces) == 0 {
+		return nil
+	}
+
+	exemptions := slices.Clone(source.ServiceMesh.Auth.ExemptNamespaces)
+	slices.Sort(exemptions)
+
+	return slices.Compact(exemptions)
+}
+
+// AuthorizedNamespaces returns the members of the mesh where requests to model servers are authorized, sorted.
+// It is empty unless namespaces are exempted, as authorization then applies to the whole mesh.
+func AuthorizedNamespaces(ctx context.Context, cli client.Client, source *dsciv1.DSCInitializationSpec) ([]string, error) {
+	exemptions := AuthorizationExemptions(source)
+	if len(exemptions) == 0 {
+		return nil, nil
+	}
+
+	members := &corev1.NamespaceList{}
+	if err := cli.List(ctx, members, client.MatchingLabels{labels.MaistraMemberOf: source.ServiceMesh.ControlPlane.Namespace}); err != nil {
+		return nil, fmt.Errorf("failed to list members of the mesh: %w", err)
+	}
+
+	namespaces := []string{}
+	for _, namespace := range members.Items {
+		if !slices.Contains(exemptions, namespace.Name) {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	slices.Sort(namespaces)
+
+	return namespaces, nil
+}
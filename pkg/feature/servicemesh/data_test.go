@@ -0,0 +1,119 @@
+package servicemesh_test
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/feature/servicemesh"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template values", func() {
+	var spec *dsciv1.DSCInitializationSpec
+
+	BeforeEach(func() {
+		spec = &dsciv1.DSCInitializationSpec{TemplateValues: map[string]string{
+			"ingressGatewaySelector": "custom-gateway",
+			"proxyMemoryLimit":       "512Mi",
+		}}
+	})
+
+	It("should use the supplied values over the defaults", func() {
+		Expect(servicemesh.TemplateValues(spec)).To(Equal(map[string]string{
+			"ingressGatewaySelector":   "custom-gateway",
+			"proxyMemoryLimit":         "512Mi",
+			"terminationDrainDuration": "35s",
+		}))
+	})
+
+	It("should use the defaults when no value is supplied", func() {
+		spec.TemplateValues = nil
+
+		Expect(servicemesh.TemplateValues(spec)).To(Equal(servicemesh.DefaultTemplateValues))
+	})
+
+	It("should leave the defaults untouched", func() {
+		_, err := servicemesh.TemplateValues(spec)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(servicemesh.DefaultTemplateValues).To(HaveKeyWithValue("ingressGatewaySelector", "ingressgateway"))
+		Expect(servicemesh.DefaultTemplateValues).ToNot(HaveKey("proxyMemoryLimit"))
+	})
+
+	DescribeTable("should reject values changing the structure of the manifests",
+		func(value string) {
+			spec.TemplateValues["terminationDrainDuration"] = value
+
+			_, err := servicemesh.TemplateValues(spec)
+			Expect(err).To(MatchError(ContainSubstring("invalid template value terminationDrainDuration")))
+		},
+		Entry("with a new line", "35s\nextra: field"),
+		Entry("with a template action", "{{ .Values }}"),
+		Entry("when empty", ""),
+	)
+})
+
+var _ = Describe("Authorized namespaces", func() {
+	var (
+		cli  client.Client
+		spec *dsciv1.DSCInitializationSpec
+	)
+
+	member := func(name, controlPlane string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.MaistraMemberOf: controlPlane}}}
+	}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithObjects(
+			member("models", "istio-system"),
+			member("smoke-tests", "istio-system"),
+			member("demo", "istio-system"),
+			member("other-mesh", "other-system"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "outside"}},
+		).Build()
+		spec = &dsciv1.DSCInitializationSpec{ServiceMesh: &infrav1.ServiceMeshSpec{
+			ControlPlane: infrav1.ControlPlaneSpec{Namespace: "istio-system"},
+		}}
+	})
+
+	It("should be empty without exemptions, as authorization applies to the whole mesh", func(ctx context.Context) {
+		Expect(servicemesh.AuthorizationExemptions(spec)).To(BeEmpty())
+		Expect(servicemesh.AuthorizedNamespaces(ctx, cli, spec)).To(BeNil())
+	})
+
+	It("should be empty without service mesh", func(ctx context.Context) {
+		spec.ServiceMesh = nil
+
+		Expect(servicemesh.AuthorizationExemptions(spec)).To(BeEmpty())
+		Expect(servicemesh.AuthorizedNamespaces(ctx, cli, spec)).To(BeNil())
+	})
+
+	When("namespaces are exempted", func() {
+		BeforeEach(func() {
+			spec.ServiceMesh.Auth.ExemptNamespaces = []string{"smoke-tests", "demo", "smoke-tests"}
+		})
+
+		It("should sort the exemptions without duplicates", func() {
+			Expect(servicemesh.AuthorizationExemptions(spec)).To(Equal([]string{"demo", "smoke-tests"}))
+		})
+
+		It("should be the members of the mesh which are not exempted", func(ctx context.Context) {
+			Expect(servicemesh.AuthorizedNamespaces(ctx, cli, spec)).To(Equal([]string{"models"}))
+		})
+
+		It("should be empty, not nil, when all members are exempted", func(ctx context.Context) {
+			spec.ServiceMesh.Auth.ExemptNamespaces = append(spec.ServiceMesh.Auth.ExemptNamespaces, "models")
+
+			Expect(servicemesh.AuthorizedNamespaces(ctx, cli, spec)).To(And(Not(BeNil()), BeEmpty()))
+		})
+	})
+})
@@ -85,3 +85,8 @@ func AuthRefs(ctx context.Context, cli client.Client, f *feature.Feature) error
 		feature.OwnedBy(f),
 	)
 }
+
+// HasSidecarInjected tells whether pods created from the template get the mesh sidecar injected.
+func HasSidecarInjected(template *corev1.PodTemplateSpec) bool {
+	return template.GetAnnotations()[SidecarInject] == "true" || template.GetLabels()[SidecarInject] == "true"
+}
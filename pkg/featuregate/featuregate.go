@@ -0,0 +1,190 @@
+// Package featuregate guards experimental functionality of the operator behind named gates, which can be enabled
+// with the FEATURE_GATES environment variable or in the OperatorConfig.
+package featuregate
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnvVar is the environment variable holding gates to enable or disable on startup,
+// as a comma separated list of name=bool pairs, e.g. "KServeRawDeployment=true,ComponentSelfHealing=false".
+const EnvVar = "FEATURE_GATES"
+
+// Stage is the maturity of a gated functionality.
+type Stage string
+
+const (
+	// Alpha functionality is disabled by default.
+	Alpha Stage = "Alpha"
+	// Beta functionality is enabled by default, but can still be disabled.
+	Beta Stage = "Beta"
+	// GA functionality is always enabled, the gate is kept for compatibility only.
+	GA Stage = "GA"
+)
+
+// Known gates.
+const (
+	// KServeRawDeployment allows RawDeployment as default deployment mode of KServe.
+	KServeRawDeployment = "KServeRawDeployment"
+	// ComponentSelfHealing allows remediation of unavailable component Deployments.
+	ComponentSelfHealing = "ComponentSelfHealing"
+	// ComponentConfigRollout restarts component Deployments when ConfigMaps or Secrets they consume change.
+	ComponentConfigRollout = "ComponentConfigRollout"
+	// RouteHealthMonitoring probes Routes and VirtualServices of components for reachability and certificate expiry.
+	RouteHealthMonitoring = "RouteHealthMonitoring"
+	// ModelRegistryDashboardSync publishes connection details of available model registries into the dashboard config.
+	ModelRegistryDashboardSync = "ModelRegistryDashboardSync"
+	// SecurityPolicyReports summarizes the security posture of the platform in PolicyReports for compliance scanners.
+	SecurityPolicyReports = "SecurityPolicyReports"
+	// MultiClusterFederation distributes the platform from an Open Cluster Management hub to managed clusters.
+	MultiClusterFederation = "MultiClusterFederation"
+	// ManagedClusterClaims publishes the health and capabilities of the platform as ClusterClaims of a managed cluster.
+	ManagedClusterClaims = "ManagedClusterClaims"
+	// DeprecatedAPIReport lists resources of data science projects using APIs deprecated by upcoming component versions.
+	DeprecatedAPIReport = "DeprecatedAPIReport"
+	// ServingCatalog lists the models served in data science projects in a cluster-scoped ServingCatalog.
+	ServingCatalog = "ServingCatalog"
+	// WebhookFailurePolicyDowngrade sets non-critical webhooks of the operator to ignore failures while they are unavailable.
+	WebhookFailurePolicyDowngrade = "WebhookFailurePolicyDowngrade"
+	// SecretRotationReport lists the certificates and secrets managed by the operator with their age, expiry and last rotation.
+	SecretRotationReport = "SecretRotationReport"
+	// AdmissionPolicies enforces the validation rules of the operator webhook expressible in CEL with ValidatingAdmissionPolicies.
+	AdmissionPolicies = "AdmissionPolicies"
+)
+
+var stages = map[string]Stage{
+	KServeRawDeployment:           Beta,
+	ComponentSelfHealing:          Beta,
+	ComponentConfigRollout:        Beta,
+	RouteHealthMonitoring:         Beta,
+	ModelRegistryDashboardSync:    Beta,
+	SecurityPolicyReports:         Alpha,
+	MultiClusterFederation:        Alpha,
+	ManagedClusterClaims:          Alpha,
+	DeprecatedAPIReport:           Beta,
+	ServingCatalog:                Alpha,
+	WebhookFailurePolicyDowngrade: Alpha,
+	SecretRotationReport:          Alpha,
+	AdmissionPolicies:             Alpha,
+}
+
+// Status tells whether a gate is enabled.
+type Status struct {
+	Name    string
+	Stage   Stage
+	Enabled bool
+}
+
+var (
+	mu        sync.RWMutex
+	envGates  = map[string]bool{}
+	overrides = map[string]bool{}
+)
+
+// Enabled tells whether the functionality guarded by the gate is enabled.
+// Gate set in the OperatorConfig takes precedence over the environment, then the stage default applies.
+func Enabled(name string) bool {
+	stage, known := stages[name]
+	if !known {
+		return false
+	}
+	if stage == GA {
+		return true
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if enabled, found := overrides[name]; found {
+		return enabled
+	}
+	if enabled, found := envGates[name]; found {
+		return enabled
+	}
+
+	return stage == Beta
+}
+
+// LoadEnv reads gates from the FEATURE_GATES environment variable.
+func LoadEnv() error {
+	gates, err := Parse(os.Getenv(EnvVar))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", EnvVar, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	envGates = gates
+
+	return nil
+}
+
+// Set replaces gates set in the OperatorConfig.
+func Set(gates map[string]bool) error {
+	if err := validate(gates); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	overrides = make(map[string]bool, len(gates))
+	for name, enabled := range gates {
+		overrides[name] = enabled
+	}
+
+	return nil
+}
+
+// Parse reads gates from a comma separated list of name=bool pairs.
+func Parse(value string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, rawEnabled, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("expected name=bool, got %q", pair)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(rawEnabled))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of gate %s: %w", name, err)
+		}
+		gates[strings.TrimSpace(name)] = enabled
+	}
+
+	return gates, validate(gates)
+}
+
+// All returns status of all known gates, sorted by name.
+func All() []Status {
+	gates := make([]Status, 0, len(stages))
+	for name, stage := range stages {
+		gates = append(gates, Status{Name: name, Stage: stage, Enabled: Enabled(name)})
+	}
+	sort.Slice(gates, func(i, j int) bool {
+		return gates[i].Name < gates[j].Name
+	})
+
+	return gates
+}
+
+func validate(gates map[string]bool) error {
+	for name, enabled := range gates {
+		stage, known := stages[name]
+		if !known {
+			return fmt.Errorf("unknown feature gate %q", name)
+		}
+		if stage == GA && !enabled {
+			return fmt.Errorf("feature gate %q is GA and cannot be disabled", name)
+		}
+	}
+
+	return nil
+}
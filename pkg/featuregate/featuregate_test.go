@@ -0,0 +1,103 @@
+package featuregate_test
+
+import (
+	"sort"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature gates", func() {
+	DescribeTable("should be parsed from name=bool pairs",
+		func(value string, expected map[string]bool) {
+			Expect(featuregate.Parse(value)).To(Equal(expected))
+		},
+		Entry("when empty", "", map[string]bool{}),
+		Entry("with known gates", "KServeRawDeployment=false, ComponentSelfHealing=true",
+			map[string]bool{featuregate.KServeRawDeployment: false, featuregate.ComponentSelfHealing: true}),
+		Entry("with surrounding whitespace and empty pairs", " ServingCatalog = true ,,",
+			map[string]bool{featuregate.ServingCatalog: true}),
+	)
+
+	DescribeTable("should be rejected",
+		func(value, message string) {
+			_, err := featuregate.Parse(value)
+
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("when unknown", "GatewayAPI=true", `unknown feature gate "GatewayAPI"`),
+		Entry("without a value", "KServeRawDeployment", `expected name=bool, got "KServeRawDeployment"`),
+		Entry("with an invalid value", "KServeRawDeployment=maybe", "invalid value of gate KServeRawDeployment"),
+	)
+
+	Context("enabled", func() {
+		load := func(value string) error {
+			GinkgoT().Setenv(featuregate.EnvVar, value)
+			return featuregate.LoadEnv()
+		}
+
+		BeforeEach(func() {
+			// registered first, so it runs once the environment has been restored
+			DeferCleanup(func() {
+				Expect(featuregate.LoadEnv()).To(Succeed())
+				Expect(featuregate.Set(nil)).To(Succeed())
+			})
+		})
+
+		It("should default to the stage of the gate", func() {
+			Expect(featuregate.Enabled(featuregate.ComponentSelfHealing)).To(BeTrue())
+			Expect(featuregate.Enabled(featuregate.SecurityPolicyReports)).To(BeFalse())
+		})
+
+		It("should never enable unknown gates", func() {
+			Expect(featuregate.Enabled("Unknown")).To(BeFalse())
+		})
+
+		It("should be read from the environment", func() {
+			Expect(load("KServeRawDeployment=false,SecurityPolicyReports=true")).To(Succeed())
+
+			Expect(featuregate.Enabled(featuregate.KServeRawDeployment)).To(BeFalse())
+			Expect(featuregate.Enabled(featuregate.SecurityPolicyReports)).To(BeTrue())
+		})
+
+		It("should reject an invalid environment and keep the gates", func() {
+			Expect(load("KServeRawDeployment=false")).To(Succeed())
+
+			Expect(load("KServeRawDeployment")).To(MatchError(ContainSubstring(featuregate.EnvVar)))
+			Expect(featuregate.Enabled(featuregate.KServeRawDeployment)).To(BeFalse())
+		})
+
+		It("should prefer the operator configuration over the environment", func() {
+			Expect(load("KServeRawDeployment=false")).To(Succeed())
+			Expect(featuregate.Set(map[string]bool{featuregate.KServeRawDeployment: true})).To(Succeed())
+
+			Expect(featuregate.Enabled(featuregate.KServeRawDeployment)).To(BeTrue())
+		})
+
+		It("should fall back to the environment once the operator configuration is removed", func() {
+			Expect(load("KServeRawDeployment=false")).To(Succeed())
+			Expect(featuregate.Set(map[string]bool{featuregate.KServeRawDeployment: true})).To(Succeed())
+			Expect(featuregate.Set(nil)).To(Succeed())
+
+			Expect(featuregate.Enabled(featuregate.KServeRawDeployment)).To(BeFalse())
+		})
+
+		It("should keep the gates when invalid ones are set", func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.KServeRawDeployment: false})).To(Succeed())
+
+			Expect(featuregate.Set(map[string]bool{featuregate.KServeRawDeployment: true, "Unknown": true})).NotTo(Succeed())
+			Expect(featuregate.Enabled(featuregate.KServeRawDeployment)).To(BeFalse())
+		})
+
+		It("should be listed for all known gates, sorted by name", func() {
+			Expect(featuregate.Set(map[string]bool{featuregate.ServingCatalog: true})).To(Succeed())
+
+			gates := featuregate.All()
+			Expect(sort.SliceIsSorted(gates, func(i, j int) bool { return gates[i].Name < gates[j].Name })).To(BeTrue())
+			Expect(gates).To(ContainElement(featuregate.Status{Name: featuregate.ServingCatalog, Stage: featuregate.Alpha, Enabled: true}))
+			Expect(gates).To(ContainElement(featuregate.Status{Name: featuregate.ComponentSelfHealing, Stage: featuregate.Beta, Enabled: true}))
+		})
+	})
+})
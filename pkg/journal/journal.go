@@ -0,0 +1,186 @@
+// Package journal records the progress of multi-step operations in a ConfigMap of the operator namespace, so that an
+// operation interrupted by a restart of the operator is resumed, or compensated, instead of being forgotten.
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapName is the ConfigMap holding one entry per operation in flight, keyed by the name of the operation.
+const ConfigMapName = "opendatahub-operator-journal"
+
+// Step is a unit of work of an operation, recorded in the journal once it succeeds.
+type Step struct {
+	Name string
+	// Run performs the step with the data the operation was started with.
+	Run func(ctx context.Context, data map[string]string) error
+	// Compensate undoes the step when the operation is abandoned, it is optional.
+	Compensate func(ctx context.Context, data map[string]string) error
+}
+
+// Entry is the record of an operation in flight.
+type Entry struct {
+	Started metav1.Time `json:"started"`
+	// Data is the input of the operation, kept for a resumed operation to proceed with what it was started with.
+	Data map[string]string `json:"data,omitempty"`
+	// Completed lists the steps which succeeded, in order.
+	Completed []string `json:"completed,omitempty"`
+}
+
+// Journal reads and writes the entries of the operations in flight.
+type Journal struct {
+	cli       client.Client
+	namespace string
+}
+
+// New returns the Journal of the operator namespace. A nil Journal runs operations without recording them.
+func New(cli client.Client, namespace string) *Journal {
+	return &Journal{cli: cli, namespace: namespace}
+}
+
+// Run runs, in order, the steps of the operation which are not recorded as completed yet, and stops at the first
+// failure. The data of an operation already in flight takes precedence over the given one. The entry is removed
+// once all the steps succeed, and kept otherwise for the next run to resume from the failed step.
+func (j *Journal) Run(ctx context.Context, operation string, data map[string]string, steps ...Step) error {
+	if j == nil {
+		for _, step := range steps {
+			if err := step.Run(ctx, data); err != nil {
+				return fmt.Errorf("step %s of operation %s failed: %w", step.Name, operation, err)
+			}
+		}
+		return nil
+	}
+
+	entries, err := j.InFlight(ctx)
+	if err != nil {
+		return err
+	}
+	entry, resumed := entries[operation]
+	if resumed {
+		ctrl.Log.Info("resuming operation interrupted by a restart", "operation", operation, "completed", entry.Completed)
+	} else {
+		entry = Entry{Started: metav1.Now(), Data: data}
+		if err := j.save(ctx, operation, &entry); err != nil {
+			return err
+		}
+	}
+
+	for _, step := range steps {
+		if slices.Contains(entry.Completed, step.Name) {
+			continue
+		}
+		if err := step.Run(ctx, entry.Data); err != nil {
+			return fmt.Errorf("step %s of operation %s failed: %w", step.Name, operation, err)
+		}
+		entry.Completed = append(entry.Completed, step.Name)
+		if err := j.save(ctx, operation, &entry); err != nil {
+			return err
+		}
+	}
+
+	return j.save(ctx, operation, nil)
+}
+
+// Compensate undoes, in reverse order, the completed steps of an operation in flight, then removes its entry.
+// Nothing happens when the operation is not in flight.
+func (j *Journal) Compensate(ctx context.Context, operation string, steps ...Step) error {
+	entries, err := j.InFlight(ctx)
+	if err != nil {
+		return err
+	}
+	entry, found := entries[operation]
+	if !found {
+		return nil
+	}
+
+	for i := len(entry.Completed) - 1; i >= 0; i-- {
+		idx := slices.IndexFunc(steps, func(s Step) bool { return s.Name == entry.Completed[i] })
+		if idx < 0 || steps[idx].Compensate == nil {
+			continue
+		}
+		if err := steps[idx].Compensate(ctx, entry.Data); err != nil {
+			return fmt.Errorf("failed to compensate step %s of operation %s: %w", entry.Completed[i], operation, err)
+		}
+		entry.Completed = entry.Completed[:i]
+		if err := j.save(ctx, operation, &entry); err != nil {
+			return err
+		}
+	}
+	ctrl.Log.Info("compensated operation", "operation", operation)
+
+	return j.save(ctx, operation, nil)
+}
+
+// InFlight returns the entries of the operations in flight, by operation name.
+func (j *Journal) InFlight(ctx context.Context) (map[string]Entry, error) {
+	if j == nil {
+		return map[string]Entry{}, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := j.cli.Get(ctx, types.NamespacedName{Namespace: j.namespace, Name: ConfigMapName}, cm); err != nil {
+		if k8serr.IsNotFound(err) {
+			return map[string]Entry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read the operation journal: %w", err)
+	}
+
+	entries := make(map[string]Entry, len(cm.Data))
+	for operation, raw := range cm.Data {
+		entry := Entry{}
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			return nil, fmt.Errorf("invalid entry of operation %s in the journal: %w", operation, err)
+		}
+		entries[operation] = entry
+	}
+
+	return entries, nil
+}
+
+// save writes the entry of the operation, removing it when nil.
+func (j *Journal) save(ctx context.Context, operation string, entry *Entry) error {
+	cm := &corev1.ConfigMap{}
+	err := j.cli.Get(ctx, types.NamespacedName{Namespace: j.namespace, Name: ConfigMapName}, cm)
+	if err != nil && !k8serr.IsNotFound(err) {
+		return fmt.Errorf("failed to read the operation journal: %w", err)
+	}
+	exists := err == nil
+
+	if entry == nil {
+		if _, found := cm.Data[operation]; !found {
+			return nil
+		}
+		delete(cm.Data, operation)
+	} else {
+		raw, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[operation] = string(raw)
+	}
+
+	if exists {
+		err = j.cli.Update(ctx, cm)
+	} else {
+		cm.Name = ConfigMapName
+		cm.Namespace = j.namespace
+		err = j.cli.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record operation %s in the journal: %w", operation, err)
+	}
+
+	return nil
+}
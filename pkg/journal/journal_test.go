@@ -0,0 +1,137 @@
+package journal_test
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/journal"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Operation journal", func() {
+	var (
+		cli    client.Client
+		j      *journal.Journal
+		ran    []string
+		undone []string
+		failAt string
+	)
+
+	step := func(name string) journal.Step {
+		return journal.Step{
+			Name: name,
+			Run: func(_ context.Context, data map[string]string) error {
+				if name == failAt {
+					return errors.New("interrupted")
+				}
+				ran = append(ran, name+":"+data["release"])
+				return nil
+			},
+			Compensate: func(context.Context, map[string]string) error {
+				undone = append(undone, name)
+				return nil
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().Build()
+		j = journal.New(cli, "opendatahub-operator")
+		ran = nil
+		undone = nil
+		failAt = ""
+	})
+
+	It("should run the steps in order and remove the completed operation", func(ctx context.Context) {
+		Expect(j.Run(ctx, "upgrade", map[string]string{"release": "2.13"}, step("first"), step("second"))).To(Succeed())
+
+		Expect(ran).To(Equal([]string{"first:2.13", "second:2.13"}))
+		Expect(j.InFlight(ctx)).To(BeEmpty())
+	})
+
+	When("an operation is interrupted", func() {
+		BeforeEach(func(ctx context.Context) {
+			failAt = "second"
+			Expect(j.Run(ctx, "upgrade", map[string]string{"release": "2.13"}, step("first"), step("second"), step("third"))).
+				To(MatchError(ContainSubstring("step second of operation upgrade failed")))
+		})
+
+		It("should record the completed steps and the data in the journal", func(ctx context.Context) {
+			entries, err := j.InFlight(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveKeyWithValue("upgrade", And(
+				HaveField("Completed", Equal([]string{"first"})),
+				HaveField("Data", Equal(map[string]string{"release": "2.13"})),
+			)))
+		})
+
+		It("should resume with the data it was started with", func(ctx context.Context) {
+			failAt = ""
+
+			Expect(j.Run(ctx, "upgrade", map[string]string{"release": "2.14"}, step("first"), step("second"), step("third"))).To(Succeed())
+
+			Expect(ran).To(Equal([]string{"first:2.13", "second:2.13", "third:2.13"}))
+			Expect(j.InFlight(ctx)).To(BeEmpty())
+		})
+
+		It("should undo the completed steps in reverse order when compensated", func(ctx context.Context) {
+			failAt = "third"
+			Expect(j.Run(ctx, "upgrade", nil, step("first"), step("second"), step("third"))).To(HaveOccurred())
+
+			Expect(j.Compensate(ctx, "upgrade", step("first"), step("second"), step("third"))).To(Succeed())
+
+			Expect(undone).To(Equal([]string{"second", "first"}))
+			Expect(j.InFlight(ctx)).To(BeEmpty())
+		})
+
+		It("should skip the steps without compensation", func(ctx context.Context) {
+			first := step("first")
+			first.Compensate = nil
+
+			Expect(j.Compensate(ctx, "upgrade", first, step("second"))).To(Succeed())
+
+			Expect(undone).To(BeEmpty())
+			Expect(j.InFlight(ctx)).To(BeEmpty())
+		})
+
+		It("should keep other operations in flight", func(ctx context.Context) {
+			Expect(j.Run(ctx, "activation", nil, step("first"), step("second"))).To(HaveOccurred())
+
+			Expect(j.Compensate(ctx, "upgrade", step("first"), step("second"))).To(Succeed())
+
+			Expect(j.InFlight(ctx)).To(HaveKey("activation"))
+		})
+	})
+
+	It("should not compensate an operation which is not in flight", func(ctx context.Context) {
+		Expect(j.Compensate(ctx, "upgrade", step("first"))).To(Succeed())
+
+		Expect(undone).To(BeEmpty())
+	})
+
+	It("should fail on an invalid entry in the journal", func(ctx context.Context) {
+		Expect(cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: journal.ConfigMapName, Namespace: "opendatahub-operator"},
+			Data:       map[string]string{"upgrade": "{"},
+		})).To(Succeed())
+
+		Expect(j.Run(ctx, "upgrade", nil, step("first"))).To(MatchError(ContainSubstring("invalid entry of operation upgrade")))
+		Expect(ran).To(BeEmpty())
+	})
+
+	It("should run the steps without recording them when there is no journal", func(ctx context.Context) {
+		var none *journal.Journal
+
+		Expect(none.Run(ctx, "upgrade", map[string]string{"release": "2.13"}, step("first"))).To(Succeed())
+
+		Expect(ran).To(Equal([]string{"first:2.13"}))
+		Expect(none.InFlight(ctx)).To(BeEmpty())
+	})
+})
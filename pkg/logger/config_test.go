@@ -0,0 +1,77 @@
+package logger_test
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runtime logging configuration", func() {
+	DescribeTable("should be parsed from the ConfigMap",
+		func(data map[string]string, expected logger.Config) {
+			cfg, err := logger.ParseConfig(data)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(expected))
+		},
+		Entry("with defaults when empty", map[string]string{},
+			logger.Config{Level: zapcore.InfoLevel, Components: map[string]zapcore.Level{}}),
+		Entry("with a global level and per component overrides", map[string]string{
+			"level":        "error",
+			"format":       "console",
+			"level.kserve": "debug",
+			"level.ray":    "2",
+		}, logger.Config{
+			Level:      zapcore.ErrorLevel,
+			Format:     "console",
+			Components: map[string]zapcore.Level{"kserve": zapcore.DebugLevel, "ray": zapcore.Level(-2)},
+		}),
+		Entry("with surrounding whitespace", map[string]string{"level": " warn\n", "format": "json "},
+			logger.Config{Level: zapcore.WarnLevel, Format: "json", Components: map[string]zapcore.Level{}}),
+	)
+
+	DescribeTable("should be rejected",
+		func(data map[string]string, message string) {
+			_, err := logger.ParseConfig(data)
+
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("with an unsupported level", map[string]string{"level": "verbose"}, `unsupported log level "verbose"`),
+		Entry("with a negative verbosity", map[string]string{"level": "-1"}, "must not be negative"),
+		Entry("with an unsupported level of a component", map[string]string{"level.kserve": "loud"}, "invalid level for kserve"),
+		Entry("with an unsupported format", map[string]string{"format": "xml"}, `unsupported log format "xml"`),
+		Entry("with an unknown key", map[string]string{"loglevel": "debug"}, `unknown logging configuration key "loglevel"`),
+	)
+
+	When("applied", func() {
+		BeforeEach(func() {
+			cfg, err := logger.ParseConfig(map[string]string{"level.kserve": "debug"})
+			Expect(err).NotTo(HaveOccurred())
+			logger.ApplyConfig(cfg)
+			DeferCleanup(logger.ResetConfig)
+		})
+
+		It("should override the level of loggers of the component", func() {
+			log := logger.NewComponentLogger(logr.Discard(), "kserve", "kserve", "")
+
+			Expect(log.V(1).Enabled()).To(BeTrue())
+		})
+
+		It("should leave loggers of other components unchanged", func() {
+			log := logger.NewComponentLogger(logr.Discard(), "ray", "ray", "")
+
+			Expect(log.Enabled()).To(BeFalse())
+		})
+
+		It("should not override the level anymore once reset", func() {
+			logger.ResetConfig()
+
+			log := logger.NewComponentLogger(logr.Discard(), "kserve", "kserve", "")
+			Expect(log.Enabled()).To(BeFalse())
+		})
+	})
+})
@@ -19,6 +19,27 @@ func NewNamedLogger(log logr.Logger, name string, mode string) logr.Logger {
 	return log.WithName(name)
 }
 
+// NewComponentLogger creates a new logger for a component or capability, taking into account runtime overrides
+// set through ConfigMapName. Without any override it behaves the same as NewNamedLogger.
+func NewComponentLogger(log logr.Logger, component string, name string, mode string) logr.Logger {
+	lvl, hasLevel, format := componentOverride(component)
+	if !hasLevel && format == "" {
+		return NewNamedLogger(log, name, mode)
+	}
+
+	opts := newOptions(mode)
+	if hasLevel {
+		opts.Level = lvl
+	}
+	switch format {
+	case "json":
+		zap.JSONEncoder(opts.EncoderConfigOptions...)(opts)
+	case "console":
+		zap.ConsoleEncoder(opts.EncoderConfigOptions...)(opts)
+	}
+	return newLogger(opts).WithName(name)
+}
+
 func NewLoggerWithOptions(mode string, override *zap.Options) logr.Logger {
 	opts := newOptions(mode)
 	overrideOptions(opts, override)
@@ -42,14 +63,14 @@ func newOptions(mode string) *zap.Options {
 		opts = zap.Options{
 			Development:     true,
 			StacktraceLevel: zapcore.WarnLevel,
-			Level:           zapcore.InfoLevel,
+			Level:           globalLevel,
 			DestWriter:      os.Stdout,
 		}
 	case "prod", "production": // the least logging verbosity
 		opts = zap.Options{
 			Development:     false,
 			StacktraceLevel: zapcore.ErrorLevel,
-			Level:           zapcore.InfoLevel,
+			Level:           globalLevel,
 			DestWriter:      os.Stdout,
 			EncoderConfigOptions: []zap.EncoderConfigOption{func(config *zapcore.EncoderConfig) {
 				config.EncodeTime = zapcore.ISO8601TimeEncoder // human readable not epoch
@@ -66,7 +87,7 @@ func newOptions(mode string) *zap.Options {
 		opts = zap.Options{
 			Development:     false,
 			StacktraceLevel: zapcore.ErrorLevel,
-			Level:           zapcore.InfoLevel,
+			Level:           globalLevel,
 			DestWriter:      os.Stdout,
 		}
 	}
@@ -90,7 +111,13 @@ func overrideOptions(orig, override *zap.Options) {
 	}
 
 	if override.Level != nil {
-		orig.Level = override.Level
+		// keep the level shared, so it can still be changed at runtime
+		if lvl, ok := override.Level.(interface{ Level() zapcore.Level }); ok {
+			defaultLevel = lvl.Level()
+			globalLevel.SetLevel(defaultLevel)
+		} else {
+			orig.Level = override.Level
+		}
 	}
 
 	if override.DestWriter != nil {
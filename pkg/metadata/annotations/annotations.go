@@ -1,6 +1,7 @@
 package annotations
 
 // ManagedByODHOperator is used to denote if a resource/component should be reconciled - when true, reconcile.
+// When false, as annotation or label, resources created by the operator are no longer updated nor deleted.
 const ManagedByODHOperator = "opendatahub.io/managed"
 
 // trust CA bundler.
@@ -8,8 +9,97 @@ const InjectionOfCABundleAnnotatoion = "security.opendatahub.io/inject-trusted-c
 
 // secret generator.
 const (
+	// SecretGeneratorPrefix prefixes the annotations of the Secrets the secret generator generates values for.
+	SecretGeneratorPrefix       = "secret-generator.opendatahub.io/"
 	SecretNameAnnotation        = "secret-generator.opendatahub.io/name"
 	SecretTypeAnnotation        = "secret-generator.opendatahub.io/type"
 	SecretLengthAnnotation      = "secret-generator.opendatahub.io/complexity"
 	SecretOauthClientAnnotation = "secret-generator.opendatahub.io/oauth-client-route"
 )
+
+// OAuthClientSecretRotatedAt is set on the Secrets of the OAuthClients managed by the operator with the time their
+// secret was last generated.
+const OAuthClientSecretRotatedAt = "opendatahub.io/oauth-client-secret-rotated-at"
+
+// PublishRenderedManifests is set on the DataScienceCluster to publish the final, post-kustomize manifests
+// of each component in a ConfigMap - when true, publish.
+const PublishRenderedManifests = "opendatahub.io/publish-rendered-manifests"
+
+// self-healing.
+const (
+	// LastRemediation is set on a Deployment with the time the operator last remediated it.
+	LastRemediation = "opendatahub.io/last-remediation"
+	// RemediationRequested is set on the DataScienceCluster to get manifests of the components applied again.
+	RemediationRequested = "opendatahub.io/remediation-requested"
+)
+
+// ConfigHash is set on component Deployments and their pod template with the checksum of the ConfigMaps and Secrets
+// consumed by their pods. Changing it on the pod template rolls the Deployment out.
+const ConfigHash = "opendatahub.io/config-hash"
+
+// PublishImageSet is set on the DataScienceCluster to publish images of the enabled components
+// in oc-mirror ImageSetConfiguration format - when true, publish.
+const PublishImageSet = "opendatahub.io/publish-image-set"
+
+// data connections.
+const (
+	// ConnectionType tells the dashboard which kind of data connection a Secret holds.
+	ConnectionType = "opendatahub.io/connection-type"
+	// DisplayName is the name of a resource displayed in the dashboard.
+	DisplayName = "openshift.io/display-name"
+)
+
+// AcceleratorDisabledTemplates is set on the OdhDashboardConfig with the comma-separated names of the serving runtime
+// templates the operator disabled while the accelerators they run on are not ready, to enable them back once ready.
+const AcceleratorDisabledTemplates = "opendatahub.io/accelerator-disabled-templates"
+
+// PipelineServer is set on data science projects with the name of the pipeline server the operator created,
+// so that it is not created again once deleted by users.
+const PipelineServer = "opendatahub.io/pipeline-server"
+
+// DeploymentMode selects how KServe serves an InferenceService, e.g. "ModelMesh", "Serverless" or "RawDeployment".
+const DeploymentMode = "serving.kserve.io/deploymentMode"
+
+// ModelMeshDeploymentMode is the DeploymentMode of InferenceServices served by ModelMesh.
+const ModelMeshDeploymentMode = "ModelMesh"
+
+// ReconcileComponent is set on the DataScienceCluster with the comma-separated names of the components to reconcile
+// alone, without the other ones. It is removed once they have been reconciled.
+const ReconcileComponent = "opendatahub.io/reconcile-component"
+
+// ResetComponent is set on the DataScienceCluster with the comma-separated names of the components whose devFlags and
+// extraParams are to be discarded, for them to be deployed from the embedded manifests with their default parameters.
+// It is removed once they have been reset.
+const ResetComponent = "opendatahub.io/reset-component"
+
+// ConfirmRemoval is set on the DataScienceCluster with the comma-separated names of the components whose removal is
+// confirmed despite the workloads depending on them.
+const ConfirmRemoval = "opendatahub.io/confirm-removal"
+
+// project templates.
+const (
+	// ProjectTemplate is set on a namespace with the name of the ProjectTemplate to instantiate in it.
+	ProjectTemplate = "opendatahub.io/project-template"
+	// ProjectTemplateInstantiated is set on a namespace with the name of the ProjectTemplate once all of its resources
+	// have been created, so that resources deleted by users are not created again.
+	ProjectTemplateInstantiated = "opendatahub.io/project-template-instantiated"
+)
+
+// DowngradedWebhooks is set on webhook configurations of the operator with the comma-separated names of the webhooks
+// whose failurePolicy has been downgraded to Ignore while they are unavailable, for it to be restored afterwards.
+const DowngradedWebhooks = "opendatahub.io/downgraded-webhooks"
+
+// ForceCleanupAfter is set on the DataScienceCluster or DSCInitialization with a duration, e.g. "10m", after which
+// their deletion skips the cleanup steps which fail, e.g. because prerequisite operators are already uninstalled,
+// rather than being stuck on the finalizer. The skipped steps are reported in a CleanupSkipped event.
+const ForceCleanupAfter = "opendatahub.io/force-cleanup-after"
+
+// ImportDashboardConfig is set on the DataScienceCluster, when "true", to import the groups, model server sizes, notebook
+// sizes and PVC size of the dashboard config into the fields of the dashboard and workbenches not set yet, from which the
+// dashboard config is managed afterwards. It is removed once they have been imported.
+const ImportDashboardConfig = "opendatahub.io/import-dashboard-config"
+
+// TransferOwnershipTo is set on a resource of a feature with the name of the FeatureTracker, e.g. of another operator,
+// to hand it over to. Until then, the feature of another FeatureTracker owning the resource reports an ownership
+// conflict rather than applying it.
+const TransferOwnershipTo = "opendatahub.io/transfer-ownership-to"
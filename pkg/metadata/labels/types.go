@@ -5,6 +5,12 @@ const (
 	InjectTrustCA     = "config.openshift.io/inject-trusted-cabundle"
 	SecurityEnforce   = "pod-security.kubernetes.io/enforce"
 	ClusterMonitoring = "openshift.io/cluster-monitoring"
+	IstioInjection    = "istio-injection"
+	IstioRevision     = "istio.io/rev"
+	// MaistraMemberOf labels namespaces which are members of the mesh with the namespace of its control plane.
+	MaistraMemberOf = "maistra.io/member-of"
+	// ModelMeshEnabled labels data science projects serving models with ModelMesh ("true") or KServe ("false").
+	ModelMeshEnabled = "modelmesh-enabled"
 )
 
 // K8SCommon keeps common kubernetes labels [1]
@@ -18,11 +24,35 @@ var K8SCommon = struct {
 
 // ODH holds Open Data Hub specific labels grouped by types.
 var ODH = struct {
-	OwnedNamespace string
-	Component      func(string) string
+	OwnedNamespace   string
+	SidecarInjection string
+	Dashboard        string
+	DashboardAccess  string
+	DataConnection   string
+	Fleet            string
+	WorkloadPolicy   string
+	IdleReaperExempt string
+	CostCenter       string
+	WorkloadIdentity string
+	Profile          string
+	Component        func(string) string
+	AggregateTo      func(string) string
 }{
-	OwnedNamespace: "opendatahub.io/generated-namespace",
+	OwnedNamespace:   "opendatahub.io/generated-namespace",
+	SidecarInjection: "opendatahub.io/managed-sidecar-injection",
+	Dashboard:        "opendatahub.io/dashboard",
+	DashboardAccess:  "opendatahub.io/dashboard-access",
+	DataConnection:   "opendatahub.io/data-connection",
+	Fleet:            "opendatahub.io/fleet",
+	WorkloadPolicy:   "opendatahub.io/workload-policy",
+	IdleReaperExempt: "opendatahub.io/idle-reaper-exempt",
+	CostCenter:       "opendatahub.io/cost-center",
+	WorkloadIdentity: "opendatahub.io/workload-identity",
+	Profile:          "opendatahub.io/profile",
 	Component: func(name string) string {
 		return ODHAppPrefix + "/" + name
 	},
+	AggregateTo: func(role string) string {
+		return "opendatahub.io/aggregate-to-" + role
+	},
 }
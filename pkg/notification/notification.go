@@ -0,0 +1,202 @@
+// Package notification sends lifecycle events of the platform, e.g. degraded components or completed upgrades, to the
+// webhook, Slack and email sinks configured in the OperatorConfig, so teams are notified without relying on alerts only.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+)
+
+const (
+	defaultRepeatInterval = 24 * time.Hour
+	sendTimeout           = 10 * time.Second
+)
+
+// Event is a lifecycle event of the platform.
+type Event struct {
+	Type operatorconfigv1alpha1.NotificationEvent `json:"type"`
+	// Object the event is about, e.g. the name of the component.
+	Object  string    `json:"object"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// Notifier sends events to the sinks of the OperatorConfig. A nil Notifier sends nothing.
+type Notifier struct {
+	cli       client.Reader
+	namespace string
+	// HTTPClient posts events to webhook and Slack sinks, a client with a timeout is used when nil.
+	HTTPClient *http.Client
+	// SendMail sends events to email sinks, smtp.SendMail when nil.
+	SendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+	mu sync.Mutex
+	// sent records when events were last sent, by type and object
+	sent map[string]time.Time
+}
+
+// New creates a Notifier reading the OperatorConfig and the Secrets of the sinks, from the given namespace.
+func New(cli client.Reader, namespace string) *Notifier {
+	return &Notifier{cli: cli, namespace: namespace, sent: map[string]time.Time{}}
+}
+
+// Notify sends the event to the sinks subscribed to its type. Events already sent about the same object are not sent
+// again within the repeat interval. All sinks are tried, errors of those failing are returned together.
+func (n *Notifier) Notify(ctx context.Context, event Event) error {
+	if n == nil {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	instance := &operatorconfigv1alpha1.OperatorConfig{}
+	if err := n.cli.Get(ctx, client.ObjectKey{Name: operatorconfigv1alpha1.OperatorConfigName}, instance); err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	notifications := instance.Spec.Notifications
+	if notifications == nil {
+		return nil
+	}
+	repeatInterval := defaultRepeatInterval
+	if notifications.RepeatInterval != nil {
+		repeatInterval = notifications.RepeatInterval.Duration
+	}
+
+	key := string(event.Type) + "/" + event.Object
+	n.mu.Lock()
+	last, sent := n.sent[key]
+	n.mu.Unlock()
+	if sent && event.Time.Sub(last) < repeatInterval {
+		return nil
+	}
+
+	var errs []error
+	delivered := false
+	for _, sink := range notifications.Sinks {
+		if len(sink.Events) != 0 && !slices.Contains(sink.Events, event.Type) {
+			continue
+		}
+		if err := n.send(ctx, sink, event); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify sink %s: %w", sink.Name, err))
+			continue
+		}
+		delivered = true
+	}
+	if delivered {
+		n.mu.Lock()
+		n.sent[key] = event.Time
+		n.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) send(ctx context.Context, sink operatorconfigv1alpha1.NotificationSink, event Event) error {
+	secret := &corev1.Secret{}
+	if err := n.cli.Get(ctx, client.ObjectKey{Namespace: n.namespace, Name: sink.SecretName}, secret); err != nil {
+		return fmt.Errorf("failed to read settings from Secret %s: %w", sink.SecretName, err)
+	}
+	settings := map[string]string{}
+	for key, value := range secret.Data {
+		settings[key] = string(value)
+	}
+
+	switch sink.Type {
+	case operatorconfigv1alpha1.WebhookSink:
+		return n.post(ctx, settings["url"], event)
+	case operatorconfigv1alpha1.SlackSink:
+		return n.post(ctx, settings["url"], map[string]string{"text": fmt.Sprintf("*%s* %s: %s", event.Type, event.Object, event.Message)})
+	case operatorconfigv1alpha1.EmailSink:
+		return n.mail(settings, event)
+	default:
+		return fmt.Errorf("unsupported sink type %q", sink.Type)
+	}
+}
+
+// post sends the payload as JSON to the URL.
+func (n *Notifier) post(ctx context.Context, url string, payload any) error {
+	if url == "" {
+		return errors.New(`missing "url" setting`)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: sendTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("request rejected with status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// mail sends the event through the SMTP server of the settings.
+func (n *Notifier) mail(settings map[string]string, event Event) error {
+	host, from := settings["host"], settings["from"]
+	var to []string
+	for _, recipient := range strings.Split(settings["to"], ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			to = append(to, recipient)
+		}
+	}
+	if host == "" || from == "" || len(to) == 0 {
+		return errors.New(`"host", "from" and "to" settings are required`)
+	}
+
+	var auth smtp.Auth
+	if settings["username"] != "" {
+		hostname, _, err := net.SplitHostPort(host)
+		if err != nil {
+			return fmt.Errorf("invalid host %q: %w", host, err)
+		}
+		auth = smtp.PlainAuth("", settings["username"], settings["password"], hostname)
+	}
+	// line breaks would end the headers
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(fmt.Sprintf("[Open Data Hub] %s: %s", event.Type, event.Object))
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n\r\nReported at %s.\r\n",
+		from, strings.Join(to, ", "), subject, event.Message, event.Time.UTC().Format(time.RFC3339))
+
+	sendMail := n.SendMail
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+
+	return sendMail(host, auth, from, to, []byte(msg))
+}
@@ -0,0 +1,244 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorconfigv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/operatorconfig/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/notification"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func settings(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opendatahub-operator"}, Data: map[string][]byte{}}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret
+}
+
+var _ = Describe("Notifier", func() {
+	var (
+		config   *operatorconfigv1alpha1.OperatorConfig
+		objects  []client.Object
+		n        *notification.Notifier
+		status   int
+		received []map[string]any
+		mails    []string
+		now      time.Time
+	)
+
+	notify := func(ctx context.Context, event notification.Event) error {
+		if n == nil {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(operatorconfigv1alpha1.AddToScheme(scheme)).To(Succeed())
+			n = notification.New(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), "opendatahub-operator")
+			n.SendMail = func(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+				Expect(addr).To(Equal("smtp.example.com:587"))
+				Expect(to).To(Equal([]string{"ops@example.com", "sre@example.com"}))
+				mails = append(mails, string(msg))
+				return nil
+			}
+		}
+		return n.Notify(ctx, event)
+	}
+	degraded := func() notification.Event {
+		return notification.Event{Type: operatorconfigv1alpha1.ComponentDegraded, Object: "kserve", Message: "failed\nto deploy", Time: now}
+	}
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		received = nil
+		mails = nil
+		now = time.Now()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			payload := map[string]any{}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			received = append(received, payload)
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+
+		config = &operatorconfigv1alpha1.OperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: operatorconfigv1alpha1.OperatorConfigName},
+			Spec: operatorconfigv1alpha1.OperatorConfigSpec{Notifications: &operatorconfigv1alpha1.NotificationsSpec{
+				Sinks: []operatorconfigv1alpha1.NotificationSink{
+					{Name: "webhook", Type: operatorconfigv1alpha1.WebhookSink, SecretName: "webhook"},
+					{
+						Name: "slack", Type: operatorconfigv1alpha1.SlackSink, SecretName: "slack",
+						Events: []operatorconfigv1alpha1.NotificationEvent{operatorconfigv1alpha1.UpgradeCompleted},
+					},
+					{Name: "email", Type: operatorconfigv1alpha1.EmailSink, SecretName: "email"},
+				},
+			}},
+		}
+		objects = []client.Object{
+			config,
+			settings("webhook", map[string]string{"url": server.URL}),
+			settings("slack", map[string]string{"url": server.URL}),
+			settings("email", map[string]string{"host": "smtp.example.com:587", "from": "odh@example.com", "to": "ops@example.com, sre@example.com"}),
+		}
+		n = nil
+	})
+
+	It("should send events to the sinks subscribed to their type", func(ctx context.Context) {
+		Expect(notify(ctx, degraded())).To(Succeed())
+
+		Expect(received).To(ConsistOf(And(
+			HaveKeyWithValue("type", "ComponentDegraded"),
+			HaveKeyWithValue("object", "kserve"),
+			HaveKeyWithValue("message", "failed\nto deploy"),
+		)))
+		Expect(mails).To(ConsistOf(And(
+			ContainSubstring("To: ops@example.com, sre@example.com\r\n"),
+			ContainSubstring("Subject: [Open Data Hub] ComponentDegraded: kserve\r\n"),
+			ContainSubstring("\r\n\r\nfailed\nto deploy\r\n"),
+		)))
+	})
+
+	It("should send events to all sinks without subscriptions", func(ctx context.Context) {
+		Expect(notify(ctx, notification.Event{Type: operatorconfigv1alpha1.UpgradeCompleted, Object: "default-dsc", Message: "Upgraded"})).To(Succeed())
+
+		Expect(received).To(ConsistOf(
+			HaveKeyWithValue("object", "default-dsc"),
+			HaveKeyWithValue("text", "*UpgradeCompleted* default-dsc: Upgraded"),
+		))
+		Expect(mails).To(HaveLen(1))
+	})
+
+	It("should keep line breaks of the object out of the mail headers", func(ctx context.Context) {
+		event := degraded()
+		event.Object = "kserve\r\nBcc: attacker@example.com"
+
+		Expect(notify(ctx, event)).To(Succeed())
+
+		Expect(mails).To(ConsistOf(ContainSubstring("Subject: [Open Data Hub] ComponentDegraded: kserve  Bcc: attacker@example.com\r\n")))
+	})
+
+	Context("repeated events", func() {
+		BeforeEach(func(ctx context.Context) {
+			Expect(notify(ctx, degraded())).To(Succeed())
+		})
+
+		It("should not be sent again within the repeat interval", func(ctx context.Context) {
+			event := degraded()
+			event.Time = now.Add(time.Hour)
+
+			Expect(notify(ctx, event)).To(Succeed())
+
+			Expect(received).To(HaveLen(1))
+			Expect(mails).To(HaveLen(1))
+		})
+
+		It("should be sent again after the repeat interval", func(ctx context.Context) {
+			event := degraded()
+			event.Time = now.Add(25 * time.Hour)
+
+			Expect(notify(ctx, event)).To(Succeed())
+
+			Expect(received).To(HaveLen(2))
+			Expect(mails).To(HaveLen(2))
+		})
+
+		It("should be sent when about another object", func(ctx context.Context) {
+			event := degraded()
+			event.Object = "dashboard"
+
+			Expect(notify(ctx, event)).To(Succeed())
+
+			Expect(received).To(HaveLen(2))
+		})
+	})
+
+	It("should follow the configured repeat interval", func(ctx context.Context) {
+		config.Spec.Notifications.RepeatInterval = &metav1.Duration{Duration: time.Minute}
+		Expect(notify(ctx, degraded())).To(Succeed())
+
+		event := degraded()
+		event.Time = now.Add(time.Hour)
+		Expect(notify(ctx, event)).To(Succeed())
+
+		Expect(received).To(HaveLen(2))
+	})
+
+	When("a sink fails", func() {
+		BeforeEach(func() {
+			config.Spec.Notifications.Sinks = append(config.Spec.Notifications.Sinks,
+				operatorconfigv1alpha1.NotificationSink{Name: "broken", Type: operatorconfigv1alpha1.WebhookSink, SecretName: "missing"})
+		})
+
+		It("should report it and still notify the other sinks", func(ctx context.Context) {
+			Expect(notify(ctx, degraded())).To(MatchError(ContainSubstring("failed to notify sink broken")))
+
+			Expect(received).To(HaveLen(1))
+			Expect(mails).To(HaveLen(1))
+		})
+	})
+
+	DescribeTable("should report sinks which cannot be notified",
+		func(ctx context.Context, sink operatorconfigv1alpha1.NotificationSink, data map[string]string, message string) {
+			config.Spec.Notifications.Sinks = []operatorconfigv1alpha1.NotificationSink{sink}
+			if data != nil {
+				objects = append(objects, settings(sink.SecretName, data))
+			}
+			status = http.StatusInternalServerError
+
+			err := notify(ctx, degraded())
+			Expect(err).To(MatchError(And(ContainSubstring("failed to notify sink "+sink.Name), ContainSubstring(message))))
+
+			By("trying again, as the event was not delivered")
+			Expect(notify(ctx, degraded())).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("when the webhook rejects the request",
+			operatorconfigv1alpha1.NotificationSink{Name: "webhook", Type: operatorconfigv1alpha1.WebhookSink, SecretName: "webhook"},
+			nil, "request rejected with status 500"),
+		Entry("without URL",
+			operatorconfigv1alpha1.NotificationSink{Name: "slack", Type: operatorconfigv1alpha1.SlackSink, SecretName: "no-url"},
+			map[string]string{}, `missing "url" setting`),
+		Entry("without recipients",
+			operatorconfigv1alpha1.NotificationSink{Name: "email", Type: operatorconfigv1alpha1.EmailSink, SecretName: "no-recipients"},
+			map[string]string{"host": "smtp.example.com:587", "from": "odh@example.com", "to": " , "}, `"host", "from" and "to" settings are required`),
+		Entry("of an unsupported type",
+			operatorconfigv1alpha1.NotificationSink{Name: "pager", Type: "PagerDuty", SecretName: "pager"},
+			map[string]string{}, `unsupported sink type "PagerDuty"`),
+	)
+
+	It("should send nothing without notifications configured", func(ctx context.Context) {
+		config.Spec.Notifications = nil
+
+		Expect(notify(ctx, degraded())).To(Succeed())
+
+		Expect(received).To(BeEmpty())
+		Expect(mails).To(BeEmpty())
+	})
+
+	It("should send nothing without OperatorConfig", func(ctx context.Context) {
+		objects = objects[1:]
+
+		Expect(notify(ctx, degraded())).To(Succeed())
+
+		Expect(received).To(BeEmpty())
+	})
+
+	It("should send nothing without notifier", func(ctx context.Context) {
+		var none *notification.Notifier
+
+		Expect(none.Notify(ctx, degraded())).To(Succeed())
+	})
+})
@@ -0,0 +1,92 @@
+package plugins
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// ArgsPlugin sets command line flags of the first container of the Deployment with the given name, which runs its
+// main process. Flags already passed by the manifests are replaced, a flag with several values is passed once per value.
+type ArgsPlugin struct {
+	DeploymentName string
+	Args           map[string][]string
+}
+
+var _ resmap.Transformer = &ArgsPlugin{}
+
+// Transform sets the flags in the matching Deployment of the ResMap.
+func (p *ArgsPlugin) Transform(m resmap.ResMap) error {
+	return m.ApplyFilter(ArgsFilter(*p))
+}
+
+type ArgsFilter ArgsPlugin
+
+var _ kio.Filter = ArgsFilter{}
+
+func (f ArgsFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	return kio.FilterAll(kyaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f ArgsFilter) run(node *kyaml.RNode) (*kyaml.RNode, error) {
+	if len(f.Args) == 0 || node.GetKind() != gvk.Deployment.Kind || node.GetName() != f.DeploymentName {
+		return node, nil
+	}
+
+	containers, err := node.Pipe(kyaml.Lookup("spec", "template", "spec", "containers"))
+	if err != nil || containers == nil {
+		return node, err
+	}
+	elements, err := containers.Elements()
+	if err != nil || len(elements) == 0 {
+		return node, err
+	}
+	container := elements[0]
+
+	var current []string
+	if argsNode := container.Field("args"); argsNode != nil {
+		elements, err := argsNode.Value.Elements()
+		if err != nil {
+			return node, err
+		}
+		for _, element := range elements {
+			current = append(current, element.YNode().Value)
+		}
+	}
+
+	return node, container.PipeE(kyaml.SetField("args", kyaml.NewListRNode(setFlags(current, f.Args)...)))
+}
+
+// setFlags returns the arguments without the given flags, followed by the flags in "--name=value" form sorted by name.
+func setFlags(args []string, flags map[string][]string) []string {
+	result := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if _, replaced := flags[name]; !replaced || !strings.HasPrefix(args[i], "-") {
+			result = append(result, args[i])
+			continue
+		}
+		// skip the value of flags passed in "--name value" form
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+		}
+	}
+
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range flags[name] {
+			result = append(result, "--"+name+"="+value)
+		}
+	}
+
+	return result
+}
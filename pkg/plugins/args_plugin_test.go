@@ -0,0 +1,73 @@
+package plugins_test
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Args plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		deployment, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: kubeflow-training-operator
+spec:
+  template:
+    spec:
+      containers:
+      - name: training-operator
+        command:
+        - /manager
+        args:
+        - --zap-log-level=info
+        - --enable-scheme=tfjob
+        - --gang-scheduler-name
+        - volcano
+      - name: proxy
+        args:
+        - --secure-listen-address=0.0.0.0:8443
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		resMap = resmap.New()
+		Expect(resMap.Append(deployment)).To(Succeed())
+	})
+
+	It("Should replace flags of the first container of the named Deployment", func() {
+		argsPlugin := plugins.ArgsPlugin{
+			DeploymentName: "kubeflow-training-operator",
+			Args:           map[string][]string{"enable-scheme": {"pytorchjob", "mpijob"}, "gang-scheduler-name": {"scheduler-plugins"}},
+		}
+		Expect(argsPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: kubeflow-training-operator
+spec:
+  template:
+    spec:
+      containers:
+      - name: training-operator
+        command:
+        - /manager
+        args:
+        - --zap-log-level=info
+        - --enable-scheme=pytorchjob
+        - --enable-scheme=mpijob
+        - --gang-scheduler-name=scheduler-plugins
+      - name: proxy
+        args:
+        - --secure-listen-address=0.0.0.0:8443
+`
+		Expect(resMap.Resources()[0].MustYaml()).To(MatchYAML(expected))
+	})
+})
@@ -0,0 +1,80 @@
+package plugins
+
+import (
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// CloudPlugin integrates the resources with the cloud the cluster runs on: the annotations of load balancers are set on
+// the Services of type LoadBalancer, the annotations of workload identities on the ServiceAccounts, and the labels
+// injecting the identities on the pods of the Deployments.
+type CloudPlugin struct {
+	LoadBalancerAnnotations   map[string]string
+	ServiceAccountAnnotations map[string]string
+	PodLabels                 map[string]string
+}
+
+var _ resmap.Transformer = &CloudPlugin{}
+
+// Transform sets the cloud annotations and labels on the resources of the ResMap.
+func (p *CloudPlugin) Transform(m resmap.ResMap) error {
+	return m.ApplyFilter(CloudFilter(*p))
+}
+
+type CloudFilter CloudPlugin
+
+var _ kio.Filter = CloudFilter{}
+
+func (f CloudFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	return kio.FilterAll(kyaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f CloudFilter) run(node *kyaml.RNode) (*kyaml.RNode, error) {
+	switch node.GetKind() {
+	case "Service":
+		serviceType, err := node.Pipe(kyaml.Lookup("spec", "type"))
+		if err != nil || serviceType == nil || kyaml.GetValue(serviceType) != "LoadBalancer" {
+			return node, err
+		}
+		return node, set(node, f.LoadBalancerAnnotations, annotation)
+	case "ServiceAccount":
+		return node, set(node, f.ServiceAccountAnnotations, annotation)
+	case gvk.Deployment.Kind:
+		if len(f.PodLabels) == 0 {
+			return node, nil
+		}
+		template, err := node.Pipe(kyaml.LookupCreate(kyaml.MappingNode, "spec", "template"))
+		if err != nil {
+			return node, err
+		}
+		return node, set(template, f.PodLabels, label)
+	}
+
+	return node, nil
+}
+
+func annotation(key, value string) kyaml.Filter { return kyaml.SetAnnotation(key, value) }
+
+func label(key, value string) kyaml.Filter { return kyaml.SetLabel(key, value) }
+
+// set sets the annotations or labels of the node in the order of their keys.
+func set(node *kyaml.RNode, values map[string]string, setter func(key, value string) kyaml.Filter) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := node.PipeE(setter(key, values[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
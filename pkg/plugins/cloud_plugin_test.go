@@ -0,0 +1,118 @@
+package plugins_test
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cloud plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		resMap = resmap.New()
+		for _, manifest := range []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: gateway
+spec:
+  type: LoadBalancer
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: metrics
+spec:
+  type: ClusterIP
+`, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: data-science-pipelines
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: data-science-pipelines-operator
+spec:
+  template:
+    metadata:
+      labels:
+        app: data-science-pipelines-operator
+`} {
+			resource, err := factory.FromBytes([]byte(manifest))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resMap.Append(resource)).To(Succeed())
+		}
+	})
+
+	It("Should annotate load balancers and service accounts, and label pods", func() {
+		cloudPlugin := plugins.CloudPlugin{
+			LoadBalancerAnnotations:   map[string]string{"service.beta.kubernetes.io/azure-load-balancer-internal": "true"},
+			ServiceAccountAnnotations: map[string]string{"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000000"},
+			PodLabels:                 map[string]string{"azure.workload.identity/use": "true"},
+		}
+		Expect(cloudPlugin.Transform(resMap)).To(Succeed())
+
+		expected := []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: gateway
+  annotations:
+    service.beta.kubernetes.io/azure-load-balancer-internal: "true"
+spec:
+  type: LoadBalancer
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: metrics
+spec:
+  type: ClusterIP
+`, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: data-science-pipelines
+  annotations:
+    azure.workload.identity/client-id: 00000000-0000-0000-0000-000000000000
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: data-science-pipelines-operator
+spec:
+  template:
+    metadata:
+      labels:
+        app: data-science-pipelines-operator
+        azure.workload.identity/use: "true"
+`}
+		for i, resource := range resMap.Resources() {
+			actual, err := resource.AsYAML()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(MatchYAML(expected[i]))
+		}
+	})
+
+	It("Should leave the resources unchanged without integrations", func() {
+		cloudPlugin := plugins.CloudPlugin{}
+		Expect(cloudPlugin.Transform(resMap)).To(Succeed())
+
+		service, err := resMap.Resources()[0].AsYAML()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service).To(MatchYAML(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: gateway
+spec:
+  type: LoadBalancer
+`))
+	})
+})
@@ -0,0 +1,72 @@
+package plugins
+
+import (
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// EnvPlugin sets environment variables in all containers of the Deployment with the given name,
+// replacing values of the variables already defined by the manifests.
+type EnvPlugin struct {
+	DeploymentName string
+	Env            map[string]string
+}
+
+var _ resmap.Transformer = &EnvPlugin{}
+
+// Transform sets the environment variables in the matching Deployment of the ResMap.
+func (p *EnvPlugin) Transform(m resmap.ResMap) error {
+	return m.ApplyFilter(EnvFilter(*p))
+}
+
+type EnvFilter EnvPlugin
+
+var _ kio.Filter = EnvFilter{}
+
+func (f EnvFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	return kio.FilterAll(kyaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f EnvFilter) run(node *kyaml.RNode) (*kyaml.RNode, error) {
+	if len(f.Env) == 0 || node.GetKind() != gvk.Deployment.Kind || node.GetName() != f.DeploymentName {
+		return node, nil
+	}
+
+	names := make([]string, 0, len(f.Env))
+	for name := range f.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	containers, err := node.Pipe(kyaml.Lookup("spec", "template", "spec", "containers"))
+	if err != nil || containers == nil {
+		return node, err
+	}
+
+	return node, containers.VisitElements(func(container *kyaml.RNode) error {
+		env, err := container.Pipe(kyaml.LookupCreate(kyaml.SequenceNode, "env"))
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			// values are set as strings, as "true" would otherwise end up as a boolean
+			variable := kyaml.NewMapRNode(nil)
+			if err := variable.PipeE(kyaml.SetField("name", kyaml.NewStringRNode(name))); err != nil {
+				return err
+			}
+			if err := variable.PipeE(kyaml.SetField("value", kyaml.NewStringRNode(f.Env[name]))); err != nil {
+				return err
+			}
+			if err := env.PipeE(kyaml.ElementSetter{Keys: []string{"name"}, Values: []string{name}, Element: variable.YNode()}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package plugins_test
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Env plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		deployment, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-model-controller
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        env:
+        - name: ENABLE_ROUTE_RECONCILER
+          value: "true"
+        - name: NAMESPACE
+          value: namespace
+      - name: proxy
+`))
+		Expect(err).NotTo(HaveOccurred())
+		other, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: kserve-controller-manager
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		resMap = resmap.New()
+		Expect(resMap.Append(deployment)).To(Succeed())
+		Expect(resMap.Append(other)).To(Succeed())
+	})
+
+	It("Should set env variables in containers of the named Deployment only", func() {
+		envPlugin := plugins.EnvPlugin{
+			DeploymentName: "odh-model-controller",
+			Env:            map[string]string{"ENABLE_ROUTE_RECONCILER": "false", "ENABLE_METRICS_RECONCILER": "true"},
+		}
+		Expect(envPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-model-controller
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        env:
+        - name: ENABLE_ROUTE_RECONCILER
+          value: "false"
+        - name: NAMESPACE
+          value: namespace
+        - name: ENABLE_METRICS_RECONCILER
+          value: "true"
+      - name: proxy
+        env:
+        - name: ENABLE_METRICS_RECONCILER
+          value: "true"
+        - name: ENABLE_ROUTE_RECONCILER
+          value: "false"
+`
+		Expect(resMap.Resources()[0].MustYaml()).To(MatchYAML(expected))
+		Expect(resMap.Resources()[1].MustYaml()).NotTo(ContainSubstring("env"))
+	})
+})
@@ -0,0 +1,55 @@
+package plugins
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// MetadataPlugin sets labels and annotations on all resources, where they do not set them already.
+type MetadataPlugin struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+var _ resmap.Transformer = &MetadataPlugin{}
+
+// Transform sets the labels and annotations in the resources of the ResMap.
+func (p *MetadataPlugin) Transform(m resmap.ResMap) error {
+	return m.ApplyFilter(MetadataFilter(*p))
+}
+
+type MetadataFilter MetadataPlugin
+
+var _ kio.Filter = MetadataFilter{}
+
+func (f MetadataFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	return kio.FilterAll(kyaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f MetadataFilter) run(node *kyaml.RNode) (*kyaml.RNode, error) {
+	if len(f.Labels) != 0 {
+		if err := node.SetLabels(withDefaults(node.GetLabels(), f.Labels)); err != nil {
+			return node, err
+		}
+	}
+	if len(f.Annotations) != 0 {
+		if err := node.SetAnnotations(withDefaults(node.GetAnnotations(), f.Annotations)); err != nil {
+			return node, err
+		}
+	}
+
+	return node, nil
+}
+
+func withDefaults(values, defaults map[string]string) map[string]string {
+	result := make(map[string]string, len(values)+len(defaults))
+	for key, value := range defaults {
+		result[key] = value
+	}
+	for key, value := range values {
+		result[key] = value
+	}
+
+	return result
+}
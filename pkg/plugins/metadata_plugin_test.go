@@ -0,0 +1,47 @@
+package plugins_test
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metadata plugin", func() {
+	It("Should set labels and annotations the resources do not set", func() {
+		service, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: odh-dashboard
+  labels:
+    app: odh-dashboard
+    cost-center: dashboard
+`))
+		Expect(err).NotTo(HaveOccurred())
+		resMap := resmap.New()
+		Expect(resMap.Append(service)).To(Succeed())
+
+		metadataPlugin := plugins.MetadataPlugin{
+			Labels:      map[string]string{"cost-center": "4242", "backup": "daily"},
+			Annotations: map[string]string{"compliance.example.com/level": "high"},
+		}
+		Expect(metadataPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: odh-dashboard
+  labels:
+    app: odh-dashboard
+    backup: daily
+    cost-center: dashboard
+  annotations:
+    compliance.example.com/level: high
+`
+		Expect(resMap.Resources()[0].MustYaml()).To(MatchYAML(expected))
+	})
+})
@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// OAuthClientPlugin removes the secret generator annotations of the Secrets of the OAuthClients managed by the
+// operator, so that the secret generator neither creates nor deletes these OAuthClients.
+type OAuthClientPlugin struct {
+	Names []string
+}
+
+var _ resmap.Transformer = &OAuthClientPlugin{}
+
+// Transform removes the secret generator annotations of the Secrets named after the OAuthClients in the ResMap.
+func (p *OAuthClientPlugin) Transform(m resmap.ResMap) error {
+	for _, res := range m.Resources() {
+		if res.GetKind() != "Secret" || res.GetApiVersion() != "v1" || !slices.Contains(p.Names, res.GetName()) {
+			continue
+		}
+		resourceAnnotations := res.GetAnnotations()
+		for key := range resourceAnnotations {
+			if strings.HasPrefix(key, annotations.SecretGeneratorPrefix) {
+				delete(resourceAnnotations, key)
+			}
+		}
+		if err := res.SetAnnotations(resourceAnnotations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package plugins_test
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuthClient plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		resMap = resmap.New()
+		for _, manifest := range []string{`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dashboard-oauth-client
+  annotations:
+    secret-generator.opendatahub.io/name: secret
+    secret-generator.opendatahub.io/type: random
+    secret-generator.opendatahub.io/complexity: "32"
+    secret-generator.opendatahub.io/oauth-client-route: odh-dashboard
+    openshift.io/owning-component: dashboard
+`, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dashboard-oauth-config
+  annotations:
+    secret-generator.opendatahub.io/name: cookie_secret
+    secret-generator.opendatahub.io/type: random
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: dashboard-oauth-client
+  annotations:
+    secret-generator.opendatahub.io/name: secret
+`} {
+			res, err := factory.FromBytes([]byte(manifest))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resMap.Append(res)).To(Succeed())
+		}
+	})
+
+	It("Should remove the secret generator annotations of the Secrets of the OAuthClients", func() {
+		oauthClientPlugin := plugins.OAuthClientPlugin{Names: []string{"dashboard-oauth-client"}}
+		Expect(oauthClientPlugin.Transform(resMap)).To(Succeed())
+
+		Expect(resMap.Resources()[0].GetAnnotations()).To(Equal(map[string]string{"openshift.io/owning-component": "dashboard"}))
+	})
+
+	It("Should leave other Secrets and resources alone", func() {
+		oauthClientPlugin := plugins.OAuthClientPlugin{Names: []string{"dashboard-oauth-client"}}
+		Expect(oauthClientPlugin.Transform(resMap)).To(Succeed())
+
+		Expect(resMap.Resources()[1].GetAnnotations()).To(HaveLen(2))
+		Expect(resMap.Resources()[2].GetAnnotations()).To(HaveKey("secret-generator.opendatahub.io/name"))
+	})
+
+	It("Should change nothing without OAuthClients", func() {
+		oauthClientPlugin := plugins.OAuthClientPlugin{}
+		Expect(oauthClientPlugin.Transform(resMap)).To(Succeed())
+
+		Expect(resMap.Resources()[0].GetAnnotations()).To(HaveLen(5))
+	})
+})
@@ -0,0 +1,81 @@
+package plugins
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+// PodDisruptionBudgetPlugin adds a PodDisruptionBudget, named after the Deployment, for each of the Deployments with
+// the given names running more than one replica. A budget would block node drains of single replica Deployments.
+// When neither MinAvailable nor MaxUnavailable is set, one pod at a time can be disrupted.
+type PodDisruptionBudgetPlugin struct {
+	DeploymentNames []string
+	MinAvailable    *intstr.IntOrString
+	MaxUnavailable  *intstr.IntOrString
+}
+
+var _ resmap.Transformer = &PodDisruptionBudgetPlugin{}
+
+// Transform appends the PodDisruptionBudgets of the matching Deployments to the ResMap.
+func (p *PodDisruptionBudgetPlugin) Transform(m resmap.ResMap) error {
+	factory := provider.NewDefaultDepProvider().GetResourceFactory()
+
+	for _, res := range m.Resources() {
+		if res.GetKind() != "Deployment" || !slices.Contains(p.DeploymentNames, res.GetName()) {
+			continue
+		}
+		deployment, err := res.Map()
+		if err != nil {
+			return err
+		}
+		// the Deployment defaults to a single replica
+		replicas, _, _ := unstructured.NestedFieldNoCopy(deployment, "spec", "replicas")
+		selector, found, err := unstructured.NestedMap(deployment, "spec", "selector")
+		if err != nil {
+			return err
+		}
+		if n, ok := replicas.(int); !ok || n <= 1 || !found {
+			continue
+		}
+
+		if err := m.Append(factory.FromMap(map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]interface{}{
+				"name":      res.GetName(),
+				"namespace": res.GetNamespace(),
+			},
+			"spec": p.spec(selector),
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PodDisruptionBudgetPlugin) spec(selector map[string]interface{}) map[string]interface{} {
+	spec := map[string]interface{}{"selector": selector}
+	switch {
+	case p.MinAvailable != nil:
+		spec["minAvailable"] = intOrStringValue(p.MinAvailable)
+	case p.MaxUnavailable != nil:
+		spec["maxUnavailable"] = intOrStringValue(p.MaxUnavailable)
+	default:
+		spec["maxUnavailable"] = 1
+	}
+
+	return spec
+}
+
+func intOrStringValue(value *intstr.IntOrString) interface{} {
+	if value.Type == intstr.Int {
+		return value.IntValue()
+	}
+
+	return value.StrVal
+}
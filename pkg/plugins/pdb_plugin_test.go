@@ -0,0 +1,76 @@
+package plugins_test
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PodDisruptionBudget plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		resources, err := factory.SliceFromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-dashboard
+  namespace: opendatahub
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: odh-dashboard
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-notebook-controller-manager
+  namespace: opendatahub
+spec:
+  selector:
+    matchLabels:
+      app: odh-notebook-controller
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		resMap = resmap.New()
+		for _, res := range resources {
+			Expect(resMap.Append(res)).To(Succeed())
+		}
+	})
+
+	It("Should add a budget allowing one disrupted pod for multi-replica Deployments only", func() {
+		pdbPlugin := plugins.PodDisruptionBudgetPlugin{DeploymentNames: []string{"odh-dashboard", "odh-notebook-controller-manager"}}
+		Expect(pdbPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: odh-dashboard
+  namespace: opendatahub
+spec:
+  maxUnavailable: 1
+  selector:
+    matchLabels:
+      app: odh-dashboard
+`
+		Expect(resMap.Resources()).To(HaveLen(3))
+		Expect(resMap.Resources()[2].MustYaml()).To(MatchYAML(expected))
+	})
+
+	It("Should set the overridden budget", func() {
+		minAvailable := intstr.FromString("50%")
+		pdbPlugin := plugins.PodDisruptionBudgetPlugin{DeploymentNames: []string{"odh-dashboard"}, MinAvailable: &minAvailable}
+		Expect(pdbPlugin.Transform(resMap)).To(Succeed())
+
+		Expect(resMap.Resources()).To(HaveLen(3))
+		Expect(resMap.Resources()[2].MustYaml()).To(ContainSubstring("minAvailable: 50%"))
+		Expect(resMap.Resources()[2].MustYaml()).NotTo(ContainSubstring("maxUnavailable"))
+	})
+})
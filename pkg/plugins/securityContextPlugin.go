@@ -0,0 +1,156 @@
+package plugins
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// SecurityContextPlugin sets the security context of the pods of all Deployments, and the SecurityContextConstraints
+// they require in the given annotation. The UID, GID, fsGroup and seccomp profile replace the ones of the manifests.
+// When Restricted, the fields the restricted Pod Security Standard requires are set where the manifests leave them unset.
+type SecurityContextPlugin struct {
+	Restricted     bool
+	SCCAnnotation  string
+	SCC            string
+	RunAsUser      *int64
+	RunAsGroup     *int64
+	FSGroup        *int64
+	SeccompProfile *corev1.SeccompProfile
+}
+
+var _ resmap.Transformer = &SecurityContextPlugin{}
+
+// Transform sets the security context of the Deployments of the ResMap.
+func (p *SecurityContextPlugin) Transform(m resmap.ResMap) error {
+	return m.ApplyFilter(SecurityContextFilter(*p))
+}
+
+type SecurityContextFilter SecurityContextPlugin
+
+var _ kio.Filter = SecurityContextFilter{}
+
+func (f SecurityContextFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	return kio.FilterAll(kyaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f SecurityContextFilter) run(node *kyaml.RNode) (*kyaml.RNode, error) {
+	if node.GetKind() != gvk.Deployment.Kind {
+		return node, nil
+	}
+
+	template, err := node.Pipe(kyaml.LookupCreate(kyaml.MappingNode, "spec", "template"))
+	if err != nil {
+		return node, err
+	}
+	if f.SCC != "" {
+		if err := template.PipeE(kyaml.SetAnnotation(f.SCCAnnotation, f.SCC)); err != nil {
+			return node, err
+		}
+	}
+
+	podSecurityContext, err := template.Pipe(kyaml.LookupCreate(kyaml.MappingNode, "spec", "securityContext"))
+	if err != nil {
+		return node, err
+	}
+	if err := setInt(podSecurityContext, "runAsUser", f.RunAsUser, true); err != nil {
+		return node, err
+	}
+	if err := setInt(podSecurityContext, "runAsGroup", f.RunAsGroup, true); err != nil {
+		return node, err
+	}
+	if err := setInt(podSecurityContext, "fsGroup", f.FSGroup, true); err != nil {
+		return node, err
+	}
+	seccompProfile := f.SeccompProfile
+	if seccompProfile == nil && f.Restricted && podSecurityContext.Field("seccompProfile") == nil {
+		seccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	if seccompProfile != nil {
+		profile := kyaml.NewMapRNode(&map[string]string{"type": string(seccompProfile.Type)})
+		if seccompProfile.LocalhostProfile != nil {
+			if err := profile.PipeE(kyaml.SetField("localhostProfile", kyaml.NewStringRNode(*seccompProfile.LocalhostProfile))); err != nil {
+				return node, err
+			}
+		}
+		if err := podSecurityContext.PipeE(kyaml.SetField("seccompProfile", profile)); err != nil {
+			return node, err
+		}
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, err := template.Pipe(kyaml.Lookup("spec", field))
+		if err != nil {
+			return node, err
+		}
+		if containers == nil {
+			continue
+		}
+		if err := containers.VisitElements(f.setContainer); err != nil {
+			return node, err
+		}
+	}
+
+	return node, nil
+}
+
+// setContainer replaces the UID and GID the container runs as when set by the manifests, which would otherwise take
+// precedence over the ones of the pod, and completes its security context when Restricted.
+func (f SecurityContextFilter) setContainer(container *kyaml.RNode) error {
+	if !f.Restricted && f.RunAsUser == nil && f.RunAsGroup == nil {
+		return nil
+	}
+	securityContext, err := container.Pipe(kyaml.LookupCreate(kyaml.MappingNode, "securityContext"))
+	if err != nil {
+		return err
+	}
+	if err := setInt(securityContext, "runAsUser", f.RunAsUser, false); err != nil {
+		return err
+	}
+	if err := setInt(securityContext, "runAsGroup", f.RunAsGroup, false); err != nil {
+		return err
+	}
+	if !f.Restricted {
+		return nil
+	}
+
+	if securityContext.Field("allowPrivilegeEscalation") == nil {
+		if err := securityContext.PipeE(kyaml.SetField("allowPrivilegeEscalation", scalar(kyaml.NodeTagBool, "false"))); err != nil {
+			return err
+		}
+	}
+	if securityContext.Field("runAsNonRoot") == nil {
+		if err := securityContext.PipeE(kyaml.SetField("runAsNonRoot", scalar(kyaml.NodeTagBool, "true"))); err != nil {
+			return err
+		}
+	}
+	capabilities, err := securityContext.Pipe(kyaml.LookupCreate(kyaml.MappingNode, "capabilities"))
+	if err != nil {
+		return err
+	}
+	if capabilities.Field("drop") == nil {
+		return capabilities.PipeE(kyaml.SetField("drop", kyaml.NewListRNode("ALL")))
+	}
+
+	return nil
+}
+
+// setInt sets the field of the security context to the value when given. Fields not set yet are only added when create
+// is true.
+func setInt(securityContext *kyaml.RNode, field string, value *int64, create bool) error {
+	if value == nil || (!create && securityContext.Field(field) == nil) {
+		return nil
+	}
+
+	return securityContext.PipeE(kyaml.SetField(field, scalar(kyaml.NodeTagInt, strconv.FormatInt(*value, 10))))
+}
+
+// scalar returns a node of the value with the given tag, for it not to be set as a string.
+func scalar(tag, value string) *kyaml.RNode {
+	return kyaml.NewRNode(&kyaml.Node{Kind: kyaml.ScalarNode, Tag: tag, Value: value})
+}
@@ -0,0 +1,115 @@
+package plugins_test
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/kustomize/api/resmap"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Security context plugin", func() {
+	var resMap resmap.ResMap
+
+	BeforeEach(func() {
+		deployment, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-model-controller
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        securityContext:
+          runAsUser: 1000
+          allowPrivilegeEscalation: true
+      initContainers:
+      - name: setup
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		resMap = resmap.New()
+		Expect(resMap.Append(deployment)).To(Succeed())
+	})
+
+	It("Should set the SCC and complete the security context of containers for the restricted level", func() {
+		securityContextPlugin := plugins.SecurityContextPlugin{
+			Restricted:    true,
+			SCCAnnotation: "openshift.io/required-scc",
+			SCC:           "restricted-v2",
+			RunAsUser:     ptr.To[int64](1000680000),
+			FSGroup:       ptr.To[int64](1000680000),
+		}
+		Expect(securityContextPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-model-controller
+spec:
+  template:
+    metadata:
+      annotations:
+        openshift.io/required-scc: restricted-v2
+    spec:
+      securityContext:
+        runAsUser: 1000680000
+        fsGroup: 1000680000
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - name: manager
+        securityContext:
+          runAsUser: 1000680000
+          allowPrivilegeEscalation: true
+          runAsNonRoot: true
+          capabilities:
+            drop:
+            - ALL
+      initContainers:
+      - name: setup
+        securityContext:
+          allowPrivilegeEscalation: false
+          runAsNonRoot: true
+          capabilities:
+            drop:
+            - ALL
+`
+		Expect(resMap.Resources()[0].MustYaml()).To(MatchYAML(expected))
+	})
+
+	It("Should only replace the seccomp profile at the baseline level", func() {
+		securityContextPlugin := plugins.SecurityContextPlugin{
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: ptr.To("profiles/odh.json")},
+		}
+		Expect(securityContextPlugin.Transform(resMap)).To(Succeed())
+
+		expected := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: odh-model-controller
+spec:
+  template:
+    spec:
+      securityContext:
+        seccompProfile:
+          type: Localhost
+          localhostProfile: profiles/odh.json
+      containers:
+      - name: manager
+        securityContext:
+          runAsUser: 1000
+          allowPrivilegeEscalation: true
+      initContainers:
+      - name: setup
+`
+		Expect(resMap.Resources()[0].MustYaml()).To(MatchYAML(expected))
+	})
+})
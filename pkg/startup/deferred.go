@@ -0,0 +1,75 @@
+// Package startup sets up the controllers of the operator in stages, for the critical ones to reconcile as soon as
+// possible after a restart of the operator.
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// SetupFunc sets up a controller with the manager.
+type SetupFunc func(mgr manager.Manager) error
+
+type deferredController struct {
+	name  string
+	setup SetupFunc
+}
+
+// Deferred is a manager.Runnable setting up secondary controllers once the caches of the kinds watched by the
+// critical ones are synced. The informers of the secondary controllers then no longer compete with the critical ones
+// for the API server while they list their objects, which on busy clusters delays the first reconciliation by minutes.
+type Deferred struct {
+	mgr         manager.Manager
+	log         logr.Logger
+	waitFor     []client.Object
+	controllers []deferredController
+}
+
+var _ manager.LeaderElectionRunnable = (*Deferred)(nil)
+
+// NewDeferred returns a Deferred waiting for the caches of the given kinds to be synced.
+func NewDeferred(mgr manager.Manager, log logr.Logger, waitFor ...client.Object) *Deferred {
+	return &Deferred{mgr: mgr, log: log, waitFor: waitFor}
+}
+
+// Add defers the setup of the named controller.
+func (d *Deferred) Add(name string, setup SetupFunc) {
+	d.controllers = append(d.controllers, deferredController{name: name, setup: setup})
+}
+
+// Start waits for the caches of the critical controllers to be synced and sets up the deferred controllers. A failing
+// setup stops the manager, as it would have if the controller had been set up before starting it.
+func (d *Deferred) Start(ctx context.Context) error {
+	started := time.Now()
+	for _, obj := range d.waitFor {
+		// blocks until the informer is synced
+		if _, err := d.mgr.GetCache().GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("failed waiting for the cache of %T to sync: %w", obj, err)
+		}
+	}
+	// informers of the objects owned by the critical controllers have been started along with them
+	if !d.mgr.GetCache().WaitForCacheSync(ctx) {
+		return errors.New("failed waiting for the caches of the critical controllers to sync")
+	}
+	d.log.Info("caches of critical controllers synced, setting up deferred controllers",
+		"after", time.Since(started).Round(time.Millisecond).String(), "controllers", len(d.controllers))
+
+	for _, c := range d.controllers {
+		if err := c.setup(d.mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %w", c.name, err)
+		}
+	}
+
+	return nil
+}
+
+// NeedLeaderElection makes the deferred controllers start on the leader only, like the critical ones.
+func (d *Deferred) NeedLeaderElection() bool {
+	return true
+}
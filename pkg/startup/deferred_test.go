@@ -0,0 +1,89 @@
+package startup_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/startup"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeManager struct {
+	manager.Manager
+	cache cache.Cache
+}
+
+func (m *fakeManager) GetCache() cache.Cache {
+	return m.cache
+}
+
+var _ = Describe("Deferred controllers", func() {
+	var (
+		synced    bool
+		informers *informertest.FakeInformers
+		deferred  *startup.Deferred
+		setUp     []string
+	)
+
+	setup := func(name string) startup.SetupFunc {
+		return func(manager.Manager) error {
+			setUp = append(setUp, name)
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+		synced = true
+		setUp = nil
+		informers = &informertest.FakeInformers{Scheme: scheme, Synced: &synced}
+		deferred = startup.NewDeferred(&fakeManager{cache: informers}, logr.Discard(), &dscv1.DataScienceCluster{})
+		deferred.Add("SecretGenerator", setup("SecretGenerator"))
+		deferred.Add("RouteHealth", setup("RouteHealth"))
+	})
+
+	It("should not be set up before the manager starts", func() {
+		Expect(setUp).To(BeEmpty())
+	})
+
+	It("should be set up in order once the caches are synced", func(ctx context.Context) {
+		Expect(deferred.Start(ctx)).To(Succeed())
+
+		Expect(setUp).To(Equal([]string{"SecretGenerator", "RouteHealth"}))
+		Expect(informers.InformersByGVK).To(HaveKey(dscv1.GroupVersion.WithKind("DataScienceCluster")))
+	})
+
+	It("should stop the manager when a setup fails", func(ctx context.Context) {
+		deferred.Add("PolicyReport", func(manager.Manager) error { return errors.New("no kind match") })
+
+		Expect(deferred.Start(ctx)).To(MatchError("unable to create controller PolicyReport: no kind match"))
+	})
+
+	It("should not be set up when the caches do not sync", func(ctx context.Context) {
+		synced = false
+
+		Expect(deferred.Start(ctx)).To(MatchError(ContainSubstring("failed waiting for the caches")))
+		Expect(setUp).To(BeEmpty())
+	})
+
+	It("should not be set up when an informer of the critical controllers cannot be started", func(ctx context.Context) {
+		informers.Error = errors.New("forbidden")
+
+		Expect(deferred.Start(ctx)).To(MatchError(ContainSubstring("failed waiting for the cache of *v1.DataScienceCluster to sync")))
+		Expect(setUp).To(BeEmpty())
+	})
+
+	It("should only start on the leader", func() {
+		Expect(deferred.NeedLeaderElection()).To(BeTrue())
+	})
+})
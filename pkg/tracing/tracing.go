@@ -0,0 +1,126 @@
+// Package tracing exports traces of the reconciliations of the operator with OTLP, e.g. to diagnose long
+// reconciliations of a DataScienceCluster. Spans are not recorded until an exporter is configured in the OperatorConfig.
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	serviceName = "opendatahub-operator"
+	tracerName  = "github.com/opendatahub-io/opendatahub-operator/v2"
+
+	// shutdownTimeout bounds the time spent flushing the spans of a provider being replaced.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Config holds the runtime tracing configuration.
+type Config struct {
+	// Endpoint of the OTLP/HTTP receiver, as host:port.
+	Endpoint string
+	// Insecure exports spans over plain HTTP.
+	Insecure bool
+}
+
+var (
+	mu       sync.RWMutex
+	current  Config
+	provider *sdktrace.TracerProvider
+	tracer   = trace.NewNoopTracerProvider().Tracer(tracerName)
+)
+
+// ApplyConfig exports the spans recorded from now on to the endpoint of the configuration. The spans recorded with
+// the previous configuration are flushed.
+func ApplyConfig(ctx context.Context, cfg Config) error {
+	mu.RLock()
+	unchanged := provider != nil && cfg == current
+	mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	replace(tp, tp.Tracer(tracerName), cfg)
+	return nil
+}
+
+// ResetConfig stops recording spans, after flushing the ones recorded so far.
+func ResetConfig() {
+	replace(nil, trace.NewNoopTracerProvider().Tracer(tracerName), Config{})
+}
+
+// Shutdown flushes the spans recorded so far, e.g. before the operator exits.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	previous := provider
+	provider, tracer, current = nil, trace.NewNoopTracerProvider().Tracer(tracerName), Config{}
+	mu.Unlock()
+
+	if previous == nil {
+		return nil
+	}
+	return previous.Shutdown(ctx)
+}
+
+func replace(tp *sdktrace.TracerProvider, t trace.Tracer, cfg Config) {
+	mu.Lock()
+	previous := provider
+	provider, tracer, current = tp, t, cfg
+	mu.Unlock()
+
+	if previous != nil {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			_ = previous.Shutdown(ctx)
+		}()
+	}
+}
+
+// Start starts a span as a child of the span of the context, if any. The logger of the context returned for a new
+// trace carries its ID, so the logs of a reconciliation can be found from its trace.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	mu.RLock()
+	t := tracer
+	mu.RUnlock()
+
+	parent := trace.SpanContextFromContext(ctx)
+	ctx, span := t.Start(ctx, name, trace.WithAttributes(attrs...))
+	if spanContext := span.SpanContext(); spanContext.IsValid() && !parent.IsValid() {
+		ctx = logf.IntoContext(ctx, logf.FromContext(ctx).WithValues("traceID", spanContext.TraceID().String()))
+	}
+
+	return ctx, span
+}
+
+// End ends the span, marking it as failed with the error, if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-logr/logr/funcr"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		exported atomic.Int32
+		receiver *httptest.Server
+		cfg      Config
+	)
+
+	BeforeEach(func() {
+		exported.Store(0)
+		receiver = httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/traces" {
+				exported.Add(1)
+			}
+		}))
+		DeferCleanup(receiver.Close)
+		DeferCleanup(ResetConfig)
+		cfg = Config{Endpoint: strings.TrimPrefix(receiver.URL, "http://"), Insecure: true}
+	})
+
+	It("should not record spans without configuration", func(ctx context.Context) {
+		_, span := Start(ctx, "disabled")
+
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(Shutdown(ctx)).To(Succeed())
+	})
+
+	When("an endpoint is configured", func() {
+		BeforeEach(func(ctx context.Context) {
+			Expect(ApplyConfig(ctx, cfg)).To(Succeed())
+		})
+
+		It("should record spans of the same trace and export them", func(ctx context.Context) {
+			ctx, parent := Start(ctx, "reconcile")
+			_, child := Start(ctx, "reconcile component")
+			Expect(parent.IsRecording()).To(BeTrue())
+			Expect(child.SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+			End(child, nil)
+			End(parent, nil)
+
+			Expect(Shutdown(ctx)).To(Succeed())
+			Expect(exported.Load()).NotTo(BeZero())
+		})
+
+		It("should add the trace ID to the logs of a new trace", func(ctx context.Context) {
+			var logged string
+			ctx = logf.IntoContext(ctx, funcr.New(func(_, args string) { logged = args }, funcr.Options{}))
+
+			ctx, span := Start(ctx, "reconcile")
+			logf.FromContext(ctx).Info("reconciling")
+
+			Expect(logged).To(ContainSubstring(span.SpanContext().TraceID().String()))
+		})
+
+		It("should mark spans ended with an error as failed", func(ctx context.Context) {
+			_, span := Start(ctx, "reconcile")
+			End(span, errors.New("boom"))
+
+			readOnly, ok := span.(sdktrace.ReadOnlySpan)
+			Expect(ok).To(BeTrue())
+			Expect(readOnly.Status().Code).To(Equal(codes.Error))
+			Expect(readOnly.Status().Description).To(Equal("boom"))
+		})
+
+		It("should keep the provider when the configuration did not change", func(ctx context.Context) {
+			mu.RLock()
+			previous := provider
+			mu.RUnlock()
+
+			Expect(ApplyConfig(ctx, cfg)).To(Succeed())
+
+			mu.RLock()
+			defer mu.RUnlock()
+			Expect(provider).To(BeIdenticalTo(previous))
+		})
+
+		It("should stop recording spans once the configuration is reset", func(ctx context.Context) {
+			ResetConfig()
+
+			_, span := Start(ctx, "reconcile")
+			Expect(span.IsRecording()).To(BeFalse())
+		})
+	})
+})
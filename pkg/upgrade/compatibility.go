@@ -0,0 +1,203 @@
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+)
+
+var incompatibleResources = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "odh_incompatible_resources",
+		Help: "Number of DataScienceClusters and DSCInitializations incompatible with the running operator, labeled by kind.",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(incompatibleResources)
+}
+
+// compatibilityCheck returns the incompatibilities of a resource, read as unstructured to get the fields the API
+// does not know anymore.
+type compatibilityCheck func(obj *unstructured.Unstructured) ([]string, error)
+
+var compatibilityChecks = map[schema.GroupVersionKind][]compatibilityCheck{
+	gvk.DataScienceCluster: {unknownFields(func() any { return &dscv1.DataScienceClusterSpec{} }), dataScienceClusterFeatures},
+	gvk.DSCInitialization:  {unknownFields(func() any { return &dsciv1.DSCInitializationSpec{} }), dscInitializationFeatures},
+}
+
+// VerifyCompatibility checks the existing DataScienceClusters and DSCInitializations against the API and the features
+// of the running operator, e.g. after an upgrade removed a field or a feature gate got disabled. It is meant to run
+// before their reconciliation begins. Incompatibilities are reported in the Compatible condition of the resources and
+// in the odh_incompatible_resources metric, while compatible resources get the condition removed.
+func VerifyCompatibility(ctx context.Context, cli client.Client) error {
+	log := logf.FromContext(ctx)
+
+	for kind, checks := range compatibilityChecks {
+		objs := &unstructured.UnstructuredList{}
+		objs.SetGroupVersionKind(kind)
+		if err := cli.List(ctx, objs); err != nil {
+			return fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+		}
+
+		incompatible := 0
+		for i := range objs.Items {
+			obj := &objs.Items[i]
+			var problems []string
+			for _, check := range checks {
+				found, err := check(obj)
+				if err != nil {
+					return fmt.Errorf("failed to verify %s %s: %w", kind.Kind, obj.GetName(), err)
+				}
+				problems = append(problems, found...)
+			}
+			if len(problems) > 0 {
+				incompatible++
+				log.Info("resource incompatible with the operator", "kind", kind.Kind, "name", obj.GetName(), "problems", problems)
+			}
+			if err := reportCompatibility(ctx, cli, kind, obj.GetName(), problems); err != nil {
+				return err
+			}
+		}
+		incompatibleResources.WithLabelValues(kind.Kind).Set(float64(incompatible))
+	}
+
+	return nil
+}
+
+// HasIncompatibilities tells whether the conditions report incompatibilities found by VerifyCompatibility, which
+// should be verified again once the resource changed.
+func HasIncompatibilities(conditions []conditionsv1.Condition) bool {
+	return conditionsv1.FindStatusCondition(conditions, status.ConditionCompatible) != nil
+}
+
+func reportCompatibility(ctx context.Context, cli client.Client, kind schema.GroupVersionKind, name string, problems []string) error {
+	var err error
+	switch kind {
+	case gvk.DataScienceCluster:
+		instance := &dscv1.DataScienceCluster{}
+		if err = cli.Get(ctx, client.ObjectKey{Name: name}, instance); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !HasIncompatibilities(instance.Status.Conditions) && len(problems) == 0 {
+			return nil
+		}
+		_, err = status.UpdateWithRetry(ctx, cli, instance, func(saved *dscv1.DataScienceCluster) {
+			setCompatibleCondition(&saved.Status.Conditions, problems)
+		})
+	case gvk.DSCInitialization:
+		instance := &dsciv1.DSCInitialization{}
+		if err = cli.Get(ctx, client.ObjectKey{Name: name}, instance); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !HasIncompatibilities(instance.Status.Conditions) && len(problems) == 0 {
+			return nil
+		}
+		_, err = status.UpdateWithRetry(ctx, cli, instance, func(saved *dsciv1.DSCInitialization) {
+			setCompatibleCondition(&saved.Status.Conditions, problems)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to report compatibility of %s %s: %w", kind.Kind, name, err)
+	}
+
+	return nil
+}
+
+func setCompatibleCondition(conditions *[]conditionsv1.Condition, problems []string) {
+	if len(problems) == 0 {
+		conditionsv1.RemoveStatusCondition(conditions, status.ConditionCompatible)
+		return
+	}
+	status.SetCondition(conditions, string(status.ConditionCompatible), status.IncompatibleSpec,
+		"Not compatible with the operator: "+strings.Join(problems, "; "), corev1.ConditionFalse)
+}
+
+// unknownFields reports the fields of the resource the API does not define, e.g. fields removed by an upgrade.
+func unknownFields(newSpec func() any) compatibilityCheck {
+	return func(obj *unstructured.Unstructured) ([]string, error) {
+		if err := decode(obj.Object["spec"], newSpec(), true); err != nil {
+			return []string{"spec: " + err.Error()}, nil
+		}
+
+		return nil, nil
+	}
+}
+
+// decode converts the content of a resource with encoding/json, as the unstructured converter does not handle the
+// components embedded with `json:""` tags.
+func decode(content any, into any, strict bool) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	return decoder.Decode(into)
+}
+
+var managementStates = []operatorv1.ManagementState{operatorv1.Managed, operatorv1.Removed}
+
+func dataScienceClusterFeatures(obj *unstructured.Unstructured) ([]string, error) {
+	instance := &dscv1.DataScienceCluster{}
+	if err := decode(obj.Object, instance, false); err != nil {
+		return []string{err.Error()}, nil
+	}
+	allComponents, err := instance.GetComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, component := range allComponents {
+		if state := component.GetManagementState(); state != "" && !slices.Contains(managementStates, state) {
+			problems = append(problems, fmt.Sprintf("managementState %s of %s is not supported", state, component.GetComponentName()))
+		}
+	}
+	if instance.Spec.Components.Kserve.DefaultDeploymentMode == kserve.RawDeployment && !featuregate.Enabled(featuregate.KServeRawDeployment) {
+		problems = append(problems, fmt.Sprintf("defaultDeploymentMode %s of kserve requires the %s feature gate", kserve.RawDeployment, featuregate.KServeRawDeployment))
+	}
+
+	return problems, nil
+}
+
+func dscInitializationFeatures(obj *unstructured.Unstructured) ([]string, error) {
+	instance := &dsciv1.DSCInitialization{}
+	if err := decode(obj.Object, instance, false); err != nil {
+		return []string{err.Error()}, nil
+	}
+
+	var problems []string
+	if state := instance.Spec.Monitoring.ManagementState; state != "" && !slices.Contains(managementStates, state) {
+		problems = append(problems, fmt.Sprintf("managementState %s of monitoring is not supported", state))
+	}
+	if policy := instance.Spec.NamespacePolicy; policy != "" && policy != dsciv1.NamespacePolicyCreate && policy != dsciv1.NamespacePolicyVerify {
+		problems = append(problems, fmt.Sprintf("namespacePolicy %s is not supported", policy))
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,156 @@
+package upgrade
+
+import (
+	"context"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/kserve"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/featuregate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unknown fields", func() {
+	var obj *unstructured.Unstructured
+	check := unknownFields(func() any { return &dscv1.DataScienceClusterSpec{} })
+
+	BeforeEach(func() {
+		obj = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "datasciencecluster.opendatahub.io/v1",
+			"kind":       "DataScienceCluster",
+			"metadata":   map[string]interface{}{"name": "default-dsc"},
+			"spec": map[string]interface{}{"components": map[string]interface{}{
+				"dashboard": map[string]interface{}{"managementState": "Managed"},
+				"legacy":    map[string]interface{}{"managementState": "Managed"},
+			}},
+		}}
+	})
+
+	It("should report a component removed from the API", func() {
+		Expect(check(obj)).To(ConsistOf(ContainSubstring("legacy")))
+	})
+
+	It("should not report anything for known fields", func() {
+		unstructured.RemoveNestedField(obj.Object, "spec", "components", "legacy")
+
+		Expect(check(obj)).To(BeEmpty())
+	})
+
+	It("should not report anything for a resource without spec", func() {
+		unstructured.RemoveNestedField(obj.Object, "spec")
+
+		Expect(check(obj)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Verifying compatibility", func() {
+	var (
+		dsc  *dscv1.DataScienceCluster
+		dsci *dsciv1.DSCInitialization
+		cli  client.Client
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dscv1.AddToScheme(scheme)).To(Succeed())
+		Expect(dsciv1.AddToScheme(scheme)).To(Succeed())
+
+		dsc = &dscv1.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}}
+		dsci = &dsciv1.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}}
+		cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(dsc, dsci).WithStatusSubresource(dsc, dsci).Build()
+	})
+
+	compatibleCondition := func(ctx context.Context, obj client.Object) *conditionsv1.Condition {
+		GinkgoHelper()
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		switch obj := obj.(type) {
+		case *dscv1.DataScienceCluster:
+			return conditionsv1.FindStatusCondition(obj.Status.Conditions, status.ConditionCompatible)
+		case *dsciv1.DSCInitialization:
+			return conditionsv1.FindStatusCondition(obj.Status.Conditions, status.ConditionCompatible)
+		}
+
+		return nil
+	}
+
+	It("should not set any condition on compatible resources", func(ctx context.Context) {
+		Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+		Expect(compatibleCondition(ctx, dsc)).To(BeNil())
+		Expect(compatibleCondition(ctx, dsci)).To(BeNil())
+	})
+
+	When("a DataScienceCluster uses an unsupported management state", func() {
+		BeforeEach(func(ctx context.Context) {
+			dsc.Spec.Components.Workbenches.ManagementState = operatorv1.Unmanaged
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+		})
+
+		It("should report it in the Compatible condition", func(ctx context.Context) {
+			Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+			condition := compatibleCondition(ctx, dsc)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(status.IncompatibleSpec))
+			Expect(condition.Message).To(Equal("Not compatible with the operator: managementState Unmanaged of workbenches is not supported"))
+			Expect(compatibleCondition(ctx, dsci)).To(BeNil())
+		})
+
+		It("should remove the condition once the spec is fixed", func(ctx context.Context) {
+			Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+			Expect(compatibleCondition(ctx, dsc)).NotTo(BeNil())
+
+			dsc.Spec.Components.Workbenches.ManagementState = operatorv1.Removed
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+			Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+			Expect(compatibleCondition(ctx, dsc)).To(BeNil())
+		})
+	})
+
+	When("KServe defaults to RawDeployment", func() {
+		BeforeEach(func(ctx context.Context) {
+			dsc.Spec.Components.Kserve.DefaultDeploymentMode = kserve.RawDeployment
+			Expect(cli.Update(ctx, dsc)).To(Succeed())
+		})
+
+		It("should be compatible while the feature gate is enabled", func(ctx context.Context) {
+			Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+			Expect(compatibleCondition(ctx, dsc)).To(BeNil())
+		})
+
+		It("should report the disabled feature gate", func(ctx context.Context) {
+			Expect(featuregate.Set(map[string]bool{featuregate.KServeRawDeployment: false})).To(Succeed())
+			DeferCleanup(func() { Expect(featuregate.Set(nil)).To(Succeed()) })
+
+			Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+			condition := compatibleCondition(ctx, dsc)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(ContainSubstring("requires the KServeRawDeployment feature gate"))
+		})
+	})
+
+	It("should report an unsupported namespace policy of a DSCInitialization", func(ctx context.Context) {
+		dsci.Spec.NamespacePolicy = "Adopt"
+		Expect(cli.Update(ctx, dsci)).To(Succeed())
+
+		Expect(VerifyCompatibility(ctx, cli)).To(Succeed())
+
+		condition := compatibleCondition(ctx, dsci)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Message).To(Equal("Not compatible with the operator: namespacePolicy Adopt is not supported"))
+	})
+})
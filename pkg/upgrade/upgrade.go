@@ -4,6 +4,7 @@ package upgrade
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -15,7 +16,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,8 +43,12 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/workbenches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/journal"
 )
 
+// cleanupOperation is the name of the cleanup of previous releases in the operation journal.
+const cleanupOperation = "cleanup-existing-resources"
+
 type ResourceSpec struct {
 	Gvk       schema.GroupVersionKind
 	Namespace string
@@ -210,9 +214,46 @@ func getDashboardWatsonResources(ns string) []ResourceSpec {
 // TODO: remove function once we have a generic solution across all components.
 func CleanupExistingResource(ctx context.Context,
 	cli client.Client,
+	operationJournal *journal.Journal,
 	platform cluster.Platform,
 	dscApplicationsNamespace, dscMonitoringNamespace string,
 	oldReleaseVersion cluster.Release,
+) error {
+	release, err := json.Marshal(oldReleaseVersion)
+	if err != nil {
+		return err
+	}
+
+	// the release is recorded with the operation, as the DSCI reports the new one once reconciled: an upgrade
+	// interrupted by a restart then proceeds with the release it started from.
+	return operationJournal.Run(ctx, cleanupOperation, map[string]string{"release": string(release)},
+		journal.Step{
+			Name: "upgrade-dashboard-config",
+			Run: func(ctx context.Context, data map[string]string) error {
+				// only apply on RHOAI since ODH has a different way to create this CR by dashboard
+				if platform != cluster.SelfManagedRhods && platform != cluster.ManagedRhods {
+					return nil
+				}
+				startedFrom := cluster.Release{}
+				if err := json.Unmarshal([]byte(data["release"]), &startedFrom); err != nil {
+					return err
+				}
+				return upgradeODCCR(ctx, cli, "odh-dashboard-config", dscApplicationsNamespace, startedFrom)
+			},
+		},
+		journal.Step{
+			Name: "delete-deprecated-resources",
+			Run: func(ctx context.Context, _ map[string]string) error {
+				return deleteDeprecatedResourcesOfReleases(ctx, cli, platform, dscApplicationsNamespace, dscMonitoringNamespace)
+			},
+		},
+	)
+}
+
+func deleteDeprecatedResourcesOfReleases(ctx context.Context,
+	cli client.Client,
+	platform cluster.Platform,
+	dscApplicationsNamespace, dscMonitoringNamespace string,
 ) error {
 	var multiErr *multierror.Error
 	// Special Handling of cleanup of deprecated model monitoring stack
@@ -268,13 +309,6 @@ func CleanupExistingResource(ctx context.Context,
 			"jupyterhub-use-s3-bucket-data",
 		})
 	multiErr = multierror.Append(multiErr, deleteResources(ctx, cli, &odhDocJPH))
-	// only apply on RHOAI since ODH has a different way to create this CR by dashboard
-	if platform == cluster.SelfManagedRhods || platform == cluster.ManagedRhods {
-		if err := upgradeODCCR(ctx, cli, "odh-dashboard-config", dscApplicationsNamespace, oldReleaseVersion); err != nil {
-			return err
-		}
-	}
-
 	// to take a reference
 	toDelete := getDashboardWatsonResources(dscApplicationsNamespace)
 	multiErr = multierror.Append(multiErr, deleteResources(ctx, cli, &toDelete))
@@ -387,16 +421,17 @@ func deleteDeprecatedServiceMonitors(ctx context.Context, cli client.Client, nam
 	return multiErr.ErrorOrNil()
 }
 
-func removOdhApplicationsCR(ctx context.Context, cli client.Client, gvk schema.GroupVersionKind, instanceName string, applicationNS string) error {
+func removOdhApplicationsCR(ctx context.Context, cli client.Client, crGVK schema.GroupVersionKind, instanceName string, applicationNS string) error {
 	// first check if CRD in cluster
-	crd := &apiextv1.CustomResourceDefinition{}
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(gvk.CustomResourceDefinition)
 	if err := cli.Get(ctx, client.ObjectKey{Name: "odhapplications.dashboard.opendatahub.io"}, crd); err != nil {
 		return client.IgnoreNotFound(err)
 	}
 
 	// then check if CR in cluster to delete
 	odhObject := &unstructured.Unstructured{}
-	odhObject.SetGroupVersionKind(gvk)
+	odhObject.SetGroupVersionKind(crGVK)
 	if err := cli.Get(ctx, client.ObjectKey{
 		Namespace: applicationNS,
 		Name:      instanceName,
@@ -415,7 +450,8 @@ func removOdhApplicationsCR(ctx context.Context, cli client.Client, gvk schema.G
 // 2. flip TrustyAI BiasMetrics to false (.spec.dashboardConfig.disableBiasMetrics) if it is lower release version than input 'release'.
 // 3. flip ModelRegistry to false (.spec.dashboardConfig.disableModelRegistry) if it is lower release version than input 'release'.
 func upgradeODCCR(ctx context.Context, cli client.Client, instanceName string, applicationNS string, release cluster.Release) error {
-	crd := &apiextv1.CustomResourceDefinition{}
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(gvk.CustomResourceDefinition)
 	if err := cli.Get(ctx, client.ObjectKey{Name: "odhdashboardconfigs.opendatahub.io"}, crd); err != nil {
 		return client.IgnoreNotFound(err)
 	}
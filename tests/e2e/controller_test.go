@@ -26,6 +26,7 @@ import (
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
 	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	featurev1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/features/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/e2e/harness"
 )
 
 var (
@@ -50,6 +51,8 @@ type testContext struct {
 	testDsc *dscv1.DataScienceCluster
 	// test DSCI CR because we do not create it in ODH by default
 	testDSCI *dsciv1.DSCInitialization
+	// harness running the steps shared with downstream test suites
+	harness *harness.Harness
 	// context for accessing resources
 	//nolint:containedctx //reason: legacy v1 test setup
 	ctx context.Context
@@ -80,6 +83,9 @@ func NewTestContext() (*testContext, error) {
 	// Setup DataScienceCluster CR
 	testDSC := setupDSCInstance("e2e-test-dsc")
 
+	h := harness.NewForClient(custClient)
+	h.ApplicationsNamespace = testDSCI.Spec.ApplicationsNamespace
+
 	return &testContext{
 		cfg:                   config,
 		kubeClient:            kc,
@@ -89,6 +95,7 @@ func NewTestContext() (*testContext, error) {
 		ctx:                   context.TODO(),
 		testDsc:               testDSC,
 		testDSCI:              testDSCI,
+		harness:               h,
 	}, nil
 }
 
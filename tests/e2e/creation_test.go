@@ -1,10 +1,8 @@
 package e2e_test
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
 	"testing"
 	"time"
@@ -18,12 +16,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
-	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
 	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/modelregistry"
@@ -118,38 +113,11 @@ func creationTestSuite(t *testing.T) {
 }
 
 func (tc *testContext) testDSCICreation() error {
-	dscLookupKey := types.NamespacedName{Name: tc.testDsc.Name}
-	createdDSCI := &dsciv1.DSCInitialization{}
-	existingDSCIList := &dsciv1.DSCInitializationList{}
-
-	err := tc.customClient.List(tc.ctx, existingDSCIList)
-	if err == nil {
-		// use what you have
-		if len(existingDSCIList.Items) == 1 {
-			tc.testDSCI = &existingDSCIList.Items[0]
-			return nil
-		}
-	}
-	// create one for you
-	err = tc.customClient.Get(tc.ctx, dscLookupKey, createdDSCI)
+	dsci, err := tc.harness.EnsureDSCI(tc.ctx, tc.testDSCI)
 	if err != nil {
-		if k8serr.IsNotFound(err) {
-			nberr := wait.PollUntilContextTimeout(tc.ctx, generalRetryInterval, dsciCreationTimeout, false, func(ctx context.Context) (bool, error) {
-				creationErr := tc.customClient.Create(ctx, tc.testDSCI)
-				if creationErr != nil {
-					log.Printf("error creating DSCI resource %v: %v, trying again",
-						tc.testDSCI.Name, creationErr)
-					return false, nil
-				}
-				return true, nil
-			})
-			if nberr != nil {
-				return fmt.Errorf("error creating e2e-test-dsci DSCI CR %s: %w", tc.testDSCI.Name, nberr)
-			}
-		} else {
-			return fmt.Errorf("error getting e2e-test-dsci DSCI CR %s: %w", tc.testDSCI.Name, err)
-		}
+		return err
 	}
+	tc.testDSCI = dsci
 
 	return nil
 }
@@ -157,39 +125,12 @@ func (tc *testContext) testDSCICreation() error {
 func (tc *testContext) testDSCCreation(t *testing.T) error {
 	t.Helper()
 	// Create DataScienceCluster resource if not already created
-
-	existingDSCList := &dscv1.DataScienceClusterList{}
-	err := tc.customClient.List(tc.ctx, existingDSCList)
-	if err == nil {
-		if len(existingDSCList.Items) > 0 {
-			// Use DSC instance if it already exists
-			tc.testDsc = &existingDSCList.Items[0]
-			return nil
-		}
-	}
-
-	dscLookupKey := types.NamespacedName{Name: tc.testDsc.Name}
-	createdDSC := &dscv1.DataScienceCluster{}
-	err = tc.customClient.Get(tc.ctx, dscLookupKey, createdDSC)
+	dsc, err := tc.harness.EnsureDSC(tc.ctx, tc.testDsc)
 	if err != nil {
-		if k8serr.IsNotFound(err) {
-			dsciErr := wait.PollUntilContextTimeout(tc.ctx, generalRetryInterval, dscCreationTimeout, false, func(ctx context.Context) (bool, error) {
-				creationErr := tc.customClient.Create(ctx, tc.testDsc)
-				if creationErr != nil {
-					log.Printf("error creating DSC resource %v: %v, trying again",
-						tc.testDsc.Name, creationErr)
-
-					return false, nil
-				}
-				return true, nil
-			})
-			if dsciErr != nil {
-				return fmt.Errorf("error creating e2e-test-dsc DSC %s: %w", tc.testDsc.Name, dsciErr)
-			}
-		} else {
-			return fmt.Errorf("error getting e2e-test-dsc DSC %s: %w", tc.testDsc.Name, err)
-		}
+		return err
 	}
+	tc.testDsc = dsc
+
 	return nil
 }
 
@@ -201,22 +142,7 @@ func (tc *testContext) validateDSCReady() error {
 
 func waitDSCReady(tc *testContext) error {
 	// wait for 2 mins which is on the safe side, normally it should get ready once all components are ready
-	err := tc.wait(func(ctx context.Context) (bool, error) {
-		key := types.NamespacedName{Name: tc.testDsc.Name}
-		dsc := &dscv1.DataScienceCluster{}
-
-		err := tc.customClient.Get(ctx, key, dsc)
-		if err != nil {
-			return false, err
-		}
-		return dsc.Status.Phase == "Ready", nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("Error waiting Ready state for DSC %v: %w", tc.testDsc.Name, err)
-	}
-
-	return nil
+	return tc.harness.WaitForDSCReady(tc.ctx, tc.testDsc.Name)
 }
 
 func (tc *testContext) requireInstalled(t *testing.T, gvk schema.GroupVersionKind) {
@@ -302,39 +228,8 @@ func (tc *testContext) testAllComponentCreation(t *testing.T) error { //nolint:f
 }
 
 func (tc *testContext) testComponentCreation(component components.ComponentInterface) error {
-	err := wait.PollUntilContextTimeout(tc.ctx, generalRetryInterval, componentReadyTimeout, true, func(ctx context.Context) (bool, error) {
-		// TODO: see if checking deployment is a good test, CF does not create deployment
-		appList, err := tc.kubeClient.AppsV1().Deployments(tc.applicationsNamespace).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.ODH.Component(component.GetComponentName()),
-		})
-		if err != nil {
-			log.Printf("error listing component deployments :%v", err)
-			return false, fmt.Errorf("error listing component deployments :%w", err)
-		}
-		if len(appList.Items) != 0 {
-			if component.GetManagementState() == operatorv1.Removed {
-				// deployment exists for removed component, retrying
-				return false, nil
-			}
-
-			for _, deployment := range appList.Items {
-				if deployment.Status.ReadyReplicas < 1 {
-					log.Printf("waiting for component deployments to be in Ready state: %s", deployment.Name)
-					return false, nil
-				}
-			}
-			return true, nil
-		}
-		// when no deployment is found
-		// It's ok not to have deployements for unmanaged component
-		if component.GetManagementState() != operatorv1.Managed {
-			return true, nil
-		}
-
-		return false, nil
-	})
-
-	return err
+	// TODO: see if checking deployment is a good test, CF does not create deployment
+	return tc.harness.WaitForComponent(tc.ctx, component)
 }
 
 func (tc *testContext) validateDSCI() error {
@@ -573,32 +468,13 @@ func (tc *testContext) testUpdateDSCComponentEnabled() error {
 	}
 
 	// Disable component Dashboard
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// refresh the instance in case it was updated during the reconcile
-		err := tc.customClient.Get(tc.ctx, types.NamespacedName{Name: tc.testDsc.Name}, tc.testDsc)
-		if err != nil {
-			return fmt.Errorf("error getting resource %w", err)
-		}
-		// Disable the Component
-		tc.testDsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
-
-		// Try to update
-		err = tc.customClient.Update(tc.ctx, tc.testDsc)
-		// Return err itself here (not wrapped inside another error)
-		// so that RetryOnConflict can identify it correctly.
-		if err != nil {
-			return fmt.Errorf("error updating component from 'enabled: true' to 'enabled: false': %w", err)
-		}
-
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("error after retry %w", err)
+	if err := tc.harness.SetComponentState(tc.ctx, tc.testDsc.Name, "dashboard", operatorv1.Removed); err != nil {
+		return fmt.Errorf("error updating component from 'enabled: true' to 'enabled: false': %w", err)
 	}
 
 	// Sleep for 80 seconds to allow the operator to reconcile
 	time.Sleep(8 * generalRetryInterval)
-	_, err = tc.kubeClient.AppsV1().Deployments(tc.applicationsNamespace).Get(tc.ctx, dashboardDeploymentName, metav1.GetOptions{})
+	_, err := tc.kubeClient.AppsV1().Deployments(tc.applicationsNamespace).Get(tc.ctx, dashboardDeploymentName, metav1.GetOptions{})
 	if err != nil {
 		if k8serr.IsNotFound(err) {
 			return nil // correct result: should not find deployment after we disable it already
@@ -0,0 +1,107 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+// WaitForComponent waits for the deployments of the component to match its management state: all ready when it is
+// managed, none left when it is removed.
+func (h *Harness) WaitForComponent(ctx context.Context, component components.ComponentInterface) error {
+	name := component.GetComponentName()
+	state := component.GetManagementState()
+	err := h.poll(ctx, h.Timeouts.ComponentReady, func(ctx context.Context) (bool, error) {
+		deployments := &appsv1.DeploymentList{}
+		if err := h.Client.List(ctx, deployments, client.InNamespace(h.ApplicationsNamespace),
+			client.HasLabels{labels.ODH.Component(name)}); err != nil {
+			return false, fmt.Errorf("error listing component deployments: %w", err)
+		}
+		if len(deployments.Items) == 0 {
+			// it's ok not to have deployments for unmanaged components
+			return state != operatorv1.Managed, nil
+		}
+		if state == operatorv1.Removed {
+			return false, nil
+		}
+		for _, deployment := range deployments.Items {
+			if deployment.Status.ReadyReplicas < 1 {
+				log.Printf("waiting for component deployments to be in Ready state: %s", deployment.Name)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for component %s to be %s: %w", name, state, err)
+	}
+
+	return nil
+}
+
+// WaitForCapability waits for the capability to be reported as available, in the conditions of either the
+// DSCInitialization, e.g. for the service mesh routing and authorization, or the DataScienceCluster.
+func (h *Harness) WaitForCapability(ctx context.Context, capability conditionsv1.ConditionType) error {
+	err := h.poll(ctx, h.Timeouts.Capability, func(ctx context.Context) (bool, error) {
+		dscis := &dsciv1.DSCInitializationList{}
+		if err := h.Client.List(ctx, dscis); err != nil {
+			return false, err
+		}
+		for _, dsci := range dscis.Items {
+			if conditionsv1.IsStatusConditionTrue(dsci.Status.Conditions, capability) {
+				return true, nil
+			}
+		}
+		dscs := &dscv1.DataScienceClusterList{}
+		if err := h.Client.List(ctx, dscs); err != nil {
+			return false, err
+		}
+		for _, dsc := range dscs.Items {
+			if conditionsv1.IsStatusConditionTrue(dsc.Status.Conditions, capability) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for capability %s: %w", capability, err)
+	}
+
+	return nil
+}
+
+// WaitForCRD waits for the CustomResourceDefinition to be established, e.g. for the one of a component to be served.
+func (h *Harness) WaitForCRD(ctx context.Context, name string) error {
+	err := h.poll(ctx, h.Timeouts.CRD, func(ctx context.Context) (bool, error) {
+		crd := &apiextv1.CustomResourceDefinition{}
+		if err := h.Client.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			if k8serr.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		for _, condition := range crd.Status.Conditions {
+			if condition.Type == apiextv1.Established {
+				return condition.Status == apiextv1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for CRD %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package harness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	infrav1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/infrastructure/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+)
+
+// NewDSCI returns a DSCInitialization deploying the components to the namespace, with monitoring, the trusted CA
+// bundle and Service Mesh managed.
+func NewDSCI(name, applicationsNamespace string) *dsciv1.DSCInitialization {
+	dsci := &dsciv1.DSCInitialization{}
+	dsci.Name = name
+	dsci.Spec = dsciv1.DSCInitializationSpec{
+		ApplicationsNamespace: applicationsNamespace,
+		Monitoring: dsciv1.Monitoring{
+			ManagementState: operatorv1.Managed,
+			Namespace:       applicationsNamespace,
+		},
+		TrustedCABundle: &dsciv1.TrustedCABundleSpec{
+			ManagementState: operatorv1.Managed,
+		},
+		ServiceMesh: &infrav1.ServiceMeshSpec{
+			ControlPlane: infrav1.ControlPlaneSpec{
+				MetricsCollection: "Istio",
+				Name:              "data-science-smcp",
+				Namespace:         "istio-system",
+			},
+			ManagementState: operatorv1.Managed,
+		},
+	}
+
+	return dsci
+}
+
+// NewDSC returns a DataScienceCluster with the named components managed and the others removed. Components are named
+// as in the DataScienceCluster spec, e.g. "kserve" or "datasciencepipelines".
+func NewDSC(name string, managed ...string) (*dscv1.DataScienceCluster, error) {
+	dsc := &dscv1.DataScienceCluster{}
+	dsc.Name = name
+	for _, component := range ComponentNames() {
+		if err := setManagementState(&dsc.Spec.Components, component, operatorv1.Removed); err != nil {
+			return nil, err
+		}
+	}
+	for _, component := range managed {
+		if err := setManagementState(&dsc.Spec.Components, component, operatorv1.Managed); err != nil {
+			return nil, err
+		}
+	}
+
+	return dsc, nil
+}
+
+// ComponentNames lists the components as named in the DataScienceCluster spec.
+func ComponentNames() []string {
+	fields := map[string]json.RawMessage{}
+	data, _ := json.Marshal(dscv1.Components{})
+	_ = json.Unmarshal(data, &fields)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func setManagementState(components *dscv1.Components, component string, state operatorv1.ManagementState) error {
+	fields := map[string]map[string]any{}
+	data, err := json.Marshal(components)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if _, found := fields[component]; !found {
+		return fmt.Errorf("unknown component %q", component)
+	}
+	fields[component]["managementState"] = state
+
+	if data, err = json.Marshal(fields); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, components)
+}
+
+// EnsureDSCI uses the DSCInitialization of the cluster, or creates the one given when there is none. The DSCInitialization
+// being a singleton, the one in use is returned.
+func (h *Harness) EnsureDSCI(ctx context.Context, dsci *dsciv1.DSCInitialization) (*dsciv1.DSCInitialization, error) {
+	existing := &dsciv1.DSCInitializationList{}
+	if err := h.Client.List(ctx, existing); err != nil {
+		return nil, fmt.Errorf("error listing DSCInitialization: %w", err)
+	}
+	if len(existing.Items) > 0 {
+		dsci = &existing.Items[0]
+	} else if err := h.create(ctx, dsci); err != nil {
+		return nil, err
+	}
+	h.ApplicationsNamespace = dsci.Spec.ApplicationsNamespace
+
+	return dsci, nil
+}
+
+// EnsureDSC uses the DataScienceCluster of the cluster, or creates the one given when there is none. The
+// DataScienceCluster being a singleton, the one in use is returned.
+func (h *Harness) EnsureDSC(ctx context.Context, dsc *dscv1.DataScienceCluster) (*dscv1.DataScienceCluster, error) {
+	existing := &dscv1.DataScienceClusterList{}
+	if err := h.Client.List(ctx, existing); err != nil {
+		return nil, fmt.Errorf("error listing DataScienceCluster: %w", err)
+	}
+	if len(existing.Items) > 0 {
+		return &existing.Items[0], nil
+	}
+	if err := h.create(ctx, dsc); err != nil {
+		return nil, err
+	}
+
+	return dsc, nil
+}
+
+// create retries the creation, which fails until the webhooks of the operator are ready.
+func (h *Harness) create(ctx context.Context, obj client.Object) error {
+	err := h.poll(ctx, h.Timeouts.Creation, func(ctx context.Context) (bool, error) {
+		if err := h.Client.Create(ctx, obj); err != nil {
+			log.Printf("error creating %s: %v, trying again", obj.GetName(), err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// SetComponentState sets the management state of the component of the DataScienceCluster, the component being named
+// as in its spec, e.g. "kserve".
+func (h *Harness) SetComponentState(ctx context.Context, dscName, component string, state operatorv1.ManagementState) error {
+	if !slices.Contains(ComponentNames(), component) {
+		return fmt.Errorf("unknown component %q", component)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"components": map[string]any{
+				component: map[string]any{"managementState": state},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	dsc := &dscv1.DataScienceCluster{}
+	dsc.Name = dscName
+	if err := h.Client.Patch(ctx, dsc, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("error setting %s to %s: %w", component, state, err)
+	}
+
+	return nil
+}
+
+// WaitForDSCReady waits for the DataScienceCluster to reach the Ready phase, once all its components are ready.
+func (h *Harness) WaitForDSCReady(ctx context.Context, dscName string) error {
+	err := h.poll(ctx, h.Timeouts.DSCReady, func(ctx context.Context) (bool, error) {
+		dsc := &dscv1.DataScienceCluster{}
+		if err := h.Client.Get(ctx, types.NamespacedName{Name: dscName}, dsc); err != nil {
+			return false, err
+		}
+		return dsc.Status.Phase == status.PhaseReady, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting Ready state for DSC %v: %w", dscName, err)
+	}
+
+	return nil
+}
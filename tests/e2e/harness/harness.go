@@ -0,0 +1,95 @@
+// Package harness exposes the steps of the operator e2e tests, for downstream distributions and component teams to
+// run conformance suites against their builds: creating the DSCInitialization and DataScienceCluster, toggling
+// components, and asserting that components and capabilities become ready.
+//
+// A suite typically looks like:
+//
+//	h, err := harness.New(cfg, scheme)
+//	dsci, err := h.EnsureDSCI(ctx, harness.NewDSCI("default-dsci", "opendatahub"))
+//	dsc, err := harness.NewDSC("default-dsc", "dashboard", "kserve")
+//	dsc, err = h.EnsureDSC(ctx, dsc)
+//	err = h.WaitForDSCReady(ctx, dsc.Name)
+//	err = h.WaitForCapability(ctx, status.CapabilityServiceMeshAuthorization)
+//	err = h.SetComponentState(ctx, dsc.Name, "kserve", operatorv1.Removed)
+package harness
+
+import (
+	"context"
+	"time"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+)
+
+var schemeBuilder = runtime.NewSchemeBuilder(
+	clientgoscheme.AddToScheme,
+	apiextv1.AddToScheme,
+	dsciv1.AddToScheme,
+	dscv1.AddToScheme,
+)
+
+// AddToScheme registers the types the harness reads and writes.
+var AddToScheme = schemeBuilder.AddToScheme
+
+// Timeouts bounds how long the harness waits for each step.
+type Timeouts struct {
+	// Interval between two checks
+	Interval time.Duration
+	// Creation of the DSCInitialization and DataScienceCluster, retried while the webhooks are not ready
+	Creation time.Duration
+	// DSCReady is the time for the DataScienceCluster to reach the Ready phase
+	DSCReady time.Duration
+	// ComponentReady is the time for the deployments of a component to be ready, or removed
+	ComponentReady time.Duration
+	// Capability is the time for a capability to be reported as available
+	Capability time.Duration
+	// CRD is the time for a CustomResourceDefinition to be established
+	CRD time.Duration
+}
+
+// DefaultTimeouts are the timeouts used by the operator e2e tests.
+var DefaultTimeouts = Timeouts{
+	Interval:       10 * time.Second,
+	Creation:       20 * time.Second,
+	DSCReady:       2 * time.Minute,
+	ComponentReady: 7 * time.Minute,
+	Capability:     2 * time.Minute,
+	CRD:            1 * time.Minute,
+}
+
+// Harness runs the e2e steps against a cluster the operator is deployed to.
+type Harness struct {
+	Client client.Client
+	// ApplicationsNamespace the components are deployed to, set by EnsureDSCI from the DSCInitialization in use
+	ApplicationsNamespace string
+	Timeouts              Timeouts
+}
+
+// New returns a Harness for the cluster of the config, the scheme having the types of AddToScheme registered.
+func New(config *rest.Config, scheme *runtime.Scheme) (*Harness, error) {
+	cli, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewForClient(cli), nil
+}
+
+// NewForClient returns a Harness using the client.
+func NewForClient(cli client.Client) *Harness {
+	return &Harness{
+		Client:   cli,
+		Timeouts: DefaultTimeouts,
+	}
+}
+
+func (h *Harness) poll(ctx context.Context, timeout time.Duration, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextTimeout(ctx, h.Timeouts.Interval, timeout, true, condition)
+}
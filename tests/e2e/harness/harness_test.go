@@ -0,0 +1,343 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/datasciencecluster/v1"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/apis/dscinitialization/v1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components"
+	"github.com/opendatahub-io/opendatahub-operator/v2/components/dashboard"
+	"github.com/opendatahub-io/opendatahub-operator/v2/controllers/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func deployment(namespace, name string, readyReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{labels.ODH.Component(dashboard.ComponentNameUpstream): "true"},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+	}
+}
+
+func crd(name string, established apiextv1.ConditionStatus) *apiextv1.CustomResourceDefinition {
+	return &apiextv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: apiextv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextv1.CustomResourceDefinitionCondition{{Type: apiextv1.Established, Status: established}},
+		},
+	}
+}
+
+func dashboardIn(state operatorv1.ManagementState) components.ComponentInterface {
+	return &dashboard.Dashboard{Component: components.Component{ManagementState: state}}
+}
+
+var _ = Describe("NewDSC", func() {
+	It("should manage the named components and remove the others", func() {
+		dsc, err := NewDSC("default-dsc", "kserve", "datasciencepipelines")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dsc.Name).To(Equal("default-dsc"))
+		Expect(dsc.Spec.Components.Kserve.ManagementState).To(Equal(operatorv1.Managed))
+		Expect(dsc.Spec.Components.DataSciencePipelines.ManagementState).To(Equal(operatorv1.Managed))
+		Expect(dsc.Spec.Components.Dashboard.ManagementState).To(Equal(operatorv1.Removed))
+		Expect(dsc.Spec.Components.Workbenches.ManagementState).To(Equal(operatorv1.Removed))
+	})
+
+	It("should remove all the components when none is named", func() {
+		dsc, err := NewDSC("default-dsc")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dsc.Spec.Components.Kserve.ManagementState).To(Equal(operatorv1.Removed))
+		Expect(dsc.Spec.Components.Dashboard.ManagementState).To(Equal(operatorv1.Removed))
+	})
+
+	It("should refuse an unknown component", func() {
+		_, err := NewDSC("default-dsc", "kserve", "unknown")
+		Expect(err).To(MatchError(ContainSubstring(`unknown component "unknown"`)))
+	})
+
+	It("should name the components as in the DataScienceCluster spec, sorted", func() {
+		names := ComponentNames()
+		Expect(names).To(ContainElements("dashboard", "datasciencepipelines", "kserve", "workbenches"))
+		Expect(slices.IsSorted(names)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Harness", func() {
+	var (
+		objects []client.Object
+		funcs   interceptor.Funcs
+		h       *Harness
+	)
+
+	BeforeEach(func() {
+		objects = nil
+		funcs = interceptor.Funcs{}
+		h = nil
+	})
+
+	harness := func() *Harness {
+		if h == nil {
+			scheme := runtime.NewScheme()
+			Expect(AddToScheme(scheme)).To(Succeed())
+			cli := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&dscv1.DataScienceCluster{}, &dsciv1.DSCInitialization{}).
+				WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+			h = NewForClient(cli)
+			h.Timeouts = Timeouts{
+				Interval:       10 * time.Millisecond,
+				Creation:       100 * time.Millisecond,
+				DSCReady:       100 * time.Millisecond,
+				ComponentReady: 100 * time.Millisecond,
+				Capability:     100 * time.Millisecond,
+				CRD:            100 * time.Millisecond,
+			}
+		}
+		return h
+	}
+	newDSC := func(name string, managed ...string) *dscv1.DataScienceCluster {
+		GinkgoHelper()
+		dsc, err := NewDSC(name, managed...)
+		Expect(err).ToNot(HaveOccurred())
+		return dsc
+	}
+
+	Describe("EnsureDSCI", func() {
+		It("should create the DSCInitialization when there is none", func(ctx context.Context) {
+			dsci, err := harness().EnsureDSCI(ctx, NewDSCI("default-dsci", "opendatahub"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsci.Name).To(Equal("default-dsci"))
+			Expect(h.ApplicationsNamespace).To(Equal("opendatahub"))
+			Expect(h.Client.Get(ctx, client.ObjectKey{Name: "default-dsci"}, &dsciv1.DSCInitialization{})).To(Succeed())
+		})
+
+		It("should use the existing DSCInitialization and its applications namespace", func(ctx context.Context) {
+			objects = append(objects, NewDSCI("existing-dsci", "redhat-ods-applications"))
+
+			dsci, err := harness().EnsureDSCI(ctx, NewDSCI("default-dsci", "opendatahub"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsci.Name).To(Equal("existing-dsci"))
+			Expect(h.ApplicationsNamespace).To(Equal("redhat-ods-applications"))
+			Expect(h.Client.Get(ctx, client.ObjectKey{Name: "default-dsci"}, &dsciv1.DSCInitialization{})).ToNot(Succeed())
+		})
+
+		It("should retry the creation until it succeeds", func(ctx context.Context) {
+			attempts := 0
+			funcs.Create = func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if attempts++; attempts < 3 {
+					return errors.New("webhook not ready")
+				}
+				return cli.Create(ctx, obj, opts...)
+			}
+
+			_, err := harness().EnsureDSCI(ctx, NewDSCI("default-dsci", "opendatahub"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("should fail once the creation timeout is reached", func(ctx context.Context) {
+			funcs.Create = func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+				return errors.New("webhook not ready")
+			}
+
+			_, err := harness().EnsureDSCI(ctx, NewDSCI("default-dsci", "opendatahub"))
+			Expect(err).To(MatchError(ContainSubstring("error creating default-dsci")))
+			Expect(h.ApplicationsNamespace).To(BeEmpty())
+		})
+	})
+
+	Describe("EnsureDSC", func() {
+		It("should create the DataScienceCluster when there is none", func(ctx context.Context) {
+			dsc, err := harness().EnsureDSC(ctx, newDSC("default-dsc", "dashboard"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsc.Name).To(Equal("default-dsc"))
+
+			created := &dscv1.DataScienceCluster{}
+			Expect(h.Client.Get(ctx, client.ObjectKey{Name: "default-dsc"}, created)).To(Succeed())
+			Expect(created.Spec.Components.Dashboard.ManagementState).To(Equal(operatorv1.Managed))
+		})
+
+		It("should use the existing DataScienceCluster", func(ctx context.Context) {
+			objects = append(objects, newDSC("existing-dsc"))
+
+			dsc, err := harness().EnsureDSC(ctx, newDSC("default-dsc", "dashboard"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsc.Name).To(Equal("existing-dsc"))
+			Expect(dsc.Spec.Components.Dashboard.ManagementState).To(Equal(operatorv1.Removed))
+		})
+
+		It("should fail when the DataScienceClusters cannot be listed", func(ctx context.Context) {
+			funcs.List = func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				return errors.New("connection refused")
+			}
+
+			_, err := harness().EnsureDSC(ctx, newDSC("default-dsc"))
+			Expect(err).To(MatchError(ContainSubstring("error listing DataScienceCluster")))
+		})
+	})
+
+	Describe("SetComponentState", func() {
+		BeforeEach(func() {
+			objects = append(objects, newDSC("default-dsc", "dashboard", "kserve"))
+		})
+
+		It("should set the management state of the component only", func(ctx context.Context) {
+			Expect(harness().SetComponentState(ctx, "default-dsc", "dashboard", operatorv1.Removed)).To(Succeed())
+
+			dsc := &dscv1.DataScienceCluster{}
+			Expect(h.Client.Get(ctx, client.ObjectKey{Name: "default-dsc"}, dsc)).To(Succeed())
+			Expect(dsc.Spec.Components.Dashboard.ManagementState).To(Equal(operatorv1.Removed))
+			Expect(dsc.Spec.Components.Kserve.ManagementState).To(Equal(operatorv1.Managed))
+		})
+
+		It("should refuse an unknown component", func(ctx context.Context) {
+			Expect(harness().SetComponentState(ctx, "default-dsc", "unknown", operatorv1.Removed)).
+				To(MatchError(ContainSubstring(`unknown component "unknown"`)))
+		})
+
+		It("should fail when the DataScienceCluster does not exist", func(ctx context.Context) {
+			Expect(harness().SetComponentState(ctx, "missing-dsc", "dashboard", operatorv1.Removed)).
+				To(MatchError(ContainSubstring("error setting dashboard to Removed")))
+		})
+	})
+
+	Describe("WaitForDSCReady", func() {
+		It("should succeed once the DataScienceCluster is Ready", func(ctx context.Context) {
+			dsc := newDSC("default-dsc")
+			dsc.Status.Phase = status.PhaseReady
+			objects = append(objects, dsc)
+
+			Expect(harness().WaitForDSCReady(ctx, "default-dsc")).To(Succeed())
+		})
+
+		It("should time out while the DataScienceCluster is not Ready", func(ctx context.Context) {
+			dsc := newDSC("default-dsc")
+			dsc.Status.Phase = status.PhaseProgressing
+			objects = append(objects, dsc)
+
+			Expect(harness().WaitForDSCReady(ctx, "default-dsc")).
+				To(MatchError(ContainSubstring("error waiting Ready state for DSC default-dsc")))
+		})
+
+		It("should fail when the DataScienceCluster does not exist", func(ctx context.Context) {
+			Expect(harness().WaitForDSCReady(ctx, "missing-dsc")).To(HaveOccurred())
+		})
+	})
+
+	Describe("WaitForCapability", func() {
+		It("should time out while the capability is not reported", func(ctx context.Context) {
+			objects = append(objects, NewDSCI("default-dsci", "opendatahub"), newDSC("default-dsc"))
+
+			Expect(harness().WaitForCapability(ctx, status.CapabilityDSPv2Argo)).
+				To(MatchError(ContainSubstring("error waiting for capability")))
+		})
+
+		It("should succeed once the DataScienceCluster reports the capability", func(ctx context.Context) {
+			dsc := newDSC("default-dsc")
+			status.SetCondition(&dsc.Status.Conditions, string(status.CapabilityDSPv2Argo), status.ReconcileCompleted, "", corev1.ConditionTrue)
+			objects = append(objects, dsc)
+
+			Expect(harness().WaitForCapability(ctx, status.CapabilityDSPv2Argo)).To(Succeed())
+		})
+
+		It("should succeed once the DSCInitialization reports the capability", func(ctx context.Context) {
+			dsci := NewDSCI("default-dsci", "opendatahub")
+			status.SetCondition(&dsci.Status.Conditions, string(status.CapabilityServiceMeshAuthorization), status.ReconcileCompleted, "", corev1.ConditionTrue)
+			objects = append(objects, dsci)
+
+			Expect(harness().WaitForCapability(ctx, status.CapabilityServiceMeshAuthorization)).To(Succeed())
+		})
+
+		It("should not take an unavailable capability as reported", func(ctx context.Context) {
+			dsc := newDSC("default-dsc")
+			status.SetCondition(&dsc.Status.Conditions, string(status.CapabilityDSPv2Argo), status.MissingOperatorReason, "", corev1.ConditionFalse)
+			objects = append(objects, dsc)
+
+			Expect(harness().WaitForCapability(ctx, status.CapabilityDSPv2Argo)).To(HaveOccurred())
+		})
+
+		It("should be reported once the condition is updated", func(ctx context.Context) {
+			dsc := newDSC("default-dsc")
+			objects = append(objects, dsc)
+			Expect(harness().WaitForCapability(ctx, status.CapabilityDSPv2Argo)).To(HaveOccurred())
+
+			Expect(h.Client.Get(ctx, client.ObjectKeyFromObject(dsc), dsc)).To(Succeed())
+			status.SetCondition(&dsc.Status.Conditions, string(status.CapabilityDSPv2Argo), status.ReconcileCompleted, "", corev1.ConditionTrue)
+			Expect(h.Client.Status().Update(ctx, dsc)).To(Succeed())
+			Expect(h.WaitForCapability(ctx, status.CapabilityDSPv2Argo)).To(Succeed())
+		})
+	})
+
+	Describe("WaitForComponent", func() {
+		DescribeTable("should wait for the deployments to match the management state",
+			func(ctx context.Context, state operatorv1.ManagementState, deployments []client.Object, ready bool) {
+				objects = append(objects, deployments...)
+				harness().ApplicationsNamespace = "opendatahub"
+
+				err := h.WaitForComponent(ctx, dashboardIn(state))
+				if ready {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring("error waiting for component dashboard to be " + string(state))))
+				}
+			},
+			Entry("managed with all deployments ready", operatorv1.Managed,
+				[]client.Object{deployment("opendatahub", "odh-dashboard", 1), deployment("opendatahub", "odh-dashboard-proxy", 2)}, true),
+			Entry("managed with a deployment not ready", operatorv1.Managed,
+				[]client.Object{deployment("opendatahub", "odh-dashboard", 1), deployment("opendatahub", "odh-dashboard-proxy", 0)}, false),
+			Entry("managed without deployments", operatorv1.Managed, nil, false),
+			Entry("managed with deployments in another namespace", operatorv1.Managed,
+				[]client.Object{deployment("other", "odh-dashboard", 1)}, false),
+			Entry("removed without deployments", operatorv1.Removed, nil, true),
+			Entry("removed with deployments left", operatorv1.Removed,
+				[]client.Object{deployment("opendatahub", "odh-dashboard", 1)}, false),
+			Entry("unmanaged without deployments", operatorv1.Unmanaged, nil, true),
+		)
+	})
+
+	Describe("WaitForCRD", func() {
+		It("should succeed once the CRD is established", func(ctx context.Context) {
+			objects = append(objects, crd("notebooks.kubeflow.org", apiextv1.ConditionTrue))
+
+			Expect(harness().WaitForCRD(ctx, "notebooks.kubeflow.org")).To(Succeed())
+		})
+
+		DescribeTable("should time out while the CRD is not established",
+			func(ctx context.Context, crds ...client.Object) {
+				objects = append(objects, crds...)
+
+				Expect(harness().WaitForCRD(ctx, "notebooks.kubeflow.org")).
+					To(MatchError(ContainSubstring("error waiting for CRD notebooks.kubeflow.org")))
+			},
+			Entry("missing CRD"),
+			Entry("CRD not established", crd("notebooks.kubeflow.org", apiextv1.ConditionFalse)),
+			Entry("CRD without conditions", &apiextv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "notebooks.kubeflow.org"}}),
+		)
+
+		It("should fail right away when the CRD cannot be read", func(ctx context.Context) {
+			funcs.Get = func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+				return errors.New("forbidden")
+			}
+
+			Expect(harness().WaitForCRD(ctx, "notebooks.kubeflow.org")).To(MatchError(ContainSubstring("forbidden")))
+		})
+	})
+})
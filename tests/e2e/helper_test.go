@@ -13,7 +13,6 @@ import (
 	ofapi "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,6 +35,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/trainingoperator"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/trustyai"
 	"github.com/opendatahub-io/opendatahub-operator/v2/components/workbenches"
+	"github.com/opendatahub-io/opendatahub-operator/v2/tests/e2e/harness"
 )
 
 const (
@@ -45,12 +45,8 @@ const (
 	ownedNamespaceNumber     = 1 // set to 4 for RHOAI
 	deleteConfigMap          = "delete-configmap-name"
 	operatorReadyTimeout     = 2 * time.Minute
-	componentReadyTimeout    = 7 * time.Minute // in component code is to set 2-3 mins, keep it 7 mins just the same value we used after introduce "Ready" check
 	componentDeletionTimeout = 1 * time.Minute
-	crdReadyTimeout          = 1 * time.Minute
 	csvWaitTimeout           = 1 * time.Minute
-	dsciCreationTimeout      = 20 * time.Second // time required to get a DSCI is created.
-	dscCreationTimeout       = 20 * time.Second // time required to wait till DSC is created.
 	generalRetryInterval     = 10 * time.Second
 	generalWaitTimeout       = 2 * time.Minute
 )
@@ -82,31 +78,7 @@ func (tc *testContext) waitForOperatorDeployment(name string, replicas int32) er
 }
 
 func setupDSCICR(name string) *dsciv1.DSCInitialization {
-	dsciTest := &dsciv1.DSCInitialization{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-		},
-		Spec: dsciv1.DSCInitializationSpec{
-			ApplicationsNamespace: "opendatahub",
-			Monitoring: dsciv1.Monitoring{
-				ManagementState: "Managed",
-				Namespace:       "opendatahub",
-			},
-			TrustedCABundle: &dsciv1.TrustedCABundleSpec{
-				ManagementState: "Managed",
-				CustomCABundle:  "",
-			},
-			ServiceMesh: &infrav1.ServiceMeshSpec{
-				ControlPlane: infrav1.ControlPlaneSpec{
-					MetricsCollection: "Istio",
-					Name:              "data-science-smcp",
-					Namespace:         "istio-system",
-				},
-				ManagementState: "Managed",
-			},
-		},
-	}
-	return dsciTest
+	return harness.NewDSCI(name, "opendatahub")
 }
 
 func setupDSCInstance(name string) *dscv1.DataScienceCluster {
@@ -199,35 +171,7 @@ func setupSubscription(name string, ns string) *ofapi.Subscription {
 }
 
 func (tc *testContext) validateCRD(crdName string) error {
-	crd := &apiextv1.CustomResourceDefinition{}
-	obj := client.ObjectKey{
-		Name: crdName,
-	}
-
-	err := wait.PollUntilContextTimeout(tc.ctx, generalRetryInterval, crdReadyTimeout, false, func(ctx context.Context) (bool, error) {
-		err := tc.customClient.Get(ctx, obj, crd)
-		if err != nil {
-			if k8serr.IsNotFound(err) {
-				return false, nil
-			}
-			log.Printf("Failed to get CRD %s", crdName)
-
-			return false, err
-		}
-
-		for _, condition := range crd.Status.Conditions {
-			if condition.Type == apiextv1.Established {
-				if condition.Status == apiextv1.ConditionTrue {
-					return true, nil
-				}
-			}
-		}
-		log.Printf("Error to get CRD %s condition's matching", crdName)
-
-		return false, nil
-	})
-
-	return err
+	return tc.harness.WaitForCRD(tc.ctx, crdName)
 }
 
 func (tc *testContext) wait(isReady func(ctx context.Context) (bool, error)) error {